@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -41,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -179,6 +181,20 @@ func getRelevantDescChanges(
 				if _, ok := interestingParents[table.ParentID]; ok {
 					interestingIDs[table.ID] = struct{}{}
 				}
+			} else if sc := i.GetSchema(); sc != nil {
+				// Likewise for the user-defined schemas of the DBs.
+				if _, ok := interestingParents[sc.ParentID]; ok {
+					interestingIDs[sc.ID] = struct{}{}
+				}
+			} else if fn := i.GetFunction(); fn != nil {
+				// And for their user-defined functions and types.
+				if _, ok := interestingParents[fn.ParentID]; ok {
+					interestingIDs[fn.ID] = struct{}{}
+				}
+			} else if typ := i.GetType(); typ != nil {
+				if _, ok := interestingParents[typ.ParentID]; ok {
+					interestingIDs[typ.ID] = struct{}{}
+				}
 			}
 			if _, ok := interestingIDs[i.GetID()]; ok {
 				desc := i
@@ -208,6 +224,21 @@ func getRelevantDescChanges(
 					interestingIDs[table.ID] = struct{}{}
 					interestingChanges = append(interestingChanges, change)
 				}
+			} else if sc := change.Desc.GetSchema(); sc != nil {
+				if _, ok := interestingParents[sc.ParentID]; ok {
+					interestingIDs[sc.ID] = struct{}{}
+					interestingChanges = append(interestingChanges, change)
+				}
+			} else if fn := change.Desc.GetFunction(); fn != nil {
+				if _, ok := interestingParents[fn.ParentID]; ok {
+					interestingIDs[fn.ID] = struct{}{}
+					interestingChanges = append(interestingChanges, change)
+				}
+			} else if typ := change.Desc.GetType(); typ != nil {
+				if _, ok := interestingParents[typ.ParentID]; ok {
+					interestingIDs[typ.ID] = struct{}{}
+					interestingChanges = append(interestingChanges, change)
+				}
 			}
 		}
 	}
@@ -1060,7 +1091,18 @@ func backupPlanHook(
 			return err
 		}
 
-		_, errCh, err := p.ExecCfg().JobRegistry.StartJob(ctx, resultsCh, jobs.Record{
+		// Protect the data being backed up from garbage collection until the
+		// job finishes, so that the backup does not fail if it runs (or is
+		// paused) for longer than the GC TTL. The record is created along with
+		// the job, and verified to protect data which has not yet been
+		// collected.
+		var protectedtsID *uuid.UUID
+		if p.ExecCfg().Settings.Version.IsActive(cluster.VersionProtectedTimestamps) {
+			id := uuid.MakeV4()
+			protectedtsID = &id
+		}
+
+		_, errCh, err := p.ExecCfg().JobRegistry.StartJobWithTxnFn(ctx, resultsCh, jobs.Record{
 			Description: description,
 			Username:    p.User(),
 			DescriptorIDs: func() (sqlDescIDs []sqlbase.ID) {
@@ -1070,12 +1112,19 @@ func backupPlanHook(
 				return sqlDescIDs
 			}(),
 			Details: jobspb.BackupDetails{
-				StartTime:        startTime,
-				EndTime:          endTime,
-				URI:              to,
-				BackupDescriptor: descBytes,
+				StartTime:                startTime,
+				EndTime:                  endTime,
+				URI:                      to,
+				BackupDescriptor:         descBytes,
+				ProtectedTimestampRecord: protectedtsID,
 			},
 			Progress: jobspb.BackupProgress{},
+		}, func(ctx context.Context, txn *client.Txn, jobID int64) error {
+			if protectedtsID == nil {
+				return nil
+			}
+			return protectTimestampForBackup(
+				ctx, p, txn, jobID, *protectedtsID, startTime, endTime, spans)
 		})
 		if err != nil {
 			return err
@@ -1142,8 +1191,47 @@ func (b *backupResumer) Resume(
 	return err
 }
 
-func (b *backupResumer) OnFailOrCancel(context.Context, *client.Txn, *jobs.Job) error { return nil }
-func (b *backupResumer) OnSuccess(context.Context, *client.Txn, *jobs.Job) error      { return nil }
+// protectTimestampForBackup creates the protected timestamp record with the
+// given ID for the spans of the backup job in txn.
+func protectTimestampForBackup(
+	ctx context.Context,
+	p sql.PlanHookState,
+	txn *client.Txn,
+	jobID int64,
+	id uuid.UUID,
+	startTime, endTime hlc.Timestamp,
+	spans []roachpb.Span,
+) error {
+	// An incremental backup reads the revisions since its start time, while a
+	// full backup only needs the data as of its end time.
+	tsToProtect := endTime
+	if startTime != (hlc.Timestamp{}) {
+		tsToProtect = startTime
+	}
+	rec := jobsprotectedts.MakeRecord(id, jobID, tsToProtect, spans)
+	return p.ExecCfg().ProtectedTimestampProvider.Protect(ctx, txn, rec)
+}
+
+// releaseProtectedTimestamp releases the protected timestamp record of the
+// backup, if any.
+func releaseProtectedTimestamp(ctx context.Context, txn *client.Txn, job *jobs.Job) error {
+	details := job.Details().(jobspb.BackupDetails)
+	if details.ProtectedTimestampRecord == nil {
+		return nil
+	}
+	phs, cleanup := job.MakePlanHookState("backup-release-protected-timestamp")
+	defer cleanup()
+	pts := phs.(sql.PlanHookState).ExecCfg().ProtectedTimestampProvider
+	return jobsprotectedts.Release(ctx, txn, pts, details.ProtectedTimestampRecord)
+}
+
+func (b *backupResumer) OnFailOrCancel(ctx context.Context, txn *client.Txn, job *jobs.Job) error {
+	return releaseProtectedTimestamp(ctx, txn, job)
+}
+
+func (b *backupResumer) OnSuccess(ctx context.Context, txn *client.Txn, job *jobs.Job) error {
+	return releaseProtectedTimestamp(ctx, txn, job)
+}
 
 func (b *backupResumer) OnTerminal(
 	ctx context.Context, job *jobs.Job, status jobs.Status, resultsCh chan<- tree.Datums,
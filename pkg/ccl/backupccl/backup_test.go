@@ -611,6 +611,32 @@ func TestBackupRestoreSystemJobsProgress(t *testing.T) {
 	checkInProgressBackupRestore(t, checkFraction, checkFraction)
 }
 
+// TestBackupProtectedTimestamp verifies that a running backup protects the
+// data it reads from garbage collection, and releases the protection once it
+// is done.
+func TestBackupProtectedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	checkRecords := func(expected int) inProgressChecker {
+		return func(_ context.Context, ip inProgressState) error {
+			var n int
+			if err := ip.QueryRow(
+				`SELECT count(*) FROM system.protected_ts_records`,
+			).Scan(&n); err != nil {
+				return err
+			}
+			if n != expected {
+				return errors.Errorf("expected %d protected timestamp records, got %d", expected, n)
+			}
+			return nil
+		}
+	}
+
+	// The restore runs after the backup has finished, by which point the
+	// record of the backup has been released.
+	checkInProgressBackupRestore(t, checkRecords(1), checkRecords(0))
+}
+
 func TestBackupRestoreCheckpointing(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -2773,6 +2799,195 @@ func TestBackupRestoreSequence(t *testing.T) {
 	})
 }
 
+func TestBackupRestoreUserDefinedSchemas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	const numAccounts = 1
+	_, _, origDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+	args := base.TestServerArgs{ExternalIODir: dir}
+
+	origDB.Exec(t, `USE data`)
+	origDB.Exec(t, `CREATE SCHEMA sc`)
+	origDB.Exec(t, `CREATE TABLE sc.t (id SERIAL PRIMARY KEY, v STRING)`)
+	origDB.Exec(t, `INSERT INTO sc.t (v) VALUES ('foo'), ('bar')`)
+	origDB.Exec(t, `CREATE VIEW sc.v AS SELECT v FROM sc.t`)
+
+	origDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+	expected := origDB.QueryStr(t, `SELECT * FROM data.sc.t ORDER BY v`)
+
+	t.Run("database", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `RESTORE DATABASE data FROM $1`, localFoo)
+		newDB.CheckQueryResults(t, `SELECT * FROM data.sc.t ORDER BY v`, expected)
+		newDB.CheckQueryResults(t, `SELECT * FROM data.sc.v ORDER BY v`, [][]string{{"bar"}, {"foo"}})
+		newDB.CheckQueryResults(t,
+			`SELECT schema_name FROM data.information_schema.schemata WHERE schema_name = 'sc'`,
+			[][]string{{"sc"}},
+		)
+		newDB.Exec(t, `INSERT INTO data.sc.t (v) VALUES ('baz')`)
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `CREATE DATABASE data`)
+		newDB.ExpectErr(t, `a schema named "sc" needs to exist`, `RESTORE data.sc.* FROM $1`, localFoo)
+
+		newDB.Exec(t, `USE data`)
+		newDB.Exec(t, `CREATE SCHEMA sc`)
+		newDB.Exec(t, `RESTORE data.sc.* FROM $1`, localFoo)
+		newDB.CheckQueryResults(t, `SELECT * FROM data.sc.t ORDER BY v`, expected)
+	})
+}
+
+func TestBackupRestoreUserDefinedFunctions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	const numAccounts = 1
+	_, _, origDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+	args := base.TestServerArgs{ExternalIODir: dir}
+
+	origDB.Exec(t, `USE data`)
+	origDB.Exec(t, `CREATE FUNCTION add_one(x INT) RETURNS INT LANGUAGE SQL AS 'SELECT x + 1'`)
+	origDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY)`)
+	origDB.Exec(t, `INSERT INTO t VALUES (1), (2)`)
+
+	origDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+
+	t.Run("database", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `RESTORE DATABASE data FROM $1`, localFoo)
+		newDB.Exec(t, `USE data`)
+		newDB.CheckQueryResults(t, `SELECT add_one(a) FROM t ORDER BY a`, [][]string{{"2"}, {"3"}})
+		newDB.CheckQueryResults(t,
+			`SELECT proname FROM data.pg_catalog.pg_proc WHERE proname = 'add_one'`,
+			[][]string{{"add_one"}},
+		)
+		newDB.Exec(t, `DROP FUNCTION add_one`)
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `CREATE DATABASE data`)
+		newDB.Exec(t, `USE data`)
+		newDB.Exec(t, `CREATE FUNCTION add_one(x INT) RETURNS INT LANGUAGE SQL AS 'SELECT x + 10'`)
+		newDB.ExpectErr(t, `cannot restore function "add_one": relation "add_one" already exists`,
+			`RESTORE data.* FROM $1`, localFoo)
+
+		// Restoring a single table does not restore the functions.
+		newDB.Exec(t, `RESTORE data.t FROM $1`, localFoo)
+		newDB.CheckQueryResults(t, `SELECT add_one(a) FROM t ORDER BY a`, [][]string{{"11"}, {"12"}})
+
+		newDB.Exec(t, `DROP TABLE t`)
+		newDB.Exec(t, `DROP FUNCTION add_one`)
+		newDB.Exec(t, `RESTORE data.* FROM $1`, localFoo)
+		newDB.CheckQueryResults(t, `SELECT add_one(a) FROM t ORDER BY a`, [][]string{{"2"}, {"3"}})
+	})
+}
+
+func TestBackupRestoreTriggers(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	const numAccounts = 1
+	_, _, origDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+	args := base.TestServerArgs{ExternalIODir: dir}
+
+	origDB.Exec(t, `USE data`)
+	origDB.Exec(t, `CREATE FUNCTION upper_b() RETURNS TRIGGER LANGUAGE SQL AS 'SELECT new.a, upper(new.b) FROM new'`)
+	origDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, b STRING)`)
+	origDB.Exec(t, `CREATE TRIGGER t_upper BEFORE INSERT ON t FOR EACH ROW EXECUTE FUNCTION upper_b()`)
+	origDB.Exec(t, `INSERT INTO t VALUES (1, 'one')`)
+
+	// The function of the trigger is backed up along with the table.
+	origDB.Exec(t, `BACKUP data.t TO $1`, localFoo)
+
+	tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+	defer tc.Stopper().Stop(context.TODO())
+	newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+	newDB.Exec(t, `CREATE DATABASE data`)
+	newDB.Exec(t, `USE data`)
+	newDB.Exec(t, `CREATE FUNCTION upper_b() RETURNS TRIGGER LANGUAGE SQL AS 'SELECT new.a, new.b FROM new'`)
+	newDB.ExpectErr(t, `cannot restore function "upper_b": relation "upper_b" already exists`,
+		`RESTORE data.t FROM $1`, localFoo)
+
+	newDB.Exec(t, `DROP FUNCTION upper_b`)
+	newDB.Exec(t, `RESTORE data.t FROM $1`, localFoo)
+	newDB.Exec(t, `INSERT INTO t VALUES (2, 'two')`)
+	newDB.CheckQueryResults(t, `SELECT a, b FROM t ORDER BY a`, [][]string{{"1", "ONE"}, {"2", "TWO"}})
+
+	// The restored function knows about the trigger of the restored table.
+	newDB.ExpectErr(t, `cannot drop function upper_b because triggers depend on it`, `DROP FUNCTION upper_b`)
+	newDB.Exec(t, `DROP FUNCTION upper_b CASCADE`)
+	newDB.Exec(t, `INSERT INTO t VALUES (3, 'three')`)
+	newDB.CheckQueryResults(t, `SELECT b FROM t WHERE a = 3`, [][]string{{"three"}})
+}
+
+func TestBackupRestoreEnums(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	const numAccounts = 1
+	_, _, origDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+	args := base.TestServerArgs{ExternalIODir: dir}
+
+	origDB.Exec(t, `USE data`)
+	origDB.Exec(t, `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy')`)
+	origDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, m mood)`)
+	origDB.Exec(t, `INSERT INTO t VALUES (1, 'sad'), (2, 'happy')`)
+
+	dbBackup, tableBackup := localFoo+"/db", localFoo+"/table"
+	origDB.Exec(t, `BACKUP DATABASE data TO $1`, dbBackup)
+	origDB.Exec(t, `BACKUP data.t TO $1`, tableBackup)
+
+	// checkRestored verifies that the restored table references the restored
+	// type: the values are decoded, and the type can't be dropped.
+	checkRestored := func(t *testing.T, newDB *sqlutils.SQLRunner) {
+		t.Helper()
+		newDB.CheckQueryResults(t, `SELECT a, m FROM t WHERE m > 'ok' ORDER BY a`, [][]string{{"2", "happy"}})
+		newDB.Exec(t, `INSERT INTO t VALUES (3, 'ok')`)
+		newDB.CheckQueryResults(t, `SELECT a FROM t WHERE m = 'ok'::mood`, [][]string{{"3"}})
+		newDB.ExpectErr(t, `cannot drop type "mood" because other objects depend on it`, `DROP TYPE mood`)
+	}
+
+	t.Run("database", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `RESTORE DATABASE data FROM $1`, dbBackup)
+		newDB.Exec(t, `USE data`)
+		checkRestored(t, newDB)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		// The types used by a table are backed up along with it.
+		newDB.Exec(t, `CREATE DATABASE data`)
+		newDB.Exec(t, `USE data`)
+		newDB.Exec(t, `CREATE TYPE mood AS ENUM ('meh')`)
+		newDB.ExpectErr(t, `cannot restore type "mood": relation "mood" already exists`,
+			`RESTORE data.t FROM $1`, tableBackup)
+
+		newDB.Exec(t, `DROP TYPE mood`)
+		newDB.Exec(t, `RESTORE data.t FROM $1`, tableBackup)
+		checkRestored(t, newDB)
+	})
+}
+
 func TestBackupRestoreShowJob(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
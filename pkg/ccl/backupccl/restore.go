@@ -113,11 +113,18 @@ func loadSQLDescsFromBackupsAtTime(
 	for _, desc := range byID {
 		if t := desc.GetTable(); t != nil {
 			// A table revisions may have been captured before it was in a DB that is
-			// backed up -- if the DB is missing, filter the table.
-			if byID[t.ParentID] == nil {
+			// backed up -- if the DB is missing, filter the table. Likewise for
+			// the user-defined schema of the table.
+			if byID[t.ParentID] == nil || byID[t.GetNamespaceParentID()] == nil {
 				continue
 			}
 		}
+		if fn := desc.GetFunction(); fn != nil && byID[fn.ParentID] == nil {
+			continue
+		}
+		if typ := desc.GetType(); typ != nil && byID[typ.ParentID] == nil {
+			continue
+		}
 		allDescs = append(allDescs, *desc)
 	}
 	return allDescs, lastBackupDesc
@@ -184,7 +191,8 @@ func rewriteViewQueryDBNames(table *sqlbase.TableDescriptor, newDB string) error
 
 // allocateTableRewrites determines the new ID and parentID (a "TableRewrite")
 // for each table in sqlDescs and returns a mapping from old ID to said
-// TableRewrite. It first validates that the provided sqlDescs can be restored
+// TableRewrite. The rewrites of the user-defined schemas of the tables and of
+// the user-defined functions and types are included in the mapping as well. It first validates that the provided sqlDescs can be restored
 // into their original database (or the database specified in opst) to avoid
 // leaking table IDs if we can be sure the restore would fail.
 func allocateTableRewrites(
@@ -207,12 +215,21 @@ func allocateTableRewrites(
 	}
 
 	databasesByID := make(map[sqlbase.ID]*sqlbase.DatabaseDescriptor)
+	schemasByID := make(map[sqlbase.ID]*sqlbase.SchemaDescriptor)
 	tablesByID := make(map[sqlbase.ID]*sqlbase.TableDescriptor)
+	functionsByID := make(map[sqlbase.ID]*sqlbase.FunctionDescriptor)
+	typesByID := make(map[sqlbase.ID]*sqlbase.TypeDescriptor)
 	for _, desc := range sqlDescs {
 		if dbDesc := desc.GetDatabase(); dbDesc != nil {
 			databasesByID[dbDesc.ID] = dbDesc
+		} else if scDesc := desc.GetSchema(); scDesc != nil {
+			schemasByID[scDesc.ID] = scDesc
 		} else if tableDesc := desc.GetTable(); tableDesc != nil {
 			tablesByID[tableDesc.ID] = tableDesc
+		} else if fnDesc := desc.GetFunction(); fnDesc != nil {
+			functionsByID[fnDesc.ID] = fnDesc
+		} else if typDesc := desc.GetType(); typDesc != nil {
+			typesByID[typDesc.ID] = typDesc
 		}
 	}
 
@@ -255,6 +272,28 @@ func allocateTableRewrites(
 		}
 	}
 
+	// Check that the functions executed by the triggers of the tables are
+	// restored along with them.
+	for _, table := range tablesByID {
+		for _, trigger := range table.Triggers {
+			if _, ok := functionsByID[trigger.FunctionID]; !ok {
+				return nil, errors.Errorf("cannot restore table %q without the function %d of its trigger %q",
+					table.Name, trigger.FunctionID, trigger.Name)
+			}
+		}
+	}
+
+	// Check that the types used by the tables and functions are restored
+	// along with them.
+	for i := range sqlDescs {
+		for _, typ := range userDefinedTypes(&sqlDescs[i]) {
+			if _, ok := typesByID[typ.EnumMetadata.TypeID]; !ok {
+				return nil, errors.Errorf("cannot restore %q without referenced type %d",
+					sqlDescs[i].GetName(), typ.EnumMetadata.TypeID)
+			}
+		}
+	}
+
 	needsNewParentIDs := make(map[string][]sqlbase.ID)
 
 	// Fail fast if the necessary databases don't exist or are otherwise
@@ -271,49 +310,100 @@ func allocateTableRewrites(
 			}
 		}
 
-		for _, table := range tablesByID {
-			var targetDB string
+		// getTargetDB returns the name of the database into which the object
+		// of the given kind, name and parent in the backup is restored.
+		getTargetDB := func(parentID sqlbase.ID, kind, name string) (string, error) {
 			if renaming {
-				targetDB = overrideDB
-			} else {
-				database, ok := databasesByID[table.ParentID]
-				if !ok {
-					return errors.Errorf("no database with ID %d in backup for table %q",
-						table.ParentID, table.Name)
+				return overrideDB, nil
+			}
+			database, ok := databasesByID[parentID]
+			if !ok {
+				return "", errors.Errorf("no database with ID %d in backup for %s %q",
+					parentID, kind, name)
+			}
+			return database.Name, nil
+		}
+		// getExistingDBID returns the ID of targetDB, an existing database into
+		// which the object of the given kind and name is restored.
+		getExistingDBID := func(targetDB, kind, name string) (sqlbase.ID, error) {
+			existingDatabaseID, err := txn.Get(ctx, sqlbase.MakeNameMetadataKey(keys.RootNamespaceID, targetDB))
+			if err != nil {
+				return 0, err
+			}
+			if existingDatabaseID.Value == nil {
+				return 0, errors.Errorf("a database named %q needs to exist to restore %s %q",
+					targetDB, kind, name)
+			}
+			newParentID, err := existingDatabaseID.Value.GetInt()
+			if err != nil {
+				return 0, err
+			}
+			return sqlbase.ID(newParentID), nil
+		}
+
+		for _, table := range tablesByID {
+			var scDesc *sqlbase.SchemaDescriptor
+			if scID := table.UnexposedParentSchemaID; scID != sqlbase.InvalidID {
+				var ok bool
+				if scDesc, ok = schemasByID[scID]; !ok {
+					return errors.Errorf("no schema with ID %d in backup for table %q",
+						scID, table.Name)
 				}
-				targetDB = database.Name
+			}
+
+			targetDB, err := getTargetDB(table.ParentID, "table", table.Name)
+			if err != nil {
+				return err
 			}
 
 			if _, ok := restoreDBNames[targetDB]; ok {
 				needsNewParentIDs[targetDB] = append(needsNewParentIDs[targetDB], table.ID)
 			} else {
-				var parentID sqlbase.ID
-				{
-					existingDatabaseID, err := txn.Get(ctx, sqlbase.MakeNameMetadataKey(keys.RootNamespaceID, targetDB))
+				parentID, err := getExistingDBID(targetDB, "table", table.Name)
+				if err != nil {
+					return err
+				}
+
+				// A table of a user-defined schema is restored into the schema
+				// with the same name in the target database.
+				namespaceID := parentID
+				if scDesc != nil {
+					existingSchemaID, err := txn.Get(ctx, sqlbase.MakeNameMetadataKey(parentID, scDesc.Name))
 					if err != nil {
 						return err
 					}
-					if existingDatabaseID.Value == nil {
-						return errors.Errorf("a database named %q needs to exist to restore table %q",
-							targetDB, table.Name)
+					if existingSchemaID.Value == nil {
+						return errors.Errorf("a schema named %q needs to exist in database %q to restore table %q",
+							scDesc.Name, targetDB, table.Name)
 					}
-
-					newParentID, err := existingDatabaseID.Value.GetInt()
+					newSchemaID, err := existingSchemaID.Value.GetInt()
 					if err != nil {
 						return err
 					}
-					parentID = sqlbase.ID(newParentID)
+					namespaceID = sqlbase.ID(newSchemaID)
+					tableRewrites[scDesc.ID] = &jobspb.RestoreDetails_TableRewrite{
+						TableID: namespaceID, ParentID: parentID,
+					}
 				}
 
 				// Check that the table name is _not_ in use.
 				// This would fail the CPut later anyway, but this yields a prettier error.
-				if err := CheckTableExists(ctx, txn, parentID, table.Name); err != nil {
+				if err := CheckTableExists(ctx, txn, namespaceID, table.Name); err != nil {
 					return err
 				}
 
 				// Check privileges. These will be checked again in the transaction
 				// that actually writes the new table descriptors.
-				{
+				if scDesc != nil {
+					parentSchema, err := sqlbase.GetSchemaDescFromID(ctx, txn, namespaceID)
+					if err != nil {
+						return errors.Wrapf(err, "failed to lookup parent schema %d", namespaceID)
+					}
+
+					if err := p.CheckPrivilege(ctx, parentSchema, privilege.CREATE); err != nil {
+						return err
+					}
+				} else {
 					parentDB, err := sqlbase.GetDatabaseDescFromID(ctx, txn, parentID)
 					if err != nil {
 						return errors.Wrapf(err, "failed to lookup parent DB %d", parentID)
@@ -328,6 +418,45 @@ func allocateTableRewrites(
 				tableRewrites[table.ID] = &jobspb.RestoreDetails_TableRewrite{ParentID: parentID}
 			}
 		}
+
+		// The user-defined functions and types are restored like the tables of
+		// their database, into the public schema of the target database.
+		allocateParentID := func(id, parentID sqlbase.ID, kind, name string) error {
+			targetDB, err := getTargetDB(parentID, kind, name)
+			if err != nil {
+				return err
+			}
+			if _, ok := restoreDBNames[targetDB]; ok {
+				needsNewParentIDs[targetDB] = append(needsNewParentIDs[targetDB], id)
+				return nil
+			}
+			newParentID, err := getExistingDBID(targetDB, kind, name)
+			if err != nil {
+				return err
+			}
+			if err := CheckTableExists(ctx, txn, newParentID, name); err != nil {
+				return errors.Wrapf(err, "cannot restore %s %q", kind, name)
+			}
+			parentDB, err := sqlbase.GetDatabaseDescFromID(ctx, txn, newParentID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to lookup parent DB %d", newParentID)
+			}
+			if err := p.CheckPrivilege(ctx, parentDB, privilege.CREATE); err != nil {
+				return err
+			}
+			tableRewrites[id] = &jobspb.RestoreDetails_TableRewrite{ParentID: newParentID}
+			return nil
+		}
+		for _, fn := range functionsByID {
+			if err := allocateParentID(fn.ID, fn.ParentID, "function", fn.Name); err != nil {
+				return err
+			}
+		}
+		for _, typ := range typesByID {
+			if err := allocateParentID(typ.ID, typ.ParentID, "type", typ.Name); err != nil {
+				return err
+			}
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -355,6 +484,17 @@ func allocateTableRewrites(
 		for _, tableID := range needsNewParentIDs[db.Name] {
 			tableRewrites[tableID] = &jobspb.RestoreDetails_TableRewrite{ParentID: newID}
 		}
+		// The user-defined schemas of the database are restored along with it.
+		for _, sc := range schemasByID {
+			if sc.ParentID != db.ID {
+				continue
+			}
+			newSchemaID, err := sql.GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+			if err != nil {
+				return nil, err
+			}
+			tableRewrites[sc.ID] = &jobspb.RestoreDetails_TableRewrite{TableID: newSchemaID, ParentID: newID}
+		}
 	}
 
 	tables := make([]*sqlbase.TableDescriptor, 0, len(tablesByID))
@@ -369,6 +509,22 @@ func allocateTableRewrites(
 		}
 		tableRewrites[table.ID].TableID = newTableID
 	}
+	// The functions and types have no data, so the order of their IDs does not
+	// matter.
+	for _, fn := range functionsByID {
+		newFunctionID, err := sql.GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+		if err != nil {
+			return nil, err
+		}
+		tableRewrites[fn.ID].TableID = newFunctionID
+	}
+	for _, typ := range typesByID {
+		newTypeID, err := sql.GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+		if err != nil {
+			return nil, err
+		}
+		tableRewrites[typ.ID].TableID = newTypeID
+	}
 
 	return tableRewrites, nil
 }
@@ -390,8 +546,9 @@ func CheckTableExists(
 }
 
 // RewriteTableDescs mutates tables to match the ID and privilege specified
-// in tableRewrites, as well as adjusting cross-table references to use the
-// new IDs. overrideDB can be specified to set database names in views.
+// in tableRewrites, as well as adjusting cross-table references, and the
+// references to user-defined types, to use the new IDs. overrideDB can be
+// specified to set database names in views.
 func RewriteTableDescs(
 	tables []*sqlbase.TableDescriptor, tableRewrites TableRewriteMap, overrideDB string,
 ) error {
@@ -414,6 +571,13 @@ func RewriteTableDescs(
 
 		table.ID = tableRewrite.TableID
 		table.ParentID = tableRewrite.ParentID
+		if scID := table.UnexposedParentSchemaID; scID != sqlbase.InvalidID {
+			schemaRewrite, ok := tableRewrites[scID]
+			if !ok {
+				return errors.Errorf("missing schema rewrite for table %q", table.Name)
+			}
+			table.UnexposedParentSchemaID = schemaRewrite.TableID
+		}
 
 		if err := table.ForeachNonDropIndex(func(index *sqlbase.IndexDescriptor) error {
 			// Verify that for any interleaved index being restored, the interleave
@@ -507,6 +671,20 @@ func RewriteTableDescs(
 			table.Columns[idx] = col
 		}
 
+		if err := rewriteTypeReferences(sqlbase.WrapDescriptor(table), tableRewrites); err != nil {
+			return err
+		}
+
+		for i := range table.Triggers {
+			trigger := &table.Triggers[i]
+			fnRewrite, ok := tableRewrites[trigger.FunctionID]
+			if !ok {
+				return errors.Errorf("missing function rewrite for trigger %q of table %q",
+					trigger.Name, table.Name)
+			}
+			trigger.FunctionID = fnRewrite.TableID
+		}
+
 		// since this is a "new" table in eyes of new cluster, any leftover change
 		// lease is obviously bogus (plus the nodeID is relative to backup cluster).
 		table.Lease = nil
@@ -514,6 +692,20 @@ func RewriteTableDescs(
 	return nil
 }
 
+// rewriteTypeReferences updates the columns of a table, or the arguments and
+// result of a function, to use the new IDs of the user-defined types they
+// reference.
+func rewriteTypeReferences(desc *sqlbase.Descriptor, tableRewrites TableRewriteMap) error {
+	for _, typ := range userDefinedTypes(desc) {
+		rewrite, ok := tableRewrites[typ.EnumMetadata.TypeID]
+		if !ok {
+			return errors.Errorf("missing type rewrite for %q", desc.GetName())
+		}
+		typ.EnumMetadata.TypeID = rewrite.TableID
+	}
+	return nil
+}
+
 type intervalSpan roachpb.Span
 
 var _ interval.Interface = intervalSpan{}
@@ -867,12 +1059,19 @@ func splitAndScatter(
 // WriteTableDescs writes all the the new descriptors: First the ID ->
 // TableDescriptor for the new table, then flip (or initialize) the name -> ID
 // entry so any new queries will use the new one. The tables are assigned the
-// permissions of their parent database and the user must have CREATE permission
-// on that database at the time this function is called.
+// permissions of their parent database (or user-defined schema) and the user
+// must have CREATE permission on that database (or schema) at the time this
+// function is called. The schemas are only written along with their database.
+// The types and functions are written into the public schema of their
+// database, with the privileges CREATE TYPE and CREATE FUNCTION would give
+// them.
 func WriteTableDescs(
 	ctx context.Context,
 	txn *client.Txn,
 	databases []*sqlbase.DatabaseDescriptor,
+	schemas []*sqlbase.SchemaDescriptor,
+	types []*sqlbase.TypeDescriptor,
+	functions []*sqlbase.FunctionDescriptor,
 	tables []*sqlbase.TableDescriptor,
 	user string,
 	settings *cluster.Settings,
@@ -890,8 +1089,74 @@ func WriteTableDescs(
 			b.CPut(sqlbase.MakeDescMetadataKey(desc.ID), sqlbase.WrapDescriptor(desc), nil)
 			b.CPut(sqlbase.MakeNameMetadataKey(keys.RootNamespaceID, desc.Name), desc.ID, nil)
 		}
+		wroteSchemas := make(map[sqlbase.ID]*sqlbase.SchemaDescriptor)
+		for _, desc := range schemas {
+			parentDB, ok := wroteDBs[desc.ParentID]
+			if !ok {
+				return errors.Errorf("schema %q restored without its parent DB %d", desc.Name, desc.ParentID)
+			}
+			desc.Privileges = parentDB.GetPrivileges()
+			wroteSchemas[desc.ID] = desc
+			b.CPut(sqlbase.MakeDescMetadataKey(desc.ID), sqlbase.WrapDescriptor(desc), nil)
+			b.CPut(sqlbase.MakeNameMetadataKey(desc.ParentID, desc.Name), desc.ID, nil)
+		}
+		// getParentDB returns the database into which a type or function is
+		// written, checking that the user can create objects in it if it
+		// already exists.
+		getParentDB := func(parentID sqlbase.ID) (*sqlbase.DatabaseDescriptor, error) {
+			if parentDB, ok := wroteDBs[parentID]; ok {
+				return parentDB, nil
+			}
+			parentDB, err := sqlbase.GetDatabaseDescFromID(ctx, txn, parentID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to lookup parent DB %d", parentID)
+			}
+			if err := sql.CheckPrivilegeForUser(ctx, user, parentDB, privilege.CREATE); err != nil {
+				return nil, err
+			}
+			return parentDB, nil
+		}
+		for _, desc := range types {
+			parentDB, err := getParentDB(desc.ParentID)
+			if err != nil {
+				return err
+			}
+			desc.Privileges = parentDB.GetPrivileges()
+			if err := desc.Validate(); err != nil {
+				return errors.Wrapf(err, "validate type %d", desc.ID)
+			}
+			b.CPut(sqlbase.MakeDescMetadataKey(desc.ID), sqlbase.WrapDescriptor(desc), nil)
+			b.CPut(sqlbase.MakeNameMetadataKey(desc.ParentID, desc.Name), desc.ID, nil)
+		}
+		for _, desc := range functions {
+			parentDB, err := getParentDB(desc.ParentID)
+			if err != nil {
+				return err
+			}
+			desc.Privileges = sql.MakeFunctionPrivileges(parentDB.GetPrivileges(), user)
+			if err := desc.Validate(); err != nil {
+				return errors.Wrapf(err, "validate function %d", desc.ID)
+			}
+			b.CPut(sqlbase.MakeDescMetadataKey(desc.ID), sqlbase.WrapDescriptor(desc), nil)
+			b.CPut(sqlbase.MakeNameMetadataKey(desc.ParentID, desc.Name), desc.ID, nil)
+		}
 		for _, table := range tables {
-			if wrote, ok := wroteDBs[table.ParentID]; ok {
+			if scID := table.UnexposedParentSchemaID; scID != sqlbase.InvalidID {
+				if wrote, ok := wroteSchemas[scID]; ok {
+					table.Privileges = wrote.GetPrivileges()
+				} else {
+					parentSchema, err := sqlbase.GetSchemaDescFromID(ctx, txn, scID)
+					if err != nil {
+						return errors.Wrapf(err, "failed to lookup parent schema %d", scID)
+					}
+					if err := sql.CheckPrivilegeForUser(ctx, user, parentSchema, privilege.CREATE); err != nil {
+						return err
+					}
+					// Like CREATE TABLE, tables of a schema get the privileges of
+					// the schema.
+					table.Privileges = parentSchema.GetPrivileges()
+				}
+			} else if wrote, ok := wroteDBs[table.ParentID]; ok {
 				table.Privileges = wrote.GetPrivileges()
 			} else {
 				parentDB, err := sqlbase.GetDatabaseDescFromID(ctx, txn, table.ParentID)
@@ -1003,7 +1268,15 @@ func restore(
 	overrideDB string,
 	job *jobs.Job,
 	resultsCh chan<- tree.Datums,
-) (roachpb.BulkOpSummary, []*sqlbase.DatabaseDescriptor, []*sqlbase.TableDescriptor, error) {
+) (
+	roachpb.BulkOpSummary,
+	[]*sqlbase.DatabaseDescriptor,
+	[]*sqlbase.SchemaDescriptor,
+	[]*sqlbase.TypeDescriptor,
+	[]*sqlbase.FunctionDescriptor,
+	[]*sqlbase.TableDescriptor,
+	error,
+) {
 	// A note about contexts and spans in this method: the top-level context
 	// `restoreCtx` is used for orchestration logging. All operations that carry
 	// out work get their individual contexts.
@@ -1018,9 +1291,49 @@ func restore(
 	}
 
 	var databases []*sqlbase.DatabaseDescriptor
+	var schemas []*sqlbase.SchemaDescriptor
+	var types []*sqlbase.TypeDescriptor
+	var functions []*sqlbase.FunctionDescriptor
 	var tables []*sqlbase.TableDescriptor
 	var oldTableIDs []sqlbase.ID
 	for _, desc := range sqlDescs {
+		// The user-defined schemas are only created when their database is
+		// restored. Otherwise, the tables are restored into existing schemas.
+		if scDesc := desc.GetSchema(); scDesc != nil {
+			if _, ok := tableRewrites[scDesc.ParentID]; ok {
+				if rewrite, ok := tableRewrites[scDesc.ID]; ok {
+					scDesc.ID = rewrite.TableID
+					scDesc.ParentID = rewrite.ParentID
+					schemas = append(schemas, scDesc)
+				}
+			}
+		}
+		if fnDesc := desc.GetFunction(); fnDesc != nil {
+			if rewrite, ok := tableRewrites[fnDesc.ID]; ok {
+				fnDesc.ID = rewrite.TableID
+				fnDesc.ParentID = rewrite.ParentID
+				if err := rewriteTypeReferences(sqlbase.WrapDescriptor(fnDesc), tableRewrites); err != nil {
+					return mu.res, nil, nil, nil, nil, nil, err
+				}
+				// The function only keeps track of the triggers of the tables
+				// which are restored with it.
+				origTableIDs := fnDesc.TriggerTableIDs
+				fnDesc.TriggerTableIDs = nil
+				for _, id := range origTableIDs {
+					if tableRewrite, ok := tableRewrites[id]; ok {
+						fnDesc.TriggerTableIDs = append(fnDesc.TriggerTableIDs, tableRewrite.TableID)
+					}
+				}
+				functions = append(functions, fnDesc)
+			}
+		}
+		if typDesc := desc.GetType(); typDesc != nil {
+			if rewrite, ok := tableRewrites[typDesc.ID]; ok {
+				typDesc.ID = rewrite.TableID
+				typDesc.ParentID = rewrite.ParentID
+				types = append(types, typDesc)
+			}
+		}
 		if tableDesc := desc.GetTable(); tableDesc != nil {
 			tables = append(tables, tableDesc)
 			oldTableIDs = append(oldTableIDs, tableDesc.ID)
@@ -1042,7 +1355,7 @@ func restore(
 	// Assign new IDs and privileges to the tables, and update all references to
 	// use the new IDs.
 	if err := RewriteTableDescs(tables, tableRewrites, overrideDB); err != nil {
-		return mu.res, nil, nil, err
+		return mu.res, nil, nil, nil, nil, nil, err
 	}
 
 	{
@@ -1062,7 +1375,7 @@ func restore(
 	for i := range tables {
 		newDescBytes, err := protoutil.Marshal(sqlbase.WrapDescriptor(tables[i]))
 		if err != nil {
-			return mu.res, nil, nil, errors.Wrap(err, "marshaling descriptor")
+			return mu.res, nil, nil, nil, nil, nil, errors.Wrap(err, "marshaling descriptor")
 		}
 		rekeys = append(rekeys, roachpb.ImportRequest_TableRekey{
 			OldID:   uint32(oldTableIDs[i]),
@@ -1071,7 +1384,7 @@ func restore(
 	}
 	kr, err := storageccl.MakeKeyRewriterFromRekeys(rekeys)
 	if err != nil {
-		return mu.res, nil, nil, err
+		return mu.res, nil, nil, nil, nil, nil, err
 	}
 
 	// Pivot the backups, which are grouped by time, into requests for import,
@@ -1079,7 +1392,7 @@ func restore(
 	highWaterMark := job.Progress().Details.(*jobspb.Progress_Restore).Restore.HighWater
 	importSpans, _, err := makeImportSpans(spans, backupDescs, highWaterMark, errOnMissingRange)
 	if err != nil {
-		return mu.res, nil, nil, errors.Wrapf(err, "making import requests for %d backups", len(backupDescs))
+		return mu.res, nil, nil, nil, nil, nil, errors.Wrapf(err, "making import requests for %d backups", len(backupDescs))
 	}
 
 	for i := range importSpans {
@@ -1216,10 +1529,10 @@ func restore(
 		// This leaves the data that did get imported in case the user wants to
 		// retry.
 		// TODO(dan): Build tooling to allow a user to restart a failed restore.
-		return mu.res, nil, nil, errors.Wrapf(err, "importing %d ranges", len(importSpans))
+		return mu.res, nil, nil, nil, nil, nil, errors.Wrapf(err, "importing %d ranges", len(importSpans))
 	}
 
-	return mu.res, databases, tables, nil
+	return mu.res, databases, schemas, types, functions, tables, nil
 }
 
 // RestoreHeader is the header for RESTORE stmt results.
@@ -1427,6 +1740,9 @@ type restoreResumer struct {
 	settings       *cluster.Settings
 	res            roachpb.BulkOpSummary
 	databases      []*sqlbase.DatabaseDescriptor
+	schemas        []*sqlbase.SchemaDescriptor
+	types          []*sqlbase.TypeDescriptor
+	functions      []*sqlbase.FunctionDescriptor
 	tables         []*sqlbase.TableDescriptor
 	statsRefresher *stats.Refresher
 }
@@ -1442,7 +1758,7 @@ func (r *restoreResumer) Resume(
 		return err
 	}
 
-	res, databases, tables, err := restore(
+	res, databases, schemas, types, functions, tables, err := restore(
 		ctx,
 		p.ExecCfg().DB,
 		p.ExecCfg().Gossip,
@@ -1456,6 +1772,9 @@ func (r *restoreResumer) Resume(
 	)
 	r.res = res
 	r.databases = databases
+	r.schemas = schemas
+	r.types = types
+	r.functions = functions
 	r.tables = tables
 	r.statsRefresher = p.ExecCfg().StatsRefresher
 	return err
@@ -1486,7 +1805,7 @@ func (r *restoreResumer) OnSuccess(ctx context.Context, txn *client.Txn, job *jo
 	// Write the new TableDescriptors and flip the namespace entries over to
 	// them. After this call, any queries on a table will be served by the newly
 	// restored data.
-	if err := WriteTableDescs(ctx, txn, r.databases, r.tables, job.Payload().Username, r.settings, nil); err != nil {
+	if err := WriteTableDescs(ctx, txn, r.databases, r.schemas, r.types, r.functions, r.tables, job.Payload().Username, r.settings, nil); err != nil {
 		return errors.Wrapf(err, "restoring %d TableDescriptors", len(r.tables))
 	}
 
@@ -52,19 +52,37 @@ type descriptorResolver struct {
 	descByID map[sqlbase.ID]sqlbase.Descriptor
 	// Map: db name -> dbID
 	dbsByName map[string]sqlbase.ID
-	// Map: dbID -> obj name -> obj ID
+	// Map: dbID -> schema name -> schema ID, for user-defined schemas.
+	schemasByName map[sqlbase.ID]map[string]sqlbase.ID
+	// Map: dbID or schema ID -> obj name -> obj ID
 	objsByName map[sqlbase.ID]map[string]sqlbase.ID
+	// Map: dbID -> IDs of the user-defined functions of the database.
+	funcsByDB map[sqlbase.ID][]sqlbase.ID
+	// Map: dbID -> IDs of the user-defined types of the database.
+	typesByDB map[sqlbase.ID][]sqlbase.ID
+}
+
+// lookupNamespaceID returns the ID under which the names of the objects of
+// the given schema are stored: the ID of the database for the public schema,
+// or the ID of a user-defined schema.
+func (r *descriptorResolver) lookupNamespaceID(dbName, scName string) (sqlbase.ID, bool) {
+	dbID, ok := r.dbsByName[dbName]
+	if !ok {
+		return 0, false
+	}
+	if scName == tree.PublicSchema {
+		return dbID, true
+	}
+	scID, ok := r.schemasByName[dbID][scName]
+	return scID, ok
 }
 
 // LookupSchema implements the tree.TableNameTargetResolver interface.
 func (r *descriptorResolver) LookupSchema(
 	_ context.Context, dbName, scName string,
 ) (bool, tree.SchemaMeta, error) {
-	if scName != tree.PublicSchema {
-		return false, nil, nil
-	}
-	if dbID, ok := r.dbsByName[dbName]; ok {
-		return true, r.descByID[dbID], nil
+	if id, ok := r.lookupNamespaceID(dbName, scName); ok {
+		return true, r.descByID[id], nil
 	}
 	return false, nil, nil
 }
@@ -76,14 +94,11 @@ func (r *descriptorResolver) LookupObject(
 	if requireMutable {
 		panic("did not expect request for mutable descriptor")
 	}
-	if scName != tree.PublicSchema {
-		return false, nil, nil
-	}
-	dbID, ok := r.dbsByName[dbName]
+	id, ok := r.lookupNamespaceID(dbName, scName)
 	if !ok {
 		return false, nil, nil
 	}
-	if objMap, ok := r.objsByName[dbID]; ok {
+	if objMap, ok := r.objsByName[id]; ok {
 		if objID, ok := objMap[obName]; ok {
 			return true, r.descByID[objID], nil
 		}
@@ -95,9 +110,12 @@ func (r *descriptorResolver) LookupObject(
 // known set of descriptors.
 func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, error) {
 	r := &descriptorResolver{
-		descByID:   make(map[sqlbase.ID]sqlbase.Descriptor),
-		dbsByName:  make(map[string]sqlbase.ID),
-		objsByName: make(map[sqlbase.ID]map[string]sqlbase.ID),
+		descByID:      make(map[sqlbase.ID]sqlbase.Descriptor),
+		dbsByName:     make(map[string]sqlbase.ID),
+		schemasByName: make(map[sqlbase.ID]map[string]sqlbase.ID),
+		objsByName:    make(map[sqlbase.ID]map[string]sqlbase.ID),
+		funcsByDB:     make(map[sqlbase.ID][]sqlbase.ID),
+		typesByDB:     make(map[sqlbase.ID][]sqlbase.ID),
 	}
 
 	// Iterate to find the databases first. We need that because we also
@@ -119,10 +137,47 @@ func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, err
 		}
 		r.descByID[desc.GetID()] = desc
 	}
+	// Then the user-defined schemas, which are needed to check the tables.
+	for _, desc := range descs {
+		if scDesc := desc.GetSchema(); scDesc != nil {
+			parentDesc, ok := r.descByID[scDesc.ParentID]
+			if !ok || parentDesc.GetDatabase() == nil {
+				return nil, errors.Errorf("schema %q has unknown ParentID %d", scDesc.Name, scDesc.ParentID)
+			}
+			scMap := r.schemasByName[scDesc.ParentID]
+			if scMap == nil {
+				scMap = make(map[string]sqlbase.ID)
+			}
+			if _, ok := scMap[scDesc.Name]; ok {
+				return nil, errors.Errorf("duplicate schema name: %q.%q used for ID %d and %d",
+					parentDesc.GetName(), scDesc.Name, scDesc.ID, scMap[scDesc.Name])
+			}
+			scMap[scDesc.Name] = scDesc.ID
+			r.schemasByName[scDesc.ParentID] = scMap
+		}
+	}
+	// The user-defined functions and types, which can't be targeted by name
+	// but are included in the expansions of their database.
+	for _, desc := range descs {
+		if fnDesc := desc.GetFunction(); fnDesc != nil {
+			parentDesc, ok := r.descByID[fnDesc.ParentID]
+			if !ok || parentDesc.GetDatabase() == nil {
+				return nil, errors.Errorf("function %q has unknown ParentID %d", fnDesc.Name, fnDesc.ParentID)
+			}
+			r.funcsByDB[fnDesc.ParentID] = append(r.funcsByDB[fnDesc.ParentID], fnDesc.ID)
+		} else if typDesc := desc.GetType(); typDesc != nil {
+			parentDesc, ok := r.descByID[typDesc.ParentID]
+			if !ok || parentDesc.GetDatabase() == nil {
+				return nil, errors.Errorf("type %q has unknown ParentID %d", typDesc.Name, typDesc.ParentID)
+			}
+			r.typesByDB[typDesc.ParentID] = append(r.typesByDB[typDesc.ParentID], typDesc.ID)
+		}
+	}
 	// Now on to the tables.
 	for _, desc := range descs {
 		if tbDesc := desc.GetTable(); tbDesc != nil {
-			if tbDesc.Dropped() {
+			// Temporary tables are not included in backups.
+			if tbDesc.Dropped() || tbDesc.Temporary {
 				continue
 			}
 			parentDesc, ok := r.descByID[tbDesc.ParentID]
@@ -133,7 +188,14 @@ func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, err
 				return nil, errors.Errorf("table %q's ParentID %d (%q) is not a database",
 					tbDesc.Name, tbDesc.ParentID, parentDesc.GetName())
 			}
-			objMap := r.objsByName[parentDesc.GetID()]
+			nsID := tbDesc.GetNamespaceParentID()
+			if nsID != tbDesc.ParentID {
+				if scDesc, ok := r.descByID[nsID]; !ok || scDesc.GetSchema() == nil ||
+					scDesc.GetSchema().ParentID != tbDesc.ParentID {
+					return nil, errors.Errorf("table %q has unknown schema ID %d", tbDesc.Name, nsID)
+				}
+			}
+			objMap := r.objsByName[nsID]
 			if objMap == nil {
 				objMap = make(map[string]sqlbase.ID)
 			}
@@ -142,7 +204,7 @@ func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, err
 					parentDesc.GetName(), tbDesc.Name, tbDesc.ID, objMap[tbDesc.Name])
 			}
 			objMap[tbDesc.Name] = tbDesc.ID
-			r.objsByName[parentDesc.GetID()] = objMap
+			r.objsByName[nsID] = objMap
 		}
 	}
 
@@ -156,6 +218,16 @@ func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, err
 // named as DBs (e.g. with `DATABASE foo`, not `foo.*`). These distinctions are
 // used e.g. by RESTORE.
 //
+// The descriptor of a user-defined schema is included if the schema is
+// expanded, via either `sc.*` or `DATABASE foo` (`foo.*` only expands the
+// public schema), or if one of its tables matches the targets.
+//
+// The descriptors of the user-defined functions and types of a database are
+// included if the database is expanded, via either `foo.*` or `DATABASE foo`.
+// The descriptors of the functions executed by the triggers of the included
+// tables, and of the types used by the included tables and functions, are
+// always included, along with the descriptors of their databases.
+//
 // This is guaranteed to not return duplicates.
 func descriptorsMatchingTargets(
 	ctx context.Context,
@@ -164,9 +236,6 @@ func descriptorsMatchingTargets(
 	descriptors []sqlbase.Descriptor,
 	targets tree.TargetList,
 ) (descriptorsMatched, error) {
-	// TODO(dan): once CockroachDB supports schemas in addition to
-	// catalogs, then this method will need to support it.
-
 	ret := descriptorsMatched{}
 
 	resolver, err := newDescriptorResolver(descriptors)
@@ -176,6 +245,7 @@ func descriptorsMatchingTargets(
 
 	alreadyRequestedDBs := make(map[sqlbase.ID]struct{})
 	alreadyExpandedDBs := make(map[sqlbase.ID]struct{})
+	alreadyExpandedSchemas := make(map[sqlbase.ID]struct{})
 	// Process all the DATABASE requests.
 	for _, d := range targets.Databases {
 		dbID, ok := resolver.dbsByName[string(d)]
@@ -189,12 +259,23 @@ func descriptorsMatchingTargets(
 			ret.expandedDB = append(ret.expandedDB, dbID)
 			alreadyRequestedDBs[dbID] = struct{}{}
 			alreadyExpandedDBs[dbID] = struct{}{}
+			for _, scID := range resolver.schemasByName[dbID] {
+				alreadyExpandedSchemas[scID] = struct{}{}
+			}
 		}
 	}
 
 	// Process all the TABLE requests.
-	// Pulling in a table needs to pull in the underlying database too.
+	// Pulling in a table needs to pull in the underlying database too, and
+	// its schema if it is in a user-defined schema.
 	alreadyRequestedTables := make(map[sqlbase.ID]struct{})
+	alreadyRequestedSchemas := make(map[sqlbase.ID]struct{})
+	requestSchema := func(scID sqlbase.ID) {
+		if _, ok := alreadyRequestedSchemas[scID]; !ok {
+			ret.descs = append(ret.descs, resolver.descByID[scID])
+			alreadyRequestedSchemas[scID] = struct{}{}
+		}
+	}
 	for _, pattern := range targets.Tables {
 		var err error
 		pattern, err = pattern.NormalizeTablePattern()
@@ -220,6 +301,10 @@ func descriptorsMatchingTargets(
 				ret.descs = append(ret.descs, parentDesc)
 				alreadyRequestedDBs[parentID] = struct{}{}
 			}
+			// Likewise for the schema of the table.
+			if nsID := desc.GetTable().GetNamespaceParentID(); nsID != parentID {
+				requestSchema(nsID)
+			}
 			// Then request the table itself.
 			if _, ok := alreadyRequestedTables[desc.GetID()]; !ok {
 				alreadyRequestedTables[desc.GetID()] = struct{}{}
@@ -236,6 +321,17 @@ func descriptorsMatchingTargets(
 			}
 			desc := descI.(sqlbase.Descriptor)
 
+			// A user-defined schema is expanded on its own. Its database is
+			// requested but not expanded.
+			if scDesc := desc.GetSchema(); scDesc != nil {
+				if _, ok := alreadyRequestedDBs[scDesc.ParentID]; !ok {
+					ret.descs = append(ret.descs, resolver.descByID[scDesc.ParentID])
+					alreadyRequestedDBs[scDesc.ParentID] = struct{}{}
+				}
+				alreadyExpandedSchemas[scDesc.ID] = struct{}{}
+				continue
+			}
+
 			// If the database is not requested already, request it now.
 			dbID := desc.GetID()
 			if _, ok := alreadyRequestedDBs[dbID]; !ok {
@@ -254,14 +350,106 @@ func descriptorsMatchingTargets(
 		}
 	}
 
-	// Then process the database expansions.
-	for dbID := range alreadyExpandedDBs {
-		for _, tblID := range resolver.objsByName[dbID] {
+	// Then process the database and schema expansions.
+	expand := func(nsID sqlbase.ID) {
+		for _, tblID := range resolver.objsByName[nsID] {
 			if _, ok := alreadyRequestedTables[tblID]; !ok {
 				ret.descs = append(ret.descs, resolver.descByID[tblID])
+				alreadyRequestedTables[tblID] = struct{}{}
 			}
 		}
 	}
+	alreadyRequestedFunctions := make(map[sqlbase.ID]struct{})
+	alreadyRequestedTypes := make(map[sqlbase.ID]struct{})
+	for dbID := range alreadyExpandedDBs {
+		expand(dbID)
+		for _, fnID := range resolver.funcsByDB[dbID] {
+			ret.descs = append(ret.descs, resolver.descByID[fnID])
+			alreadyRequestedFunctions[fnID] = struct{}{}
+		}
+		for _, typID := range resolver.typesByDB[dbID] {
+			ret.descs = append(ret.descs, resolver.descByID[typID])
+			alreadyRequestedTypes[typID] = struct{}{}
+		}
+	}
+	for scID := range alreadyExpandedSchemas {
+		requestSchema(scID)
+		expand(scID)
+	}
+
+	// Finally, request the functions executed by the triggers of the requested
+	// tables, and then the types used by the requested tables and functions.
+	// They can belong to other databases, which are requested as well.
+	requestDBObject := func(id, parentID sqlbase.ID) {
+		if _, ok := alreadyRequestedDBs[parentID]; !ok {
+			ret.descs = append(ret.descs, resolver.descByID[parentID])
+			alreadyRequestedDBs[parentID] = struct{}{}
+		}
+		ret.descs = append(ret.descs, resolver.descByID[id])
+	}
+	for i := range ret.descs {
+		table := ret.descs[i].GetTable()
+		if table == nil {
+			continue
+		}
+		for _, trigger := range table.Triggers {
+			if _, ok := alreadyRequestedFunctions[trigger.FunctionID]; ok {
+				continue
+			}
+			fnDesc, ok := resolver.descByID[trigger.FunctionID]
+			if !ok || fnDesc.GetFunction() == nil {
+				return ret, errors.Errorf("trigger %q of table %q references unknown function %d",
+					trigger.Name, table.Name, trigger.FunctionID)
+			}
+			requestDBObject(trigger.FunctionID, fnDesc.GetFunction().ParentID)
+			alreadyRequestedFunctions[trigger.FunctionID] = struct{}{}
+		}
+	}
+	for i := range ret.descs {
+		desc := ret.descs[i]
+		for _, typ := range userDefinedTypes(&desc) {
+			typID := typ.EnumMetadata.TypeID
+			if _, ok := alreadyRequestedTypes[typID]; ok {
+				continue
+			}
+			typDesc, ok := resolver.descByID[typID]
+			if !ok || typDesc.GetType() == nil {
+				return ret, errors.Errorf("%q references unknown type %d", desc.GetName(), typID)
+			}
+			requestDBObject(typID, typDesc.GetType().ParentID)
+			alreadyRequestedTypes[typID] = struct{}{}
+		}
+	}
 
 	return ret, nil
 }
+
+// userDefinedTypes returns the column types of the given table or function
+// descriptor which are user-defined types: the types of the columns of a
+// table, including those of the columns being added or dropped, or the types
+// of the arguments and result of a function. The returned types can be
+// modified in place.
+func userDefinedTypes(desc *sqlbase.Descriptor) []*sqlbase.ColumnType {
+	var ret []*sqlbase.ColumnType
+	add := func(typ *sqlbase.ColumnType) {
+		if typ.SemanticType == sqlbase.ColumnType_ENUM {
+			ret = append(ret, typ)
+		}
+	}
+	if table := desc.GetTable(); table != nil {
+		for i := range table.Columns {
+			add(&table.Columns[i].Type)
+		}
+		for i := range table.Mutations {
+			if col := table.Mutations[i].GetColumn(); col != nil {
+				add(&col.Type)
+			}
+		}
+	} else if fn := desc.GetFunction(); fn != nil {
+		for i := range fn.Args {
+			add(&fn.Args[i].Type)
+		}
+		add(&fn.ReturnType)
+	}
+	return ret
+}
@@ -26,6 +26,13 @@ import (
 func TestDescriptorsMatchingTargets(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
+	moodColumns := []sqlbase.ColumnDescriptor{{
+		Name: "m",
+		Type: sqlbase.ColumnType{
+			SemanticType: sqlbase.ColumnType_ENUM,
+			EnumMetadata: &sqlbase.EnumMetadata{TypeID: 9, TypeName: "mood"},
+		},
+	}}
 	descriptors := []sqlbase.Descriptor{
 		*sqlbase.WrapDescriptor(&sqlbase.DatabaseDescriptor{ID: 0, Name: "system"}),
 		*sqlbase.WrapDescriptor(&sqlbase.TableDescriptor{ID: 1, Name: "foo", ParentID: 0}),
@@ -33,6 +40,12 @@ func TestDescriptorsMatchingTargets(t *testing.T) {
 		*sqlbase.WrapDescriptor(&sqlbase.TableDescriptor{ID: 4, Name: "baz", ParentID: 3}),
 		*sqlbase.WrapDescriptor(&sqlbase.DatabaseDescriptor{ID: 3, Name: "data"}),
 		*sqlbase.WrapDescriptor(&sqlbase.DatabaseDescriptor{ID: 5, Name: "empty"}),
+		*sqlbase.WrapDescriptor(&sqlbase.SchemaDescriptor{ID: 6, Name: "sc", ParentID: 3}),
+		*sqlbase.WrapDescriptor(&sqlbase.TableDescriptor{ID: 7, Name: "qux", ParentID: 3, UnexposedParentSchemaID: 6, Columns: moodColumns}),
+		*sqlbase.WrapDescriptor(&sqlbase.FunctionDescriptor{ID: 8, Name: "fn", ParentID: 3}),
+		*sqlbase.WrapDescriptor(&sqlbase.TypeDescriptor{ID: 9, Name: "mood", ParentID: 3}),
+		*sqlbase.WrapDescriptor(&sqlbase.TableDescriptor{ID: 10, Name: "e", ParentID: 5, Columns: moodColumns,
+			Triggers: []sqlbase.TriggerDescriptor{{Name: "trig", FunctionID: 8}}}),
 	}
 
 	tests := []struct {
@@ -45,13 +58,13 @@ func TestDescriptorsMatchingTargets(t *testing.T) {
 		{"", "DATABASE system", []string{"system", "foo", "bar"}, []string{"system"}, ``},
 		{"", "DATABASE system, noexist", nil, nil, `unknown database "noexist"`},
 		{"", "DATABASE system, system", []string{"system", "foo", "bar"}, []string{"system"}, ``},
-		{"", "DATABASE data", []string{"data", "baz"}, []string{"data"}, ``},
-		{"", "DATABASE system, data", []string{"system", "foo", "bar", "data", "baz"}, []string{"data", "system"}, ``},
+		{"", "DATABASE data", []string{"data", "baz", "sc", "qux", "fn", "mood"}, []string{"data"}, ``},
+		{"", "DATABASE system, data", []string{"system", "foo", "bar", "data", "baz", "sc", "qux", "fn", "mood"}, []string{"data", "system"}, ``},
 		{"", "DATABASE system, data, noexist", nil, nil, `unknown database "noexist"`},
 		{"system", "DATABASE system", []string{"system", "foo", "bar"}, []string{"system"}, ``},
 		{"system", "DATABASE system, noexist", nil, nil, `unknown database "noexist"`},
-		{"system", "DATABASE data", []string{"data", "baz"}, []string{"data"}, ``},
-		{"system", "DATABASE system, data", []string{"system", "foo", "bar", "data", "baz"}, []string{"data", "system"}, ``},
+		{"system", "DATABASE data", []string{"data", "baz", "sc", "qux", "fn", "mood"}, []string{"data"}, ``},
+		{"system", "DATABASE system, data", []string{"system", "foo", "bar", "data", "baz", "sc", "qux", "fn", "mood"}, []string{"data", "system"}, ``},
 		{"system", "DATABASE system, data, noexist", nil, nil, `unknown database "noexist"`},
 
 		{"", "TABLE foo", nil, nil, `table "foo" does not exist`},
@@ -63,8 +76,8 @@ func TestDescriptorsMatchingTargets(t *testing.T) {
 		{"", "TABLE *, system.public.foo", nil, nil, `"\*" does not match any valid database or schema`},
 		{"noexist", "TABLE *", nil, nil, `"\*" does not match any valid database or schema`},
 		{"system", "TABLE *", []string{"system", "foo", "bar"}, nil, ``},
-		{"data", "TABLE *", []string{"data", "baz"}, nil, ``},
-		{"empty", "TABLE *", []string{"empty"}, nil, ``},
+		{"data", "TABLE *", []string{"data", "baz", "fn", "mood"}, nil, ``},
+		{"empty", "TABLE *", []string{"empty", "e", "data", "fn", "mood"}, nil, ``},
 
 		{"", "TABLE foo, baz", nil, nil, `table "(foo|baz)" does not exist`},
 		{"system", "TABLE foo, baz", nil, nil, `table "baz" does not exist`},
@@ -81,7 +94,8 @@ func TestDescriptorsMatchingTargets(t *testing.T) {
 		{"system", "TABLE system.foo, bar", []string{"system", "foo", "bar"}, nil, ``},
 
 		{"", "TABLE noexist.*", nil, nil, `"noexist\.\*" does not match any valid database or schema`},
-		{"", "TABLE empty.*", []string{"empty"}, nil, ``},
+		{"", "TABLE empty.*", []string{"empty", "e", "data", "fn", "mood"}, nil, ``},
+		{"", "TABLE empty.e", []string{"empty", "e", "data", "fn", "mood"}, nil, ``},
 		{"", "TABLE system.*", []string{"system", "foo", "bar"}, nil, ``},
 		{"", "TABLE system.public.*", []string{"system", "foo", "bar"}, nil, ``},
 		{"", "TABLE system.public.*, foo, baz", nil, nil, `table "(foo|baz)" does not exist`},
@@ -89,6 +103,18 @@ func TestDescriptorsMatchingTargets(t *testing.T) {
 		{"data", "TABLE system.public.*, baz", []string{"system", "foo", "bar", "data", "baz"}, nil, ``},
 		{"data", "TABLE system.public.*, foo, baz", nil, nil, `table "(foo|baz)" does not exist`},
 
+		{"data", "TABLE qux", nil, nil, `table "qux" does not exist`},
+		{"data", "TABLE fn", nil, nil, `table "fn" does not exist`},
+		{"data", "TABLE mood", nil, nil, `table "mood" does not exist`},
+		{"data", "TABLE sc.qux", []string{"data", "sc", "qux", "mood"}, nil, ``},
+		{"", "TABLE data.sc.qux", []string{"data", "sc", "qux", "mood"}, nil, ``},
+		{"", "TABLE data.sc.qux, data.baz", []string{"data", "sc", "qux", "baz", "mood"}, nil, ``},
+		{"data", "TABLE sc.*", []string{"data", "sc", "qux", "mood"}, nil, ``},
+		{"", "TABLE data.sc.*", []string{"data", "sc", "qux", "mood"}, nil, ``},
+		{"", "TABLE data.*", []string{"data", "baz", "fn", "mood"}, nil, ``},
+		{"", "TABLE data.*, data.sc.*", []string{"data", "baz", "sc", "qux", "fn", "mood"}, nil, ``},
+		{"", "TABLE data.noexist.*", nil, nil, `"data\.noexist\.\*" does not match any valid database or schema`},
+
 		{"", "TABLE SyStEm.FoO", []string{"system", "foo"}, nil, ``},
 		{"", "TABLE SyStEm.pUbLic.FoO", []string{"system", "foo"}, nil, ``},
 
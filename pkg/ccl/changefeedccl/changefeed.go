@@ -12,6 +12,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -20,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/util/bufalloc"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -32,8 +34,18 @@ var changefeedPollInterval = settings.RegisterNonNegativeDurationSetting(
 	1*time.Second,
 )
 
+// changefeedProtectTimestampInterval is the minimum amount by which the
+// resolved timestamp of a changefeed must advance before the timestamp
+// protected by its protected timestamp record is moved up to it.
+var changefeedProtectTimestampInterval = settings.RegisterNonNegativeDurationSetting(
+	"changefeed.protect_timestamp_interval",
+	"controls how often the changefeed forwards its protected timestamp to the resolved timestamp",
+	10*time.Minute,
+)
+
 func init() {
 	changefeedPollInterval.Hide()
+	changefeedProtectTimestampInterval.Hide()
 }
 
 // PushEnabled is a cluster setting that triggers all subsequently
@@ -290,11 +302,14 @@ func emitEntries(
 }
 
 // checkpointResolvedTimestamp checkpoints a changefeed-level resolved timestamp
-// to the jobs record.
+// to the jobs record. If pts is non-nil, the timestamp protected by the
+// changefeed's protected timestamp record is moved up to the resolved
+// timestamp in the same transaction.
 func checkpointResolvedTimestamp(
 	ctx context.Context,
 	jobProgressedFn func(context.Context, jobs.HighWaterProgressedFn) error,
 	sf *spanFrontier,
+	pts protectedts.Storage,
 ) error {
 	resolved := sf.Frontier()
 	var resolvedSpans []jobspb.ResolvedSpan
@@ -311,13 +326,24 @@ func checkpointResolvedTimestamp(
 	// this resolved timestamp, keep this update of the high-water mark
 	// before emitting the resolved timestamp to the sink.
 	if jobProgressedFn != nil {
-		progressedClosure := func(ctx context.Context, d jobspb.ProgressDetails) hlc.Timestamp {
+		progressedClosure := func(
+			ctx context.Context, txn *client.Txn, d jobspb.ProgressDetails,
+		) (hlc.Timestamp, error) {
 			// TODO(dan): This was making enormous jobs rows, especially in
 			// combination with how many mvcc versions there are. Cut down on
 			// the amount of data used here dramatically and re-enable.
 			//
 			// d.(*jobspb.Progress_Changefeed).Changefeed.ResolvedSpans = resolvedSpans
-			return resolved
+			if cf, ok := d.(*jobspb.Progress_Changefeed); ok && pts != nil &&
+				cf.Changefeed.ProtectedTimestampRecord != nil {
+				// Nothing below the resolved timestamp will be read again.
+				if err := pts.UpdateTimestamp(
+					ctx, txn, *cf.Changefeed.ProtectedTimestampRecord, resolved,
+				); err != nil {
+					return hlc.Timestamp{}, err
+				}
+			}
+			return resolved, nil
 		}
 		if err := jobProgressedFn(ctx, progressedClosure); err != nil {
 			return err
@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -323,6 +324,12 @@ type changeFrontier struct {
 	// jobProgressedFn, if non-nil, is called to checkpoint the changefeed's
 	// progress in the corresponding system job entry.
 	jobProgressedFn func(context.Context, jobs.HighWaterProgressedFn) error
+	// pts, if non-nil, is used to forward the changefeed's protected
+	// timestamp as its resolved timestamp advances.
+	pts protectedts.Storage
+	// lastProtectedTimestampUpdate is the resolved timestamp at which the
+	// protected timestamp was last forwarded.
+	lastProtectedTimestampUpdate hlc.Timestamp
 	// passthroughBuf, in some but not all flows, contains changed row data to
 	// pass through unchanged to the gateway node.
 	passthroughBuf encDatumRowBuffer
@@ -432,6 +439,7 @@ func (cf *changeFrontier) Start(ctx context.Context) context.Context {
 			return ctx
 		}
 		cf.jobProgressedFn = job.HighWaterProgressed
+		cf.pts = cf.flowCtx.ProtectedTimestampProvider
 	}
 
 	cf.metrics.mu.Lock()
@@ -526,9 +534,19 @@ func (cf *changeFrontier) noteResolvedSpan(d sqlbase.EncDatum) error {
 			cf.metrics.mu.resolved[cf.metricsID] = newResolved
 		}
 		cf.metrics.mu.Unlock()
-		if err := checkpointResolvedTimestamp(cf.Ctx, cf.jobProgressedFn, cf.sf); err != nil {
+		// Only forward the protected timestamp periodically to avoid writing to
+		// the protected timestamp records on every checkpoint.
+		var pts protectedts.Storage
+		protectInterval := changefeedProtectTimestampInterval.Get(&cf.flowCtx.Settings.SV)
+		if newResolved.GoTime().Sub(cf.lastProtectedTimestampUpdate.GoTime()) >= protectInterval {
+			pts = cf.pts
+		}
+		if err := checkpointResolvedTimestamp(cf.Ctx, cf.jobProgressedFn, cf.sf, pts); err != nil {
 			return err
 		}
+		if pts != nil {
+			cf.lastProtectedTimestampUpdate = newResolved
+		}
 		sinceEmitted := newResolved.GoTime().Sub(cf.lastEmitResolved)
 		if cf.freqEmitResolved != emitNoResolved && sinceEmitted >= cf.freqEmitResolved {
 			// Keeping this after the checkpointResolvedTimestamp call will avoid
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -29,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -395,6 +397,18 @@ func (b *changefeedResumer) Resume(
 	details := job.Details().(jobspb.ChangefeedDetails)
 	progress := job.Progress()
 
+	// Protect the data the changefeed has yet to emit from garbage collection,
+	// so that the changefeed can be resumed after being paused for longer than
+	// the GC TTL.
+	if cfProgress := progress.GetChangefeed(); cfProgress != nil &&
+		cfProgress.ProtectedTimestampRecord == nil &&
+		phs.ExecCfg().Settings.Version.IsActive(cluster.VersionProtectedTimestamps) {
+		var err error
+		if progress, err = protectTimestampForChangefeed(ctx, phs, job, details, progress); err != nil {
+			return err
+		}
+	}
+
 	// Errors encountered while emitting changes to the Sink may be transient; for
 	// example, a temporary network outage. When one of these errors occurs, we do
 	// not fail the job but rather restart the distSQL flow after a short backoff.
@@ -444,8 +458,65 @@ func (b *changefeedResumer) Resume(
 	return err
 }
 
-func (b *changefeedResumer) OnFailOrCancel(context.Context, *client.Txn, *jobs.Job) error { return nil }
-func (b *changefeedResumer) OnSuccess(context.Context, *client.Txn, *jobs.Job) error      { return nil }
+// protectTimestampForChangefeed creates a protected timestamp record for the
+// spans watched by the changefeed at its high-water mark and stores its ID in
+// the job's progress. It returns the updated progress.
+func protectTimestampForChangefeed(
+	ctx context.Context,
+	phs sql.PlanHookState,
+	job *jobs.Job,
+	details jobspb.ChangefeedDetails,
+	progress jobspb.Progress,
+) (jobspb.Progress, error) {
+	tsToProtect := details.StatementTime
+	if h := progress.GetHighWater(); h != nil && *h != (hlc.Timestamp{}) {
+		tsToProtect = *h
+	}
+	execCfg := phs.ExecCfg()
+	spans, err := fetchSpansForTargets(ctx, execCfg.DB, details.Targets, tsToProtect)
+	if err != nil {
+		return progress, err
+	}
+	id := uuid.MakeV4()
+	rec := jobsprotectedts.MakeRecord(id, *job.ID(), tsToProtect, spans)
+	cfProgress := *progress.GetChangefeed()
+	cfProgress.ProtectedTimestampRecord = &id
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		if err := execCfg.ProtectedTimestampProvider.Protect(ctx, txn, rec); err != nil {
+			return err
+		}
+		return job.WithTxn(txn).SetProgress(ctx, cfProgress)
+	}); err != nil {
+		return progress, err
+	}
+	progress.Details = &jobspb.Progress_Changefeed{Changefeed: &cfProgress}
+	return progress, nil
+}
+
+// releaseProtectedTimestamp releases the protected timestamp record of the
+// changefeed, if any.
+func releaseProtectedTimestamp(ctx context.Context, txn *client.Txn, job *jobs.Job) error {
+	progress := job.Progress()
+	cfProgress := progress.GetChangefeed()
+	if cfProgress == nil || cfProgress.ProtectedTimestampRecord == nil {
+		return nil
+	}
+	phs, cleanup := job.MakePlanHookState("changefeed-release-protected-timestamp")
+	defer cleanup()
+	pts := phs.(sql.PlanHookState).ExecCfg().ProtectedTimestampProvider
+	return jobsprotectedts.Release(ctx, txn, pts, cfProgress.ProtectedTimestampRecord)
+}
+
+func (b *changefeedResumer) OnFailOrCancel(
+	ctx context.Context, txn *client.Txn, job *jobs.Job,
+) error {
+	return releaseProtectedTimestamp(ctx, txn, job)
+}
+
+func (b *changefeedResumer) OnSuccess(ctx context.Context, txn *client.Txn, job *jobs.Job) error {
+	return releaseProtectedTimestamp(ctx, txn, job)
+}
+
 func (b *changefeedResumer) OnTerminal(
 	context.Context, *jobs.Job, jobs.Status, chan<- tree.Datums,
 ) {
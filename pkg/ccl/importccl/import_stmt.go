@@ -693,6 +693,7 @@ func importPlanHook(
 		}
 
 		var tableDescs []*sqlbase.TableDescriptor
+		var typeDescs []*sqlbase.TypeDescriptor
 		var jobDesc string
 		var names []string
 		seqVals := make(map[sqlbase.ID]int64)
@@ -724,7 +725,7 @@ func importPlanHook(
 				tableDescs, err = readMysqlCreateTable(ctx, reader, evalCtx, defaultCSVTableID, parentID, match, fks, seqVals)
 			case roachpb.IOFileFormat_PgDump:
 				evalCtx := &p.ExtendedEvalContext().EvalContext
-				tableDescs, err = readPostgresCreateTable(reader, evalCtx, p.ExecCfg().Settings, match, parentID, walltime, fks, int(format.PgDump.MaxRowSize))
+				tableDescs, typeDescs, err = readPostgresCreateTable(reader, evalCtx, p.ExecCfg().Settings, match, parentID, walltime, fks, int(format.PgDump.MaxRowSize))
 			default:
 				return errors.Errorf("non-bundle format %q does not support reading schemas", format.Format.String())
 			}
@@ -782,6 +783,9 @@ func importPlanHook(
 		}
 
 		if transform != "" {
+			if len(typeDescs) > 0 {
+				return pgerror.Unimplemented("import.transform-types", "user-defined types are not supported with transform")
+			}
 			transformStorage, err := storageccl.ExportStorageFromURI(ctx, transform, p.ExecCfg().Settings)
 			if err != nil {
 				return err
@@ -799,6 +803,12 @@ func importPlanHook(
 					return err
 				}
 			}
+			// Types share the namespace of tables.
+			for _, typeDesc := range typeDescs {
+				if err := backupccl.CheckTableExists(ctx, p.Txn(), parentID, typeDesc.Name); err != nil {
+					return err
+				}
+			}
 			// Verification steps have passed, generate a new table ID if we're
 			// restoring. We do this last because we want to avoid calling
 			// GenerateUniqueDescID if there's any kind of error above.
@@ -817,11 +827,29 @@ func importPlanHook(
 				newSeqVals[id] = seqVals[tableDesc.ID]
 			}
 			seqVals = newSeqVals
+			// The types created by the import get new IDs as well, which the
+			// columns of the tables are updated to reference.
+			for _, typeDesc := range typeDescs {
+				id, err := sql.GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+				if err != nil {
+					return err
+				}
+				tableRewrites[typeDesc.ID] = &jobspb.RestoreDetails_TableRewrite{
+					TableID:  id,
+					ParentID: parentID,
+				}
+				typeDesc.ID = id
+				typeDesc.ParentID = parentID
+			}
 			if err := backupccl.RewriteTableDescs(tableDescs, tableRewrites, ""); err != nil {
 				return err
 			}
 		}
 
+		typeDetails := make([]sqlbase.TypeDescriptor, len(typeDescs))
+		for i := range typeDescs {
+			typeDetails[i] = *typeDescs[i]
+		}
 		tableDetails := make([]jobspb.ImportDetails_Table, 0, len(tableDescs))
 		for _, tbl := range tableDescs {
 			tableDetails = append(tableDetails, jobspb.ImportDetails_Table{Desc: tbl, SeqVal: seqVals[tbl.ID]})
@@ -840,6 +868,7 @@ func importPlanHook(
 				Format:     format,
 				ParentID:   parentID,
 				Tables:     tableDetails,
+				Types:      typeDetails,
 				BackupPath: transform,
 				SSTSize:    sstSize,
 				Oversample: oversample,
@@ -1118,10 +1147,17 @@ func (r *importResumer) OnSuccess(ctx context.Context, txn *client.Txn, job *job
 		}
 	}
 
+	// The types created by the import are written along with the tables.
+	typs := make([]*sqlbase.TypeDescriptor, len(details.Types))
+	for i := range details.Types {
+		typs[i] = &details.Types[i]
+		typs[i].ParentID = details.ParentID
+	}
+
 	// Write the new TableDescriptors and flip the namespace entries over to
 	// them. After this call, any queries on a table will be served by the newly
 	// imported data.
-	if err := backupccl.WriteTableDescs(ctx, txn, nil, toWrite, job.Payload().Username, r.settings, seqs); err != nil {
+	if err := backupccl.WriteTableDescs(ctx, txn, nil, nil, typs, nil, toWrite, job.Payload().Username, r.settings, seqs); err != nil {
 		return errors.Wrapf(err, "creating tables")
 	}
 
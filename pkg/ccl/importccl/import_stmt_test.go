@@ -568,6 +568,44 @@ COPY t (a, b, c) FROM stdin;
 				`SHOW CREATE SEQUENCE i_seq`: {{"i_seq", "CREATE SEQUENCE i_seq MINVALUE 1 MAXVALUE 9223372036854775807 INCREMENT 1 START 1"}},
 			},
 		},
+		{
+			name: "enum",
+			typ:  "PGDUMP",
+			data: `
+				CREATE TYPE public.mood AS ENUM (
+				    'sad',
+				    'ok',
+				    'happy'
+				);
+				CREATE TYPE public.unused AS ENUM ('a');
+				CREATE TABLE public.t (
+				    i integer PRIMARY KEY,
+				    m public.mood DEFAULT 'ok'::public.mood
+				);
+				COPY public.t (i, m) FROM stdin;
+1	happy
+2	sad
+3	\N
+\.
+				INSERT INTO public.t VALUES (4, 'ok');
+			`,
+			query: map[string][][]string{
+				`SELECT i, m FROM t ORDER BY m, i`: {{"3", "NULL"}, {"2", "sad"}, {"4", "ok"}, {"1", "happy"}},
+				`SELECT enumlabel FROM pg_catalog.pg_enum ORDER BY enumsortorder`: {
+					{"sad"}, {"ok"}, {"happy"},
+				},
+				`SELECT typname FROM pg_catalog.pg_type WHERE typtype = 'e'`: {{"mood"}},
+				`SELECT column_default FROM information_schema.columns WHERE table_name = 't' AND column_name = 'm'`: {
+					{"'ok'"},
+				},
+			},
+		},
+		{
+			name: "enum undefined",
+			typ:  "PGDUMP",
+			data: "create table t (m public.mood)",
+			err:  `type "public.mood" does not exist`,
+		},
 		{
 			name: "non-public schema",
 			typ:  "PGDUMP",
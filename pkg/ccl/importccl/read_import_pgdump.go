@@ -203,8 +203,82 @@ func removeDefaultRegclass(create *tree.CreateTable) {
 	}
 }
 
+// userDefinedTypeResolver resolves the references to user-defined types in
+// column definitions using the types created earlier in the same dump.
+type userDefinedTypeResolver struct {
+	typs map[string]*sqlbase.TypeDescriptor
+	err  error
+}
+
+var _ tree.Visitor = &userDefinedTypeResolver{}
+
+func (r *userDefinedTypeResolver) resolve(
+	t coltypes.CastTargetType,
+) (coltypes.CastTargetType, error) {
+	ref, ok := t.(*coltypes.TUserDefined)
+	if !ok {
+		return t, nil
+	}
+	if ref.Schema != "" && ref.Schema != "public" {
+		return nil, pgerror.Unimplemented(
+			"import non-public schema",
+			fmt.Sprintf("non-public schemas unsupported: %s", ref.Schema),
+		)
+	}
+	desc := r.typs[ref.Name]
+	if desc == nil {
+		return nil, tree.NewUndefinedTypeError(ref)
+	}
+	return &coltypes.TUserDefined{Schema: ref.Schema, Name: ref.Name, Typ: desc.EnumType()}, nil
+}
+
+func (r *userDefinedTypeResolver) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if r.err != nil {
+		return false, expr
+	}
+	// As in regclassRewriter, the statement is modified directly.
+	switch t := expr.(type) {
+	case *tree.CastExpr:
+		t.Type, r.err = r.resolve(t.Type)
+	case *tree.AnnotateTypeExpr:
+		t.Type, r.err = r.resolve(t.Type)
+	}
+	return r.err == nil, expr
+}
+
+func (*userDefinedTypeResolver) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// resolveUserDefinedTypes resolves the user-defined types of the columns of
+// the table and the casts to user-defined types in their DEFAULT expressions.
+// MakeSimpleTableDescriptor does not have access to the types, so they must
+// be resolved beforehand.
+func resolveUserDefinedTypes(
+	create *tree.CreateTable, typs map[string]*sqlbase.TypeDescriptor,
+) error {
+	r := &userDefinedTypeResolver{typs: typs}
+	for _, def := range create.Defs {
+		def, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			continue
+		}
+		typ, err := r.resolve(def.Type)
+		if err != nil {
+			return err
+		}
+		def.Type = typ.(coltypes.T)
+		if def.DefaultExpr.Expr != nil {
+			def.DefaultExpr.Expr, _ = tree.WalkExpr(r, def.DefaultExpr.Expr)
+			if r.err != nil {
+				return r.err
+			}
+		}
+	}
+	return nil
+}
+
 // readPostgresCreateTable returns table descriptors for all tables or the
-// matching table from SQL statements.
+// matching table from SQL statements, as well as descriptors for the
+// user-defined types they reference.
 func readPostgresCreateTable(
 	input io.Reader,
 	evalCtx *tree.EvalContext,
@@ -214,7 +288,7 @@ func readPostgresCreateTable(
 	walltime int64,
 	fks fkHandler,
 	max int,
-) ([]*sqlbase.TableDescriptor, error) {
+) ([]*sqlbase.TableDescriptor, []*sqlbase.TypeDescriptor, error) {
 	// Modify the CreateTable stmt with the various index additions. We do this
 	// instead of creating a full table descriptor first and adding indexes
 	// later because MakeSimpleTableDescriptor calls the sql package which calls
@@ -224,13 +298,17 @@ func readPostgresCreateTable(
 	createTbl := make(map[string]*tree.CreateTable)
 	createSeq := make(map[string]*tree.CreateSequence)
 	tableFKs := make(map[string][]*tree.ForeignKeyConstraintTableDef)
+	// Types are created when their CREATE TYPE statement is read, with
+	// temporary IDs. The tables are given temporary IDs following them.
+	typs := make(map[string]*sqlbase.TypeDescriptor)
+	var typDescs []*sqlbase.TypeDescriptor
 	ps := newPostgreStream(input, max)
 	for {
 		stmt, err := ps.Next()
 		if err == io.EOF {
 			ret := make([]*sqlbase.TableDescriptor, 0, len(createTbl))
 			for name, seq := range createSeq {
-				id := sqlbase.ID(int(defaultCSVTableID) + len(ret))
+				id := sqlbase.ID(int(defaultCSVTableID) + len(typDescs) + len(ret))
 				desc, err := sql.MakeSequenceTableDesc(
 					name,
 					seq.Options,
@@ -241,7 +319,7 @@ func readPostgresCreateTable(
 					settings,
 				)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				fks.resolver[desc.Name] = &desc
 				ret = append(ret, desc.TableDesc())
@@ -252,10 +330,13 @@ func readPostgresCreateTable(
 					continue
 				}
 				removeDefaultRegclass(create)
-				id := sqlbase.ID(int(defaultCSVTableID) + len(ret))
+				if err := resolveUserDefinedTypes(create, typs); err != nil {
+					return nil, nil, err
+				}
+				id := sqlbase.ID(int(defaultCSVTableID) + len(typDescs) + len(ret))
 				desc, err := MakeSimpleTableDescriptor(evalCtx.Ctx(), settings, create, parentID, id, fks, walltime)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				fks.resolver[desc.Name] = desc
 				backrefs[desc.ID] = desc
@@ -268,11 +349,11 @@ func readPostgresCreateTable(
 				}
 				for _, constraint := range constraints {
 					if err := sql.ResolveFK(evalCtx.Ctx(), nil /* txn */, fks.resolver, desc, constraint, backrefs, sql.NewTable); err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 				if err := fixDescriptorFKState(desc.TableDesc()); err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 			}
 			if match != "" && len(ret) != 1 {
@@ -280,24 +361,24 @@ func readPostgresCreateTable(
 				for name := range createTbl {
 					found = append(found, name)
 				}
-				return nil, errors.Errorf("table %q not found in file (found tables: %s)", match, strings.Join(found, ", "))
+				return nil, nil, errors.Errorf("table %q not found in file (found tables: %s)", match, strings.Join(found, ", "))
 			}
 			if len(ret) == 0 {
-				return nil, errors.Errorf("no table definition found")
+				return nil, nil, errors.Errorf("no table definition found")
 			}
-			return ret, nil
+			return ret, referencedTypes(ret, typDescs), nil
 		}
 		if err != nil {
 			if pg, ok := pgerror.GetPGCause(err); ok {
-				return nil, errors.Errorf("%s\n%s", pg.Message, pg.Detail)
+				return nil, nil, errors.Errorf("%s\n%s", pg.Message, pg.Detail)
 			}
-			return nil, errors.Wrap(err, "postgres parse error")
+			return nil, nil, errors.Wrap(err, "postgres parse error")
 		}
 		switch stmt := stmt.(type) {
 		case *tree.CreateTable:
 			name, err := getTableName(&stmt.Table)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			if match != "" && match != name {
 				createTbl[name] = nil
@@ -307,7 +388,7 @@ func readPostgresCreateTable(
 		case *tree.CreateIndex:
 			name, err := getTableName(&stmt.Table)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			create := createTbl[name]
 			if create == nil {
@@ -328,7 +409,7 @@ func readPostgresCreateTable(
 		case *tree.AlterTable:
 			name, err := getTableName(&stmt.Table)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			create := createTbl[name]
 			if create == nil {
@@ -357,19 +438,59 @@ func readPostgresCreateTable(
 				case *tree.AlterTableValidateConstraint:
 					// ignore
 				default:
-					return nil, errors.Errorf("unsupported statement: %s", stmt)
+					return nil, nil, errors.Errorf("unsupported statement: %s", stmt)
 				}
 			}
 		case *tree.CreateSequence:
 			name, err := getTableName(&stmt.Name)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			if match == "" || match == name {
 				createSeq[name] = stmt
 			}
+		case *tree.CreateType:
+			if stmt.Variety != tree.Enum {
+				return nil, nil, pgerror.Unimplemented("import.create-type", "unsupported statement: %s", stmt)
+			}
+			name, err := getTableName(&stmt.TypeName)
+			if err != nil {
+				return nil, nil, err
+			}
+			if typs[name] != nil {
+				return nil, nil, sqlbase.NewTypeAlreadyExistsError(name)
+			}
+			id := sqlbase.ID(int(defaultCSVTableID) + len(typDescs))
+			desc, err := sql.MakeEnumTypeDesc(name, stmt.EnumLabels, parentID, id, sqlbase.NewDefaultPrivilegeDescriptor())
+			if err != nil {
+				return nil, nil, err
+			}
+			typs[name] = &desc
+			typDescs = append(typDescs, &desc)
+		}
+	}
+}
+
+// referencedTypes returns the types which are referenced by the columns of
+// the given tables.
+func referencedTypes(
+	tables []*sqlbase.TableDescriptor, typs []*sqlbase.TypeDescriptor,
+) []*sqlbase.TypeDescriptor {
+	referenced := make(map[sqlbase.ID]bool)
+	for _, table := range tables {
+		for i := range table.Columns {
+			if col := &table.Columns[i]; col.Type.SemanticType == sqlbase.ColumnType_ENUM {
+				referenced[col.Type.EnumMetadata.TypeID] = true
+			}
+		}
+	}
+	var ret []*sqlbase.TypeDescriptor
+	for _, typ := range typs {
+		if referenced[typ.ID] {
+			ret = append(ret, typ)
 		}
 	}
+	return ret
 }
 
 func getTableName(tn *tree.TableName) (string, error) {
@@ -2335,6 +2335,7 @@ writing ` + os.DevNull + `
   debug/nodes/1/ranges/18
   debug/nodes/1/ranges/19
   debug/nodes/1/ranges/20
+  debug/nodes/1/ranges/21
   debug/reports/problemranges
   debug/schema/defaultdb@details
   debug/schema/postgres@details
@@ -2346,6 +2347,7 @@ writing ` + os.DevNull + `
   debug/schema/system/lease
   debug/schema/system/locations
   debug/schema/system/namespace
+  debug/schema/system/protected_ts_records
   debug/schema/system/rangelog
   debug/schema/system/role_members
   debug/schema/system/settings
@@ -533,12 +533,15 @@ func runDebugGCCmd(cmd *cobra.Command, args []string) error {
 	for _, desc := range descs {
 		snap := db.NewSnapshot()
 		defer snap.Close()
+		now := hlc.Timestamp{WallTime: timeutil.Now().UnixNano()}
+		policy := config.GCPolicy{TTLSeconds: int32(gcTTLInSeconds)}
 		info, err := storage.RunGC(
 			context.Background(),
 			&desc,
 			snap,
-			hlc.Timestamp{WallTime: timeutil.Now().UnixNano()},
-			config.GCPolicy{TTLSeconds: int32(gcTTLInSeconds)},
+			now,
+			engine.CalculateThreshold(now, policy),
+			policy,
 			storage.NoopGCer{},
 			func(_ context.Context, _ []roachpb.Intent) error { return nil },
 			func(_ context.Context, _ *roachpb.Transaction, _ []roachpb.Intent) error { return nil },
@@ -114,13 +114,23 @@ func tryRaftLogEntry(kv engine.MVCCKeyValue) (string, error) {
 			return fmt.Sprintf("%s by %s\n%s\n", &ent, leaseStr, &cmd), nil
 		}
 		return fmt.Sprintf("%s: EMPTY\n", &ent), nil
-	} else if ent.Type == raftpb.EntryConfChange {
-		var cc raftpb.ConfChange
-		if err := protoutil.Unmarshal(ent.Data, &cc); err != nil {
-			return "", err
+	} else if ent.Type == raftpb.EntryConfChange || ent.Type == raftpb.EntryConfChangeV2 {
+		var encodedCtx []byte
+		if ent.Type == raftpb.EntryConfChange {
+			var cc raftpb.ConfChange
+			if err := protoutil.Unmarshal(ent.Data, &cc); err != nil {
+				return "", err
+			}
+			encodedCtx = cc.Context
+		} else {
+			var cc raftpb.ConfChangeV2
+			if err := protoutil.Unmarshal(ent.Data, &cc); err != nil {
+				return "", err
+			}
+			encodedCtx = cc.Context
 		}
 		var ctx storage.ConfChangeContext
-		if err := protoutil.Unmarshal(cc.Context, &ctx); err != nil {
+		if err := protoutil.Unmarshal(encodedCtx, &ctx); err != nil {
 			return "", err
 		}
 		var cmd storagepb.ReplicatedEvalResult
@@ -635,6 +635,16 @@ func dumpTableData(w io.Writer, conn *sqlConn, clusterTS string, bmd basicMetada
 						if err != nil {
 							return err
 						}
+					case coltypes.TSVector:
+						d, err = tree.ParseDTSVector(string(t))
+						if err != nil {
+							return err
+						}
+					case coltypes.TSQuery:
+						d, err = tree.ParseDTSQuery(string(t))
+						if err != nil {
+							return err
+						}
 					default:
 						// STRING and DECIMAL types can have optional length
 						// suffixes, so only examine the prefix of the type.
@@ -653,20 +663,30 @@ func dumpTableData(w io.Writer, conn *sqlConn, clusterTS string, bmd basicMetada
 							if err != nil {
 								return err
 							}
+						} else if _, ok := ct.(*coltypes.TInterval); ok {
+							// INTERVAL can have an optional precision suffix.
+							d, err = tree.ParseDInterval(string(t))
+							if err != nil {
+								return err
+							}
 						} else {
 							return errors.Errorf("unknown []byte type: %s, %v: %s", t, cols[si], md.columnTypes[cols[si]])
 						}
 					}
 				case time.Time:
-					switch ct := md.columnTypes[cols[si]]; ct {
-					case coltypes.Date:
+					// The time types can have optional precision suffixes, so
+					// only examine the kind of the type.
+					switch md.columnTypes[cols[si]].(type) {
+					case *coltypes.TDate:
 						d = tree.NewDDateFromTime(t, time.UTC)
-					case coltypes.Time:
+					case *coltypes.TTime:
 						// pq awkwardly represents TIME as a time.Time with date 0000-01-01.
 						d = tree.MakeDTime(timeofday.FromTime(t))
-					case coltypes.Timestamp:
+					case *coltypes.TTimeTZ:
+						d = tree.MakeDTimeTZFromTime(t)
+					case *coltypes.TTimestamp:
 						d = tree.MakeDTimestamp(t, time.Nanosecond)
-					case coltypes.TimestampWithTZ:
+					case *coltypes.TTimestampTZ:
 						d = tree.MakeDTimestampTZ(t, time.Nanosecond)
 					default:
 						return errors.Errorf("unknown timestamp type: %s, %v: %s", t, cols[si], md.columnTypes[cols[si]])
@@ -271,6 +271,8 @@ func linkTypeName(s string) string {
 	switch s {
 	case "timestamptz":
 		s = "timestamp"
+	case "timetz":
+		s = "time"
 	}
 	s = strings.TrimSuffix(s, "[]")
 	switch s {
@@ -387,6 +387,31 @@ func (z *ZoneConfig) IsComplete() bool {
 		(!z.InheritedConstraints) && (!z.InheritedLeasePreferences))
 }
 
+// GetNumVoters returns the number of voting replicas desired by the zone. If
+// num_voters is not set, all replicas are voters.
+func (z *ZoneConfig) GetNumVoters() int32 {
+	if z.NumVoters != nil {
+		return *z.NumVoters
+	}
+	return *z.NumReplicas
+}
+
+// GetNumNonVoters returns the number of non-voting replicas desired by the
+// zone.
+func (z *ZoneConfig) GetNumNonVoters() int32 {
+	return *z.NumReplicas - z.GetNumVoters()
+}
+
+// GetVoterConstraints returns the constraints that apply to the voting
+// replicas of the zone. If no voter_constraints are set, the zone's
+// constraints apply to voters.
+func (z *ZoneConfig) GetVoterConstraints() []Constraints {
+	if z.NumVoters != nil && len(z.VoterConstraints) > 0 {
+		return z.VoterConstraints
+	}
+	return z.Constraints
+}
+
 // ValidateTandemFields returns an error if the ZoneConfig to be written
 // specifies a configuration that could cause problems with the introduction
 // of cascading zone configs.
@@ -399,6 +424,12 @@ func (z *ZoneConfig) ValidateTandemFields() error {
 	if numConstrainedRepls > 0 && z.NumReplicas == nil {
 		return fmt.Errorf("when per-replica constraints are set, num_replicas must be set as well")
 	}
+	if z.NumVoters != nil && z.NumReplicas == nil {
+		return fmt.Errorf("when num_voters is set, num_replicas must be set as well")
+	}
+	if len(z.VoterConstraints) > 0 && z.NumVoters == nil {
+		return fmt.Errorf("when voter_constraints are set, num_voters must be set as well")
+	}
 	if (z.RangeMinBytes != nil || z.RangeMaxBytes != nil) &&
 		(z.RangeMinBytes == nil || z.RangeMaxBytes == nil) {
 		return fmt.Errorf("range_min_bytes and range_max_bytes must be set together")
@@ -453,7 +484,45 @@ func (z *ZoneConfig) Validate() error {
 		return fmt.Errorf("GC.TTLSeconds %d less than minimum allowed 1", z.GC.TTLSeconds)
 	}
 
-	for _, constraints := range z.Constraints {
+	if z.NumVoters != nil {
+		switch {
+		case *z.NumVoters <= 0:
+			return fmt.Errorf("at least one voting replica is required")
+		case *z.NumVoters == 2:
+			return fmt.Errorf("at least 3 voting replicas are required for multi-replica configurations")
+		case z.NumReplicas != nil && *z.NumVoters > *z.NumReplicas:
+			return fmt.Errorf("num_voters (%d) cannot be greater than num_replicas (%d)",
+				*z.NumVoters, *z.NumReplicas)
+		}
+	}
+
+	if err := validateConstraints(z.Constraints, z.NumReplicas, "replicas"); err != nil {
+		return err
+	}
+	if err := validateConstraints(z.VoterConstraints, z.NumVoters, "voting replicas"); err != nil {
+		return err
+	}
+
+	for _, leasePref := range z.LeasePreferences {
+		if len(leasePref.Constraints) == 0 {
+			return fmt.Errorf("every lease preference must include at least one constraint")
+		}
+		for _, constraint := range leasePref.Constraints {
+			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
+				return fmt.Errorf("lease preference constraints must either be required " +
+					"(prefixed with a '+') or prohibited (prefixed with a '-')")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateConstraints validates a list of constraints that apply to numReplicas
+// replicas of a range. replicaKind is used to describe those replicas in error
+// messages.
+func validateConstraints(constraintsList []Constraints, numReplicas *int32, replicaKind string) error {
+	for _, constraints := range constraintsList {
 		for _, constraint := range constraints.Constraints {
 			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
 				return fmt.Errorf("constraints must either be required (prefixed with a '+') or " +
@@ -465,9 +534,9 @@ func (z *ZoneConfig) Validate() error {
 	// We only need to further validate constraints if per-replica constraints
 	// are in use. The old style of constraints that apply to all replicas don't
 	// require validation.
-	if len(z.Constraints) > 1 || (len(z.Constraints) == 1 && z.Constraints[0].NumReplicas != 0) {
+	if len(constraintsList) > 1 || (len(constraintsList) == 1 && constraintsList[0].NumReplicas != 0) {
 		var numConstrainedRepls int64
-		for _, constraints := range z.Constraints {
+		for _, constraints := range constraintsList {
 			if constraints.NumReplicas <= 0 {
 				return fmt.Errorf("constraints must apply to at least one replica")
 			}
@@ -475,31 +544,18 @@ func (z *ZoneConfig) Validate() error {
 			for _, constraint := range constraints.Constraints {
 				// TODO(a-robinson): Relax this constraint to allow prohibited replicas,
 				// as discussed on #23014.
-				if constraint.Type != Constraint_REQUIRED && z.NumReplicas != nil && constraints.NumReplicas != *z.NumReplicas {
+				if constraint.Type != Constraint_REQUIRED && numReplicas != nil && constraints.NumReplicas != *numReplicas {
 					return fmt.Errorf(
 						"only required constraints (prefixed with a '+') can be applied to a subset of replicas")
 				}
 			}
 		}
-		if z.NumReplicas != nil && numConstrainedRepls > int64(*z.NumReplicas) {
-			return fmt.Errorf("the number of replicas specified in constraints (%d) cannot be greater "+
-				"than the number of replicas configured for the zone (%d)",
-				numConstrainedRepls, *z.NumReplicas)
+		if numReplicas != nil && numConstrainedRepls > int64(*numReplicas) {
+			return fmt.Errorf("the number of %[1]s specified in constraints (%[2]d) cannot be greater "+
+				"than the number of %[1]s configured for the zone (%[3]d)",
+				replicaKind, numConstrainedRepls, *numReplicas)
 		}
 	}
-
-	for _, leasePref := range z.LeasePreferences {
-		if len(leasePref.Constraints) == 0 {
-			return fmt.Errorf("every lease preference must include at least one constraint")
-		}
-		for _, constraint := range leasePref.Constraints {
-			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
-				return fmt.Errorf("lease preference constraints must either be required " +
-					"(prefixed with a '+') or prohibited (prefixed with a '-')")
-			}
-		}
-	}
-
 	return nil
 }
 
@@ -510,6 +566,12 @@ func (z *ZoneConfig) InheritFromParent(parent ZoneConfig) {
 			z.NumReplicas = proto.Int32(*parent.NumReplicas)
 		}
 	}
+	if z.NumVoters == nil {
+		if parent.NumVoters != nil {
+			z.NumVoters = proto.Int32(*parent.NumVoters)
+			z.VoterConstraints = parent.VoterConstraints
+		}
+	}
 	if z.RangeMinBytes == nil {
 		if parent.RangeMinBytes != nil {
 			z.RangeMinBytes = proto.Int64(*parent.RangeMinBytes)
@@ -549,6 +611,15 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 				z.NumReplicas = proto.Int32(*other.NumReplicas)
 			}
 		}
+		if fieldName == "num_voters" {
+			z.NumVoters = nil
+			if other.NumVoters != nil {
+				z.NumVoters = proto.Int32(*other.NumVoters)
+			}
+		}
+		if fieldName == "voter_constraints" {
+			z.VoterConstraints = other.VoterConstraints
+		}
 		if fieldName == "range_min_bytes" {
 			z.RangeMinBytes = nil
 			if other.RangeMinBytes != nil {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/testutils"
@@ -235,6 +236,54 @@ func TestZoneConfigValidate(t *testing.T) {
 			},
 			"",
 		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				NumVoters:   proto.Int32(0),
+			},
+			"at least one voting replica is required",
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				NumVoters:   proto.Int32(2),
+			},
+			"at least 3 voting replicas are required for multi-replica configurations",
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(3),
+				NumVoters:   proto.Int32(5),
+			},
+			`num_voters \(5\) cannot be greater than num_replicas \(3\)`,
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				NumVoters:   proto.Int32(3),
+				VoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Key: "region", Value: "a", Type: Constraint_REQUIRED}},
+						NumReplicas: 4,
+					},
+				},
+			},
+			`the number of voting replicas specified in constraints \(4\) cannot be greater ` +
+				`than the number of voting replicas configured for the zone \(3\)`,
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				NumVoters:   proto.Int32(3),
+				VoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Key: "region", Value: "a", Type: Constraint_REQUIRED}},
+						NumReplicas: 3,
+					},
+				},
+			},
+			"",
+		},
 	}
 
 	for i, c := range testCases {
@@ -287,6 +336,23 @@ func TestZoneConfigValidateTandemFields(t *testing.T) {
 			},
 			"lease preferences can not be set unless the constraints are explicitly set as well",
 		},
+		{
+			ZoneConfig{
+				NumVoters: proto.Int32(3),
+			},
+			"when num_voters is set, num_replicas must be set as well",
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				VoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Value: "a", Type: Constraint_REQUIRED}},
+					},
+				},
+			},
+			"when voter_constraints are set, num_voters must be set as well",
+		},
 	}
 
 	for i, c := range testCases {
@@ -737,6 +803,63 @@ func TestExperimentalLeasePreferencesYAML(t *testing.T) {
 	}
 }
 
+func TestNonVotingReplicasYAML(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	original := ZoneConfig{
+		RangeMinBytes: proto.Int64(1),
+		RangeMaxBytes: proto.Int64(1),
+		GC: &GCPolicy{
+			TTLSeconds: 1,
+		},
+		NumReplicas: proto.Int32(5),
+		NumVoters:   proto.Int32(3),
+		Constraints: []Constraints{
+			{Constraints: []Constraint{{Key: "a", Value: "b", Type: Constraint_REQUIRED}}},
+		},
+		VoterConstraints: []Constraints{
+			{Constraints: []Constraint{{Key: "region", Value: "us", Type: Constraint_REQUIRED}}},
+		},
+	}
+	expected := `range_min_bytes: 1
+range_max_bytes: 1
+gc:
+  ttlseconds: 1
+num_replicas: 5
+constraints: [+a=b]
+num_voters: 3
+voter_constraints: [+region=us]
+lease_preferences: []
+`
+
+	body, err := yaml.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != expected {
+		t.Fatalf("yaml.Marshal(%+v)\ngot:\n%s\nwant:\n%s", original, body, expected)
+	}
+
+	var unmarshaled ZoneConfig
+	if err := yaml.UnmarshalStrict(body, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(&unmarshaled, &original) {
+		t.Errorf("yaml.Unmarshal(%q)\ngot:\n%+v\nwant:\n%+v", body, unmarshaled, original)
+	}
+
+	// A zone without num_voters does not mention the non-voting fields at all.
+	original.NumVoters = nil
+	original.VoterConstraints = nil
+	body, err = yaml.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "voter") {
+		t.Errorf("unexpected voter fields in yaml:\n%s", body)
+	}
+}
+
 func TestConstraintsListYAML(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -206,6 +206,8 @@ type marshalableZoneConfig struct {
 	GC                           *GCPolicy         `json:"gc"`
 	NumReplicas                  *int32            `json:"num_replicas" yaml:"num_replicas"`
 	Constraints                  ConstraintsList   `json:"constraints" yaml:"constraints,flow"`
+	NumVoters                    *int32            `json:"num_voters" yaml:"num_voters,omitempty"`
+	VoterConstraints             ConstraintsList   `json:"voter_constraints" yaml:"voter_constraints,flow,omitempty"`
 	LeasePreferences             []LeasePreference `json:"lease_preferences" yaml:"lease_preferences,flow"`
 	ExperimentalLeasePreferences []LeasePreference `json:"experimental_lease_preferences" yaml:"experimental_lease_preferences,flow,omitempty"`
 	Subzones                     []Subzone         `json:"subzones" yaml:"-"`
@@ -228,6 +230,10 @@ func zoneConfigToMarshalable(c ZoneConfig) marshalableZoneConfig {
 		m.NumReplicas = proto.Int32(*c.NumReplicas)
 	}
 	m.Constraints = ConstraintsList{c.Constraints, c.InheritedConstraints}
+	if c.NumVoters != nil {
+		m.NumVoters = proto.Int32(*c.NumVoters)
+		m.VoterConstraints = ConstraintsList{c.VoterConstraints, false}
+	}
 	if !c.InheritedLeasePreferences {
 		m.LeasePreferences = c.LeasePreferences
 	}
@@ -257,6 +263,10 @@ func zoneConfigFromMarshalable(m marshalableZoneConfig, c ZoneConfig) ZoneConfig
 	}
 	c.Constraints = m.Constraints.Constraints
 	c.InheritedConstraints = m.Constraints.Inherited
+	if m.NumVoters != nil {
+		c.NumVoters = proto.Int32(*m.NumVoters)
+	}
+	c.VoterConstraints = m.VoterConstraints.Constraints
 	if m.LeasePreferences != nil {
 		c.LeasePreferences = m.LeasePreferences
 	}
@@ -617,9 +617,7 @@ func (b *Batch) adminTransferLease(key interface{}, target roachpb.StoreID) {
 
 // adminChangeReplicas is only exported on DB. It is here for symmetry with the
 // other operations.
-func (b *Batch) adminChangeReplicas(
-	key interface{}, changeType roachpb.ReplicaChangeType, targets []roachpb.ReplicationTarget,
-) {
+func (b *Batch) adminChangeReplicas(key interface{}, chgs []roachpb.ReplicationChange) {
 	k, err := marshalKey(key)
 	if err != nil {
 		b.initResult(0, 0, notRaw, err)
@@ -629,8 +627,20 @@ func (b *Batch) adminChangeReplicas(
 		RequestHeader: roachpb.RequestHeader{
 			Key: k,
 		},
-		ChangeType: changeType,
-		Targets:    targets,
+	}
+	// Changes of a single type are sent using the fields understood by nodes
+	// which predate atomic replication changes.
+	sameType := true
+	for _, chg := range chgs {
+		sameType = sameType && chg.ChangeType == chgs[0].ChangeType
+	}
+	if sameType && len(chgs) > 0 {
+		req.ChangeType = chgs[0].ChangeType
+		for _, chg := range chgs {
+			req.Targets = append(req.Targets, chg.Target)
+		}
+	} else {
+		req.InternalChanges = chgs
 	}
 	b.appendReqs(req)
 	b.initResult(1, 0, notRaw, nil)
@@ -493,15 +493,13 @@ func (db *DB) AdminTransferLease(
 	return getOneErr(db.Run(ctx, b), b)
 }
 
-// AdminChangeReplicas adds or removes a set of replicas for a range.
+// AdminChangeReplicas adds or removes a set of replicas for a range. Changes
+// of different types are carried out atomically.
 func (db *DB) AdminChangeReplicas(
-	ctx context.Context,
-	key interface{},
-	changeType roachpb.ReplicaChangeType,
-	targets []roachpb.ReplicationTarget,
+	ctx context.Context, key interface{}, chgs []roachpb.ReplicationChange,
 ) error {
 	b := &Batch{}
-	b.adminChangeReplicas(key, changeType, targets)
+	b.adminChangeReplicas(key, chgs)
 	return getOneErr(db.Run(ctx, b), b)
 }
 
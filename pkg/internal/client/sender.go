@@ -215,8 +215,31 @@ type TxnSender interface {
 	// However, this is used by DistSQL for sending the transaction over the wire
 	// when it creates flows.
 	SerializeTxn() *roachpb.Transaction
+
+	// CreateSavepoint establishes a savepoint in the transaction. The writes
+	// performed after this call can later be undone by passing the returned
+	// token to RollbackToSavepoint.
+	//
+	// Savepoints are only supported on root transactions, and they don't
+	// survive an epoch increment.
+	CreateSavepoint(context.Context) (SavepointToken, error)
+
+	// RollbackToSavepoint undoes all the writes performed by the transaction
+	// since the savepoint was created. The savepoint remains valid and can be
+	// rolled back to again.
+	RollbackToSavepoint(context.Context, SavepointToken) error
+
+	// ReleaseSavepoint releases a savepoint. The writes performed since the
+	// savepoint was created become part of the enclosing savepoint, or of the
+	// transaction itself.
+	ReleaseSavepoint(context.Context, SavepointToken) error
 }
 
+// SavepointToken represents a savepoint established by
+// TxnSender.CreateSavepoint. It is opaque to clients and can only be used with
+// the TxnSender that created it.
+type SavepointToken interface{}
+
 // TxnStatusOpt represents options for TxnSender.GetMeta().
 type TxnStatusOpt int
 
@@ -380,6 +403,21 @@ func (m *MockTransactionalSender) DisablePipelining() error { return nil }
 // EagerRecord is part of the client.TxnSender interface.
 func (m *MockTransactionalSender) EagerRecord() error { return nil }
 
+// CreateSavepoint is part of the client.TxnSender interface.
+func (m *MockTransactionalSender) CreateSavepoint(context.Context) (SavepointToken, error) {
+	panic("unimplemented")
+}
+
+// RollbackToSavepoint is part of the client.TxnSender interface.
+func (m *MockTransactionalSender) RollbackToSavepoint(context.Context, SavepointToken) error {
+	panic("unimplemented")
+}
+
+// ReleaseSavepoint is part of the client.TxnSender interface.
+func (m *MockTransactionalSender) ReleaseSavepoint(context.Context, SavepointToken) error {
+	panic("unimplemented")
+}
+
 // MockTxnSenderFactory is a TxnSenderFactory producing MockTxnSenders.
 type MockTxnSenderFactory struct {
 	senderFunc func(context.Context, *roachpb.Transaction, roachpb.BatchRequest) (
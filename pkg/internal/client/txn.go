@@ -305,6 +305,41 @@ func (txn *Txn) EagerRecord() error {
 	return txn.mu.sender.EagerRecord()
 }
 
+// CreateSavepoint establishes a savepoint. The writes performed by the
+// transaction after this call can be undone by RollbackToSavepoint.
+func (txn *Txn) CreateSavepoint(ctx context.Context) (SavepointToken, error) {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.CreateSavepoint(ctx)
+}
+
+// RollbackToSavepoint undoes the writes performed by the transaction since
+// the given savepoint was established.
+//
+// The rollback needs to communicate with the nodes holding the transaction's
+// intents. If it fails with a retryable error, the transaction is prepared
+// for a retry just like it would be by Send.
+func (txn *Txn) RollbackToSavepoint(ctx context.Context, s SavepointToken) error {
+	txn.mu.Lock()
+	sender := txn.mu.sender
+	txn.mu.Unlock()
+	err := sender.RollbackToSavepoint(ctx, s)
+	if retryErr, ok := err.(*roachpb.TransactionRetryWithProtoRefreshError); ok {
+		txn.mu.Lock()
+		txn.handleErrIfRetryableLocked(ctx, retryErr)
+		txn.mu.Unlock()
+	}
+	return err
+}
+
+// ReleaseSavepoint releases the given savepoint, keeping the writes performed
+// since it was established.
+func (txn *Txn) ReleaseSavepoint(ctx context.Context, s SavepointToken) error {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.ReleaseSavepoint(ctx, s)
+}
+
 // NewBatch creates and returns a new empty batch object for use with the Txn.
 func (txn *Txn) NewBatch() *Batch {
 	return &Batch{txn: txn}
@@ -213,8 +213,12 @@ func FractionUpdater(f float32) FractionProgressedFn {
 
 // HighWaterProgressedFn is a callback that computes a job's high-water mark
 // given its details. It is safe to modify details in the callback; those
-// modifications will be automatically persisted to the database record.
-type HighWaterProgressedFn func(ctx context.Context, details jobspb.ProgressDetails) hlc.Timestamp
+// modifications will be automatically persisted to the database record. The
+// callback runs in the transaction which updates the job, which allows it to
+// make other changes atomically with the progress update.
+type HighWaterProgressedFn func(
+	ctx context.Context, txn *client.Txn, details jobspb.ProgressDetails,
+) (hlc.Timestamp, error)
 
 // FractionProgressed updates the progress of the tracked job. It sets the job's
 // FractionCompleted field to the value returned by progressedFn and persists
@@ -270,11 +274,14 @@ func (j *Job) FractionDetailProgressed(
 // progressedFn's modifications to the job's progress details, if any.
 func (j *Job) HighWaterProgressed(ctx context.Context, progressedFn HighWaterProgressedFn) error {
 	return j.updateRow(ctx, updateProgressOnly,
-		func(_ *client.Txn, status *Status, payload *jobspb.Payload, progress *jobspb.Progress) (bool, error) {
+		func(txn *client.Txn, status *Status, payload *jobspb.Payload, progress *jobspb.Progress) (bool, error) {
 			if *status != StatusRunning {
 				return false, &InvalidStatusError{*j.id, *status, "update progress on", payload.Error}
 			}
-			highWater := progressedFn(ctx, progress.Details)
+			highWater, err := progressedFn(ctx, txn, progress.Details)
+			if err != nil {
+				return false, err
+			}
 			if highWater.Less(hlc.Timestamp{}) {
 				return false, errors.Errorf(
 					"Job: high-water %s is outside allowable range > 0.0 (job %d)",
@@ -459,6 +466,14 @@ func (j *Job) WithTxn(txn *client.Txn) *Job {
 	return j
 }
 
+// MakePlanHookState returns a sql.PlanHookState, as an interface{} to avoid a
+// dependency cycle, for the job's user. It gives the methods of a Resumer
+// other than Resume access to the server's state; the returned cleanup
+// function must be called once it is no longer used.
+func (j *Job) MakePlanHookState(opName string) (interface{}, func()) {
+	return j.registry.planFn(opName, j.Payload().Username)
+}
+
 func (j *Job) runInTxn(ctx context.Context, fn func(context.Context, *client.Txn) error) error {
 	if j.txn != nil {
 		defer func() { j.txn = nil }()
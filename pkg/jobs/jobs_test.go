@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -971,7 +972,9 @@ func TestJobLifecycle(t *testing.T) {
 		}
 		for _, ts := range highWaters {
 			if err := job.HighWaterProgressed(
-				ctx, func(context.Context, jobspb.ProgressDetails) hlc.Timestamp { return ts },
+				ctx, func(context.Context, *client.Txn, jobspb.ProgressDetails) (hlc.Timestamp, error) {
+					return ts, nil
+				},
 			); err != nil {
 				t.Fatal(err)
 			}
@@ -994,8 +997,8 @@ func TestJobLifecycle(t *testing.T) {
 			t.Fatalf("expected 'outside allowable range' error, but got %v", err)
 		}
 		if err := job.HighWaterProgressed(
-			ctx, func(context.Context, jobspb.ProgressDetails) hlc.Timestamp {
-				return hlc.Timestamp{WallTime: -1}
+			ctx, func(context.Context, *client.Txn, jobspb.ProgressDetails) (hlc.Timestamp, error) {
+				return hlc.Timestamp{WallTime: -1}, nil
 			},
 		); !testutils.IsError(err, "outside allowable range") {
 			t.Fatalf("expected 'outside allowable range' error, but got %v", err)
@@ -29,6 +29,7 @@ var _ Details = RestoreDetails{}
 var _ Details = SchemaChangeDetails{}
 var _ Details = ChangefeedDetails{}
 var _ Details = CreateStatsDetails{}
+var _ Details = RefreshMaterializedViewDetails{}
 
 // ProgressDetails is a marker interface for job progress details proto structs.
 type ProgressDetails interface{}
@@ -38,6 +39,7 @@ var _ ProgressDetails = RestoreProgress{}
 var _ ProgressDetails = SchemaChangeProgress{}
 var _ ProgressDetails = ChangefeedProgress{}
 var _ ProgressDetails = CreateStatsProgress{}
+var _ ProgressDetails = RefreshMaterializedViewProgress{}
 
 // Type returns the payload's job type.
 func (p *Payload) Type() Type {
@@ -59,6 +61,8 @@ func DetailsType(d isPayload_Details) Type {
 		return TypeChangefeed
 	case *Payload_CreateStats:
 		return TypeCreateStats
+	case *Payload_RefreshMaterializedView:
+		return TypeRefreshMaterializedView
 	default:
 		panic(fmt.Sprintf("Payload.Type called on a payload with an unknown details type: %T", d))
 	}
@@ -85,6 +89,8 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_Changefeed{Changefeed: &d}
 	case CreateStatsProgress:
 		return &Progress_CreateStats{CreateStats: &d}
+	case RefreshMaterializedViewProgress:
+		return &Progress_RefreshMaterializedView{RefreshMaterializedView: &d}
 	default:
 		panic(fmt.Sprintf("WrapProgressDetails: unknown details type %T", d))
 	}
@@ -106,6 +112,8 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.Changefeed
 	case *Payload_CreateStats:
 		return *d.CreateStats
+	case *Payload_RefreshMaterializedView:
+		return *d.RefreshMaterializedView
 	default:
 		return nil
 	}
@@ -127,6 +135,8 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.Changefeed
 	case *Progress_CreateStats:
 		return *d.CreateStats
+	case *Progress_RefreshMaterializedView:
+		return *d.RefreshMaterializedView
 	default:
 		return nil
 	}
@@ -161,6 +171,8 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_Changefeed{Changefeed: &d}
 	case CreateStatsDetails:
 		return &Payload_CreateStats{CreateStats: &d}
+	case RefreshMaterializedViewDetails:
+		return &Payload_RefreshMaterializedView{RefreshMaterializedView: &d}
 	default:
 		panic(fmt.Sprintf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -0,0 +1,84 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package jobsprotectedts provides the glue between jobs and the protected
+// timestamp subsystem: jobs protect the data they read with records which
+// reference them, and release the records when they reach a terminal state.
+package jobsprotectedts
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/pkg/errors"
+)
+
+// MetaType is the value of the MetaType field of the records created by jobs.
+const MetaType = "jobs"
+
+// MakeRecord makes a protected timestamp record which protects the given
+// spans at tsToProtect on behalf of the job with the given ID.
+func MakeRecord(
+	id uuid.UUID, jobID int64, tsToProtect hlc.Timestamp, spans []roachpb.Span,
+) *ptpb.Record {
+	return &ptpb.Record{
+		ID:        id,
+		Timestamp: tsToProtect,
+		MetaType:  MetaType,
+		Meta:      encodeJobID(jobID),
+		Spans:     spans,
+	}
+}
+
+// DecodeJobID decodes the ID of the job which created a record from the
+// record's Meta.
+func DecodeJobID(meta []byte) (jobID int64, err error) {
+	var rem []byte
+	rem, jobID, err = encoding.DecodeVarintAscending(meta)
+	if err != nil {
+		return 0, err
+	}
+	if len(rem) > 0 {
+		return 0, errors.Errorf("unexpected %d trailing bytes in job ID", len(rem))
+	}
+	return jobID, nil
+}
+
+func encodeJobID(jobID int64) []byte {
+	return encoding.EncodeVarintAscending(nil, jobID)
+}
+
+// Release releases the record with the given ID, if any, in txn. A record
+// which does not exist is assumed to have been released already, so that
+// jobs can call Release on every path to a terminal state.
+func Release(
+	ctx context.Context, txn *client.Txn, pts protectedts.Storage, id *uuid.UUID,
+) error {
+	if id == nil {
+		return nil
+	}
+	err := pts.Release(ctx, txn, *id)
+	if err == protectedts.ErrNotExists {
+		log.Warningf(ctx, "protected timestamp record %v was already released", *id)
+		return nil
+	}
+	return err
+}
@@ -200,6 +200,19 @@ func (r *Registry) makeJobID() int64 {
 // with (canceling ctx will not causing the job to cancel).
 func (r *Registry) StartJob(
 	ctx context.Context, resultsCh chan<- tree.Datums, record Record,
+) (*Job, <-chan error, error) {
+	return r.StartJobWithTxnFn(ctx, resultsCh, record, nil /* fn */)
+}
+
+// StartJobWithTxnFn is like StartJob, but it also runs fn, with the ID of the
+// new job, in the transaction which creates the job. This allows the caller to
+// atomically create the state which the job depends on, like a protected
+// timestamp record which references the job.
+func (r *Registry) StartJobWithTxnFn(
+	ctx context.Context,
+	resultsCh chan<- tree.Datums,
+	record Record,
+	fn func(ctx context.Context, txn *client.Txn, jobID int64) error,
 ) (*Job, <-chan error, error) {
 	resumer, err := getResumeHook(jobspb.DetailsType(jobspb.WrapPayloadDetails(record.Details)), r.settings)
 	if err != nil {
@@ -213,7 +226,17 @@ func (r *Registry) StartJob(
 	id := r.makeJobID()
 	resumeCtx, cancel := r.makeCtx()
 	r.register(id, cancel)
-	if err := j.insert(ctx, id, r.newLease()); err != nil {
+	if err := r.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		// The job is only considered created once it has been inserted, which
+		// may take several attempts of the transaction.
+		j.id = nil
+		if fn != nil {
+			if err := fn(ctx, txn, id); err != nil {
+				return err
+			}
+		}
+		return j.WithTxn(txn).insert(ctx, id, r.newLease())
+	}); err != nil {
 		r.unregister(id)
 		return nil, nil, err
 	}
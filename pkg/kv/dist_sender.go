@@ -402,6 +402,7 @@ func (ds *DistSender) sendRPC(
 	replicas ReplicaSlice,
 	ba roachpb.BatchRequest,
 	cachedLeaseHolder roachpb.ReplicaDescriptor,
+	withCommit bool,
 ) (*roachpb.BatchResponse, error) {
 	if len(replicas) == 0 {
 		return nil, roachpb.NewSendError(
@@ -421,6 +422,7 @@ func (ds *DistSender) sendRPC(
 		ba,
 		ds.nodeDialer,
 		cachedLeaseHolder,
+		withCommit,
 	)
 }
 
@@ -465,7 +467,7 @@ func (ds *DistSender) getDescriptor(
 
 // sendSingleRange gathers and rearranges the replicas, and makes an RPC call.
 func (ds *DistSender) sendSingleRange(
-	ctx context.Context, ba roachpb.BatchRequest, desc *roachpb.RangeDescriptor,
+	ctx context.Context, ba roachpb.BatchRequest, desc *roachpb.RangeDescriptor, withCommit bool,
 ) (*roachpb.BatchResponse, *roachpb.Error) {
 	// Try to send the call.
 	replicas := NewReplicaSlice(ds.gossip, desc)
@@ -493,7 +495,7 @@ func (ds *DistSender) sendSingleRange(
 		replicas.OptimizeReplicaOrder(ds.getNodeDescriptor(), latencyFn)
 	}
 
-	br, err := ds.sendRPC(ctx, desc.RangeID, replicas, ba, cachedLeaseHolder)
+	br, err := ds.sendRPC(ctx, desc.RangeID, replicas, ba, cachedLeaseHolder, withCommit)
 	if err != nil {
 		log.VErrEvent(ctx, 2, err.Error())
 		return nil, roachpb.NewError(err)
@@ -637,6 +639,13 @@ func splitBatchAndCheckForRefreshSpans(
 	return parts
 }
 
+// isParallelCommit returns whether the request is an EndTransaction
+// request which performs a parallel commit.
+func isParallelCommit(req roachpb.Request) bool {
+	et, ok := req.(*roachpb.EndTransactionRequest)
+	return ok && et.IsParallelCommit()
+}
+
 // Send implements the batch.Sender interface. It subdivides the Batch
 // into batches admissible for sending (preventing certain illegal
 // mixtures of requests), executes each individual part (which may
@@ -681,8 +690,9 @@ func (ds *DistSender) Send(
 	}
 	// To ensure that we lay down intents to prevent starvation, always
 	// split the end transaction request into its own batch on retries.
-	// Txns requiring 1PC are an exception and should never be split.
-	if ba.Txn != nil && ba.Txn.Epoch > 0 && !require1PC {
+	// Txns requiring 1PC are an exception and should never be split, as
+	// are parallel commits, which never lay down intents after staging.
+	if ba.Txn != nil && ba.Txn.Epoch > 0 && !require1PC && !isParallelCommit(lastReq) {
 		splitET = true
 	}
 	parts := splitBatchAndCheckForRefreshSpans(ba, splitET)
@@ -778,6 +788,17 @@ func (ds *DistSender) divideAndSendBatchToRanges(
 		txnCopy := *ba.Txn
 		ba.Txn = &txnCopy
 	}
+	// Determine whether this part of the batch contains a committing
+	// EndTransaction request. If so, all of the partial batches sent
+	// below are part of the commit: when the EndTransaction is a parallel
+	// commit, the outcome of each of its in-flight writes determines
+	// whether the transaction committed.
+	var withCommit, withParallelCommit bool
+	if etArg, ok := ba.GetArg(roachpb.EndTransaction); ok {
+		et := etArg.(*roachpb.EndTransactionRequest)
+		withCommit = et.Commit
+		withParallelCommit = et.IsParallelCommit()
+	}
 	// Get initial seek key depending on direction of iteration.
 	var scanDir ScanDirection
 	var seekKey roachpb.RKey
@@ -795,7 +816,9 @@ func (ds *DistSender) divideAndSendBatchToRanges(
 	}
 	// Take the fast path if this batch fits within a single range.
 	if !ri.NeedAnother(rs) {
-		resp := ds.sendPartialBatch(ctx, ba, rs, ri.Desc(), ri.Token(), batchIdx, false /* needsTruncate */)
+		resp := ds.sendPartialBatch(
+			ctx, ba, rs, ri.Desc(), ri.Token(), withCommit, batchIdx, false, /* needsTruncate */
+		)
 		return resp.reply, resp.pErr
 	}
 
@@ -917,8 +940,10 @@ func (ds *DistSender) divideAndSendBatchToRanges(
 			}
 			// If the request is more than but ends with EndTransaction, we
 			// want the caller to come again with the EndTransaction in an
-			// extra call.
-			if l := len(ba.Requests) - 1; l > 0 && ba.Requests[l].GetInner().Method() == roachpb.EndTransaction {
+			// extra call. Parallel commits are the exception: their
+			// EndTransaction is sent in parallel with the rest of the batch.
+			if l := len(ba.Requests) - 1; l > 0 && !withParallelCommit &&
+				ba.Requests[l].GetInner().Method() == roachpb.EndTransaction {
 				responseCh <- response{pErr: errNo1PCTxn}
 				return
 			}
@@ -956,10 +981,12 @@ func (ds *DistSender) divideAndSendBatchToRanges(
 		// If we can reserve one of the limited goroutines available for parallel
 		// batch RPCs, send asynchronously.
 		if canParallelize && !lastRange && ds.rpcContext != nil &&
-			ds.sendPartialBatchAsync(ctx, ba, rs, ri.Desc(), ri.Token(), batchIdx, responseCh) {
+			ds.sendPartialBatchAsync(ctx, ba, rs, ri.Desc(), ri.Token(), withCommit, batchIdx, responseCh) {
 			// Sent the batch asynchronously.
 		} else {
-			resp := ds.sendPartialBatch(ctx, ba, rs, ri.Desc(), ri.Token(), batchIdx, true /* needsTruncate */)
+			resp := ds.sendPartialBatch(
+				ctx, ba, rs, ri.Desc(), ri.Token(), withCommit, batchIdx, true, /* needsTruncate */
+			)
 			responseCh <- resp
 			if resp.pErr != nil {
 				return
@@ -1050,6 +1077,7 @@ func (ds *DistSender) sendPartialBatchAsync(
 	rs roachpb.RSpan,
 	desc *roachpb.RangeDescriptor,
 	evictToken *EvictionToken,
+	withCommit bool,
 	batchIdx int,
 	responseCh chan response,
 ) bool {
@@ -1058,7 +1086,9 @@ func (ds *DistSender) sendPartialBatchAsync(
 		ds.asyncSenderSem, false, /* wait */
 		func(ctx context.Context) {
 			ds.metrics.AsyncSentCount.Inc(1)
-			responseCh <- ds.sendPartialBatch(ctx, ba, rs, desc, evictToken, batchIdx, true /* needsTruncate */)
+			responseCh <- ds.sendPartialBatch(
+				ctx, ba, rs, desc, evictToken, withCommit, batchIdx, true, /* needsTruncate */
+			)
 		},
 	); err != nil {
 		ds.metrics.AsyncThrottledCount.Inc(1)
@@ -1077,13 +1107,15 @@ func (ds *DistSender) sendPartialBatchAsync(
 // recursively invoke divideAndSendBatchToRanges to re-enumerate the
 // ranges in the span and resend to each. If needsTruncate is true,
 // the supplied batch and span must be truncated to the supplied range
-// descriptor.
+// descriptor. withCommit indicates that the encompassing batch
+// contains a committing EndTransaction request.
 func (ds *DistSender) sendPartialBatch(
 	ctx context.Context,
 	ba roachpb.BatchRequest,
 	rs roachpb.RSpan,
 	desc *roachpb.RangeDescriptor,
 	evictToken *EvictionToken,
+	withCommit bool,
 	batchIdx int,
 	needsTruncate bool,
 ) response {
@@ -1136,7 +1168,7 @@ func (ds *DistSender) sendPartialBatch(
 			}
 		}
 
-		reply, pErr = ds.sendSingleRange(ctx, ba, desc)
+		reply, pErr = ds.sendSingleRange(ctx, ba, desc, withCommit)
 
 		// If sending succeeded, return immediately.
 		if pErr == nil {
@@ -1330,6 +1362,11 @@ func fillSkippedResponses(
 // reply. If an error occurs which is not specific to a single
 // replica, it's returned immediately. Otherwise, when all replicas
 // have been tried and failed, returns a send error.
+//
+// withCommit is set if the batch is part of a larger batch which
+// contains a committing EndTransaction request. RPC errors which
+// leave the outcome of such a batch unknown are turned into an
+// AmbiguousResultError.
 func (ds *DistSender) sendToReplicas(
 	ctx context.Context,
 	opts SendOptions,
@@ -1338,14 +1375,9 @@ func (ds *DistSender) sendToReplicas(
 	ba roachpb.BatchRequest,
 	nodeDialer *nodedialer.Dialer,
 	cachedLeaseHolder roachpb.ReplicaDescriptor,
+	withCommit bool,
 ) (*roachpb.BatchResponse, error) {
 	var ambiguousError error
-	var haveCommit bool
-	// We only check for committed txns, not aborts because aborts may
-	// be retried without any risk of inconsistencies.
-	if etArg, ok := ba.GetArg(roachpb.EndTransaction); ok {
-		haveCommit = etArg.(*roachpb.EndTransactionRequest).Commit
-	}
 
 	transport, err := ds.transportFactory(opts, nodeDialer, replicas)
 	if err != nil {
@@ -1385,7 +1417,7 @@ func (ds *DistSender) sendToReplicas(
 			// guaranteed to return an error. If the original attempt merely timed out
 			// or was lost, then the batch will succeed and we can be assured the
 			// commit was applied just once.
-			if haveCommit && !grpcutil.RequestDidNotStart(err) {
+			if withCommit && !grpcutil.RequestDidNotStart(err) {
 				ambiguousError = err
 			}
 			log.VErrEventf(ctx, 2, "RPC error: %s", err)
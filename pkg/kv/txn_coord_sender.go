@@ -134,6 +134,10 @@ type TxnCoordSender struct {
 
 		// onFinishFn is a closure invoked when state changes to done or aborted.
 		onFinishFn func(error)
+
+		// savepoints is the stack of savepoints that have been established and
+		// not released, ordered by creation.
+		savepoints []*savepoint
 	}
 
 	// A pointer member to the creating factory provides access to
@@ -148,11 +152,12 @@ type TxnCoordSender struct {
 	// additional heap allocations necessary.
 	interceptorStack []txnInterceptor
 	interceptorAlloc struct {
-		arr [6]txnInterceptor
+		arr [7]txnInterceptor
 		txnHeartbeat
 		txnIntentCollector
 		txnPipeliner
 		txnSpanRefresher
+		txnCommitter
 		txnSeqNumAllocator
 		txnMetrics
 		txnLockGatekeeper // not in interceptorStack array.
@@ -438,6 +443,12 @@ func (tcf *TxnCoordSenderFactory) TransactionalSender(
 			st: tcf.st,
 			ri: ri,
 		}
+		tcs.interceptorAlloc.txnCommitter = txnCommitter{
+			AmbientContext: tcf.AmbientContext,
+			st:             tcf.st,
+			stopper:        tcs.stopper,
+			nonTxnSender:   tcf.wrapped,
+		}
 	}
 	tcs.interceptorAlloc.txnPipeliner = txnPipeliner{
 		st: tcf.st,
@@ -467,6 +478,11 @@ func (tcf *TxnCoordSenderFactory) TransactionalSender(
 			&tcs.interceptorAlloc.txnIntentCollector,
 			&tcs.interceptorAlloc.txnPipeliner,
 			&tcs.interceptorAlloc.txnSpanRefresher,
+			// The committer is below the txnPipeliner so that it sees the
+			// in-flight writes attached to EndTransaction requests, and below
+			// the txnSpanRefresher so that failed parallel commits can be
+			// retried at a refreshed timestamp.
+			&tcs.interceptorAlloc.txnCommitter,
 			&tcs.interceptorAlloc.txnMetrics,
 		}
 		tcs.interceptorStack = tcs.interceptorAlloc.arr[:]
@@ -957,6 +973,19 @@ func (tc *TxnCoordSender) updateStateLocked(
 	}
 
 	// This is the non-retriable error case.
+	if _, ok := pErr.GetDetail().(*roachpb.ConditionFailedError); ok && tc.hasActiveSavepointLocked() {
+		// A failed condition doesn't leave the transaction in an inconsistent
+		// state; in particular, SQL relies on it for detecting unique constraint
+		// violations and may roll back to a savepoint after one. So we don't
+		// move to txnError and allow the transaction to continue. Without a
+		// savepoint to roll back to, the client has no use for the transaction
+		// anymore and it is poisoned like on any other error.
+		if errTxn := pErr.GetTxn(); errTxn != nil {
+			cp := errTxn.Clone()
+			tc.mu.txn.Update(&cp)
+		}
+		return pErr
+	}
 	if errTxn := pErr.GetTxn(); errTxn != nil {
 		tc.mu.txnState = txnError
 		tc.mu.storedErr = roachpb.NewError(&roachpb.TxnAlreadyEncounteredErrorError{
@@ -0,0 +1,212 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kv
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/pkg/errors"
+)
+
+// savepoint is the state captured by TxnCoordSender.CreateSavepoint. It is
+// handed out to clients as an opaque client.SavepointToken.
+//
+// A savepoint is identified by the sequence number of the last write the
+// transaction performed before the savepoint was established. Rolling back to
+// it marks all the sequence numbers allocated since then as ignored and
+// reverts the transaction's intents accordingly; see RollbackToSavepoint.
+type savepoint struct {
+	txnID  uuid.UUID
+	epoch  uint32
+	seqNum int32
+}
+
+var _ client.SavepointToken = &savepoint{}
+
+// errSavepointAfterRestart is returned when trying to roll back to a savepoint
+// established in an earlier epoch of the transaction. The writes performed
+// since that savepoint have already been discarded by the restart.
+var errSavepointAfterRestart = errors.New("cannot roll back to a savepoint after a transaction restart")
+
+// CreateSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) CreateSavepoint(ctx context.Context) (client.SavepointToken, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if err := tc.checkSavepointsAllowedLocked(ctx); err != nil {
+		return nil, err
+	}
+	sp := &savepoint{
+		txnID:  tc.mu.txn.ID,
+		epoch:  tc.mu.txn.Epoch,
+		seqNum: tc.interceptorAlloc.txnSeqNumAllocator.seqNumCounter,
+	}
+	tc.mu.savepoints = append(tc.mu.savepoints, sp)
+	return sp, nil
+}
+
+// RollbackToSavepoint is part of the client.TxnSender interface.
+//
+// The rollback is performed eagerly: once all in-flight writes have been
+// proven, every intent span of the transaction is resolved as PENDING with the
+// sequence numbers allocated since the savepoint marked as ignored. This
+// reverts each intent to the latest value it had as of the savepoint, or
+// removes it if the key was first written after the savepoint. Readers,
+// including the transaction itself, thus never observe the discarded writes.
+func (tc *TxnCoordSender) RollbackToSavepoint(ctx context.Context, s client.SavepointToken) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	sp, err := tc.checkSavepointLocked(ctx, s)
+	if err != nil {
+		return err
+	}
+	// The savepoints established after this one are released; this one
+	// remains.
+	tc.truncateSavepointsLocked(sp, true /* inclusive */)
+	curSeq := tc.interceptorAlloc.txnSeqNumAllocator.seqNumCounter
+	if curSeq == sp.seqNum {
+		// Nothing was written since the savepoint.
+		return nil
+	}
+
+	// Prove all the writes that are still in flight first, so that none of them
+	// can land after their intents have been rolled back.
+	{
+		txn := tc.mu.txn.Clone()
+		ba := roachpb.BatchRequest{}
+		ba.Txn = &txn
+		br, pErr := tc.interceptorAlloc.txnPipeliner.proveOutstandingWritesLocked(ctx, ba)
+		if pErr := tc.updateStateLocked(ctx, 0 /* startNS */, ba, br, pErr); pErr != nil {
+			return pErr.GoError()
+		}
+	}
+
+	ignored := []enginepb.IgnoredSeqNumRange{{Start: sp.seqNum + 1, End: curSeq}}
+	intents, _ := roachpb.MergeSpans(
+		append([]roachpb.Span(nil), tc.interceptorAlloc.txnIntentCollector.intents...))
+	var ba roachpb.BatchRequest
+	for _, span := range intents {
+		if len(span.EndKey) == 0 {
+			ba.Add(&roachpb.ResolveIntentRequest{
+				RequestHeader:  roachpb.RequestHeaderFromSpan(span),
+				IntentTxn:      tc.mu.txn.TxnMeta,
+				Status:         roachpb.PENDING,
+				IgnoredSeqNums: ignored,
+			})
+		} else {
+			ba.Add(&roachpb.ResolveIntentRangeRequest{
+				RequestHeader:  roachpb.RequestHeaderFromSpan(span),
+				IntentTxn:      tc.mu.txn.TxnMeta,
+				Status:         roachpb.PENDING,
+				IgnoredSeqNums: ignored,
+			})
+		}
+	}
+	if len(ba.Requests) == 0 {
+		return nil
+	}
+
+	log.VEventf(ctx, 2, "rolling back to savepoint at seq %d; ignoring %v", sp.seqNum, ignored)
+	// The resolution is not transactional, so it bypasses the interceptors.
+	// The gatekeeper still takes care of releasing the lock while sending.
+	if _, pErr := tc.interceptorAlloc.txnLockGatekeeper.SendLocked(ctx, ba); pErr != nil {
+		// Some of the intents may have been rolled back while others were not.
+		// There's no way to tell, so the transaction cannot be allowed to
+		// continue.
+		tc.mu.txnState = txnError
+		tc.mu.storedErr = roachpb.NewError(&roachpb.TxnAlreadyEncounteredErrorError{
+			PrevError: pErr.String(),
+		})
+		tc.cleanupTxnLocked(ctx)
+		return pErr.GoError()
+	}
+	return nil
+}
+
+// ReleaseSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) ReleaseSavepoint(ctx context.Context, s client.SavepointToken) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	// Releasing a savepoint doesn't require any work; the writes performed
+	// since it was established simply remain.
+	sp, err := tc.checkSavepointLocked(ctx, s)
+	if err != nil {
+		return err
+	}
+	tc.truncateSavepointsLocked(sp, false /* inclusive */)
+	return nil
+}
+
+// truncateSavepointsLocked releases the savepoints established after the given
+// one, as well as the given one itself unless inclusive is set.
+func (tc *TxnCoordSender) truncateSavepointsLocked(sp *savepoint, inclusive bool) {
+	for i := len(tc.mu.savepoints) - 1; i >= 0; i-- {
+		if tc.mu.savepoints[i] == sp {
+			if inclusive {
+				i++
+			}
+			tc.mu.savepoints = tc.mu.savepoints[:i]
+			return
+		}
+	}
+}
+
+// hasActiveSavepointLocked returns true if the transaction has established a
+// savepoint in its current epoch which has not been released.
+func (tc *TxnCoordSender) hasActiveSavepointLocked() bool {
+	for _, sp := range tc.mu.savepoints {
+		if sp.epoch == tc.mu.txn.Epoch {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSavepointsAllowedLocked returns an error if the transaction cannot
+// establish or use savepoints in its current state.
+func (tc *TxnCoordSender) checkSavepointsAllowedLocked(ctx context.Context) error {
+	if tc.typ != client.RootTxn {
+		return errors.Errorf("savepoints are only supported on root transactions")
+	}
+	if pErr := tc.maybeRejectClientLocked(ctx, nil /* ba */); pErr != nil {
+		return pErr.GoError()
+	}
+	return nil
+}
+
+// checkSavepointLocked verifies that the token is a savepoint of the current
+// epoch of this transaction.
+func (tc *TxnCoordSender) checkSavepointLocked(
+	ctx context.Context, s client.SavepointToken,
+) (*savepoint, error) {
+	if err := tc.checkSavepointsAllowedLocked(ctx); err != nil {
+		return nil, err
+	}
+	sp, ok := s.(*savepoint)
+	if !ok || sp.txnID != tc.mu.txn.ID {
+		return nil, errors.Errorf("savepoint does not belong to transaction %s", tc.mu.txn.ID.Short())
+	}
+	if sp.epoch != tc.mu.txn.Epoch {
+		return nil, errSavepointAfterRestart
+	}
+	return sp, nil
+}
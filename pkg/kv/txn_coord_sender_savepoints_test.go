@@ -0,0 +1,202 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// TestTxnCoordSenderSavepoints verifies that rolling back to a savepoint
+// undoes the writes performed since it was established, both for the
+// transaction itself and for other readers once the transaction commits.
+func TestTxnCoordSenderSavepoints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	ctx := context.Background()
+	txn := client.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */, client.RootTxn)
+
+	expectValue := func(key string, exp string) {
+		t.Helper()
+		kv, err := txn.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kv.Value == nil {
+			if exp != "" {
+				t.Fatalf("%s: expected %q, got no value", key, exp)
+			}
+			return
+		}
+		if act := string(kv.ValueBytes()); act != exp {
+			t.Fatalf("%s: expected %q, got %q", key, exp, act)
+		}
+	}
+
+	if err := txn.Put(ctx, "a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	sp1, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Put(ctx, "a", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Put(ctx, "b", "2"); err != nil {
+		t.Fatal(err)
+	}
+	sp2, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.DelRange(ctx, "a", "c"); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("a", "")
+
+	// Roll back the DelRange.
+	if err := txn.RollbackToSavepoint(ctx, sp2); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("a", "2")
+	expectValue("b", "2")
+
+	// Roll back everything but the first write.
+	if err := txn.RollbackToSavepoint(ctx, sp1); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("a", "1")
+	expectValue("b", "")
+
+	// A failed condition doesn't prevent rolling back to a savepoint.
+	if err := txn.CPut(ctx, "a", "3", "wrong"); !testutils.IsError(err, "unexpected value") {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	}
+	if err := txn.RollbackToSavepoint(ctx, sp1); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.ReleaseSavepoint(ctx, sp1); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, exp := range map[string]string{"a": "1", "b": ""} {
+		kv, err := s.DB.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act := string(kv.ValueBytes()); act != exp {
+			t.Fatalf("%s: expected %q, got %q", key, exp, act)
+		}
+	}
+}
+
+// TestTxnCoordSenderSavepointAfterRestart verifies that savepoints can't be
+// rolled back to once the transaction has moved to a new epoch.
+func TestTxnCoordSenderSavepointAfterRestart(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	ctx := context.Background()
+	txn := client.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */, client.RootTxn)
+	if err := txn.Put(ctx, "a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	sp, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn.ManualRestart(ctx, s.Clock.Now())
+	if err := txn.RollbackToSavepoint(ctx, sp); !testutils.IsError(
+		err, "cannot roll back to a savepoint after a transaction restart",
+	) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := txn.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Savepoints are tied to their transaction.
+	other := client.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */, client.RootTxn)
+	if err := other.RollbackToSavepoint(ctx, sp); !testutils.IsError(
+		err, "savepoint does not belong to transaction",
+	) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := other.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTxnCoordSenderConditionFailedPoisonsTxn verifies that a failed condition
+// only leaves the transaction usable while it has a savepoint to roll back to.
+func TestTxnCoordSenderConditionFailedPoisonsTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	ctx := context.Background()
+	const alreadyEncounteredErr = "txn already encountered an error"
+
+	// Without a savepoint, the transaction can't be used after the failure.
+	txn := client.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */, client.RootTxn)
+	if err := txn.Put(ctx, "a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.CPut(ctx, "a", "2", "wrong"); !testutils.IsError(err, "unexpected value") {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	}
+	if err := txn.Put(ctx, "b", "1"); !testutils.IsError(err, alreadyEncounteredErr) {
+		t.Fatalf("expected the transaction to be poisoned, got %v", err)
+	}
+	if err := txn.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once its only savepoint is released, the transaction is poisoned again.
+	txn = client.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */, client.RootTxn)
+	sp, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.CPut(ctx, "a", "2", "wrong"); !testutils.IsError(err, "unexpected value") {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	}
+	if err := txn.Put(ctx, "b", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.ReleaseSavepoint(ctx, sp); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.CPut(ctx, "a", "2", "wrong"); !testutils.IsError(err, "unexpected value") {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	}
+	if err := txn.Put(ctx, "c", "1"); !testutils.IsError(err, alreadyEncounteredErr) {
+		t.Fatalf("expected the transaction to be poisoned, got %v", err)
+	}
+	if err := txn.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
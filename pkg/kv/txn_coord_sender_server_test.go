@@ -197,3 +197,80 @@ func TestNoDuplicateHeartbeatLoops(t *testing.T) {
 		t.Fatal("no heartbeat loop found. Test rotted?")
 	}
 }
+
+// TestParallelCommitRecoveryAfterFailedExplicitCommit tests that a transaction
+// which is implicitly committed by a parallel commit is reported as committed
+// to the client, and that its commit is recovered by a conflicting
+// transaction when the coordinator fails to make the commit explicit.
+func TestParallelCommitRecoveryAfterFailedExplicitCommit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+	var explicitCommitRejected int64
+	s, _, db := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			Store: &storage.StoreTestingKnobs{
+				TestingRequestFilter: func(ba roachpb.BatchRequest) *roachpb.Error {
+					// Reject the requests that make the commit of the STAGING
+					// transaction explicit.
+					if ba.Txn == nil || ba.Txn.Status != roachpb.STAGING ||
+						!ba.IsSingleEndTransactionRequest() {
+						return nil
+					}
+					et := ba.Requests[0].GetInner().(*roachpb.EndTransactionRequest)
+					if et.Commit && !et.IsParallelCommit() && et.Key.Equal(keyA) {
+						atomic.StoreInt64(&explicitCommitRejected, 1)
+						return roachpb.NewErrorf("injected explicit commit failure")
+					}
+					return nil
+				},
+			},
+		},
+	})
+	ctx := context.Background()
+	defer s.Stopper().Stop(ctx)
+
+	// Write to two ranges so that the transaction performs a parallel commit.
+	if _, _, err := s.SplitRange(keyB); err != nil {
+		t.Fatal(err)
+	}
+	txn := client.NewTxn(ctx, db, 0 /* gatewayNodeID */, client.RootTxn)
+	if err := txn.Put(ctx, keyA, "val"); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Put(ctx, keyB, "val"); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	testutils.SucceedsSoon(t, func() error {
+		if atomic.LoadInt64(&explicitCommitRejected) == 0 {
+			return fmt.Errorf("explicit commit not attempted yet")
+		}
+		return nil
+	})
+
+	// The transaction record is left in the STAGING status. A conflicting
+	// transaction which pushes it recovers its commit.
+	conflictTxn := client.NewTxn(ctx, db, 0 /* gatewayNodeID */, client.RootTxn)
+	// We need to explicitly set a high priority for the push to happen.
+	if err := conflictTxn.SetUserPriority(roachpb.MaxUserPriority); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []roachpb.Key{keyA, keyB} {
+		kv, err := conflictTxn.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !kv.Exists() {
+			t.Fatalf("expected the write to %s to be committed", key)
+		}
+		if v := string(kv.ValueBytes()); v != "val" {
+			t.Fatalf("expected value %q for %s, got %q", "val", key, v)
+		}
+	}
+	if err := conflictTxn.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kv
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+)
+
+var parallelCommitsEnabled = settings.RegisterBoolSetting(
+	"kv.transaction.parallel_commits_enabled",
+	"if enabled, transactional commits will be parallelized with transactional writes",
+	true,
+)
+
+// txnCommitter is a txnInterceptor that concerns itself with committing and
+// rolling back transactions. It intercepts EndTransaction requests and
+// coordinates their execution. This is accomplished either by issuing them
+// directly with proper addressing if they are alone, or by parallelizing them
+// with the rest of the writes in their batch.
+//
+// The txnPipeliner declares all writes that a committing EndTransaction request
+// depends on as the request's in-flight writes. These are the transaction's
+// outstanding writes, which are proven by QueryIntent requests in the same
+// batch, and the point writes in the batch itself. When the txnCommitter
+// permits it, the EndTransaction request is evaluated in parallel with these
+// writes and moves the transaction record to the STAGING status instead of
+// committing the transaction directly. This avoids a round of consensus that
+// would otherwise be needed to prove the writes before sending the
+// EndTransaction request.
+//
+// A transaction in the STAGING status is implicitly committed once all of its
+// in-flight writes have succeeded at or below the timestamp at which it was
+// staged. This is the case if the batch containing the staging EndTransaction
+// request succeeds without the transaction being pushed. The txnCommitter then
+// treats the transaction as committed and makes the commit explicit
+// asynchronously by sending a second EndTransaction request, which moves the
+// transaction record to the COMMITTED status and resolves the transaction's
+// intents. If the coordinator crashes before doing so, any other transaction
+// that encounters the STAGING record recovers its status by querying all of its
+// in-flight writes (see intentresolver.RecoverIndeterminateCommit).
+//
+// If any of the in-flight writes fails or is pushed, the transaction is not
+// implicitly committed and remains in the STAGING status until it either
+// retries at a higher timestamp or epoch or is rolled back. STAGING is never
+// exposed above the txnCommitter.
+type txnCommitter struct {
+	log.AmbientContext
+	st      *cluster.Settings
+	stopper *stop.Stopper
+	// nonTxnSender is the non-transactional sender of the
+	// TxnCoordSenderFactory. The commits are made explicit through it
+	// instead of through the interceptor stack, as this happens after the
+	// client has been told that the transaction committed and the
+	// TxnCoordSender may have been closed.
+	nonTxnSender client.Sender
+	wrapped      lockedSender
+}
+
+// SendLocked implements the lockedSender interface.
+func (tc *txnCommitter) SendLocked(
+	ctx context.Context, ba roachpb.BatchRequest,
+) (*roachpb.BatchResponse, *roachpb.Error) {
+	rArgs, hasET := ba.GetArg(roachpb.EndTransaction)
+	if !hasET {
+		return tc.wrapped.SendLocked(ctx, ba)
+	}
+	et := rArgs.(*roachpb.EndTransactionRequest)
+
+	// Determine whether the commit can be run in parallel with the rest of the
+	// writes in the batch. If not, strip the in-flight writes from the request
+	// so that it commits the transaction directly.
+	if et.IsParallelCommit() && !tc.canCommitInParallelWithWrites(ba, et) {
+		etCpy := *et
+		etCpy.InFlightWrites = nil
+		ba.Requests = append([]roachpb.RequestUnion(nil), ba.Requests...)
+		ba.Requests[len(ba.Requests)-1].MustSetInner(&etCpy)
+		et = &etCpy
+	}
+
+	// Send the adjusted batch through the wrapped lockedSender. Unlocks while
+	// sending then re-locks.
+	br, pErr := tc.wrapped.SendLocked(ctx, ba)
+	if pErr != nil {
+		// A parallel commit may have moved the transaction record to the
+		// STAGING status before another request in the batch failed. The
+		// transaction is not implicitly committed, so the STAGING status must
+		// not leak to the rest of the interceptor stack.
+		if txn := pErr.GetTxn(); txn != nil && txn.Status == roachpb.STAGING {
+			txnCpy := txn.Clone()
+			txnCpy.Status = roachpb.PENDING
+			pErr.SetTxn(&txnCpy)
+		}
+		return nil, pErr
+	}
+
+	if br.Txn == nil || br.Txn.Status != roachpb.STAGING {
+		return br, nil
+	}
+
+	// The transaction record was moved to the STAGING status. If none of the
+	// writes in the batch were pushed above the timestamp at which the record
+	// was staged, the transaction is implicitly committed. Otherwise, it needs
+	// to retry at its new timestamp. The STAGING record will be overwritten by
+	// the retry or by a rollback.
+	etResp := br.Responses[len(br.Responses)-1].GetInner().(*roachpb.EndTransactionResponse)
+	if etResp.StagingTimestamp.Less(br.Txn.Timestamp) {
+		txn := br.Txn.Clone()
+		txn.Status = roachpb.PENDING
+		log.VEventf(ctx, 2, "parallel commit failed; txn pushed from %s to %s",
+			etResp.StagingTimestamp, txn.Timestamp)
+		return nil, roachpb.NewErrorWithTxn(
+			roachpb.NewTransactionRetryError(roachpb.RETRY_SERIALIZABLE), &txn,
+		)
+	}
+
+	// The transaction is implicitly committed. Make the commit explicit
+	// asynchronously and report the transaction as committed to the client.
+	tc.makeTxnCommitExplicitAsync(ctx, br.Txn, et)
+	br.Txn.Status = roachpb.COMMITTED
+	return br, nil
+}
+
+// canCommitInParallelWithWrites determines whether the batch can issue its
+// committing EndTransaction in parallel with its in-flight writes.
+func (tc *txnCommitter) canCommitInParallelWithWrites(
+	ba roachpb.BatchRequest, et *roachpb.EndTransactionRequest,
+) bool {
+	if !parallelCommitsEnabled.Get(&tc.st.SV) ||
+		!tc.st.Version.IsActive(cluster.VersionParallelCommits) {
+		return false
+	}
+
+	// We don't support a parallel commit when an EndTransaction request
+	// contains a commit trigger. Commit triggers need to run before the
+	// transaction's intents are resolved, which can't be delayed until
+	// after the commit is made explicit.
+	if et.InternalCommitTrigger != nil {
+		return false
+	}
+
+	// Check whether every request in the batch is a point write, a
+	// QueryIntent proving an in-flight write, or the BeginTransaction and
+	// EndTransaction requests themselves. Only the outcome of these requests
+	// is captured by the in-flight writes.
+	for _, ru := range ba.Requests[:len(ba.Requests)-1] {
+		req := ru.GetInner()
+		switch req.Method() {
+		case roachpb.BeginTransaction, roachpb.QueryIntent:
+			continue
+		}
+		if !roachpb.IsTransactionWrite(req) || roachpb.IsRange(req) {
+			return false
+		}
+	}
+	return true
+}
+
+// makeTxnCommitExplicitAsync launches an async task that sends an
+// EndTransaction request to the implicitly committed transaction's record
+// to move it to the COMMITTED status and resolve its intents.
+//
+// The request is sent through the non-transactional sender with its own
+// copy of the transaction, so the task neither needs the TxnCoordSender's
+// lock nor interacts with the interceptors, which consider the transaction
+// finished. The request reuses the sequence number of the EndTransaction
+// request that staged the transaction, which is the last sequence number
+// allocated by the transaction.
+func (tc *txnCommitter) makeTxnCommitExplicitAsync(
+	ctx context.Context, txn *roachpb.Transaction, et *roachpb.EndTransactionRequest,
+) {
+	txnCpy := txn.Clone()
+	// NB: We use context.Background() here because we don't want a canceled
+	// context to interrupt the explicit commit.
+	ctx = tc.AnnotateCtx(context.Background())
+
+	ba := roachpb.BatchRequest{}
+	ba.Header = roachpb.Header{Txn: &txnCpy}
+	etCpy := roachpb.EndTransactionRequest{
+		RequestHeader: roachpb.RequestHeader{Key: et.Key, Sequence: et.Sequence},
+		Commit:        true,
+		IntentSpans:   et.IntentSpans,
+	}
+	ba.Add(&etCpy)
+
+	log.VEventf(ctx, 2, "making txn commit explicit: %s", txnCpy)
+	if err := tc.stopper.RunAsyncTask(
+		ctx, "txnCommitter: making txn commit explicit", func(ctx context.Context) {
+			if _, pErr := tc.nonTxnSender.Send(ctx, ba); pErr != nil {
+				// If the explicit commit fails, the transaction is left in the
+				// STAGING status and will be recovered by the first transaction
+				// that encounters it.
+				log.VErrEventf(ctx, 1, "making txn commit explicit failed for %s: %s", txnCpy, pErr)
+			}
+		},
+	); err != nil {
+		log.Warning(ctx, err)
+	}
+}
+
+// setWrapped implements the txnInterceptor interface.
+func (tc *txnCommitter) setWrapped(wrapped lockedSender) { tc.wrapped = wrapped }
+
+// populateMetaLocked implements the txnInterceptor interface.
+func (*txnCommitter) populateMetaLocked(meta *roachpb.TxnCoordMeta) {}
+
+// augmentMetaLocked implements the txnInterceptor interface.
+func (*txnCommitter) augmentMetaLocked(meta roachpb.TxnCoordMeta) {}
+
+// epochBumpedLocked implements the txnInterceptor interface.
+func (*txnCommitter) epochBumpedLocked() {}
+
+// closeLocked implements the txnInterceptor interface.
+func (*txnCommitter) closeLocked() {}
@@ -0,0 +1,271 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/stretchr/testify/require"
+)
+
+// makeMockTxnCommitter creates a txnCommitter which wraps a mock
+// lockedSender and makes commits explicit through a second mock sender.
+func makeMockTxnCommitter() (txnCommitter, *mockLockedSender, *mockLockedSender, *stop.Stopper) {
+	mockSender := &mockLockedSender{}
+	mockNonTxnSender := &mockLockedSender{}
+	stopper := stop.NewStopper()
+	return txnCommitter{
+		st:           cluster.MakeTestingClusterSettings(),
+		stopper:      stopper,
+		nonTxnSender: client.SenderFunc(mockNonTxnSender.SendLocked),
+		wrapped:      mockSender,
+	}, mockSender, mockNonTxnSender, stopper
+}
+
+// TestTxnCommitterParallelCommit tests that the txnCommitter performs a
+// parallel commit when a committing EndTransaction request carries in-flight
+// writes, that it reports an implicitly committed transaction as committed,
+// and that it makes the commit explicit asynchronously through the
+// non-transactional sender.
+func TestTxnCommitterParallelCommit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	tc, mockSender, mockNonTxnSender, stopper := makeMockTxnCommitter()
+	defer stopper.Stop(ctx)
+
+	txn := makeTxnProto()
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	putArgs := roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}}
+	putArgs.Sequence = 2
+	qiArgs := roachpb.QueryIntentRequest{RequestHeader: roachpb.RequestHeader{Key: keyB}}
+	etArgs := roachpb.EndTransactionRequest{
+		RequestHeader: roachpb.RequestHeader{Key: txn.Key},
+		Commit:        true,
+		IntentSpans:   []roachpb.Span{{Key: keyA}, {Key: keyB}},
+		InFlightWrites: []roachpb.SequencedWrite{
+			{Key: keyB, Sequence: 1}, {Key: keyA, Sequence: 2},
+		},
+	}
+	etArgs.Sequence = 3
+	ba.Add(&qiArgs, &putArgs, &etArgs)
+
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Equal(t, 3, len(ba.Requests))
+		et := ba.Requests[2].GetInner().(*roachpb.EndTransactionRequest)
+		require.True(t, et.IsParallelCommit())
+		require.Equal(t, etArgs.InFlightWrites, et.InFlightWrites)
+
+		br := ba.CreateReply()
+		txnCpy := ba.Txn.Clone()
+		br.Txn = &txnCpy
+		br.Txn.Status = roachpb.STAGING
+		br.Responses[2].GetInner().(*roachpb.EndTransactionResponse).StagingTimestamp = br.Txn.Timestamp
+		return br, nil
+	})
+
+	explicitCommitC := make(chan struct{})
+	mockNonTxnSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Equal(t, 1, len(ba.Requests))
+		et := ba.Requests[0].GetInner().(*roachpb.EndTransactionRequest)
+		require.True(t, et.Commit)
+		require.False(t, et.IsParallelCommit())
+		require.Equal(t, txn.Key, et.Key)
+		require.Equal(t, etArgs.Sequence, et.Sequence)
+		require.Equal(t, etArgs.IntentSpans, et.IntentSpans)
+		require.Equal(t, txn.ID, ba.Txn.ID)
+
+		br := ba.CreateReply()
+		txnCpy := ba.Txn.Clone()
+		br.Txn = &txnCpy
+		br.Txn.Status = roachpb.COMMITTED
+		close(explicitCommitC)
+		return br, nil
+	})
+
+	br, pErr := tc.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+	require.Equal(t, roachpb.COMMITTED, br.Txn.Status)
+
+	<-explicitCommitC
+}
+
+// TestTxnCommitterStripsInFlightWrites tests that the txnCommitter strips the
+// in-flight writes from EndTransaction requests that can't perform a parallel
+// commit.
+func TestTxnCommitterStripsInFlightWrites(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	tc, mockSender, _, stopper := makeMockTxnCommitter()
+	defer stopper.Stop(ctx)
+
+	txn := makeTxnProto()
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+
+	// Parallel commits are not allowed in batches with reads.
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	putArgs := roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}}
+	putArgs.Sequence = 1
+	scanArgs := roachpb.ScanRequest{RequestHeader: roachpb.RequestHeader{Key: keyA, EndKey: keyB}}
+	etArgs := roachpb.EndTransactionRequest{Commit: true}
+	etArgs.InFlightWrites = []roachpb.SequencedWrite{{Key: keyA, Sequence: 1}}
+	ba.Add(&putArgs, &scanArgs, &etArgs)
+
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Equal(t, 3, len(ba.Requests))
+		et := ba.Requests[2].GetInner().(*roachpb.EndTransactionRequest)
+		require.True(t, et.Commit)
+		require.Nil(t, et.InFlightWrites)
+
+		br := ba.CreateReply()
+		txnCpy := ba.Txn.Clone()
+		br.Txn = &txnCpy
+		br.Txn.Status = roachpb.COMMITTED
+		return br, nil
+	})
+
+	br, pErr := tc.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+	// The original request is not modified.
+	require.Equal(t, 1, len(etArgs.InFlightWrites))
+
+	// Parallel commits are not allowed when disabled.
+	parallelCommitsEnabled.Override(&tc.st.SV, false)
+	ba.Requests = nil
+	ba.Add(&putArgs, &etArgs)
+
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Equal(t, 2, len(ba.Requests))
+		et := ba.Requests[1].GetInner().(*roachpb.EndTransactionRequest)
+		require.True(t, et.Commit)
+		require.Nil(t, et.InFlightWrites)
+
+		br := ba.CreateReply()
+		txnCpy := ba.Txn.Clone()
+		br.Txn = &txnCpy
+		br.Txn.Status = roachpb.COMMITTED
+		return br, nil
+	})
+
+	br, pErr = tc.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+}
+
+// TestTxnCommitterRetryAfterStaging tests that the txnCommitter returns a
+// retry error when a parallel commit moves the transaction record to the
+// STAGING status but one of the in-flight writes is pushed to a higher
+// timestamp.
+func TestTxnCommitterRetryAfterStaging(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	tc, mockSender, _, stopper := makeMockTxnCommitter()
+	defer stopper.Stop(ctx)
+
+	txn := makeTxnProto()
+	keyA := roachpb.Key("a")
+
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	putArgs := roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}}
+	putArgs.Sequence = 1
+	etArgs := roachpb.EndTransactionRequest{Commit: true}
+	etArgs.InFlightWrites = []roachpb.SequencedWrite{{Key: keyA, Sequence: 1}}
+	ba.Add(&putArgs, &etArgs)
+
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Equal(t, 2, len(ba.Requests))
+		et := ba.Requests[1].GetInner().(*roachpb.EndTransactionRequest)
+		require.True(t, et.IsParallelCommit())
+
+		br := ba.CreateReply()
+		txnCpy := ba.Txn.Clone()
+		br.Txn = &txnCpy
+		br.Txn.Status = roachpb.STAGING
+		br.Responses[1].GetInner().(*roachpb.EndTransactionResponse).StagingTimestamp = br.Txn.Timestamp
+		// The write was pushed.
+		br.Txn.Timestamp.Logical++
+		return br, nil
+	})
+
+	br, pErr := tc.SendLocked(ctx, ba)
+	require.Nil(t, br)
+	require.NotNil(t, pErr)
+	require.IsType(t, &roachpb.TransactionRetryError{}, pErr.GetDetail())
+	require.Equal(t, roachpb.PENDING, pErr.GetTxn().Status)
+	require.Equal(t, txn.Timestamp.Next(), pErr.GetTxn().Timestamp)
+}
+
+// TestTxnCommitterStagingErrorIsNotExposed tests that the txnCommitter does not
+// expose the STAGING status of a transaction whose parallel commit failed
+// because another request in the batch returned an error, and that it does
+// not try to make the commit explicit.
+func TestTxnCommitterStagingErrorIsNotExposed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	tc, mockSender, mockNonTxnSender, stopper := makeMockTxnCommitter()
+	defer stopper.Stop(ctx)
+
+	txn := makeTxnProto()
+	keyA := roachpb.Key("a")
+
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	putArgs := roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}}
+	putArgs.Sequence = 1
+	etArgs := roachpb.EndTransactionRequest{Commit: true}
+	etArgs.InFlightWrites = []roachpb.SequencedWrite{{Key: keyA, Sequence: 1}}
+	ba.Add(&putArgs, &etArgs)
+
+	var stagingTxn roachpb.Transaction
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Equal(t, 2, len(ba.Requests))
+		et := ba.Requests[1].GetInner().(*roachpb.EndTransactionRequest)
+		require.True(t, et.IsParallelCommit())
+
+		// The EndTransaction request staged the transaction, but the write
+		// failed.
+		stagingTxn = ba.Txn.Clone()
+		stagingTxn.Status = roachpb.STAGING
+		return nil, roachpb.NewErrorWithTxn(&roachpb.WriteTooOldError{
+			Timestamp:       stagingTxn.Timestamp,
+			ActualTimestamp: stagingTxn.Timestamp.Next(),
+		}, &stagingTxn)
+	})
+	mockNonTxnSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		t.Errorf("unexpected explicit commit: %s", ba)
+		return nil, roachpb.NewErrorf("unexpected explicit commit")
+	})
+
+	br, pErr := tc.SendLocked(ctx, ba)
+	require.Nil(t, br)
+	require.NotNil(t, pErr)
+	require.IsType(t, &roachpb.WriteTooOldError{}, pErr.GetDetail())
+	require.Equal(t, roachpb.PENDING, pErr.GetTxn().Status)
+	require.Equal(t, txn.ID, pErr.GetTxn().ID)
+	// The transaction returned by the wrapped sender is not modified.
+	require.Equal(t, roachpb.STAGING, stagingTxn.Status)
+}
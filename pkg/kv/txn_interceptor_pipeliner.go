@@ -121,11 +121,12 @@ var pipelinedWritesMaxBatchSize = settings.RegisterNonNegativeIntSetting(
 //    they finish consensus without any extra RPCs.
 // So far, none of these approaches have been integrated.
 //
-// [1] A proposal called "parallel commits" (#24194) exists that would allow all
-//     QueryIntent requests and the EndTransaction request that they are prepended
-//     to to be sent by the DistSender in parallel. This would help with this
-//     issue by hiding the cost of the QueryIntent requests behind the cost of the
-//     "staging" EndTransaction request.
+// [1] With "parallel commits" (#24194), the txnPipeliner declares all
+//     outstanding writes as in-flight writes on the EndTransaction request.
+//     When the txnCommitter permits it, the QueryIntent requests and the
+//     EndTransaction request that they are prepended to are then sent by the
+//     DistSender in parallel. This hides the cost of the QueryIntent requests
+//     behind the cost of the "staging" EndTransaction request.
 //
 type txnPipeliner struct {
 	st       *cluster.Settings
@@ -306,12 +307,52 @@ func (tp *txnPipeliner) chainToOutstandingWrites(ba roachpb.BatchRequest) roachp
 		}
 	}
 
+	// If the batch commits the transaction, declare the writes that the commit
+	// depends on as in-flight writes on the EndTransaction request.
+	if rArgs, hasET := ba.GetArg(roachpb.EndTransaction); hasET {
+		if et := rArgs.(*roachpb.EndTransactionRequest); et.Commit {
+			tp.attachInFlightWritesToEndTxn(ba, et)
+		}
+	}
+
 	// Set the batch's AsyncConsensus flag based on whether AsyncConsensus is
 	// permitted for the batch.
 	ba.AsyncConsensus = asyncConsensus
 	return ba
 }
 
+// attachInFlightWritesToEndTxn populates the InFlightWrites of the provided
+// committing EndTransaction request with all outstanding writes, which the
+// batch proves using QueryIntent requests, and with all point writes performed
+// by the batch itself. If the commit is performed in parallel with these
+// writes, the transaction is implicitly committed once all of them succeed.
+// The txnCommitter strips the in-flight writes from the request if a parallel
+// commit is not possible.
+func (tp *txnPipeliner) attachInFlightWritesToEndTxn(
+	ba roachpb.BatchRequest, et *roachpb.EndTransactionRequest,
+) {
+	et.InFlightWrites = nil
+	if tp.outstandingWritesLen() > 0 {
+		tp.outstandingWrites.Ascend(func(item btree.Item) bool {
+			w := item.(*outstandingWrite)
+			et.InFlightWrites = append(et.InFlightWrites, w.SequencedWrite)
+			return true
+		})
+	}
+	for _, ru := range ba.Requests {
+		req := ru.GetInner()
+		if req.Method() == roachpb.BeginTransaction {
+			continue
+		}
+		if roachpb.IsTransactionWrite(req) && !roachpb.IsRange(req) {
+			header := req.Header()
+			et.InFlightWrites = append(et.InFlightWrites, roachpb.SequencedWrite{
+				Key: header.Key, Sequence: header.Sequence,
+			})
+		}
+	}
+}
+
 // updateOutstandingWrites reads the response for the given request and uses
 // it to update the tracked outstanding write set. It does so by performing
 // two actions:
@@ -363,6 +404,39 @@ func (tp *txnPipeliner) updateOutstandingWrites(
 	return br
 }
 
+// proveOutstandingWritesLocked proves all outstanding writes by sending a
+// QueryIntent request for each of them in the provided batch, which is
+// expected to carry the transaction but no requests. Once it returns without
+// error, no writes remain outstanding. It is used before operations that need
+// all of the transaction's writes to have landed, like rolling back to a
+// savepoint.
+func (tp *txnPipeliner) proveOutstandingWritesLocked(
+	ctx context.Context, ba roachpb.BatchRequest,
+) (*roachpb.BatchResponse, *roachpb.Error) {
+	if tp.outstandingWritesLen() == 0 {
+		return &roachpb.BatchResponse{}, nil
+	}
+	tp.outstandingWrites.Ascend(func(item btree.Item) bool {
+		w := item.(*outstandingWrite)
+		meta := ba.Txn.TxnMeta
+		meta.Sequence = w.Sequence
+		ba.Add(&roachpb.QueryIntentRequest{
+			RequestHeader: roachpb.RequestHeader{
+				Key: w.Key,
+			},
+			Txn:       meta,
+			IfMissing: roachpb.QueryIntentRequest_RETURN_ERROR,
+		})
+		return true
+	})
+
+	br, pErr := tp.wrapped.SendLocked(ctx, ba)
+	if pErr != nil {
+		return nil, tp.adjustError(ctx, ba, pErr)
+	}
+	return tp.updateOutstandingWrites(ctx, ba, br), nil
+}
+
 // adjustError adjusts the provided error based on the request that caused it.
 // It transforms any IntentMissingError into a TransactionRetryError and fixes
 // the error's index position.
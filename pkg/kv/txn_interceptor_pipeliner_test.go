@@ -218,6 +218,14 @@ func TestTxnPipelinerTrackOutstandingWrites(t *testing.T) {
 		require.Equal(t, int32(3), qiReq2.Txn.Sequence)
 		require.Equal(t, int32(5), qiReq3.Txn.Sequence)
 
+		etReq := ba.Requests[4].GetInner().(*roachpb.EndTransactionRequest)
+		require.Equal(t, []roachpb.SequencedWrite{
+			{Key: keyA, Sequence: 2},
+			{Key: keyB, Sequence: 3},
+			{Key: keyC, Sequence: 5},
+			{Key: keyD, Sequence: 6},
+		}, etReq.InFlightWrites)
+
 		br = ba.CreateReply()
 		br.Txn = ba.Txn
 		br.Txn.Status = roachpb.COMMITTED
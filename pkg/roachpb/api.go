@@ -527,6 +527,9 @@ func (*QueryTxnRequest) Method() Method { return QueryTxn }
 // Method implements the Request interface.
 func (*QueryIntentRequest) Method() Method { return QueryIntent }
 
+// Method implements the Request interface.
+func (*RecoverTxnRequest) Method() Method { return RecoverTxn }
+
 // Method implements the Request interface.
 func (*ResolveIntentRequest) Method() Method { return ResolveIntent }
 
@@ -653,6 +656,13 @@ func (btr *BeginTransactionRequest) ShallowCopy() Request {
 	return &shallowCopy
 }
 
+// IsParallelCommit returns whether the EndTransaction request is attempting to
+// perform a parallel commit. See txn_interceptor_committer.go for a discussion
+// about parallel commits.
+func (etr *EndTransactionRequest) IsParallelCommit() bool {
+	return etr.Commit && len(etr.InFlightWrites) > 0
+}
+
 // ShallowCopy implements the Request interface.
 func (etr *EndTransactionRequest) ShallowCopy() Request {
 	shallowCopy := *etr
@@ -719,6 +729,12 @@ func (pir *QueryIntentRequest) ShallowCopy() Request {
 	return &shallowCopy
 }
 
+// ShallowCopy implements the Request interface.
+func (rtr *RecoverTxnRequest) ShallowCopy() Request {
+	shallowCopy := *rtr
+	return &shallowCopy
+}
+
 // ShallowCopy implements the Request interface.
 func (rir *ResolveIntentRequest) ShallowCopy() Request {
 	shallowCopy := *rir
@@ -1011,9 +1027,24 @@ func (drr *DeleteRangeRequest) flags() int {
 // Note that ClearRange commands cannot be part of a transaction as
 // they clear all MVCC versions.
 func (*ClearRangeRequest) flags() int { return isWrite | isRange | isAlone }
-func (*ScanRequest) flags() int       { return isRead | isRange | isTxn | updatesReadTSCache | needsRefresh }
-func (*ReverseScanRequest) flags() int {
-	return isRead | isRange | isReverse | isTxn | updatesReadTSCache | needsRefresh
+
+// flagForKeyLocking returns the additional flags for Scan and ReverseScan
+// requests that lock the keys they return. Such requests lay down
+// intents, so they are treated as transactional writes in addition to
+// reads.
+func flagForKeyLocking(str KeyLockingStrength) int {
+	if str == NO_KEY_LOCKING {
+		return 0
+	}
+	return isWrite | isTxnWrite | consultsTSCache
+}
+
+func (r *ScanRequest) flags() int {
+	return isRead | isRange | isTxn | updatesReadTSCache | needsRefresh | flagForKeyLocking(r.KeyLocking)
+}
+func (r *ReverseScanRequest) flags() int {
+	return isRead | isRange | isReverse | isTxn | updatesReadTSCache | needsRefresh |
+		flagForKeyLocking(r.KeyLocking)
 }
 func (*BeginTransactionRequest) flags() int { return isWrite | isTxn }
 
@@ -1045,6 +1076,10 @@ func (*ResolveIntentRangeRequest) flags() int { return isWrite | isRange }
 func (*TruncateLogRequest) flags() int        { return isWrite }
 func (*MergeRequest) flags() int              { return isWrite }
 
+// RecoverTxnRequest updates the write timestamp cache when it aborts a
+// transaction, which prevents the transaction record from being rewritten.
+func (*RecoverTxnRequest) flags() int { return isWrite | isAlone | updatesWriteTSCache }
+
 func (*RequestLeaseRequest) flags() int {
 	return isWrite | isAlone | skipLeaseCheck
 }
@@ -1131,3 +1166,10 @@ func (e *RangeFeedEvent) MustSetValue(value interface{}) {
 		panic(fmt.Sprintf("%T excludes %T", e, value))
 	}
 }
+
+// Changes returns the changes requested by this AdminChangeReplicasRequest,
+// taking the deprecated ChangeType and Targets fields into account.
+func (acrr *AdminChangeReplicasRequest) Changes() ReplicationChanges {
+	chgs := MakeReplicationChanges(acrr.ChangeType, acrr.Targets...)
+	return append(chgs, acrr.InternalChanges...)
+}
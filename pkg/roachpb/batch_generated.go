@@ -70,6 +70,8 @@ func (ru ErrorDetail) GetInner() error {
 		return t.MergeInProgress
 	case *ErrorDetail_RangefeedRetry:
 		return t.RangefeedRetry
+	case *ErrorDetail_IndeterminateCommit:
+		return t.IndeterminateCommit
 	default:
 		return nil
 	}
@@ -146,6 +148,8 @@ func (ru RequestUnion) GetInner() Request {
 		return t.QueryTxn
 	case *RequestUnion_QueryIntent:
 		return t.QueryIntent
+	case *RequestUnion_RecoverTxn:
+		return t.RecoverTxn
 	case *RequestUnion_AdminScatter:
 		return t.AdminScatter
 	case *RequestUnion_AddSstable:
@@ -234,6 +238,8 @@ func (ru ResponseUnion) GetInner() Response {
 		return t.QueryTxn
 	case *ResponseUnion_QueryIntent:
 		return t.QueryIntent
+	case *ResponseUnion_RecoverTxn:
+		return t.RecoverTxn
 	case *ResponseUnion_AdminScatter:
 		return t.AdminScatter
 	case *ResponseUnion_AddSstable:
@@ -315,6 +321,8 @@ func (ru *ErrorDetail) SetInner(r error) bool {
 		union = &ErrorDetail_MergeInProgress{t}
 	case *RangeFeedRetryError:
 		union = &ErrorDetail_RangefeedRetry{t}
+	case *IndeterminateCommitError:
+		union = &ErrorDetail_IndeterminateCommit{t}
 	default:
 		return false
 	}
@@ -394,6 +402,8 @@ func (ru *RequestUnion) SetInner(r Request) bool {
 		union = &RequestUnion_QueryTxn{t}
 	case *QueryIntentRequest:
 		union = &RequestUnion_QueryIntent{t}
+	case *RecoverTxnRequest:
+		union = &RequestUnion_RecoverTxn{t}
 	case *AdminScatterRequest:
 		union = &RequestUnion_AdminScatter{t}
 	case *AddSSTableRequest:
@@ -485,6 +495,8 @@ func (ru *ResponseUnion) SetInner(r Response) bool {
 		union = &ResponseUnion_QueryTxn{t}
 	case *QueryIntentResponse:
 		union = &ResponseUnion_QueryIntent{t}
+	case *RecoverTxnResponse:
+		union = &ResponseUnion_RecoverTxn{t}
 	case *AdminScatterResponse:
 		union = &ResponseUnion_AdminScatter{t}
 	case *AddSSTableResponse:
@@ -506,7 +518,7 @@ func (ru *ResponseUnion) SetInner(r Response) bool {
 	return true
 }
 
-type reqCounts [41]int32
+type reqCounts [42]int32
 
 // getReqCounts returns the number of times each
 // request type appears in the batch.
@@ -582,20 +594,22 @@ func (ba *BatchRequest) getReqCounts() reqCounts {
 			counts[32]++
 		case *RequestUnion_QueryIntent:
 			counts[33]++
-		case *RequestUnion_AdminScatter:
+		case *RequestUnion_RecoverTxn:
 			counts[34]++
-		case *RequestUnion_AddSstable:
+		case *RequestUnion_AdminScatter:
 			counts[35]++
-		case *RequestUnion_RecomputeStats:
+		case *RequestUnion_AddSstable:
 			counts[36]++
-		case *RequestUnion_Refresh:
+		case *RequestUnion_RecomputeStats:
 			counts[37]++
-		case *RequestUnion_RefreshRange:
+		case *RequestUnion_Refresh:
 			counts[38]++
-		case *RequestUnion_Subsume:
+		case *RequestUnion_RefreshRange:
 			counts[39]++
-		case *RequestUnion_RangeStats:
+		case *RequestUnion_Subsume:
 			counts[40]++
+		case *RequestUnion_RangeStats:
+			counts[41]++
 		default:
 			panic(fmt.Sprintf("unsupported request: %+v", ru))
 		}
@@ -638,6 +652,7 @@ var requestNames = []string{
 	"Import",
 	"QueryTxn",
 	"QueryIntent",
+	"RecoverTxn",
 	"AdmScatter",
 	"AddSstable",
 	"RecomputeStats",
@@ -812,6 +827,10 @@ type queryIntentResponseAlloc struct {
 	union ResponseUnion_QueryIntent
 	resp  QueryIntentResponse
 }
+type recoverTxnResponseAlloc struct {
+	union ResponseUnion_RecoverTxn
+	resp  RecoverTxnResponse
+}
 type adminScatterResponseAlloc struct {
 	union ResponseUnion_AdminScatter
 	resp  AdminScatterResponse
@@ -884,13 +903,14 @@ func (ba *BatchRequest) CreateReply() *BatchResponse {
 	var buf31 []importResponseAlloc
 	var buf32 []queryTxnResponseAlloc
 	var buf33 []queryIntentResponseAlloc
-	var buf34 []adminScatterResponseAlloc
-	var buf35 []addSSTableResponseAlloc
-	var buf36 []recomputeStatsResponseAlloc
-	var buf37 []refreshResponseAlloc
-	var buf38 []refreshRangeResponseAlloc
-	var buf39 []subsumeResponseAlloc
-	var buf40 []rangeStatsResponseAlloc
+	var buf34 []recoverTxnResponseAlloc
+	var buf35 []adminScatterResponseAlloc
+	var buf36 []addSSTableResponseAlloc
+	var buf37 []recomputeStatsResponseAlloc
+	var buf38 []refreshResponseAlloc
+	var buf39 []refreshRangeResponseAlloc
+	var buf40 []subsumeResponseAlloc
+	var buf41 []rangeStatsResponseAlloc
 
 	for i, r := range ba.Requests {
 		switch r.GetValue().(type) {
@@ -1132,55 +1152,62 @@ func (ba *BatchRequest) CreateReply() *BatchResponse {
 			buf33[0].union.QueryIntent = &buf33[0].resp
 			br.Responses[i].Value = &buf33[0].union
 			buf33 = buf33[1:]
-		case *RequestUnion_AdminScatter:
+		case *RequestUnion_RecoverTxn:
 			if buf34 == nil {
-				buf34 = make([]adminScatterResponseAlloc, counts[34])
+				buf34 = make([]recoverTxnResponseAlloc, counts[34])
 			}
-			buf34[0].union.AdminScatter = &buf34[0].resp
+			buf34[0].union.RecoverTxn = &buf34[0].resp
 			br.Responses[i].Value = &buf34[0].union
 			buf34 = buf34[1:]
-		case *RequestUnion_AddSstable:
+		case *RequestUnion_AdminScatter:
 			if buf35 == nil {
-				buf35 = make([]addSSTableResponseAlloc, counts[35])
+				buf35 = make([]adminScatterResponseAlloc, counts[35])
 			}
-			buf35[0].union.AddSstable = &buf35[0].resp
+			buf35[0].union.AdminScatter = &buf35[0].resp
 			br.Responses[i].Value = &buf35[0].union
 			buf35 = buf35[1:]
-		case *RequestUnion_RecomputeStats:
+		case *RequestUnion_AddSstable:
 			if buf36 == nil {
-				buf36 = make([]recomputeStatsResponseAlloc, counts[36])
+				buf36 = make([]addSSTableResponseAlloc, counts[36])
 			}
-			buf36[0].union.RecomputeStats = &buf36[0].resp
+			buf36[0].union.AddSstable = &buf36[0].resp
 			br.Responses[i].Value = &buf36[0].union
 			buf36 = buf36[1:]
-		case *RequestUnion_Refresh:
+		case *RequestUnion_RecomputeStats:
 			if buf37 == nil {
-				buf37 = make([]refreshResponseAlloc, counts[37])
+				buf37 = make([]recomputeStatsResponseAlloc, counts[37])
 			}
-			buf37[0].union.Refresh = &buf37[0].resp
+			buf37[0].union.RecomputeStats = &buf37[0].resp
 			br.Responses[i].Value = &buf37[0].union
 			buf37 = buf37[1:]
-		case *RequestUnion_RefreshRange:
+		case *RequestUnion_Refresh:
 			if buf38 == nil {
-				buf38 = make([]refreshRangeResponseAlloc, counts[38])
+				buf38 = make([]refreshResponseAlloc, counts[38])
 			}
-			buf38[0].union.RefreshRange = &buf38[0].resp
+			buf38[0].union.Refresh = &buf38[0].resp
 			br.Responses[i].Value = &buf38[0].union
 			buf38 = buf38[1:]
-		case *RequestUnion_Subsume:
+		case *RequestUnion_RefreshRange:
 			if buf39 == nil {
-				buf39 = make([]subsumeResponseAlloc, counts[39])
+				buf39 = make([]refreshRangeResponseAlloc, counts[39])
 			}
-			buf39[0].union.Subsume = &buf39[0].resp
+			buf39[0].union.RefreshRange = &buf39[0].resp
 			br.Responses[i].Value = &buf39[0].union
 			buf39 = buf39[1:]
-		case *RequestUnion_RangeStats:
+		case *RequestUnion_Subsume:
 			if buf40 == nil {
-				buf40 = make([]rangeStatsResponseAlloc, counts[40])
+				buf40 = make([]subsumeResponseAlloc, counts[40])
 			}
-			buf40[0].union.RangeStats = &buf40[0].resp
+			buf40[0].union.Subsume = &buf40[0].resp
 			br.Responses[i].Value = &buf40[0].union
 			buf40 = buf40[1:]
+		case *RequestUnion_RangeStats:
+			if buf41 == nil {
+				buf41 = make([]rangeStatsResponseAlloc, counts[41])
+			}
+			buf41[0].union.RangeStats = &buf41[0].resp
+			br.Responses[i].Value = &buf41[0].union
+			buf41 = buf41[1:]
 		default:
 			panic(fmt.Sprintf("unsupported request: %+v", r))
 		}
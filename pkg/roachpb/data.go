@@ -741,6 +741,13 @@ const (
 	MaxTxnPriority = math.MaxInt32
 )
 
+// IsFinalized determines whether the transaction status is in a finalized
+// state. A finalized state is terminal, meaning that once a transaction
+// enters one of these states, it will never leave it.
+func (ts TransactionStatus) IsFinalized() bool {
+	return ts == COMMITTED || ts == ABORTED
+}
+
 // MakeTransaction creates a new transaction. The transaction key is
 // composed using the specified baseKey (for locality with data
 // affected by the transaction) and a random ID to guarantee
@@ -825,6 +832,7 @@ func (t Transaction) Clone() Transaction {
 	// Note that we're not cloning the span keys under the assumption that the
 	// keys themselves are not mutable.
 	t.Intents = append([]Span(nil), t.Intents...)
+	t.InFlightWrites = append([]SequencedWrite(nil), t.InFlightWrites...)
 	return t
 }
 
@@ -985,8 +993,25 @@ func (t *Transaction) Update(o *Transaction) {
 	if len(t.Key) == 0 {
 		t.Key = o.Key
 	}
-	if o.Status != PENDING {
+	switch o.Status {
+	case PENDING:
+		// A PENDING transaction doesn't override the status, unless the
+		// receiver was staging a commit in an epoch that o has since moved
+		// past.
+		if t.Status == STAGING && t.Epoch < o.Epoch {
+			t.Status = PENDING
+			t.InFlightWrites = nil
+		}
+	case STAGING:
+		// A STAGING transaction can't override a finalized status or a later
+		// epoch.
+		if !t.Status.IsFinalized() && t.Epoch <= o.Epoch {
+			t.Status = STAGING
+			t.InFlightWrites = o.InFlightWrites
+		}
+	default:
 		t.Status = o.Status
+		t.InFlightWrites = nil
 	}
 
 	// If the epoch or refreshed timestamp move forward, overwrite
@@ -1127,6 +1152,7 @@ func (t *Transaction) AsRecord() TransactionRecord {
 	tr.LastHeartbeat = t.LastHeartbeat
 	tr.OrigTimestamp = t.OrigTimestamp
 	tr.Intents = t.Intents
+	tr.InFlightWrites = t.InFlightWrites
 	return tr
 }
 
@@ -1140,6 +1166,7 @@ func (tr *TransactionRecord) AsTransaction() Transaction {
 	t.LastHeartbeat = tr.LastHeartbeat
 	t.OrigTimestamp = tr.OrigTimestamp
 	t.Intents = tr.Intents
+	t.InFlightWrites = tr.InFlightWrites
 	return t
 }
 
@@ -1281,7 +1308,41 @@ func writeTooOldRetryTimestamp(txn *Transaction, err *WriteTooOldError) hlc.Time
 var _ fmt.Stringer = &ChangeReplicasTrigger{}
 
 func (crt ChangeReplicasTrigger) String() string {
-	return fmt.Sprintf("%s(%s): updated=%s next=%d", crt.ChangeType, crt.Replica, crt.UpdatedReplicas, crt.NextReplicaID)
+	if crt.Replica.ReplicaID != 0 {
+		return fmt.Sprintf("%s(%s): updated=%s next=%d", crt.ChangeType, crt.Replica, crt.UpdatedReplicas, crt.NextReplicaID)
+	}
+	added, removed := crt.Added(), crt.Removed()
+	if len(added) == 0 && len(removed) == 0 {
+		return fmt.Sprintf("LEAVE_JOINT: updated=%s next=%d", crt.UpdatedReplicas, crt.NextReplicaID)
+	}
+	return fmt.Sprintf("ENTER_JOINT(%s %s %s %s): updated=%s next=%d",
+		ADD_REPLICA, added, REMOVE_REPLICA, removed, crt.UpdatedReplicas, crt.NextReplicaID)
+}
+
+// Added returns the replicas added by this change, including replicas which
+// are promoted from learners to voters.
+func (crt ChangeReplicasTrigger) Added() []ReplicaDescriptor {
+	if crt.Replica.ReplicaID != 0 {
+		// A trigger written by a node which predates atomic replication
+		// changes. It carries exactly one change.
+		if crt.ChangeType == ADD_REPLICA {
+			return []ReplicaDescriptor{crt.Replica}
+		}
+		return nil
+	}
+	return crt.InternalAddedReplicas
+}
+
+// Removed returns the replicas removed by this change, including voters which
+// are demoted to learners.
+func (crt ChangeReplicasTrigger) Removed() []ReplicaDescriptor {
+	if crt.Replica.ReplicaID != 0 {
+		if crt.ChangeType == REMOVE_REPLICA {
+			return []ReplicaDescriptor{crt.Replica}
+		}
+		return nil
+	}
+	return crt.InternalRemovedReplicas
 }
 
 // LeaseSequence is a custom type for a lease sequence number.
@@ -483,7 +483,7 @@ var nonZeroTxn = Transaction{
 		Sequence:  123,
 	},
 	Name:                     "name",
-	Status:                   COMMITTED,
+	Status:                   STAGING,
 	LastHeartbeat:            makeTS(1, 2),
 	OrigTimestamp:            makeTS(30, 31),
 	RefreshedTimestamp:       makeTS(20, 22),
@@ -494,6 +494,7 @@ var nonZeroTxn = Transaction{
 	Intents:                  []Span{{Key: []byte("a"), EndKey: []byte("b")}},
 	EpochZeroTimestamp:       makeTS(1, 1),
 	OrigTimestampWasObserved: true,
+	InFlightWrites:           []SequencedWrite{{Key: []byte("c"), Sequence: 1}},
 }
 
 func TestTransactionUpdate(t *testing.T) {
@@ -537,6 +538,51 @@ func TestTransactionUpdateEpochZero(t *testing.T) {
 	}
 }
 
+// TestTransactionUpdateStaging verifies how Update merges the STAGING status
+// and the accompanying in-flight writes.
+func TestTransactionUpdateStaging(t *testing.T) {
+	writes := []SequencedWrite{{Key: Key("a"), Sequence: 1}}
+	testCases := []struct {
+		status, oStatus TransactionStatus
+		epoch, oEpoch   uint32
+		expStatus       TransactionStatus
+		expWrites       bool
+	}{
+		{PENDING, STAGING, 0, 0, STAGING, true},
+		{PENDING, STAGING, 1, 0, PENDING, false},
+		{STAGING, STAGING, 0, 1, STAGING, true},
+		{STAGING, PENDING, 0, 0, STAGING, true},
+		{STAGING, PENDING, 0, 1, PENDING, false},
+		{STAGING, COMMITTED, 0, 0, COMMITTED, false},
+		{STAGING, ABORTED, 0, 0, ABORTED, false},
+		{COMMITTED, STAGING, 0, 0, COMMITTED, false},
+		{ABORTED, STAGING, 0, 1, ABORTED, false},
+	}
+	for i, c := range testCases {
+		txn := MakeTransaction("test", Key("k"), 0, makeTS(1, 0), 0)
+		txn.Status = c.status
+		txn.Epoch = c.epoch
+		if c.status == STAGING {
+			txn.InFlightWrites = writes
+		}
+		o := txn.Clone()
+		o.Status = c.oStatus
+		o.Epoch = c.oEpoch
+		o.InFlightWrites = nil
+		if c.oStatus == STAGING {
+			o.InFlightWrites = writes
+		}
+
+		txn.Update(&o)
+		if txn.Status != c.expStatus {
+			t.Errorf("%d: expected status %s, found %s", i, c.expStatus, txn.Status)
+		}
+		if hasWrites := len(txn.InFlightWrites) > 0; hasWrites != c.expWrites {
+			t.Errorf("%d: expected in-flight writes %t, found %v", i, c.expWrites, txn.InFlightWrites)
+		}
+	}
+}
+
 func TestTransactionClone(t *testing.T) {
 	txn := nonZeroTxn.Clone()
 
@@ -547,6 +593,7 @@ func TestTransactionClone(t *testing.T) {
 	// listed below. If this test fails, please update the list below and/or
 	// Transaction.Clone().
 	expFields := []string{
+		"InFlightWrites.Key",
 		"Intents.EndKey",
 		"Intents.Key",
 		"TxnMeta.Key",
@@ -595,6 +642,9 @@ func TestTransactionRecordRoundtrips(t *testing.T) {
 	if !reflect.DeepEqual(txnRecord.Intents, txn.Intents) {
 		t.Fatalf("txnRecord.Intents = %v, txn.Intents = %v", txnRecord.Intents, txn.Intents)
 	}
+	if !reflect.DeepEqual(txnRecord.InFlightWrites, txn.InFlightWrites) {
+		t.Fatalf("txnRecord.InFlightWrites = %v, txn.InFlightWrites = %v", txnRecord.InFlightWrites, txn.InFlightWrites)
+	}
 
 	// Verify that converting through a Transaction message and back
 	// to a TransactionRecord is a lossless round trip.
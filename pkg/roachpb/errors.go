@@ -727,3 +727,22 @@ func (e *RangeFeedRetryError) message(pErr *Error) string {
 }
 
 var _ ErrorDetailInterface = &RangeFeedRetryError{}
+
+// NewIndeterminateCommitError initializes a new IndeterminateCommitError.
+func NewIndeterminateCommitError(txn Transaction) *IndeterminateCommitError {
+	return &IndeterminateCommitError{StagingTxn: txn}
+}
+
+func (e *IndeterminateCommitError) Error() string {
+	return e.message(nil)
+}
+
+func (e *IndeterminateCommitError) message(pErr *Error) string {
+	s := fmt.Sprintf("found txn in indeterminate STAGING state %s", e.StagingTxn)
+	if pErr == nil {
+		return s
+	}
+	return fmt.Sprintf("txn %s %s", pErr.GetTxn(), s)
+}
+
+var _ ErrorDetailInterface = &IndeterminateCommitError{}
@@ -133,6 +133,55 @@ func (r RangeDescriptor) GetReplicaDescriptorByID(replicaID ReplicaID) (ReplicaD
 	return ReplicaDescriptor{}, false
 }
 
+// Voters returns the replicas of the range which participate in Raft
+// elections and count towards its quorum once any in-progress atomic
+// replication change completes. This includes incoming voters but not
+// demoting ones.
+func (r RangeDescriptor) Voters() []ReplicaDescriptor {
+	return r.filterReplicas(func(typ ReplicaType) bool {
+		return typ == ReplicaType_VOTER || typ == ReplicaType_VOTER_INCOMING
+	})
+}
+
+// Learners returns the replicas of the range which are Raft learners. A
+// learner receives the Raft log but does not vote. It is promoted to a voter
+// once it has caught up.
+func (r RangeDescriptor) Learners() []ReplicaDescriptor {
+	return r.filterReplicas(func(typ ReplicaType) bool {
+		return typ == ReplicaType_LEARNER
+	})
+}
+
+// NonVoters returns the non-voting replicas of the range. Like learners, they
+// receive the Raft log without voting, but they are never promoted.
+func (r RangeDescriptor) NonVoters() []ReplicaDescriptor {
+	return r.filterReplicas(func(typ ReplicaType) bool {
+		return typ == ReplicaType_NON_VOTER
+	})
+}
+
+// InAtomicReplicationChange returns true if the range is in a joint
+// configuration, i.e. if any of its replicas are incoming or demoting voters.
+func (r RangeDescriptor) InAtomicReplicationChange() bool {
+	for _, rep := range r.Replicas {
+		switch rep.GetType() {
+		case ReplicaType_VOTER_INCOMING, ReplicaType_VOTER_DEMOTING:
+			return true
+		}
+	}
+	return false
+}
+
+func (r RangeDescriptor) filterReplicas(pred func(ReplicaType) bool) []ReplicaDescriptor {
+	var reps []ReplicaDescriptor
+	for _, rep := range r.Replicas {
+		if pred(rep.GetType()) {
+			reps = append(reps, rep)
+		}
+	}
+	return reps
+}
+
 // IsInitialized returns false if this descriptor represents an
 // uninitialized range.
 // TODO(bdarnell): unify this with Validate().
@@ -208,6 +257,54 @@ func (r ReplicationTarget) String() string {
 	return fmt.Sprintf("n%d,s%d", r.NodeID, r.StoreID)
 }
 
+// ReplicationChanges is a list of ReplicationChange.
+type ReplicationChanges []ReplicationChange
+
+// MakeReplicationChanges returns a slice of changes of the given type with an
+// item for each target.
+func MakeReplicationChanges(
+	changeType ReplicaChangeType, targets ...ReplicationTarget,
+) ReplicationChanges {
+	chgs := make(ReplicationChanges, 0, len(targets))
+	for _, target := range targets {
+		chgs = append(chgs, ReplicationChange{
+			ChangeType: changeType,
+			Target:     target,
+		})
+	}
+	return chgs
+}
+
+func (c ReplicationChanges) byType(typ ReplicaChangeType) []ReplicationTarget {
+	var sl []ReplicationTarget
+	for _, chg := range c {
+		if chg.ChangeType == typ {
+			sl = append(sl, chg.Target)
+		}
+	}
+	return sl
+}
+
+// Additions returns the targets of all ADD_REPLICA changes.
+func (c ReplicationChanges) Additions() []ReplicationTarget {
+	return c.byType(ADD_REPLICA)
+}
+
+// Removals returns the targets of all REMOVE_REPLICA changes.
+func (c ReplicationChanges) Removals() []ReplicationTarget {
+	return c.byType(REMOVE_REPLICA)
+}
+
+// NonVoterAdditions returns the targets of all ADD_NON_VOTER changes.
+func (c ReplicationChanges) NonVoterAdditions() []ReplicationTarget {
+	return c.byType(ADD_NON_VOTER)
+}
+
+// NonVoterRemovals returns the targets of all REMOVE_NON_VOTER changes.
+func (c ReplicationChanges) NonVoterRemovals() []ReplicationTarget {
+	return c.byType(REMOVE_NON_VOTER)
+}
+
 func (r ReplicaDescriptor) String() string {
 	var buf bytes.Buffer
 	fmt.Fprintf(&buf, "(n%d,s%d):", r.NodeID, r.StoreID)
@@ -216,9 +313,21 @@ func (r ReplicaDescriptor) String() string {
 	} else {
 		fmt.Fprintf(&buf, "%d", r.ReplicaID)
 	}
+	if typ := r.GetType(); typ != ReplicaType_VOTER {
+		buf.WriteString(typ.String())
+	}
 	return buf.String()
 }
 
+// GetType returns the type of the replica. Replicas without an explicit type
+// are voters.
+func (r ReplicaDescriptor) GetType() ReplicaType {
+	if r.Type != nil {
+		return *r.Type
+	}
+	return ReplicaType_VOTER
+}
+
 // Validate performs some basic validation of the contents of a replica descriptor.
 func (r ReplicaDescriptor) Validate() error {
 	if r.NodeID == 0 {
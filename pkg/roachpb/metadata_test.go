@@ -92,6 +92,103 @@ func TestRangeDescriptorMissingReplica(t *testing.T) {
 	}
 }
 
+func TestRangeDescriptorVotersLearners(t *testing.T) {
+	desc := RangeDescriptor{
+		Replicas: []ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 2, ReplicaID: 2, Type: ReplicaType_LEARNER.Enum()},
+			{NodeID: 3, StoreID: 3, ReplicaID: 3, Type: ReplicaType_VOTER.Enum()},
+		},
+	}
+	if voters, exp := desc.Voters(), []ReplicaDescriptor{desc.Replicas[0], desc.Replicas[2]}; !reflect.DeepEqual(voters, exp) {
+		t.Errorf("expected voters %v, got %v", exp, voters)
+	}
+	if learners, exp := desc.Learners(), []ReplicaDescriptor{desc.Replicas[1]}; !reflect.DeepEqual(learners, exp) {
+		t.Errorf("expected learners %v, got %v", exp, learners)
+	}
+	for i, exp := range []string{"(n1,s1):1", "(n2,s2):2LEARNER", "(n3,s3):3"} {
+		if s := desc.Replicas[i].String(); s != exp {
+			t.Errorf("%d: expected %q, got %q", i, exp, s)
+		}
+	}
+}
+
+func TestRangeDescriptorInAtomicReplicationChange(t *testing.T) {
+	desc := RangeDescriptor{
+		Replicas: []ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 2, ReplicaID: 2, Type: ReplicaType_VOTER_DEMOTING.Enum()},
+			{NodeID: 3, StoreID: 3, ReplicaID: 3, Type: ReplicaType_VOTER_INCOMING.Enum()},
+			{NodeID: 4, StoreID: 4, ReplicaID: 4, Type: ReplicaType_LEARNER.Enum()},
+		},
+	}
+	if !desc.InAtomicReplicationChange() {
+		t.Errorf("expected %s to be in an atomic replication change", desc)
+	}
+	if voters, exp := desc.Voters(), []ReplicaDescriptor{desc.Replicas[0], desc.Replicas[2]}; !reflect.DeepEqual(voters, exp) {
+		t.Errorf("expected voters %v, got %v", exp, voters)
+	}
+	if learners, exp := desc.Learners(), []ReplicaDescriptor{desc.Replicas[3]}; !reflect.DeepEqual(learners, exp) {
+		t.Errorf("expected learners %v, got %v", exp, learners)
+	}
+	desc.Replicas = []ReplicaDescriptor{desc.Replicas[0], desc.Replicas[3]}
+	if desc.InAtomicReplicationChange() {
+		t.Errorf("expected %s not to be in an atomic replication change", desc)
+	}
+}
+
+func TestReplicationChanges(t *testing.T) {
+	t1 := ReplicationTarget{NodeID: 1, StoreID: 1}
+	t2 := ReplicationTarget{NodeID: 2, StoreID: 2}
+	t3 := ReplicationTarget{NodeID: 3, StoreID: 3}
+	req := AdminChangeReplicasRequest{
+		ChangeType:      ADD_REPLICA,
+		Targets:         []ReplicationTarget{t1},
+		InternalChanges: MakeReplicationChanges(REMOVE_REPLICA, t2, t3),
+	}
+	chgs := req.Changes()
+	if exp := []ReplicationTarget{t1}; !reflect.DeepEqual(chgs.Additions(), exp) {
+		t.Errorf("expected additions %v, got %v", exp, chgs.Additions())
+	}
+	if exp := []ReplicationTarget{t2, t3}; !reflect.DeepEqual(chgs.Removals(), exp) {
+		t.Errorf("expected removals %v, got %v", exp, chgs.Removals())
+	}
+
+	chgs = append(chgs, MakeReplicationChanges(ADD_NON_VOTER, t2)...)
+	chgs = append(chgs, MakeReplicationChanges(REMOVE_NON_VOTER, t3)...)
+	if exp := []ReplicationTarget{t1}; !reflect.DeepEqual(chgs.Additions(), exp) {
+		t.Errorf("expected additions %v, got %v", exp, chgs.Additions())
+	}
+	if exp := []ReplicationTarget{t2}; !reflect.DeepEqual(chgs.NonVoterAdditions(), exp) {
+		t.Errorf("expected non-voter additions %v, got %v", exp, chgs.NonVoterAdditions())
+	}
+	if exp := []ReplicationTarget{t3}; !reflect.DeepEqual(chgs.NonVoterRemovals(), exp) {
+		t.Errorf("expected non-voter removals %v, got %v", exp, chgs.NonVoterRemovals())
+	}
+}
+
+func TestRangeDescriptorNonVoters(t *testing.T) {
+	desc := RangeDescriptor{
+		Replicas: []ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 2, ReplicaID: 2, Type: ReplicaType_NON_VOTER.Enum()},
+			{NodeID: 3, StoreID: 3, ReplicaID: 3, Type: ReplicaType_LEARNER.Enum()},
+		},
+	}
+	if desc.InAtomicReplicationChange() {
+		t.Errorf("expected %s not to be in an atomic replication change", desc)
+	}
+	if voters, exp := desc.Voters(), []ReplicaDescriptor{desc.Replicas[0]}; !reflect.DeepEqual(voters, exp) {
+		t.Errorf("expected voters %v, got %v", exp, voters)
+	}
+	if nonVoters, exp := desc.NonVoters(), []ReplicaDescriptor{desc.Replicas[1]}; !reflect.DeepEqual(nonVoters, exp) {
+		t.Errorf("expected non-voters %v, got %v", exp, nonVoters)
+	}
+	if learners, exp := desc.Learners(), []ReplicaDescriptor{desc.Replicas[2]}; !reflect.DeepEqual(learners, exp) {
+		t.Errorf("expected learners %v, got %v", exp, learners)
+	}
+}
+
 // TestLocalityConversions verifies that setting the value from the CLI short
 // hand format works correctly.
 func TestLocalityConversions(t *testing.T) {
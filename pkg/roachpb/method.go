@@ -96,6 +96,10 @@ const (
 	QueryTxn
 	// QueryIntent checks whether the specified intent exists.
 	QueryIntent
+	// RecoverTxn recovers the status of a transaction that was found in the
+	// STAGING state after its coordinator failed to finalize it. It moves the
+	// transaction to either the COMMITTED or the ABORTED state.
+	RecoverTxn
 	// ResolveIntent resolves existing write intents for a key.
 	ResolveIntent
 	// ResolveIntentRange resolves existing write intents for a key range.
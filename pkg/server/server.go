@@ -60,6 +60,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/bulk"
 	"github.com/cockroachdb/cockroach/pkg/storage/closedts/container"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptprovider"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
 	"github.com/cockroachdb/cockroach/pkg/ts"
 	"github.com/cockroachdb/cockroach/pkg/ui"
@@ -193,6 +195,9 @@ type Server struct {
 	adminMemMetrics    sql.MemoryMetrics
 	// sqlMemMetrics are used to track memory usage of sql sessions.
 	sqlMemMetrics sql.MemoryMetrics
+	// protectedtsProvider stores the protected timestamp records and keeps the
+	// view of them which is consulted by the GC queue.
+	protectedtsProvider protectedts.Provider
 }
 
 // NewServer creates a Server from a server.Config.
@@ -426,6 +431,15 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 	// Similarly for execCfg.
 	var execCfg sql.ExecutorConfig
 
+	// The protected timestamp records are stored in a system table, which is
+	// accessed through the internal executor.
+	s.protectedtsProvider = ptprovider.New(ptprovider.Config{
+		Settings:         st,
+		DB:               s.db,
+		InternalExecutor: internalExecutor,
+		SystemConfig:     s.gossip,
+	})
+
 	// TODO(bdarnell): make StoreConfig configurable.
 	storeCfg := storage.StoreConfig{
 		Settings:                st,
@@ -447,6 +461,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		SQLExecutor:             internalExecutor,
 		LogRangeEvents:          s.cfg.EventLogEnabled,
 		TimeSeriesDataStore:     s.tsDB,
+		ProtectedTimestampCache: s.protectedtsProvider,
 
 		// Initialize the closed timestamp subsystem. Note that it won't
 		// be ready until it is .Start()ed, but the grpc server can be
@@ -535,6 +550,8 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		Gossip:       s.gossip,
 		NodeDialer:   s.nodeDialer,
 		LeaseManager: s.leaseMgr,
+
+		ProtectedTimestampProvider: s.protectedtsProvider,
 	}
 	if distSQLTestingKnobs := s.cfg.TestingKnobs.DistSQL; distSQLTestingKnobs != nil {
 		distSQLCfg.TestingKnobs = *distSQLTestingKnobs.(*distsqlrun.TestingKnobs)
@@ -617,6 +634,8 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		LeaseHolderCache:        s.distSender.LeaseHolderCache(),
 		TestingKnobs:            sqlExecutorTestingKnobs,
 
+		ProtectedTimestampProvider: s.protectedtsProvider,
+
 		DistSQLPlanner: sql.NewDistSQLPlanner(
 			ctx,
 			distsqlrun.Version,
@@ -1645,6 +1664,12 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}
 	log.Infof(ctx, "done ensuring all necessary migrations have run")
+
+	// Start polling the protected timestamp records, which allows the GC queue
+	// to make progress. The records live in a table created by a migration.
+	if err := s.protectedtsProvider.Start(ctx, s.stopper); err != nil {
+		return err
+	}
 	close(serveSQL)
 
 	log.Info(ctx, "serving sql connections")
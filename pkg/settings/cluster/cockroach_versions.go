@@ -63,6 +63,24 @@ const (
 	VersionSequencedReads
 	VersionUnreplicatedRaftTruncatedState // see versionsSingleton for details
 	VersionCreateStats
+	VersionSavepoints
+	VersionMaterializedViews
+	VersionTriggers
+	VersionDeferrableConstraints
+	VersionAlterColumnTypeGeneral
+	VersionSetNotNull
+	VersionParallelCommits
+	VersionLearnerReplicas
+	VersionAtomicChangeReplicas
+	VersionNonVotingReplicas
+	VersionProtectedTimestamps
+	VersionSelectForUpdate
+	VersionUserDefinedFunctions
+	VersionPartialIndexes
+	VersionEnums
+	VersionUserDefinedSchemas
+	VersionTimeTZ
+	VersionTextSearch
 
 	// Add new versions here (step one of two).
 
@@ -412,6 +430,119 @@ var versionsSingleton = keyedVersions([]keyedVersion{
 		Key:     VersionCreateStats,
 		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 7},
 	},
+	{
+		// VersionSavepoints enables nested SQL savepoints, which rely on intent
+		// resolution that can ignore ranges of sequence numbers.
+		Key:     VersionSavepoints,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 8},
+	},
+	{
+		// VersionMaterializedViews enables materialized views, whose
+		// descriptors older nodes would treat as regular views.
+		Key:     VersionMaterializedViews,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 9},
+	},
+	{
+		// VersionTriggers enables CREATE TRIGGER. Older nodes would ignore
+		// the triggers stored on table descriptors when writing rows.
+		Key:     VersionTriggers,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 10},
+	},
+	{
+		// VersionDeferrableConstraints enables deferrable foreign key
+		// constraints, whose checks older nodes would not defer.
+		Key:     VersionDeferrableConstraints,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 11},
+	},
+	{
+		// VersionAlterColumnTypeGeneral enables ALTER COLUMN TYPE conversions
+		// that rewrite the column data, which use a schema change mutation
+		// that older nodes do not understand.
+		Key:     VersionAlterColumnTypeGeneral,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 12},
+	},
+	{
+		// VersionSetNotNull enables ALTER COLUMN SET NOT NULL, which uses a
+		// constraint mutation type that older nodes do not understand.
+		Key:     VersionSetNotNull,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 13},
+	},
+	{
+		// VersionParallelCommits enables parallel commits, which write STAGING
+		// transaction records and RecoverTxn requests that older nodes do not
+		// understand.
+		Key:     VersionParallelCommits,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 14},
+	},
+	{
+		// VersionLearnerReplicas enables adding replicas to a range as Raft
+		// learners, which older nodes do not know how to configure.
+		Key:     VersionLearnerReplicas,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 15},
+	},
+	{
+		// VersionAtomicChangeReplicas enables atomic replication changes using
+		// Raft joint configurations, which older nodes can neither propose nor
+		// apply.
+		Key:     VersionAtomicChangeReplicas,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 16},
+	},
+	{
+		// VersionNonVotingReplicas enables the num_voters and voter_constraints
+		// zone config fields and the NON_VOTER replica type, which older nodes
+		// would add to ranges as voters.
+		Key:     VersionNonVotingReplicas,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 17},
+	},
+	{
+		// VersionProtectedTimestamps enables the creation of protected timestamp
+		// records in the system.protected_ts_records table, which is created by
+		// a migration.
+		Key:     VersionProtectedTimestamps,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 18},
+	},
+	{
+		// VersionSelectForUpdate enables SELECT ... FOR UPDATE/SHARE, which sends
+		// locking scans that older nodes would evaluate without acquiring locks.
+		Key:     VersionSelectForUpdate,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 19},
+	},
+	{
+		// VersionUserDefinedFunctions enables CREATE FUNCTION, which writes
+		// function descriptors that older nodes cannot resolve.
+		Key:     VersionUserDefinedFunctions,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 20},
+	},
+	{
+		// VersionPartialIndexes enables partial indexes, whose entries older nodes
+		// would write for every row regardless of the index predicate.
+		Key:     VersionPartialIndexes,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 21},
+	},
+	{
+		// VersionEnums enables CREATE TYPE ... AS ENUM and columns of enum types, whose
+		// type descriptors older nodes cannot resolve.
+		Key:     VersionEnums,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 22},
+	},
+	{
+		// VersionUserDefinedSchemas enables CREATE SCHEMA, which writes schema
+		// descriptors and three-level namespace entries that older nodes cannot resolve.
+		Key:     VersionUserDefinedSchemas,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 23},
+	},
+	{
+		// VersionTimeTZ enables columns of type TIMETZ and time types with a
+		// precision, which older nodes cannot encode or round.
+		Key:     VersionTimeTZ,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 24},
+	},
+	{
+		// VersionTextSearch enables columns of type TSVECTOR and TSQUERY, which
+		// older nodes cannot encode or index.
+		Key:     VersionTextSearch,
+		Version: roachpb.Version{Major: 2, Minor: 1, Unstable: 25},
+	},
 
 	// Add new versions here (step two of two).
 
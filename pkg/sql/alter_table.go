@@ -21,7 +21,9 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachange"
@@ -295,6 +297,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if dropped {
 				continue
 			}
+			if columnTypeChangeInProgress(n.tableDesc, col.ID) {
+				return fmt.Errorf("column %q in the middle of changing type, try again later", col.Name)
+			}
 
 			// If the dropped column uses a sequence, remove references to it from that sequence.
 			if len(col.UsesSequenceIds) > 0 {
@@ -388,6 +393,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 						containsThisColumn = true
 					}
 				}
+				// The predicate of a partial index cannot be evaluated without the
+				// column either.
+				if used, err := idx.PredicateUsesColumn(n.tableDesc.TableDesc(), col.ID); err != nil {
+					return err
+				} else if used {
+					containsThisColumn = true
+				}
 
 				// Perform the DROP.
 				if containsThisColumn {
@@ -720,6 +732,12 @@ func applyColumnMutation(
 			return err
 		}
 
+		// A USING expression always requires the column data to be
+		// rewritten, whatever the old and new types are.
+		if t.Using != nil {
+			return alterColumnTypeGeneral(tableDesc, col, nextType, t, params)
+		}
+
 		// No-op if the types are Equal.  We don't use Equivalent here
 		// because the user may want to change the visible type of the
 		// column without changing the underlying semantic type.
@@ -743,9 +761,7 @@ func applyColumnMutation(
 		case schemachange.ColumnConversionTrivial:
 			col.Type = nextType
 		default:
-			return pgerror.UnimplementedWithIssueDetailError(9851,
-				fmt.Sprintf("%s->%s", col.Type.SQLString(), nextType.SQLString()),
-				"type conversion not yet implemented")
+			return alterColumnTypeGeneral(tableDesc, col, nextType, t, params)
 		}
 
 	case *tree.AlterTableSetDefault:
@@ -779,7 +795,34 @@ func applyColumnMutation(
 			}
 		}
 
+	case *tree.AlterTableSetNotNull:
+		if !col.Nullable {
+			return nil
+		}
+		if !params.p.ExecCfg().Settings.Version.IsActive(cluster.VersionSetNotNull) {
+			return errors.Errorf(`SET NOT NULL requires all nodes to be upgraded to %s`,
+				cluster.VersionByKey(cluster.VersionSetNotNull),
+			)
+		}
+		if notNullValidationInProgress(tableDesc, col.ID) {
+			return fmt.Errorf("NOT NULL constraint on column %q in the middle of being added, try again later",
+				col.Name)
+		}
+
+		// The column is made non-nullable once the existing rows are validated.
+		// Until then, a check constraint enforces the constraint on writes.
+		ck, err := makeNotNullCheckConstraint(params.ctx, tableDesc, col)
+		if err != nil {
+			return err
+		}
+		tableDesc.Checks = append(tableDesc.Checks, ck)
+		tableDesc.AddNotNullValidationMutation(ck.Name, col.ID)
+
 	case *tree.AlterTableDropNotNull:
+		if notNullValidationInProgress(tableDesc, col.ID) {
+			return fmt.Errorf("NOT NULL constraint on column %q in the middle of being added, try again later",
+				col.Name)
+		}
 		col.Nullable = true
 
 	case *tree.AlterTableDropStored:
@@ -792,6 +835,337 @@ func applyColumnMutation(
 	return nil
 }
 
+// alterColumnTypeGeneral changes the type of a column whose data has to be
+// rewritten. A new column, computed from the old one by the cast to the new
+// type or the USING expression, is added along with copies of the indexes
+// that contain the old column. The column backfiller computes the new column
+// and the index backfiller populates the new indexes, after which a computed
+// column swap mutation gives the new column and indexes the names of the old
+// ones and drops the old ones.
+func alterColumnTypeGeneral(
+	tableDesc *sqlbase.MutableTableDescriptor,
+	col *sqlbase.ColumnDescriptor,
+	nextType sqlbase.ColumnType,
+	t *tree.AlterTableAlterColumnType,
+	params runParams,
+) error {
+	if !params.p.ExecCfg().Settings.Version.IsActive(cluster.VersionAlterColumnTypeGeneral) {
+		return errors.Errorf(`type conversions that rewrite the column data require all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionAlterColumnTypeGeneral),
+		)
+	}
+
+	unimplemented := func(reason string) error {
+		return pgerror.UnimplementedWithIssueDetailError(9851,
+			fmt.Sprintf("%s->%s", col.Type.SQLString(), nextType.SQLString()),
+			fmt.Sprintf("type conversion of a column %s is not yet implemented", reason))
+	}
+
+	if _, err := tableDesc.FindActiveColumnByID(col.ID); err != nil {
+		return fmt.Errorf("column %q in the middle of being added, try again later", col.Name)
+	}
+	if columnTypeChangeInProgress(tableDesc, col.ID) {
+		return fmt.Errorf("column %q in the middle of changing type, try again later", col.Name)
+	}
+	if tableDesc.PrimaryIndex.ContainsColumnID(col.ID) {
+		return unimplemented("in the primary key")
+	}
+	if col.IsComputed() {
+		return unimplemented("that is computed")
+	}
+	if len(col.UsesSequenceIds) > 0 {
+		return unimplemented("that uses a sequence")
+	}
+	for _, ref := range tableDesc.DependedOnBy {
+		for _, colID := range ref.ColumnIDs {
+			if colID == col.ID {
+				return unimplemented("referenced by a view")
+			}
+		}
+	}
+	for _, check := range tableDesc.Checks {
+		if used, err := check.UsesColumn(tableDesc.TableDesc(), col.ID); err != nil {
+			return err
+		} else if used {
+			return unimplemented("referenced by a CHECK constraint")
+		}
+	}
+	for i := range tableDesc.Columns {
+		if !tableDesc.Columns[i].IsComputed() {
+			continue
+		}
+		expr, err := parser.ParseExpr(*tableDesc.Columns[i].ComputeExpr)
+		if err != nil {
+			return err
+		}
+		if err := iterColDescriptorsInExpr(tableDesc, expr, func(c sqlbase.ColumnDescriptor) error {
+			if c.ID == col.ID {
+				return unimplemented("referenced by a computed column")
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	for _, m := range tableDesc.Mutations {
+		if idx := m.GetIndex(); idx != nil && idx.ContainsColumnID(col.ID) {
+			return fmt.Errorf("index %q on column %q in the middle of a schema change, try again later",
+				idx.Name, col.Name)
+		}
+	}
+
+	// Find the secondary indexes that have to be rewritten.
+	var oldIndexes []*sqlbase.IndexDescriptor
+	for i := range tableDesc.Indexes {
+		idx := &tableDesc.Indexes[i]
+		usesPredicate, err := idx.PredicateUsesColumn(tableDesc.TableDesc(), col.ID)
+		if err != nil {
+			return err
+		}
+		if !idx.ContainsColumnID(col.ID) && !usesPredicate {
+			continue
+		}
+		switch {
+		case idx.ForeignKey.IsSet() || len(idx.ReferencedBy) > 0:
+			return unimplemented("in an index used by a foreign key")
+		case idx.IsInterleaved():
+			return unimplemented("in an interleaved index")
+		case idx.Partitioning.NumColumns > 0:
+			return unimplemented("in a partitioned index")
+		case usesPredicate:
+			return unimplemented("referenced by a partial index predicate")
+		}
+		oldIndexes = append(oldIndexes, idx)
+	}
+
+	// Build the expression computing the new column.
+	var expr tree.Expr
+	if t.Using != nil {
+		if err := iterColDescriptorsInExpr(tableDesc, t.Using, func(c sqlbase.ColumnDescriptor) error {
+			if c.IsComputed() {
+				return pgerror.NewError(pgerror.CodeInvalidColumnReferenceError,
+					"USING expression cannot reference computed columns")
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		expr = t.Using
+	} else {
+		var err error
+		expr, err = castToColumnType(&tree.ColumnItem{ColumnName: tree.Name(col.Name)}, nextType)
+		if err != nil {
+			return err
+		}
+	}
+	replacedExpr, _, err := replaceVars(tableDesc, expr)
+	if err != nil {
+		return err
+	}
+	if _, err := sqlbase.SanitizeVarFreeExpr(
+		replacedExpr, nextType.ToDatumType(), "USING expression", &params.p.semaCtx, false, /* allowImpure */
+	); err != nil {
+		return err
+	}
+	computeExpr := tree.Serialize(expr)
+
+	// The default expression of the column is converted to the new type.
+	swap := &sqlbase.ComputedColumnSwap{OldColumnID: col.ID}
+	if col.HasDefault() {
+		defaultExpr, err := parser.ParseExpr(*col.DefaultExpr)
+		if err != nil {
+			return err
+		}
+		defaultExpr, err = castToColumnType(defaultExpr, nextType)
+		if err != nil {
+			return err
+		}
+		if _, err := sqlbase.SanitizeVarFreeExpr(
+			defaultExpr, nextType.ToDatumType(), "DEFAULT", &params.p.semaCtx, true, /* allowImpure */
+		); err != nil {
+			return err
+		}
+		s := tree.Serialize(defaultExpr)
+		swap.DefaultExpr = &s
+	}
+
+	// Nodes that use the table descriptor version preceding the swap read and
+	// write the old column until it is dropped, so it is computed from the new
+	// column once the swap completes. The conversion back to the old type
+	// must therefore be valid.
+	checkExpr, err := castToColumnType(
+		&dummyColumnItem{typ: nextType.ToDatumType(), name: tree.Name(col.Name)}, col.Type,
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := sqlbase.SanitizeVarFreeExpr(
+		checkExpr, col.Type.ToDatumType(), "ALTER COLUMN TYPE", &params.p.semaCtx, false, /* allowImpure */
+	); err != nil {
+		return errors.Wrapf(err, "cannot convert column %q back to %s during the schema change",
+			col.Name, col.Type.SQLString())
+	}
+	inverseExpr, err := castToColumnType(&tree.ColumnItem{ColumnName: tree.Name(col.Name)}, col.Type)
+	if err != nil {
+		return err
+	}
+	inverse := tree.Serialize(inverseExpr)
+	swap.InverseExpr = &inverse
+
+	// Add the new column to the family of the old one.
+	newCol := sqlbase.ColumnDescriptor{
+		Name:        string(makeUniqueColumnName(tableDesc, col.Name)),
+		Type:        nextType,
+		Nullable:    col.Nullable,
+		Hidden:      col.Hidden,
+		ComputeExpr: &computeExpr,
+	}
+	tableDesc.AddColumnMutation(newCol, sqlbase.DescriptorMutation_ADD)
+	for i := range tableDesc.Families {
+		for _, colID := range tableDesc.Families[i].ColumnIDs {
+			if colID == col.ID {
+				if err := tableDesc.AddColumnToFamilyMaybeCreate(
+					newCol.Name, tableDesc.Families[i].Name, false /* create */, false, /* ifNotExists */
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Add copies of the indexes that use the new column instead of the old
+	// one. They are given generated names until the swap.
+	newIndexes := make([]*sqlbase.IndexDescriptor, len(oldIndexes))
+	for i, oldIdx := range oldIndexes {
+		newIdx := protoutil.Clone(oldIdx).(*sqlbase.IndexDescriptor)
+		newIdx.ID = 0
+		newIdx.Name = ""
+		newIdx.CompositeColumnIDs = nil
+		for j := range newIdx.ColumnIDs {
+			if newIdx.ColumnIDs[j] == col.ID {
+				newIdx.ColumnIDs[j] = 0
+				newIdx.ColumnNames[j] = newCol.Name
+			}
+		}
+		for j := range newIdx.StoreColumnNames {
+			if newIdx.StoreColumnNames[j] == col.Name {
+				newIdx.StoreColumnNames[j] = newCol.Name
+			}
+		}
+		if err := tableDesc.AddIndexMutation(newIdx, sqlbase.DescriptorMutation_ADD); err != nil {
+			return err
+		}
+		newIndexes[i] = newIdx
+	}
+
+	if err := tableDesc.AllocateIDs(); err != nil {
+		return err
+	}
+	added, _, err := tableDesc.FindColumnByName(tree.Name(newCol.Name))
+	if err != nil {
+		return err
+	}
+	swap.NewColumnID = added.ID
+	for i := range oldIndexes {
+		swap.OldIndexIDs = append(swap.OldIndexIDs, oldIndexes[i].ID)
+		swap.NewIndexIDs = append(swap.NewIndexIDs, newIndexes[i].ID)
+	}
+	tableDesc.AddComputedColumnSwapMutation(swap)
+	return nil
+}
+
+// castToColumnType returns an expression casting expr to the given column
+// type. The width of the type is not part of the cast, so that values that do
+// not fit are rejected rather than truncated.
+func castToColumnType(expr tree.Expr, typ sqlbase.ColumnType) (tree.Expr, error) {
+	datumType := typ.ToDatumType()
+	if collated, ok := datumType.(types.TCollatedString); ok {
+		return &tree.CollateExpr{
+			Expr:   &tree.CastExpr{Expr: expr, Type: coltypes.String, SyntaxMode: tree.CastShort},
+			Locale: collated.Locale,
+		}, nil
+	}
+	colType, err := coltypes.DatumTypeToColumnType(datumType)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.CastExpr{Expr: expr, Type: colType, SyntaxMode: tree.CastShort}, nil
+}
+
+// makeUniqueColumnName returns a name, derived from the given one, that is
+// not used by any column of the table.
+func makeUniqueColumnName(desc *sqlbase.MutableTableDescriptor, name string) tree.Name {
+	for i := 1; ; i++ {
+		candidate := tree.Name(fmt.Sprintf("%s_%d", name, i))
+		if _, _, err := desc.FindColumnByName(candidate); err != nil {
+			return candidate
+		}
+	}
+}
+
+// columnTypeChangeInProgress returns whether a computed column swap
+// involving the given column is queued on the table.
+func columnTypeChangeInProgress(desc *sqlbase.MutableTableDescriptor, colID sqlbase.ColumnID) bool {
+	for _, m := range desc.Mutations {
+		if swap := m.GetComputedColumnSwap(); swap != nil &&
+			(swap.OldColumnID == colID || swap.NewColumnID == colID) {
+			return true
+		}
+	}
+	return false
+}
+
+// makeNotNullCheckConstraint returns the check constraint that enforces that
+// the given column is not NULL while the NOT NULL constraint is validated.
+func makeNotNullCheckConstraint(
+	ctx context.Context, desc *sqlbase.MutableTableDescriptor, col *sqlbase.ColumnDescriptor,
+) (*sqlbase.TableDescriptor_CheckConstraint, error) {
+	info, err := desc.GetConstraintInfo(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The check constraint is hidden from the constraint info, so the checks
+	// are searched as well to find an unused name.
+	inuse := func(name string) bool {
+		if _, ok := info[name]; ok {
+			return true
+		}
+		_, err := desc.FindCheckByName(name)
+		return err == nil
+	}
+	name := fmt.Sprintf("%s_auto_not_null", col.Name)
+	for i := 1; inuse(name); i++ {
+		name = fmt.Sprintf("%s_auto_not_null%d", col.Name, i)
+	}
+	expr := &tree.ComparisonExpr{
+		Operator: tree.IsDistinctFrom,
+		Left:     &tree.ColumnItem{ColumnName: tree.Name(col.Name)},
+		Right:    tree.DNull,
+	}
+	return &sqlbase.TableDescriptor_CheckConstraint{
+		Expr:                tree.Serialize(expr),
+		Name:                name,
+		Validity:            sqlbase.ConstraintValidity_Validating,
+		ColumnIDs:           []sqlbase.ColumnID{col.ID},
+		IsNonNullConstraint: true,
+	}, nil
+}
+
+// notNullValidationInProgress returns whether a NOT NULL constraint on the
+// given column is being validated.
+func notNullValidationInProgress(
+	desc *sqlbase.MutableTableDescriptor, colID sqlbase.ColumnID,
+) bool {
+	for _, m := range desc.Mutations {
+		if constraint := m.GetConstraint(); constraint != nil &&
+			constraint.ConstraintType == sqlbase.ConstraintToValidate_NOT_NULL &&
+			constraint.NotNullColumn == colID {
+			return true
+		}
+	}
+	return false
+}
+
 func labeledRowValues(cols []sqlbase.ColumnDescriptor, values tree.Datums) string {
 	var s bytes.Buffer
 	for i := range cols {
@@ -0,0 +1,325 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/enum"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/pkg/errors"
+)
+
+// alterTypeNode represents an ALTER TYPE statement.
+type alterTypeNode struct {
+	n    *tree.AlterType
+	desc *sqlbase.TypeDescriptor
+}
+
+// AlterType applies a schema change on a user-defined type.
+// Privileges: CREATE on type.
+//   notes: postgres requires ownership of the type.
+//
+// A value added to an enum type is not usable right away: nodes may still
+// hold leases on versions of the tables referencing the type that do not
+// know about the value and could not decode it. The value is added as a
+// read-only member, which can be decoded but not written, and is made public
+// by publishEnumMembers once the transaction has committed and all the
+// leases on the older versions of the tables have expired.
+func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionEnums) {
+		return nil, errors.Errorf(`ALTER TYPE requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionEnums),
+		)
+	}
+	desc, err := p.resolveTypeDesc(ctx, &n.Type, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	switch t := n.Cmd.(type) {
+	case *tree.AlterTypeAddValue:
+		added, err := addEnumValue(desc, t)
+		if err != nil {
+			return nil, err
+		}
+		if !added {
+			// The value may exist but still be read-only if the transaction
+			// that added it failed to make it public. Try again.
+			for i := range desc.EnumMembers {
+				if desc.EnumMembers[i].Capability == sqlbase.TypeDescriptor_EnumMember_READ_ONLY {
+					p.extendedEvalCtx.SchemaChangers.queueTypeChange(desc.ID)
+					break
+				}
+			}
+			return newZeroNode(nil /* columns */), nil
+		}
+	default:
+		return nil, pgerror.NewAssertionErrorf("unknown ALTER TYPE command %T", n.Cmd)
+	}
+
+	return &alterTypeNode{n: n, desc: desc}, nil
+}
+
+// addEnumValue adds the value described by the given ALTER TYPE ADD VALUE
+// command to the members of the enum type, as a read-only member. It returns
+// false if the value already exists and IF NOT EXISTS was specified.
+func addEnumValue(desc *sqlbase.TypeDescriptor, cmd *tree.AlterTypeAddValue) (bool, error) {
+	members := desc.EnumMembers
+	for i := range members {
+		if members[i].LogicalRepresentation == cmd.NewVal {
+			if cmd.IfNotExists {
+				return false, nil
+			}
+			return false, pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+				"enum label %q already exists", cmd.NewVal)
+		}
+	}
+
+	// pos is the position of the new value in the list of members.
+	pos := len(members)
+	if cmd.Placement != nil {
+		pos = -1
+		for i := range members {
+			if members[i].LogicalRepresentation == cmd.Placement.ExistingVal {
+				pos = i
+				break
+			}
+		}
+		if pos == -1 {
+			return false, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+				"%q is not an existing enum label", cmd.Placement.ExistingVal)
+		}
+		if !cmd.Placement.Before {
+			pos++
+		}
+	}
+
+	var prev, next []byte
+	if pos > 0 {
+		prev = members[pos-1].PhysicalRepresentation
+	}
+	if pos < len(members) {
+		next = members[pos].PhysicalRepresentation
+	}
+	newMember := sqlbase.TypeDescriptor_EnumMember{
+		PhysicalRepresentation: enum.GenByteStringBetween(prev, next),
+		LogicalRepresentation:  cmd.NewVal,
+		Capability:             sqlbase.TypeDescriptor_EnumMember_READ_ONLY,
+	}
+	members = append(members, sqlbase.TypeDescriptor_EnumMember{})
+	copy(members[pos+1:], members[pos:])
+	members[pos] = newMember
+	desc.EnumMembers = members
+	return true, nil
+}
+
+func (n *alterTypeNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	n.desc.Version++
+	if err := writeTypeDesc(ctx, p, n.desc); err != nil {
+		return err
+	}
+
+	// Update the copies of the members of the type that are held by the
+	// columns of the type, so that the new versions of the tables can decode
+	// the new value once it is public.
+	tableIDs, err := getTablesReferencingType(ctx, p.txn, n.desc.ID)
+	if err != nil {
+		return err
+	}
+	colType, err := sqlbase.DatumTypeToColumnType(n.desc.EnumType())
+	if err != nil {
+		return err
+	}
+	for _, id := range tableIDs {
+		tableDesc, err := p.Tables().getMutableTableVersionByID(ctx, id, p.txn)
+		if err != nil {
+			return err
+		}
+		setTableEnumMetadata(tableDesc, n.desc.ID, colType.EnumMetadata)
+		if err := p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID); err != nil {
+			return err
+		}
+	}
+	p.extendedEvalCtx.SchemaChangers.queueTypeChange(n.desc.ID)
+
+	// Log Alter Type event. This is an auditable log event and is recorded
+	// in the same transaction as the type descriptor update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		ctx,
+		p.txn,
+		EventLogAlterType,
+		int32(n.desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TypeName  string
+			Statement string
+			User      string
+		}{n.n.Type.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+// errEnumTableVersionChanged is returned by publishEnumMembers when a table
+// referencing the type was modified while waiting for the leases on its
+// older versions to expire.
+var errEnumTableVersionChanged = errors.New("table version changed")
+
+// publishEnumMembers makes public the read-only members of the enum type with
+// the given ID. It first waits until there is a single version of each of the
+// tables referencing the type, which is one that knows about the read-only
+// members, and then updates the type and the copies of its members held by
+// the tables in a single transaction.
+func publishEnumMembers(ctx context.Context, cfg *ExecutorConfig, typeID sqlbase.ID) error {
+	for r := retry.Start(base.DefaultRetryOptions()); r.Next(); {
+		var tableIDs []sqlbase.ID
+		if err := cfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			var err error
+			tableIDs, err = getTablesReferencingType(ctx, txn, typeID)
+			return err
+		}); err != nil {
+			return err
+		}
+		versions := make(map[sqlbase.ID]sqlbase.DescriptorVersion, len(tableIDs))
+		for _, id := range tableIDs {
+			version, err := cfg.LeaseManager.WaitForOneVersion(ctx, id, base.DefaultRetryOptions())
+			if err != nil {
+				return err
+			}
+			versions[id] = version
+		}
+
+		err := cfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			if err := txn.SetSystemConfigTrigger(); err != nil {
+				return err
+			}
+			desc := &sqlbase.Descriptor{}
+			if err := txn.GetProto(ctx, sqlbase.MakeDescMetadataKey(typeID), desc); err != nil {
+				return err
+			}
+			typeDesc := desc.GetType()
+			if typeDesc == nil {
+				// The type was dropped.
+				return nil
+			}
+			changed := false
+			for i := range typeDesc.EnumMembers {
+				if typeDesc.EnumMembers[i].Capability == sqlbase.TypeDescriptor_EnumMember_READ_ONLY {
+					typeDesc.EnumMembers[i].Capability = sqlbase.TypeDescriptor_EnumMember_ALL
+					changed = true
+				}
+			}
+			if !changed {
+				return nil
+			}
+			typeDesc.Version++
+			if err := typeDesc.Validate(); err != nil {
+				return err
+			}
+
+			tableIDs, err := getTablesReferencingType(ctx, txn, typeID)
+			if err != nil {
+				return err
+			}
+			colType, err := sqlbase.DatumTypeToColumnType(typeDesc.EnumType())
+			if err != nil {
+				return err
+			}
+			b := txn.NewBatch()
+			b.Put(sqlbase.MakeDescMetadataKey(typeID), sqlbase.WrapDescriptor(typeDesc))
+			for _, id := range tableIDs {
+				tableDesc, err := sqlbase.GetMutableTableDescFromID(ctx, txn, id)
+				if err != nil {
+					return err
+				}
+				if version, ok := versions[id]; !ok || version != tableDesc.Version {
+					return errEnumTableVersionChanged
+				}
+				setTableEnumMetadata(tableDesc, typeID, colType.EnumMetadata)
+				if err := maybeIncrementVersion(ctx, tableDesc, txn); err != nil {
+					return err
+				}
+				if err := tableDesc.ValidateTable(cfg.Settings); err != nil {
+					return err
+				}
+				b.Put(sqlbase.MakeDescMetadataKey(id), sqlbase.WrapDescriptor(tableDesc))
+			}
+			return txn.Run(ctx, b)
+		})
+		if err != errEnumTableVersionChanged {
+			return err
+		}
+		if log.V(3) {
+			log.Infof(ctx, "publishing members of type %d: table version changed", typeID)
+		}
+	}
+	return ctx.Err()
+}
+
+// setTableEnumMetadata replaces the copies of the members of the type with the
+// given ID held by the columns of the table.
+func setTableEnumMetadata(
+	tableDesc *sqlbase.MutableTableDescriptor, typeID sqlbase.ID, md *sqlbase.EnumMetadata,
+) {
+	for i := range tableDesc.Columns {
+		setColumnEnumMetadata(&tableDesc.Columns[i], typeID, md)
+	}
+	for i := range tableDesc.Mutations {
+		if col := tableDesc.Mutations[i].GetColumn(); col != nil {
+			setColumnEnumMetadata(col, typeID, md)
+		}
+	}
+}
+
+// setColumnEnumMetadata replaces the copy of the members of the type with the
+// given ID held by the column, if the column is of that type.
+func setColumnEnumMetadata(
+	col *sqlbase.ColumnDescriptor, typeID sqlbase.ID, md *sqlbase.EnumMetadata,
+) {
+	if columnHasType(col, typeID) {
+		mdCopy := *md
+		col.Type.EnumMetadata = &mdCopy
+	}
+}
+
+func (*alterTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterTypeNode) Close(context.Context)        {}
+
+// writeTypeDesc writes an updated type descriptor.
+func writeTypeDesc(ctx context.Context, p *planner, desc *sqlbase.TypeDescriptor) error {
+	if err := desc.Validate(); err != nil {
+		return err
+	}
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	descDesc := sqlbase.WrapDescriptor(desc)
+	if p.extendedEvalCtx.Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "Put %s -> %s", descKey, descDesc)
+	}
+	b := &client.Batch{}
+	b.Put(descKey, descDesc)
+	return p.txn.Run(ctx, b)
+}
@@ -160,11 +160,14 @@ func (sc *SchemaChanger) runBackfill(
 				addedIndexDescs = append(addedIndexDescs, *t.Index)
 			case *sqlbase.DescriptorMutation_Constraint:
 				switch t.Constraint.ConstraintType {
-				case sqlbase.ConstraintToValidate_CHECK:
+				case sqlbase.ConstraintToValidate_CHECK, sqlbase.ConstraintToValidate_NOT_NULL:
 					checksToValidate = append(checksToValidate, *t.Constraint)
 				default:
 					return errors.Errorf("unsupported constraint type: %d", t.Constraint.ConstraintType)
 				}
+			case *sqlbase.DescriptorMutation_ComputedColumnSwap:
+				// The new column and indexes are backfilled by their own
+				// mutations.
 			default:
 				return errors.Errorf("unsupported mutation: %+v", m)
 			}
@@ -177,7 +180,7 @@ func (sc *SchemaChanger) runBackfill(
 				if !sc.canClearRangeForDrop(t.Index) {
 					droppedIndexDescs = append(droppedIndexDescs, *t.Index)
 				}
-			case *sqlbase.DescriptorMutation_Constraint:
+			case *sqlbase.DescriptorMutation_Constraint, *sqlbase.DescriptorMutation_ComputedColumnSwap:
 				// no-op
 			default:
 				return errors.Errorf("unsupported mutation: %+v", m)
@@ -643,8 +646,11 @@ func (sc *SchemaChanger) validateIndexes(
 					ie.impl.tcModifier = nil
 				}()
 
-				row, err := newEvalCtx.InternalExecutor.QueryRow(ctx, "verify-idx-count", txn,
-					fmt.Sprintf(`SELECT count(*) FROM [%d AS t]@[%d]`, tableDesc.ID, idx.ID))
+				query := fmt.Sprintf(`SELECT count(*) FROM [%d AS t]@[%d]`, tableDesc.ID, idx.ID)
+				if idx.IsPartial() {
+					query += fmt.Sprintf(` WHERE %s`, idx.Predicate)
+				}
+				row, err := newEvalCtx.InternalExecutor.QueryRow(ctx, "verify-idx-count", txn, query)
 				if err != nil {
 					return err
 				}
@@ -653,6 +659,26 @@ func (sc *SchemaChanger) validateIndexes(
 				log.Infof(ctx, "index %s/%s row count = %d, took %s",
 					tableDesc.Name, idx.Name, idxLen, timeutil.Since(start))
 
+				if idx.IsPartial() {
+					// A partial index only contains the rows that satisfy its
+					// predicate, so compare against the number of these rows
+					// rather than the number of rows in the table.
+					row, err := newEvalCtx.InternalExecutor.QueryRow(ctx, "verify-partial-idx-count", txn,
+						fmt.Sprintf(`SELECT count(1) FROM [%d AS t]@[%d] WHERE %s`,
+							tableDesc.ID, tableDesc.PrimaryIndex.ID, idx.Predicate))
+					if err != nil {
+						return err
+					}
+					if expected := int64(tree.MustBeDInt(row[0])); idxLen != expected {
+						return pgerror.NewErrorf(
+							pgerror.CodeUniqueViolationError,
+							"%d entries, expected %d violates unique constraint %q",
+							idxLen, expected, idx.Name,
+						)
+					}
+					return nil
+				}
+
 				select {
 				case <-tableCountReady:
 					if idxLen != tableRowCount {
@@ -795,73 +821,82 @@ func runSchemaChangesInTxn(
 		return nil
 	}
 
-	// Only needed because columnBackfillInTxn() backfills
-	// all column mutations.
-	doneColumnBackfill := false
 	// Checks are validated after all other mutations have been applied.
 	var checksToValidate []sqlbase.ConstraintToValidate
 
-	for _, m := range tableDesc.Mutations {
-		immutDesc := sqlbase.NewImmutableTableDescriptor(*tableDesc.TableDesc())
-		switch m.Direction {
-		case sqlbase.DescriptorMutation_ADD:
-			switch t := m.Descriptor_.(type) {
-			case *sqlbase.DescriptorMutation_Column:
-				if doneColumnBackfill || !sqlbase.ColumnNeedsBackfill(m.GetColumn()) {
-					break
-				}
-				if err := columnBackfillInTxn(ctx, txn, tc, evalCtx, immutDesc, traceKV); err != nil {
-					return err
-				}
-				doneColumnBackfill = true
+	// Completing a computed column swap queues DROP mutations for the
+	// replaced column and indexes, which are applied in another pass.
+	for len(tableDesc.Mutations) > 0 {
+		mutations := tableDesc.Mutations
+		// Only needed because columnBackfillInTxn() backfills
+		// all column mutations.
+		doneColumnBackfill := false
+
+		for _, m := range mutations {
+			immutDesc := sqlbase.NewImmutableTableDescriptor(*tableDesc.TableDesc())
+			switch m.Direction {
+			case sqlbase.DescriptorMutation_ADD:
+				switch t := m.Descriptor_.(type) {
+				case *sqlbase.DescriptorMutation_Column:
+					if doneColumnBackfill || !sqlbase.ColumnNeedsBackfill(m.GetColumn()) {
+						break
+					}
+					if err := columnBackfillInTxn(ctx, txn, tc, evalCtx, immutDesc, traceKV); err != nil {
+						return err
+					}
+					doneColumnBackfill = true
 
-			case *sqlbase.DescriptorMutation_Index:
-				if err := indexBackfillInTxn(ctx, txn, immutDesc, traceKV); err != nil {
-					return err
-				}
+				case *sqlbase.DescriptorMutation_Index:
+					if err := indexBackfillInTxn(ctx, txn, immutDesc, traceKV); err != nil {
+						return err
+					}
+
+				case *sqlbase.DescriptorMutation_Constraint:
+					switch t.Constraint.ConstraintType {
+					case sqlbase.ConstraintToValidate_CHECK, sqlbase.ConstraintToValidate_NOT_NULL:
+						checksToValidate = append(checksToValidate, *t.Constraint)
+					default:
+						return errors.Errorf("unsupported constraint type: %d", t.Constraint.ConstraintType)
+					}
+
+				case *sqlbase.DescriptorMutation_ComputedColumnSwap:
+					// The swap is performed by MakeMutationComplete.
 
-			case *sqlbase.DescriptorMutation_Constraint:
-				switch t.Constraint.ConstraintType {
-				case sqlbase.ConstraintToValidate_CHECK:
-					checksToValidate = append(checksToValidate, *t.Constraint)
 				default:
-					return errors.Errorf("unsupported constraint type: %d", t.Constraint.ConstraintType)
+					return errors.Errorf("unsupported mutation: %+v", m)
 				}
 
-			default:
-				return errors.Errorf("unsupported mutation: %+v", m)
-			}
+			case sqlbase.DescriptorMutation_DROP:
+				// Drop the name and drop the associated data later.
+				switch m.Descriptor_.(type) {
+				case *sqlbase.DescriptorMutation_Column:
+					if doneColumnBackfill {
+						break
+					}
+					if err := columnBackfillInTxn(ctx, txn, tc, evalCtx, immutDesc, traceKV); err != nil {
+						return err
+					}
+					doneColumnBackfill = true
 
-		case sqlbase.DescriptorMutation_DROP:
-			// Drop the name and drop the associated data later.
-			switch m.Descriptor_.(type) {
-			case *sqlbase.DescriptorMutation_Column:
-				if doneColumnBackfill {
-					break
-				}
-				if err := columnBackfillInTxn(ctx, txn, tc, evalCtx, immutDesc, traceKV); err != nil {
-					return err
-				}
-				doneColumnBackfill = true
+				case *sqlbase.DescriptorMutation_Index:
+					if err := indexTruncateInTxn(ctx, txn, execCfg, immutDesc, traceKV); err != nil {
+						return err
+					}
 
-			case *sqlbase.DescriptorMutation_Index:
-				if err := indexTruncateInTxn(ctx, txn, execCfg, immutDesc, traceKV); err != nil {
-					return err
-				}
+				case *sqlbase.DescriptorMutation_Constraint:
+					return errors.Errorf("constraint validation mutation cannot be in the DROP state within the same transaction: %+v", m)
 
-			case *sqlbase.DescriptorMutation_Constraint:
-				return errors.Errorf("constraint validation mutation cannot be in the DROP state within the same transaction: %+v", m)
+				default:
+					return errors.Errorf("unsupported mutation: %+v", m)
+				}
 
-			default:
-				return errors.Errorf("unsupported mutation: %+v", m)
 			}
-
-		}
-		if err := tableDesc.MakeMutationComplete(m); err != nil {
-			return err
+			if err := tableDesc.MakeMutationComplete(m); err != nil {
+				return err
+			}
 		}
+		tableDesc.Mutations = tableDesc.Mutations[len(mutations):]
 	}
-	tableDesc.Mutations = nil
 
 	// Now that the table descriptor is in a valid state with all column and index
 	// mutations applied, it can be used for validating check constraints
@@ -900,7 +935,13 @@ func validateCheckInTxn(
 	if err != nil {
 		return err
 	}
-	return validateCheckExpr(ctx, check.Expr, tableDesc.TableDesc(), ie, txn)
+	if err := validateCheckExpr(ctx, check.Expr, tableDesc.TableDesc(), ie, txn); err != nil {
+		if check.IsNonNullConstraint {
+			return errors.Wrap(err, "validation of NOT NULL constraint failed")
+		}
+		return err
+	}
+	return nil
 }
 
 // columnBackfillInTxn backfills columns for all mutation columns in
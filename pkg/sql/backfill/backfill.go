@@ -124,7 +124,13 @@ func (cb *ColumnBackfiller) Init(
 		ValNeededForCol: valNeededForCol,
 	}
 	return cb.fetcher.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &cb.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&cb.alloc,
+		tableArgs,
 	)
 }
 
@@ -232,8 +238,15 @@ func (cb *ColumnBackfiller) RunColumnBackfillChunk(
 			if err != nil {
 				return roachpb.Key{}, sqlbase.NewInvalidSchemaDefinitionError(err)
 			}
-			if j < len(cb.added) && !cb.added[j].Nullable && val == tree.DNull {
-				return roachpb.Key{}, sqlbase.NewNonNullViolationError(cb.added[j].Name)
+			if j < len(cb.added) {
+				if !cb.added[j].Nullable && val == tree.DNull {
+					return roachpb.Key{}, sqlbase.NewNonNullViolationError(cb.added[j].Name)
+				}
+				// The values are checked against the width of the column, as
+				// they are by INSERT and UPDATE.
+				if val, err = sqlbase.LimitValueWidth(cb.added[j].Type, val, &cb.added[j].Name); err != nil {
+					return roachpb.Key{}, err
+				}
 			}
 
 			// Added computed column values should be usable for the next
@@ -293,6 +306,9 @@ type IndexBackfiller struct {
 	// colIdxMap maps ColumnIDs to indices into desc.Columns and desc.Mutations.
 	colIdxMap map[sqlbase.ColumnID]int
 
+	// predicates is set if some of the added indexes are partial indexes.
+	predicates *sqlbase.PartialIndexPredicates
+
 	types   []sqlbase.ColumnType
 	rowVals tree.Datums
 }
@@ -340,6 +356,28 @@ func (ib *IndexBackfiller) Init(desc *sqlbase.ImmutableTableDescriptor) error {
 		}
 	}
 
+	var err error
+	ib.predicates, err = sqlbase.NewPartialIndexPredicates(desc.TableDesc(), ib.added)
+	if err != nil {
+		return err
+	}
+	if ib.predicates != nil {
+		// The columns referenced by the predicates of partial indexes are
+		// needed to determine which rows are in these indexes.
+		for i := range ib.added {
+			if !ib.added[i].IsPartial() {
+				continue
+			}
+			for _, id := range ib.predicates.ColumnIDs(&ib.added[i]) {
+				for j := range cols {
+					if cols[j].ID == id {
+						valNeededForCol.Add(j)
+					}
+				}
+			}
+		}
+	}
+
 	ib.types = make([]sqlbase.ColumnType, len(cols))
 	for i := range cols {
 		ib.types[i] = cols[i].Type
@@ -358,7 +396,13 @@ func (ib *IndexBackfiller) Init(desc *sqlbase.ImmutableTableDescriptor) error {
 		ValNeededForCol: valNeededForCol,
 	}
 	return ib.fetcher.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &ib.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&ib.alloc,
+		tableArgs,
 	)
 }
 
@@ -423,7 +467,25 @@ func (ib *IndexBackfiller) BuildIndexEntriesChunk(
 			ib.rowVals, buffer); err != nil {
 			return nil, nil, err
 		}
-		entries = append(entries, buffer...)
+		if ib.predicates == nil {
+			entries = append(entries, buffer...)
+			continue
+		}
+		// Leave out the entries of the partial indexes that don't contain the
+		// row. Partial indexes cannot be inverted indexes, so their entries are
+		// the ones at the same positions as the indexes.
+		for j := range buffer {
+			if j < len(ib.added) {
+				ok, err := ib.predicates.Satisfies(&ib.added[j], ib.colIdxMap, ib.rowVals)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !ok {
+					continue
+				}
+			}
+			entries = append(entries, buffer[j])
+		}
 	}
 	return entries, ib.fetcher.Key(), nil
 }
@@ -58,6 +58,8 @@ var (
 
 	// Time is an immutable T instance.
 	Time = &TTime{}
+	// TimeTZ is an immutable T instance.
+	TimeTZ = &TTimeTZ{}
 
 	// Timestamp is an immutable T instance.
 	Timestamp = &TTimestamp{}
@@ -94,6 +96,11 @@ var (
 	// JSON is an immutable T instance.
 	JSON = &TJSON{}
 
+	// TSVector is an immutable T instance.
+	TSVector = &TTSVector{}
+	// TSQuery is an immutable T instance.
+	TSQuery = &TTSQuery{}
+
 	// Oid is an immutable T instance.
 	Oid = &TOid{Name: "OID"}
 	// RegClass is an immutable T instance.
@@ -122,6 +129,16 @@ func NewBitArrayType(width int, varying bool) (*TBitArray, error) {
 	return &TBitArray{Width: uint(width), Variable: varying}, nil
 }
 
+// CheckTimePrecision verifies that prec is a valid precision for the time
+// types and INTERVAL.
+func CheckTimePrecision(prec int64) error {
+	if prec < 0 || prec > MaxTimePrecision {
+		return pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+			"precision %d out of range", prec)
+	}
+	return nil
+}
+
 var errFloatPrecAtLeast1 = pgerror.NewError(pgerror.CodeInvalidParameterValueError,
 	"precision for type float must be at least 1 bit")
 var errFloatPrecMax54 = pgerror.NewError(pgerror.CodeInvalidParameterValueError,
@@ -195,8 +212,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"pg_lsn":        -1,
 	"point":         21286,
 	"polygon":       21286,
-	"tsquery":       7821,
-	"tsvector":      7821,
 	"txid_snapshot": -1,
 	"xml":           -1,
 }
@@ -86,6 +86,10 @@ func DatumTypeToColumnType(t types.T) (T, error) {
 		return Interval, nil
 	case types.JSON:
 		return JSON, nil
+	case types.TSVector:
+		return TSVector, nil
+	case types.TSQuery:
+		return TSQuery, nil
 	case types.UUID:
 		return UUID, nil
 	case types.INet:
@@ -94,6 +98,8 @@ func DatumTypeToColumnType(t types.T) (T, error) {
 		return Date, nil
 	case types.Time:
 		return Time, nil
+	case types.TimeTZ:
+		return TimeTZ, nil
 	case types.String:
 		return String, nil
 	case types.Name:
@@ -133,6 +139,10 @@ func DatumTypeToColumnType(t types.T) (T, error) {
 		return colTyp, nil
 	case types.TOidWrapper:
 		return DatumTypeToColumnType(typ.T)
+	case types.TEnum:
+		if typ.TypeID != 0 {
+			return &TUserDefined{Name: typ.Name, Typ: typ}, nil
+		}
 	}
 
 	return nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
@@ -172,6 +182,8 @@ func CastTargetToDatumType(t CastTargetType) types.T {
 		return types.Date
 	case *TTime:
 		return types.Time
+	case *TTimeTZ:
+		return types.TimeTZ
 	case *TTimestamp:
 		return types.Timestamp
 	case *TTimestampTZ:
@@ -180,6 +192,10 @@ func CastTargetToDatumType(t CastTargetType) types.T {
 		return types.Interval
 	case *TJSON:
 		return types.JSON
+	case *TTSVector:
+		return types.TSVector
+	case *TTSQuery:
+		return types.TSQuery
 	case *TUUID:
 		return types.UUID
 	case *TIPAddr:
@@ -205,6 +221,12 @@ func CastTargetToDatumType(t CastTargetType) types.T {
 		return ret
 	case *TOid:
 		return TOidToType(ct)
+	case *TUserDefined:
+		if !ct.IsResolved() {
+			// The type is not known until the reference is resolved.
+			return types.Any
+		}
+		return ct.Typ
 	default:
 		panic(fmt.Sprintf("unexpected CastTarget %T", t))
 	}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 )
 
 // This file contains column type definitions that don't fit
@@ -59,6 +60,28 @@ func (node *TJSON) Format(buf *bytes.Buffer, _ lex.EncodeFlags) {
 	buf.WriteString(node.TypeName())
 }
 
+// TTSVector represents the TSVECTOR type.
+type TTSVector struct{}
+
+// TypeName implements the ColTypeFormatter interface.
+func (node *TTSVector) TypeName() string { return "TSVECTOR" }
+
+// Format implements the ColTypeFormatter interface.
+func (node *TTSVector) Format(buf *bytes.Buffer, _ lex.EncodeFlags) {
+	buf.WriteString(node.TypeName())
+}
+
+// TTSQuery represents the TSQUERY type.
+type TTSQuery struct{}
+
+// TypeName implements the ColTypeFormatter interface.
+func (node *TTSQuery) TypeName() string { return "TSQUERY" }
+
+// Format implements the ColTypeFormatter interface.
+func (node *TTSQuery) Format(buf *bytes.Buffer, _ lex.EncodeFlags) {
+	buf.WriteString(node.TypeName())
+}
+
 // TOid represents an OID type, which is the type of system object
 // identifiers. There are several different OID types: the raw OID type, which
 // can be any integer, and the reg* types, each of which corresponds to the
@@ -77,3 +100,43 @@ func (node *TOid) TypeName() string { return node.Name }
 func (node *TOid) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.Name)
 }
+
+// TUserDefined represents a reference to a user-defined type, such as an
+// enum type created with CREATE TYPE. The reference is resolved during
+// semantic analysis, after which Typ holds the type that it refers to.
+type TUserDefined struct {
+	// Schema is the schema that the name is qualified with, if any.
+	Schema string
+	Name   string
+	// Typ is the resolved type, or nil if the reference has not been
+	// resolved yet.
+	Typ types.T
+}
+
+// TypeName implements the ColTypeFormatter interface.
+func (node *TUserDefined) TypeName() string { return node.Name }
+
+// Format implements the ColTypeFormatter interface.
+func (node *TUserDefined) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
+	if node.Schema != "" {
+		formatTypeIdent(buf, node.Schema, f)
+		buf.WriteByte('.')
+	}
+	formatTypeIdent(buf, node.Name, f)
+}
+
+// formatTypeIdent formats a part of the name of a user-defined type. Type
+// names are only recognized as plain identifiers, so keywords must be quoted
+// even when they are not reserved.
+func formatTypeIdent(buf *bytes.Buffer, s string, f lex.EncodeFlags) {
+	if _, isKeyword := lex.KeywordsCategories[s]; isKeyword && !f.HasFlags(lex.EncBareIdentifiers) {
+		buf.WriteByte('"')
+		buf.WriteString(s)
+		buf.WriteByte('"')
+		return
+	}
+	lex.EncodeRestrictedSQLIdent(buf, s, f)
+}
+
+// IsResolved returns true if the type reference has been resolved.
+func (node *TUserDefined) IsResolved() bool { return node.Typ != nil }
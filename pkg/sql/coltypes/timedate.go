@@ -16,6 +16,7 @@ package coltypes
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 )
@@ -32,7 +33,12 @@ func (node *TDate) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 }
 
 // TTime represents a TIME type.
-type TTime struct{}
+type TTime struct {
+	// Precision is the number of fractional digits of the seconds, if
+	// PrecisionSet is set. Otherwise the default precision of 6 is used.
+	Precision    int
+	PrecisionSet bool
+}
 
 // TypeName implements the ColTypeFormatter interface.
 func (node *TTime) TypeName() string { return "TIME" }
@@ -40,10 +46,33 @@ func (node *TTime) TypeName() string { return "TIME" }
 // Format implements the ColTypeFormatter interface.
 func (node *TTime) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.TypeName())
+	formatTimePrecision(buf, node.Precision, node.PrecisionSet)
+}
+
+// TTimeTZ represents a TIMETZ type.
+type TTimeTZ struct {
+	// Precision is the number of fractional digits of the seconds, if
+	// PrecisionSet is set. Otherwise the default precision of 6 is used.
+	Precision    int
+	PrecisionSet bool
+}
+
+// TypeName implements the ColTypeFormatter interface.
+func (node *TTimeTZ) TypeName() string { return "TIMETZ" }
+
+// Format implements the ColTypeFormatter interface.
+func (node *TTimeTZ) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
+	buf.WriteString(node.TypeName())
+	formatTimePrecision(buf, node.Precision, node.PrecisionSet)
 }
 
 // TTimestamp represents a TIMESTAMP type.
-type TTimestamp struct{}
+type TTimestamp struct {
+	// Precision is the number of fractional digits of the seconds, if
+	// PrecisionSet is set. Otherwise the default precision of 6 is used.
+	Precision    int
+	PrecisionSet bool
+}
 
 // TypeName implements the ColTypeFormatter interface.
 func (node *TTimestamp) TypeName() string { return "TIMESTAMP" }
@@ -51,10 +80,16 @@ func (node *TTimestamp) TypeName() string { return "TIMESTAMP" }
 // Format implements the ColTypeFormatter interface.
 func (node *TTimestamp) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.TypeName())
+	formatTimePrecision(buf, node.Precision, node.PrecisionSet)
 }
 
 // TTimestampTZ represents a TIMESTAMP type.
-type TTimestampTZ struct{}
+type TTimestampTZ struct {
+	// Precision is the number of fractional digits of the seconds, if
+	// PrecisionSet is set. Otherwise the default precision of 6 is used.
+	Precision    int
+	PrecisionSet bool
+}
 
 // TypeName implements the ColTypeFormatter interface.
 func (node *TTimestampTZ) TypeName() string { return "TIMESTAMPTZ" }
@@ -62,10 +97,16 @@ func (node *TTimestampTZ) TypeName() string { return "TIMESTAMPTZ" }
 // Format implements the ColTypeFormatter interface.
 func (node *TTimestampTZ) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.TypeName())
+	formatTimePrecision(buf, node.Precision, node.PrecisionSet)
 }
 
 // TInterval represents an INTERVAL type
-type TInterval struct{}
+type TInterval struct {
+	// Precision is the number of fractional digits of the seconds, if
+	// PrecisionSet is set. Otherwise the default precision of 6 is used.
+	Precision    int
+	PrecisionSet bool
+}
 
 // TypeName implements the ColTypeFormatter interface.
 func (node *TInterval) TypeName() string { return "INTERVAL" }
@@ -73,4 +114,35 @@ func (node *TInterval) TypeName() string { return "INTERVAL" }
 // Format implements the ColTypeFormatter interface.
 func (node *TInterval) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.TypeName())
+	formatTimePrecision(buf, node.Precision, node.PrecisionSet)
+}
+
+// MaxTimePrecision is the maximum precision of the TIME, TIMETZ, TIMESTAMP,
+// TIMESTAMPTZ and INTERVAL types. Values are stored with microsecond
+// resolution.
+const MaxTimePrecision = 6
+
+// TimePrecision returns the precision of a TIME, TIMETZ, TIMESTAMP,
+// TIMESTAMPTZ or INTERVAL type, and whether a precision was specified. It
+// returns false for all other types.
+func TimePrecision(t CastTargetType) (int, bool) {
+	switch t := t.(type) {
+	case *TTime:
+		return t.Precision, t.PrecisionSet
+	case *TTimeTZ:
+		return t.Precision, t.PrecisionSet
+	case *TTimestamp:
+		return t.Precision, t.PrecisionSet
+	case *TTimestampTZ:
+		return t.Precision, t.PrecisionSet
+	case *TInterval:
+		return t.Precision, t.PrecisionSet
+	}
+	return 0, false
+}
+
+func formatTimePrecision(buf *bytes.Buffer, precision int, precisionSet bool) {
+	if precisionSet {
+		fmt.Fprintf(buf, "(%d)", precision)
+	}
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -44,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/fsm"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logtags"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
@@ -319,6 +321,7 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 		}
 	})
 	s.PeriodicallyClearStmtStats(ctx, stopper)
+	s.PeriodicallyCleanupTemporaryObjects(ctx, stopper)
 }
 
 // ResetStatementStats resets the executor's collected statement statistics.
@@ -523,6 +526,7 @@ func (s *Server) newConnExecutor(
 	}
 
 	ex.state.txnAbortCount = ex.metrics.StatementCounters.TxnAbortCount
+	ex.extraTxnState.deferredFKChecks.Init(ex.sessionMon)
 
 	ex.dataMutator = sessionDataMutator{
 		data:           &ex.sessionData,
@@ -537,6 +541,9 @@ func (s *Server) newConnExecutor(
 			ex.appStats = ex.server.sqlStats.getStatsForApplication(newName)
 			ex.applicationName.Store(newName)
 		},
+		temporarySchemaCreated: func() {
+			ex.hasCreatedTemporarySchema = true
+		},
 	}
 
 	if setFromDefaults {
@@ -757,6 +764,17 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		log.Warningf(ctx, "error while cleaning up connExecutor: %s", err)
 	}
 
+	if ex.hasCreatedTemporarySchema {
+		// Drop the temporary tables of the session. The session's context may
+		// already be canceled at this point, so use a fresh one.
+		cleanupCtx := logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+		if err := cleanupSessionTempObjects(cleanupCtx, ex.server.cfg, ex.sessionID); err != nil {
+			// The temporary schema will be cleaned up by the background
+			// reaper eventually.
+			log.Warningf(ctx, "error while cleaning up temporary tables: %s", err)
+		}
+	}
+
 	if closeType != panicClose {
 		// Close all statements and prepared portals.
 		ex.extraTxnState.prepStmtsNamespace.resetTo(ctx, prepStmtNamespace{})
@@ -847,6 +865,10 @@ type connExecutor struct {
 		// is done if the statement was executed in an implicit txn).
 		schemaChangers schemaChangerCollection
 
+		// deferredFKChecks accumulates the foreign key checks deferred to the
+		// end of the transaction, which are run before it commits.
+		deferredFKChecks row.DeferredFKChecks
+
 		// autoRetryCounter keeps track of the which iteration of a transaction
 		// auto-retry we're currently in. It's 0 whenever the transaction state is not
 		// stateOpen.
@@ -938,6 +960,10 @@ type connExecutor struct {
 
 	sessionID ClusterWideID
 
+	// hasCreatedTemporarySchema is set if the session created a temporary
+	// schema, which needs to be cleaned up when the session closes.
+	hasCreatedTemporarySchema bool
+
 	// activated determines whether activate() was called already.
 	// When this is set, close() must be called to release resources.
 	activated bool
@@ -1025,6 +1051,8 @@ func (ex *connExecutor) resetExtraTxnState(
 ) error {
 	ex.extraTxnState.schemaChangers.reset()
 
+	ex.extraTxnState.deferredFKChecks.Reset(ctx)
+
 	ex.extraTxnState.tables.releaseTables(ctx)
 
 	ex.extraTxnState.tables.databaseCache = dbCacheHolder.getDatabaseCache()
@@ -1484,7 +1512,12 @@ func (ex *connExecutor) setTxnRewindPos(ctx context.Context, pos CmdPos) {
 // statement do not change with retries.
 func (ex *connExecutor) stmtDoesntNeedRetry(stmt tree.Statement) bool {
 	wrap := Statement{Statement: parser.Statement{AST: stmt}}
-	return isSavepoint(wrap) || isSetTransaction(wrap)
+	if isSavepoint(wrap) {
+		// Regular savepoints capture the state of the KV transaction, so they
+		// need to be established again when the transaction is retried.
+		return ex.isRestartSavepoint(stmt.(*tree.Savepoint).Name)
+	}
+	return isSetTransaction(wrap)
 }
 
 func stateToTxnStatusIndicator(s fsm.State) TransactionStatusIndicator {
@@ -1842,17 +1875,19 @@ func (ex *connExecutor) evalCtx(
 			ReCache:          ex.server.reCache,
 			InternalExecutor: &ie,
 		},
-		SessionMutator:  &ex.dataMutator,
-		VirtualSchemas:  ex.server.cfg.VirtualSchemas,
-		Tracing:         &ex.sessionTracing,
-		StatusServer:    ex.server.cfg.StatusServer,
-		MemMetrics:      &ex.memMetrics,
-		Tables:          &ex.extraTxnState.tables,
-		ExecCfg:         ex.server.cfg,
-		DistSQLPlanner:  ex.server.cfg.DistSQLPlanner,
-		TxnModesSetter:  ex,
-		SchemaChangers:  &ex.extraTxnState.schemaChangers,
-		schemaAccessors: scInterface,
+		SessionMutator:   &ex.dataMutator,
+		VirtualSchemas:   ex.server.cfg.VirtualSchemas,
+		Tracing:          &ex.sessionTracing,
+		StatusServer:     ex.server.cfg.StatusServer,
+		MemMetrics:       &ex.memMetrics,
+		Tables:           &ex.extraTxnState.tables,
+		ExecCfg:          ex.server.cfg,
+		DistSQLPlanner:   ex.server.cfg.DistSQLPlanner,
+		TxnModesSetter:   ex,
+		SchemaChangers:   &ex.extraTxnState.schemaChangers,
+		DeferredFKChecks: &ex.extraTxnState.deferredFKChecks,
+		SessionID:        ex.sessionID,
+		schemaAccessors:  scInterface,
 	}
 }
 
@@ -1907,6 +1942,7 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx = tree.MakeSemaContext()
 	p.semaCtx.Location = &ex.sessionData.DataConversion.Location
 	p.semaCtx.SearchPath = ex.sessionData.SearchPath
+	p.semaCtx.TypeResolver = p
 	p.semaCtx.AsOfTimestamp = nil
 
 	p.extendedEvalCtx = ex.evalCtx(ctx, p, stmtTS)
@@ -1968,7 +2004,7 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 			return advanceInfo{}, err
 		}
 		scc := &ex.extraTxnState.schemaChangers
-		if len(scc.schemaChangers) != 0 {
+		if len(scc.schemaChangers) != 0 || len(scc.typeIDs) != 0 {
 			ieFactory := func(ctx context.Context, sd *sessiondata.SessionData) sqlutil.InternalExecutor {
 				ie := MakeSessionBoundInternalExecutor(
 					ctx,
@@ -2001,6 +2037,10 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 
 		fallthrough
 	case txnRestart, txnAborted:
+		// A restart moves the KV transaction to a new epoch, which invalidates
+		// all the savepoints established so far.
+		ex.state.savepoints = nil
+		ex.state.numDDL = 0
 		if err := ex.resetExtraTxnState(ex.Ctx(), ex.server.dbCache); err != nil {
 			return advanceInfo{}, err
 		}
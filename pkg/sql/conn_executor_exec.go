@@ -40,7 +40,10 @@ import (
 	"github.com/pkg/errors"
 )
 
-// RestartSavepointName is the only savepoint ident that we accept.
+// RestartSavepointName is the name of the special savepoint used for
+// client-directed transaction retries. Unlike regular savepoints, it has to be
+// established at the beginning of the transaction and rolling back to it
+// restarts the transaction.
 const RestartSavepointName string = "cockroach_restart"
 
 var errSavepointNotUsed = pgerror.NewErrorf(
@@ -210,6 +213,10 @@ func (ex *connExecutor) execStmtInOpenState(
 		return makeErrEvent(err)
 	}
 
+	if stmt.AST.StatementType() == tree.DDL {
+		ex.state.numDDL++
+	}
+
 	switch s := stmt.AST.(type) {
 	case *tree.BeginTransaction:
 		// BEGIN is always an error when in the Open state. It's legitimate only in
@@ -222,6 +229,12 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 
 	case *tree.ReleaseSavepoint:
+		if !ex.isRestartSavepoint(s.Savepoint) {
+			if err := ex.execReleaseSavepointInOpenState(ctx, s, os.ImplicitTxn.Get()); err != nil {
+				return makeErrEvent(err)
+			}
+			return nil, nil, nil
+		}
 		if err := ex.validateSavepointName(s.Savepoint); err != nil {
 			return makeErrEvent(err)
 		}
@@ -240,6 +253,12 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 
 	case *tree.Savepoint:
+		if !ex.isRestartSavepoint(s.Name) {
+			if err := ex.execSavepointInOpenState(ctx, s, os.ImplicitTxn.Get()); err != nil {
+				return makeErrEvent(err)
+			}
+			return nil, nil, nil
+		}
 		// Ensure that the user isn't trying to run BEGIN; SAVEPOINT; SAVEPOINT;
 		if ex.state.activeSavepointName != "" {
 			err := fmt.Errorf("SAVEPOINT may not be nested")
@@ -256,7 +275,7 @@ func (ex *connExecutor) execStmtInOpenState(
 		// See also:
 		// https://github.com/cockroachdb/cockroach/issues/15012
 		meta := ex.state.mu.txn.GetTxnCoordMeta(ctx)
-		if meta.CommandCount > 0 {
+		if meta.CommandCount > 0 || len(ex.state.savepoints) > 0 {
 			err := fmt.Errorf("SAVEPOINT %s needs to be the first statement in a "+
 				"transaction", RestartSavepointName)
 			return makeErrEvent(err)
@@ -267,6 +286,15 @@ func (ex *connExecutor) execStmtInOpenState(
 		return eventRetryIntentSet{}, nil /* payload */, nil
 
 	case *tree.RollbackToSavepoint:
+		if !ex.isRestartSavepoint(s.Savepoint) {
+			if err := ex.checkSavepointsAllowed(os.ImplicitTxn.Get()); err != nil {
+				return makeErrEvent(err)
+			}
+			if err := ex.execRollbackToSavepoint(ctx, s.Savepoint); err != nil {
+				return makeErrEvent(err)
+			}
+			return nil, nil, nil
+		}
 		if err := ex.validateSavepointName(s.Savepoint); err != nil {
 			return makeErrEvent(err)
 		}
@@ -611,6 +639,14 @@ func (ex *connExecutor) commitSQLTransaction(
 		isRelease = true
 	}
 
+	// Run the foreign key checks that were deferred until now. A violation
+	// aborts the transaction.
+	if err := ex.extraTxnState.deferredFKChecks.Run(
+		ctx, ex.state.mu.txn, nil, /* filter */
+	); err != nil {
+		return ex.makeErrEvent(err, stmt)
+	}
+
 	if err := ex.checkTableTwoVersionInvariant(ctx); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
@@ -1137,8 +1173,10 @@ func (ex *connExecutor) execStmtInNoTxnState(
 // execStmtInAbortedState executes a statement in a txn that's in state
 // Aborted or RestartWait. All statements result in error events except:
 // - COMMIT / ROLLBACK: aborts the current transaction.
-// - ROLLBACK TO SAVEPOINT / SAVEPOINT: reopens the current transaction,
-//   allowing it to be retried.
+// - ROLLBACK TO SAVEPOINT / SAVEPOINT cockroach_restart: reopens the current
+//   transaction, allowing it to be retried.
+// - ROLLBACK TO SAVEPOINT of a regular savepoint: reopens the current
+//   transaction, undoing everything done since the savepoint.
 func (ex *connExecutor) execStmtInAbortedState(
 	ctx context.Context, stmt Statement, res RestrictedCommandResult,
 ) (fsm.Event, fsm.EventPayload) {
@@ -1147,8 +1185,14 @@ func (ex *connExecutor) execStmtInAbortedState(
 	// TODO(andrei/cuongdo): Figure out what statements to count here.
 	switch s := stmt.AST.(type) {
 	case *tree.CommitTransaction, *tree.RollbackTransaction:
-		if inRestartWait {
+		if inRestartWait || len(ex.state.savepoints) > 0 {
+			// The KV transaction is still open; roll it back. If there are
+			// savepoints, it was kept open when the error occurred so that they
+			// could be rolled back to.
 			ev, payload := ex.rollbackSQLTransaction(ctx)
+			if !inRestartWait {
+				res.ResetStmtType((*tree.RollbackTransaction)(nil))
+			}
 			return ev, payload
 		}
 		ex.state.activeSavepointName = ""
@@ -1172,6 +1216,9 @@ func (ex *connExecutor) execStmtInAbortedState(
 		default:
 			panic("unreachable")
 		}
+		if !ex.isRestartSavepoint(spName) {
+			return ex.execSavepointInAbortedState(ctx, spName, isRollback, inRestartWait)
+		}
 		// If the user issued a SAVEPOINT in the abort state, validate
 		// as though there were no active savepoint.
 		if !isRollback {
@@ -1205,6 +1252,19 @@ func (ex *connExecutor) execStmtInAbortedState(
 		if inRestartWait {
 			return eventTxnRestart{}, nil
 		}
+		if len(ex.state.savepoints) > 0 {
+			// The KV transaction was kept open for the benefit of the regular
+			// savepoints, and so was the state associated with it. Get rid of
+			// both; a new transaction is started below.
+			if err := ex.state.mu.txn.Rollback(ctx); err != nil {
+				log.Warningf(ctx, "txn rollback failed: %s", err)
+			}
+			if err := ex.resetExtraTxnState(ctx, ex.server.dbCache); err != nil {
+				ev := eventNonRetriableErr{IsCommit: fsm.False}
+				payload := eventNonRetriableErrPayload{err: err}
+				return ev, payload
+			}
+		}
 		// We accept ROLLBACK TO SAVEPOINT even after non-retryable errors to make
 		// it easy for client libraries that want to indiscriminately issue
 		// ROLLBACK TO SAVEPOINT after every error and possibly follow it with a
@@ -1240,6 +1300,38 @@ func (ex *connExecutor) execStmtInAbortedState(
 	}
 }
 
+// execSavepointInAbortedState handles a SAVEPOINT or a ROLLBACK TO SAVEPOINT
+// statement referencing a regular savepoint in the Aborted or RestartWait
+// states. Only rolling back to an existing savepoint is allowed, and only in
+// the Aborted state; it moves the transaction back to the Open state.
+func (ex *connExecutor) execSavepointInAbortedState(
+	ctx context.Context, spName tree.Name, isRollback bool, inRestartWait bool,
+) (fsm.Event, fsm.EventPayload) {
+	ev := eventNonRetriableErr{IsCommit: fsm.False}
+	if inRestartWait {
+		var err error
+		if isRollback {
+			// Point the client to the restart savepoint.
+			err = ex.validateSavepointName(spName)
+		}
+		if err == nil {
+			err = sqlbase.NewTransactionAbortedError(
+				"Expected \"ROLLBACK TO SAVEPOINT COCKROACH_RESTART\"" /* customMsg */)
+		}
+		return ev, eventNonRetriableErrPayload{err: err}
+	}
+	if !isRollback || len(ex.state.savepoints) == 0 {
+		// Without savepoints, the KV transaction has already been rolled back.
+		return ev, eventNonRetriableErrPayload{
+			err: sqlbase.NewTransactionAbortedError("" /* customMsg */),
+		}
+	}
+	if err := ex.execRollbackToSavepoint(ctx, spName); err != nil {
+		return ev, eventNonRetriableErrPayload{err: err}
+	}
+	return eventSavepointRollback{}, nil
+}
+
 // execStmtInCommitWaitState executes a statement in a txn that's in state
 // CommitWait.
 // Everything but COMMIT/ROLLBACK causes errors. ROLLBACK is treated like COMMIT.
@@ -1445,11 +1537,9 @@ func (ex *connExecutor) incrementStmtCounter(stmt Statement) {
 	ex.metrics.StatementCounters.incrementCount(stmt.AST)
 }
 
-// validateSavepointName validates that it is that the provided ident
-// matches the active savepoint name, begins with RestartSavepointName,
-// or that force_savepoint_restart==true. We accept everything with the
-// desired prefix because at least the C++ libpqxx appends sequence
-// numbers to the savepoint name specified by the user.
+// validateSavepointName validates that the provided restart savepoint ident
+// matches the active savepoint name, if any. See isRestartSavepoint for the
+// names that designate the restart savepoint.
 func (ex *connExecutor) validateSavepointName(savepoint tree.Name) error {
 	if ex.state.activeSavepointName != "" {
 		if savepoint == ex.state.activeSavepointName {
@@ -1458,11 +1548,5 @@ func (ex *connExecutor) validateSavepointName(savepoint tree.Name) error {
 		return pgerror.NewErrorf(pgerror.CodeInvalidSavepointSpecificationError,
 			`SAVEPOINT %q is in use`, tree.ErrString(&ex.state.activeSavepointName))
 	}
-	if !ex.sessionData.ForceSavepointRestart && !strings.HasPrefix(string(savepoint), RestartSavepointName) {
-		return pgerror.UnimplementedWithIssueHintError(10735,
-			"SAVEPOINT not supported except for "+RestartSavepointName,
-			"Retryable transactions with arbitrary SAVEPOINT names can be enabled "+
-				"with SET force_savepoint_restart=true")
-	}
 	return nil
 }
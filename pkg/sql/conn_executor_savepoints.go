@@ -0,0 +1,146 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/pkg/errors"
+)
+
+// savepoint is a regular SQL savepoint, as opposed to the special
+// cockroach_restart savepoint which is handled by the state machine.
+type savepoint struct {
+	name tree.Name
+	// kvToken identifies the state of the KV transaction when the savepoint was
+	// established.
+	kvToken client.SavepointToken
+	// numDDL is the number of DDL statements executed in the transaction before
+	// the savepoint was established.
+	numDDL int
+}
+
+// savepointStack is the stack of savepoints established in a transaction,
+// innermost last.
+type savepointStack []savepoint
+
+// find returns the index of the innermost savepoint with the given name, or
+// -1 if there is none. Like Postgres, we allow names to be reused; the newer
+// savepoint shadows the older one until it is released.
+func (s savepointStack) find(name tree.Name) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isRestartSavepoint returns true if the savepoint name designates the special
+// cockroach_restart savepoint. We accept everything with the desired prefix
+// because at least the C++ libpqxx appends sequence numbers to the savepoint
+// name specified by the user. All names are treated as restart savepoints when
+// force_savepoint_restart is set.
+func (ex *connExecutor) isRestartSavepoint(name tree.Name) bool {
+	return ex.sessionData.ForceSavepointRestart ||
+		strings.HasPrefix(string(name), RestartSavepointName)
+}
+
+func newSavepointDoesNotExistError(name tree.Name) error {
+	return pgerror.NewErrorf(pgerror.CodeInvalidSavepointSpecificationError,
+		"savepoint %q does not exist", tree.ErrString(&name))
+}
+
+// checkSavepointsAllowed returns an error if regular savepoints cannot be used
+// in the current transaction.
+func (ex *connExecutor) checkSavepointsAllowed(implicitTxn bool) error {
+	if implicitTxn {
+		return pgerror.NewErrorf(pgerror.CodeNoActiveSQLTransactionError,
+			"SAVEPOINT can only be used in transaction blocks")
+	}
+	if !ex.server.cfg.Settings.Version.IsActive(cluster.VersionSavepoints) {
+		return errors.Errorf(`SAVEPOINT requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionSavepoints),
+		)
+	}
+	return nil
+}
+
+// execSavepointInOpenState establishes a regular savepoint.
+func (ex *connExecutor) execSavepointInOpenState(
+	ctx context.Context, s *tree.Savepoint, implicitTxn bool,
+) error {
+	if err := ex.checkSavepointsAllowed(implicitTxn); err != nil {
+		return err
+	}
+	token, err := ex.state.mu.txn.CreateSavepoint(ctx)
+	if err != nil {
+		return err
+	}
+	ex.state.savepoints = append(ex.state.savepoints, savepoint{
+		name:    s.Name,
+		kvToken: token,
+		numDDL:  ex.state.numDDL,
+	})
+	return nil
+}
+
+// execReleaseSavepointInOpenState releases a regular savepoint along with all
+// the savepoints established after it. The writes performed since then are
+// kept.
+func (ex *connExecutor) execReleaseSavepointInOpenState(
+	ctx context.Context, s *tree.ReleaseSavepoint, implicitTxn bool,
+) error {
+	if err := ex.checkSavepointsAllowed(implicitTxn); err != nil {
+		return err
+	}
+	idx := ex.state.savepoints.find(s.Savepoint)
+	if idx == -1 {
+		return newSavepointDoesNotExistError(s.Savepoint)
+	}
+	if err := ex.state.mu.txn.ReleaseSavepoint(ctx, ex.state.savepoints[idx].kvToken); err != nil {
+		return err
+	}
+	ex.state.savepoints = ex.state.savepoints[:idx]
+	return nil
+}
+
+// execRollbackToSavepoint rolls back the transaction to the savepoint with the
+// given name, undoing all the writes performed since. The savepoint itself
+// remains established; the ones established after it are released. It is
+// used in both the Open and the Aborted states.
+func (ex *connExecutor) execRollbackToSavepoint(ctx context.Context, name tree.Name) error {
+	idx := ex.state.savepoints.find(name)
+	if idx == -1 {
+		return newSavepointDoesNotExistError(name)
+	}
+	sp := &ex.state.savepoints[idx]
+	if ex.state.numDDL > sp.numDDL {
+		// Rolling back schema changes would require undoing the changes staged
+		// in the connExecutor's descriptor collection and schema changers too.
+		return pgerror.UnimplementedWithIssueError(10735,
+			"ROLLBACK TO SAVEPOINT not supported after DDL statements")
+	}
+	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, sp.kvToken); err != nil {
+		return err
+	}
+	ex.state.savepoints = ex.state.savepoints[:idx+1]
+	return nil
+}
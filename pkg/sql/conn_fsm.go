@@ -214,18 +214,24 @@ var _ payloadWithError = eventRetriableErrPayload{}
 // cockroach_restart. It moves the state to CommitWait.
 type eventTxnReleased struct{}
 
+// eventSavepointRollback is generated in the Aborted state after a successful
+// ROLLBACK TO SAVEPOINT of a regular savepoint. It moves the state back to
+// Open.
+type eventSavepointRollback struct{}
+
 // payloadWithError is a common interface for the payloads that wrap an error.
 type payloadWithError interface {
 	errorCause() error
 }
 
-func (eventRetryIntentSet) Event()  {}
-func (eventTxnStart) Event()        {}
-func (eventTxnFinish) Event()       {}
-func (eventTxnRestart) Event()      {}
-func (eventNonRetriableErr) Event() {}
-func (eventRetriableErr) Event()    {}
-func (eventTxnReleased) Event()     {}
+func (eventRetryIntentSet) Event()    {}
+func (eventTxnStart) Event()          {}
+func (eventTxnFinish) Event()         {}
+func (eventTxnRestart) Event()        {}
+func (eventNonRetriableErr) Event()   {}
+func (eventRetriableErr) Event()      {}
+func (eventTxnReleased) Event()       {}
+func (eventSavepointRollback) Event() {}
 
 // TxnStateTransitions describe the transitions used by a connExecutor's
 // fsm.Machine. Args.Extended is a txnState, which is muted by the Actions.
@@ -331,9 +337,15 @@ var TxnStateTransitions = Compile(Pattern{
 			Next: stateAborted{RetryIntent: Var("retryIntent")},
 			Action: func(args Args) error {
 				ts := args.Extended.(*txnState)
+				ts.txnAbortCount.Inc(1)
+				if len(ts.savepoints) > 0 {
+					// Keep the KV txn, and the state associated with it, around so
+					// that the client can roll back to one of the savepoints.
+					ts.setAdvanceInfo(skipBatch, noRewind, noEvent)
+					return nil
+				}
 				ts.mu.txn.CleanupOnError(ts.Ctx, args.Payload.(payloadWithError).errorCause())
 				ts.setAdvanceInfo(skipBatch, noRewind, txnAborted)
-				ts.txnAbortCount.Inc(1)
 				return nil
 			},
 		},
@@ -390,9 +402,8 @@ var TxnStateTransitions = Compile(Pattern{
 			Action: func(args Args) error {
 				state := args.Extended.(*txnState)
 				// NOTE: We don't bump the txn timestamp on this restart. Should we?
-				// Well, if we generally supported savepoints and one would issue a
-				// rollback to a regular savepoint, clearly we couldn't bump the
-				// timestamp in that case. In the special case of the cockroach_restart
+				// Well, when rolling back to a regular savepoint we clearly don't bump
+				// the timestamp. In the special case of the cockroach_restart
 				// savepoint, it's not clear to me what a user's expectation might be.
 				state.mu.txn.ManualRestart(args.Ctx, hlc.Timestamp{})
 				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, txnRestart)
@@ -417,7 +428,7 @@ var TxnStateTransitions = Compile(Pattern{
 				return nil
 			},
 		},
-		eventNonRetriableErr{IsCommit: Any}: {
+		eventNonRetriableErr{IsCommit: False}: {
 			// This event doesn't change state, but it returns a skipBatch code.
 			Description: "any other statement",
 			Next:        stateAborted{RetryIntent: Var("retryIntent")},
@@ -426,6 +437,31 @@ var TxnStateTransitions = Compile(Pattern{
 				return nil
 			},
 		},
+		eventNonRetriableErr{IsCommit: True}: {
+			// This event doesn't change state, but it returns a skipBatch code.
+			// It is only generated when the connection is closing, in which case
+			// a KV txn kept open for the savepoints is rolled back.
+			Description: "connection closing",
+			Next:        stateAborted{RetryIntent: Var("retryIntent")},
+			Action: func(args Args) error {
+				ts := args.Extended.(*txnState)
+				if len(ts.savepoints) > 0 {
+					ts.mu.txn.CleanupOnError(ts.Ctx, args.Payload.(payloadWithError).errorCause())
+					ts.savepoints = nil
+				}
+				ts.setAdvanceInfo(skipBatch, noRewind, noEvent)
+				return nil
+			},
+		},
+		// ROLLBACK TO SAVEPOINT of a regular savepoint.
+		eventSavepointRollback{}: {
+			Description: "ROLLBACK TO SAVEPOINT of a regular savepoint",
+			Next:        stateOpen{ImplicitTxn: False, RetryIntent: Var("retryIntent")},
+			Action: func(args Args) error {
+				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, noEvent)
+				return nil
+			},
+		},
 	},
 	stateAborted{RetryIntent: True}: {
 		// ROLLBACK TO SAVEPOINT. We accept this in the Aborted state for the
@@ -0,0 +1,138 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CopyFormat describes how the rows of a COPY TO statement are encoded in the
+// Copy-out pgwire subprotocol. The rows are produced like the rows of a query;
+// it is up to the connection to encode them as CopyData messages instead of
+// DataRow messages.
+type CopyFormat struct {
+	// CSV is set for the CSV format, and unset for the text format.
+	CSV bool
+	// Delimiter separates the columns of a row.
+	Delimiter byte
+	// Null is the string representing NULL values.
+	Null string
+	// Header is set if the rows are preceded by a line containing the names
+	// of the columns.
+	Header bool
+}
+
+// MakeCopyFormat validates the options of a COPY TO statement and returns
+// the corresponding format. The defaults are the ones of Postgres: the text
+// format uses tabs and \N, and the CSV format uses commas and empty strings.
+func MakeCopyFormat(opts *tree.CopyOptions) (CopyFormat, error) {
+	var f CopyFormat
+	switch opts.DataFormat {
+	case "", "text":
+		f = CopyFormat{Delimiter: '\t', Null: `\N`}
+	case "csv":
+		f = CopyFormat{CSV: true, Delimiter: ',', Null: ""}
+	case "binary":
+		return CopyFormat{}, pgerror.Unimplemented("copy.binary",
+			"binary format for COPY is not supported")
+	default:
+		return CopyFormat{}, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+			"COPY format %q not recognized", string(opts.DataFormat))
+	}
+
+	if opts.Delimiter != nil {
+		if len(*opts.Delimiter) != 1 {
+			return CopyFormat{}, pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+				"COPY delimiter must be a single one-byte character")
+		}
+		f.Delimiter = (*opts.Delimiter)[0]
+	}
+	if f.Delimiter == '\n' || f.Delimiter == '\r' {
+		return CopyFormat{}, pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+			"COPY delimiter cannot be newline or carriage return")
+	}
+	if !f.CSV && f.Delimiter == '\\' {
+		return CopyFormat{}, pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+			`COPY delimiter cannot be "\"`)
+	}
+	if f.CSV && f.Delimiter == '"' {
+		return CopyFormat{}, pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+			"COPY delimiter and quote must be different")
+	}
+
+	if opts.Null != nil {
+		f.Null = *opts.Null
+	}
+	if strings.ContainsAny(f.Null, "\r\n") {
+		return CopyFormat{}, pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+			"COPY null representation cannot use newline or carriage return")
+	}
+	if f.CSV && strings.IndexByte(f.Null, f.Delimiter) >= 0 {
+		return CopyFormat{}, pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+			"COPY delimiter must not appear in the NULL specification")
+	}
+
+	if opts.Header {
+		if !f.CSV {
+			return CopyFormat{}, pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+				"COPY HEADER available only in CSV mode")
+		}
+		f.Header = true
+	}
+	return f, nil
+}
+
+// CopyTo plans a COPY TO statement. The statement returns the rows of the
+// table or of the query like a SELECT statement would.
+// Privileges: SELECT on table.
+//   Notes: postgres requires SELECT on the copied columns.
+func (p *planner) CopyTo(ctx context.Context, n *tree.CopyTo) (planNode, error) {
+	if _, err := MakeCopyFormat(&n.Options); err != nil {
+		return nil, err
+	}
+	if n.Query != nil {
+		return p.newPlan(ctx, n.Query, nil /* desiredTypes */)
+	}
+
+	// Only tables can be copied; views and sequences must be copied with the
+	// COPY (query) TO form.
+	tableDesc, err := ResolveExistingObject(ctx, p, &n.Table, true /*required*/, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	exprs := tree.SelectExprs{tree.StarSelectExpr()}
+	if len(n.Columns) > 0 {
+		cols, err := p.processColumns(tableDesc, n.Columns,
+			true /* ensureColumns */, false /* allowMutations */)
+		if err != nil {
+			return nil, err
+		}
+		exprs = make(tree.SelectExprs, len(cols))
+		for i := range cols {
+			exprs[i] = tree.SelectExpr{Expr: tree.NewUnresolvedName(cols[i].Name)}
+		}
+	}
+	sel := &tree.Select{
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From:  &tree.From{Tables: tree.TableExprs{&n.Table}},
+		},
+	}
+	return p.newPlan(ctx, sel, nil /* desiredTypes */)
+}
@@ -303,7 +303,14 @@ CREATE TABLE crdb_internal.schema_changes (
 					targetName = tree.NewDString(d.Index.Name)
 				case *sqlbase.DescriptorMutation_Constraint:
 					mutType = "CONSTRAINT VALIDATION"
+					if d.Constraint.ConstraintType == sqlbase.ConstraintToValidate_NOT_NULL {
+						mutType = "NOT NULL VALIDATION"
+						targetID = tree.NewDInt(tree.DInt(int64(d.Constraint.NotNullColumn)))
+					}
 					targetName = tree.NewDString(d.Constraint.Name)
+				case *sqlbase.DescriptorMutation_ComputedColumnSwap:
+					mutType = "COMPUTED COLUMN SWAP"
+					targetID = tree.NewDInt(tree.DInt(int64(d.ComputedColumnSwap.NewColumnID)))
 				}
 				if err := addRow(
 					tableID,
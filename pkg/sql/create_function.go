@@ -0,0 +1,340 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// createFunctionNode represents a CREATE FUNCTION statement.
+type createFunctionNode struct {
+	n      *tree.CreateFunction
+	dbDesc *sqlbase.DatabaseDescriptor
+	// desc is the descriptor of the new function. Its ID, version and
+	// privileges are filled in by startExec.
+	desc sqlbase.FunctionDescriptor
+}
+
+// CreateFunction creates a user-defined function.
+// Privileges: CREATE on database, plus the privileges needed to run the body
+// of the function.
+//   notes: postgres requires USAGE on the language and CREATE on the schema.
+func (p *planner) CreateFunction(ctx context.Context, n *tree.CreateFunction) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionUserDefinedFunctions) {
+		return nil, errors.Errorf(`CREATE FUNCTION requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionUserDefinedFunctions),
+		)
+	}
+	if n.Options.Language == "" {
+		return nil, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+			"no language specified")
+	}
+	if n.Options.Language != "sql" {
+		return nil, pgerror.UnimplementedWithIssueErrorf(17511,
+			"language %q is not supported", n.Options.Language)
+	}
+	if n.Options.Body == nil {
+		return nil, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+			"no function body specified")
+	}
+
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotTemporarySchema(&n.Name, "function"); err != nil {
+		return nil, err
+	}
+	if err := checkPublicSchema(&n.Name, "function"); err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	desc := sqlbase.FunctionDescriptor{
+		Name:     n.Name.Table(),
+		ParentID: dbDesc.ID,
+		Language: string(n.Options.Language),
+		Body:     *n.Options.Body,
+		Args:     make([]sqlbase.FunctionDescriptor_Arg, len(n.Args)),
+	}
+	for i := range n.Args {
+		desc.Args[i].Name = string(n.Args[i].Name)
+		desc.Args[i].Type, err = sqlbase.DatumTypeToColumnType(
+			coltypes.CastTargetToDatumType(n.Args[i].Type))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if n.ReturnsTrigger {
+		if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionTriggers) {
+			return nil, errors.Errorf(`CREATE FUNCTION ... RETURNS TRIGGER requires all nodes to be upgraded to %s`,
+				cluster.VersionByKey(cluster.VersionTriggers),
+			)
+		}
+		if len(n.Args) > 0 {
+			return nil, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+				"trigger functions cannot have declared arguments").SetHintf(
+				"The arguments of the trigger can be accessed through tg_nargs and tg_argv instead.")
+		}
+		desc.ReturnsTrigger = true
+	} else {
+		desc.ReturnType, err = sqlbase.DatumTypeToColumnType(coltypes.CastTargetToDatumType(n.ReturnType))
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch n.Options.Volatility {
+	case tree.FunctionStable:
+		desc.Volatility = sqlbase.FunctionDescriptor_STABLE
+	case tree.FunctionImmutable:
+		desc.Volatility = sqlbase.FunctionDescriptor_IMMUTABLE
+	default:
+		desc.Volatility = sqlbase.FunctionDescriptor_VOLATILE
+	}
+	switch n.Options.NullInput {
+	case tree.FunctionReturnsNullOnNullInput, tree.FunctionStrict:
+		desc.Strict = true
+	}
+
+	seen := make(map[string]struct{}, len(desc.Args))
+	for i := range desc.Args {
+		name := desc.Args[i].Name
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			return nil, pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+				"parameter name %q used more than once", name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	if desc.ReturnsTrigger {
+		// The body of a trigger function can only be checked against the
+		// tables of its triggers, when the trigger is created.
+		if _, err := parseTriggerFunctionBody(desc.Body); err != nil {
+			return nil, err
+		}
+	} else if err := p.validateFunctionBody(ctx, &desc); err != nil {
+		return nil, err
+	}
+
+	return &createFunctionNode{n: n, dbDesc: dbDesc, desc: desc}, nil
+}
+
+// validateFunctionBody checks that the body of the given function is a valid
+// query that returns a single column of the return type of the function,
+// when the parameters are bound to arguments of the declared types.
+func (p *planner) validateFunctionBody(ctx context.Context, desc *sqlbase.FunctionDescriptor) error {
+	query, err := makeFunctionQuery(desc)
+	if err != nil {
+		return err
+	}
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+
+	// Build the query that evaluates the function in a separate memo; the
+	// arguments are placeholders whose types are given by the casts in the
+	// query.
+	var catalog optCatalog
+	catalog.init(p.execCfg.TableStatsCache, p)
+	semaCtx := p.semaCtx
+	if err := semaCtx.Placeholders.Init(len(desc.Args), nil /* typeHints */); err != nil {
+		return err
+	}
+	var f norm.Factory
+	f.Init(p.EvalContext())
+	bld := optbuilder.New(ctx, &semaCtx, p.EvalContext(), &catalog, &f, stmt.AST)
+	bld.KeepPlaceholders = true
+	if err := bld.Build(); err != nil {
+		return err
+	}
+
+	col := f.Memo().RootProps().Presentation[0].ID
+	typ := f.Metadata().ColumnMeta(col).Type
+	retType := desc.ReturnType.ToDatumType()
+	if typ != types.Unknown && !typ.Equivalent(retType) {
+		return pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+			"return type mismatch in function declared to return %s", retType).SetDetailf(
+			"Actual return type is %s.", typ)
+	}
+	return nil
+}
+
+func (n *createFunctionNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	desc := &n.desc
+	key := tableKey{parentID: n.dbDesc.ID, name: desc.Name}.Key()
+
+	existing, err := getFunctionDesc(ctx, p.txn, n.dbDesc.ID, desc.Name)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if exists, err := descExists(ctx, p.txn, key); err != nil {
+			return err
+		} else if exists {
+			return newNameInUseError(ctx, p.txn, key, "function", desc.Name)
+		}
+
+		id, err := GenerateUniqueDescID(ctx, params.extendedEvalCtx.ExecCfg.DB)
+		if err != nil {
+			return err
+		}
+		desc.Privileges = MakeFunctionPrivileges(n.dbDesc.GetPrivileges(), p.User())
+		if err := p.createDescriptorWithID(ctx, key, id, desc, params.EvalContext().Settings); err != nil {
+			return err
+		}
+	} else {
+		if !n.n.Replace {
+			return sqlbase.NewFunctionAlreadyExistsError(desc.Name)
+		}
+		if err := p.CheckPrivilege(ctx, existing, privilege.DROP); err != nil {
+			return err
+		}
+		if err := checkFunctionReplacement(existing, desc); err != nil {
+			return err
+		}
+		desc.ID = existing.ID
+		desc.Version = existing.Version + 1
+		desc.Privileges = existing.Privileges
+		desc.TriggerTableIDs = existing.TriggerTableIDs
+		if err := p.validateFunctionTriggers(ctx, desc); err != nil {
+			return err
+		}
+		if err := writeFunctionDesc(ctx, p, desc); err != nil {
+			return err
+		}
+	}
+
+	// Log Create Function event. This is an auditable log event and is
+	// recorded in the same transaction as the function descriptor update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		ctx,
+		p.txn,
+		EventLogCreateFunction,
+		int32(desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			FunctionName string
+			Statement    string
+			User         string
+		}{n.n.Name.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (*createFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (*createFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createFunctionNode) Close(context.Context)        {}
+
+// MakeFunctionPrivileges returns the privileges of a new function. Like
+// tables, functions inherit the privileges of their database, limited to the
+// privileges that apply to functions. The creator of the function is granted
+// all privileges on it, so that it can be called.
+func MakeFunctionPrivileges(
+	dbPrivs *sqlbase.PrivilegeDescriptor, user string,
+) *sqlbase.PrivilegeDescriptor {
+	privs := sqlbase.NewDefaultPrivilegeDescriptor()
+	for _, u := range dbPrivs.Users {
+		var kinds privilege.List
+		for _, k := range privilege.ListFromBitField(u.Privileges) {
+			if privilege.FunctionPrivileges.Contains(k) {
+				kinds = append(kinds, k)
+			}
+		}
+		if len(kinds) > 0 {
+			privs.Grant(u.User, kinds)
+		}
+	}
+	privs.Grant(user, privilege.List{privilege.ALL})
+	return privs
+}
+
+// checkFunctionReplacement returns an error if CREATE OR REPLACE FUNCTION
+// cannot replace the existing function with the new one. As in Postgres, the
+// signature of the function cannot change.
+func checkFunctionReplacement(existing, desc *sqlbase.FunctionDescriptor) error {
+	sameArgs := len(existing.Args) == len(desc.Args)
+	for i := 0; sameArgs && i < len(desc.Args); i++ {
+		sameArgs = existing.Args[i].Type.Equal(desc.Args[i].Type)
+	}
+	if !sameArgs {
+		return pgerror.NewErrorf(pgerror.CodeDuplicateFunctionError,
+			"function %q already exists with different argument types", desc.Name)
+	}
+	if existing.ReturnsTrigger != desc.ReturnsTrigger || !existing.ReturnType.Equal(desc.ReturnType) {
+		return pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+			"cannot change return type of existing function").SetHintf(
+			"Use DROP FUNCTION %s first.", desc.Name)
+	}
+	return nil
+}
+
+// validateFunctionTriggers checks that the new body of a trigger function is
+// valid for all the triggers that execute it.
+func (p *planner) validateFunctionTriggers(
+	ctx context.Context, desc *sqlbase.FunctionDescriptor,
+) error {
+	for _, id := range desc.TriggerTableIDs {
+		tableDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, id)
+		if err != nil {
+			return err
+		}
+		for i := range tableDesc.Triggers {
+			t := &tableDesc.Triggers[i]
+			if t.FunctionID != desc.ID {
+				continue
+			}
+			if err := p.validateTriggerFunction(ctx, t, desc, tableDesc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeFunctionDesc writes an updated function descriptor.
+func writeFunctionDesc(ctx context.Context, p *planner, desc *sqlbase.FunctionDescriptor) error {
+	if err := desc.Validate(); err != nil {
+		return err
+	}
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	descDesc := sqlbase.WrapDescriptor(desc)
+	if p.extendedEvalCtx.Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "Put %s -> %s", descKey, descDesc)
+	}
+	b := &client.Batch{}
+	b.Put(descKey, descDesc)
+	return p.txn.Run(ctx, b)
+}
@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/pkg/errors"
 )
 
 type createIndexNode struct {
@@ -72,6 +75,10 @@ func MakeIndexDescriptor(n *tree.CreateIndex) (*sqlbase.IndexDescriptor, error)
 		if n.Unique {
 			return nil, pgerror.NewError(pgerror.CodeInvalidSQLStatementNameError, "inverted indexes can't be unique")
 		}
+
+		if n.Predicate != nil {
+			return nil, pgerror.NewError(pgerror.CodeInvalidSQLStatementNameError, "inverted indexes can't be partial")
+		}
 		indexDesc.Type = sqlbase.IndexDescriptor_INVERTED
 	}
 
@@ -81,6 +88,49 @@ func MakeIndexDescriptor(n *tree.CreateIndex) (*sqlbase.IndexDescriptor, error)
 	return &indexDesc, nil
 }
 
+// makePartialIndexPredicate validates the predicate of a partial index on the
+// given table and returns it in the form in which it is stored in the index
+// descriptor. The predicate must be a boolean expression over the columns of
+// the table; it cannot contain subqueries, aggregates or impure functions,
+// since it has to evaluate to the same value each time a row is written.
+func makePartialIndexPredicate(
+	ctx context.Context,
+	st *cluster.Settings,
+	desc *sqlbase.MutableTableDescriptor,
+	pred tree.Expr,
+	semaCtx *tree.SemaContext,
+	tableName tree.TableName,
+) (string, error) {
+	if !st.Version.IsActive(cluster.VersionPartialIndexes) {
+		return "", errors.Errorf(`partial indexes require all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionPartialIndexes),
+		)
+	}
+	expr, _, err := replaceVars(desc, pred)
+	if err != nil {
+		return "", err
+	}
+
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called from another context
+	// which uses the properties field.
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	semaCtx.Properties.Require("index predicate",
+		tree.RejectSpecial|tree.RejectSubqueries|tree.RejectImpureFunctions)
+	if _, err := tree.TypeCheckAndRequire(expr, semaCtx, types.Bool, "index predicate"); err != nil {
+		return "", err
+	}
+
+	sourceInfo := sqlbase.NewSourceInfoForSingleTable(
+		tableName, sqlbase.ResultColumnsFromColDescs(desc.TableDesc().AllNonDropColumns()),
+	)
+	expr, err = dequalifyColumnRefs(ctx, sqlbase.MultiSourceInfo{sourceInfo}, pred)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(expr), nil
+}
+
 func (n *createIndexNode) startExec(params runParams) error {
 	_, dropped, err := n.tableDesc.FindIndexByName(string(n.n.Name))
 	if err == nil {
@@ -106,6 +156,14 @@ func (n *createIndexNode) startExec(params runParams) error {
 		indexDesc.Partitioning = partitioning
 	}
 
+	if n.n.Predicate != nil {
+		indexDesc.Predicate, err = makePartialIndexPredicate(
+			params.ctx, params.ExecCfg().Settings, n.tableDesc, n.n.Predicate, &params.p.semaCtx, n.n.Table)
+		if err != nil {
+			return err
+		}
+	}
+
 	mutationIdx := len(n.tableDesc.Mutations)
 	if err := n.tableDesc.AddIndexMutation(indexDesc, sqlbase.DescriptorMutation_ADD); err != nil {
 		return err
@@ -0,0 +1,128 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/pkg/errors"
+)
+
+// createSchemaNode represents a CREATE SCHEMA statement.
+type createSchemaNode struct {
+	n      *tree.CreateSchema
+	dbDesc *sqlbase.DatabaseDescriptor
+}
+
+// CreateSchema creates a schema in the current database.
+// Privileges: CREATE on database.
+//   notes: postgres requires CREATE on the database.
+func (p *planner) CreateSchema(ctx context.Context, n *tree.CreateSchema) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionUserDefinedSchemas) {
+		return nil, errors.Errorf(`CREATE SCHEMA requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionUserDefinedSchemas),
+		)
+	}
+	if p.CurrentDatabase() == "" {
+		return nil, errNoDatabase
+	}
+	if err := checkSchemaNameAvailable(string(n.Schema)); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.ResolveUncachedDatabaseByName(ctx, p.CurrentDatabase(), true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return &createSchemaNode{n: n, dbDesc: dbDesc}, nil
+}
+
+// checkSchemaNameAvailable returns an error if the given name cannot be used
+// for a user-defined schema because it is the name of a builtin schema or
+// uses the prefix reserved for system schemas.
+func checkSchemaNameAvailable(name string) error {
+	if name == tree.PublicSchema || isVirtualSchemaName(name) {
+		return sqlbase.NewSchemaAlreadyExistsError(name)
+	}
+	if strings.HasPrefix(name, "pg_") {
+		return pgerror.NewErrorf(pgerror.CodeReservedNameError,
+			"unacceptable schema name %q", name).SetDetailf(
+			"The prefix \"pg_\" is reserved for system schemas.")
+	}
+	return nil
+}
+
+func (n *createSchemaNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	name := string(n.n.Schema)
+	key := tableKey{parentID: n.dbDesc.ID, name: name}.Key()
+
+	if exists, err := descExists(ctx, p.txn, key); err != nil {
+		return err
+	} else if exists {
+		existing, err := getSchemaDesc(ctx, p.txn, n.dbDesc.ID, name)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return sqlbase.NewRelationAlreadyExistsError(name)
+		}
+		if n.n.IfNotExists {
+			return nil
+		}
+		return sqlbase.NewSchemaAlreadyExistsError(name)
+	}
+
+	id, err := GenerateUniqueDescID(ctx, params.extendedEvalCtx.ExecCfg.DB)
+	if err != nil {
+		return err
+	}
+	desc := &sqlbase.SchemaDescriptor{
+		Name:       name,
+		ParentID:   n.dbDesc.ID,
+		Privileges: n.dbDesc.GetPrivileges(),
+	}
+	if err := p.createDescriptorWithID(ctx, key, id, desc, params.EvalContext().Settings); err != nil {
+		return err
+	}
+
+	// Log Create Schema event. This is an auditable log event and is recorded
+	// in the same transaction as the schema descriptor creation.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		ctx,
+		p.txn,
+		EventLogCreateSchema,
+		int32(desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			SchemaName string
+			Statement  string
+			User       string
+		}{name, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (*createSchemaNode) Next(runParams) (bool, error) { return false, nil }
+func (*createSchemaNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createSchemaNode) Close(context.Context)        {}
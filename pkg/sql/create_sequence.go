@@ -29,6 +29,7 @@ import (
 type createSequenceNode struct {
 	n      *tree.CreateSequence
 	dbDesc *sqlbase.DatabaseDescriptor
+	scDesc *sqlbase.SchemaDescriptor
 }
 
 func (p *planner) CreateSequence(ctx context.Context, n *tree.CreateSequence) (planNode, error) {
@@ -36,25 +37,38 @@ func (p *planner) CreateSequence(ctx context.Context, n *tree.CreateSequence) (p
 	if err != nil {
 		return nil, err
 	}
+	if err := checkNotTemporarySchema(&n.Name, "sequence"); err != nil {
+		return nil, err
+	}
+	scDesc, err := resolveTargetSchema(ctx, p.txn, dbDesc, &n.Name)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+	if scDesc != nil {
+		err = p.CheckPrivilege(ctx, scDesc, privilege.CREATE)
+	} else {
+		err = p.CheckPrivilege(ctx, dbDesc, privilege.CREATE)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	return &createSequenceNode{
 		n:      n,
 		dbDesc: dbDesc,
+		scDesc: scDesc,
 	}, nil
 }
 
 func (n *createSequenceNode) startExec(params runParams) error {
-	tKey := getSequenceKey(n.dbDesc, n.n.Name.Table())
+	tKey := getSequenceKey(n.dbDesc, n.scDesc, n.n.Name.Table())
 	if exists, err := descExists(params.ctx, params.p.txn, tKey.Key()); err == nil && exists {
 		if n.n.IfNotExists {
 			// If the sequence exists but the user specified IF NOT EXISTS, return without doing anything.
 			return nil
 		}
-		return sqlbase.NewRelationAlreadyExistsError(tKey.Name())
+		return newNameInUseError(params.ctx, params.p.txn, tKey.Key(), "relation", tKey.Name())
 	} else if err != nil {
 		return err
 	}
@@ -62,7 +76,14 @@ func (n *createSequenceNode) startExec(params runParams) error {
 	return doCreateSequence(params, n.n.String(), n.dbDesc, &n.n.Name, n.n.Options)
 }
 
-func getSequenceKey(dbDesc *DatabaseDescriptor, seqName string) tableKey {
+// getSequenceKey returns the namespace key of a sequence of the given
+// database. scDesc is the user-defined schema of the sequence, if any.
+func getSequenceKey(
+	dbDesc *DatabaseDescriptor, scDesc *sqlbase.SchemaDescriptor, seqName string,
+) tableKey {
+	if scDesc != nil {
+		return tableKey{parentID: scDesc.ID, name: seqName}
+	}
 	return tableKey{parentID: dbDesc.ID, name: seqName}
 }
 
@@ -75,13 +96,23 @@ func doCreateSequence(
 	name *ObjectName,
 	opts tree.SequenceOptions,
 ) error {
+	// The sequences created for SERIAL columns live in the schema of their
+	// table, so the schema is resolved here rather than by the callers.
+	scDesc, err := resolveTargetSchema(params.ctx, params.p.txn, dbDesc, name)
+	if err != nil {
+		return err
+	}
+
 	id, err := GenerateUniqueDescID(params.ctx, params.p.ExecCfg().DB)
 	if err != nil {
 		return err
 	}
 
-	// Inherit permissions from the database descriptor.
+	// Inherit permissions from the database or schema descriptor.
 	privs := dbDesc.GetPrivileges()
+	if scDesc != nil {
+		privs = scDesc.GetPrivileges()
+	}
 
 	desc, err := MakeSequenceTableDesc(name.Table(), opts,
 		dbDesc.ID, id, params.p.txn.CommitTimestamp(), privs, params.EvalContext().Settings)
@@ -89,10 +120,14 @@ func doCreateSequence(
 		return err
 	}
 
+	if scDesc != nil {
+		desc.UnexposedParentSchemaID = scDesc.ID
+	}
+
 	// makeSequenceTableDesc already validates the table. No call to
 	// desc.ValidateTable() needed here.
 
-	key := getSequenceKey(dbDesc, name.Table()).Key()
+	key := getSequenceKey(dbDesc, scDesc, name.Table()).Key()
 	if err = params.p.createDescriptorWithID(params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
 		return err
 	}
@@ -102,7 +102,7 @@ func (n *createStatsNode) startJob(ctx context.Context, resultsCh chan<- tree.Da
 		// TODO(anyone): if CREATE STATISTICS is meant to be able to operate
 		// within a transaction, then the following should probably run with
 		// caching disabled, like other DDL statements.
-		tableDesc, err = ResolveExistingObject(ctx, n.p, t, true /*required*/, requireTableDesc)
+		tableDesc, err = ResolveExistingObject(ctx, n.p, t, true /*required*/, requireTableOrViewDesc)
 		if err != nil {
 			return err
 		}
@@ -121,7 +121,7 @@ func (n *createStatsNode) startJob(ctx context.Context, resultsCh chan<- tree.Da
 		return pgerror.NewError(pgerror.CodeWrongObjectTypeError, "cannot create statistics on virtual tables")
 	}
 
-	if tableDesc.IsView() {
+	if tableDesc.IsView() && !tableDesc.MaterializedView() {
 		return pgerror.NewError(pgerror.CodeWrongObjectTypeError, "cannot create statistics on views")
 	}
 
@@ -34,27 +34,47 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/pkg/errors"
 )
 
 type createTableNode struct {
-	n          *tree.CreateTable
-	dbDesc     *sqlbase.DatabaseDescriptor
+	n      *tree.CreateTable
+	dbDesc *sqlbase.DatabaseDescriptor
+	// scDesc is the descriptor of the user-defined schema of the table, if
+	// any.
+	scDesc     *sqlbase.SchemaDescriptor
 	sourcePlan planNode
 
+	// view is set when the table stores the rows of a materialized view
+	// created by CREATE MATERIALIZED VIEW. viewDeps then tracks the tables
+	// and views the view depends on.
+	view     *tree.CreateView
+	viewDeps planDependencies
+
 	run createTableRun
 }
 
 // CreateTable creates a table.
-// Privileges: CREATE on database.
+// Privileges: CREATE on database, or CREATE on schema for tables in a
+// user-defined schema.
 //   Notes: postgres/mysql require CREATE on database.
 func (p *planner) CreateTable(ctx context.Context, n *tree.CreateTable) (planNode, error) {
 	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.Table)
 	if err != nil {
 		return nil, err
 	}
+	scDesc, err := resolveTargetSchema(ctx, p.txn, dbDesc, &n.Table)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+	if scDesc != nil {
+		err = p.CheckPrivilege(ctx, scDesc, privilege.CREATE)
+	} else {
+		err = p.CheckPrivilege(ctx, dbDesc, privilege.CREATE)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -85,7 +105,7 @@ func (p *planner) CreateTable(ctx context.Context, n *tree.CreateTable) (planNod
 		synthRowID = true
 	}
 
-	ct := &createTableNode{n: n, dbDesc: dbDesc, sourcePlan: sourcePlan}
+	ct := &createTableNode{n: n, dbDesc: dbDesc, scDesc: scDesc, sourcePlan: sourcePlan}
 	ct.run.synthRowID = synthRowID
 	return ct, nil
 }
@@ -104,13 +124,29 @@ type createTableRun struct {
 }
 
 func (n *createTableNode) startExec(params runParams) error {
-	tKey := tableKey{parentID: n.dbDesc.ID, name: n.n.Table.Table()}
+	parentID := n.dbDesc.ID
+	if n.scDesc != nil {
+		parentID = n.scDesc.ID
+	}
+	temporary := isTemporaryTable(n.n)
+	if temporary {
+		if n.n.Table.ExplicitSchema && (n.n.Table.Schema() == tree.PublicSchema || n.scDesc != nil) {
+			return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"cannot create temporary relation in non-temporary schema")
+		}
+		var err error
+		parentID, err = params.p.getOrCreateTemporarySchema(params.ctx, n.dbDesc.ID)
+		if err != nil {
+			return err
+		}
+	}
+	tKey := tableKey{parentID: parentID, name: n.n.Table.Table()}
 	key := tKey.Key()
 	if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
 		if n.n.IfNotExists {
 			return nil
 		}
-		return sqlbase.NewRelationAlreadyExistsError(tKey.Name())
+		return newNameInUseError(params.ctx, params.p.txn, key, "relation", tKey.Name())
 	} else if err != nil {
 		return err
 	}
@@ -123,9 +159,20 @@ func (n *createTableNode) startExec(params runParams) error {
 	// If a new system table is being created (which should only be doable by
 	// an internal user account), make sure it gets the correct privileges.
 	privs := n.dbDesc.GetPrivileges()
+	if n.scDesc != nil {
+		// Tables in a user-defined schema inherit the privileges of the
+		// schema.
+		privs = n.scDesc.GetPrivileges()
+	}
 	if n.dbDesc.ID == keys.SystemDatabaseID {
 		privs = sqlbase.NewDefaultPrivilegeDescriptor()
 	}
+	if temporary {
+		// The session which creates a temporary table owns it, like in
+		// postgres.
+		privs = protoutil.Clone(privs).(*sqlbase.PrivilegeDescriptor)
+		privs.Grant(params.SessionData().User, privilege.List{privilege.ALL})
+	}
 
 	var asCols sqlbase.ResultColumns
 	var desc sqlbase.MutableTableDescriptor
@@ -142,6 +189,13 @@ func (n *createTableNode) startExec(params runParams) error {
 		desc, err = makeTableDescIfAs(
 			n.n, n.dbDesc.ID, id, creationTime, asCols,
 			privs, &params.p.semaCtx)
+		if n.view != nil {
+			desc.ViewQuery = tree.AsStringWithFlags(n.view.AsSource, tree.FmtParsable)
+			desc.IsMaterializedView = true
+			for backrefID := range n.viewDeps {
+				desc.DependsOn = append(desc.DependsOn, backrefID)
+			}
+		}
 	} else {
 		affected = make(map[sqlbase.ID]*sqlbase.MutableTableDescriptor)
 		desc, err = makeTableDesc(params, n.n, n.dbDesc.ID, id, creationTime, privs, affected)
@@ -149,6 +203,9 @@ func (n *createTableNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if parentID != n.dbDesc.ID {
+		desc.UnexposedParentSchemaID = parentID
+	}
 
 	if desc.Adding() {
 		// if this table and all its references are created in the same
@@ -181,6 +238,12 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	if n.view != nil {
+		if err := params.p.persistViewBackReferences(params.ctx, desc.ID, n.viewDeps); err != nil {
+			return err
+		}
+	}
+
 	for _, index := range desc.AllNonDropIndexes() {
 		if len(index.Interleave.Ancestors) > 0 {
 			if err := params.p.finalizeInterleave(params.ctx, &desc, index); err != nil {
@@ -195,18 +258,34 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	// Log Create Table event. This is an auditable log event and is
 	// recorded in the same transaction as the table descriptor update.
-	if err := MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
-		params.ctx,
-		params.p.txn,
-		EventLogCreateTable,
-		int32(desc.ID),
-		int32(params.extendedEvalCtx.NodeID),
-		struct {
-			TableName string
-			Statement string
-			User      string
-		}{n.n.Table.FQString(), n.n.String(), params.SessionData().User},
-	); err != nil {
+	if n.view != nil {
+		err = MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			params.ctx,
+			params.p.txn,
+			EventLogCreateView,
+			int32(desc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				ViewName  string
+				Statement string
+				User      string
+			}{n.view.Name.FQString(), n.view.String(), params.SessionData().User},
+		)
+	} else {
+		err = MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			params.ctx,
+			params.p.txn,
+			EventLogCreateTable,
+			int32(desc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				TableName string
+				Statement string
+				User      string
+			}{n.n.Table.FQString(), n.n.String(), params.SessionData().User},
+		)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -369,9 +448,24 @@ func (p *planner) resolveFK(
 	backrefs map[sqlbase.ID]*sqlbase.MutableTableDescriptor,
 	ts FKTableState,
 ) error {
+	if err := checkFKDeferrability(p.ExecCfg().Settings, d); err != nil {
+		return err
+	}
 	return ResolveFK(ctx, p.txn, p, tbl, d, backrefs, ts)
 }
 
+// checkFKDeferrability checks that the cluster version supports the
+// deferrability of the given foreign key constraint.
+func checkFKDeferrability(st *cluster.Settings, d *tree.ForeignKeyConstraintTableDef) error {
+	if d.Deferrability != tree.NotDeferrable &&
+		!st.Version.IsActive(cluster.VersionDeferrableConstraints) {
+		return errors.Errorf(`deferrable constraints require all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionDeferrableConstraints),
+		)
+	}
+	return nil
+}
+
 func qualifyFKColErrorWithDB(
 	ctx context.Context, txn *client.Txn, tbl *sqlbase.TableDescriptor, col string,
 ) string {
@@ -444,6 +538,14 @@ func ResolveFK(
 	if err != nil {
 		return err
 	}
+	if target.Temporary != tbl.Temporary {
+		persistence := "permanent"
+		if tbl.Temporary {
+			persistence = "temporary"
+		}
+		return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+			"constraints on %s tables may reference only %s tables", persistence, persistence)
+	}
 	if target.ID == tbl.ID {
 		// When adding a self-ref FK to an _existing_ table, we want to make sure
 		// we edit the same copy.
@@ -565,6 +667,9 @@ func ResolveFK(
 		OnDelete:        sqlbase.ForeignKeyReferenceActionValue[d.Actions.Delete],
 		OnUpdate:        sqlbase.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:           sqlbase.CompositeKeyMatchMethodValue[d.Match],
+
+		Deferrable:        d.Deferrability != tree.NotDeferrable,
+		InitiallyDeferred: d.Deferrability == tree.DeferrableInitiallyDeferred,
 	}
 
 	if ts != NewTable {
@@ -726,6 +831,10 @@ func addInterleave(
 	if err != nil {
 		return err
 	}
+	if desc.Temporary || parentTable.Temporary {
+		return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"temporary tables cannot be interleaved")
+	}
 	parentIndex := parentTable.PrimaryIndex
 
 	// typeOfIndex is used to give more informative error messages.
@@ -901,6 +1010,7 @@ func makeTableDescIfAs(
 	semaCtx *tree.SemaContext,
 ) (desc sqlbase.MutableTableDescriptor, err error) {
 	desc = InitTableDescriptor(id, parentID, p.Table.Table(), creationTime, privileges)
+	desc.Temporary = isTemporaryTable(p)
 	for i, colRes := range resultColumns {
 		colType, err := coltypes.DatumTypeToColumnType(colRes.Typ)
 		if err != nil {
@@ -992,6 +1102,7 @@ func MakeTableDesc(
 	evalCtx *tree.EvalContext,
 ) (sqlbase.MutableTableDescriptor, error) {
 	desc := InitTableDescriptor(id, parentID, n.Table.Table(), creationTime, privileges)
+	desc.Temporary = isTemporaryTable(n)
 
 	for _, def := range n.Defs {
 		if d, ok := def.(*tree.ColumnTableDef); ok {
@@ -1071,11 +1182,22 @@ func MakeTableDesc(
 				StoreColumnNames: d.Storing.ToStrings(),
 			}
 			if d.Inverted {
+				if d.Predicate != nil {
+					return desc, pgerror.NewError(pgerror.CodeInvalidSQLStatementNameError,
+						"inverted indexes can't be partial")
+				}
 				idx.Type = sqlbase.IndexDescriptor_INVERTED
 			}
 			if err := idx.FillColumns(d.Columns); err != nil {
 				return desc, err
 			}
+			if d.Predicate != nil {
+				var err error
+				idx.Predicate, err = makePartialIndexPredicate(ctx, st, &desc, d.Predicate, semaCtx, n.Table)
+				if err != nil {
+					return desc, err
+				}
+			}
 			if d.PartitionBy != nil {
 				partitioning, err := CreatePartitioning(ctx, st, evalCtx, &desc, &idx, d.PartitionBy)
 				if err != nil {
@@ -1098,6 +1220,13 @@ func MakeTableDesc(
 			if err := idx.FillColumns(d.Columns); err != nil {
 				return desc, err
 			}
+			if d.Predicate != nil {
+				var err error
+				idx.Predicate, err = makePartialIndexPredicate(ctx, st, &desc, d.Predicate, semaCtx, n.Table)
+				if err != nil {
+					return desc, err
+				}
+			}
 			if d.PartitionBy != nil {
 				partitioning, err := CreatePartitioning(ctx, st, evalCtx, &desc, &idx, d.PartitionBy)
 				if err != nil {
@@ -1205,6 +1334,9 @@ func MakeTableDesc(
 			desc.Checks = append(desc.Checks, ck)
 
 		case *tree.ForeignKeyConstraintTableDef:
+			if err := checkFKDeferrability(st, d); err != nil {
+				return desc, err
+			}
 			if err := ResolveFK(ctx, txn, fkResolver, &desc, d, affected, NewTable); err != nil {
 				return desc, err
 			}
@@ -1250,6 +1382,11 @@ func makeTableDesc(
 			return ret, err
 		}
 		if seqName != nil {
+			if isTemporaryTable(n) {
+				return ret, pgerror.UnimplementedWithIssueErrorf(5807,
+					"cannot use SERIAL in temporary table with serial_normalization = %s",
+					params.SessionData().SerialNormalizationMode)
+			}
 			if err := doCreateSequence(params, n.String(), seqDbDesc, seqName, seqOpts); err != nil {
 				return ret, err
 			}
@@ -0,0 +1,232 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/pkg/errors"
+)
+
+// createTriggerNode represents a CREATE TRIGGER statement.
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *sqlbase.MutableTableDescriptor
+	fnDesc    *sqlbase.FunctionDescriptor
+	trigger   sqlbase.TriggerDescriptor
+}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: CREATE on table and EXECUTE on the trigger function.
+//   notes: postgres requires TRIGGER on the table and EXECUTE on the
+//          function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionTriggers) {
+		return nil, errors.Errorf(`CREATE TRIGGER requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionTriggers),
+		)
+	}
+
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, &n.Table, true /* required */, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	fnDesc, err := p.resolveFunction(ctx, &n.FuncName, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if !fnDesc.ReturnsTrigger {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidObjectDefinitionError,
+			"function %s must return type trigger", &n.FuncName)
+	}
+	if fnDesc.ParentID != tableDesc.ParentID {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"cross-database trigger function references not supported")
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Name) {
+			return nil, pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+				"trigger %q for relation %q already exists", n.Name, tableDesc.Name)
+		}
+	}
+
+	trigger := sqlbase.TriggerDescriptor{
+		Name:         string(n.Name),
+		ForEachRow:   n.ForEachRow,
+		FunctionID:   fnDesc.ID,
+		Args:         n.Args,
+		IsConstraint: n.Constraint,
+	}
+	switch n.ActionTime {
+	case tree.TriggerBefore:
+		trigger.ActionTime = sqlbase.TriggerDescriptor_BEFORE
+	case tree.TriggerAfter:
+		trigger.ActionTime = sqlbase.TriggerDescriptor_AFTER
+	}
+	for _, e := range n.Events {
+		var event sqlbase.TriggerDescriptor_Event
+		switch e {
+		case tree.TriggerInsert:
+			event = sqlbase.TriggerDescriptor_INSERT
+		case tree.TriggerUpdate:
+			event = sqlbase.TriggerDescriptor_UPDATE
+		case tree.TriggerDelete:
+			event = sqlbase.TriggerDescriptor_DELETE
+		}
+		if !triggerFiresOn(&trigger, event) {
+			trigger.Events = append(trigger.Events, event)
+		}
+	}
+
+	if err := p.validateTriggerFunction(ctx, &trigger, fnDesc, tableDesc.TableDesc()); err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{n: n, tableDesc: tableDesc, fnDesc: fnDesc, trigger: trigger}, nil
+}
+
+// validateTriggerFunction checks that the body of the function of the given
+// trigger is a valid query when run by the trigger on the given table. The
+// function of a BEFORE ROW trigger must also return rows with the columns of
+// the table.
+func (p *planner) validateTriggerFunction(
+	ctx context.Context,
+	trigger *sqlbase.TriggerDescriptor,
+	fn *sqlbase.FunctionDescriptor,
+	desc *sqlbase.TableDescriptor,
+) error {
+	cols := triggerColumns(desc)
+	query, err := makeTriggerQuery(trigger, fn, desc, cols)
+	if err != nil {
+		return err
+	}
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+
+	// Build the query in a separate memo, like validateFunctionBody.
+	var catalog optCatalog
+	catalog.init(p.execCfg.TableStatsCache, p)
+	semaCtx := p.semaCtx
+	if err := semaCtx.Placeholders.Init(numTriggerPlaceholders(len(cols)), nil /* typeHints */); err != nil {
+		return err
+	}
+	var f norm.Factory
+	f.Init(p.EvalContext())
+	bld := optbuilder.New(ctx, &semaCtx, p.EvalContext(), &catalog, &f, stmt.AST)
+	bld.KeepPlaceholders = true
+	if err := bld.Build(); err != nil {
+		return err
+	}
+
+	if trigger.ActionTime != sqlbase.TriggerDescriptor_BEFORE || !trigger.ForEachRow {
+		return nil
+	}
+	presentation := f.Memo().RootProps().Presentation
+	mismatch := len(presentation) != len(cols)
+	for i := 0; !mismatch && i < len(cols); i++ {
+		typ := f.Metadata().ColumnMeta(presentation[i].ID).Type
+		mismatch = typ != types.Unknown && !typ.Equivalent(cols[i].Type.ToDatumType())
+	}
+	if mismatch {
+		return pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+			"function %s cannot be used by BEFORE ROW trigger %q", fn.Name, trigger.Name).SetDetailf(
+			"The function must return rows with the columns of table %s.", desc.Name)
+	}
+	return nil
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+
+	// Triggers fire in alphabetical order, as in Postgres.
+	triggers := append(n.tableDesc.Triggers, n.trigger)
+	sort.Slice(triggers, func(i, j int) bool { return triggers[i].Name < triggers[j].Name })
+	n.tableDesc.Triggers = triggers
+
+	if !containsID(n.fnDesc.TriggerTableIDs, n.tableDesc.ID) {
+		n.fnDesc.TriggerTableIDs = append(n.fnDesc.TriggerTableIDs, n.tableDesc.ID)
+		if err := writeFunctionDesc(ctx, p, n.fnDesc); err != nil {
+			return err
+		}
+	}
+
+	if err := n.tableDesc.Validate(ctx, p.txn, p.EvalContext().Settings); err != nil {
+		return err
+	}
+	if err := p.writeSchemaChange(ctx, n.tableDesc, sqlbase.InvalidMutationID); err != nil {
+		return err
+	}
+
+	// Log Create Trigger event. This is an auditable log event and is
+	// recorded in the same transaction as the table descriptor update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		ctx,
+		p.txn,
+		EventLogCreateTrigger,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TriggerName string
+			TableName   string
+			Statement   string
+			User        string
+		}{n.n.Name.String(), n.n.Table.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (*createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTriggerNode) Close(context.Context)        {}
+
+// containsID returns true if the given list of IDs contains id.
+func containsID(ids []sqlbase.ID, id sqlbase.ID) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+// removeID returns the given list of IDs without id.
+func removeID(ids []sqlbase.ID, id sqlbase.ID) []sqlbase.ID {
+	res := ids[:0]
+	for _, x := range ids {
+		if x != id {
+			res = append(res, x)
+		}
+	}
+	return res
+}
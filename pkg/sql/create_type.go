@@ -0,0 +1,159 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/enum"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/pkg/errors"
+)
+
+// createTypeNode represents a CREATE TYPE statement.
+type createTypeNode struct {
+	n      *tree.CreateType
+	dbDesc *sqlbase.DatabaseDescriptor
+	// desc is the descriptor of the new type. Its ID and privileges are
+	// filled in by startExec.
+	desc sqlbase.TypeDescriptor
+}
+
+// CreateType creates a user-defined type.
+// Privileges: CREATE on database.
+//   notes: postgres requires CREATE on the schema.
+func (p *planner) CreateType(ctx context.Context, n *tree.CreateType) (planNode, error) {
+	if n.Variety != tree.Enum {
+		return nil, pgerror.UnimplementedWithIssueError(24873, "CREATE TYPE")
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionEnums) {
+		return nil, errors.Errorf(`CREATE TYPE requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionEnums),
+		)
+	}
+
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotTemporarySchema(&n.TypeName, "type"); err != nil {
+		return nil, err
+	}
+	if err := checkPublicSchema(&n.TypeName, "type"); err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	desc, err := MakeEnumTypeDesc(n.TypeName.Table(), n.EnumLabels, dbDesc.ID, 0 /* id */, nil /* privileges */)
+	if err != nil {
+		return nil, err
+	}
+	return &createTypeNode{n: n, dbDesc: dbDesc, desc: desc}, nil
+}
+
+// MakeEnumTypeDesc creates a type descriptor for an enum type with the given
+// labels. The physical representations of the labels are evenly spaced, so
+// that values can later be added between any two of them.
+func MakeEnumTypeDesc(
+	name string,
+	labels []string,
+	parentID, id sqlbase.ID,
+	privileges *sqlbase.PrivilegeDescriptor,
+) (sqlbase.TypeDescriptor, error) {
+	if err := checkTypeNameAvailable(name); err != nil {
+		return sqlbase.TypeDescriptor{}, err
+	}
+	desc := sqlbase.TypeDescriptor{
+		Name:        name,
+		ID:          id,
+		ParentID:    parentID,
+		Kind:        sqlbase.TypeDescriptor_ENUM,
+		EnumMembers: make([]sqlbase.TypeDescriptor_EnumMember, len(labels)),
+		Privileges:  privileges,
+	}
+	seen := make(map[string]struct{}, len(labels))
+	physicalReps := enum.GenerateNEvenlySpacedBytes(len(labels))
+	for i, label := range labels {
+		if _, ok := seen[label]; ok {
+			return sqlbase.TypeDescriptor{}, pgerror.NewErrorf(pgerror.CodeInvalidObjectDefinitionError,
+				"enum label %q used more than once", label)
+		}
+		seen[label] = struct{}{}
+		desc.EnumMembers[i] = sqlbase.TypeDescriptor_EnumMember{
+			PhysicalRepresentation: physicalReps[i],
+			LogicalRepresentation:  label,
+		}
+	}
+	return desc, nil
+}
+
+// checkTypeNameAvailable returns an error if the given name cannot be used
+// for a user-defined type because it would be shadowed by a builtin type.
+func checkTypeNameAvailable(name string) error {
+	_, isBuiltin, unimp := coltypes.TypeForNonKeywordTypeName(name)
+	if isBuiltin || unimp != 0 || name == "char" {
+		return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+			"type %q already exists", name).SetDetailf("%q is the name of a builtin type.", name)
+	}
+	return nil
+}
+
+func (n *createTypeNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	desc := &n.desc
+	key := tableKey{parentID: n.dbDesc.ID, name: desc.Name}.Key()
+
+	if exists, err := descExists(ctx, p.txn, key); err != nil {
+		return err
+	} else if exists {
+		return newNameInUseError(ctx, p.txn, key, "type", desc.Name)
+	}
+
+	id, err := GenerateUniqueDescID(ctx, params.extendedEvalCtx.ExecCfg.DB)
+	if err != nil {
+		return err
+	}
+	desc.Privileges = n.dbDesc.GetPrivileges()
+	if err := p.createDescriptorWithID(ctx, key, id, desc, params.EvalContext().Settings); err != nil {
+		return err
+	}
+
+	// Log Create Type event. This is an auditable log event and is recorded
+	// in the same transaction as the type descriptor creation.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		ctx,
+		p.txn,
+		EventLogCreateType,
+		int32(desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TypeName  string
+			Statement string
+			User      string
+		}{n.n.TypeName.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (*createTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTypeNode) Close(context.Context)        {}
@@ -18,19 +18,25 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/pkg/errors"
 )
 
 // createViewNode represents a CREATE VIEW statement.
 type createViewNode struct {
-	n             *tree.CreateView
-	dbDesc        *sqlbase.DatabaseDescriptor
+	n      *tree.CreateView
+	dbDesc *sqlbase.DatabaseDescriptor
+	// scDesc is the descriptor of the user-defined schema of the view, if
+	// any.
+	scDesc        *sqlbase.SchemaDescriptor
 	sourceColumns sqlbase.ResultColumns
 	// planDeps tracks which tables and views the view being created
 	// depends on. This is collected during the construction of
@@ -49,7 +55,20 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 		return nil, err
 	}
 
-	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+	if err := checkNotTemporarySchema(&n.Name, "view"); err != nil {
+		return nil, err
+	}
+	scDesc, err := resolveTargetSchema(ctx, p.txn, dbDesc, &n.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if scDesc != nil {
+		err = p.CheckPrivilege(ctx, scDesc, privilege.CREATE)
+	} else {
+		err = p.CheckPrivilege(ctx, dbDesc, privilege.CREATE)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -101,9 +120,21 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 
 	log.VEventf(ctx, 2, "collected view dependencies:\n%s", planDeps.String())
 
+	for _, dep := range planDeps {
+		if dep.desc.Temporary {
+			return nil, pgerror.UnimplementedWithIssueErrorf(5807,
+				"views depending on temporary table %q", tree.ErrNameString(&dep.desc.Name))
+		}
+	}
+
+	if n.Materialized {
+		return p.createMaterializedView(ctx, n, planDeps)
+	}
+
 	return &createViewNode{
 		n:             n,
 		dbDesc:        dbDesc,
+		scDesc:        scDesc,
 		sourceColumns: sourceColumns,
 		planDeps:      planDeps,
 	}, nil
@@ -111,11 +142,15 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 
 func (n *createViewNode) startExec(params runParams) error {
 	viewName := n.n.Name.Table()
-	tKey := tableKey{parentID: n.dbDesc.ID, name: viewName}
+	parentID := n.dbDesc.ID
+	if n.scDesc != nil {
+		parentID = n.scDesc.ID
+	}
+	tKey := tableKey{parentID: parentID, name: viewName}
 	key := tKey.Key()
 	if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
 		// TODO(a-robinson): Support CREATE OR REPLACE commands.
-		return sqlbase.NewRelationAlreadyExistsError(tKey.Name())
+		return newNameInUseError(params.ctx, params.p.txn, key, "relation", tKey.Name())
 	} else if err != nil {
 		return err
 	}
@@ -125,8 +160,11 @@ func (n *createViewNode) startExec(params runParams) error {
 		return err
 	}
 
-	// Inherit permissions from the database descriptor.
+	// Inherit permissions from the database or schema descriptor.
 	privs := n.dbDesc.GetPrivileges()
+	if n.scDesc != nil {
+		privs = n.scDesc.GetPrivileges()
+	}
 
 	desc, err := n.makeViewTableDesc(
 		params,
@@ -141,6 +179,10 @@ func (n *createViewNode) startExec(params runParams) error {
 		return err
 	}
 
+	if n.scDesc != nil {
+		desc.UnexposedParentSchemaID = n.scDesc.ID
+	}
+
 	// Collect all the tables/views this view depends on.
 	for backrefID := range n.planDeps {
 		desc.DependsOn = append(desc.DependsOn, backrefID)
@@ -151,25 +193,8 @@ func (n *createViewNode) startExec(params runParams) error {
 		return err
 	}
 
-	// Persist the back-references in all referenced table descriptors.
-	for _, updated := range n.planDeps {
-		backrefID := updated.desc.ID
-		backRefMutable := params.p.Tables().getUncommittedTableByID(backrefID).MutableTableDescriptor
-		if backRefMutable == nil {
-			backRefMutable = sqlbase.NewMutableExistingTableDescriptor(*updated.desc.TableDesc())
-		}
-		for _, dep := range updated.deps {
-			// The logical plan constructor merely registered the dependencies.
-			// It did not populate the "ID" field of TableDescriptor_Reference,
-			// because the ID of the newly created view descriptor was not
-			// yet known.
-			// We need to do it here.
-			dep.ID = desc.ID
-			backRefMutable.DependedOnBy = append(backRefMutable.DependedOnBy, dep)
-		}
-		if err := params.p.writeSchemaChange(params.ctx, backRefMutable, sqlbase.InvalidMutationID); err != nil {
-			return err
-		}
+	if err := params.p.persistViewBackReferences(params.ctx, desc.ID, n.planDeps); err != nil {
+		return err
 	}
 
 	if err := desc.Validate(params.ctx, params.p.txn, params.EvalContext().Settings); err != nil {
@@ -192,6 +217,58 @@ func (n *createViewNode) startExec(params runParams) error {
 	)
 }
 
+// createMaterializedView plans a CREATE MATERIALIZED VIEW statement. The
+// view is created like a table by CREATE TABLE ... AS, which also computes
+// its initial contents.
+func (p *planner) createMaterializedView(
+	ctx context.Context, n *tree.CreateView, planDeps planDependencies,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionMaterializedViews) {
+		return nil, errors.Errorf(`CREATE MATERIALIZED VIEW requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionMaterializedViews),
+		)
+	}
+	plan, err := p.CreateTable(ctx, &tree.CreateTable{
+		Table:         n.Name,
+		AsSource:      n.AsSource,
+		AsColumnNames: n.ColumnNames,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ct := plan.(*createTableNode)
+	ct.view = n
+	ct.viewDeps = planDeps
+	return ct, nil
+}
+
+// persistViewBackReferences records the view with the given ID in the
+// DependedOnBy lists of all the relations it depends on.
+func (p *planner) persistViewBackReferences(
+	ctx context.Context, viewID sqlbase.ID, planDeps planDependencies,
+) error {
+	for _, updated := range planDeps {
+		backrefID := updated.desc.ID
+		backRefMutable := p.Tables().getUncommittedTableByID(backrefID).MutableTableDescriptor
+		if backRefMutable == nil {
+			backRefMutable = sqlbase.NewMutableExistingTableDescriptor(*updated.desc.TableDesc())
+		}
+		for _, dep := range updated.deps {
+			// The logical plan constructor merely registered the dependencies.
+			// It did not populate the "ID" field of TableDescriptor_Reference,
+			// because the ID of the newly created view descriptor was not
+			// yet known.
+			// We need to do it here.
+			dep.ID = viewID
+			backRefMutable.DependedOnBy = append(backRefMutable.DependedOnBy, dep)
+		}
+		if err := p.writeSchemaChange(ctx, backRefMutable, sqlbase.InvalidMutationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (*createViewNode) Next(runParams) (bool, error) { return false, nil }
 func (*createViewNode) Values() tree.Datums          { return tree.Datums{} }
 func (n *createViewNode) Close(ctx context.Context)  {}
@@ -167,6 +167,11 @@ func (p *planner) getDataSource(
 	case *tree.AliasedTableExpr:
 		// Alias clause: source AS alias(cols...)
 
+		if t.Lateral {
+			return planDataSource{}, pgerror.UnimplementedWithIssueErrorf(24560,
+				"LATERAL is only supported by the cost-based optimizer")
+		}
+
 		if t.IndexFlags != nil {
 			indexFlags = t.IndexFlags
 		}
@@ -307,7 +312,7 @@ func (p *planner) getPlanForDesc(
 	indexFlags *tree.IndexFlags,
 	colCfg scanColumnsConfig,
 ) (planDataSource, error) {
-	if desc.IsView() {
+	if desc.IsView() && !desc.MaterializedView() {
 		if colCfg.wantedColumns != nil {
 			return planDataSource{},
 				errors.Errorf("cannot specify an explicit column list when accessing a view by reference")
@@ -317,7 +322,7 @@ func (p *planner) getPlanForDesc(
 	if desc.IsSequence() {
 		return p.getSequenceSource(ctx, *tn, desc)
 	}
-	if !desc.IsTable() {
+	if !desc.IsTable() && !desc.MaterializedView() {
 		return planDataSource{}, errors.Errorf(
 			"unexpected table descriptor of type %s for %q", desc.TypeName(), tree.ErrString(tn))
 	}
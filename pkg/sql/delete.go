@@ -60,6 +60,11 @@ func (p *planner) Delete(
 		return nil, pgerror.NewDangerousStatementErrorf("DELETE without WHERE clause")
 	}
 
+	if len(n.Using) > 0 {
+		return nil, pgerror.Unimplemented("delete using",
+			"DELETE ... USING is only supported by the cost-based optimizer")
+	}
+
 	// CTE analysis.
 	resetter, err := p.initWith(ctx, n.With)
 	if err != nil {
@@ -118,14 +123,14 @@ func (p *planner) Delete(
 	// Also, rowsNeeded determines which rows of the source we need
 	// in the table deleter.
 	var requestedCols []sqlbase.ColumnDescriptor
-	if rowsNeeded {
+	if rowsNeeded || len(desc.Triggers) > 0 {
 		// Note: in contrast to INSERT and UPDATE which also require the
 		// data if there are CHECK expressions, DELETE does not care about
 		// constraint checking (because the rows are being deleted after
 		// all).
 
 		// TODO(dan): This could be made tighter, just the rows needed for RETURNING
-		// exprs.
+		// exprs. Triggers see the whole rows.
 		requestedCols = desc.Columns
 	}
 
@@ -136,6 +141,7 @@ func (p *planner) Delete(
 	if err != nil {
 		return nil, err
 	}
+	rd.DeferFKChecks(p.deferredFKChecks())
 
 	tracing.AnnotateTrace()
 
@@ -170,13 +176,22 @@ func (p *planner) Delete(
 		columns = columns[:len(requestedCols)]
 	}
 
+	triggers, err := p.newTriggerRunner(ctx, desc, sqlbase.TriggerDescriptor_DELETE)
+	if err != nil {
+		return nil, err
+	}
+
 	// Now make a delete node. We use a pool.
 	dn := deleteNodePool.Get().(*deleteNode)
 	*dn = deleteNode{
 		source:  rows,
 		columns: columns,
 		run: deleteRun{
-			td:                  tableDeleter{rd: rd, alloc: &p.alloc},
+			td: tableDeleter{
+				tableWriterBase: tableWriterBase{triggers: triggers},
+				rd:              rd,
+				alloc:           &p.alloc,
+			},
 			rowsNeeded:          rowsNeeded,
 			fastPathInterleaved: canDeleteFastInterleaved(desc, fkTables),
 		},
@@ -285,8 +300,6 @@ func (d *deleteNode) BatchedNext(params runParams) (bool, error) {
 			return false, err
 		}
 
-		d.run.rowCount++
-
 		// Are we done yet with the current batch?
 		if d.run.td.curBatchSize() >= maxDeleteBatchSize {
 			break
@@ -328,10 +341,17 @@ func (d *deleteNode) BatchedNext(params runParams) (bool, error) {
 // processSourceRow processes one row from the source for deletion and, if
 // result rows are needed, saves it in the result row container
 func (d *deleteNode) processSourceRow(params runParams, sourceVals tree.Datums) error {
+	// Fire the BEFORE triggers, if any. They can skip the deletion of the
+	// row.
+	if keep, err := d.run.td.beforeRow(params.ctx, sourceVals); err != nil || !keep {
+		return err
+	}
+
 	// Queue the deletion in the KV batch.
 	if err := d.run.td.row(params.ctx, sourceVals, d.run.traceKV); err != nil {
 		return err
 	}
+	d.run.rowCount++
 
 	// If result rows need to be accumulated, do it.
 	if d.run.rows != nil {
@@ -369,6 +389,11 @@ func (d *deleteNode) Close(ctx context.Context) {
 }
 
 func canDeleteFastInterleaved(table *ImmutableTableDescriptor, fkTables row.FkTableMetadata) bool {
+	// The triggers of the table must fire for every deleted row.
+	if len(table.Triggers) > 0 {
+		return false
+	}
+
 	// If there are no interleaved tables then don't take the fast path.
 	// This avoids superfluous use of DelRange in cases where there isn't as much of a performance boost.
 	hasInterleaved := false
@@ -93,6 +93,45 @@ func descExists(ctx context.Context, txn *client.Txn, idKey roachpb.Key) (bool,
 	return gr.Exists(), nil
 }
 
+// newNameInUseError returns the error for a new object of the given kind
+// ("relation", "function" or "type") whose name key, idKey, is already in
+// use. Functions and types share the namespace of the relations of their
+// database, so when the name is used by an object of another kind, the error
+// says which.
+func newNameInUseError(
+	ctx context.Context, txn *client.Txn, idKey roachpb.Key, kind, name string,
+) error {
+	existingKind := "relation"
+	gr, err := txn.Get(ctx, idKey)
+	if err != nil {
+		return err
+	}
+	if gr.Exists() {
+		desc := &sqlbase.Descriptor{}
+		if err := txn.GetProto(ctx, sqlbase.MakeDescMetadataKey(sqlbase.ID(gr.ValueInt())), desc); err != nil {
+			return err
+		}
+		if desc.GetFunction() != nil {
+			existingKind = "function"
+		} else if desc.GetType() != nil {
+			existingKind = "type"
+		}
+	}
+	if existingKind == kind {
+		switch kind {
+		case "function":
+			return sqlbase.NewFunctionAlreadyExistsError(name)
+		case "type":
+			return sqlbase.NewTypeAlreadyExistsError(name)
+		default:
+			return sqlbase.NewRelationAlreadyExistsError(name)
+		}
+	}
+	return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+		"cannot create %s %q because %s %q already exists", kind, name, existingKind, name).SetHintf(
+		"Functions and types share the namespace of the tables, views and sequences of their database.")
+}
+
 func (p *planner) createDescriptorWithID(
 	ctx context.Context,
 	idKey roachpb.Key,
@@ -188,6 +227,12 @@ func getDescriptorByID(
 	case *sqlbase.TableDescriptor:
 		table := desc.GetTable()
 		if table == nil {
+			if desc.GetFunction() != nil || desc.GetType() != nil || desc.GetSchema() != nil {
+				// Functions, types and schemas share the namespace of tables,
+				// so a table lookup can stumble upon one. Report it as a
+				// missing table.
+				return sqlbase.ErrDescriptorNotFound
+			}
 			return errors.Errorf("%q is not a table", desc.String())
 		}
 		table.MaybeFillInDescriptor()
@@ -206,6 +251,36 @@ func getDescriptorByID(
 			return err
 		}
 		*t = *database
+	case *sqlbase.FunctionDescriptor:
+		function := desc.GetFunction()
+		if function == nil {
+			return errors.Errorf("%q is not a function", desc.String())
+		}
+
+		if err := function.Validate(); err != nil {
+			return err
+		}
+		*t = *function
+	case *sqlbase.TypeDescriptor:
+		typ := desc.GetType()
+		if typ == nil {
+			return errors.Errorf("%q is not a type", desc.String())
+		}
+
+		if err := typ.Validate(); err != nil {
+			return err
+		}
+		*t = *typ
+	case *sqlbase.SchemaDescriptor:
+		schema := desc.GetSchema()
+		if schema == nil {
+			return errors.Errorf("%q is not a schema", desc.String())
+		}
+
+		if err := schema.Validate(); err != nil {
+			return err
+		}
+		*t = *schema
 	}
 	return nil
 }
@@ -230,6 +305,12 @@ func GetAllDescriptors(ctx context.Context, txn *client.Txn) ([]sqlbase.Descript
 			descs[i] = desc.GetTable()
 		case *sqlbase.Descriptor_Database:
 			descs[i] = desc.GetDatabase()
+		case *sqlbase.Descriptor_Function:
+			descs[i] = desc.GetFunction()
+		case *sqlbase.Descriptor_Type:
+			descs[i] = desc.GetType()
+		case *sqlbase.Descriptor_Schema:
+			descs[i] = desc.GetSchema()
 		default:
 			return nil, errors.Errorf("Descriptor.Union has unexpected type %T", t)
 		}
@@ -38,10 +38,31 @@ func (p *planner) Discard(ctx context.Context, s *tree.Discard) (planNode, error
 
 		// DEALLOCATE ALL
 		p.preparedStatements.DeleteAll(ctx)
+
+		// DISCARD TEMP
+		return p.discardTemporaryTables(ctx)
+	case tree.DiscardModeTemp:
+		return p.discardTemporaryTables(ctx)
 	default:
 		return nil, pgerror.NewAssertionErrorf("unknown mode for DISCARD: %d", s.Mode)
 	}
-	return newZeroNode(nil /* columns */), nil
+}
+
+// discardTemporaryTables returns a plan which drops all the temporary tables
+// of the session.
+func (p *planner) discardTemporaryTables(ctx context.Context) (planNode, error) {
+	names, err := p.getTemporaryTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return p.DropTable(ctx, &tree.DropTable{
+		Names:        names,
+		IfExists:     true,
+		DropBehavior: tree.DropCascade,
+	})
 }
 
 func resetSessionVars(ctx context.Context, m *sessionDataMutator) error {
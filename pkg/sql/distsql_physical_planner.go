@@ -219,9 +219,12 @@ func (v *distSQLExprCheckVisitor) VisitPre(expr tree.Expr) (recurse bool, newExp
 	case *tree.DOid:
 		v.err = newQueryNotSupportedError("OID expressions are not supported by distsql")
 		return false, expr
+	case *tree.DEnum:
+		v.err = newQueryNotSupportedError("enum expressions are not supported by distsql")
+		return false, expr
 	case *tree.CastExpr:
 		switch t.Type.(type) {
-		case *coltypes.TOid:
+		case *coltypes.TOid, *coltypes.TUserDefined:
 			v.err = newQueryNotSupportedErrorf("cast to %s is not supported by distsql", t.Type)
 			return false, expr
 		}
@@ -293,6 +296,8 @@ func newQueryNotSupportedErrorf(format string, args ...interface{}) error {
 
 var mutationsNotSupportedError = newQueryNotSupportedError("mutations not supported")
 var setNotSupportedError = newQueryNotSupportedError("SET / SET CLUSTER SETTING should never distribute")
+var rowLockingNotSupportedError = newQueryNotSupportedError(
+	"scans with row-level locking cannot be distributed")
 
 // mustWrapNode returns true if a node has no DistSQL-processor equivalent.
 // This must be kept in sync with createPlanForNode.
@@ -376,6 +381,13 @@ func (dsp *DistSQLPlanner) checkSupportForNode(node planNode) (distRecommendatio
 		return rec, nil
 
 	case *scanNode:
+		if n.lockingStrength != sqlbase.ScanLockingStrength_FOR_NONE {
+			// Scans that are performing row-level locking cannot currently be
+			// distributed because their locks would not be propagated back to
+			// the root transaction coordinator.
+			return cannotDistribute, rowLockingNotSupportedError
+		}
+
 		rec := canDistribute
 		if n.softLimit != 0 {
 			// We don't yet recommend distributing plans where soft limits propagate
@@ -399,13 +411,17 @@ func (dsp *DistSQLPlanner) checkSupportForNode(node planNode) (distRecommendatio
 
 	case *indexJoinNode:
 		// n.table doesn't have meaningful spans, but we need to check support (e.g.
-		// for any filtering expression).
+		// for any filtering expression, or row-level locking).
 		if _, err := dsp.checkSupportForNode(n.table); err != nil {
 			return cannotDistribute, err
 		}
 		return dsp.checkSupportForNode(n.index)
 
 	case *lookupJoinNode:
+		if n.table.lockingStrength != sqlbase.ScanLockingStrength_FOR_NONE {
+			// See the comment in the scanNode case.
+			return cannotDistribute, rowLockingNotSupportedError
+		}
 		if err := dsp.checkExpr(n.onCond); err != nil {
 			return cannotDistribute, err
 		}
@@ -860,10 +876,12 @@ func initTableReaderSpec(
 ) (*distsqlpb.TableReaderSpec, distsqlpb.PostProcessSpec, error) {
 	s := distsqlplan.NewTableReaderSpec()
 	*s = distsqlpb.TableReaderSpec{
-		Table:      *n.desc.TableDesc(),
-		Reverse:    n.reverse,
-		IsCheck:    n.run.isCheck,
-		Visibility: n.colCfg.visibility.toDistSQLScanVisibility(),
+		Table:             *n.desc.TableDesc(),
+		Reverse:           n.reverse,
+		IsCheck:           n.run.isCheck,
+		Visibility:        n.colCfg.visibility.toDistSQLScanVisibility(),
+		LockingStrength:   n.lockingStrength,
+		LockingWaitPolicy: n.lockingWaitPolicy,
 
 		// Retain the capacity of the spans slice.
 		Spans: s.Spans[:0],
@@ -1828,9 +1846,11 @@ func (dsp *DistSQLPlanner) createPlanForIndexJoin(
 	}
 
 	joinReaderSpec := distsqlpb.JoinReaderSpec{
-		Table:      *n.index.desc.TableDesc(),
-		IndexIdx:   0,
-		Visibility: n.table.colCfg.visibility.toDistSQLScanVisibility(),
+		Table:             *n.index.desc.TableDesc(),
+		IndexIdx:          0,
+		Visibility:        n.table.colCfg.visibility.toDistSQLScanVisibility(),
+		LockingStrength:   n.table.lockingStrength,
+		LockingWaitPolicy: n.table.lockingWaitPolicy,
 	}
 
 	filter, err := distsqlplan.MakeExpression(
@@ -1896,8 +1916,10 @@ func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 	}
 
 	joinReaderSpec := distsqlpb.JoinReaderSpec{
-		Table: *n.table.desc.TableDesc(),
-		Type:  n.joinType,
+		Table:             *n.table.desc.TableDesc(),
+		Type:              n.joinType,
+		LockingStrength:   n.table.lockingStrength,
+		LockingWaitPolicy: n.table.lockingWaitPolicy,
 	}
 	joinReaderSpec.IndexIdx, err = getIndexIdx(n.table)
 	if err != nil {
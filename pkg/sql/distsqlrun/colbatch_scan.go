@@ -85,7 +85,7 @@ func newColBatchScan(
 	fetcher := row.CFetcher{}
 	if _, _, err := initCRowFetcher(
 		&fetcher, &spec.Table, int(spec.IndexIdx), columnIdxMap, spec.Reverse,
-		neededColumns, spec.IsCheck, spec.Visibility,
+		spec.LockingStrength, spec.LockingWaitPolicy, neededColumns, spec.IsCheck, spec.Visibility,
 	); err != nil {
 		return nil, err
 	}
@@ -110,6 +110,8 @@ func initCRowFetcher(
 	indexIdx int,
 	colIdxMap map[sqlbase.ColumnID]int,
 	reverseScan bool,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
 	valNeededForCol util.FastIntSet,
 	isCheck bool,
 	scanVisibility distsqlpb.ScanVisibility,
@@ -133,7 +135,7 @@ func initCRowFetcher(
 		ValNeededForCol:  valNeededForCol,
 	}
 	if err := fetcher.Init(
-		reverseScan, true /* returnRangeInfo */, isCheck, tableArgs,
+		reverseScan, lockStr, lockWaitPolicy, true /* returnRangeInfo */, isCheck, tableArgs,
 	); err != nil {
 		return nil, false, err
 	}
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/storage/diskmap"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -105,6 +106,10 @@ type FlowCtx struct {
 	// JobRegistry is used during backfill to load jobs which keep state.
 	JobRegistry *jobs.Registry
 
+	// ProtectedTimestampProvider is used by changefeeds to protect the data
+	// they have yet to emit from garbage collection.
+	ProtectedTimestampProvider protectedts.Provider
+
 	// traceKV is true if KV tracing was requested by the session.
 	traceKV bool
 
@@ -107,6 +107,8 @@ func newIndexJoiner(
 		0, /* primary index */
 		ij.desc.ColumnIdxMapWithMutations(needMutations),
 		false, /* reverse */
+		spec.LockingStrength,
+		spec.LockingWaitPolicy,
 		ij.out.neededColumns(),
 		false, /* isCheck */
 		&ij.alloc,
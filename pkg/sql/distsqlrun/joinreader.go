@@ -198,6 +198,7 @@ func newJoinReader(
 
 	_, _, err = initRowFetcher(
 		&jr.fetcher, &jr.desc, int(spec.IndexIdx), jr.colIdxMap, false, /* reverse */
+		spec.LockingStrength, spec.LockingWaitPolicy,
 		jr.neededRightCols(), false /* isCheck */, &jr.alloc,
 		distsqlpb.ScanVisibility_PUBLIC,
 	)
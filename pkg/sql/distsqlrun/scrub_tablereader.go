@@ -126,6 +126,7 @@ func newScrubTableReader(
 
 	if _, _, err := initRowFetcher(
 		&tr.fetcher, &tr.tableDesc, int(spec.IndexIdx), tr.tableDesc.ColumnIdxMap(), spec.Reverse,
+		sqlbase.ScanLockingStrength_FOR_NONE, sqlbase.ScanLockingWaitPolicy_BLOCK,
 		neededColumns, true /* isCheck */, &tr.alloc,
 		distsqlpb.ScanVisibility_PUBLIC,
 	); err != nil {
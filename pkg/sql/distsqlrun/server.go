@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/storage/diskmap"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
@@ -75,11 +76,11 @@ import (
 //
 // ATTENTION: When updating these fields, add to version_history.txt explaining
 // what changed.
-const Version distsqlpb.DistSQLVersion = 22
+const Version distsqlpb.DistSQLVersion = 23
 
 // MinAcceptedVersion is the oldest version that the server is
 // compatible with; see above.
-const MinAcceptedVersion distsqlpb.DistSQLVersion = 21
+const MinAcceptedVersion distsqlpb.DistSQLVersion = 23
 
 // minFlowDrainWait is the minimum amount of time a draining server allows for
 // any incoming flows to be registered. It acts as a grace period in which the
@@ -105,6 +106,12 @@ var settingWorkMemBytes = settings.RegisterByteSizeSetting(
 	64*1024*1024, /* 64MB */
 )
 
+// GetWorkMemLimit returns the amount of memory that a processor (or a planNode
+// that buffers rows) can use before falling back to temp storage.
+func GetWorkMemLimit(sv *settings.Values) int64 {
+	return settingWorkMemBytes.Get(sv)
+}
+
 var noteworthyMemoryUsageBytes = envutil.EnvOrDefaultInt64("COCKROACH_NOTEWORTHY_DISTSQL_MEMORY_USAGE", 1024*1024 /* 1MB */)
 
 // ServerConfig encompasses the configuration required to create a
@@ -155,6 +162,10 @@ type ServerConfig struct {
 	// JobRegistry manages jobs being used by this Server.
 	JobRegistry *jobs.Registry
 
+	// ProtectedTimestampProvider maintains the state of the protected
+	// timestamp subsystem.
+	ProtectedTimestampProvider protectedts.Provider
+
 	// LeaseManager is a *sql.LeaseManager. It's stored as an `interface{}` due
 	// to package dependency cycles
 	LeaseManager interface{}
@@ -432,25 +443,26 @@ func (ds *ServerImpl) setupFlow(
 	}
 	// TODO(radu): we should sanity check some of these fields.
 	flowCtx := FlowCtx{
-		Settings:       ds.Settings,
-		AmbientContext: ds.AmbientContext,
-		stopper:        ds.Stopper,
-		id:             req.Flow.FlowID,
-		EvalCtx:        evalCtx,
-		nodeDialer:     ds.NodeDialer,
-		Gossip:         ds.Gossip,
-		txn:            txn,
-		ClientDB:       ds.DB,
-		executor:       ds.Executor,
-		LeaseManager:   ds.ServerConfig.LeaseManager,
-		testingKnobs:   ds.TestingKnobs,
-		nodeID:         nodeID,
-		TempStorage:    ds.TempStorage,
-		BulkAdder:      ds.BulkAdder,
-		diskMonitor:    ds.DiskMonitor,
-		JobRegistry:    ds.ServerConfig.JobRegistry,
-		traceKV:        req.TraceKV,
-		local:          localState.IsLocal,
+		Settings:                   ds.Settings,
+		AmbientContext:             ds.AmbientContext,
+		stopper:                    ds.Stopper,
+		id:                         req.Flow.FlowID,
+		EvalCtx:                    evalCtx,
+		nodeDialer:                 ds.NodeDialer,
+		Gossip:                     ds.Gossip,
+		txn:                        txn,
+		ClientDB:                   ds.DB,
+		executor:                   ds.Executor,
+		LeaseManager:               ds.ServerConfig.LeaseManager,
+		testingKnobs:               ds.TestingKnobs,
+		nodeID:                     nodeID,
+		TempStorage:                ds.TempStorage,
+		BulkAdder:                  ds.BulkAdder,
+		diskMonitor:                ds.DiskMonitor,
+		JobRegistry:                ds.ServerConfig.JobRegistry,
+		ProtectedTimestampProvider: ds.ServerConfig.ProtectedTimestampProvider,
+		traceKV:                    req.TraceKV,
+		local:                      localState.IsLocal,
 	}
 	f := newFlow(flowCtx, ds.flowRegistry, syncFlowConsumer, localState.LocalProcs)
 	if err := f.setup(ctx, &req.Flow); err != nil {
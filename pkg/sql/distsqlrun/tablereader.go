@@ -115,7 +115,8 @@ func newTableReader(
 	columnIdxMap := spec.Table.ColumnIdxMapWithMutations(returnMutations)
 	if _, _, err := initRowFetcher(
 		&tr.fetcher, &spec.Table, int(spec.IndexIdx), columnIdxMap, spec.Reverse,
-		neededColumns, spec.IsCheck, &tr.alloc, spec.Visibility,
+		spec.LockingStrength, spec.LockingWaitPolicy, neededColumns, spec.IsCheck, &tr.alloc,
+		spec.Visibility,
 	); err != nil {
 		return nil, err
 	}
@@ -173,6 +174,8 @@ func initRowFetcher(
 	indexIdx int,
 	colIdxMap map[sqlbase.ColumnID]int,
 	reverseScan bool,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
 	valNeededForCol util.FastIntSet,
 	isCheck bool,
 	alloc *sqlbase.DatumAlloc,
@@ -197,7 +200,7 @@ func initRowFetcher(
 		ValNeededForCol:  valNeededForCol,
 	}
 	if err := fetcher.Init(
-		reverseScan, true /* returnRangeInfo */, isCheck, alloc, tableArgs,
+		reverseScan, lockStr, lockWaitPolicy, true /* returnRangeInfo */, isCheck, alloc, tableArgs,
 	); err != nil {
 		return nil, false, err
 	}
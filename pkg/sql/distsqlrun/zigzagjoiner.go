@@ -436,6 +436,8 @@ func (z *zigzagJoiner) setupInfo(spec *distsqlpb.ZigzagJoinerSpec, side int, col
 		int(info.index.ID)-1,
 		info.table.ColumnIdxMap(),
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		neededCols,
 		false, /* check */
 		info.alloc,
@@ -32,6 +32,14 @@ type dropDatabaseNode struct {
 	n      *tree.DropDatabase
 	dbDesc *sqlbase.DatabaseDescriptor
 	td     []toDelete
+	fns    []functionToDelete
+	typs   []typeToDelete
+	// schemas are the user-defined schemas of the database. Their tables are
+	// included in td.
+	schemas []*sqlbase.SchemaDescriptor
+	// tempSchemas are the temporary schemas of the database, whose names are
+	// removed along with the database.
+	tempSchemas []namespaceEntry
 }
 
 // DropDatabase drops a database.
@@ -70,7 +78,36 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	if len(tbNames) > 0 {
+	// The temporary tables of all sessions are dropped along with the
+	// database. They are looked up by ID since they can only be resolved by
+	// name by the session which created them.
+	tempSchemas, err := getTemporarySchemas(ctx, p.txn, dbDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+	var tempTables []toDelete
+	for _, sc := range tempSchemas {
+		entries, err := getNamespaceEntries(ctx, p.txn, sc.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			tbDesc, err := p.Tables().getMutableTableVersionByID(ctx, e.id, p.txn)
+			if err != nil {
+				return nil, err
+			}
+			if tbDesc.Dropped() || tbDesc.Name != e.name {
+				continue
+			}
+			if err := p.CheckPrivilege(ctx, tbDesc, privilege.DROP); err != nil {
+				return nil, err
+			}
+			tn := tree.MakeTableNameWithSchema(tree.Name(dbDesc.Name), tree.Name(sc.name), tree.Name(e.name))
+			tempTables = append(tempTables, toDelete{&tn, tbDesc})
+		}
+	}
+
+	if len(tbNames) > 0 || len(tempTables) > 0 {
 		switch n.DropBehavior {
 		case tree.DropRestrict:
 			return nil, pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
@@ -86,13 +123,57 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		}
 	}
 
-	td := make([]toDelete, 0, len(tbNames))
+	td := make([]toDelete, 0, len(tbNames)+len(tempTables))
+	td = append(td, tempTables...)
+	var fns []functionToDelete
+	var typs []typeToDelete
+	var schemas []*sqlbase.SchemaDescriptor
 	for i := range tbNames {
 		tbDesc, err := p.prepareDrop(ctx, &tbNames[i], false /*required*/, anyDescType)
 		if err != nil {
 			return nil, err
 		}
 		if tbDesc == nil {
+			// The name may refer to a user-defined function.
+			fnDesc, err := getFunctionDesc(ctx, p.txn, dbDesc.ID, tbNames[i].Table())
+			if err != nil {
+				return nil, err
+			}
+			if fnDesc != nil {
+				if err := p.CheckPrivilege(ctx, fnDesc, privilege.DROP); err != nil {
+					return nil, err
+				}
+				fns = append(fns, functionToDelete{name: &tbNames[i], desc: fnDesc})
+				continue
+			}
+			// The name may also refer to a user-defined type.
+			typDesc, err := getTypeDesc(ctx, p.txn, dbDesc.ID, tbNames[i].Table())
+			if err != nil {
+				return nil, err
+			}
+			if typDesc != nil {
+				if err := p.CheckPrivilege(ctx, typDesc, privilege.DROP); err != nil {
+					return nil, err
+				}
+				typs = append(typs, typeToDelete{name: &tbNames[i], desc: typDesc})
+				continue
+			}
+			// Or to a user-defined schema, whose tables are dropped as well.
+			scDesc, err := getSchemaDesc(ctx, p.txn, dbDesc.ID, tbNames[i].Table())
+			if err != nil {
+				return nil, err
+			}
+			if scDesc != nil {
+				if err := p.CheckPrivilege(ctx, scDesc, privilege.DROP); err != nil {
+					return nil, err
+				}
+				scTables, err := p.prepareDropSchemaTables(ctx, dbDesc, scDesc)
+				if err != nil {
+					return nil, err
+				}
+				td = append(td, scTables...)
+				schemas = append(schemas, scDesc)
+			}
 			continue
 		}
 		// Recursively check permissions on all dependent views, since some may
@@ -110,7 +191,27 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	return &dropDatabaseNode{n: n, dbDesc: dbDesc, td: td}, nil
+	// The types of the database can only be dropped if they are not used
+	// by tables of other databases.
+	dropped := make(map[sqlbase.ID]struct{}, len(td))
+	for _, t := range td {
+		dropped[t.desc.ID] = struct{}{}
+	}
+	for _, typ := range typs {
+		tableIDs, err := getTablesReferencingType(ctx, p.txn, typ.desc.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range tableIDs {
+			if _, ok := dropped[id]; !ok {
+				return nil, p.checkTypeNotReferenced(ctx, typ.desc, tree.DropRestrict)
+			}
+		}
+	}
+
+	return &dropDatabaseNode{
+		n: n, dbDesc: dbDesc, td: td, fns: fns, typs: typs, schemas: schemas, tempSchemas: tempSchemas,
+	}, nil
 }
 
 func (n *dropDatabaseNode) startExec(params runParams) error {
@@ -172,6 +273,51 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 	b.Del(descKey)
 	b.Del(nameKey)
 
+	// Functions do not hold any data, so they are removed right away.
+	for _, fn := range n.fns {
+		fnNameKey := tableKey{parentID: fn.desc.ParentID, name: fn.desc.Name}.Key()
+		fnDescKey := sqlbase.MakeDescMetadataKey(fn.desc.ID)
+		if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", fnNameKey)
+			log.VEventf(ctx, 2, "Del %s", fnDescKey)
+		}
+		b.Del(fnNameKey)
+		b.Del(fnDescKey)
+		tbNameStrings = append(tbNameStrings, fn.name.FQString())
+	}
+
+	// Likewise for types.
+	for _, typ := range n.typs {
+		typNameKey := tableKey{parentID: typ.desc.ParentID, name: typ.desc.Name}.Key()
+		typDescKey := sqlbase.MakeDescMetadataKey(typ.desc.ID)
+		if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", typNameKey)
+			log.VEventf(ctx, 2, "Del %s", typDescKey)
+		}
+		b.Del(typNameKey)
+		b.Del(typDescKey)
+		tbNameStrings = append(tbNameStrings, typ.name.FQString())
+	}
+
+	for _, sc := range n.schemas {
+		scNameKey := tableKey{parentID: sc.ParentID, name: sc.Name}.Key()
+		scDescKey := sqlbase.MakeDescMetadataKey(sc.ID)
+		if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", scNameKey)
+			log.VEventf(ctx, 2, "Del %s", scDescKey)
+		}
+		b.Del(scNameKey)
+		b.Del(scDescKey)
+	}
+
+	for _, sc := range n.tempSchemas {
+		scNameKey := tableKey{parentID: n.dbDesc.ID, name: sc.name}.Key()
+		if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", scNameKey)
+		}
+		b.Del(scNameKey)
+	}
+
 	// No job was created because no tables were dropped, so zone config can be
 	// immediately removed.
 	if jobID == 0 {
@@ -0,0 +1,153 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropFunctionNode struct {
+	n   *tree.DropFunction
+	fns []functionToDelete
+}
+
+type functionToDelete struct {
+	name *tree.TableName
+	desc *sqlbase.FunctionDescriptor
+}
+
+// DropFunction drops user-defined functions.
+// Privileges: DROP on function.
+//   notes: postgres requires ownership of the function.
+func (p *planner) DropFunction(ctx context.Context, n *tree.DropFunction) (planNode, error) {
+	fns := make([]functionToDelete, 0, len(n.Functions))
+	for i := range n.Functions {
+		ref := &n.Functions[i]
+		desc, err := p.resolveFunction(ctx, &ref.Name, !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if desc == nil {
+			// IfExists specified and function does not exist.
+			continue
+		}
+		if ref.HasArgTypes && !functionArgTypesMatch(desc, ref.ArgTypes) {
+			if n.IfExists {
+				continue
+			}
+			return nil, sqlbase.NewUndefinedFunctionError(ref)
+		}
+		if err := p.CheckPrivilege(ctx, desc, privilege.DROP); err != nil {
+			return nil, err
+		}
+		if len(desc.TriggerTableIDs) > 0 && n.DropBehavior != tree.DropCascade {
+			return nil, pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+				"cannot drop function %s because triggers depend on it", desc.Name).SetHintf(
+				"Use DROP FUNCTION ... CASCADE to drop the triggers too.")
+		}
+		fns = append(fns, functionToDelete{name: &ref.Name, desc: desc})
+	}
+
+	if len(fns) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return &dropFunctionNode{n: n, fns: fns}, nil
+}
+
+// functionArgTypesMatch returns true if the given argument types are the
+// types of the parameters of the function.
+func functionArgTypesMatch(desc *sqlbase.FunctionDescriptor, argTypes []coltypes.T) bool {
+	if len(argTypes) != len(desc.Args) {
+		return false
+	}
+	for i, t := range argTypes {
+		if !coltypes.CastTargetToDatumType(t).Equivalent(desc.Args[i].Type.ToDatumType()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *dropFunctionNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	for _, fn := range n.fns {
+		for _, id := range fn.desc.TriggerTableIDs {
+			if err := dropFunctionTriggers(ctx, p, id, fn.desc.ID); err != nil {
+				return err
+			}
+		}
+
+		nameKey := tableKey{parentID: fn.desc.ParentID, name: fn.desc.Name}.Key()
+		descKey := sqlbase.MakeDescMetadataKey(fn.desc.ID)
+		if p.extendedEvalCtx.Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", nameKey)
+			log.VEventf(ctx, 2, "Del %s", descKey)
+		}
+		b := &client.Batch{}
+		b.Del(nameKey)
+		b.Del(descKey)
+		if err := p.txn.Run(ctx, b); err != nil {
+			return err
+		}
+
+		// Log a Drop Function event for this function. This is an auditable log
+		// event and is recorded in the same transaction as the deletion of the
+		// function descriptor.
+		if err := MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			ctx,
+			p.txn,
+			EventLogDropFunction,
+			int32(fn.desc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				FunctionName string
+				Statement    string
+				User         string
+			}{fn.name.FQString(), n.n.String(), params.SessionData().User},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropFunctionNode) Close(context.Context)        {}
+
+// dropFunctionTriggers drops the triggers of the table with the given ID
+// that execute the function with the given ID.
+func dropFunctionTriggers(ctx context.Context, p *planner, tableID, fnID sqlbase.ID) error {
+	tableDesc, err := p.Tables().getMutableTableVersionByID(ctx, tableID, p.txn)
+	if err != nil {
+		return err
+	}
+	triggers := tableDesc.Triggers[:0]
+	for _, t := range tableDesc.Triggers {
+		if t.FunctionID != fnID {
+			triggers = append(triggers, t)
+		}
+	}
+	tableDesc.Triggers = triggers
+	return p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID)
+}
@@ -0,0 +1,210 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropSchemaNode struct {
+	n       *tree.DropSchema
+	schemas []*sqlbase.SchemaDescriptor
+	// td contains the tables, views and sequences of all the dropped
+	// schemas.
+	td []toDelete
+}
+
+// DropSchema drops user-defined schemas of the current database.
+// Privileges: DROP on schema and DROP on all tables in the schema.
+//   notes: postgres requires ownership of the schema.
+func (p *planner) DropSchema(ctx context.Context, n *tree.DropSchema) (planNode, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, errNoDatabase
+	}
+	dbDesc, err := p.ResolveUncachedDatabaseByName(ctx, p.CurrentDatabase(), true /* required */)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []*sqlbase.SchemaDescriptor
+	var td []toDelete
+	for _, name := range n.Names {
+		scName := string(name)
+		if scName == tree.PublicSchema || isVirtualSchemaName(scName) {
+			return nil, pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+				"cannot drop schema %q because it is required by the database system", scName)
+		}
+		scDesc, err := getSchemaDesc(ctx, p.txn, dbDesc.ID, scName)
+		if err != nil {
+			return nil, err
+		}
+		if scDesc == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, sqlbase.NewUndefinedSchemaError(scName)
+		}
+		if err := p.CheckPrivilege(ctx, scDesc, privilege.DROP); err != nil {
+			return nil, err
+		}
+
+		tables, err := p.prepareDropSchemaTables(ctx, dbDesc, scDesc)
+		if err != nil {
+			return nil, err
+		}
+		if len(tables) > 0 && n.DropBehavior != tree.DropCascade {
+			return nil, pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+				"schema %q is not empty and CASCADE was not specified", scName)
+		}
+		schemas = append(schemas, scDesc)
+		td = append(td, tables...)
+	}
+
+	if len(schemas) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	td, err = p.filterCascadedTables(ctx, td)
+	if err != nil {
+		return nil, err
+	}
+	return &dropSchemaNode{n: n, schemas: schemas, td: td}, nil
+}
+
+// prepareDropSchemaTables returns the tables, views and sequences of the given
+// user-defined schema, after checking that the current user can drop them
+// along with the views which depend on them.
+func (p *planner) prepareDropSchemaTables(
+	ctx context.Context, dbDesc *sqlbase.DatabaseDescriptor, scDesc *sqlbase.SchemaDescriptor,
+) ([]toDelete, error) {
+	tbNames, err := GetObjectNames(ctx, p.txn, p, dbDesc, scDesc.Name, true /*explicitPrefix*/)
+	if err != nil {
+		return nil, err
+	}
+	td := make([]toDelete, 0, len(tbNames))
+	for i := range tbNames {
+		tbDesc, err := p.prepareDrop(ctx, &tbNames[i], false /*required*/, anyDescType)
+		if err != nil {
+			return nil, err
+		}
+		if tbDesc == nil {
+			continue
+		}
+		// Recursively check permissions on all dependent views, since some may
+		// be in other schemas.
+		for _, ref := range tbDesc.DependedOnBy {
+			if err := p.canRemoveDependentView(ctx, tbDesc, ref, tree.DropCascade); err != nil {
+				return nil, err
+			}
+		}
+		td = append(td, toDelete{&tbNames[i], tbDesc})
+	}
+	return td, nil
+}
+
+func (n *dropSchemaNode) startExec(params runParams) error {
+	ctx := params.ctx
+	p := params.p
+
+	droppedTableDetails := make([]jobspb.DroppedTableDetails, 0, len(n.td))
+	tableDescs := make([]*sqlbase.MutableTableDescriptor, 0, len(n.td))
+	for _, toDel := range n.td {
+		if toDel.desc.IsView() {
+			continue
+		}
+		droppedTableDetails = append(droppedTableDetails, jobspb.DroppedTableDetails{
+			Name: toDel.tn.FQString(),
+			ID:   toDel.desc.ID,
+		})
+		tableDescs = append(tableDescs, toDel.desc)
+	}
+	if len(tableDescs) > 0 {
+		if _, err := p.createDropTablesJob(
+			ctx,
+			tableDescs,
+			droppedTableDetails,
+			tree.AsStringWithFlags(n.n, tree.FmtAlwaysQualifyTableNames),
+			true, /* drainNames */
+			sqlbase.InvalidID /* droppedDatabaseID */); err != nil {
+			return err
+		}
+	}
+
+	// The names of the dropped objects are reported in the event of the
+	// schema which contained them.
+	droppedObjects := make(map[string][]string, len(n.schemas))
+	for _, toDel := range n.td {
+		var cascadedViews []string
+		var err error
+		if toDel.desc.IsView() {
+			cascadedViews, err = p.dropViewImpl(ctx, toDel.desc, tree.DropCascade)
+		} else {
+			cascadedViews, err = p.dropTableImpl(params, toDel.desc)
+		}
+		if err != nil {
+			return err
+		}
+		scName := toDel.tn.Schema()
+		droppedObjects[scName] = append(droppedObjects[scName], cascadedViews...)
+		droppedObjects[scName] = append(droppedObjects[scName], toDel.tn.FQString())
+	}
+
+	for _, scDesc := range n.schemas {
+		nameKey := tableKey{parentID: scDesc.ParentID, name: scDesc.Name}.Key()
+		descKey := sqlbase.MakeDescMetadataKey(scDesc.ID)
+		if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", nameKey)
+			log.VEventf(ctx, 2, "Del %s", descKey)
+		}
+		b := &client.Batch{}
+		b.Del(nameKey)
+		b.Del(descKey)
+		if err := p.txn.Run(ctx, b); err != nil {
+			return err
+		}
+
+		// Log a Drop Schema event for this schema. This is an auditable log
+		// event and is recorded in the same transaction as the deletion of the
+		// schema descriptor.
+		if err := MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			ctx,
+			p.txn,
+			EventLogDropSchema,
+			int32(scDesc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				SchemaName           string
+				Statement            string
+				User                 string
+				DroppedSchemaObjects []string
+			}{scDesc.Name, n.n.String(), p.SessionData().User, droppedObjects[scDesc.Name]},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropSchemaNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropSchemaNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropSchemaNode) Close(context.Context)        {}
@@ -293,6 +293,13 @@ func (p *planner) dropTableImpl(
 		}
 	}
 
+	// Remove the table from the functions executed by its triggers.
+	if err := updateTriggerFunctions(ctx, p, tableDesc.TableDesc(), func(fn *sqlbase.FunctionDescriptor) {
+		fn.TriggerTableIDs = removeID(fn.TriggerTableIDs, tableDesc.ID)
+	}); err != nil {
+		return droppedViews, err
+	}
+
 	// Drop all views that depend on this table, assuming that we wouldn't have
 	// made it to this point if `cascade` wasn't enabled.
 	for _, ref := range tableDesc.DependedOnBy {
@@ -343,7 +350,7 @@ func (p *planner) initiateDropTable(
 	//
 	// TODO(bram): If interleaved and ON DELETE CASCADE, we will be
 	// able to use this faster mechanism.
-	if tableDesc.IsTable() && !tableDesc.IsInterleaved() &&
+	if (tableDesc.IsTable() || tableDesc.MaterializedView()) && !tableDesc.IsInterleaved() &&
 		p.ExecCfg().Settings.Version.IsActive(cluster.VersionClearRange) {
 		// Get the zone config applying to this table in order to
 		// ensure there is a GC TTL.
@@ -361,7 +368,7 @@ func (p *planner) initiateDropTable(
 	if drainName {
 		// Queue up name for draining.
 		nameDetails := sqlbase.TableDescriptor_NameInfo{
-			ParentID: tableDesc.ParentID,
+			ParentID: tableDesc.GetNamespaceParentID(),
 			Name:     tableDesc.Name}
 		tableDesc.DrainingNames = append(tableDesc.DrainingNames, nameDetails)
 	}
@@ -380,7 +387,9 @@ func (p *planner) initiateDropTable(
 		}
 	}
 	for _, gcm := range tableDesc.GCMutations {
-		jobIDs[gcm.JobID] = struct{}{}
+		if gcm.JobID != 0 {
+			jobIDs[gcm.JobID] = struct{}{}
+		}
 	}
 	for jobID := range jobIDs {
 		job, err := p.ExecCfg().JobRegistry.LoadJobWithTxn(ctx, jobID, p.txn)
@@ -0,0 +1,137 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// dropTriggerNode represents a DROP TRIGGER statement.
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *sqlbase.MutableTableDescriptor
+	idx       int
+}
+
+// DropTrigger drops a trigger.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, &n.Table, !n.IfExists, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		// IfExists specified and table does not exist.
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Name) {
+			return &dropTriggerNode{n: n, tableDesc: tableDesc, idx: i}, nil
+		}
+	}
+	if n.IfExists {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return nil, pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+		"trigger %q for table %q does not exist", n.Name, tableDesc.Name)
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	fnID := n.tableDesc.Triggers[n.idx].FunctionID
+	n.tableDesc.Triggers = append(n.tableDesc.Triggers[:n.idx], n.tableDesc.Triggers[n.idx+1:]...)
+	if err := removeTriggerTableReference(ctx, p, fnID, n.tableDesc.TableDesc()); err != nil {
+		return err
+	}
+	if err := p.writeSchemaChange(ctx, n.tableDesc, sqlbase.InvalidMutationID); err != nil {
+		return err
+	}
+
+	// Log Drop Trigger event. This is an auditable log event and is recorded
+	// in the same transaction as the table descriptor update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		ctx,
+		p.txn,
+		EventLogDropTrigger,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TriggerName string
+			TableName   string
+			Statement   string
+			User        string
+		}{n.n.Name.String(), n.n.Table.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (*dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropTriggerNode) Close(context.Context)        {}
+
+// removeTriggerTableReference removes the given table from the tables that
+// have triggers executing the function with the given ID, unless another
+// trigger of the table still executes it.
+func removeTriggerTableReference(
+	ctx context.Context, p *planner, fnID sqlbase.ID, desc *sqlbase.TableDescriptor,
+) error {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].FunctionID == fnID {
+			return nil
+		}
+	}
+	fn := &sqlbase.FunctionDescriptor{}
+	if err := getDescriptorByID(ctx, p.txn, fnID, fn); err != nil {
+		return err
+	}
+	fn.TriggerTableIDs = removeID(fn.TriggerTableIDs, desc.ID)
+	return writeFunctionDesc(ctx, p, fn)
+}
+
+// updateTriggerFunctions applies the given update to the descriptors of the
+// functions executed by the triggers of the given table, then writes them.
+func updateTriggerFunctions(
+	ctx context.Context,
+	p *planner,
+	desc *sqlbase.TableDescriptor,
+	update func(fn *sqlbase.FunctionDescriptor),
+) error {
+	var fnIDs []sqlbase.ID
+	for i := range desc.Triggers {
+		if fnID := desc.Triggers[i].FunctionID; !containsID(fnIDs, fnID) {
+			fnIDs = append(fnIDs, fnID)
+		}
+	}
+	for _, fnID := range fnIDs {
+		fn := &sqlbase.FunctionDescriptor{}
+		if err := getDescriptorByID(ctx, p.txn, fnID, fn); err != nil {
+			return err
+		}
+		update(fn)
+		if err := writeFunctionDesc(ctx, p, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
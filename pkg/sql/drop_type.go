@@ -0,0 +1,128 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropTypeNode struct {
+	n     *tree.DropType
+	types []typeToDelete
+}
+
+type typeToDelete struct {
+	name *tree.TableName
+	desc *sqlbase.TypeDescriptor
+}
+
+// DropType drops user-defined types.
+// Privileges: DROP on type.
+//   notes: postgres requires ownership of the type.
+func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, error) {
+	typs := make([]typeToDelete, 0, len(n.Names))
+	for i := range n.Names {
+		tn := &n.Names[i]
+		desc, err := p.resolveTypeDesc(ctx, tn, !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if desc == nil {
+			// IfExists specified and type does not exist.
+			continue
+		}
+		if err := p.CheckPrivilege(ctx, desc, privilege.DROP); err != nil {
+			return nil, err
+		}
+		if err := p.checkTypeNotReferenced(ctx, desc, n.DropBehavior); err != nil {
+			return nil, err
+		}
+		typs = append(typs, typeToDelete{name: tn, desc: desc})
+	}
+
+	if len(typs) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return &dropTypeNode{n: n, types: typs}, nil
+}
+
+// checkTypeNotReferenced returns an error if a table has columns of the given
+// type.
+func (p *planner) checkTypeNotReferenced(
+	ctx context.Context, desc *sqlbase.TypeDescriptor, behavior tree.DropBehavior,
+) error {
+	tableIDs, err := getTablesReferencingType(ctx, p.txn, desc.ID)
+	if err != nil || len(tableIDs) == 0 {
+		return err
+	}
+	if behavior == tree.DropCascade {
+		return pgerror.UnimplementedWithIssueError(24873, "DROP TYPE CASCADE")
+	}
+	tableDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, tableIDs[0])
+	if err != nil {
+		return err
+	}
+	return pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+		"cannot drop type %q because other objects depend on it", desc.Name).SetDetailf(
+		"table %q depends on type %q", tableDesc.Name, desc.Name)
+}
+
+func (n *dropTypeNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	for _, typ := range n.types {
+		nameKey := tableKey{parentID: typ.desc.ParentID, name: typ.desc.Name}.Key()
+		descKey := sqlbase.MakeDescMetadataKey(typ.desc.ID)
+		if p.extendedEvalCtx.Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "Del %s", nameKey)
+			log.VEventf(ctx, 2, "Del %s", descKey)
+		}
+		b := &client.Batch{}
+		b.Del(nameKey)
+		b.Del(descKey)
+		if err := p.txn.Run(ctx, b); err != nil {
+			return err
+		}
+
+		// Log a Drop Type event for this type. This is an auditable log event
+		// and is recorded in the same transaction as the deletion of the type
+		// descriptor.
+		if err := MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			ctx,
+			p.txn,
+			EventLogDropType,
+			int32(typ.desc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				TypeName  string
+				Statement string
+				User      string
+			}{typ.name.FQString(), n.n.String(), params.SessionData().User},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropTypeNode) Close(context.Context)        {}
@@ -145,6 +145,37 @@ func (n *DropUserNode) startExec(params runParams) error {
 			}
 		}
 	}
+	for _, fnID := range lCtx.fnIDs {
+		fn := lCtx.fnDescs[fnID]
+		for _, u := range fn.GetPrivileges().Users {
+			if _, ok := userNames[u.User]; ok {
+				if f.Len() > 0 {
+					f.WriteString(", ")
+				}
+				tn := tree.MakeTableName(tree.Name(lCtx.dbNames[fn.ParentID]), tree.Name(fn.Name))
+				f.FormatNode(&tn)
+				break
+			}
+		}
+	}
+	for _, scID := range lCtx.scIDs {
+		sc := lCtx.scDescs[scID]
+		for _, u := range sc.GetPrivileges().Users {
+			if _, ok := userNames[u.User]; ok {
+				if f.Len() > 0 {
+					f.WriteString(", ")
+				}
+				prefix := tree.TableNamePrefix{
+					CatalogName:     tree.Name(lCtx.dbNames[sc.ParentID]),
+					SchemaName:      tree.Name(sc.Name),
+					ExplicitCatalog: true,
+					ExplicitSchema:  true,
+				}
+				f.FormatNode(&prefix)
+				break
+			}
+		}
+	}
 
 	// Was there any object dependin on that user?
 	if f.Len() > 0 {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -46,6 +47,9 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 			// IfExists specified and the view did not exist.
 			continue
 		}
+		if err := checkViewMatchesMaterialized(tn, droppedDesc, n.IsMaterialized); err != nil {
+			return nil, err
+		}
 
 		td = append(td, toDelete{tn, droppedDesc})
 	}
@@ -111,6 +115,24 @@ func (*dropViewNode) Next(runParams) (bool, error) { return false, nil }
 func (*dropViewNode) Values() tree.Datums          { return tree.Datums{} }
 func (*dropViewNode) Close(context.Context)        {}
 
+// checkViewMatchesMaterialized returns an error if the view is materialized
+// but the statement does not say so, or vice versa.
+func checkViewMatchesMaterialized(
+	tn *tree.TableName, desc *sqlbase.MutableTableDescriptor, materialized bool,
+) error {
+	if desc.MaterializedView() == materialized {
+		return nil
+	}
+	if materialized {
+		return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"%q is not a materialized view", tree.ErrString(tn)).SetHintf(
+			"use DROP VIEW to remove a view")
+	}
+	return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+		"%q is a materialized view", tree.ErrString(tn)).SetHintf(
+		"use DROP MATERIALIZED VIEW to remove a materialized view")
+}
+
 func descInSlice(descID sqlbase.ID, td []toDelete) bool {
 	for _, toDel := range td {
 		if descID == toDel.desc.ID {
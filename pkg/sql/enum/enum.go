@@ -0,0 +1,97 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package enum generates the physical representations of the values of
+// user-defined enum types. The representation of a value is the byte string
+// that is stored on disk in place of its label; the byte strings of the
+// values of a type sort in the declared order of the values, so that indexes
+// on enum columns are ordered like the type.
+//
+// The byte strings never end with a zero byte, so that a new byte string can
+// always be generated between any two existing ones without rewriting them.
+package enum
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// GenerateNEvenlySpacedBytes returns n byte strings, sorted in ascending
+// order, that are spread evenly over the space of byte strings of the
+// smallest length that can hold them. Spreading them evenly leaves room to
+// insert new values between them while keeping the representations short.
+func GenerateNEvenlySpacedBytes(n int) [][]byte {
+	if n <= 0 {
+		return nil
+	}
+	// Find the smallest number of bytes that can represent n distinct
+	// non-zero values with some room in between.
+	numBytes := 1
+	space := uint64(256)
+	for space <= uint64(n) && numBytes < 8 {
+		numBytes++
+		space *= 256
+	}
+	step := space / uint64(n+1)
+	result := make([][]byte, n)
+	for i := range result {
+		result[i] = encodeFixed((uint64(i)+1)*step, numBytes)
+	}
+	return result
+}
+
+// encodeFixed encodes v as a big-endian byte string of the given length,
+// without its trailing zero bytes.
+func encodeFixed(v uint64, numBytes int) []byte {
+	b := make([]byte, numBytes)
+	for i := numBytes - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return bytes.TrimRight(b, "\x00")
+}
+
+// GenByteStringBetween returns a short byte string that sorts strictly
+// between prev and next. A nil prev is treated as the smallest possible byte
+// string and a nil next as the largest one. It panics if prev does not sort
+// before next.
+func GenByteStringBetween(prev []byte, next []byte) []byte {
+	if next != nil && bytes.Compare(prev, next) >= 0 {
+		panic(fmt.Sprintf("%x is not less than %x", prev, next))
+	}
+	var result []byte
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(prev) {
+			lo = int(prev[i])
+		}
+		hi := 256
+		if next != nil {
+			hi = 0
+			if i < len(next) {
+				hi = int(next[i])
+			}
+		}
+		if hi-lo > 1 {
+			return append(result, byte((lo+hi)/2))
+		}
+		if hi-lo == 1 {
+			// Any byte string that starts with the result so far followed by
+			// lo sorts before next, so next no longer bounds the remaining
+			// bytes.
+			next = nil
+		}
+		result = append(result, byte(lo))
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package enum
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+)
+
+func checkSortedAndValid(t *testing.T, reps [][]byte) {
+	t.Helper()
+	for i, r := range reps {
+		if len(r) == 0 || r[len(r)-1] == 0 {
+			t.Fatalf("invalid representation %x", r)
+		}
+		if i > 0 && bytes.Compare(reps[i-1], r) >= 0 {
+			t.Fatalf("representations not sorted: %x >= %x", reps[i-1], r)
+		}
+	}
+}
+
+func TestGenerateNEvenlySpacedBytes(t *testing.T) {
+	testCases := []struct {
+		n        int
+		maxBytes int
+	}{
+		{0, 0},
+		{1, 1},
+		{3, 1},
+		{255, 1},
+		{256, 2},
+		{1000, 2},
+		{70000, 3},
+	}
+	for _, tc := range testCases {
+		reps := GenerateNEvenlySpacedBytes(tc.n)
+		if len(reps) != tc.n {
+			t.Fatalf("%d: expected %d representations, got %d", tc.n, tc.n, len(reps))
+		}
+		checkSortedAndValid(t, reps)
+		for _, r := range reps {
+			if len(r) > tc.maxBytes {
+				t.Fatalf("%d: representation %x is longer than %d bytes", tc.n, r, tc.maxBytes)
+			}
+		}
+	}
+
+	if reps := GenerateNEvenlySpacedBytes(3); !bytes.Equal(reps[1], []byte{128}) {
+		t.Fatalf("expected the middle of 3 values to be 80, got %x", reps[1])
+	}
+}
+
+func TestGenByteStringBetween(t *testing.T) {
+	testCases := []struct {
+		prev, next []byte
+		expected   []byte
+	}{
+		{nil, nil, []byte{128}},
+		{[]byte{128}, nil, []byte{192}},
+		{nil, []byte{128}, []byte{64}},
+		{[]byte{1}, []byte{3}, []byte{2}},
+		{[]byte{1}, []byte{2}, []byte{1, 128}},
+		{nil, []byte{1}, []byte{0, 128}},
+		{[]byte{255}, nil, []byte{255, 128}},
+		{[]byte{1, 255}, []byte{2}, []byte{1, 255, 128}},
+		{[]byte{1}, []byte{1, 1}, []byte{1, 0, 128}},
+	}
+	for _, tc := range testCases {
+		res := GenByteStringBetween(tc.prev, tc.next)
+		if !bytes.Equal(res, tc.expected) {
+			t.Errorf("between %x and %x: expected %x, got %x", tc.prev, tc.next, tc.expected, res)
+		}
+	}
+}
+
+func TestGenByteStringBetweenRandom(t *testing.T) {
+	rng, _ := randutil.NewPseudoRand()
+	reps := GenerateNEvenlySpacedBytes(3)
+	for i := 0; i < 1000; i++ {
+		// Insert a new value at a random position.
+		pos := rng.Intn(len(reps) + 1)
+		if rng.Intn(2) == 0 {
+			// Favor inserting at the same position to create long
+			// representations.
+			pos = len(reps) / 2
+		}
+		var prev, next []byte
+		if pos > 0 {
+			prev = reps[pos-1]
+		}
+		if pos < len(reps) {
+			next = reps[pos]
+		}
+		res := GenByteStringBetween(prev, next)
+		reps = append(reps, nil)
+		copy(reps[pos+1:], reps[pos:])
+		reps[pos] = res
+		checkSortedAndValid(t, reps)
+	}
+}
@@ -69,6 +69,28 @@ const (
 	// EventLogAlterSequence is recorded when a sequence is altered.
 	EventLogAlterSequence EventLogType = "alter_sequence"
 
+	// EventLogCreateFunction is recorded when a function is created.
+	EventLogCreateFunction EventLogType = "create_function"
+	// EventLogDropFunction is recorded when a function is dropped.
+	EventLogDropFunction EventLogType = "drop_function"
+
+	// EventLogCreateTrigger is recorded when a trigger is created.
+	EventLogCreateTrigger EventLogType = "create_trigger"
+	// EventLogDropTrigger is recorded when a trigger is dropped.
+	EventLogDropTrigger EventLogType = "drop_trigger"
+
+	// EventLogCreateType is recorded when a type is created.
+	EventLogCreateType EventLogType = "create_type"
+	// EventLogAlterType is recorded when a type is altered.
+	EventLogAlterType EventLogType = "alter_type"
+	// EventLogDropType is recorded when a type is dropped.
+	EventLogDropType EventLogType = "drop_type"
+
+	// EventLogCreateSchema is recorded when a schema is created.
+	EventLogCreateSchema EventLogType = "create_schema"
+	// EventLogDropSchema is recorded when a schema is dropped.
+	EventLogDropSchema EventLogType = "drop_schema"
+
 	// EventLogReverseSchemaChange is recorded when an in-progress schema change
 	// encounters a problem and is reversed.
 	EventLogReverseSchemaChange EventLogType = "reverse_schema_change"
@@ -51,6 +51,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -352,6 +353,10 @@ type ExecutorConfig struct {
 	InternalExecutor *InternalExecutor
 	QueryCache       *querycache.C
 
+	// ProtectedTimestampProvider is used by jobs to protect the data they
+	// read from garbage collection.
+	ProtectedTimestampProvider protectedts.Provider
+
 	TestingKnobs              *ExecutorTestingKnobs
 	SchemaChangerTestingKnobs *SchemaChangerTestingKnobs
 	DistSQLRunTestingKnobs    *distsqlrun.TestingKnobs
@@ -637,10 +642,13 @@ func checkResultType(typ types.T) error {
 	case types.String:
 	case types.Date:
 	case types.Time:
+	case types.TimeTZ:
 	case types.Timestamp:
 	case types.TimestampTZ:
 	case types.Interval:
 	case types.JSON:
+	case types.TSVector:
+	case types.TSQuery:
 	case types.UUID:
 	case types.INet:
 	case types.NameArray:
@@ -923,14 +931,22 @@ const MaxSQLBytes = 1000
 
 type schemaChangerCollection struct {
 	schemaChangers []SchemaChanger
+	// typeIDs are the IDs of the enum types whose read-only members are to be
+	// made public once the transaction that added them has committed.
+	typeIDs []sqlbase.ID
 }
 
 func (scc *schemaChangerCollection) queueSchemaChanger(schemaChanger SchemaChanger) {
 	scc.schemaChangers = append(scc.schemaChangers, schemaChanger)
 }
 
+func (scc *schemaChangerCollection) queueTypeChange(typeID sqlbase.ID) {
+	scc.typeIDs = append(scc.typeIDs, typeID)
+}
+
 func (scc *schemaChangerCollection) reset() {
 	scc.schemaChangers = nil
+	scc.typeIDs = nil
 }
 
 // execSchemaChanges releases schema leases and runs the queued
@@ -944,7 +960,7 @@ func (scc *schemaChangerCollection) execSchemaChanges(
 	tracing *SessionTracing,
 	ieFactory sqlutil.SessionBoundInternalExecutorFactory,
 ) error {
-	if len(scc.schemaChangers) == 0 {
+	if len(scc.schemaChangers) == 0 && len(scc.typeIDs) == 0 {
 		return nil
 	}
 	if fn := cfg.SchemaChangerTestingKnobs.SyncFilter; fn != nil {
@@ -993,6 +1009,14 @@ func (scc *schemaChangerCollection) execSchemaChanges(
 		}
 	}
 	scc.schemaChangers = nil
+	// Make public the enum members added by the transaction, now that the
+	// tables referencing their types are able to decode them.
+	for _, id := range scc.typeIDs {
+		if err := publishEnumMembers(ctx, cfg, id); err != nil && firstError == nil {
+			firstError = err
+		}
+	}
+	scc.typeIDs = nil
 	return firstError
 }
 
@@ -1610,6 +1634,9 @@ type sessionDataMutator struct {
 	// applicationNamedChanged, if set, is called when the "application name"
 	// variable is updated.
 	applicationNameChanged func(newName string)
+	// temporarySchemaCreated, if set, is called when a temporary schema is
+	// created for the session.
+	temporarySchemaCreated func()
 }
 
 // SetApplicationName sets the application name.
@@ -1677,7 +1704,18 @@ func (m *sessionDataMutator) SetSafeUpdates(val bool) {
 }
 
 func (m *sessionDataMutator) SetSearchPath(val sessiondata.SearchPath) {
-	m.data.SearchPath = val
+	// The temporary schema of the session is not part of the search_path
+	// variable and survives changes to it.
+	m.data.SearchPath = val.WithTemporarySchemaName(m.data.SearchPath.GetTemporarySchemaName())
+}
+
+// SetTemporarySchemaName records that the session has created a temporary
+// schema with the given name, which is searched before the search path.
+func (m *sessionDataMutator) SetTemporarySchemaName(scName string) {
+	m.data.SearchPath = m.data.SearchPath.WithTemporarySchemaName(scName)
+	if m.temporarySchemaCreated != nil {
+		m.temporarySchemaCreated()
+	}
 }
 
 func (m *sessionDataMutator) SetLocation(loc *time.Location) {
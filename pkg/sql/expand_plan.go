@@ -291,6 +291,9 @@ func doExpandPlan(
 	case *max1RowNode:
 		n.plan, err = doExpandPlan(ctx, p, noParams, n.plan)
 
+	case *recursiveCTENode:
+		n.initial, err = doExpandPlan(ctx, p, noParams, n.initial)
+
 	case *sortNode:
 		if !n.ordering.IsPrefixOf(params.desiredOrdering) {
 			params.desiredOrdering = n.ordering
@@ -354,6 +357,7 @@ func doExpandPlan(
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *commentOnColumnNode:
 	case *commentOnDatabaseNode:
@@ -368,12 +372,21 @@ func doExpandPlan(
 	case *createIndexNode:
 	case *CreateUserNode:
 	case *createViewNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
+	case *createTypeNode:
+	case *createSchemaNode:
 	case *createSequenceNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
+	case *dropTypeNode:
+	case *dropSchemaNode:
 	case *dropSequenceNode:
 	case *DropUserNode:
 	case *zeroNode:
@@ -386,8 +399,10 @@ func doExpandPlan(
 			}
 		}
 	case *sequenceSelectNode:
+	case *workTableScanNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
+	case *setConstraintsNode:
 	case *setZoneConfigNode:
 	case *showZoneConfigNode:
 	case *showFingerprintsNode:
@@ -767,6 +782,9 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 	case *max1RowNode:
 		n.plan = p.simplifyOrderings(n.plan, usefulOrdering)
 
+	case *recursiveCTENode:
+		n.initial = p.simplifyOrderings(n.initial, nil)
+
 	case *spoolNode:
 		n.source = p.simplifyOrderings(n.source, usefulOrdering)
 
@@ -868,6 +886,7 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *commentOnColumnNode:
 	case *commentOnDatabaseNode:
@@ -882,20 +901,31 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 	case *createIndexNode:
 	case *CreateUserNode:
 	case *createViewNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
+	case *createTypeNode:
+	case *createSchemaNode:
 	case *createSequenceNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
+	case *dropTypeNode:
+	case *dropSchemaNode:
 	case *dropSequenceNode:
 	case *DropUserNode:
 	case *zeroNode:
 	case *unaryNode:
 	case *hookFnNode:
 	case *sequenceSelectNode:
+	case *workTableScanNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
+	case *setConstraintsNode:
 	case *setZoneConfigNode:
 	case *showZoneConfigNode:
 	case *showFingerprintsNode:
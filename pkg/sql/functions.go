@@ -0,0 +1,253 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// User-defined functions are stored as FunctionDescriptors. They live in the
+// namespace of their parent database, next to its tables, views and
+// sequences: a name refers to at most one of these objects. Function
+// descriptors are not leased; they are read in the current transaction every
+// time they are resolved.
+
+// getFunctionDesc looks up the function with the given name in the database
+// with the given ID. It returns nil if there is no such function, including
+// when the name refers to a table.
+func getFunctionDesc(
+	ctx context.Context, txn *client.Txn, parentID sqlbase.ID, name string,
+) (*sqlbase.FunctionDescriptor, error) {
+	key := tableKey{parentID: parentID, name: name}.Key()
+	log.Eventf(ctx, "looking up function ID for name key %q", key)
+	gr, err := txn.Get(ctx, key)
+	if err != nil || !gr.Exists() {
+		return nil, err
+	}
+	desc := &sqlbase.Descriptor{}
+	if err := txn.GetProto(ctx, sqlbase.MakeDescMetadataKey(sqlbase.ID(gr.ValueInt())), desc); err != nil {
+		return nil, err
+	}
+	fn := desc.GetFunction()
+	if fn == nil {
+		return nil, nil
+	}
+	if err := fn.Validate(); err != nil {
+		return nil, err
+	}
+	return fn, nil
+}
+
+// functionResolver is a tree.TableNameExistingResolver which looks up
+// user-defined functions instead of tables.
+type functionResolver struct {
+	p *planner
+}
+
+// LookupObject implements the tree.TableNameExistingResolver interface.
+func (r functionResolver) LookupObject(
+	ctx context.Context, _ bool, dbName, scName, obName string,
+) (found bool, objMeta tree.NameResolutionResult, err error) {
+	// At this point, functions can only be created in the public schema.
+	if scName != tree.PublicSchema {
+		return false, nil, nil
+	}
+	p := r.p
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(
+		ctx, p.txn, dbName, p.CommonLookupFlags(false /* required */))
+	if err != nil || dbDesc == nil {
+		return false, nil, err
+	}
+	fn, err := getFunctionDesc(ctx, p.txn, dbDesc.ID, obName)
+	if err != nil || fn == nil {
+		return false, nil, err
+	}
+	return true, fn, nil
+}
+
+// resolveFunction looks up an existing user-defined function. If required is
+// true, an error is returned if the function does not exist.
+//
+// The function name is modified in-place with the result of the name
+// resolution, if successful.
+func (p *planner) resolveFunction(
+	ctx context.Context, tn *tree.TableName, required bool,
+) (*sqlbase.FunctionDescriptor, error) {
+	found, desc, err := tn.ResolveExisting(
+		ctx, functionResolver{p: p}, false /* requireMutable */, p.CurrentDatabase(), p.CurrentSearchPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if required {
+			return nil, sqlbase.NewUndefinedFunctionError(tn)
+		}
+		return nil, nil
+	}
+	return desc.(*sqlbase.FunctionDescriptor), nil
+}
+
+// functionArgsAlias is the name of the data source that holds the arguments
+// of a user-defined function in the query built by makeFunctionQuery.
+const functionArgsAlias = "$args"
+
+// functionArgColName returns the name of the column that holds the i-th
+// argument of the given function in the query built by makeFunctionQuery.
+// Unnamed parameters can only be referenced positionally, so the column is
+// given a name that cannot clash with any identifier in the body.
+func functionArgColName(desc *sqlbase.FunctionDescriptor, i int) tree.Name {
+	if name := desc.Args[i].Name; name != "" {
+		return tree.Name(name)
+	}
+	return tree.Name("$" + strconv.Itoa(i+1))
+}
+
+// parseFunctionBody parses the body of a user-defined function, which must
+// be a single query.
+func parseFunctionBody(body string) (*tree.Select, error) {
+	stmt, err := parser.ParseOne(body)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+			"the body of a SQL function must be a single query, not %s", stmt.AST.StatementTag())
+	}
+	return sel, nil
+}
+
+// makeFunctionQuery returns the query that evaluates a call to the given
+// function. The arguments of the call are passed as placeholders; they are
+// exposed to the body under the names of the parameters through a VALUES
+// clause. For example, the function
+//
+//   CREATE FUNCTION add(a INT, b INT) RETURNS INT AS 'SELECT a + b'
+//
+// is evaluated as:
+//
+//   SELECT (SELECT a + b LIMIT 1) FROM (VALUES ($1::INT, $2::INT)) AS "$args" (a, b)
+//
+// Like in Postgres, the result of the function is the first row returned by
+// the body, or NULL if there is none.
+func makeFunctionQuery(desc *sqlbase.FunctionDescriptor) (string, error) {
+	body, err := parseFunctionBody(desc.Body)
+	if err != nil {
+		return "", err
+	}
+	if body.Limit == nil {
+		body.Limit = &tree.Limit{Count: tree.NewDInt(1)}
+	}
+	sel := &tree.SelectClause{
+		Exprs: tree.SelectExprs{{Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: body}}}},
+	}
+	if len(desc.Args) > 0 {
+		row := make(tree.Exprs, len(desc.Args))
+		cols := make(tree.NameList, len(desc.Args))
+		for i := range desc.Args {
+			typ, err := coltypes.DatumTypeToColumnType(desc.Args[i].Type.ToDatumType())
+			if err != nil {
+				return "", err
+			}
+			row[i] = &tree.CastExpr{
+				Expr:       &tree.Placeholder{Idx: types.PlaceholderIdx(i)},
+				Type:       typ,
+				SyntaxMode: tree.CastShort,
+			}
+			cols[i] = functionArgColName(desc, i)
+		}
+		sel.From = &tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+			Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: &tree.Select{
+				Select: &tree.ValuesClause{Rows: []tree.Exprs{row}},
+			}}},
+			As: tree.AliasClause{Alias: functionArgsAlias, Cols: cols},
+		}}}
+	}
+	return tree.AsStringWithFlags(&tree.Select{Select: sel}, tree.FmtParsable), nil
+}
+
+// maxFunctionCallDepth is the maximum nesting depth of calls to user-defined
+// functions that are evaluated by running their body.
+const maxFunctionCallDepth = 32
+
+// functionCallDepthKey is the context key that holds the nesting depth of
+// calls to user-defined functions.
+type functionCallDepthKey struct{}
+
+// makeFunctionDefinition returns the definition of the given user-defined
+// function, which can be used to type check and evaluate calls to it.
+func makeFunctionDefinition(desc *sqlbase.FunctionDescriptor) (*tree.FunctionDefinition, error) {
+	if desc.ReturnsTrigger {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"trigger functions can only be called as triggers")
+	}
+	query, err := makeFunctionQuery(desc)
+	if err != nil {
+		return nil, err
+	}
+	opName := fmt.Sprintf("udf-%s", desc.Name)
+	argTypes := make(tree.ArgTypes, len(desc.Args))
+	for i := range desc.Args {
+		argTypes[i].Name = string(functionArgColName(desc, i))
+		argTypes[i].Typ = desc.Args[i].Type.ToDatumType()
+	}
+	props := &tree.FunctionProperties{
+		NullableArgs: !desc.Strict,
+		Impure:       desc.Volatility == sqlbase.FunctionDescriptor_VOLATILE,
+		// The body of the function is run by the internal executor of the
+		// session, which is not available in remote flows.
+		DistsqlBlacklist: true,
+	}
+	overload := tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(desc.ReturnType.ToDatumType()),
+		Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			// Each nested call runs in a new internal execution, so bound the
+			// nesting depth to stop runaway recursion.
+			ctx := evalCtx.Ctx()
+			depth, _ := ctx.Value(functionCallDepthKey{}).(int)
+			if depth >= maxFunctionCallDepth {
+				return nil, pgerror.NewErrorf(pgerror.CodeStatementTooComplexError,
+					"function calls nested too deeply (more than %d levels)", maxFunctionCallDepth)
+			}
+			ctx = context.WithValue(ctx, functionCallDepthKey{}, depth+1)
+
+			qargs := make([]interface{}, len(args))
+			for i := range args {
+				qargs[i] = args[i]
+			}
+			row, err := evalCtx.InternalExecutor.QueryRow(ctx, opName, evalCtx.Txn, query, qargs...)
+			if err != nil {
+				return nil, err
+			}
+			if row == nil {
+				return tree.DNull, nil
+			}
+			return row[0], nil
+		},
+	}
+	return tree.NewFunctionDefinition(desc.Name, props, []tree.Overload{overload}), nil
+}
@@ -17,6 +17,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -25,7 +26,7 @@ import (
 
 // Grant adds privileges to users.
 // Current status:
-// - Target: single database, table, or view.
+// - Target: single database, table, view, or function.
 // TODO(marc): open questions:
 // - should we have root always allowed and not present in the permissions list?
 // - should we make users case-insensitive?
@@ -33,14 +34,15 @@ import (
 //   Notes: postgres requires the object owner.
 //          mysql requires the "grant option" and the same privileges, and sometimes superuser.
 func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
-	return p.changePrivileges(ctx, n.Targets, n.Grantees, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
-		privDesc.Grant(grantee, n.Privileges)
-	})
+	return p.changePrivileges(ctx, n.Targets, n.Grantees, n.Privileges,
+		func(privDesc *sqlbase.PrivilegeDescriptor, grantee string, _ privilege.List) {
+			privDesc.Grant(grantee, n.Privileges)
+		})
 }
 
 // Revoke removes privileges from users.
 // Current status:
-// - Target: single database, table, or view.
+// - Target: single database, table, view, or function.
 // TODO(marc): open questions:
 // - should we have root always allowed and not present in the permissions list?
 // - should we make users case-insensitive?
@@ -48,16 +50,18 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 //   Notes: postgres requires the object owner.
 //          mysql requires the "grant option" and the same privileges, and sometimes superuser.
 func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error) {
-	return p.changePrivileges(ctx, n.Targets, n.Grantees, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
-		privDesc.Revoke(grantee, n.Privileges)
-	})
+	return p.changePrivileges(ctx, n.Targets, n.Grantees, n.Privileges,
+		func(privDesc *sqlbase.PrivilegeDescriptor, grantee string, validPrivs privilege.List) {
+			privDesc.Revoke(grantee, n.Privileges, validPrivs)
+		})
 }
 
 func (p *planner) changePrivileges(
 	ctx context.Context,
 	targets tree.TargetList,
 	grantees tree.NameList,
-	changePrivilege func(*sqlbase.PrivilegeDescriptor, string),
+	privs privilege.List,
+	changePrivilege func(*sqlbase.PrivilegeDescriptor, string, privilege.List),
 ) (planNode, error) {
 	// Check whether grantees exists
 	users, err := p.GetAllUsersAndRoles(ctx)
@@ -92,9 +96,19 @@ func (p *planner) changePrivileges(
 		if err := p.CheckPrivilege(ctx, descriptor, privilege.GRANT); err != nil {
 			return nil, err
 		}
+		validPrivs := privilege.DBTablePrivileges
+		if _, ok := descriptor.(*sqlbase.FunctionDescriptor); ok {
+			validPrivs = privilege.FunctionPrivileges
+		}
+		for _, priv := range privs {
+			if !validPrivs.Contains(priv) {
+				return nil, pgerror.NewErrorf(pgerror.CodeInvalidGrantOperationError,
+					"invalid privilege type %s for %s", priv, descriptor.TypeName())
+			}
+		}
 		privileges := descriptor.GetPrivileges()
 		for _, grantee := range grantees {
-			changePrivilege(privileges, string(grantee))
+			changePrivilege(privileges, string(grantee), validPrivs)
 		}
 
 		// Validate privilege descriptors directly as the db/table level Validate
@@ -111,6 +125,21 @@ func (p *planner) changePrivileges(
 			descKey := sqlbase.MakeDescMetadataKey(descriptor.GetID())
 			b.Put(descKey, sqlbase.WrapDescriptor(descriptor))
 
+		case *sqlbase.FunctionDescriptor:
+			if err := d.Validate(); err != nil {
+				return nil, err
+			}
+			d.Version++
+			descKey := sqlbase.MakeDescMetadataKey(descriptor.GetID())
+			b.Put(descKey, sqlbase.WrapDescriptor(descriptor))
+
+		case *sqlbase.SchemaDescriptor:
+			if err := d.Validate(); err != nil {
+				return nil, err
+			}
+			descKey := sqlbase.MakeDescMetadataKey(descriptor.GetID())
+			b.Put(descKey, sqlbase.WrapDescriptor(descriptor))
+
 		case *sqlbase.MutableTableDescriptor:
 			if !d.Dropped() {
 				if err := p.writeSchemaChangeToBatch(
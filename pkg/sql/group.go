@@ -634,6 +634,11 @@ func (v *extractAggregatesVisitor) VisitPre(expr tree.Expr) (recurse bool, newEx
 
 	switch t := expr.(type) {
 	case *tree.FuncExpr:
+		if t.AggType == tree.OrderedSetAgg {
+			v.err = pgerror.Unimplemented("within group",
+				"WITHIN GROUP is only supported by the cost-based optimizer")
+			return false, expr
+		}
 		if agg := t.GetAggregateConstructor(); agg != nil {
 			var f *aggregateFuncHolder
 			if len(t.Exprs) == 0 {
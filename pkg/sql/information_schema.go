@@ -379,8 +379,7 @@ func numericScale(colType sqlbase.ColumnType) tree.Datum {
 }
 
 func datetimePrecision(colType sqlbase.ColumnType) tree.Datum {
-	// We currently do not support a datetime precision.
-	return tree.DNull
+	return dIntFnOrNull(colType.DatetimePrecision)
 }
 
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-constraint-column-usage.html
@@ -764,7 +763,7 @@ var informationSchemaSchemataTable = virtualSchemaTable{
 	schema: vtable.InformationSchemaSchemata,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachDatabaseDesc(ctx, p, dbContext, func(db *sqlbase.DatabaseDescriptor) error {
-			return forEachSchemaName(ctx, p, db, func(sc string) error {
+			return forEachSchemaName(ctx, p, db, func(sc string, _ *sqlbase.SchemaDescriptor) error {
 				return addRow(
 					tree.NewDString(db.Name), // catalog_name
 					tree.NewDString(sc),      // schema_name
@@ -789,8 +788,11 @@ CREATE TABLE information_schema.schema_privileges (
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachDatabaseDesc(ctx, p, dbContext, func(db *sqlbase.DatabaseDescriptor) error {
-			return forEachSchemaName(ctx, p, db, func(scName string) error {
+			return forEachSchemaName(ctx, p, db, func(scName string, scDesc *sqlbase.SchemaDescriptor) error {
 				privs := db.Privileges.Show()
+				if scDesc != nil {
+					privs = scDesc.Privileges.Show()
+				}
 				dbNameStr := tree.NewDString(db.Name)
 				scNameStr := tree.NewDString(scName)
 				for _, u := range privs {
@@ -1183,18 +1185,45 @@ CREATE TABLE information_schema.views (
 	},
 }
 
-// forEachSchemaName iterates over the physical and virtual schemas.
+// forEachSchemaName iterates over the physical and virtual schemas. The
+// descriptor passed to fn is only set for user-defined schemas.
 func forEachSchemaName(
-	ctx context.Context, p *planner, db *sqlbase.DatabaseDescriptor, fn func(string) error,
+	ctx context.Context,
+	p *planner,
+	db *sqlbase.DatabaseDescriptor,
+	fn func(string, *sqlbase.SchemaDescriptor) error,
 ) error {
 	scNames := []string{string(tree.PublicSchemaName)}
+	// Handle user-defined schemas.
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	scDescs := make(map[string]*sqlbase.SchemaDescriptor)
+	for _, desc := range descs {
+		if sc, ok := desc.(*sqlbase.SchemaDescriptor); ok && sc.ParentID == db.ID {
+			scNames = append(scNames, sc.Name)
+			scDescs[sc.Name] = sc
+		}
+	}
+	// Handle the temporary schema of the session, if it has one in this
+	// database.
+	if tempSchemaName := p.SessionData().SearchPath.GetTemporarySchemaName(); tempSchemaName != "" {
+		scID, err := getTemporarySchemaID(ctx, p.txn, db.ID, tempSchemaName)
+		if err != nil {
+			return err
+		}
+		if scID != sqlbase.InvalidID {
+			scNames = append(scNames, tempSchemaName)
+		}
+	}
 	// Handle virtual schemas.
 	for _, schema := range p.getVirtualTabler().getEntries() {
 		scNames = append(scNames, schema.desc.Name)
 	}
 	sort.Strings(scNames)
 	for _, sc := range scNames {
-		if err := fn(sc); err != nil {
+		if err := fn(sc, scDescs[sc]); err != nil {
 			return err
 		}
 	}
@@ -1233,6 +1262,58 @@ func forEachDatabaseDesc(
 	return nil
 }
 
+// forEachFunctionDesc retrieves all user-defined function descriptors in
+// the given database on which the current user has any privilege, and calls
+// fn with each of them.
+func forEachFunctionDesc(
+	ctx context.Context,
+	p *planner,
+	dbDesc *DatabaseDescriptor,
+	fn func(*sqlbase.FunctionDescriptor) error,
+) error {
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	lCtx := newInternalLookupCtx(descs, dbDesc)
+	for _, fnID := range lCtx.fnIDs {
+		fnDesc := lCtx.fnDescs[fnID]
+		if p.CheckAnyPrivilege(ctx, fnDesc) != nil {
+			continue
+		}
+		if err := fn(fnDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachTypeDesc retrieves all user-defined type descriptors in the given
+// database on which the current user has any privilege, and calls fn with
+// each of them.
+func forEachTypeDesc(
+	ctx context.Context,
+	p *planner,
+	dbDesc *DatabaseDescriptor,
+	fn func(*sqlbase.TypeDescriptor) error,
+) error {
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	lCtx := newInternalLookupCtx(descs, dbDesc)
+	for _, typID := range lCtx.typIDs {
+		typDesc := lCtx.typDescs[typID]
+		if p.CheckAnyPrivilege(ctx, typDesc) != nil {
+			continue
+		}
+		if err := fn(typDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // forEachTableDesc retrieves all table descriptors from the current
 // database and all system databases and iterates through them. For
 // each table, the function will call fn with its respective database
@@ -1366,13 +1447,41 @@ func forEachTableDescWithTableLookupInternal(
 	}
 
 	// Physical descriptors next.
+	tempSchemaName := p.SessionData().SearchPath.GetTemporarySchemaName()
+	tempSchemaIDs := make(map[sqlbase.ID]sqlbase.ID)
 	for _, tbID := range lCtx.tbIDs {
 		table := lCtx.tbDescs[tbID]
 		dbDesc, parentExists := lCtx.dbDescs[table.GetParentID()]
 		if table.Dropped() || !userCanSeeTable(ctx, p, table, allowAdding) || !parentExists {
 			continue
 		}
-		if err := fn(dbDesc, tree.PublicSchema, table, lCtx); err != nil {
+		scName := tree.PublicSchema
+		if table.Temporary {
+			// Only the temporary tables of the current session are visible.
+			if tempSchemaName == "" {
+				continue
+			}
+			scID, ok := tempSchemaIDs[dbDesc.ID]
+			if !ok {
+				scID, err = getTemporarySchemaID(ctx, p.txn, dbDesc.ID, tempSchemaName)
+				if err != nil {
+					return err
+				}
+				tempSchemaIDs[dbDesc.ID] = scID
+			}
+			if table.UnexposedParentSchemaID != scID {
+				continue
+			}
+			scName = tempSchemaName
+		} else if scID := table.UnexposedParentSchemaID; scID != sqlbase.InvalidID {
+			sc, ok := lCtx.scDescs[scID]
+			if !ok {
+				// The schema was dropped along with the table.
+				continue
+			}
+			scName = sc.Name
+		}
+		if err := fn(dbDesc, scName, table, lCtx); err != nil {
 			return err
 		}
 	}
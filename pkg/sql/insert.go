@@ -63,6 +63,17 @@ var _ autoCommitNode = &insertNode{}
 func (p *planner) Insert(
 	ctx context.Context, n *tree.Insert, desiredTypes []types.T,
 ) (result planNode, resultErr error) {
+	if n.OnConflict != nil {
+		if n.OnConflict.Constraint != "" {
+			return nil, pgerror.UnimplementedWithIssueErrorf(28161,
+				"ON CONFLICT ON CONSTRAINT is only supported by the cost-based optimizer")
+		}
+		if n.OnConflict.ArbiterPredicate != nil {
+			return nil, pgerror.UnimplementedWithIssueErrorf(32557,
+				"ON CONFLICT ... WHERE is only supported by the cost-based optimizer")
+		}
+	}
+
 	// CTE analysis.
 	resetter, err := p.initWith(ctx, n.With)
 	if err != nil {
@@ -191,6 +202,24 @@ func (p *planner) Insert(
 		return nil, err
 	}
 
+	// BEFORE triggers can set the values of all the columns of the inserted
+	// rows, so the remaining columns are inserted into as well, with NULL
+	// values.
+	if len(desc.Triggers) > 0 && n.OnConflict == nil {
+		inserted := make(map[sqlbase.ColumnID]struct{}, len(insertCols))
+		for _, col := range insertCols {
+			inserted[col.ID] = struct{}{}
+		}
+		for _, col := range desc.WritableColumns() {
+			if _, ok := inserted[col.ID]; !ok {
+				insertCols = append(insertCols, col)
+				if defaultExprs != nil {
+					defaultExprs = append(defaultExprs, tree.DNull)
+				}
+			}
+		}
+	}
+
 	// Now create the source data plan. For this we need an AST and as
 	// list of required types. The AST comes from the Rows operand, the
 	// required types from the inserted columns.
@@ -278,6 +307,7 @@ func (p *planner) Insert(
 	if err != nil {
 		return nil, err
 	}
+	ri.DeferFKChecks(p.deferredFKChecks())
 
 	// rowsNeeded will help determine whether we need to allocate a
 	// rowsContainer.
@@ -305,12 +335,16 @@ func (p *planner) Insert(
 		}
 	} else {
 		// Regular path for INSERT.
+		triggers, err := p.newTriggerRunner(ctx, desc, sqlbase.TriggerDescriptor_INSERT)
+		if err != nil {
+			return nil, err
+		}
 		in := insertNodePool.Get().(*insertNode)
 		*in = insertNode{
 			source:  rows,
 			columns: columns,
 			run: insertRun{
-				ti:           tableInserter{ri: ri},
+				ti:           tableInserter{tableWriterBase: tableWriterBase{triggers: triggers}, ri: ri},
 				checkHelper:  checkHelper,
 				rowsNeeded:   rowsNeeded,
 				computedCols: computedCols,
@@ -486,8 +520,6 @@ func (n *insertNode) BatchedNext(params runParams) (bool, error) {
 			return false, err
 		}
 
-		n.run.rowCount++
-
 		// Are we done yet with the current batch?
 		if n.run.ti.curBatchSize() >= maxInsertBatchSize {
 			break
@@ -567,10 +599,17 @@ func (n *insertNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		}
 	}
 
+	// Fire the BEFORE triggers, if any. They can modify the row or skip its
+	// insertion.
+	if rowVals, err = n.run.ti.beforeRow(params.ctx, rowVals); err != nil || rowVals == nil {
+		return err
+	}
+
 	// Queue the insert in the KV batch.
 	if err = n.run.ti.row(params.ctx, rowVals, n.run.traceKV); err != nil {
 		return err
 	}
+	n.run.rowCount++
 
 	// If result rows need to be accumulated, do it.
 	if n.run.rows != nil {
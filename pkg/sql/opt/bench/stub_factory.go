@@ -40,6 +40,7 @@ func (f *stubFactory) ConstructScan(
 	reverse bool,
 	maxResults uint64,
 	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
@@ -114,18 +115,30 @@ func (f *stubFactory) ConstructSetOp(
 	return struct{}{}, nil
 }
 
+func (f *stubFactory) ConstructRecursiveCTE(
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, deduplicate bool,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
 func (f *stubFactory) ConstructSort(
 	input exec.Node, ordering sqlbase.ColumnOrdering,
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
 
-func (f *stubFactory) ConstructOrdinality(input exec.Node, colName string) (exec.Node, error) {
+func (f *stubFactory) ConstructOrdinality(
+	input exec.Node, colName string, partition exec.ColumnOrdinalSet,
+) (exec.Node, error) {
 	return struct{}{}, nil
 }
 
 func (f *stubFactory) ConstructIndexJoin(
-	input exec.Node, table cat.Table, cols exec.ColumnOrdinalSet, reqOrdering exec.OutputOrdering,
+	input exec.Node,
+	table cat.Table,
+	cols exec.ColumnOrdinalSet,
+	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
@@ -139,6 +152,7 @@ func (f *stubFactory) ConstructLookupJoin(
 	lookupCols exec.ColumnOrdinalSet,
 	onCond tree.TypedExpr,
 	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
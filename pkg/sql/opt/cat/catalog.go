@@ -82,6 +82,16 @@ type Catalog interface {
 	// more details.
 	ResolveDataSourceByID(ctx context.Context, id StableID) (DataSource, error)
 
+	// ResolveFunction locates a user-defined function with the given name and
+	// returns it along with the resolved FunctionName. Like for data sources,
+	// the resolved name has the ExplicitCatalog/ExplicitSchema flags set to
+	// correspond to the input name.
+	//
+	// If no such function exists, then ResolveFunction returns a nil Function
+	// and no error, so that the caller can report the error that is
+	// appropriate in its context.
+	ResolveFunction(ctx context.Context, name *FunctionName) (Function, FunctionName, error)
+
 	// CheckPrivilege verifies that the current user has the given privilege on
 	// the given catalog object. If not, then CheckPrivilege returns an error.
 	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
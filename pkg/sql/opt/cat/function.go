@@ -0,0 +1,83 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cat
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
+)
+
+// FunctionName is an alias for tree.TableName, since user-defined functions
+// live in the same namespace as tables.
+type FunctionName = tree.TableName
+
+// Function is an interface to a user-defined SQL function.
+type Function interface {
+	Object
+
+	// Name returns the fully normalized, fully qualified, and fully resolved
+	// name of the function (<db-name>.<schema-name>.<function-name>). The
+	// ExplicitCatalog and ExplicitSchema fields will always be true, since all
+	// parts of the name are always specified.
+	Name() *FunctionName
+
+	// Definition returns the definition of the function, which is used to type
+	// check calls to the function, and to evaluate the calls that are not
+	// inlined by the optimizer.
+	Definition() *tree.FunctionDefinition
+
+	// ParamCount returns the number of parameters of the function.
+	ParamCount() int
+
+	// ParamName returns the name of the ith parameter of the function, where
+	// i < ParamCount. It is empty if the parameter is unnamed.
+	ParamName(i int) string
+
+	// ParamType returns the type of the ith parameter of the function, where
+	// i < ParamCount.
+	ParamType(i int) types.T
+
+	// ReturnType returns the type of the result of the function.
+	ReturnType() types.T
+
+	// Body returns the SQL text of the function body, which is a single SELECT
+	// statement.
+	Body() string
+
+	// IsStrict returns true if the function returns NULL when any of its
+	// arguments is NULL, without evaluating its body.
+	IsStrict() bool
+}
+
+// FormatCatalogFunction nicely formats a catalog function using a treeprinter
+// for debugging and testing.
+func FormatCatalogFunction(fn Function, tp treeprinter.Node) {
+	var buf bytes.Buffer
+	for i, n := 0, fn.ParamCount(); i < n; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if name := fn.ParamName(i); name != "" {
+			buf.WriteString(name)
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(fn.ParamType(i).String())
+	}
+	child := tp.Childf("FUNCTION %s(%s) RETURNS %s", fn.Name().TableName, buf.String(), fn.ReturnType())
+	child.Childf("%s", fn.Body())
+}
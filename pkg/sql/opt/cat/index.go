@@ -117,6 +117,12 @@ type Index interface {
 	// of an outbound foreign key relation. Returns false for the second
 	// return value if there is no foreign key reference on this index.
 	ForeignKey() (ForeignKeyReference, bool)
+
+	// Predicate returns the predicate of a partial index, as a SQL expression
+	// that refers to the columns of the table by name. Only the rows that
+	// satisfy the predicate have an entry in a partial index. Returns false for
+	// the second return value if the index is not a partial index.
+	Predicate() (string, bool)
 }
 
 // IndexColumn describes a single column that is part of an index definition.
@@ -49,6 +49,25 @@ type Table interface {
 	// information_schema tables.
 	IsVirtualTable() bool
 
+	// IsMaterializedView returns true if this table stores the rows of a
+	// materialized view. Such tables are read like any other table, but are
+	// only written when the view is refreshed.
+	IsMaterializedView() bool
+
+	// HasTriggers returns true if triggers are defined on this table. The
+	// triggers of a table see whole rows, so mutations of such tables must
+	// fetch and write all of their columns.
+	HasTriggers() bool
+
+	// HasBeforeUpdateTriggers returns true if BEFORE UPDATE FOR EACH ROW
+	// triggers are defined on this table. Such triggers can modify any column
+	// of the updated rows.
+	HasBeforeUpdateTriggers() bool
+
+	// HasBeforeInsertTriggers returns true if BEFORE INSERT FOR EACH ROW
+	// triggers are defined on this table.
+	HasBeforeInsertTriggers() bool
+
 	// IsInterleaved returns true if any of this table's indexes are interleaved
 	// with index(es) from other table(s).
 	IsInterleaved() bool
@@ -276,6 +295,10 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node) {
 
 		child.Child(buf.String())
 	}
+
+	if pred, isPartial := idx.Predicate(); isPartial {
+		child.Childf("WHERE %s", pred)
+	}
 }
 
 // formatColPrefix returns a string representation of the first prefixLen columns of idx.
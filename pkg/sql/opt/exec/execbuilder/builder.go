@@ -35,6 +35,11 @@ type Builder struct {
 	// expressions we built. Each entry is associated with a tree.Subquery
 	// expression node.
 	subqueries []exec.Subquery
+
+	// workTables maps the first working table column of each recursive CTE
+	// that is being built to the node which returns the rows of its working
+	// table. See buildRecursiveCTE.
+	workTables map[opt.ColumnID]exec.Node
 }
 
 // New constructs an instance of the execution node builder using the
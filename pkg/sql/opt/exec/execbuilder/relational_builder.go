@@ -223,6 +223,12 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.SequenceSelectExpr:
 		ep, err = b.buildSequenceSelect(t)
 
+	case *memo.RecursiveCTEExpr:
+		ep, err = b.buildRecursiveCTE(t)
+
+	case *memo.WorkTableScanExpr:
+		ep, err = b.buildWorkTableScan(t)
+
 	default:
 		if opt.IsSetOp(e) {
 			ep, err = b.buildSetOp(e)
@@ -368,6 +374,7 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (execPlan, error) {
 		ordering.ScanIsReverse(scan, &scan.RequiredPhysical().Ordering),
 		b.indexConstraintMaxResults(scan),
 		res.reqOrdering(scan),
+		scan.Locking,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -697,9 +704,17 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 // extractAggregateConstArgs returns the list of constant arguments associated with a given aggregate
 // expression.
 func (b *Builder) extractAggregateConstArgs(agg opt.ScalarExpr) tree.Datums {
-	switch agg.Op() {
-	case opt.StringAggOp:
+	switch {
+	case agg.Op() == opt.StringAggOp:
 		return tree.Datums{memo.ExtractConstDatum(agg.Child(1))}
+	case opt.IsOrderedSetAggregateOp(agg):
+		// The first child is the WITHIN GROUP sort expression; the other children
+		// are constants.
+		res := make(tree.Datums, agg.ChildCount()-1)
+		for i := range res {
+			res[i] = memo.ExtractConstDatum(agg.Child(i + 1))
+		}
+		return res
 	default:
 		return nil
 	}
@@ -851,6 +866,73 @@ func (b *Builder) buildSetOp(set memo.RelExpr) (execPlan, error) {
 	return ep, nil
 }
 
+// buildRecursiveCTE builds a plan for a RecursiveCTEOp. The plan for the
+// recursive query is built anew for every iteration, using a separate Builder
+// in which the working table columns are bound to the node that returns the
+// rows produced by the previous iteration.
+func (b *Builder) buildRecursiveCTE(rec *memo.RecursiveCTEExpr) (execPlan, error) {
+	initial, err := b.buildRelational(rec.Initial)
+	if err != nil {
+		return execPlan{}, err
+	}
+	initial, err = b.ensureColumns(
+		initial, rec.InitialCols, nil /* colNames */, rec.Initial.ProvidedPhysical().Ordering,
+	)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	fn := func(workTable exec.Node) (exec.Node, error) {
+		innerBld := New(b.factory, b.mem, rec.Recursive, b.evalCtx)
+		innerBld.workTables = make(map[opt.ColumnID]exec.Node, len(b.workTables)+1)
+		for col, node := range b.workTables {
+			innerBld.workTables[col] = node
+		}
+		innerBld.workTables[rec.WorkTableCols[0]] = workTable
+
+		plan, err := innerBld.buildRelational(rec.Recursive)
+		if err != nil {
+			return nil, err
+		}
+		if len(innerBld.subqueries) > 0 {
+			return nil, pgerror.UnimplementedWithIssueErrorf(21085,
+				"subqueries are not supported in the recursive query of %q", rec.Name)
+		}
+		plan, err = innerBld.ensureColumns(
+			plan, rec.RecursiveCols, nil /* colNames */, rec.Recursive.ProvidedPhysical().Ordering,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return plan.root, nil
+	}
+
+	node, err := b.factory.ConstructRecursiveCTE(initial.root, fn, rec.Name, rec.Deduplicate)
+	if err != nil {
+		return execPlan{}, err
+	}
+	ep := execPlan{root: node}
+	for i, col := range rec.OutCols {
+		ep.outputCols.Set(int(col), i)
+	}
+	return ep, nil
+}
+
+// buildWorkTableScan builds a plan for a WorkTableScanOp, which returns the
+// rows of the working table of the enclosing recursive CTE.
+func (b *Builder) buildWorkTableScan(scan *memo.WorkTableScanExpr) (execPlan, error) {
+	node, ok := b.workTables[scan.Cols[0]]
+	if !ok {
+		return execPlan{}, pgerror.NewAssertionErrorf(
+			"working table of recursive query %q is not bound", scan.Name)
+	}
+	ep := execPlan{root: node}
+	for i, col := range scan.Cols {
+		ep.outputCols.Set(int(col), i)
+	}
+	return ep, nil
+}
+
 // buildLimitOffset builds a plan for a LimitOp or OffsetOp
 func (b *Builder) buildLimitOffset(e memo.RelExpr) (execPlan, error) {
 	input, err := b.buildRelational(e.Child(0).(memo.RelExpr))
@@ -890,8 +972,9 @@ func (b *Builder) buildRowNumber(rowNum *memo.RowNumberExpr) (execPlan, error) {
 	}
 
 	colName := b.mem.Metadata().ColumnMeta(rowNum.ColID).Alias
+	partition := input.getColumnOrdinalSet(rowNum.Partition)
 
-	node, err := b.factory.ConstructOrdinality(input.root, colName)
+	node, err := b.factory.ConstructOrdinality(input.root, colName, partition)
 	if err != nil {
 		return execPlan{}, err
 	}
@@ -937,7 +1020,7 @@ func (b *Builder) buildIndexJoin(join *memo.IndexJoinExpr) (execPlan, error) {
 	}
 
 	res.root, err = b.factory.ConstructIndexJoin(
-		input.root, md.Table(join.Table), needed, reqOrdering,
+		input.root, md.Table(join.Table), needed, reqOrdering, join.Locking,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -992,6 +1075,7 @@ func (b *Builder) buildLookupJoin(join *memo.LookupJoinExpr) (execPlan, error) {
 		lookupOrdinals,
 		onExpr,
 		res.reqOrdering(join),
+		join.Locking,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -1373,6 +1457,11 @@ func (b *Builder) canUseDeleteRange(del *memo.DeleteExpr) bool {
 		// is possible, because the integrity of those references must be checked.
 		return false
 	}
+	if tab.HasTriggers() {
+		// If the table has triggers, no fast path is possible, because the
+		// triggers must fire for each deleted row.
+		return false
+	}
 
 	// Check for simple Scan input operator without a limit; anything else is not
 	// supported by a range delete.
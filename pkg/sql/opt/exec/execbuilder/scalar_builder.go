@@ -42,24 +42,25 @@ func init() {
 	// the functions depend on scalarBuildFuncMap which in turn depends on the
 	// functions).
 	scalarBuildFuncMap = [opt.NumOperators]buildFunc{
-		opt.VariableOp:        (*Builder).buildVariable,
-		opt.ConstOp:           (*Builder).buildTypedExpr,
-		opt.NullOp:            (*Builder).buildNull,
-		opt.PlaceholderOp:     (*Builder).buildTypedExpr,
-		opt.TupleOp:           (*Builder).buildTuple,
-		opt.FunctionOp:        (*Builder).buildFunction,
-		opt.CaseOp:            (*Builder).buildCase,
-		opt.CastOp:            (*Builder).buildCast,
-		opt.CoalesceOp:        (*Builder).buildCoalesce,
-		opt.ColumnAccessOp:    (*Builder).buildColumnAccess,
-		opt.ArrayOp:           (*Builder).buildArray,
-		opt.AnyOp:             (*Builder).buildAny,
-		opt.AnyScalarOp:       (*Builder).buildAnyScalar,
-		opt.IndirectionOp:     (*Builder).buildIndirection,
-		opt.CollateOp:         (*Builder).buildCollate,
-		opt.ArrayFlattenOp:    (*Builder).buildArrayFlatten,
-		opt.IfErrOp:           (*Builder).buildIfErr,
-		opt.UnsupportedExprOp: (*Builder).buildUnsupportedExpr,
+		opt.VariableOp:            (*Builder).buildVariable,
+		opt.ConstOp:               (*Builder).buildTypedExpr,
+		opt.NullOp:                (*Builder).buildNull,
+		opt.PlaceholderOp:         (*Builder).buildTypedExpr,
+		opt.TupleOp:               (*Builder).buildTuple,
+		opt.FunctionOp:            (*Builder).buildFunction,
+		opt.UserDefinedFunctionOp: (*Builder).buildUDF,
+		opt.CaseOp:                (*Builder).buildCase,
+		opt.CastOp:                (*Builder).buildCast,
+		opt.CoalesceOp:            (*Builder).buildCoalesce,
+		opt.ColumnAccessOp:        (*Builder).buildColumnAccess,
+		opt.ArrayOp:               (*Builder).buildArray,
+		opt.AnyOp:                 (*Builder).buildAny,
+		opt.AnyScalarOp:           (*Builder).buildAnyScalar,
+		opt.IndirectionOp:         (*Builder).buildIndirection,
+		opt.CollateOp:             (*Builder).buildCollate,
+		opt.ArrayFlattenOp:        (*Builder).buildArrayFlatten,
+		opt.IfErrOp:               (*Builder).buildIfErr,
+		opt.UnsupportedExprOp:     (*Builder).buildUnsupportedExpr,
 
 		// Item operators.
 		opt.ProjectionsItemOp:  (*Builder).buildItem,
@@ -275,6 +276,38 @@ func (b *Builder) buildFunction(
 	), nil
 }
 
+// buildUDF builds a call to a user-defined function that was not inlined. The
+// function is evaluated by running its body (see the overload in the private).
+func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.TypedExpr, error) {
+	udf := scalar.(*memo.UserDefinedFunctionExpr)
+	exprs := make(tree.TypedExprs, len(udf.Args))
+	var err error
+	for i := range exprs {
+		exprs[i], err = b.buildScalar(ctx, udf.Args[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	// User-defined functions are not in the builtin registry, so they can't be
+	// looked up by name using tree.WrapFunction.
+	funcRef := tree.ResolvableFunctionReference{
+		FunctionReference: &tree.FunctionDefinition{
+			Name:               udf.Name,
+			FunctionProperties: *udf.Properties,
+		},
+	}
+	return tree.NewTypedFuncExpr(
+		funcRef,
+		0, /* aggQualifier */
+		exprs,
+		nil, /* filter */
+		nil, /* windowDef */
+		udf.Typ,
+		udf.Properties,
+		udf.Overload,
+	), nil
+}
+
 func (b *Builder) buildCase(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.TypedExpr, error) {
 	cas := scalar.(*memo.CaseExpr)
 	input, err := b.buildScalar(ctx, cas.Input)
@@ -55,6 +55,8 @@ type Factory interface {
 	//     the scan.
 	//   - If maxResults > 0, the scan is guaranteed to return at most maxResults
 	//     rows.
+	//   - If locking is not nil, the scan locks the rows that it returns with
+	//     the given row-level locking mode.
 	ConstructScan(
 		table cat.Table,
 		index cat.Index,
@@ -64,6 +66,7 @@ type Factory interface {
 		reverse bool,
 		maxResults uint64,
 		reqOrdering OutputOrdering,
+		locking *tree.LockingItem,
 	) (Node, error)
 
 	// ConstructVirtualScan returns a node that represents the scan of a virtual
@@ -151,19 +154,37 @@ type Factory interface {
 	// nodes must have the same number of columns.
 	ConstructSetOp(typ tree.UnionType, all bool, left, right Node) (Node, error)
 
+	// ConstructRecursiveCTE returns a node that implements a recursive query
+	// (WITH RECURSIVE). The initial node is run once, and its results form the
+	// first working table. The iteration function is then called repeatedly to
+	// construct a node that computes the next working table from the current
+	// one, until an iteration returns no rows. If deduplicate is set, rows that
+	// were already returned are discarded (UNION rather than UNION ALL).
+	ConstructRecursiveCTE(
+		initial Node, fn RecursiveCTEIterationFn, label string, deduplicate bool,
+	) (Node, error)
+
 	// ConstructSort returns a node that performs a resorting of the rows produced
 	// by the input node.
 	ConstructSort(input Node, ordering sqlbase.ColumnOrdering) (Node, error)
 
 	// ConstructOrdinality returns a node that appends an ordinality column to
-	// each row in the input node.
-	ConstructOrdinality(input Node, colName string) (Node, error)
+	// each row in the input node. If partition columns are specified, the rows
+	// are numbered separately for each distinct combination of values of these
+	// columns; the input must produce the rows of each partition contiguously.
+	ConstructOrdinality(input Node, colName string, partition ColumnOrdinalSet) (Node, error)
 
 	// ConstructIndexJoin returns a node that performs an index join.
 	// The input must be created by ConstructScan for the same table; cols is the
-	// set of columns produced by the index join.
+	// set of columns produced by the index join. If locking is not nil, the rows
+	// looked up in the primary index are locked with the given row-level locking
+	// mode.
 	ConstructIndexJoin(
-		input Node, table cat.Table, cols ColumnOrdinalSet, reqOrdering OutputOrdering,
+		input Node,
+		table cat.Table,
+		cols ColumnOrdinalSet,
+		reqOrdering OutputOrdering,
+		locking *tree.LockingItem,
 	) (Node, error)
 
 	// ConstructLookupJoin returns a node that preforms a lookup join.
@@ -172,7 +193,9 @@ type Factory interface {
 	// we are retrieving.
 	//
 	// The node produces the columns in the input and lookupCols (ordered by
-	// ordinal). The ON condition can refer to these using IndexedVars.
+	// ordinal). The ON condition can refer to these using IndexedVars. If
+	// locking is not nil, the looked up rows are locked with the given row-level
+	// locking mode.
 	ConstructLookupJoin(
 		joinType sqlbase.JoinType,
 		input Node,
@@ -182,6 +205,7 @@ type Factory interface {
 		lookupCols ColumnOrdinalSet,
 		onCond tree.TypedExpr,
 		reqOrdering OutputOrdering,
+		locking *tree.LockingItem,
 	) (Node, error)
 
 	// ConstructZigzagJoin returns a node that performs a zigzag join.
@@ -383,6 +407,12 @@ const (
 	SubqueryAllRows
 )
 
+// RecursiveCTEIterationFn creates a plan for an iteration of the recursive
+// query of a recursive CTE (see Factory.ConstructRecursiveCTE). The given
+// working table node returns the rows produced by the previous iteration, and
+// must be used as the data source that the recursive query refers to.
+type RecursiveCTEIterationFn func(workTable Node) (Node, error)
+
 // ColumnOrdinal is the 0-based ordinal index of a column produced by a Node.
 type ColumnOrdinal int32
 
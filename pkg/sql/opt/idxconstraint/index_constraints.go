@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 )
 
 // Convenience aliases to avoid the constraint prefix everywhere.
@@ -853,6 +854,43 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForExpr(
 			return true, append(constraints, out)
 		}
 
+	case opt.TSMatchesOp:
+		lhs, rhs := nd.Child(0), nd.Child(1)
+
+		if !c.isIndexColumn(lhs, 0 /* index */) || !opt.IsConstValueOp(rhs) {
+			c.unconstrained(0 /* offset */, out)
+			return false, append(constraints, out)
+		}
+
+		rightDatum := memo.ExtractConstDatum(rhs)
+
+		if rightDatum == tree.DNull {
+			c.contradiction(0 /* offset */, out)
+			return false, append(constraints, out)
+		}
+
+		// Every lexeme which a matching document must contain yields a span
+		// of the inverted index. Lexemes under | or ! operators, as well as
+		// prefix and weighted lexemes, cannot constrain the scan.
+		lexemes, exact := rightDatum.(*tree.DTSQuery).RequiredLexemes()
+		if len(lexemes) == 0 {
+			c.unconstrained(0 /* offset */, out)
+			return false, append(constraints, out)
+		}
+		// The spans are tight only if the query is a single lexeme.
+		tight := exact && len(lexemes) == 1
+		for i := range lexemes {
+			lexemeDatum := tree.NewDTSVector(tsearch.TSVector{{Word: lexemes[i]}})
+			c.eqSpan(0 /* offset */, lexemeDatum, out)
+			constraints = append(constraints, out)
+			if !allPaths {
+				return tight, constraints
+			}
+			// Reset out for next iteration
+			out = &constraint.Constraint{}
+		}
+		return tight, constraints
+
 	case opt.AndOp, opt.FiltersOp:
 		for i, n := 0, nd.ChildCount(); i < n; i++ {
 			tight, constraints = c.makeInvertedIndexSpansForExpr(
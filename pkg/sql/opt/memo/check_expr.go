@@ -213,6 +213,14 @@ func (m *Memo) checkExpr(e opt.Expr) {
 			panic(fmt.Sprintf("second argument to StringAggOp must always be constant, but got %s", e.Child(1).Op()))
 		}
 
+		if opt.IsOrderedSetAggregateOp(e) {
+			for i := 1; i < e.ChildCount(); i++ {
+				if !CanExtractConstDatum(e.Child(i)) {
+					panic(fmt.Sprintf("argument %d to %s must always be constant, but got %s", i+1, e.Op(), e.Child(i).Op()))
+				}
+			}
+		}
+
 		if opt.IsJoinOp(e) {
 			checkFilters(*e.Child(2).(*FiltersExpr))
 		}
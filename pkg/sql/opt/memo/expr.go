@@ -277,6 +277,11 @@ func (sf *ScanFlags) Empty() bool {
 	return !sf.NoIndexJoin && !sf.ForceIndex
 }
 
+// IsLocking returns true if the scan performs row-level locking.
+func (s *ScanPrivate) IsLocking() bool {
+	return s.Locking != nil && s.Locking.Strength != tree.ForNone
+}
+
 // NeedResults returns true if the mutation operator can return the rows that
 // were mutated.
 func (m *MutationPrivate) NeedResults() bool {
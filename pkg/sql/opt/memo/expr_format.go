@@ -17,6 +17,7 @@ package memo
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"unicode"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -179,7 +180,8 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		f.Buffer.WriteByte(')')
 
 	case *ScanExpr, *VirtualScanExpr, *IndexJoinExpr, *ShowTraceForSessionExpr,
-		*InsertExpr, *UpdateExpr, *UpsertExpr, *DeleteExpr, *SequenceSelectExpr:
+		*InsertExpr, *UpdateExpr, *UpsertExpr, *DeleteExpr, *SequenceSelectExpr,
+		*RecursiveCTEExpr, *WorkTableScanExpr:
 		fmt.Fprintf(f.Buffer, "%v", e.Op())
 		FormatPrivate(f, e.Private(), required)
 
@@ -214,6 +216,12 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		*UnionAllExpr, *IntersectAllExpr, *ExceptAllExpr:
 		colList = e.Private().(*SetPrivate).OutCols
 
+	case *RecursiveCTEExpr:
+		colList = t.OutCols
+
+	case *WorkTableScanExpr:
+		colList = t.Cols
+
 	default:
 		// Fall back to writing output columns in column id order.
 		colList = opt.ColSetToList(e.Relational().OutputCols)
@@ -253,6 +261,15 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			f.formatColList(e, tp, "right columns:", private.RightCols)
 		}
 
+	// Similarly, show the columns of the initial and recursive queries that
+	// correspond to the output columns of a recursive CTE.
+	case *RecursiveCTEExpr:
+		if !f.HasFlags(ExprFmtHideColumns) {
+			f.formatColList(e, tp, "working table columns:", t.WorkTableCols)
+			f.formatColList(e, tp, "initial columns:", t.InitialCols)
+			f.formatColList(e, tp, "recursive columns:", t.RecursiveCols)
+		}
+
 	case *ScanExpr:
 		if t.Constraint != nil {
 			tp.Childf("constraint: %s", t.Constraint)
@@ -276,6 +293,10 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 				tp.Childf("flags: force-index=%s%s", idx.Name(), dir)
 			}
 		}
+		f.formatLocking(tp, t.Locking)
+
+	case *IndexJoinExpr:
+		f.formatLocking(tp, t.Locking)
 
 	case *LookupJoinExpr:
 		idxCols := make(opt.ColList, len(t.KeyCols))
@@ -286,6 +307,7 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		if !f.HasFlags(ExprFmtHideColumns) {
 			tp.Childf("key columns: %v = %v", t.KeyCols, idxCols)
 		}
+		f.formatLocking(tp, t.Locking)
 
 	case *ZigzagJoinExpr:
 		if !f.HasFlags(ExprFmtHideColumns) {
@@ -671,6 +693,24 @@ func (f *ExprFmtCtx) formatColList(
 	}
 }
 
+// formatLocking adds a new treeprinter child describing the row-level locking
+// mode of an operator, if it performs any locking. For example:
+//
+//   locking: for-update,skip-locked
+//
+func (f *ExprFmtCtx) formatLocking(tp treeprinter.Node, locking *tree.LockingItem) {
+	if locking == nil || locking.Strength == tree.ForNone {
+		return
+	}
+	strength := strings.Replace(strings.ToLower(locking.Strength.String()), " ", "-", -1)
+	if locking.WaitPolicy == tree.LockWaitBlock {
+		tp.Childf("locking: %s", strength)
+		return
+	}
+	wait := strings.Replace(strings.ToLower(locking.WaitPolicy.String()), " ", "-", -1)
+	tp.Childf("locking: %s,%s", strength, wait)
+}
+
 // formatMutation adds a new treeprinter child for each non-zero column in the
 // given list. Each child shows how the column will be mutated, with the id of
 // the "before" and "after" columns, similar to this:
@@ -779,6 +819,15 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 		seq := f.Memo.metadata.Sequence(t.Sequence)
 		fmt.Fprintf(f.Buffer, " %s", seq.Name())
 
+	case *RecursiveCTEPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+		if !t.Deduplicate {
+			f.Buffer.WriteString(",all")
+		}
+
+	case *WorkTableScanPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *MutationPrivate:
 		fmt.Fprintf(f.Buffer, " %s", tableAlias(f, t.Table))
 
@@ -786,6 +835,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 		if !t.Ordering.Any() {
 			fmt.Fprintf(f.Buffer, " ordering=%s", t.Ordering)
 		}
+		if !t.Partition.Empty() {
+			fmt.Fprintf(f.Buffer, " partition=%s", t.Partition)
+		}
 
 	case *GroupingPrivate:
 		fmt.Fprintf(f.Buffer, " cols=%s", t.GroupingCols.String())
@@ -823,6 +875,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UDFPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *physical.OrderingChoice:
 		if !t.Any() {
 			fmt.Fprintf(f.Buffer, " ordering=%s", t)
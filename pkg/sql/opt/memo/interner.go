@@ -360,8 +360,19 @@ func (h *hasher) HashDatum(val tree.Datum) {
 		h.HashUint64(uint64(*t))
 	case *tree.DTime:
 		h.HashUint64(uint64(*t))
+	case *tree.DTimeTZ:
+		h.HashUint64(uint64(t.TimeOfDay))
+		h.HashUint64(uint64(t.OffsetSecs))
 	case *tree.DJSON:
 		h.HashString(t.String())
+	case *tree.DTSVector:
+		h.HashString(t.TSVector.String())
+	case *tree.DTSQuery:
+		h.HashString(t.TSQuery.String())
+	case *tree.DEnum:
+		// Values of different enum types can have the same encoding.
+		h.HashUint64(uint64(t.EnumTyp.TypeID))
+		h.HashBytes(t.PhysicalRep)
 	case *tree.DTuple:
 		// If labels are present, then hash of tuple's static type is needed to
 		// disambiguate when everything is the same except labels.
@@ -635,10 +646,26 @@ func (h *hasher) IsDatumEqual(l, r tree.Datum) bool {
 		if rt, ok := r.(*tree.DTime); ok {
 			return uint64(*lt) == uint64(*rt)
 		}
+	case *tree.DTimeTZ:
+		if rt, ok := r.(*tree.DTimeTZ); ok {
+			return lt.TimeOfDay == rt.TimeOfDay && lt.OffsetSecs == rt.OffsetSecs
+		}
 	case *tree.DJSON:
 		if rt, ok := r.(*tree.DJSON); ok {
 			return h.IsStringEqual(lt.String(), rt.String())
 		}
+	case *tree.DTSVector:
+		if rt, ok := r.(*tree.DTSVector); ok {
+			return h.IsStringEqual(lt.TSVector.String(), rt.TSVector.String())
+		}
+	case *tree.DTSQuery:
+		if rt, ok := r.(*tree.DTSQuery); ok {
+			return h.IsStringEqual(lt.TSQuery.String(), rt.TSQuery.String())
+		}
+	case *tree.DEnum:
+		if rt, ok := r.(*tree.DEnum); ok {
+			return lt.EnumTyp.TypeID == rt.EnumTyp.TypeID && bytes.Equal(lt.PhysicalRep, rt.PhysicalRep)
+		}
 	case *tree.DTuple:
 		if rt, ok := r.(*tree.DTuple); ok {
 			// Compare datums and then compare static types if nulls or labels
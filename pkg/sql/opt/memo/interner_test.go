@@ -38,6 +38,14 @@ func TestInterner(t *testing.T) {
 	json2, _ := tree.ParseDJSON(`{"a": 5, "b": [1, 2]}`)
 	json3, _ := tree.ParseDJSON(`[1, 2]`)
 
+	tsv1, _ := tree.ParseDTSVector(`fat:2 cat:1,3`)
+	tsv2, _ := tree.ParseDTSVector(`cat:1,3 fat:2`)
+	tsv3, _ := tree.ParseDTSVector(`cat:1,3A fat:2`)
+
+	tsq1, _ := tree.ParseDTSQuery(`fat & (rat | cat)`)
+	tsq2, _ := tree.ParseDTSQuery(`'fat' & ( 'rat' | 'cat' )`)
+	tsq3, _ := tree.ParseDTSQuery(`(fat & rat) | cat`)
+
 	tupTyp1 := types.TTuple{Types: []types.T{types.Int, types.String}, Labels: []string{"a", "b"}}
 	tupTyp2 := types.TTuple{Types: []types.T{types.Int, types.String}, Labels: []string{"a", "b"}}
 	tupTyp3 := types.TTuple{Types: []types.T{types.Int, types.String}}
@@ -194,9 +202,18 @@ func TestInterner(t *testing.T) {
 			{val1: tree.MakeDTime(timeofday.Min), val2: tree.MakeDTime(timeofday.Min), equal: true},
 			{val1: tree.MakeDTime(timeofday.Min), val2: tree.MakeDTime(timeofday.Max), equal: false},
 
+			{val1: tree.MakeDTimeTZ(timeofday.Min, 3600), val2: tree.MakeDTimeTZ(timeofday.Min, 3600), equal: true},
+			{val1: tree.MakeDTimeTZ(timeofday.Min, 3600), val2: tree.MakeDTimeTZ(timeofday.Min, 0), equal: false},
+
 			{val1: json1, val2: json2, equal: true},
 			{val1: json2, val2: json3, equal: false},
 
+			{val1: tsv1, val2: tsv2, equal: true},
+			{val1: tsv2, val2: tsv3, equal: false},
+
+			{val1: tsq1, val2: tsq2, equal: true},
+			{val1: tsq2, val2: tsq3, equal: false},
+
 			{val1: tup1, val2: tup2, equal: true},
 			{val1: tup2, val2: tup3, equal: false},
 			{val1: tup3, val2: tup4, equal: false},
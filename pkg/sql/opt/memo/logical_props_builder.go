@@ -23,7 +23,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
-	"github.com/cockroachdb/cockroach/pkg/util"
 )
 
 var fdAnnID = opt.NewTableAnnID()
@@ -641,6 +640,89 @@ func (b *logicalPropsBuilder) buildSetProps(setNode RelExpr, rel *props.Relation
 	}
 }
 
+func (b *logicalPropsBuilder) buildRecursiveCTEProps(
+	rec *RecursiveCTEExpr, rel *props.Relational,
+) {
+	BuildSharedProps(b.mem, rec, &rel.Shared)
+
+	initialProps := rec.Initial.Relational()
+	recursiveProps := rec.Recursive.Relational()
+
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	rel.OutputCols = rec.OutCols.ToSet()
+
+	// Not Null Columns
+	// ----------------
+	// Columns have to be not-null in both queries to be not-null in the result.
+	for i := range rec.OutCols {
+		if initialProps.NotNullCols.Contains(int(rec.InitialCols[i])) &&
+			recursiveProps.NotNullCols.Contains(int(rec.RecursiveCols[i])) {
+			rel.NotNullCols.Add(int(rec.OutCols[i]))
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by buildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	if rec.Deduplicate {
+		// Duplicate rows are discarded, so a strict key exists.
+		rel.FuncDeps.AddStrictKey(rel.OutputCols, rel.OutputCols)
+	}
+
+	// Cardinality
+	// -----------
+	// The recursive query can run any number of times, so the only thing that
+	// is known is that all of the rows of the initial query are returned.
+	rel.Cardinality = props.AnyCardinality.AtLeast(
+		props.Cardinality{Min: initialProps.Cardinality.Min},
+	)
+	if rec.Deduplicate {
+		rel.Cardinality = rel.Cardinality.AsLowAs(1)
+	}
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildRecursiveCTE(rec, rel)
+	}
+}
+
+func (b *logicalPropsBuilder) buildWorkTableScanProps(
+	scan *WorkTableScanExpr, rel *props.Relational,
+) {
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	rel.OutputCols = scan.Cols.ToSet()
+
+	// Not Null Columns
+	// ----------------
+	// All columns are assumed to be nullable.
+
+	// Outer Columns
+	// -------------
+	// The operator never has outer columns.
+
+	// Functional Dependencies
+	// -----------------------
+	// Nothing is known about the working table.
+
+	// Cardinality
+	// -----------
+	rel.Cardinality = props.AnyCardinality
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildWorkTableScan(rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildValuesProps(values *ValuesExpr, rel *props.Relational) {
 	BuildSharedProps(b.mem, values, &rel.Shared)
 
@@ -905,13 +987,16 @@ func (b *logicalPropsBuilder) buildRowNumberProps(rowNum *RowNumberExpr, rel *pr
 	// Functional Dependencies
 	// -----------------------
 	// Inherit functional dependencies from input, and add strict key FD for the
-	// additional key column.
+	// additional key column. If the rows are partitioned, the additional column
+	// is only a key together with the partition columns.
 	rel.FuncDeps.CopyFrom(&inputProps.FuncDeps)
 	if key, ok := rel.FuncDeps.StrictKey(); ok {
 		// Any existing keys are still keys.
 		rel.FuncDeps.AddStrictKey(key, rel.OutputCols)
 	}
-	rel.FuncDeps.AddStrictKey(util.MakeFastIntSet(int(rowNum.ColID)), rel.OutputCols)
+	key := rowNum.Partition.Copy()
+	key.Add(int(rowNum.ColID))
+	rel.FuncDeps.AddStrictKey(key, rel.OutputCols)
 
 	// Cardinality
 	// -----------
@@ -1151,6 +1236,23 @@ func BuildSharedProps(mem *Memo, e opt.Expr, shared *props.Shared) {
 			shared.CanHaveSideEffects = true
 		}
 
+	case *UserDefinedFunctionExpr:
+		if t.Properties.Impure {
+			// Impure functions can return different value on each call.
+			shared.CanHaveSideEffects = true
+		}
+		BuildSharedProps(mem, &t.Args, shared)
+
+		// The parameter columns referenced by the body are bound by the call,
+		// so they are not outer columns of the expression.
+		var body props.Shared
+		BuildSharedProps(mem, t.Body, &body)
+		shared.OuterCols.UnionWith(body.OuterCols.Difference(t.Params.ToSet()))
+		if body.CanHaveSideEffects {
+			shared.CanHaveSideEffects = true
+		}
+		return
+
 	default:
 		if opt.IsMutationOp(e) {
 			shared.CanHaveSideEffects = true
@@ -1223,6 +1325,12 @@ func makeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if _, isPartial := index.Predicate(); isPartial {
+			// Skip partial indexes, since their keys are only unique over the
+			// rows that satisfy their predicates.
+			continue
+		}
+
 		// If index has a separate lax key, add a lax key FD. Otherwise, add a
 		// strict key. See the comment for cat.Index.LaxKeyColumnCount.
 		for col := 0; col < index.LaxKeyColumnCount(); col++ {
@@ -338,7 +338,7 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.SequenceSelectOp:
 		return sb.colStatSequenceSelect(colSet, e.(*SequenceSelectExpr))
 
-	case opt.ExplainOp, opt.ShowTraceForSessionOp:
+	case opt.ExplainOp, opt.ShowTraceForSessionOp, opt.RecursiveCTEOp, opt.WorkTableScanOp:
 		relProps := e.Relational()
 		return sb.colStatLeaf(colSet, &relProps.Stats, &relProps.FuncDeps, relProps.NotNullCols)
 	}
@@ -513,6 +513,13 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 		s.ApplySelectivity(sb.selectivityFromNullCounts(cols, scan, s, inputRowCount))
 	}
 
+	// A partial index only contains the rows that satisfy its predicate. Treat
+	// each conjunct of the predicate as a filter of unknown selectivity.
+	if pred := sb.md.TableMeta(scan.Table).PartialIndexPredicate(scan.Index); pred != nil {
+		numConjuncts := float64(len(*pred.(*FiltersExpr)))
+		s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numConjuncts))
+	}
+
 	sb.finalizeFromCardinality(relProps)
 }
 
@@ -1515,6 +1522,41 @@ func (sb *statisticsBuilder) colStatSetNodeImpl(
 	return colStat
 }
 
+// +---------------+
+// | Recursive CTE |
+// +---------------+
+
+func (sb *statisticsBuilder) buildRecursiveCTE(rec *RecursiveCTEExpr, relProps *props.Relational) {
+	s := &relProps.Stats
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+
+	// The number of iterations is not known, so estimate the row count as if
+	// the recursive query ran once, like a UNION ALL.
+	initialStats := &rec.Initial.Relational().Stats
+	recursiveStats := &rec.Recursive.Relational().Stats
+	s.RowCount = initialStats.RowCount + recursiveStats.RowCount
+	sb.finalizeFromCardinality(relProps)
+}
+
+// +-----------------+
+// | Work Table Scan |
+// +-----------------+
+
+func (sb *statisticsBuilder) buildWorkTableScan(relProps *props.Relational) {
+	s := &relProps.Stats
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+
+	// The size of the working table is not known during planning.
+	s.RowCount = unknownRowCount
+	sb.finalizeFromCardinality(relProps)
+}
+
 // +--------+
 // | Values |
 // +--------+
@@ -1722,7 +1764,8 @@ func (sb *statisticsBuilder) colStatRowNumber(
 	colStat, _ := s.ColStats.Add(colSet)
 
 	if colSet.Contains(int(rowNum.ColID)) {
-		// The ordinality column is a key, so every row is distinct.
+		// The ordinality column is a key, so every row is distinct. If the rows
+		// are partitioned, this is an upper bound.
 		colStat.DistinctCount = s.RowCount
 		if colSet.Len() == 1 {
 			// The generated column is the only column being requested.
@@ -2113,6 +2156,24 @@ func countJSONPaths(conjunct *FiltersItem) int {
 	return len(paths)
 }
 
+// countTSQueryLexemes returns the number of lexemes which a document must
+// contain to match the text search query in the specified FiltersItem. Used in
+// the calculation of unapplied conjuncts in a TSMatches operator. Returns 0 if
+// the lexemes could not be counted for any reason, such as a query which does
+// not require any lexeme.
+func countTSQueryLexemes(conjunct *FiltersItem) int {
+	rhs := conjunct.Condition.Child(1)
+	if !CanExtractConstDatum(rhs) {
+		return 0
+	}
+	rd, ok := ExtractConstDatum(rhs).(*tree.DTSQuery)
+	if !ok {
+		return 0
+	}
+	lexemes, _ := rd.RequiredLexemes()
+	return len(lexemes)
+}
+
 // applyFilter uses constraints to update the distinct counts for the
 // constrained columns in the filter. The changes in the distinct counts will be
 // used later to determine the selectivity of the filter.
@@ -2159,6 +2220,20 @@ func (sb *statisticsBuilder) applyFilter(
 			return
 		}
 
+		// Special case: The current conjunct is a TSMatches operator. Like for
+		// JSON Contains, count every lexeme required by the query as a separate
+		// conjunct, since each of them results in a separate constraint on an
+		// inverted index.
+		if conjunct.Condition.Op() == opt.TSMatchesOp {
+			numLexemes := countTSQueryLexemes(conjunct)
+			if numLexemes == 0 {
+				numUnappliedConjuncts++
+			} else {
+				numUnappliedConjuncts += 2 * float64(numLexemes)
+			}
+			return
+		}
+
 		// Update constrainedCols after the above check for isEqualityWithTwoVars.
 		// We will use constrainedCols later to determine which columns to use for
 		// selectivity calculation in selectivityFromDistinctCounts, and we want to
@@ -94,8 +94,9 @@ type mdDep struct {
 	object cat.Object
 
 	// name is the unresolved name from the query that was used to resolve the
-	// object. It stores either a cat.DataSourceName, or cat.SchemaName in its
-	// TablePrefix portion, depending on the type of the object.
+	// object. It stores either a cat.DataSourceName or cat.FunctionName, or
+	// cat.SchemaName in its TablePrefix portion, depending on the type of the
+	// object.
 	name tree.TableName
 
 	// privileges is the union of all required privileges.
@@ -181,8 +182,30 @@ func (md *Metadata) AddSchemaDependency(name *cat.SchemaName, s cat.Schema, priv
 	md.deps = append(md.deps, d)
 }
 
-// CheckDependencies resolves each data source and schema on which this metadata
-// depends, in order to check that the fully qualified object names still
+// AddFunctionDependency tracks one of the user-defined functions on which the
+// query depends, as well as the privilege required to call that function. If
+// the Memo using this metadata is cached, then a call to CheckDependencies can
+// detect if the name resolves to a different function now, or if the function
+// was replaced or its permissions were changed.
+func (md *Metadata) AddFunctionDependency(
+	name *cat.FunctionName, fn cat.Function, priv privilege.Kind,
+) {
+	// Search for the same name / object pair.
+	for i := range md.deps {
+		if md.deps[i].object == fn && md.deps[i].dsName().Equals(name) {
+			md.deps[i].privileges |= (1 << priv)
+			return
+		}
+	}
+	md.deps = append(md.deps, mdDep{
+		object:     fn,
+		name:       *name,
+		privileges: (1 << priv),
+	})
+}
+
+// CheckDependencies resolves each data source, schema and function on which
+// this metadata depends, in order to check that the fully qualified object names still
 // resolve to the same version of the same objects, and that the user still has
 // sufficient privileges to access the objects. If the dependencies are no
 // longer up-to-date, then CheckDependencies returns false.
@@ -197,6 +220,21 @@ func (md *Metadata) CheckDependencies(
 		obj := md.deps[i].object
 		var toCheck cat.Object
 		switch obj.(type) {
+		case cat.Function:
+			// This case must precede the DataSource case, since functions have
+			// the same Name method as data sources.
+			name := *md.deps[i].dsName()
+			// Resolve function object.
+			new, _, err := catalog.ResolveFunction(ctx, &name)
+			if err != nil {
+				return false, err
+			}
+			if new == nil {
+				// The function no longer exists.
+				return false, nil
+			}
+			toCheck = new
+
 		case cat.DataSource:
 			name := *md.deps[i].dsName()
 			// Resolve data source object.
@@ -382,6 +382,113 @@ func (c *CustomFuncs) KeyCols(in memo.RelExpr) opt.ColSet {
 	return keyCols
 }
 
+// MakePartitionedRowNumber constructs a new RowNumberPrivate that numbers the
+// rows separately for each distinct combination of values of the partition
+// columns. Within each partition, the rows are numbered in the given ordering.
+func (c *CustomFuncs) MakePartitionedRowNumber(
+	partition opt.ColSet, ordering physical.OrderingChoice,
+) *memo.RowNumberPrivate {
+	colID := c.f.Metadata().AddColumn("rownum", types.Int)
+	return &memo.RowNumberPrivate{
+		ColID:     colID,
+		Ordering:  c.prependOrderingCols(partition, ordering),
+		Partition: partition,
+	}
+}
+
+// AddRowNumberPartition returns a new RowNumberPrivate that is a copy of the
+// given RowNumberPrivate, except that the rows are also partitioned by the
+// given columns. Each existing partition is split according to the values of
+// the new partition columns, and the rows are numbered in the same order
+// within the resulting partitions.
+func (c *CustomFuncs) AddRowNumberPartition(
+	private *memo.RowNumberPrivate, partition opt.ColSet,
+) *memo.RowNumberPrivate {
+	return &memo.RowNumberPrivate{
+		ColID:     private.ColID,
+		Ordering:  c.prependOrderingCols(partition, private.Ordering),
+		Partition: private.Partition.Union(partition),
+	}
+}
+
+// prependOrderingCols returns a copy of the given ordering, preceded by the
+// given columns in ascending order. This keeps the rows of each partition of
+// a RowNumber operator contiguous when cols are (some of) its partition
+// columns.
+func (c *CustomFuncs) prependOrderingCols(
+	cols opt.ColSet, ordering physical.OrderingChoice,
+) physical.OrderingChoice {
+	var res physical.OrderingChoice
+	cols.ForEach(func(i int) {
+		res.AppendCol(opt.ColumnID(i), false /* descending */)
+	})
+	res.Columns = append(res.Columns, ordering.Columns...)
+	res.Optional = ordering.Optional.Copy()
+	return res
+}
+
+// MakeRowNumberLimitFilter constructs a filter that only keeps the rows whose
+// number, as assigned by a RowNumber operator with the given private, does not
+// exceed the given limit.
+func (c *CustomFuncs) MakeRowNumberLimitFilter(
+	private *memo.RowNumberPrivate, limit opt.ScalarExpr,
+) memo.FiltersExpr {
+	return memo.FiltersExpr{{
+		Condition: c.f.ConstructLe(c.f.ConstructVariable(private.ColID), limit),
+	}}
+}
+
+// MakeCanaryCol returns a column of the given input expression that is never
+// NULL if there is one, or else a new column that EnsureCanary will project as
+// True. It is used to tell the rows of the input apart from the NULL-extended
+// rows of a left join.
+func (c *CustomFuncs) MakeCanaryCol(in memo.RelExpr) opt.ColumnID {
+	if id, ok := in.Relational().NotNullCols.Next(0); ok {
+		return opt.ColumnID(id)
+	}
+	return c.f.Metadata().AddColumn("canary", types.Bool)
+}
+
+// MakeLeftJoinRowNumber returns a new RowNumberPrivate that is a copy of the
+// given RowNumberPrivate partitioned by the given columns, like
+// AddRowNumberPartition, except that the rows are numbered in a new column.
+// MakeLeftJoinRowNumberProjection projects the original column from it.
+func (c *CustomFuncs) MakeLeftJoinRowNumber(
+	private *memo.RowNumberPrivate, partition opt.ColSet,
+) *memo.RowNumberPrivate {
+	newPrivate := c.AddRowNumberPartition(private, partition)
+	newPrivate.ColID = c.f.Metadata().AddColumn("rownum", types.Int)
+	return newPrivate
+}
+
+// MakeLeftJoinRowNumberProjection constructs a projection of the row number
+// column of the given original RowNumberPrivate, from the column of the new
+// RowNumberPrivate built by MakeLeftJoinRowNumber. The row number is NULL in
+// the NULL-extended rows of the left join, which are the rows where the
+// canary column is NULL.
+func (c *CustomFuncs) MakeLeftJoinRowNumberProjection(
+	private, newPrivate *memo.RowNumberPrivate, canaryCol opt.ColumnID,
+) memo.ProjectionsExpr {
+	return memo.ProjectionsExpr{{
+		Element:    c.constructCanaryChecker(c.f.ConstructVariable(canaryCol), newPrivate.ColID),
+		ColPrivate: memo.ColPrivate{Col: private.ColID},
+	}}
+}
+
+// AppendSingleRowZipItem returns a copy of the given zip with an extra item
+// that produces a single row, in a new column. A ProjectSet operator with the
+// resulting zip produces at least one row for each input row, where the
+// columns of the other items are NULL if they produce no rows.
+func (c *CustomFuncs) AppendSingleRowZipItem(zip memo.ZipExpr) memo.ZipExpr {
+	colID := c.f.Metadata().AddColumn("single_row", types.Bool)
+	newZip := make(memo.ZipExpr, len(zip), len(zip)+1)
+	copy(newZip, zip)
+	return append(newZip, memo.ZipItem{
+		Func:           memo.TrueSingleton,
+		ZipItemPrivate: memo.ZipItemPrivate{Cols: opt.ColList{colID}},
+	})
+}
+
 // NonKeyCols returns a column set consisting of the output columns of the given
 // input, minus the columns that make up its candidate key (which it must have).
 func (c *CustomFuncs) NonKeyCols(in memo.RelExpr) opt.ColSet {
@@ -26,8 +26,16 @@ import (
 // If that restriction is lifted this test can be deleted.
 func TestAllAggsIgnoreNullsOrNullOnEmpty(t *testing.T) {
 	for op := range opt.AggregateOpReverseMap {
-		if op == opt.CountRowsOp {
-			// CountRows is the only aggregate allowed to break this rule.
+		switch op {
+		case opt.CountRowsOp:
+			// CountRows is allowed to break this rule, since it is translated to
+			// Count.
+			continue
+		case opt.HypotheticalRankOp, opt.HypotheticalDenseRankOp,
+			opt.HypotheticalPercentRankOp, opt.HypotheticalCumeDistOp:
+			// The hypothetical-set aggregates also break this rule, but
+			// AggsCanBeDecorrelated prevents TranslateNonIgnoreAggs from being
+			// called with them.
 			continue
 		}
 		if !opt.AggregateIgnoresNulls(op) && !opt.AggregateIsNullOnEmpty(op) {
@@ -194,6 +194,15 @@ func (f *Factory) CopyAndReplace(
 	// can keep the same ids they had in the "from" memo.
 	f.mem.Metadata().CopyFrom(from.Memo().Metadata())
 
+	// Copy the predicates of partial indexes as well, so that they are part of
+	// this memo and can be compared with the expressions built in it.
+	md := f.mem.Metadata()
+	for _, tabMeta := range md.AllTables() {
+		md.TableMeta(tabMeta.MetaID).ReplacePartialIndexPredicates(func(e opt.ScalarExpr) opt.ScalarExpr {
+			return f.invokeReplace(e, replace).(opt.ScalarExpr)
+		})
+	}
+
 	// Perform copy and replacement, and store result as the root of this
 	// factory's memo.
 	to := f.invokeReplace(from, replace).(memo.RelExpr)
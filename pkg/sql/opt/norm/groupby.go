@@ -183,14 +183,16 @@ func (c *CustomFuncs) RemoveAggDistinctForKeys(
 }
 
 // replaceAggInputVar swaps out the aggregated variable in an aggregate with v. In
-// the case of aggregates with multiple arguments (like string_agg) the other arguments
-// are kept the same.
+// the case of aggregates with multiple arguments (like string_agg or the
+// ordered-set aggregates) the other arguments are kept the same.
 func (c *CustomFuncs) replaceAggInputVar(agg opt.ScalarExpr, v opt.ScalarExpr) opt.ScalarExpr {
 	switch agg.ChildCount() {
 	case 1:
 		return c.f.DynamicConstruct(agg.Op(), v).(opt.ScalarExpr)
 	case 2:
 		return c.f.DynamicConstruct(agg.Op(), v, agg.Child(1)).(opt.ScalarExpr)
+	case 3:
+		return c.f.DynamicConstruct(agg.Op(), v, agg.Child(1), agg.Child(2)).(opt.ScalarExpr)
 	default:
 		panic("unhandled number of aggregate children")
 	}
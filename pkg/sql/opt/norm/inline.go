@@ -213,6 +213,88 @@ func (c *CustomFuncs) CanInline(scalar opt.ScalarExpr) bool {
 	return false
 }
 
+// CanInlineUDF returns true if a call to a user-defined function with the
+// given arguments can be replaced by the body of the function. This requires
+// that:
+//
+//   1. The body of the function is a scalar expression.
+//   2. The function is not strict, unless all of the arguments are constants
+//      that are not NULL. Otherwise, the body might not evaluate to NULL when
+//      one of the arguments is NULL.
+//   3. Every argument that does not satisfy CanInline is referenced exactly
+//      one time in the body, so that it is evaluated exactly as many times as
+//      if the function were called.
+func (c *CustomFuncs) CanInlineUDF(
+	args memo.ScalarListExpr, body opt.ScalarExpr, private *memo.UDFPrivate,
+) bool {
+	if !private.HasBody {
+		return false
+	}
+	if !private.Properties.NullableArgs {
+		for _, arg := range args {
+			if !opt.IsConstValueOp(arg) || arg.Op() == opt.NullOp {
+				return false
+			}
+		}
+	}
+
+	var refs []int
+	for i, arg := range args {
+		if c.CanInline(arg) {
+			continue
+		}
+		if refs == nil {
+			refs = c.countParamRefs(body, private)
+		}
+		if refs[i] != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// countParamRefs returns the number of references to each parameter of the
+// given user-defined function in its body.
+func (c *CustomFuncs) countParamRefs(body opt.ScalarExpr, private *memo.UDFPrivate) []int {
+	refs := make([]int, len(private.Params))
+	var count func(e opt.Expr)
+	count = func(e opt.Expr) {
+		if v, ok := e.(*memo.VariableExpr); ok {
+			for i, col := range private.Params {
+				if v.Col == col {
+					refs[i]++
+				}
+			}
+			return
+		}
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			count(e.Child(i))
+		}
+	}
+	count(body)
+	return refs
+}
+
+// InlineUDF returns the body of the given user-defined function, in which
+// each reference to a parameter is replaced by the corresponding argument.
+func (c *CustomFuncs) InlineUDF(
+	args memo.ScalarListExpr, body opt.ScalarExpr, private *memo.UDFPrivate,
+) opt.ScalarExpr {
+	var replace ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if v, ok := e.(*memo.VariableExpr); ok {
+			for i, col := range private.Params {
+				if v.Col == col {
+					return args[i]
+				}
+			}
+			return v
+		}
+		return c.f.Replace(e, replace)
+	}
+	return replace(body).(opt.ScalarExpr)
+}
+
 // InlineSelectProject searches the filter conditions for any variable
 // references to columns from the given projections expression. Each variable is
 // replaced by the corresponding inlined projection expression.
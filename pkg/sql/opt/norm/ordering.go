@@ -60,11 +60,13 @@ func (c *CustomFuncs) SimplifyGroupingOrdering(
 
 // CanSimplifyRowNumberOrdering returns true if the ordering required by the
 // RowNumber operator can be made less restrictive, so that the input operator
-// has more ordering choices.
+// has more ordering choices. The ordering of partitioned row numbers is never
+// simplified, as removing a partition column from it could interleave the
+// rows of different partitions.
 func (c *CustomFuncs) CanSimplifyRowNumberOrdering(
 	in memo.RelExpr, private *memo.RowNumberPrivate,
 ) bool {
-	return c.canSimplifyOrdering(in, private.Ordering)
+	return private.Partition.Empty() && c.canSimplifyOrdering(in, private.Ordering)
 }
 
 // SimplifyRowNumberOrdering makes the ordering required by the RowNumber
@@ -0,0 +1,127 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package norm
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+)
+
+// IsPartialIndex returns true if the index with the given ordinal is a
+// partial index, i.e. if it only has entries for the rows that satisfy its
+// predicate.
+func (c *CustomFuncs) IsPartialIndex(tabID opt.TableID, indexOrd int) bool {
+	_, ok := c.mem.Metadata().Table(tabID).Index(indexOrd).Predicate()
+	return ok
+}
+
+// FiltersImplyPartialIndexPredicate returns true if every row that satisfies
+// the given filters also satisfies the predicate of the index with the given
+// ordinal, in which case a scan over the index returns all the rows that
+// satisfy the filters. It always returns true if the index is not a partial
+// index.
+func (c *CustomFuncs) FiltersImplyPartialIndexPredicate(
+	filters memo.FiltersExpr, tabID opt.TableID, indexOrd int,
+) bool {
+	if !c.IsPartialIndex(tabID, indexOrd) {
+		return true
+	}
+	pred := c.mem.Metadata().TableMeta(tabID).PartialIndexPredicate(indexOrd)
+	if pred == nil {
+		// The predicate was not built, so nothing can be proven about it.
+		return false
+	}
+	return c.FiltersImply(filters, *pred.(*memo.FiltersExpr))
+}
+
+// FiltersImply returns true if the given filters imply the given predicate,
+// i.e. if every row that satisfies the filters also satisfies the predicate.
+// FiltersImply is conservative: it returns false if it cannot prove the
+// implication. Each conjunct of the predicate is implied if either:
+//
+//   1. The filters contain the same condition. Since scalar expressions are
+//      interned by the memo, this is a pointer comparison.
+//   2. The conjunct is exactly represented by a constraint, and that
+//      constraint contains the constraint that the filters place on the same
+//      columns. For example, the filter a = 5 implies the predicate a > 0.
+//
+func (c *CustomFuncs) FiltersImply(filters, pred memo.FiltersExpr) bool {
+	var filterConstraints *constraint.Set
+	for i := range pred {
+		if c.filtersContainCondition(filters, pred[i].Condition) {
+			continue
+		}
+		if filterConstraints == nil {
+			filterConstraints = constraint.Unconstrained
+			for j := range filters {
+				filterProps := filters[j].ScalarProps(c.mem)
+				filterConstraints = filterConstraints.Intersect(c.f.evalCtx, filterProps.Constraints)
+			}
+		}
+		if !c.constraintsImplyCondition(filterConstraints, &pred[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// filtersContainCondition returns true if one of the given filters is the
+// given condition.
+func (c *CustomFuncs) filtersContainCondition(
+	filters memo.FiltersExpr, condition opt.ScalarExpr,
+) bool {
+	for i := range filters {
+		if filters[i].Condition == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintsImplyCondition returns true if the given constraint set, derived
+// from filters, implies the given condition.
+func (c *CustomFuncs) constraintsImplyCondition(
+	filterConstraints *constraint.Set, item *memo.FiltersItem,
+) bool {
+	if filterConstraints == constraint.Contradiction {
+		// No row satisfies the filters.
+		return true
+	}
+
+	predProps := item.ScalarProps(c.mem)
+	if !predProps.TightConstraints || predProps.Constraints.Length() != 1 {
+		return false
+	}
+	predConstraint := predProps.Constraints.Constraint(0)
+
+	for i, n := 0, filterConstraints.Length(); i < n; i++ {
+		filterConstraint := filterConstraints.Constraint(i)
+		if !filterConstraint.Columns.Equals(&predConstraint.Columns) {
+			continue
+		}
+		contained := true
+		for j, m := 0, filterConstraint.Spans.Count(); j < m; j++ {
+			if !predConstraint.ContainsSpan(c.f.evalCtx, filterConstraint.Spans.Get(j)) {
+				contained = false
+				break
+			}
+		}
+		if contained {
+			return true
+		}
+	}
+	return false
+}
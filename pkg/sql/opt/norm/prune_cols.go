@@ -30,9 +30,9 @@ func (c *CustomFuncs) NeededGroupingCols(private *memo.GroupingPrivate) opt.ColS
 }
 
 // NeededRowNumberCols returns the columns needed by a RowNumber operator's
-// requested ordering.
+// requested ordering or partition.
 func (c *CustomFuncs) NeededRowNumberCols(private *memo.RowNumberPrivate) opt.ColSet {
-	return private.Ordering.ColSet()
+	return private.Ordering.ColSet().Union(private.Partition)
 }
 
 // NeededExplainCols returns the columns needed by Explain's required physical
@@ -80,6 +80,16 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 	var cols opt.ColSet
 	tabMeta := c.mem.Metadata().TableMeta(private.Table)
 
+	// Triggers see whole rows, so none of the fetched columns can be pruned.
+	if tabMeta.Table.HasTriggers() {
+		for ord, col := range private.FetchCols {
+			if col != 0 {
+				cols.Add(int(tabMeta.MetaID.ColumnID(ord)))
+			}
+		}
+		return cols
+	}
+
 	// familyCols returns the columns in the given family.
 	familyCols := func(fam cat.Family) opt.ColSet {
 		var colSet opt.ColSet
@@ -114,6 +124,12 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		// Make sure to consider indexes that are being added or dropped.
 		for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
 			indexCols := tabMeta.IndexColumns(i)
+
+			// A row can enter or leave a partial index when the columns referenced
+			// by its predicate are updated, so treat them as index columns.
+			if pred := tabMeta.PartialIndexPredicate(i); pred != nil {
+				indexCols.UnionWith(pred.(*memo.FiltersExpr).OuterCols(c.mem))
+			}
 			if !indexCols.Intersects(updateCols) {
 				// This index is not being updated.
 				continue
@@ -152,6 +168,12 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		// or dropped.
 		for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
 			cols.UnionWith(tabMeta.IndexKeyColumns(i))
+
+			// The columns referenced by the predicate of a partial index are
+			// needed to determine whether the row has an entry in the index.
+			if pred := tabMeta.PartialIndexPredicate(i); pred != nil {
+				cols.UnionWith(pred.(*memo.FiltersExpr).OuterCols(c.mem))
+			}
 		}
 	}
 
@@ -422,12 +444,13 @@ func DerivePruneCols(e memo.RelExpr) opt.ColSet {
 
 	case opt.RowNumberOp:
 		// Any pruneable input columns can potentially be pruned, as long as
-		// they're not used as an ordering column. The new row number column
-		// cannot be pruned without adding an additional Project operator, so
-		// don't add it to the set.
+		// they're not used as an ordering or partition column. The new row
+		// number column cannot be pruned without adding an additional Project
+		// operator, so don't add it to the set.
 		rowNum := e.(*memo.RowNumberExpr)
 		inputPruneCols := DerivePruneCols(rowNum.Input)
 		relProps.Rule.PruneCols = inputPruneCols.Difference(rowNum.Ordering.ColSet())
+		relProps.Rule.PruneCols.DifferenceWith(rowNum.Partition)
 
 	case opt.IndexJoinOp, opt.LookupJoinOp:
 		// There is no need to prune columns projected by Index or Lookup joins,
@@ -140,6 +140,7 @@ var ComparisonOpReverseMap = map[Operator]tree.ComparisonOperator{
 	JsonExistsOp:     tree.JSONExists,
 	JsonSomeExistsOp: tree.JSONSomeExists,
 	JsonAllExistsOp:  tree.JSONAllExists,
+	TSMatchesOp:      tree.TSMatches,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -195,6 +196,14 @@ var AggregateOpReverseMap = map[Operator]string{
 	ConstAggOp:        "any_not_null",
 	ConstNotNullAggOp: "any_not_null",
 	AnyNotNullAggOp:   "any_not_null",
+
+	PercentileDiscOp:          "percentile_disc_impl",
+	PercentileContOp:          "percentile_cont_impl",
+	ModeOp:                    "mode_impl",
+	HypotheticalRankOp:        "rank_impl",
+	HypotheticalDenseRankOp:   "dense_rank_impl",
+	HypotheticalPercentRankOp: "percent_rank_impl",
+	HypotheticalCumeDistOp:    "cume_dist_impl",
 }
 
 // NegateOpMap maps from a comparison operator type to its negated operator
@@ -254,7 +263,7 @@ func AggregateIgnoresNulls(op Operator) bool {
 	switch op {
 	case AvgOp, BoolAndOp, BoolOrOp, CountOp, MaxOp, MinOp, SumIntOp, SumOp,
 		SqrDiffOp, VarianceOp, StdDevOp, XorAggOp, ConstNotNullAggOp,
-		AnyNotNullAggOp, StringAggOp:
+		AnyNotNullAggOp, StringAggOp, PercentileDiscOp, PercentileContOp, ModeOp:
 		return true
 	}
 	return false
@@ -269,7 +278,8 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 	switch op {
 	case AvgOp, BoolAndOp, BoolOrOp, MaxOp, MinOp, SumIntOp, SumOp, SqrDiffOp,
 		VarianceOp, StdDevOp, XorAggOp, ConstAggOp, ConstNotNullAggOp, ArrayAggOp,
-		ConcatAggOp, JsonAggOp, JsonbAggOp, AnyNotNullAggOp, StringAggOp:
+		ConcatAggOp, JsonAggOp, JsonbAggOp, AnyNotNullAggOp, StringAggOp,
+		PercentileDiscOp, PercentileContOp, ModeOp:
 		return true
 	}
 	return false
@@ -97,6 +97,14 @@ type Builder struct {
 	// are referenced multiple times in the same query.
 	views map[cat.View]*tree.Select
 
+	// udfs maps the definitions of the user-defined functions referenced by
+	// the query to the corresponding catalog functions.
+	udfs map[*tree.FunctionDefinition]cat.Function
+
+	// udfStack contains the user-defined functions whose bodies are currently
+	// being built. It is used to detect recursive functions.
+	udfStack []cat.Function
+
 	// subquery contains a pointer to the subquery which is currently being built
 	// (if any).
 	subquery *subquery
@@ -197,10 +205,10 @@ func (b *Builder) buildStmt(stmt tree.Statement, inScope *scope) (outScope *scop
 		return b.buildInsert(stmt, inScope)
 
 	case *tree.ParenSelect:
-		return b.buildSelect(stmt.Select, nil /* desiredTypes */, inScope)
+		return b.buildSelect(stmt.Select, noRowLocking, nil /* desiredTypes */, inScope)
 
 	case *tree.Select:
-		return b.buildSelect(stmt, nil /* desiredTypes */, inScope)
+		return b.buildSelect(stmt, noRowLocking, nil /* desiredTypes */, inScope)
 
 	case *tree.ShowTraceForSession:
 		return b.buildShowTrace(stmt, inScope)
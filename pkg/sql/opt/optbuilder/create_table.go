@@ -43,7 +43,7 @@ func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outSco
 	var inputCols opt.ColList
 	if ct.As() {
 		// Build the input query.
-		outScope := b.buildSelect(ct.AsSource, nil /* desiredTypes */, inScope)
+		outScope := b.buildSelect(ct.AsSource, noRowLocking, nil /* desiredTypes */, inScope)
 
 		numColNames := len(ct.AsColumnNames)
 		numColumns := len(outScope.cols)
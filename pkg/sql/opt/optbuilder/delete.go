@@ -25,7 +25,8 @@ import (
 // buildDelete builds a memo group for a DeleteOp expression, which deletes all
 // rows projected by the input expression. All columns from the deletion table
 // are projected, including mutation columns (the optimizer may later prune the
-// columns if they are not needed).
+// columns if they are not needed). Tables in the USING clause are joined with
+// the deletion table, and each matching row is deleted once.
 //
 // Note that the ORDER BY clause can only be used if the LIMIT clause is also
 // present. In that case, the ordering determines which rows are included by the
@@ -43,7 +44,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	}
 
 	if del.With != nil {
-		inScope = b.buildCTE(del.With.CTEList, inScope)
+		inScope = b.buildCTE(del.With, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
@@ -71,7 +72,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	//   ORDER BY <order-by> LIMIT <limit>
 	//
 	// All columns from the delete table will be projected.
-	mb.buildInputForUpdateOrDelete(inScope, del.Where, del.Limit, del.OrderBy)
+	mb.buildInputForUpdateOrDelete(inScope, del.Using, del.Where, del.Limit, del.OrderBy)
 
 	// Build the final delete statement, including any returned expressions.
 	if resultsNeeded(del.Returning) {
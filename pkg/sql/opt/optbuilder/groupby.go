@@ -464,8 +464,24 @@ func (b *Builder) buildAggregateFunction(
 	b.subquery = nil
 	defer func() { b.subquery = subq }()
 
-	for i, pexpr := range f.Exprs {
-		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, inScope)
+	if f.AggType == tree.OrderedSetAgg {
+		// The WITHIN GROUP sort expression provides the values of an ordered-set
+		// aggregate, so it becomes the first argument. It is followed by the
+		// direct arguments, and by the direction of the ordering.
+		order := f.OrderBy[0]
+		info.args = make(memo.ScalarListExpr, 0, len(f.Exprs)+2)
+		info.args = append(info.args,
+			b.buildAggArg(order.Expr.(tree.TypedExpr), &info, tempScope, inScope))
+		for _, pexpr := range f.Exprs {
+			info.args = append(info.args,
+				b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, inScope))
+		}
+		desc := tree.MakeDBool(tree.DBool(order.Direction == tree.Descending))
+		info.args = append(info.args, b.buildAggArg(desc, &info, tempScope, inScope))
+	} else {
+		for i, pexpr := range f.Exprs {
+			info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, inScope)
+		}
 	}
 
 	// If we have a filter, add it to tempScope after all the arguments. We'll
@@ -549,10 +565,42 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 			})
 		}
 		return b.factory.ConstructStringAgg(args[0], args[1])
+	case "percentile_disc":
+		b.checkOrderedSetArgs(name, args)
+		return b.factory.ConstructPercentileDisc(args[0], args[1], args[2])
+	case "percentile_cont":
+		b.checkOrderedSetArgs(name, args)
+		return b.factory.ConstructPercentileCont(args[0], args[1], args[2])
+	case "mode":
+		return b.factory.ConstructMode(args[0], args[1])
+	case "rank":
+		b.checkOrderedSetArgs(name, args)
+		return b.factory.ConstructHypotheticalRank(args[0], args[1], args[2])
+	case "dense_rank":
+		b.checkOrderedSetArgs(name, args)
+		return b.factory.ConstructHypotheticalDenseRank(args[0], args[1], args[2])
+	case "percent_rank":
+		b.checkOrderedSetArgs(name, args)
+		return b.factory.ConstructHypotheticalPercentRank(args[0], args[1], args[2])
+	case "cume_dist":
+		b.checkOrderedSetArgs(name, args)
+		return b.factory.ConstructHypotheticalCumeDist(args[0], args[1], args[2])
 	}
 	panic(fmt.Sprintf("unhandled aggregate: %s", name))
 }
 
+// checkOrderedSetArgs checks that the direct arguments of an ordered-set
+// aggregate are constant. The values to aggregate are in args[0], and the
+// direction of the WITHIN GROUP ordering is always constant.
+func (b *Builder) checkOrderedSetArgs(name string, args []opt.ScalarExpr) {
+	for _, arg := range args[1:] {
+		if !memo.CanExtractConstDatum(arg) {
+			panic(builderError{pgerror.UnimplementedWithIssueErrorf(28417,
+				"non-constant direct arguments of %s are not supported", name)})
+		}
+	}
+}
+
 func isAggregate(def *tree.FunctionDefinition) bool {
 	return def.Class == tree.AggregateClass
 }
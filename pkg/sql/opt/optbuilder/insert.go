@@ -141,7 +141,7 @@ func init() {
 // and thereby scrambles the input ordering.
 func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope) {
 	if ins.With != nil {
-		inScope = b.buildCTE(ins.With.CTEList, inScope)
+		inScope = b.buildCTE(ins.With, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
@@ -243,6 +243,13 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 
 	// Case 2: INSERT..ON CONFLICT DO NOTHING.
 	case ins.OnConflict.DoNothing:
+		// Conflicts are detected before the rows are written, so BEFORE INSERT
+		// triggers could not affect them. See newUpsertTriggerRunner.
+		if mb.tab.HasBeforeInsertTriggers() {
+			panic(builderError{pgerror.UnimplementedWithIssueErrorf(28296,
+				"BEFORE INSERT row-level triggers are not supported with UPSERT or INSERT ... ON CONFLICT")})
+		}
+
 		// Wrap the input in one LEFT OUTER JOIN per UNIQUE index, and filter out
 		// rows that have conflicts. See the buildInputForDoNothing comment for
 		// more details.
@@ -263,7 +270,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		if mb.needExistingRows() {
 			// Left-join each input row to the target table, using conflict columns
 			// derived from the primary index as the join condition.
-			mb.buildInputForUpsert(inScope, mb.getPrimaryKeyColumnNames(), nil /* whereClause */)
+			mb.buildInputForUpsert(inScope, cat.PrimaryIndex, nil /* whereClause */)
 
 			// Add additional columns for computed expressions that may depend on any
 			// updated columns.
@@ -275,9 +282,15 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 
 	// Case 4: INSERT..ON CONFLICT..DO UPDATE statement.
 	default:
-		// Left-join each input row to the target table, using the conflict columns
-		// as the join condition.
-		mb.buildInputForUpsert(inScope, ins.OnConflict.Columns, ins.OnConflict.Where)
+		// Check that the ON CONFLICT target references at most one target row by
+		// ensuring it matches a UNIQUE index. Using LEFT OUTER JOIN to detect
+		// conflicts relies upon this being true (otherwise result cardinality
+		// could increase). This is also a Postgres requirement.
+		conflictOrd := mb.findArbiterIndex(ins.OnConflict)
+
+		// Left-join each input row to the target table, using the columns of the
+		// arbiter index as the join condition.
+		mb.buildInputForUpsert(inScope, conflictOrd, ins.OnConflict.Where)
 
 		// Derive the columns that will be updated from the SET expressions.
 		mb.addTargetColsForUpdate(ins.OnConflict.Exprs)
@@ -306,6 +319,8 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //   2. All non-key columns (including mutation columns) have insert and update
 //      values specified for them.
 //   3. Each update value is the same as the corresponding insert value.
+//   4. There are no triggers on the table, which need to know whether each
+//      row is inserted or updated.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -313,7 +328,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 // of edge cases (that caused real correctness bugs #13437 #13962). As a result,
 // this support was removed and needs to re-enabled. See #14482.
 func (mb *mutationBuilder) needExistingRows() bool {
-	if mb.tab.DeletableIndexCount() > 1 {
+	if mb.tab.DeletableIndexCount() > 1 || mb.tab.HasTriggers() {
 		return true
 	}
 
@@ -525,7 +540,7 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 		}
 	}
 
-	mb.outScope = mb.b.buildSelect(inputRows, desiredTypes, inScope)
+	mb.outScope = mb.b.buildSelect(inputRows, noRowLocking, desiredTypes, inScope)
 
 	if len(mb.targetColList) != 0 {
 		// Target columns already exist, so ensure that the number of input
@@ -600,14 +615,14 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 // column to see if it was null-extended by the left join). See the comment
 // header for Builder.buildInsert for an example.
 func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tree.OnConflict) {
-	// DO NOTHING clause does not require ON CONFLICT columns.
-	var conflictIndex cat.Index
-	if len(onConflict.Columns) != 0 {
-		// Check that the ON CONFLICT columns reference at most one target row by
-		// ensuring they match columns of a UNIQUE index. Using LEFT OUTER JOIN
-		// to detect conflicts relies upon this being true (otherwise result
-		// cardinality could increase). This is also a Postgres requirement.
-		conflictIndex = mb.ensureUniqueConflictCols(onConflict.Columns)
+	// DO NOTHING clause does not require an ON CONFLICT target.
+	conflictOrd := -1
+	if onConflict.Constraint != "" || len(onConflict.Columns) != 0 {
+		// Check that the ON CONFLICT target references at most one target row by
+		// ensuring it matches a UNIQUE index. Using LEFT OUTER JOIN to detect
+		// conflicts relies upon this being true (otherwise result cardinality
+		// could increase). This is also a Postgres requirement.
+		conflictOrd = mb.findArbiterIndex(onConflict)
 	}
 
 	insertColSet := mb.outScope.expr.Relational().OutputCols
@@ -620,9 +635,9 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 			continue
 		}
 
-		// If conflict columns were explicitly specified, then only check for a
+		// If a conflict target was explicitly specified, then only check for a
 		// conflict on a single index. Otherwise, check on all indexes.
-		if conflictIndex != nil && conflictIndex != index {
+		if conflictOrd != -1 && conflictOrd != idx {
 			continue
 		}
 
@@ -636,6 +651,7 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 			tabID,
 			nil, /* ordinals */
 			nil, /* indexFlags */
+			noRowLocking,
 			excludeMutations,
 			inScope,
 		)
@@ -663,6 +679,13 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 			on = append(on, memo.FiltersItem{Condition: condition})
 		}
 
+		// A partial index only conflicts with existing rows that satisfy its
+		// predicate, and only if the inserted row satisfies it as well.
+		if _, isPartial := index.Predicate(); isPartial {
+			on = append(on, *mb.md.TableMeta(tabID).PartialIndexPredicate(idx).(*memo.FiltersExpr)...)
+			on = append(on, mb.buildInsertPartialIndexPredicate(idx)...)
+		}
+
 		// Construct the left join + filter.
 		// TODO(andyk): Convert this to use anti-join once we have support for
 		// lookup anti-joins.
@@ -689,21 +712,41 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 	mb.targetColSet = opt.ColSet{}
 }
 
+// buildInsertPartialIndexPredicate builds the predicate of the partial index
+// with the given ordinal over the insert columns, so that it can be used to
+// determine whether an inserted row has an entry in the index.
+func (mb *mutationBuilder) buildInsertPartialIndexPredicate(indexOrd int) memo.FiltersExpr {
+	return mb.b.buildPartialIndexPredicate(mb.md.TableMeta(mb.tabID), indexOrd, mb.insertColsScope())
+}
+
+// insertColsScope returns a scope in which the names of the columns of the
+// target table are resolved to the insert columns.
+func (mb *mutationBuilder) insertColsScope() *scope {
+	predScope := mb.b.allocScope()
+	predScope.cols = make([]scopeColumn, 0, len(mb.insertColList))
+	for ord, colID := range mb.insertColList {
+		if colID == 0 {
+			continue
+		}
+		col := mb.tab.Column(ord)
+		predScope.cols = append(predScope.cols, scopeColumn{
+			id:   colID,
+			name: col.ColName(),
+			typ:  col.DatumType(),
+		})
+	}
+	return predScope
+}
+
 // buildInputForUpsert assumes that the output scope already contains the insert
-// columns. It left-joins each insert row to the target table, using the given
-// conflict columns as the join condition. It also selects one of the table
-// columns to be a "canary column" that can be tested to determine whether a
-// given insert row conflicts with an existing row in the table. If it is null,
-// then there is no conflict.
+// columns. It left-joins each insert row to the target table, using the key
+// columns of the given UNIQUE index as the join condition. It also selects one
+// of the table columns to be a "canary column" that can be tested to determine
+// whether a given insert row conflicts with an existing row in the table. If it
+// is null, then there is no conflict.
 func (mb *mutationBuilder) buildInputForUpsert(
-	inScope *scope, conflictCols tree.NameList, whereClause *tree.Where,
+	inScope *scope, conflictOrd int, whereClause *tree.Where,
 ) {
-	// Check that the ON CONFLICT columns reference at most one target row.
-	// Using LEFT OUTER JOIN to detect conflicts relies upon this being true
-	// (otherwise result cardinality could increase). This is also a Postgres
-	// requirement.
-	mb.ensureUniqueConflictCols(conflictCols)
-
 	// Re-alias all INSERT columns so that they are accessible as if they were
 	// part of a special data source named "crdb_internal.excluded".
 	for i := range mb.outScope.cols {
@@ -718,6 +761,7 @@ func (mb *mutationBuilder) buildInputForUpsert(
 		inputTabID,
 		nil, /* ordinals */
 		nil, /* indexFlags */
+		noRowLocking,
 		includeMutations,
 		inScope,
 	)
@@ -744,19 +788,22 @@ func (mb *mutationBuilder) buildInputForUpsert(
 	//
 	//   ON ins.x = scan.a AND ins.y = scan.b
 	//
+	conflictIndex := mb.tab.Index(conflictOrd)
 	var on memo.FiltersExpr
-	for _, name := range conflictCols {
-		for i := range fetchScope.cols {
-			fetchCol := &fetchScope.cols[i]
-			if fetchCol.name == name {
-				condition := mb.b.factory.ConstructEq(
-					mb.b.factory.ConstructVariable(mb.insertColList[i]),
-					mb.b.factory.ConstructVariable(fetchCol.id),
-				)
-				on = append(on, memo.FiltersItem{Condition: condition})
-				break
-			}
-		}
+	for i, n := 0, conflictIndex.LaxKeyColumnCount(); i < n; i++ {
+		ord := conflictIndex.Column(i).Ordinal
+		condition := mb.b.factory.ConstructEq(
+			mb.b.factory.ConstructVariable(mb.insertColList[ord]),
+			mb.b.factory.ConstructVariable(fetchScope.cols[ord].id),
+		)
+		on = append(on, memo.FiltersItem{Condition: condition})
+	}
+
+	// A partial index only conflicts with existing rows that satisfy its
+	// predicate, and only if the inserted row satisfies it as well.
+	if _, isPartial := conflictIndex.Predicate(); isPartial {
+		on = append(on, *mb.md.TableMeta(inputTabID).PartialIndexPredicate(conflictOrd).(*memo.FiltersExpr)...)
+		on = append(on, mb.buildInsertPartialIndexPredicate(conflictOrd)...)
 	}
 
 	// Construct the left join.
@@ -961,11 +1008,48 @@ func (mb *mutationBuilder) projectUpsertColumns() {
 	mb.outScope = projectionsScope
 }
 
-// ensureUniqueConflictCols tries to prove that the given list of column names
-// correspond to the columns of at least one UNIQUE index on the target table.
-// If true, then ensureUniqueConflictCols returns the matching index. Otherwise,
-// it reports an error.
-func (mb *mutationBuilder) ensureUniqueConflictCols(cols tree.NameList) cat.Index {
+// findArbiterIndex returns the ordinal of the UNIQUE index on the target table
+// that is used to detect conflicts for the given ON CONFLICT clause, which is
+// known as the arbiter index. The arbiter index is either:
+//
+//   1. The unique index with the name given by ON CONSTRAINT, which cannot
+//      be a partial index.
+//   2. A unique index whose key columns match the ON CONFLICT columns. A
+//      partial unique index only matches if the arbiter predicate given by
+//      the WHERE clause implies its predicate, since it only ensures
+//      uniqueness over the rows that satisfy its predicate:
+//
+//        CREATE UNIQUE INDEX ON abc (a) WHERE b > 0
+//        INSERT INTO abc VALUES (1, 2, 3) ON CONFLICT (a) WHERE b > 0 DO NOTHING
+//
+//      Non-partial indexes are preferred over partial indexes.
+//
+// If no such index exists, findArbiterIndex reports an error.
+func (mb *mutationBuilder) findArbiterIndex(onConflict *tree.OnConflict) int {
+	if onConflict.Constraint != "" {
+		for idx, idxCount := 0, mb.tab.IndexCount(); idx < idxCount; idx++ {
+			index := mb.tab.Index(idx)
+			if !index.IsUnique() || index.Name() != onConflict.Constraint {
+				continue
+			}
+			// A partial unique index only ensures uniqueness over the rows that
+			// satisfy its predicate, so it is not a constraint that can be named.
+			if _, isPartial := index.Predicate(); isPartial {
+				panic(builderError{pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+					"ON CONFLICT ON CONSTRAINT cannot use the partial unique index %q",
+					tree.ErrString(&onConflict.Constraint),
+				).SetHintf("Specify the index columns and predicate with ON CONFLICT (...) WHERE ...")})
+			}
+			return idx
+		}
+		panic(builderError{pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+			"constraint %q for table %q does not exist",
+			tree.ErrString(&onConflict.Constraint), tree.ErrString(&mb.tab.Name().TableName))})
+	}
+
+	cols := onConflict.Columns
+	var arbiterPred memo.FiltersExpr
+	partialOrd := -1
 	for idx, idxCount := 0, mb.tab.IndexCount(); idx < idxCount; idx++ {
 		index := mb.tab.Index(idx)
 
@@ -984,22 +1068,40 @@ func (mb *mutationBuilder) ensureUniqueConflictCols(cols tree.NameList) cat.Inde
 				break
 			}
 		}
+		if !found {
+			continue
+		}
 
-		if found {
-			return index
+		if _, isPartial := index.Predicate(); !isPartial {
+			return idx
+		}
+
+		// A partial index can only be used if the arbiter predicate implies its
+		// predicate. Both predicates are built over the insert columns so that
+		// they can be compared.
+		if partialOrd != -1 || onConflict.ArbiterPredicate == nil {
+			continue
+		}
+		if arbiterPred == nil {
+			arbiterPred = mb.buildArbiterPredicate(onConflict.ArbiterPredicate)
+		}
+		indexPred := mb.buildInsertPartialIndexPredicate(idx)
+		if mb.b.factory.CustomFuncs().FiltersImply(arbiterPred, indexPred) {
+			partialOrd = idx
 		}
 	}
+	if partialOrd != -1 {
+		return partialOrd
+	}
 	panic(builderError{errors.New(
 		"there is no unique or exclusion constraint matching the ON CONFLICT specification")})
 }
 
-// getPrimaryKeyColumnNames returns the names of all primary key columns in the
-// target table.
-func (mb *mutationBuilder) getPrimaryKeyColumnNames() tree.NameList {
-	pkIndex := mb.tab.Index(cat.PrimaryIndex)
-	names := make(tree.NameList, pkIndex.KeyColumnCount())
-	for i, n := 0, pkIndex.KeyColumnCount(); i < n; i++ {
-		names[i] = pkIndex.Column(i).ColName()
-	}
-	return names
+// buildArbiterPredicate builds the WHERE clause of an ON CONFLICT target as a
+// list of filters over the insert columns.
+func (mb *mutationBuilder) buildArbiterPredicate(pred tree.Expr) memo.FiltersExpr {
+	predScope := mb.insertColsScope()
+	texpr := predScope.resolveAndRequireType(pred, types.Bool)
+	scalar := mb.b.buildScalar(texpr, predScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	return mb.b.factory.CustomFuncs().SimplifyFilters(memo.FiltersExpr{{Condition: scalar}})
 }
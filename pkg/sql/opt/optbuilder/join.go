@@ -31,21 +31,34 @@ import (
 //
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
-func (b *Builder) buildJoin(join *tree.JoinTableExpr, inScope *scope) (outScope *scope) {
-	leftScope := b.buildDataSource(join.Left, nil /* indexFlags */, inScope)
-	rightScope := b.buildDataSource(join.Right, nil /* indexFlags */, inScope)
+func (b *Builder) buildJoin(
+	join *tree.JoinTableExpr, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
+	leftScope := b.buildDataSource(join.Left, nil /* indexFlags */, locking, inScope)
+
+	// A LATERAL right side is built in the scope of the left side, so that it
+	// can refer to its columns.
+	joinType := sqlbase.JoinTypeFromAstString(join.Join)
+	lateral := isLateral(join.Right)
+	rightInScope := inScope
+	if lateral {
+		if joinType != sqlbase.InnerJoin && joinType != sqlbase.LeftOuterJoin {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeInvalidColumnReferenceError,
+				"the combining JOIN type must be INNER or LEFT for a LATERAL reference")})
+		}
+		rightInScope = leftScope
+	}
+	rightScope := b.buildDataSource(join.Right, nil /* indexFlags */, locking, rightInScope)
 
 	// Check that the same table name is not used on both sides.
 	b.validateJoinTableNames(leftScope, rightScope)
 
-	joinType := sqlbase.JoinTypeFromAstString(join.Join)
-
 	switch cond := join.Cond.(type) {
 	case tree.NaturalJoinCond, *tree.UsingJoinCond:
 		outScope = inScope.push()
 
 		var jb usingJoinBuilder
-		jb.init(b, joinType, leftScope, rightScope, outScope)
+		jb.init(b, joinType, lateral, leftScope, rightScope, outScope)
 
 		switch t := cond.(type) {
 		case tree.NaturalJoinCond:
@@ -76,7 +89,7 @@ func (b *Builder) buildJoin(join *tree.JoinTableExpr, inScope *scope) (outScope
 
 		left := leftScope.expr.(memo.RelExpr)
 		right := rightScope.expr.(memo.RelExpr)
-		outScope.expr = b.constructJoin(joinType, left, right, filters)
+		outScope.expr = b.constructJoin(joinType, left, right, filters, lateral)
 		return outScope
 
 	default:
@@ -140,12 +153,18 @@ func (b *Builder) findJoinColsToValidate(scope *scope) util.FastIntSet {
 }
 
 func (b *Builder) constructJoin(
-	joinType sqlbase.JoinType, left, right memo.RelExpr, on memo.FiltersExpr,
+	joinType sqlbase.JoinType, left, right memo.RelExpr, on memo.FiltersExpr, lateral bool,
 ) memo.RelExpr {
 	switch joinType {
 	case sqlbase.InnerJoin:
+		if lateral {
+			return b.factory.ConstructInnerJoinApply(left, right, on)
+		}
 		return b.factory.ConstructInnerJoin(left, right, on)
 	case sqlbase.LeftOuterJoin:
+		if lateral {
+			return b.factory.ConstructLeftJoinApply(left, right, on)
+		}
 		return b.factory.ConstructLeftJoin(left, right, on)
 	case sqlbase.RightOuterJoin:
 		return b.factory.ConstructRightJoin(left, right, on)
@@ -220,6 +239,7 @@ func (b *Builder) constructJoin(
 type usingJoinBuilder struct {
 	b          *Builder
 	joinType   sqlbase.JoinType
+	lateral    bool
 	filters    memo.FiltersExpr
 	leftScope  *scope
 	rightScope *scope
@@ -239,10 +259,11 @@ type usingJoinBuilder struct {
 }
 
 func (jb *usingJoinBuilder) init(
-	b *Builder, joinType sqlbase.JoinType, leftScope, rightScope, outScope *scope,
+	b *Builder, joinType sqlbase.JoinType, lateral bool, leftScope, rightScope, outScope *scope,
 ) {
 	jb.b = b
 	jb.joinType = joinType
+	jb.lateral = lateral
 	jb.leftScope = leftScope
 	jb.rightScope = rightScope
 	jb.outScope = outScope
@@ -313,6 +334,7 @@ func (jb *usingJoinBuilder) finishBuild() {
 		jb.leftScope.expr.(memo.RelExpr),
 		jb.rightScope.expr.(memo.RelExpr),
 		jb.filters,
+		jb.lateral,
 	)
 
 	if !jb.ifNullCols.Empty() {
@@ -0,0 +1,186 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// lockingSpec maintains a collection of FOR [KEY] UPDATE/SHARE items that
+// apply to a given scope. Locking clauses are added to the lockingSpec as they
+// come into scope in the AST, and are filtered as the builder descends into the
+// data sources of the FROM clause. Once a table is reached, the lockingSpec is
+// consulted through its get method to determine how the table's rows should be
+// locked.
+type lockingSpec []*tree.LockingItem
+
+// noRowLocking indicates that no row-level locking has been specified.
+var noRowLocking lockingSpec
+
+// isSet returns whether the spec contains any locking items.
+func (lm lockingSpec) isSet() bool {
+	return len(lm) != 0
+}
+
+// strength returns the strength of the first locking item in the spec. It is
+// used to name the locking clause in error messages, like Postgres does.
+func (lm lockingSpec) strength() tree.LockingStrength {
+	return lm[0].Strength
+}
+
+// apply returns a new lockingSpec that contains the items of the spec followed
+// by the items of the given locking clause.
+func (lm lockingSpec) apply(locking tree.LockingClause) lockingSpec {
+	if len(locking) == 0 {
+		return lm
+	}
+	ret := make(lockingSpec, 0, len(lm)+len(locking))
+	ret = append(ret, lm...)
+	return append(ret, locking...)
+}
+
+// filter returns the locking items that apply to the data source with the
+// given name. Items without targets apply to every data source. The returned
+// items never have targets, so that further filtering within the data source
+// (for example, of the tables referenced by a view or a subquery) keeps them.
+func (lm lockingSpec) filter(name tree.Name) lockingSpec {
+	var ret lockingSpec
+	for _, li := range lm {
+		if len(li.Targets) == 0 {
+			ret = append(ret, li)
+			continue
+		}
+		for i := range li.Targets {
+			if li.Targets[i].TableName == name {
+				ret = append(ret, &tree.LockingItem{
+					Strength:   li.Strength,
+					WaitPolicy: li.WaitPolicy,
+				})
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// get returns the single locking item that results from combining all of the
+// items in the spec, or nil if the spec is empty. When multiple items apply to
+// the same table, the strongest strength and the strictest wait policy win.
+func (lm lockingSpec) get() *tree.LockingItem {
+	switch len(lm) {
+	case 0:
+		return nil
+	case 1:
+		if len(lm[0].Targets) == 0 {
+			return lm[0]
+		}
+	}
+	var ret tree.LockingItem
+	for _, li := range lm {
+		ret.Strength = ret.Strength.Max(li.Strength)
+		ret.WaitPolicy = ret.WaitPolicy.Max(li.WaitPolicy)
+	}
+	return &ret
+}
+
+// checkLockingVersion raises an error if the given locking clause is used
+// before all nodes in the cluster have been upgraded to a version that
+// understands locking scans. Older nodes ignore the locking strength and the
+// wait policy of the requests they evaluate, so they would neither acquire the
+// locks nor respect NOWAIT or SKIP LOCKED.
+func (b *Builder) checkLockingVersion(locking tree.LockingClause) {
+	if len(locking) == 0 || b.evalCtx.Settings.Version.IsActive(cluster.VersionSelectForUpdate) {
+		return
+	}
+	panic(builderError{pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+		"%s requires all nodes to be upgraded to %s",
+		locking[0].Strength, cluster.VersionByKey(cluster.VersionSelectForUpdate))})
+}
+
+// lockingNotAllowedError returns the error raised when a locking clause is
+// applied to a query construct that doesn't identify individual table rows.
+func lockingNotAllowedError(locking lockingSpec, construct string) error {
+	return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+		"%s is not allowed with %s", locking.strength(), construct)
+}
+
+// validateLockingInSelectClause ensures that the given locking clause can be
+// applied to the given SELECT clause. Postgres does not permit row-level
+// locking on the result of DISTINCT or grouping operations, since the rows it
+// returns don't correspond to individual table rows. It also ensures that
+// each of the targets of the locking clause is a data source in the FROM
+// clause.
+func (b *Builder) validateLockingInSelectClause(sel *tree.SelectClause, locking lockingSpec) {
+	if !locking.isSet() {
+		return
+	}
+	if sel.Distinct {
+		panic(builderError{lockingNotAllowedError(locking, "DISTINCT clause")})
+	}
+	if len(sel.GroupBy) > 0 {
+		panic(builderError{lockingNotAllowedError(locking, "GROUP BY clause")})
+	}
+	if sel.Having != nil {
+		panic(builderError{lockingNotAllowedError(locking, "HAVING clause")})
+	}
+
+	var names []tree.Name
+	for _, texpr := range sel.From.Tables {
+		names = collectDataSourceNames(texpr, names)
+	}
+	for _, li := range locking {
+		for i := range li.Targets {
+			target := &li.Targets[i]
+			found := false
+			for _, name := range names {
+				if target.TableName == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				panic(builderError{pgerror.NewErrorf(pgerror.CodeUndefinedTableError,
+					"relation %q in %s clause not found in FROM clause",
+					string(target.TableName), li.Strength)})
+			}
+		}
+	}
+}
+
+// collectDataSourceNames appends the names by which the data sources in the
+// given table expression can be referenced to the given slice, and returns the
+// result.
+func collectDataSourceNames(texpr tree.TableExpr, names []tree.Name) []tree.Name {
+	switch t := texpr.(type) {
+	case *tree.AliasedTableExpr:
+		if t.As.Alias != "" {
+			return append(names, t.As.Alias)
+		}
+		return collectDataSourceNames(t.Expr, names)
+
+	case *tree.JoinTableExpr:
+		names = collectDataSourceNames(t.Left, names)
+		return collectDataSourceNames(t.Right, names)
+
+	case *tree.ParenTableExpr:
+		return collectDataSourceNames(t.Expr, names)
+
+	case *tree.TableName:
+		return append(names, t.TableName)
+	}
+	return names
+}
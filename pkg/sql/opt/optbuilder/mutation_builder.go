@@ -127,21 +127,32 @@ func (mb *mutationBuilder) init(b *Builder, op opt.Operator, tab cat.Table, alia
 
 	// Add the table and its columns (including mutation columns) to metadata.
 	mb.tabID = mb.md.AddTableWithAlias(tab, &mb.alias)
+
+	// Build the predicates of partial indexes over the columns of the target
+	// table, so that the columns they reference are known when determining the
+	// columns that need to be fetched.
+	b.addPartialIndexPredicatesForTable(mb.md.TableMeta(mb.tabID))
 }
 
 // buildInputForUpdateOrDelete constructs a Select expression from the fields in
 // the Update or Delete operator, similar to this:
 //
 //   SELECT <cols>
-//   FROM <table>
+//   FROM <table>, <from>
 //   WHERE <where>
 //   ORDER BY <order-by>
 //   LIMIT <limit>
 //
+// The <from> tables are given by the FROM clause of an UPDATE or the USING
+// clause of a DELETE. Their columns are projected after the columns of the
+// table, and can be referenced by the WHERE clause, as well as by the SET
+// expressions of an UPDATE. See buildDistinctOnTargetRows for how rows of the
+// table that match multiple rows of the <from> tables are handled.
+//
 // All columns from the table to update are added to fetchColList.
 // TODO(andyk): Do needed column analysis to project fewer columns if possible.
 func (mb *mutationBuilder) buildInputForUpdateOrDelete(
-	inScope *scope, where *tree.Where, limit *tree.Limit, orderBy tree.OrderBy,
+	inScope *scope, from tree.TableExprs, where *tree.Where, limit *tree.Limit, orderBy tree.OrderBy,
 ) {
 	// Fetch columns from different instance of the table metadata, so that it's
 	// possible to remap columns, as in this example:
@@ -155,13 +166,36 @@ func (mb *mutationBuilder) buildInputForUpdateOrDelete(
 		inputTabID,
 		nil, /* ordinals */
 		nil, /* indexFlags */
+		noRowLocking,
 		includeMutations,
 		inScope,
 	)
 
+	// FROM / USING
+	var fromScope *scope
+	if len(from) > 0 {
+		// The tables cannot reference the columns of the table being mutated.
+		fromScope = mb.b.buildFromTables(from, noRowLocking, inScope)
+		mb.b.validateJoinTableNames(mb.outScope, fromScope)
+
+		joinScope := inScope.push()
+		joinScope.appendColumnsFromScope(mb.outScope)
+		joinScope.appendColumnsFromScope(fromScope)
+		joinScope.expr = mb.b.factory.ConstructInnerJoin(
+			mb.outScope.expr.(memo.RelExpr),
+			fromScope.expr.(memo.RelExpr),
+			memo.TrueFilter,
+		)
+		mb.outScope = joinScope
+	}
+
 	// WHERE
 	mb.b.buildWhere(where, mb.outScope)
 
+	if fromScope != nil {
+		mb.buildDistinctOnTargetRows(inputTabID, fromScope)
+	}
+
 	// SELECT + ORDER BY (which may add projected expressions)
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
@@ -178,11 +212,66 @@ func (mb *mutationBuilder) buildInputForUpdateOrDelete(
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.fetchColList = make(opt.ColList, mb.tab.DeletableColumnCount())
-	for i := range mb.outScope.cols {
+	for i := range mb.fetchColList {
 		mb.fetchColList[i] = mb.outScope.cols[i].id
 	}
 }
 
+// buildDistinctOnTargetRows wraps the input expression of an Update or Delete
+// operator with FROM or USING tables in a DistinctOn operator, so that each row
+// of the target table is mutated at most once, even if it matches multiple rows
+// of the FROM or USING tables. The DistinctOn operator groups the rows by the
+// primary key of the target table.
+//
+// When a row of an UPDATE matches multiple rows, the values of the first
+// matching row in the order of the key columns of the FROM tables (or of all
+// their orderable columns if they have no key) are used, so that the new
+// values do not depend on the plan chosen by the optimizer. If each row of the
+// target table is known to match at most one row, the DistinctOn operator is
+// eliminated by normalization rules.
+func (mb *mutationBuilder) buildDistinctOnTargetRows(inputTabID opt.TableID, fromScope *scope) {
+	var private memo.GroupingPrivate
+	primary := mb.tab.Index(cat.PrimaryIndex)
+	for i, n := 0, primary.KeyColumnCount(); i < n; i++ {
+		private.GroupingCols.Add(int(inputTabID.ColumnID(primary.Column(i).Ordinal)))
+	}
+
+	// A DELETE only uses the values of the target table, which are the same for
+	// all the matching rows.
+	if mb.op == opt.UpdateOp {
+		orderingCols, ok := fromScope.expr.(memo.RelExpr).Relational().FuncDeps.StrictKey()
+		if !ok {
+			orderingCols = opt.ColSet{}
+			for i := range fromScope.cols {
+				col := &fromScope.cols[i]
+				if _, isArray := col.typ.(types.TArray); !isArray && col.typ != types.JSON {
+					orderingCols.Add(int(col.id))
+				}
+			}
+		}
+		orderingCols.ForEach(func(i int) {
+			private.Ordering.AppendCol(opt.ColumnID(i), false /* descending */)
+		})
+	}
+
+	// Build FirstAgg for all the columns except the grouping columns (and
+	// eliminate duplicates).
+	aggs := make(memo.AggregationsExpr, 0, len(mb.outScope.cols))
+	excluded := private.GroupingCols.Copy()
+	for i := range mb.outScope.cols {
+		if id := mb.outScope.cols[i].id; !excluded.Contains(int(id)) {
+			excluded.Add(int(id))
+			aggs = append(aggs, memo.AggregationsItem{
+				Agg:        mb.b.factory.ConstructFirstAgg(mb.b.factory.ConstructVariable(id)),
+				ColPrivate: memo.ColPrivate{Col: id},
+			})
+		}
+	}
+
+	input := mb.outScope.expr.(memo.RelExpr)
+	mb.outScope.expr = mb.b.factory.ConstructDistinctOn(input, aggs, &private)
+}
+
 // addTargetColsByName adds one target column for each of the names in the given
 // list.
 func (mb *mutationBuilder) addTargetColsByName(names tree.NameList) {
@@ -0,0 +1,72 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/pkg/errors"
+)
+
+// addPartialIndexPredicatesForTable builds the predicates of the partial
+// indexes of the given table, including indexes that are being added or
+// dropped, and stores them in the table metadata. Each predicate is built as a
+// list of filters over the columns of the table, so that the optimizer can
+// determine whether the filters of a query imply it.
+func (b *Builder) addPartialIndexPredicatesForTable(tabMeta *opt.TableMeta) {
+	tab := tabMeta.Table
+	var predScope *scope
+	for i, n := 0, tab.DeletableIndexCount(); i < n; i++ {
+		if _, ok := tab.Index(i).Predicate(); !ok {
+			continue
+		}
+		if predScope == nil {
+			predScope = b.allocScope()
+			predScope.cols = make([]scopeColumn, 0, tab.DeletableColumnCount())
+			for ord, cnt := 0, tab.DeletableColumnCount(); ord < cnt; ord++ {
+				col := tab.Column(ord)
+				predScope.cols = append(predScope.cols, scopeColumn{
+					id:    tabMeta.MetaID.ColumnID(ord),
+					name:  col.ColName(),
+					table: tabMeta.Alias,
+					typ:   col.DatumType(),
+				})
+			}
+		}
+		filters := b.buildPartialIndexPredicate(tabMeta, i, predScope)
+		tabMeta.AddPartialIndexPredicate(i, &filters)
+	}
+}
+
+// buildPartialIndexPredicate builds the predicate of the partial index with
+// the given ordinal as a list of filters. The columns of the table are
+// resolved in the given scope.
+func (b *Builder) buildPartialIndexPredicate(
+	tabMeta *opt.TableMeta, indexOrd int, predScope *scope,
+) memo.FiltersExpr {
+	index := tabMeta.Table.Index(indexOrd)
+	pred, _ := index.Predicate()
+	expr, err := parser.ParseExpr(pred)
+	if err != nil {
+		wrapped := errors.Wrapf(err, "failed to parse predicate of index %q", index.Name())
+		panic(builderError{wrapped})
+	}
+
+	texpr := predScope.resolveAndRequireType(expr, types.Bool)
+	scalar := b.buildScalar(texpr, predScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	return b.factory.CustomFuncs().SimplifyFilters(memo.FiltersExpr{{Condition: scalar}})
+}
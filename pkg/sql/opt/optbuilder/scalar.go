@@ -447,6 +447,11 @@ func (b *Builder) buildFunction(
 		args[i] = b.buildScalar(pexpr.(tree.TypedExpr), inScope, nil, nil, colRefs)
 	}
 
+	if fn, ok := b.udfs[def]; ok {
+		out = b.buildUDF(f, def, fn, args)
+		return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+	}
+
 	// Construct a private FuncOpDef that refers to a resolved function overload.
 	out = b.factory.ConstructFunction(args, &memo.FunctionPrivate{
 		Name:       def.Name,
@@ -609,6 +614,13 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructJsonAllExists(left, right)
 	case tree.JSONSomeExists:
 		return b.factory.ConstructJsonSomeExists(left, right)
+	case tree.TSMatches:
+		// The operator is commutative; normalize it so that the tsvector is on
+		// the left, which allows the left input to constrain inverted indexes.
+		if left.DataType() == types.TSQuery {
+			return b.factory.ConstructTSMatches(right, left)
+		}
+		return b.factory.ConstructTSMatches(left, right)
 	}
 	panic(fmt.Sprintf("unhandled comparison operator: %s", cmp))
 }
@@ -711,7 +723,20 @@ func NewScalar(
 
 // Build a memo structure from a TypedExpr: the root group represents a scalar
 // expression equivalent to expr.
-func (sb *ScalarBuilder) Build(expr tree.TypedExpr) (err error) {
+func (sb *ScalarBuilder) Build(expr tree.TypedExpr) error {
+	scalar, err := sb.BuildExpr(expr)
+	if err != nil {
+		return err
+	}
+	sb.factory.Memo().SetScalarRoot(scalar)
+	return nil
+}
+
+// BuildExpr builds a scalar expression equivalent to expr in the memo and
+// returns it, without making it the root of the memo. It can be used to build
+// expressions that are compared with the root, since expressions built in the
+// same memo are interned.
+func (sb *ScalarBuilder) BuildExpr(expr tree.TypedExpr) (_ opt.ScalarExpr, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			// This code allows us to propagate builder errors without adding
@@ -726,7 +751,5 @@ func (sb *ScalarBuilder) Build(expr tree.TypedExpr) (err error) {
 		}
 	}()
 
-	scalar := sb.buildScalar(expr, &sb.scope, nil, nil, nil)
-	sb.factory.Memo().SetScalarRoot(scalar)
-	return nil
+	return sb.buildScalar(expr, &sb.scope, nil, nil, nil), nil
 }
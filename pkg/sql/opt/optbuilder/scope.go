@@ -810,10 +810,8 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			panic(unimplementedf("window functions are not supported"))
 		}
 
-		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
-		if err != nil {
-			panic(builderError{err})
-		}
+		t, def := s.builder.resolveFunction(t)
+		expr = t
 
 		if isGenerator(def) && s.replaceSRFs {
 			expr = s.replaceSRF(t, def)
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/pkg/errors"
 )
@@ -41,10 +42,15 @@ const (
 // including two for the left and right table scans, at least one for the join
 // condition, and one for the join itself.
 //
+// The locking spec holds the row-level locking clauses that are in scope.
+// Locking clauses that target the data source by name, along with those that
+// target no data source in particular, are applied to all tables scanned by
+// the data source.
+//
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildDataSource(
-	texpr tree.TableExpr, indexFlags *tree.IndexFlags, inScope *scope,
+	texpr tree.TableExpr, indexFlags *tree.IndexFlags, locking lockingSpec, inScope *scope,
 ) (outScope *scope) {
 	// NB: The case statements are sorted lexicographically.
 	switch source := texpr.(type) {
@@ -52,8 +58,11 @@ func (b *Builder) buildDataSource(
 		if source.IndexFlags != nil {
 			indexFlags = source.IndexFlags
 		}
+		if source.As.Alias != "" {
+			locking = locking.filter(source.As.Alias)
+		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, inScope)
+		outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality("ordinality", outScope)
@@ -65,7 +74,7 @@ func (b *Builder) buildDataSource(
 		return outScope
 
 	case *tree.JoinTableExpr:
-		return b.buildJoin(source, inScope)
+		return b.buildJoin(source, locking, inScope)
 
 	case *tree.TableName:
 		tn := source
@@ -87,12 +96,25 @@ func (b *Builder) buildDataSource(
 		}
 
 		ds, resName := b.resolveDataSource(tn, privilege.SELECT)
+
+		locking = locking.filter(tn.TableName)
+		if locking.isSet() {
+			// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
+			// As with SELECT privileges, they are only checked on the view and not
+			// on the tables that it references.
+			if !b.skipSelectPrivilegeChecks {
+				b.checkPrivilege(tn, ds, privilege.UPDATE)
+			}
+		}
+
 		switch t := ds.(type) {
 		case cat.Table:
 			tabID := b.factory.Metadata().AddTableWithAlias(t, &resName)
-			return b.buildScan(tabID, nil /* ordinals */, indexFlags, excludeMutations, inScope)
+			return b.buildScan(
+				tabID, nil /* ordinals */, indexFlags, locking, excludeMutations, inScope,
+			)
 		case cat.View:
-			return b.buildView(t, inScope)
+			return b.buildView(t, locking, inScope)
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, inScope)
 		default:
@@ -100,13 +122,13 @@ func (b *Builder) buildDataSource(
 		}
 
 	case *tree.ParenTableExpr:
-		return b.buildDataSource(source.Expr, indexFlags, inScope)
+		return b.buildDataSource(source.Expr, indexFlags, locking, inScope)
 
 	case *tree.RowsFromExpr:
 		return b.buildZip(source.Items, inScope)
 
 	case *tree.Subquery:
-		outScope = b.buildStmt(source.Select, inScope)
+		outScope = b.buildSelectStmt(source.Select, locking, nil /* desiredTypes */, inScope)
 
 		// Treat the subquery result as an anonymous data source (i.e. column names
 		// are not qualified). Remove hidden columns, as they are not accessible
@@ -126,9 +148,20 @@ func (b *Builder) buildDataSource(
 
 	case *tree.TableRef:
 		ds := b.resolveDataSourceRef(source, privilege.SELECT)
+
+		if source.As.Alias != "" {
+			locking = locking.filter(source.As.Alias)
+		} else {
+			locking = locking.filter(ds.Name().TableName)
+		}
+		if locking.isSet() {
+			// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
+			b.checkPrivilege(ds.Name(), ds, privilege.UPDATE)
+		}
+
 		switch t := ds.(type) {
 		case cat.Table:
-			outScope = b.buildScanFromTableRef(t, source, indexFlags, inScope)
+			outScope = b.buildScanFromTableRef(t, source, indexFlags, locking, inScope)
 		default:
 			panic(unimplementedf("view and sequence numeric refs are not supported"))
 		}
@@ -141,7 +174,11 @@ func (b *Builder) buildDataSource(
 }
 
 // buildView parses the view query text and builds it as a Select expression.
-func (b *Builder) buildView(view cat.View, inScope *scope) (outScope *scope) {
+// Any row-level locking that applies to the view is applied to the tables that
+// it references.
+func (b *Builder) buildView(
+	view cat.View, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
 	// Cache the AST so that multiple references won't need to reparse.
 	if b.views == nil {
 		b.views = make(map[cat.View]*tree.Select)
@@ -174,7 +211,7 @@ func (b *Builder) buildView(view cat.View, inScope *scope) (outScope *scope) {
 		defer func() { b.skipSelectPrivilegeChecks = false }()
 	}
 
-	outScope = b.buildSelect(sel, nil /* desiredTypes */, &scope{builder: b})
+	outScope = b.buildSelect(sel, locking, nil /* desiredTypes */, &scope{builder: b})
 
 	// Update data source name to be the name of the view. And if view columns
 	// are specified, then update names of output columns.
@@ -255,7 +292,11 @@ func (b *Builder) renameSource(as tree.AliasClause, scope *scope) {
 // Note, the query SELECT * FROM [53() as t] is unsupported. Column lists must
 // be non-empty
 func (b *Builder) buildScanFromTableRef(
-	tab cat.Table, ref *tree.TableRef, indexFlags *tree.IndexFlags, inScope *scope,
+	tab cat.Table,
+	ref *tree.TableRef,
+	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
+	inScope *scope,
 ) (outScope *scope) {
 	if ref.Columns != nil && len(ref.Columns) == 0 {
 		panic(builderError{pgerror.NewErrorf(pgerror.CodeSyntaxError,
@@ -288,7 +329,7 @@ func (b *Builder) buildScanFromTableRef(
 	}
 
 	tabID := b.factory.Metadata().AddTable(tab)
-	return b.buildScan(tabID, ordinals, indexFlags, excludeMutations, inScope)
+	return b.buildScan(tabID, ordinals, indexFlags, locking, excludeMutations, inScope)
 }
 
 // buildScan builds a memo group for a ScanOp or VirtualScanOp expression on the
@@ -300,12 +341,16 @@ func (b *Builder) buildScanFromTableRef(
 // list are projected by the scan. Otherwise, all columns from the table are
 // projected.
 //
+// If the locking spec is set, then the scan locks the rows that it returns
+// using the combined strength and wait policy of the spec's locking items.
+//
 // See Builder.buildStmt for a description of the remaining input and return
 // values.
 func (b *Builder) buildScan(
 	tabID opt.TableID,
 	ordinals []int,
 	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
 	scanMutationCols bool,
 	inScope *scope,
 ) (outScope *scope) {
@@ -352,10 +397,15 @@ func (b *Builder) buildScan(
 		if indexFlags != nil {
 			panic(builderError{errors.Errorf("index flags not allowed with virtual tables")})
 		}
+		if locking.isSet() {
+			panic(builderError{lockingNotAllowedError(locking, "virtual tables")})
+		}
 		private := memo.VirtualScanPrivate{Table: tabID, Cols: tabColIDs}
 		outScope.expr = b.factory.ConstructVirtualScan(&private)
 	} else {
-		private := memo.ScanPrivate{Table: tabID, Cols: tabColIDs}
+		b.addPartialIndexPredicatesForTable(tabMeta)
+
+		private := memo.ScanPrivate{Table: tabID, Cols: tabColIDs, Locking: locking.get()}
 
 		if indexFlags != nil {
 			private.Flags.NoIndexJoin = indexFlags.NoIndexJoin
@@ -439,12 +489,18 @@ func (b *Builder) buildWithOrdinality(colName string, inScope *scope) (outScope
 	return inScope
 }
 
-func (b *Builder) buildCTE(ctes []*tree.CTE, inScope *scope) (outScope *scope) {
+func (b *Builder) buildCTE(with *tree.With, inScope *scope) (outScope *scope) {
 	outScope = inScope.push()
 
+	ctes := with.CTEList
 	outScope.ctes = make(map[string]*cteSource)
 	for i := range ctes {
-		cteScope := b.buildStmt(ctes[i].Stmt, outScope)
+		var cteScope *scope
+		if with.Recursive {
+			cteScope = b.buildRecursiveCTE(ctes[i], outScope)
+		} else {
+			cteScope = b.buildStmt(ctes[i].Stmt, outScope)
+		}
 		cols := cteScope.cols
 		name := ctes[i].Name.Alias
 
@@ -489,6 +545,137 @@ func (b *Builder) buildCTE(ctes []*tree.CTE, inScope *scope) (outScope *scope) {
 	return outScope
 }
 
+// buildRecursiveCTE builds a CTE defined in a WITH RECURSIVE clause. If the
+// CTE has the form:
+//
+//   <initial query> UNION [ALL] <recursive query>
+//
+// and the recursive query refers to the CTE itself, a RecursiveCTE operator is
+// built. The self-reference is built as a WorkTableScan, which returns the rows
+// produced by the previous iteration of the recursive query. Otherwise, the CTE
+// is built like a regular CTE.
+func (b *Builder) buildRecursiveCTE(cte *tree.CTE, inScope *scope) (outScope *scope) {
+	clause, ok := recursiveCTEUnion(cte.Stmt)
+	if !ok {
+		return b.buildStmt(cte.Stmt, inScope)
+	}
+
+	initialScope := b.buildSelect(clause.Left, noRowLocking, nil /* desiredTypes */, inScope)
+	initialScope.removeHiddenCols()
+
+	name := cte.Name.Alias
+	if cte.Name.Cols != nil && len(cte.Name.Cols) != len(initialScope.cols) {
+		panic(builderError{fmt.Errorf(
+			"source %q has %d columns available but %d columns specified",
+			name, len(initialScope.cols), len(cte.Name.Cols),
+		)})
+	}
+
+	// Synthesize the columns of the working table, which take the names given
+	// by the CTE alias (if any).
+	workTableScope := inScope.push()
+	tableName := tree.MakeUnqualifiedTableName(name)
+	desiredTypes := make([]types.T, len(initialScope.cols))
+	for i := range initialScope.cols {
+		col := &initialScope.cols[i]
+		colName := col.name
+		if cte.Name.Cols != nil {
+			colName = cte.Name.Cols[i]
+		}
+		workTableCol := b.synthesizeColumn(workTableScope, string(colName), col.typ, nil, nil /* scalar */)
+		workTableCol.table = tableName
+		desiredTypes[i] = col.typ
+	}
+	workTableCols := colsToColList(workTableScope.cols)
+	workTableScope.expr = b.factory.ConstructWorkTableScan(&memo.WorkTableScanPrivate{
+		Name: string(name),
+		Cols: workTableCols,
+	})
+
+	// Build the recursive query in a scope where the CTE name refers to the
+	// working table.
+	recursiveInScope := inScope.push()
+	workTable := &cteSource{
+		name: cte.Name,
+		cols: workTableScope.cols,
+		expr: workTableScope.expr,
+	}
+	recursiveInScope.ctes = map[string]*cteSource{name.String(): workTable}
+	recursiveScope := b.buildSelect(clause.Right, noRowLocking, desiredTypes, recursiveInScope)
+	recursiveScope.removeHiddenCols()
+
+	if !workTable.used {
+		// The CTE does not refer to itself.
+		return b.buildSetOp(clause.Type, clause.All, initialScope, recursiveScope, inScope)
+	}
+
+	if len(initialScope.cols) != len(recursiveScope.cols) {
+		panic(builderError{pgerror.NewErrorf(
+			pgerror.CodeSyntaxError,
+			"each %v query must have the same number of columns: %d vs %d",
+			clause.Type, len(initialScope.cols), len(recursiveScope.cols),
+		)})
+	}
+	for i := range initialScope.cols {
+		l := &initialScope.cols[i]
+		r := &recursiveScope.cols[i]
+		if !(l.typ.Equivalent(r.typ) || r.typ == types.Unknown) {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeDatatypeMismatchError,
+				"recursive query %q column %d has type %s in non-recursive term but type %s overall",
+				tree.ErrString(&name), i+1, l.typ, r.typ)})
+		}
+	}
+
+	recursive := recursiveScope.expr.(memo.RelExpr)
+	if recursive.Relational().CanMutate {
+		panic(builderError{pgerror.UnimplementedWithIssueErrorf(21085,
+			"recursive query %q cannot contain data-modifying statements", tree.ErrString(&name))})
+	}
+
+	outScope = inScope.push()
+	for i := range initialScope.cols {
+		col := &initialScope.cols[i]
+		b.synthesizeColumn(outScope, string(col.name), col.typ, nil, nil /* scalar */)
+	}
+	outScope.expr = b.factory.ConstructRecursiveCTE(
+		initialScope.expr.(memo.RelExpr),
+		recursive,
+		&memo.RecursiveCTEPrivate{
+			Name:          string(name),
+			WorkTableCols: workTableCols,
+			InitialCols:   colsToColList(initialScope.cols),
+			RecursiveCols: colsToColList(recursiveScope.cols),
+			OutCols:       colsToColList(outScope.cols),
+			Deduplicate:   !clause.All,
+		},
+	)
+	return outScope
+}
+
+// recursiveCTEUnion returns the UNION clause of the given CTE statement if
+// the statement has the form of a recursive query.
+func recursiveCTEUnion(stmt tree.Statement) (*tree.UnionClause, bool) {
+	sel, ok := stmt.(*tree.Select)
+	if !ok {
+		return nil, false
+	}
+	for {
+		if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+			return nil, false
+		}
+		paren, ok := sel.Select.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+	}
+	clause, ok := sel.Select.(*tree.UnionClause)
+	if !ok || clause.Type != tree.UnionOp {
+		return nil, false
+	}
+	return clause, true
+}
+
 // checkCTEUsage ensures that a CTE that contains a mutation (like INSERT) is
 // used at least once by the query. Otherwise, it might not be executed.
 func (b *Builder) checkCTEUsage(inScope *scope) {
@@ -506,20 +693,27 @@ func (b *Builder) checkCTEUsage(inScope *scope) {
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelectStmt(
-	stmt tree.SelectStatement, desiredTypes []types.T, inScope *scope,
+	stmt tree.SelectStatement, locking lockingSpec, desiredTypes []types.T, inScope *scope,
 ) (outScope *scope) {
 	// NB: The case statements are sorted lexicographically.
 	switch stmt := stmt.(type) {
 	case *tree.ParenSelect:
-		return b.buildSelect(stmt.Select, desiredTypes, inScope)
+		return b.buildSelect(stmt.Select, locking, desiredTypes, inScope)
 
 	case *tree.SelectClause:
-		return b.buildSelectClause(stmt, nil /* orderBy */, desiredTypes, inScope)
+		return b.buildSelectClause(stmt, nil /* orderBy */, locking, desiredTypes, inScope)
 
 	case *tree.UnionClause:
+		if locking.isSet() {
+			panic(builderError{lockingNotAllowedError(locking, "UNION/INTERSECT/EXCEPT")})
+		}
 		return b.buildUnion(stmt, desiredTypes, inScope)
 
 	case *tree.ValuesClause:
+		if locking.isSet() {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"%s cannot be applied to VALUES", locking.strength())})
+		}
 		return b.buildValuesClause(stmt, desiredTypes, inScope)
 
 	default:
@@ -530,15 +724,22 @@ func (b *Builder) buildSelectStmt(
 // buildSelect builds a set of memo groups that represent the given select
 // expression.
 //
+// The locking spec holds any row-level locking clauses that apply to the
+// select expression from an enclosing scope, such as the FROM clause of an
+// outer SELECT ... FOR UPDATE. The expression's own locking clauses are added
+// to it.
+//
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelect(
-	stmt *tree.Select, desiredTypes []types.T, inScope *scope,
+	stmt *tree.Select, locking lockingSpec, desiredTypes []types.T, inScope *scope,
 ) (outScope *scope) {
 	wrapped := stmt.Select
 	orderBy := stmt.OrderBy
 	limit := stmt.Limit
 	with := stmt.With
+	b.checkLockingVersion(stmt.Locking)
+	locking = locking.apply(stmt.Locking)
 
 	for s, ok := wrapped.(*tree.ParenSelect); ok; s, ok = wrapped.(*tree.ParenSelect) {
 		stmt = s.Select
@@ -569,23 +770,22 @@ func (b *Builder) buildSelect(
 			}
 			limit = stmt.Limit
 		}
+		b.checkLockingVersion(stmt.Locking)
+		locking = locking.apply(stmt.Locking)
 	}
 
 	if with != nil {
-		inScope = b.buildCTE(with.CTEList, inScope)
+		inScope = b.buildCTE(with, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
 	// NB: The case statements are sorted lexicographically.
 	switch t := stmt.Select.(type) {
 	case *tree.SelectClause:
-		outScope = b.buildSelectClause(t, orderBy, desiredTypes, inScope)
+		outScope = b.buildSelectClause(t, orderBy, locking, desiredTypes, inScope)
 
-	case *tree.UnionClause:
-		outScope = b.buildUnion(t, desiredTypes, inScope)
-
-	case *tree.ValuesClause:
-		outScope = b.buildValuesClause(t, desiredTypes, inScope)
+	case *tree.UnionClause, *tree.ValuesClause:
+		outScope = b.buildSelectStmt(t, locking, desiredTypes, inScope)
 
 	default:
 		panic(fmt.Errorf("unknown select statement: %T", stmt.Select))
@@ -622,9 +822,15 @@ func (b *Builder) buildSelect(
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelectClause(
-	sel *tree.SelectClause, orderBy tree.OrderBy, desiredTypes []types.T, inScope *scope,
+	sel *tree.SelectClause,
+	orderBy tree.OrderBy,
+	locking lockingSpec,
+	desiredTypes []types.T,
+	inScope *scope,
 ) (outScope *scope) {
-	fromScope := b.buildFrom(sel.From, inScope)
+	b.validateLockingInSelectClause(sel, locking)
+
+	fromScope := b.buildFrom(sel.From, locking, inScope)
 	b.buildWhere(sel.Where, fromScope)
 
 	projectionsScope := fromScope.replace()
@@ -642,6 +848,9 @@ func (b *Builder) buildSelectClause(
 	distinctOnScope := b.analyzeDistinctOnArgs(sel.DistinctOn, fromScope, projectionsScope)
 
 	if b.needsAggregation(sel, fromScope) {
+		if locking.isSet() {
+			panic(builderError{lockingNotAllowedError(locking, "aggregate functions")})
+		}
 		outScope = b.buildAggregation(
 			sel, havingExpr, fromScope, projectionsScope, orderByScope, distinctOnScope,
 		)
@@ -670,10 +879,13 @@ func (b *Builder) buildSelectClause(
 }
 
 // buildFrom builds a set of memo groups that represent the given FROM clause.
+// The locking spec is applied to the tables in the FROM clause.
 //
 // See Builder.buildStmt for a description of the remaining input and return
 // values.
-func (b *Builder) buildFrom(from *tree.From, inScope *scope) (outScope *scope) {
+func (b *Builder) buildFrom(
+	from *tree.From, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
 	// The root AS OF clause is recognized and handled by the executor. The only
 	// thing that must be done at this point is to ensure that if any timestamps
 	// are specified, the root SELECT was an AS OF SYSTEM TIME and that the time
@@ -683,7 +895,7 @@ func (b *Builder) buildFrom(from *tree.From, inScope *scope) (outScope *scope) {
 	}
 
 	if len(from.Tables) > 0 {
-		outScope = b.buildFromTables(from.Tables, inScope)
+		outScope = b.buildFromTables(from.Tables, locking, inScope)
 	} else {
 		outScope = inScope.push()
 		outScope.expr = b.factory.ConstructValues(memo.ScalarListWithEmptyTuple, opt.ColList{})
@@ -731,17 +943,28 @@ func (b *Builder) buildWhere(where *tree.Where, inScope *scope) {
 //
 //   SELECT * FROM a JOIN (b JOIN c ON true) ON true
 //
+// If any of the tables is LATERAL, the tables are instead joined in the order
+// that they appear in the list; see buildFromTablesWithLateral.
+//
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
-func (b *Builder) buildFromTables(tables tree.TableExprs, inScope *scope) (outScope *scope) {
-	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, inScope)
+func (b *Builder) buildFromTables(
+	tables tree.TableExprs, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
+	for _, table := range tables {
+		if isLateral(table) {
+			return b.buildFromTablesWithLateral(tables, locking, inScope)
+		}
+	}
+
+	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, locking, inScope)
 
 	// Recursively build table join.
 	tables = tables[1:]
 	if len(tables) == 0 {
 		return outScope
 	}
-	tableScope := b.buildFromTables(tables, inScope)
+	tableScope := b.buildFromTables(tables, locking, inScope)
 
 	// Check that the same table name is not used multiple times.
 	b.validateJoinTableNames(outScope, tableScope)
@@ -754,6 +977,56 @@ func (b *Builder) buildFromTables(tables tree.TableExprs, inScope *scope) (outSc
 	return outScope
 }
 
+// buildFromTablesWithLateral builds a series of InnerJoin expressions that
+// join together the given FROM tables, at least one of which is LATERAL. The
+// tables are joined in the order that they appear in the list, so that each
+// LATERAL table can refer to the columns of the tables that precede it. For
+// example:
+//
+//   SELECT * FROM a, b, LATERAL (SELECT * FROM c WHERE c.x = a.x)
+//
+// is joined like:
+//
+//   SELECT * FROM (a JOIN b ON true) JOIN LATERAL (...) ON true
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildFromTablesWithLateral(
+	tables tree.TableExprs, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
+	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, locking, inScope)
+
+	for _, table := range tables[1:] {
+		// A LATERAL table is built in the scope of the tables preceding it.
+		tableInScope := inScope
+		lateral := isLateral(table)
+		if lateral {
+			tableInScope = outScope
+		}
+		tableScope := b.buildDataSource(table, nil /* indexFlags */, locking, tableInScope)
+
+		// Check that the same table name is not used multiple times.
+		b.validateJoinTableNames(outScope, tableScope)
+
+		joinScope := inScope.push()
+		joinScope.appendColumnsFromScope(outScope)
+		joinScope.appendColumnsFromScope(tableScope)
+
+		left := outScope.expr.(memo.RelExpr)
+		right := tableScope.expr.(memo.RelExpr)
+		joinScope.expr = b.constructJoin(sqlbase.InnerJoin, left, right, memo.TrueFilter, lateral)
+		outScope = joinScope
+	}
+	return outScope
+}
+
+// isLateral returns true if the given table expression is a LATERAL
+// subquery or set-returning function.
+func isLateral(texpr tree.TableExpr) bool {
+	t, ok := texpr.(*tree.AliasedTableExpr)
+	return ok && t.Lateral
+}
+
 // validateAsOf ensures that any AS OF SYSTEM TIME timestamp is consistent with
 // that of the root statement.
 func (b *Builder) validateAsOf(asOf tree.AsOfClause) {
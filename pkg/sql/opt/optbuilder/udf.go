@@ -0,0 +1,233 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/pkg/errors"
+)
+
+// resolveFunction resolves the function referenced by the given function
+// call. Builtin functions take precedence; if there is no builtin function
+// with that name, then the name is looked up in the catalog as a user-defined
+// function. In that case, resolveFunction returns a copy of f that refers to
+// the definition of the user-defined function, so that the original syntax
+// tree is not modified, since the function may change before the tree is
+// built again.
+func (b *Builder) resolveFunction(f *tree.FuncExpr) (*tree.FuncExpr, *tree.FunctionDefinition) {
+	def, err := f.Func.Resolve(b.semaCtx.SearchPath)
+	if err == nil {
+		return f, def
+	}
+
+	un, ok := f.Func.FunctionReference.(*tree.UnresolvedName)
+	if !ok {
+		panic(builderError{err})
+	}
+	if pgErr, ok := pgerror.GetPGCause(err); !ok || pgErr.Code != pgerror.CodeUndefinedFunctionError {
+		panic(builderError{err})
+	}
+	pattern, nameErr := un.NormalizeTablePattern()
+	if nameErr != nil {
+		panic(builderError{err})
+	}
+	fnName, ok := pattern.(*tree.TableName)
+	if !ok {
+		panic(builderError{err})
+	}
+
+	fn, resName, resErr := b.catalog.ResolveFunction(b.ctx, fnName)
+	if resErr != nil {
+		panic(builderError{resErr})
+	}
+	if fn == nil {
+		// Report the error about the missing builtin function.
+		panic(builderError{err})
+	}
+	if err := b.catalog.CheckPrivilege(b.ctx, fn, privilege.EXECUTE); err != nil {
+		panic(builderError{err})
+	}
+	b.factory.Metadata().AddFunctionDependency(&resName, fn, privilege.EXECUTE)
+
+	def = fn.Definition()
+	if b.udfs == nil {
+		b.udfs = make(map[*tree.FunctionDefinition]cat.Function)
+	}
+	b.udfs[def] = fn
+
+	copy := *f
+	copy.Func.FunctionReference = def
+	return &copy, def
+}
+
+// buildUDF builds a call to a user-defined function. If the body of the
+// function is a single scalar expression, then it is built as well, so that
+// normalization rules can inline it in place of the call.
+func (b *Builder) buildUDF(
+	f *tree.FuncExpr, def *tree.FunctionDefinition, fn cat.Function, args memo.ScalarListExpr,
+) opt.ScalarExpr {
+	private := &memo.UDFPrivate{
+		Name:       def.Name,
+		Typ:        f.ResolvedType(),
+		Properties: &def.FunctionProperties,
+		Overload:   f.ResolvedOverload(),
+	}
+
+	// A function that calls itself, directly or indirectly, cannot be inlined.
+	// It is evaluated by running its body, which bounds the recursion depth.
+	body := opt.ScalarExpr(memo.NullSingleton)
+	recursive := false
+	for _, outer := range b.udfStack {
+		if outer.Equals(fn) {
+			recursive = true
+			break
+		}
+	}
+	if !recursive {
+		b.udfStack = append(b.udfStack, fn)
+		if expr := b.buildUDFBody(fn, private); expr != nil {
+			body = expr
+			private.HasBody = true
+		}
+		b.udfStack = b.udfStack[:len(b.udfStack)-1]
+	}
+
+	return b.factory.ConstructUserDefinedFunction(args, body, private)
+}
+
+// buildUDFBody builds the body of the given user-defined function, if it is a
+// query of the form SELECT <expr> without any other clauses. The parameters of
+// the function are represented by new columns, which are stored in the
+// private. If the body cannot be built as a scalar expression, buildUDFBody
+// returns nil.
+func (b *Builder) buildUDFBody(fn cat.Function, private *memo.UDFPrivate) opt.ScalarExpr {
+	stmt, err := parser.ParseOne(fn.Body())
+	if err != nil {
+		wrapped := errors.Wrapf(err, "failed to parse body of function %q", fn.Name())
+		panic(builderError{wrapped})
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		panic(fmt.Sprintf("expected SELECT statement in body of function %q", fn.Name()))
+	}
+	expr := scalarUDFBody(sel)
+	if expr == nil {
+		return nil
+	}
+
+	bodyScope := b.allocScope()
+	private.Params = make(opt.ColList, fn.ParamCount())
+	for i := range private.Params {
+		name := fn.ParamName(i)
+		if name == "" {
+			name = fmt.Sprintf("$%d", i+1)
+		}
+		col := b.synthesizeColumn(bodyScope, name, fn.ParamType(i), nil /* expr */, nil /* scalar */)
+		private.Params[i] = col.id
+	}
+
+	v := udfBodyVisitor{builder: b, params: bodyScope.cols, simple: true}
+	expr, _ = tree.WalkExpr(&v, expr)
+	if v.err != nil {
+		panic(builderError{v.err})
+	}
+	if !v.simple {
+		private.Params = nil
+		return nil
+	}
+
+	texpr := bodyScope.resolveAndRequireType(expr, fn.ReturnType())
+	return b.buildScalar(texpr, bodyScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// scalarUDFBody returns the expression computed by the body of a user-defined
+// function, if the body is a query of the form SELECT <expr> without any
+// other clauses. Otherwise, it returns nil.
+func scalarUDFBody(sel *tree.Select) tree.Expr {
+	if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok {
+		return nil
+	}
+	if clause.Distinct || clause.DistinctOn != nil || len(clause.From.Tables) != 0 ||
+		clause.From.AsOf.Expr != nil || clause.Where != nil || clause.GroupBy != nil ||
+		clause.Having != nil || clause.Window != nil || len(clause.Exprs) != 1 {
+		return nil
+	}
+	return clause.Exprs[0].Expr
+}
+
+// udfBodyVisitor replaces the positional parameter references ($1, $2, ...)
+// in the body of a user-defined function with the columns that represent the
+// parameters, and checks that the body is a simple scalar expression: it
+// must not contain subqueries, or calls to aggregate, window or generator
+// functions.
+type udfBodyVisitor struct {
+	builder *Builder
+	params  []scopeColumn
+	simple  bool
+	err     error
+}
+
+var _ tree.Visitor = &udfBodyVisitor{}
+
+// VisitPre is part of the Visitor interface.
+func (v *udfBodyVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.err != nil || !v.simple {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Placeholder:
+		if int(t.Idx) >= len(v.params) {
+			v.err = pgerror.NewErrorf(pgerror.CodeUndefinedParameterError,
+				"there is no parameter %s", t)
+			return false, expr
+		}
+		return false, &v.params[t.Idx]
+
+	case *tree.Subquery:
+		v.simple = false
+		return false, expr
+
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.simple = false
+			return false, expr
+		}
+		// User-defined functions cannot be resolved here, but they are
+		// allowed in the body.
+		def, err := t.Func.Resolve(v.builder.semaCtx.SearchPath)
+		if err == nil && (isAggregate(def) || isGenerator(def)) {
+			v.simple = false
+			return false, expr
+		}
+	}
+	return true, expr
+}
+
+// VisitPost is part of the Visitor interface.
+func (*udfBodyVisitor) VisitPost(expr tree.Expr) tree.Expr {
+	return expr
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/pkg/errors"
 )
 
@@ -58,6 +59,16 @@ import (
 //   LEFT JOIN LATERAL (SELECT y FROM xyz WHERE x=a)
 //   ON True
 //
+// Tables in the FROM clause are joined with the target table, and only one of
+// the matching rows is kept for each updated row:
+//
+//   UPDATE abc SET b=y FROM xyz WHERE a=x
+//   =>
+//   SELECT DISTINCT ON (a) a AS oa, b AS ob, c AS oc, y AS nb
+//   FROM abc, xyz
+//   WHERE a=x
+//   ORDER BY a, x
+//
 // Computed columns result in an additional wrapper projection that can depend
 // on input columns.
 //
@@ -77,7 +88,7 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	}
 
 	if upd.With != nil {
-		inScope = b.buildCTE(upd.With.CTEList, inScope)
+		inScope = b.buildCTE(upd.With, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
@@ -105,13 +116,15 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	//   ORDER BY <order-by> LIMIT <limit>
 	//
 	// All columns from the update table will be projected.
-	mb.buildInputForUpdateOrDelete(inScope, upd.Where, upd.Limit, upd.OrderBy)
+	mb.buildInputForUpdateOrDelete(inScope, upd.From, upd.Where, upd.Limit, upd.OrderBy)
 
-	// Derive the columns that will be updated from the SET expressions.
-	mb.addTargetColsForUpdate(upd.Exprs)
+	// Derive the columns that will be updated from the SET expressions. If the
+	// table has triggers, all the columns they can modify are assigned.
+	exprs := mb.addTriggerUpdateExprs(upd.Exprs)
+	mb.addTargetColsForUpdate(exprs)
 
 	// Build each of the SET expressions.
-	mb.addUpdateCols(upd.Exprs)
+	mb.addUpdateCols(exprs)
 
 	// Add additional columns for computed expressions that may depend on the
 	// updated columns.
@@ -127,6 +140,41 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	return mb.outScope
 }
 
+// addTriggerUpdateExprs extends the SET expressions of an UPDATE on a table
+// with BEFORE UPDATE row-level triggers, so that the triggers can modify any
+// column of the updated rows. Each visible column that is not assigned by the
+// statement, is not computed and is not part of the primary key is assigned its
+// current value.
+func (mb *mutationBuilder) addTriggerUpdateExprs(exprs tree.UpdateExprs) tree.UpdateExprs {
+	if !mb.tab.HasBeforeUpdateTriggers() {
+		return exprs
+	}
+	assigned := make(map[tree.Name]struct{})
+	for _, expr := range exprs {
+		for _, name := range expr.Names {
+			assigned[name] = struct{}{}
+		}
+	}
+	primary := mb.tab.Index(cat.PrimaryIndex)
+	var keyOrds util.FastIntSet
+	for i, n := 0, primary.KeyColumnCount(); i < n; i++ {
+		keyOrds.Add(primary.Column(i).Ordinal)
+	}
+	res := append(tree.UpdateExprs(nil), exprs...)
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		col := mb.tab.Column(i)
+		name := col.ColName()
+		if _, ok := assigned[name]; ok || col.IsHidden() || col.IsComputed() || keyOrds.Contains(i) {
+			continue
+		}
+		res = append(res, &tree.UpdateExpr{
+			Names: tree.NameList{name},
+			Expr:  &tree.ColumnItem{ColumnName: name},
+		})
+	}
+	return res
+}
+
 // addTargetColsForUpdate compiles the given SET expressions and adds the user-
 // specified column names to the list of table columns that will be updated by
 // the Update operation. Verify that the RHS of the SET expression provides
@@ -153,7 +201,7 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 				for i := range desiredTypes {
 					desiredTypes[i] = mb.md.ColumnMeta(mb.targetColList[targetIdx+i]).Type
 				}
-				outScope := mb.b.buildSelectStmt(t.Select, desiredTypes, mb.outScope)
+				outScope := mb.b.buildSelectStmt(t.Select, noRowLocking, desiredTypes, mb.outScope)
 				mb.subqueries = append(mb.subqueries, outScope)
 				n = len(outScope.cols)
 
@@ -437,6 +437,10 @@ func (b *Builder) resolveTable(
 	if !ok {
 		panic(builderError{sqlbase.NewWrongObjectTypeError(tn, "table")})
 	}
+	if priv != privilege.SELECT && tab.IsMaterializedView() {
+		// The rows of a materialized view can only be changed by refreshing it.
+		panic(builderError{sqlbase.NewWrongObjectTypeError(tn, "table")})
+	}
 	return tab, resName
 }
 
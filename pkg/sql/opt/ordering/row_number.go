@@ -24,6 +24,11 @@ func rowNumberCanProvideOrdering(expr memo.RelExpr, required *physical.OrderingC
 	r := expr.(*memo.RowNumberExpr)
 	prefix := rowNumberOrdPrefix(r, required)
 	if prefix < len(required.Columns) {
+		if !r.Partition.Empty() {
+			// Partitioned row numbers restart from 1 in each partition, so they
+			// don't provide any ordering.
+			return false
+		}
 		truncated := required.Copy()
 		truncated.Truncate(prefix)
 		return r.Ordering.Implies(&truncated)
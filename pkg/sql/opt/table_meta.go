@@ -126,6 +126,41 @@ type TableMeta struct {
 
 	// anns annotates the table metadata with arbitrary data.
 	anns [maxTableAnnIDCount]interface{}
+
+	// partialIndexPredicates maps the ordinals of the partial indexes of the
+	// table to their predicates, which are built by optbuilder as filters over
+	// the columns of the table.
+	partialIndexPredicates map[int]ScalarExpr
+}
+
+// AddPartialIndexPredicate stores the predicate of the partial index with the
+// given ordinal.
+func (tm *TableMeta) AddPartialIndexPredicate(indexOrd int, pred ScalarExpr) {
+	if tm.partialIndexPredicates == nil {
+		tm.partialIndexPredicates = make(map[int]ScalarExpr)
+	}
+	tm.partialIndexPredicates[indexOrd] = pred
+}
+
+// PartialIndexPredicate returns the predicate of the partial index with the
+// given ordinal, or nil if the index is not a partial index.
+func (tm *TableMeta) PartialIndexPredicate(indexOrd int) ScalarExpr {
+	return tm.partialIndexPredicates[indexOrd]
+}
+
+// ReplacePartialIndexPredicates replaces each partial index predicate of the
+// table with the result of calling replace on it. The map of predicates may be
+// shared with the metadata this metadata was copied from, so it is rebuilt
+// rather than modified in place.
+func (tm *TableMeta) ReplacePartialIndexPredicates(replace func(ScalarExpr) ScalarExpr) {
+	if tm.partialIndexPredicates == nil {
+		return
+	}
+	preds := make(map[int]ScalarExpr, len(tm.partialIndexPredicates))
+	for ord, pred := range tm.partialIndexPredicates {
+		preds[ord] = replace(pred)
+	}
+	tm.partialIndexPredicates = preds
 }
 
 // IndexColumns returns the metadata IDs for the set of columns in the given
@@ -0,0 +1,64 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testcat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreateFunction creates a test function from a parsed DDL statement and adds
+// it to the catalog. This is intended for testing, and is not a complete (and
+// probably not fully correct) implementation. It just has to be "good enough".
+func (tc *Catalog) CreateFunction(stmt *tree.CreateFunction) *Function {
+	tc.qualifyTableName(&stmt.Name)
+
+	fn := &Function{
+		FuncID:   tc.nextStableID(),
+		FuncName: stmt.Name,
+		Params:   make([]FunctionParam, len(stmt.Args)),
+		RetType:  coltypes.CastTargetToDatumType(stmt.ReturnType),
+		FuncBody: *stmt.Options.Body,
+		Strict:   stmt.Options.NullInput == tree.FunctionReturnsNullOnNullInput ||
+			stmt.Options.NullInput == tree.FunctionStrict,
+	}
+
+	argTypes := make(tree.ArgTypes, len(stmt.Args))
+	for i := range stmt.Args {
+		fn.Params[i].Name = string(stmt.Args[i].Name)
+		fn.Params[i].Typ = coltypes.CastTargetToDatumType(stmt.Args[i].Type)
+		argTypes[i].Name = fn.Params[i].Name
+		argTypes[i].Typ = fn.Params[i].Typ
+	}
+
+	// Test functions are never evaluated, since the optimizer tests don't
+	// execute queries.
+	props := &tree.FunctionProperties{
+		NullableArgs:     !fn.Strict,
+		Impure:           stmt.Options.Volatility <= tree.FunctionVolatile,
+		DistsqlBlacklist: true,
+	}
+	fn.Def = tree.NewFunctionDefinition(string(stmt.Name.TableName), props, []tree.Overload{{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(fn.RetType),
+		Fn: func(*tree.EvalContext, tree.Datums) (tree.Datum, error) {
+			return nil, pgerror.NewAssertionErrorf("test functions cannot be evaluated")
+		},
+	}})
+
+	tc.AddFunction(fn)
+	return fn
+}
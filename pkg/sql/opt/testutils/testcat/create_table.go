@@ -332,6 +332,9 @@ func (tt *Table) addIndex(def *tree.IndexTableDef, typ indexType) *Index {
 		Inverted: def.Inverted,
 		table:    tt,
 	}
+	if def.Predicate != nil {
+		idx.predicate = tree.Serialize(def.Predicate)
+	}
 
 	// Look for name suffixes indicating this is a mutation index.
 	if name, ok := extractWriteOnlyIndex(def); ok {
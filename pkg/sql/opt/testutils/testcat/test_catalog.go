@@ -39,6 +39,7 @@ const (
 type Catalog struct {
 	testSchema  Schema
 	dataSources map[string]cat.DataSource
+	functions   map[string]*Function
 	counter     int
 }
 
@@ -57,6 +58,7 @@ func New() *Catalog {
 			},
 		},
 		dataSources: make(map[string]cat.DataSource),
+		functions:   make(map[string]*Function),
 	}
 }
 
@@ -162,6 +164,25 @@ func (tc *Catalog) ResolveDataSourceByID(
 		"relation [%d] does not exist", id)
 }
 
+// ResolveFunction is part of the cat.Catalog interface.
+func (tc *Catalog) ResolveFunction(
+	_ context.Context, name *cat.FunctionName,
+) (cat.Function, cat.FunctionName, error) {
+	// Functions can only be created in the current database, so only look
+	// there.
+	toResolve := *name
+	if !name.ExplicitCatalog {
+		toResolve.CatalogName = testDB
+	}
+	if !name.ExplicitSchema {
+		toResolve.SchemaName = tree.PublicSchemaName
+	}
+	if fn, ok := tc.functions[toResolve.FQString()]; ok {
+		return fn, toResolve, nil
+	}
+	return nil, cat.FunctionName{}, nil
+}
+
 // CheckPrivilege is part of the cat.Catalog interface.
 func (tc *Catalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privilege.Kind) error {
 	switch t := o.(type) {
@@ -181,6 +202,10 @@ func (tc *Catalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privil
 		if t.Revoked {
 			return fmt.Errorf("user does not have privilege to access %v", t.SeqName)
 		}
+	case *Function:
+		if t.Revoked {
+			return fmt.Errorf("user does not have privilege to access %v", t.FuncName)
+		}
 	default:
 		panic("invalid Object")
 	}
@@ -269,6 +294,15 @@ func (tc *Catalog) AddSequence(seq *Sequence) {
 	tc.dataSources[fq] = seq
 }
 
+// AddFunction adds the given test function to the catalog.
+func (tc *Catalog) AddFunction(fn *Function) {
+	fq := fn.FuncName.FQString()
+	if _, ok := tc.functions[fq]; ok {
+		panic(fmt.Errorf("function %q already exists", tree.ErrString(&fn.FuncName)))
+	}
+	tc.functions[fq] = fn
+}
+
 // ExecuteDDL parses the given DDL SQL statement and creates objects in the test
 // catalog. This is used to test without spinning up a cluster.
 func (tc *Catalog) ExecuteDDL(sql string) (string, error) {
@@ -302,6 +336,10 @@ func (tc *Catalog) ExecuteDDL(sql string) (string, error) {
 		seq := tc.CreateSequence(stmt)
 		return seq.String(), nil
 
+	case *tree.CreateFunction:
+		fn := tc.CreateFunction(stmt)
+		return fn.String(), nil
+
 	default:
 		return "", fmt.Errorf("unsupported statement: %v", stmt)
 	}
@@ -491,6 +529,26 @@ func (tt *Table) IsVirtualTable() bool {
 	return tt.IsVirtual
 }
 
+// IsMaterializedView is part of the cat.Table interface.
+func (tt *Table) IsMaterializedView() bool {
+	return false
+}
+
+// HasTriggers is part of the cat.Table interface.
+func (tt *Table) HasTriggers() bool {
+	return false
+}
+
+// HasBeforeUpdateTriggers is part of the cat.Table interface.
+func (tt *Table) HasBeforeUpdateTriggers() bool {
+	return false
+}
+
+// HasBeforeInsertTriggers is part of the cat.Table interface.
+func (tt *Table) HasBeforeInsertTriggers() bool {
+	return false
+}
+
 // IsInterleaved is part of the cat.Table interface.
 func (tt *Table) IsInterleaved() bool {
 	return false
@@ -609,6 +667,10 @@ type Index struct {
 
 	Columns []cat.IndexColumn
 
+	// predicate is the predicate of a partial index, or the empty string if
+	// this is not a partial index.
+	predicate string
+
 	// table is a back reference to the table this index is on.
 	table *Table
 
@@ -669,6 +731,11 @@ func (ti *Index) ForeignKey() (cat.ForeignKeyReference, bool) {
 	return ti.foreignKey, ti.fkSet
 }
 
+// Predicate is part of the cat.Index interface.
+func (ti *Index) Predicate() (string, bool) {
+	return ti.predicate, ti.predicate != ""
+}
+
 // Column implements the cat.Column interface for testing purposes.
 type Column struct {
 	Ordinal      int
@@ -838,6 +905,90 @@ func (ts *Sequence) String() string {
 	return tp.String()
 }
 
+// Function implements the cat.Function interface for testing purposes.
+type Function struct {
+	FuncID      cat.StableID
+	FuncVersion int
+	FuncName    tree.TableName
+	Params      []FunctionParam
+	RetType     types.T
+	FuncBody    string
+	Strict      bool
+	Def         *tree.FunctionDefinition
+
+	// If Revoked is true, then the user has had privileges on the function
+	// revoked.
+	Revoked bool
+}
+
+// FunctionParam is a parameter of a test function.
+type FunctionParam struct {
+	Name string
+	Typ  types.T
+}
+
+var _ cat.Function = &Function{}
+
+// ID is part of the cat.Object interface.
+func (tf *Function) ID() cat.StableID {
+	return tf.FuncID
+}
+
+// Equals is part of the cat.Object interface.
+func (tf *Function) Equals(other cat.Object) bool {
+	otherFunction, ok := other.(*Function)
+	if !ok {
+		return false
+	}
+	return tf.FuncID == otherFunction.FuncID && tf.FuncVersion == otherFunction.FuncVersion
+}
+
+// Name is part of the cat.Function interface.
+func (tf *Function) Name() *tree.TableName {
+	return &tf.FuncName
+}
+
+// Definition is part of the cat.Function interface.
+func (tf *Function) Definition() *tree.FunctionDefinition {
+	return tf.Def
+}
+
+// ParamCount is part of the cat.Function interface.
+func (tf *Function) ParamCount() int {
+	return len(tf.Params)
+}
+
+// ParamName is part of the cat.Function interface.
+func (tf *Function) ParamName(i int) string {
+	return tf.Params[i].Name
+}
+
+// ParamType is part of the cat.Function interface.
+func (tf *Function) ParamType(i int) types.T {
+	return tf.Params[i].Typ
+}
+
+// ReturnType is part of the cat.Function interface.
+func (tf *Function) ReturnType() types.T {
+	return tf.RetType
+}
+
+// Body is part of the cat.Function interface.
+func (tf *Function) Body() string {
+	return tf.FuncBody
+}
+
+// IsStrict is part of the cat.Function interface.
+func (tf *Function) IsStrict() bool {
+	return tf.Strict
+}
+
+func (tf *Function) String() string {
+	tp := treeprinter.New()
+	cat.FormatCatalogFunction(tf, tp)
+	return tp.String()
+}
+
 // Family implements the cat.Family interface for testing purposes.
 type Family struct {
 	FamName string
@@ -80,6 +80,12 @@ func (c *CustomFuncs) GenerateIndexScans(grp memo.RelExpr, scanPrivate *memo.Sca
 			continue
 		}
 
+		// Skip partial indexes, since they do not contain all the rows of the
+		// table.
+		if c.IsPartialIndex(scanPrivate.Table, iter.indexOrdinal) {
+			continue
+		}
+
 		// If the secondary index includes the set of needed columns, then construct
 		// a new Scan operator using that index.
 		if iter.isCovering() {
@@ -188,11 +194,23 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 	var iter scanIndexIter
 	iter.init(c.e.mem, scanPrivate)
 	for iter.next() {
-		// Check whether the filter can constrain the index.
+		// A partial index can only be used if the filter implies its predicate,
+		// since otherwise rows that satisfy the filter may be missing from it.
+		isPartial := c.IsPartialIndex(scanPrivate.Table, iter.indexOrdinal)
+		if isPartial && !c.FiltersImplyPartialIndexPredicate(filters, scanPrivate.Table, iter.indexOrdinal) {
+			continue
+		}
+
+		// Check whether the filter can constrain the index. A partial index is
+		// useful even if it cannot be constrained, since it only contains the
+		// rows that satisfy its predicate.
 		constraint, remaining, ok := c.tryConstrainIndex(
 			filters, scanPrivate.Table, iter.indexOrdinal, false /* isInverted */)
 		if !ok {
-			continue
+			if !isPartial {
+				continue
+			}
+			constraint, remaining = nil, filters
 		}
 
 		// Construct new constrained ScanPrivate.
@@ -496,6 +514,12 @@ func (c *CustomFuncs) GenerateLimitedScans(
 	var iter scanIndexIter
 	iter.init(c.e.mem, scanPrivate)
 	for iter.next() {
+		// Skip partial indexes, since they do not contain all the rows of the
+		// table.
+		if c.IsPartialIndex(scanPrivate.Table, iter.indexOrdinal) {
+			continue
+		}
+
 		newScanPrivate := *scanPrivate
 		newScanPrivate.Index = iter.indexOrdinal
 
@@ -682,10 +706,17 @@ func (c *CustomFuncs) GenerateLookupJoins(
 			continue
 		}
 
+		// A partial index can only be used if the ON condition implies its
+		// predicate.
+		if !c.FiltersImplyPartialIndexPredicate(on, scanPrivate.Table, iter.indexOrdinal) {
+			continue
+		}
+
 		lookupJoin := memo.LookupJoinExpr{Input: input, On: on}
 		lookupJoin.JoinType = joinType
 		lookupJoin.Table = scanPrivate.Table
 		lookupJoin.Index = iter.indexOrdinal
+		lookupJoin.Locking = scanPrivate.Locking
 
 		// Find the longest prefix of index key columns that are equality columns.
 		numIndexKeyCols := iter.index.LaxKeyColumnCount()
@@ -777,6 +808,7 @@ func (c *CustomFuncs) GenerateLookupJoins(
 		indexJoin.Index = cat.PrimaryIndex
 		indexJoin.KeyCols = pkCols
 		indexJoin.Cols = scanPrivate.Cols.Union(inputProps.OutputCols)
+		indexJoin.Locking = scanPrivate.Locking
 
 		// Create the LookupJoin for the index join in the same group.
 		c.e.mem.AddLookupJoinToGroup(&indexJoin, grp)
@@ -902,6 +934,11 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 		return
 	}
 
+	// Zigzag joins don't support row-level locking.
+	if scanPrivate.IsLocking() {
+		return
+	}
+
 	fixedCols := memo.ExtractConstColumns(filters, c.e.mem, c.e.evalCtx)
 
 	if fixedCols.Len() == 0 {
@@ -945,6 +982,9 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 		if !fixedCols.Contains(int(scanPrivate.Table.ColumnID(iter.index.Column(0).Ordinal))) {
 			continue
 		}
+		if !c.FiltersImplyPartialIndexPredicate(filters, scanPrivate.Table, iter.indexOrdinal) {
+			continue
+		}
 
 		iter2.init(c.e.mem, scanPrivate)
 		// Only look at indexes after this one.
@@ -954,6 +994,9 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 			if !fixedCols.Contains(int(scanPrivate.Table.ColumnID(iter2.index.Column(0).Ordinal))) {
 				continue
 			}
+			if !c.FiltersImplyPartialIndexPredicate(filters, scanPrivate.Table, iter2.indexOrdinal) {
+				continue
+			}
 			// Columns that are in both indexes are, by definition, equal.
 			leftCols := iter.indexCols()
 			rightCols := iter2.indexCols()
@@ -1111,6 +1154,11 @@ func (c *CustomFuncs) GenerateInvertedIndexZigzagJoins(
 		return
 	}
 
+	// Zigzag joins don't support row-level locking.
+	if scanPrivate.IsLocking() {
+		return
+	}
+
 	var sb indexScanBuilder
 	sb.init(c, scanPrivate.Table)
 
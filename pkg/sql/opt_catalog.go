@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -149,6 +150,22 @@ func (oc *optCatalog) ResolveDataSourceByID(
 	return oc.newDataSource(ctx, desc, &name)
 }
 
+// ResolveFunction is part of the cat.Catalog interface.
+func (oc *optCatalog) ResolveFunction(
+	ctx context.Context, name *cat.FunctionName,
+) (cat.Function, cat.FunctionName, error) {
+	oc.tn = *name
+	desc, err := oc.resolver.(*planner).resolveFunction(ctx, &oc.tn, false /* required */)
+	if err != nil || desc == nil {
+		return nil, cat.FunctionName{}, err
+	}
+	fn, err := newOptFunction(desc, &oc.tn)
+	if err != nil {
+		return nil, cat.FunctionName{}, err
+	}
+	return fn, oc.tn, nil
+}
+
 // CheckPrivilege is part of the cat.Catalog interface.
 func (oc *optCatalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privilege.Kind) error {
 	switch t := o.(type) {
@@ -160,6 +177,8 @@ func (oc *optCatalog) CheckPrivilege(ctx context.Context, o cat.Object, priv pri
 		return oc.resolver.CheckPrivilege(ctx, t.desc, priv)
 	case *optSequence:
 		return oc.resolver.CheckPrivilege(ctx, t.desc, priv)
+	case *optFunction:
+		return oc.resolver.CheckPrivilege(ctx, t.desc, priv)
 	default:
 		return pgerror.NewAssertionErrorf("invalid object type: %T", o)
 	}
@@ -182,7 +201,7 @@ func (oc *optCatalog) newDataSource(
 	// Create wrapper for the data source now.
 	var ds cat.DataSource
 	switch {
-	case desc.IsTable():
+	case desc.IsTable(), desc.MaterializedView():
 		id := cat.StableID(desc.ID)
 		if desc.IsVirtualTable() {
 			// A virtual table can effectively have multiple instances, with different
@@ -340,6 +359,89 @@ func (os *optSequence) SequenceName() *tree.TableName {
 	return os.Name()
 }
 
+// optFunction is a wrapper around sqlbase.FunctionDescriptor that implements
+// the cat.Object and cat.Function interfaces.
+type optFunction struct {
+	desc *sqlbase.FunctionDescriptor
+
+	// name is the fully qualified, fully resolved, fully normalized name of the
+	// function.
+	name cat.FunctionName
+
+	def *tree.FunctionDefinition
+}
+
+var _ cat.Function = &optFunction{}
+
+func newOptFunction(desc *sqlbase.FunctionDescriptor, name *cat.FunctionName) (*optFunction, error) {
+	def, err := makeFunctionDefinition(desc)
+	if err != nil {
+		return nil, err
+	}
+	of := &optFunction{desc: desc, name: *name, def: def}
+
+	// The cat.Function interface requires that function names be fully
+	// qualified.
+	of.name.ExplicitSchema = true
+	of.name.ExplicitCatalog = true
+
+	return of, nil
+}
+
+// ID is part of the cat.Object interface.
+func (of *optFunction) ID() cat.StableID {
+	return cat.StableID(of.desc.ID)
+}
+
+// Equals is part of the cat.Object interface.
+func (of *optFunction) Equals(other cat.Object) bool {
+	otherFn, ok := other.(*optFunction)
+	if !ok {
+		return false
+	}
+	return of.desc.ID == otherFn.desc.ID && of.desc.Version == otherFn.desc.Version
+}
+
+// Name is part of the cat.Function interface.
+func (of *optFunction) Name() *cat.FunctionName {
+	return &of.name
+}
+
+// Definition is part of the cat.Function interface.
+func (of *optFunction) Definition() *tree.FunctionDefinition {
+	return of.def
+}
+
+// ParamCount is part of the cat.Function interface.
+func (of *optFunction) ParamCount() int {
+	return len(of.desc.Args)
+}
+
+// ParamName is part of the cat.Function interface.
+func (of *optFunction) ParamName(i int) string {
+	return of.desc.Args[i].Name
+}
+
+// ParamType is part of the cat.Function interface.
+func (of *optFunction) ParamType(i int) types.T {
+	return of.desc.Args[i].Type.ToDatumType()
+}
+
+// ReturnType is part of the cat.Function interface.
+func (of *optFunction) ReturnType() types.T {
+	return of.desc.ReturnType.ToDatumType()
+}
+
+// Body is part of the cat.Function interface.
+func (of *optFunction) Body() string {
+	return of.desc.Body
+}
+
+// IsStrict is part of the cat.Function interface.
+func (of *optFunction) IsStrict() bool {
+	return of.desc.Strict
+}
+
 // optTable is a wrapper around sqlbase.ImmutableTableDescriptor that caches
 // index wrappers and maintains a ColumnID => Column mapping for fast lookup.
 type optTable struct {
@@ -454,6 +556,26 @@ func (ot *optTable) IsVirtualTable() bool {
 	return ot.desc.IsVirtualTable()
 }
 
+// IsMaterializedView is part of the cat.Table interface.
+func (ot *optTable) IsMaterializedView() bool {
+	return ot.desc.MaterializedView()
+}
+
+// HasTriggers is part of the cat.Table interface.
+func (ot *optTable) HasTriggers() bool {
+	return len(ot.desc.Triggers) > 0
+}
+
+// HasBeforeUpdateTriggers is part of the cat.Table interface.
+func (ot *optTable) HasBeforeUpdateTriggers() bool {
+	return ot.desc.HasBeforeRowTriggers(sqlbase.TriggerDescriptor_UPDATE)
+}
+
+// HasBeforeInsertTriggers is part of the cat.Table interface.
+func (ot *optTable) HasBeforeInsertTriggers() bool {
+	return ot.desc.HasBeforeRowTriggers(sqlbase.TriggerDescriptor_INSERT)
+}
+
 // IsInterleaved is part of the cat.Table interface.
 func (ot *optTable) IsInterleaved() bool {
 	return ot.desc.IsInterleaved()
@@ -766,6 +888,11 @@ func (oi *optIndex) ForeignKey() (cat.ForeignKeyReference, bool) {
 	return oi.foreignKey, oi.desc.ForeignKey.IsSet()
 }
 
+// Predicate is part of the cat.Index interface.
+func (oi *optIndex) Predicate() (string, bool) {
+	return oi.desc.Predicate, oi.desc.IsPartial()
+}
+
 // Table is part of the cat.Index interface.
 func (oi *optIndex) Table() cat.Table {
 	return oi.tab
@@ -71,6 +71,7 @@ func (ef *execFactory) ConstructScan(
 	reverse bool,
 	maxResults uint64,
 	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	tabDesc := table.(*optTable).desc
 	indexDesc := index.(*optIndex).desc
@@ -98,6 +99,9 @@ func (ef *execFactory) ConstructScan(
 	scan.reverse = reverse
 	scan.maxResults = maxResults
 	scan.parallelScansEnabled = sqlbase.ParallelScans.Get(&ef.planner.extendedEvalCtx.Settings.SV)
+	if err := scan.setLocking(locking); err != nil {
+		return nil, err
+	}
 	var err error
 	scan.spans, err = spansFromConstraint(
 		tabDesc,
@@ -478,6 +482,24 @@ func (ef *execFactory) ConstructSetOp(
 	return ef.planner.newUnionNode(typ, all, left.(planNode), right.(planNode))
 }
 
+// ConstructRecursiveCTE is part of the exec.Factory interface.
+func (ef *execFactory) ConstructRecursiveCTE(
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, deduplicate bool,
+) (exec.Node, error) {
+	return &recursiveCTENode{
+		initial: initial.(planNode),
+		genIterationFn: func(_ runParams, workTable planNode) (planNode, error) {
+			plan, err := fn(workTable)
+			if err != nil {
+				return nil, err
+			}
+			return plan.(planNode), nil
+		},
+		label:       label,
+		deduplicate: deduplicate,
+	}, nil
+}
+
 // ConstructSort is part of the exec.Factory interface.
 func (ef *execFactory) ConstructSort(
 	input exec.Node, ordering sqlbase.ColumnOrdering,
@@ -493,7 +515,9 @@ func (ef *execFactory) ConstructSort(
 }
 
 // ConstructOrdinality is part of the exec.Factory interface.
-func (ef *execFactory) ConstructOrdinality(input exec.Node, colName string) (exec.Node, error) {
+func (ef *execFactory) ConstructOrdinality(
+	input exec.Node, colName string, partition exec.ColumnOrdinalSet,
+) (exec.Node, error) {
 	plan := input.(planNode)
 	inputColumns := planColumns(plan)
 	cols := make(sqlbase.ResultColumns, len(inputColumns)+1)
@@ -502,9 +526,14 @@ func (ef *execFactory) ConstructOrdinality(input exec.Node, colName string) (exe
 		Name: colName,
 		Typ:  types.Int,
 	}
+	var partitionCols []int
+	partition.ForEach(func(i int) {
+		partitionCols = append(partitionCols, i)
+	})
 	return &ordinalityNode{
-		source:  plan,
-		columns: cols,
+		source:        plan,
+		columns:       cols,
+		partitionCols: partitionCols,
 		run: ordinalityRun{
 			row:    make(tree.Datums, len(cols)),
 			curCnt: 1,
@@ -514,7 +543,11 @@ func (ef *execFactory) ConstructOrdinality(input exec.Node, colName string) (exe
 
 // ConstructIndexJoin is part of the exec.Factory interface.
 func (ef *execFactory) ConstructIndexJoin(
-	input exec.Node, table cat.Table, cols exec.ColumnOrdinalSet, reqOrdering exec.OutputOrdering,
+	input exec.Node,
+	table cat.Table,
+	cols exec.ColumnOrdinalSet,
+	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	tabDesc := table.(*optTable).desc
 	colCfg := makeScanColumnsConfig(table, cols)
@@ -543,6 +576,9 @@ func (ef *execFactory) ConstructIndexJoin(
 	tableScan.index = &primaryIndex
 	tableScan.run.isSecondaryIndex = false
 	tableScan.disableBatchLimit()
+	if err := tableScan.setLocking(locking); err != nil {
+		return nil, err
+	}
 
 	primaryKeyColumns, colIDtoRowIndex := processIndexJoinColumns(tableScan, scan)
 	primaryKeyPrefix := roachpb.Key(sqlbase.MakeIndexKeyPrefix(tabDesc.TableDesc(), tableScan.index.ID))
@@ -573,6 +609,7 @@ func (ef *execFactory) ConstructLookupJoin(
 	lookupCols exec.ColumnOrdinalSet,
 	onCond tree.TypedExpr,
 	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	tabDesc := table.(*optTable).desc
 	indexDesc := index.(*optIndex).desc
@@ -585,6 +622,9 @@ func (ef *execFactory) ConstructLookupJoin(
 
 	tableScan.index = indexDesc
 	tableScan.run.isSecondaryIndex = (indexDesc != &tabDesc.PrimaryIndex)
+	if err := tableScan.setLocking(locking); err != nil {
+		return nil, err
+	}
 
 	n := &lookupJoinNode{
 		input:    input.(planNode),
@@ -905,6 +945,7 @@ func (ef *execFactory) ConstructInsert(
 	if err != nil {
 		return nil, err
 	}
+	ri.DeferFKChecks(ef.planner.deferredFKChecks())
 
 	// Determine the relational type of the generated insert node.
 	// If rows are not needed, no columns are returned.
@@ -915,13 +956,19 @@ func (ef *execFactory) ConstructInsert(
 		returnCols = sqlbase.ResultColumnsFromColDescs(tabDesc.Columns)
 	}
 
+	triggers, err := ef.planner.newTriggerRunner(
+		ef.planner.extendedEvalCtx.Context, tabDesc, sqlbase.TriggerDescriptor_INSERT)
+	if err != nil {
+		return nil, err
+	}
+
 	// Regular path for INSERT.
 	ins := insertNodePool.Get().(*insertNode)
 	*ins = insertNode{
 		source:  input.(planNode),
 		columns: returnCols,
 		run: insertRun{
-			ti:          tableInserter{ri: ri},
+			ti:          tableInserter{tableWriterBase: tableWriterBase{triggers: triggers}, ri: ri},
 			checkHelper: checkHelper,
 			rowsNeeded:  rowsNeeded,
 			iVarContainerForComputedCols: sqlbase.RowIndexedVarContainer{
@@ -1003,6 +1050,7 @@ func (ef *execFactory) ConstructUpdate(
 	// Truncate any FetchCols added by MakeUpdater. The optimizer has already
 	// computed a correct set that can sometimes be smaller.
 	ru.FetchCols = ru.FetchCols[:len(fetchColDescs)]
+	ru.DeferFKChecks(ef.planner.deferredFKChecks())
 
 	// Determine the relational type of the generated update node.
 	// If rows are not needed, no columns are returned.
@@ -1020,12 +1068,18 @@ func (ef *execFactory) ConstructUpdate(
 		updateColsIdx[col.ID] = i
 	}
 
+	triggers, err := ef.planner.newTriggerRunner(
+		ef.planner.extendedEvalCtx.Context, tabDesc, sqlbase.TriggerDescriptor_UPDATE)
+	if err != nil {
+		return nil, err
+	}
+
 	upd := updateNodePool.Get().(*updateNode)
 	*upd = updateNode{
 		source:  input.(planNode),
 		columns: returnCols,
 		run: updateRun{
-			tu:          tableUpdater{ru: ru},
+			tu:          tableUpdater{tableWriterBase: tableWriterBase{triggers: triggers}, ru: ru},
 			checkHelper: checkHelper,
 			rowsNeeded:  rowsNeeded,
 			iVarContainerForComputedCols: sqlbase.RowIndexedVarContainer{
@@ -1099,6 +1153,7 @@ func (ef *execFactory) ConstructUpsert(
 	if err != nil {
 		return nil, err
 	}
+	ri.DeferFKChecks(ef.planner.deferredFKChecks())
 
 	// Create the table updater, which does the bulk of the update-related work.
 	// In the HP, the updater derives the columns that need to be fetched. By
@@ -1139,6 +1194,13 @@ func (ef *execFactory) ConstructUpsert(
 		updateColsIdx[col.ID] = i
 	}
 
+	// Conflicting rows are only updated if there are columns to update.
+	triggers, err := ef.planner.newUpsertTriggerRunner(
+		ef.planner.extendedEvalCtx.Context, tabDesc, updateCols.Empty() /* doNothing */)
+	if err != nil {
+		return nil, err
+	}
+
 	// Instantiate the upsert node.
 	ups := upsertNodePool.Get().(*upsertNode)
 	*ups = upsertNode{
@@ -1153,9 +1215,11 @@ func (ef *execFactory) ConstructUpsert(
 			},
 			tw: &optTableUpserter{
 				tableUpserterBase: tableUpserterBase{
-					ri:          ri,
-					alloc:       &ef.planner.alloc,
-					collectRows: rowsNeeded,
+					tableWriterBase:  tableWriterBase{triggers: triggers},
+					ri:               ri,
+					alloc:            &ef.planner.alloc,
+					collectRows:      rowsNeeded,
+					deferredFKChecks: ef.planner.deferredFKChecks(),
 				},
 				canaryOrdinal: int(canaryCol),
 				fkTables:      fkTables,
@@ -1217,6 +1281,7 @@ func (ef *execFactory) ConstructDelete(
 	// Truncate any FetchCols added by MakeUpdater. The optimizer has already
 	// computed a correct set that can sometimes be smaller.
 	rd.FetchCols = rd.FetchCols[:len(fetchColDescs)]
+	rd.DeferFKChecks(ef.planner.deferredFKChecks())
 
 	// Determine the relational type of the generated delete node.
 	// If rows are not needed, no columns are returned.
@@ -1227,13 +1292,23 @@ func (ef *execFactory) ConstructDelete(
 		returnCols = sqlbase.ResultColumnsFromColDescs(tabDesc.Columns)
 	}
 
+	triggers, err := ef.planner.newTriggerRunner(
+		ef.planner.extendedEvalCtx.Context, tabDesc, sqlbase.TriggerDescriptor_DELETE)
+	if err != nil {
+		return nil, err
+	}
+
 	// Now make a delete node. We use a pool.
 	del := deleteNodePool.Get().(*deleteNode)
 	*del = deleteNode{
 		source:  input.(planNode),
 		columns: returnCols,
 		run: deleteRun{
-			td:         tableDeleter{rd: rd, alloc: &ef.planner.alloc},
+			td: tableDeleter{
+				tableWriterBase: tableWriterBase{triggers: triggers},
+				rd:              rd,
+				alloc:           &ef.planner.alloc,
+			},
 			rowsNeeded: rowsNeeded,
 		},
 	}
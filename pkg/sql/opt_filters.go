@@ -249,6 +249,13 @@ func (p *planner) propagateFilters(
 			return plan, extraFilter, err
 		}
 
+	case *recursiveCTENode:
+		// Filters cannot be pushed into the initial query, since its rows are
+		// also the input of the recursion.
+		if n.initial, err = p.triggerFilterPropagation(ctx, n.initial); err != nil {
+			return plan, extraFilter, err
+		}
+
 	case *windowNode:
 		if n.plan, err = p.triggerFilterPropagation(ctx, n.plan); err != nil {
 			return plan, extraFilter, err
@@ -364,6 +371,7 @@ func (p *planner) propagateFilters(
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *renameColumnNode:
 	case *renameDatabaseNode:
@@ -378,21 +386,32 @@ func (p *planner) propagateFilters(
 	case *createIndexNode:
 	case *CreateUserNode:
 	case *createViewNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
+	case *createTypeNode:
+	case *createSchemaNode:
 	case *createSequenceNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *deleteRangeNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
+	case *dropTypeNode:
+	case *dropSchemaNode:
 	case *dropSequenceNode:
 	case *DropUserNode:
 	case *hookFnNode:
 	case *valuesNode:
 	case *virtualTableNode:
 	case *sequenceSelectNode:
+	case *workTableScanNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
+	case *setConstraintsNode:
 	case *setZoneConfigNode:
 	case *showZoneConfigNode:
 	case *showFingerprintsNode:
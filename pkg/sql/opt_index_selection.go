@@ -29,8 +29,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -134,6 +136,7 @@ func (p *planner) selectIndex(
 	}
 
 	var optimizer xform.Optimizer
+	var filters memo.FiltersExpr
 
 	if s.filter != nil {
 		optimizer.Init(p.EvalContext())
@@ -147,7 +150,7 @@ func (p *planner) selectIndex(
 		if err != nil {
 			return nil, err
 		}
-		filters := memo.FiltersExpr{{Condition: optimizer.Memo().RootExpr().(opt.ScalarExpr)}}
+		filters = memo.FiltersExpr{{Condition: optimizer.Memo().RootExpr().(opt.ScalarExpr)}}
 		filters = optimizer.Factory().CustomFuncs().SimplifyFilters(filters)
 		for _, c := range candidates {
 			if err := c.makeIndexConstraints(
@@ -185,6 +188,37 @@ func (p *planner) selectIndex(
 			s.specifiedIndex.Name)
 	}
 
+	// Remove any partial indexes whose predicate is not implied by the filter,
+	// since they only contain the rows that satisfy their predicate.
+	for i := 0; i < len(candidates); {
+		implied := false
+		if index := candidates[i].index; !index.IsPartial() {
+			implied = true
+		} else if s.filter != nil {
+			var err error
+			implied, err = p.filterImpliesPartialIndexPredicate(ctx, s, &optimizer, filters, index)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if implied {
+			i++
+		} else {
+			candidates[i] = candidates[len(candidates)-1]
+			candidates = candidates[:len(candidates)-1]
+		}
+	}
+
+	if len(candidates) == 0 {
+		// The primary index is never a partial index. So the only way this can
+		// happen is if we had a specified index.
+		if s.specifiedIndex == nil {
+			panic("no full indexes")
+		}
+		return nil, fmt.Errorf("index \"%s\" is a partial index and cannot be used for this query",
+			s.specifiedIndex.Name)
+	}
+
 	if s.noIndexJoin {
 		// Eliminate non-covering indexes. We do this after the check above for
 		// constant false filter.
@@ -290,6 +324,47 @@ func (p *planner) selectIndex(
 	return plan, nil
 }
 
+// filterImpliesPartialIndexPredicate returns true if the filter of the scan,
+// which has been built as the given filters in the memo of the optimizer,
+// implies the predicate of the given partial index.
+func (p *planner) filterImpliesPartialIndexPredicate(
+	ctx context.Context,
+	s *scanNode,
+	optimizer *xform.Optimizer,
+	filters memo.FiltersExpr,
+	index *sqlbase.IndexDescriptor,
+) (bool, error) {
+	raw, err := parser.ParseExpr(index.Predicate)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse predicate of index %q", index.Name)
+	}
+	tn := tree.MakeUnqualifiedTableName(tree.Name(s.desc.Name))
+	pred, err := p.analyzeExpr(
+		ctx,
+		raw,
+		sqlbase.MakeMultiSourceInfo(sqlbase.NewSourceInfoForSingleTable(tn, s.resultColumns)),
+		tree.MakeIndexedVarHelper(s, len(s.resultColumns)),
+		types.Bool,
+		true, /* requireType */
+		"index predicate",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Build the predicate in the same memo as the filter, so that identical
+	// conditions are interned as the same expression.
+	bld := optbuilder.NewScalar(ctx, &p.semaCtx, p.EvalContext(), optimizer.Factory())
+	bld.AllowUnsupportedExpr = true
+	scalar, err := bld.BuildExpr(pred)
+	if err != nil {
+		return false, err
+	}
+	funcs := optimizer.Factory().CustomFuncs()
+	predFilters := funcs.SimplifyFilters(memo.FiltersExpr{{Condition: scalar}})
+	return funcs.FiltersImply(filters, predFilters), nil
+}
+
 // calculateMaxResults returns the maximum number of results for a scan; the
 // scan is guaranteed never to return more results than this. Iff this hint is
 // invalid, 0 is returned.
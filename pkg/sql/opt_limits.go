@@ -127,6 +127,9 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *max1RowNode:
 		p.setUnlimited(n.plan)
 
+	case *recursiveCTENode:
+		p.setUnlimited(n.initial)
+
 	case *joinNode:
 		p.setUnlimited(n.left.plan)
 		p.setUnlimited(n.right.plan)
@@ -209,6 +212,7 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *deleteRangeNode:
 	case *renameColumnNode:
@@ -224,20 +228,31 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *createIndexNode:
 	case *CreateUserNode:
 	case *createViewNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
+	case *createTypeNode:
+	case *createSchemaNode:
 	case *createSequenceNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
+	case *dropTypeNode:
+	case *dropSchemaNode:
 	case *dropSequenceNode:
 	case *DropUserNode:
 	case *zeroNode:
 	case *unaryNode:
 	case *hookFnNode:
 	case *sequenceSelectNode:
+	case *workTableScanNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
+	case *setConstraintsNode:
 	case *setZoneConfigNode:
 	case *showZoneConfigNode:
 	case *showFingerprintsNode:
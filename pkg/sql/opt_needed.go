@@ -48,6 +48,11 @@ func setNeededColumns(plan planNode, needed []bool) {
 	case *max1RowNode:
 		setNeededColumns(n.plan, needed)
 
+	case *recursiveCTENode:
+		// The working table needs all the columns of the initial query, since
+		// the recursive query may refer to any of them.
+		setNeededColumns(n.initial, allColumns(n.initial))
+
 	case *spoolNode:
 		setNeededColumns(n.source, needed)
 
@@ -87,6 +92,13 @@ func setNeededColumns(plan planNode, needed []bool) {
 		markOmitted(n.columns, needed)
 
 	case *ordinalityNode:
+		// The partition columns are always needed to number the rows.
+		if len(n.partitionCols) > 0 {
+			needed = append([]bool(nil), needed...)
+			for _, colIdx := range n.partitionCols {
+				needed[colIdx] = true
+			}
+		}
 		setNeededColumns(n.source, needed[:len(needed)-1])
 		markOmitted(n.columns[:len(needed)-1], needed[:len(needed)-1])
 
@@ -257,6 +269,7 @@ func setNeededColumns(plan planNode, needed []bool) {
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *deleteRangeNode:
 	case *renameColumnNode:
@@ -272,20 +285,31 @@ func setNeededColumns(plan planNode, needed []bool) {
 	case *createIndexNode:
 	case *CreateUserNode:
 	case *createViewNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
+	case *createTypeNode:
+	case *createSchemaNode:
 	case *createSequenceNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
+	case *dropTypeNode:
+	case *dropSchemaNode:
 	case *dropSequenceNode:
 	case *DropUserNode:
 	case *zeroNode:
 	case *unaryNode:
 	case *hookFnNode:
 	case *sequenceSelectNode:
+	case *workTableScanNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
+	case *setConstraintsNode:
 	case *setZoneConfigNode:
 	case *showZoneConfigNode:
 	case *showFingerprintsNode:
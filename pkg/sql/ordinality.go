@@ -39,11 +39,19 @@ import (
 // In other words, *ordinalityNode establishes a barrier to many
 // common SQL optimizations*. Its use should be limited in clients to
 // situations where the corresponding performance cost is affordable.
+//
+// When partition columns are specified, the rows are numbered separately
+// for each distinct combination of values of these columns. The source
+// must then produce the rows of each partition contiguously.
 type ordinalityNode struct {
 	source  planNode
 	props   physicalProps
 	columns sqlbase.ResultColumns
 
+	// partitionCols are the indexes of the partition columns in the source
+	// rows, if any.
+	partitionCols []int
+
 	run ordinalityRun
 }
 
@@ -91,6 +99,10 @@ func (p *planner) wrapOrdinality(ds planDataSource) planDataSource {
 type ordinalityRun struct {
 	row    tree.Datums
 	curCnt int64
+
+	// partition contains the values of the partition columns of the
+	// previous row, or is nil before the first row.
+	partition tree.Datums
 }
 
 func (o *ordinalityNode) startExec(runParams) error {
@@ -103,6 +115,9 @@ func (o *ordinalityNode) Next(params runParams) (bool, error) {
 		return hasNext, err
 	}
 	copy(o.run.row, o.source.Values())
+	if len(o.partitionCols) > 0 && o.newPartition(params.EvalContext()) {
+		o.run.curCnt = 1
+	}
 	// o.run.row was allocated one spot larger than o.source.Values().
 	// Store the ordinality value there.
 	o.run.row[len(o.run.row)-1] = tree.NewDInt(tree.DInt(o.run.curCnt))
@@ -110,6 +125,28 @@ func (o *ordinalityNode) Next(params runParams) (bool, error) {
 	return true, nil
 }
 
+// newPartition returns true if the current row is the first row of a
+// partition, and remembers the values of its partition columns.
+func (o *ordinalityNode) newPartition(evalCtx *tree.EvalContext) bool {
+	if o.run.partition == nil {
+		o.run.partition = make(tree.Datums, len(o.partitionCols))
+		for i, colIdx := range o.partitionCols {
+			o.run.partition[i] = o.run.row[colIdx]
+		}
+		return true
+	}
+	isNew := false
+	for i, colIdx := range o.partitionCols {
+		// NULL values compare equal to each other, so the rows with NULL
+		// partition values are numbered together.
+		if !isNew && o.run.partition[i].Compare(evalCtx, o.run.row[colIdx]) != 0 {
+			isNew = true
+		}
+		o.run.partition[i] = o.run.row[colIdx]
+	}
+	return isNew
+}
+
 func (o *ordinalityNode) Values() tree.Datums       { return o.run.row }
 func (o *ordinalityNode) Close(ctx context.Context) { o.source.Close(ctx) }
 
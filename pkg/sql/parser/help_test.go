@@ -65,6 +65,9 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER SEQUENCE IF ??`, `ALTER SEQUENCE`},
 		{`ALTER SEQUENCE blah ??`, `ALTER SEQUENCE`},
 		{`ALTER SEQUENCE blah RENAME ??`, `ALTER SEQUENCE`},
+
+		{`ALTER TYPE ??`, `ALTER TYPE`},
+		{`ALTER TYPE blah ADD VALUE ??`, `ALTER TYPE`},
 		{`ALTER SEQUENCE blah RENAME TO blih ??`, `ALTER SEQUENCE`},
 
 		{`ALTER USER IF ??`, `ALTER USER`},
@@ -110,9 +113,23 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE VIEW blah AS (SELECT c FROM x) ??`, `CREATE VIEW`},
 		{`CREATE VIEW blah AS SELECT c FROM x ??`, `SELECT`},
 		{`CREATE VIEW blah AS (??`, `<SELECTCLAUSE>`},
+		{`CREATE MATERIALIZED VIEW blah (??`, `CREATE VIEW`},
+
+		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
+		{`CREATE OR REPLACE FUNCTION f(??`, `CREATE FUNCTION`},
+		{`CREATE FUNCTION f() RETURNS INT ??`, `CREATE FUNCTION`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE CONSTRAINT TRIGGER a ??`, `CREATE TRIGGER`},
 
 		{`CREATE SEQUENCE ??`, `CREATE SEQUENCE`},
 
+		{`CREATE TYPE ??`, `CREATE TYPE`},
+		{`CREATE TYPE blah AS ENUM (??`, `CREATE TYPE`},
+
+		{`CREATE SCHEMA ??`, `CREATE SCHEMA`},
+		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
+
 		{`CREATE STATISTICS ??`, `CREATE STATISTICS`},
 
 		{`CREATE TABLE blah (??`, `CREATE TABLE`},
@@ -154,6 +171,19 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP VIEW blah ??`, `DROP VIEW`},
 		{`DROP VIEW IF ??`, `DROP VIEW`},
 		{`DROP VIEW IF EXISTS blih, bloh ??`, `DROP VIEW`},
+		{`DROP MATERIALIZED VIEW blah ??`, `DROP VIEW`},
+
+		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+		{`DROP FUNCTION IF EXISTS f(??`, `DROP FUNCTION`},
+
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+		{`DROP TRIGGER IF EXISTS a ON ??`, `DROP TRIGGER`},
+
+		{`DROP TYPE ??`, `DROP TYPE`},
+		{`DROP TYPE IF EXISTS blih, bloh ??`, `DROP TYPE`},
+
+		{`DROP SCHEMA ??`, `DROP SCHEMA`},
+		{`DROP SCHEMA IF EXISTS blih, bloh ??`, `DROP SCHEMA`},
 
 		{`DROP USER ??`, `DROP USER`},
 		{`DROP USER IF ??`, `DROP USER`},
@@ -209,6 +239,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`PAUSE ??`, `PAUSE JOBS`},
 
+		{`REFRESH ??`, `REFRESH`},
+		{`REFRESH MATERIALIZED VIEW blah ??`, `REFRESH`},
+
 		{`RESUME ??`, `RESUME JOBS`},
 
 		{`REVOKE ALL ??`, `REVOKE`},
@@ -262,6 +295,7 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW CREATE TABLE blah ??`, `SHOW CREATE`},
 		{`SHOW CREATE VIEW blah ??`, `SHOW CREATE`},
 		{`SHOW CREATE SEQUENCE blah ??`, `SHOW CREATE`},
+		{`SHOW CREATE FUNCTION blah ??`, `SHOW CREATE`},
 
 		{`SHOW DATABASES ??`, `SHOW DATABASES`},
 
@@ -324,6 +358,8 @@ func TestContextualHelp(t *testing.T) {
 		{`SET SESSION blah TO 42 ??`, `SET SESSION`},
 
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
 		{`SET TIME ZONE 'UTC' ??`, `SET SESSION`},
@@ -173,6 +173,15 @@ func (l *lexer) UnimplementedWithIssueDetail(issue int, detail string) {
 	l.lastError.hint = fmt.Sprintf("See: https://github.com/cockroachdb/cockroach/issues/%d", issue)
 }
 
+// UnimplementedWithIssueDetailHint is like UnimplementedWithIssueDetail, but
+// names the unimplemented feature in the error message and adds a hint before
+// the link to the issue.
+func (l *lexer) UnimplementedWithIssueDetailHint(issue int, detail, feature, hint string) {
+	l.Error("unimplemented: " + feature)
+	l.lastError.unimplementedFeature = fmt.Sprintf("#%d.%s", issue, detail)
+	l.lastError.hint = fmt.Sprintf("%s\nSee: https://github.com/cockroachdb/cockroach/issues/%d", hint, issue)
+}
+
 func (l *lexer) Error(e string) {
 	l.initLastErr()
 	lastTok := l.lastToken()
@@ -97,10 +97,16 @@ func TestParse(t *testing.T) {
 		{`CREATE INVERTED INDEX a ON b.c (d)`},
 		{`CREATE INVERTED INDEX a ON b (c) STORING (d)`},
 		{`CREATE INVERTED INDEX a ON b (c) INTERLEAVE IN PARENT d (e)`},
+		{`CREATE INDEX a ON b (c) WHERE d > 0`},
+		{`CREATE UNIQUE INDEX a ON b (c) STORING (d) WHERE e IS NULL`},
+		{`CREATE INDEX IF NOT EXISTS a ON b (c) WHERE d AND (e = 'f')`},
 
 		{`CREATE TABLE a ()`},
 		{`EXPLAIN CREATE TABLE a ()`},
 		{`CREATE TABLE a (b INT8)`},
+		{`CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE TEMPORARY TABLE IF NOT EXISTS a (b INT8)`},
+		{`CREATE TEMPORARY TABLE a AS SELECT * FROM b`},
 		{`CREATE TABLE a (b INT8, c INT8)`},
 		{`CREATE TABLE a (b CHAR)`},
 		{`CREATE TABLE a (b CHAR(3))`},
@@ -114,6 +120,7 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b TIME)`},
 		{`CREATE TABLE a (b UUID)`},
 		{`CREATE TABLE a (b INET)`},
+		{`CREATE TABLE a (b TSVECTOR, c TSQUERY)`},
 		{`CREATE TABLE a (b "char")`},
 		{`CREATE TABLE a (b INT8 NULL)`},
 		{`CREATE TABLE a (b INT8 CONSTRAINT maybe NULL)`},
@@ -158,6 +165,9 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other MATCH FULL ON DELETE SET NULL ON UPDATE RESTRICT)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other MATCH FULL)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y) MATCH FULL)`},
+		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other DEFERRABLE)`},
+		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED)`},
+		{`ALTER TABLE a ADD CONSTRAINT fk FOREIGN KEY (b) REFERENCES other DEFERRABLE NOT VALID`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y))`},
 		{`CREATE TABLE a (b INT8, c STRING, CONSTRAINT s FOREIGN KEY (b, c) REFERENCES other (x, y))`},
@@ -269,6 +279,30 @@ func TestParse(t *testing.T) {
 		{`CREATE VIEW a AS VALUES (1, 'one'), (2, 'two')`},
 		{`CREATE VIEW a (x, y) AS VALUES (1, 'one'), (2, 'two')`},
 		{`CREATE VIEW a AS TABLE b`},
+		{`CREATE MATERIALIZED VIEW a AS SELECT * FROM b`},
+		{`CREATE MATERIALIZED VIEW a (x, y) AS SELECT c, d FROM b`},
+		{`EXPLAIN CREATE MATERIALIZED VIEW a AS SELECT * FROM b`},
+		{`REFRESH MATERIALIZED VIEW a`},
+		{`REFRESH MATERIALIZED VIEW a.b`},
+
+		{`CREATE FUNCTION f() RETURNS INT8 LANGUAGE sql AS 'SELECT 1'`},
+		{`CREATE OR REPLACE FUNCTION a.f(x INT8, STRING) RETURNS STRING LANGUAGE sql IMMUTABLE STRICT AS 'SELECT $2 || x::STRING'`},
+		{`CREATE FUNCTION f(x INT8) RETURNS INT8 LANGUAGE sql STABLE CALLED ON NULL INPUT AS 'SELECT x'`},
+		{`CREATE FUNCTION f(x DECIMAL[]) RETURNS DECIMAL LANGUAGE sql VOLATILE RETURNS NULL ON NULL INPUT AS 'SELECT x[1]'`},
+		{`EXPLAIN CREATE FUNCTION f() RETURNS INT8 LANGUAGE sql AS 'SELECT 1'`},
+		{`CREATE FUNCTION f() RETURNS TRIGGER LANGUAGE sql AS 'SELECT * FROM new'`},
+
+		{`CREATE TRIGGER a BEFORE INSERT ON b FOR EACH ROW EXECUTE FUNCTION f()`},
+		{`CREATE TRIGGER a AFTER INSERT OR UPDATE OR DELETE ON b.c FOR EACH STATEMENT EXECUTE FUNCTION d.f('x', 'y')`},
+		{`CREATE CONSTRAINT TRIGGER a AFTER DELETE ON b FOR EACH ROW EXECUTE FUNCTION f()`},
+		{`EXPLAIN CREATE TRIGGER a BEFORE UPDATE ON b FOR EACH ROW EXECUTE FUNCTION f()`},
+
+		{`CREATE TYPE a AS ENUM ('b', 'c')`},
+		{`CREATE TYPE a.b AS ENUM ()`},
+		{`EXPLAIN CREATE TYPE a AS ENUM ('b')`},
+		{`CREATE SCHEMA a`},
+		{`CREATE SCHEMA IF NOT EXISTS a`},
+		{`EXPLAIN CREATE SCHEMA a`},
 
 		{`CREATE SEQUENCE a`},
 		{`EXPLAIN CREATE SEQUENCE a`},
@@ -308,8 +342,12 @@ func TestParse(t *testing.T) {
 		{`DELETE FROM a WHERE a = b RETURNING a + b`},
 		{`DELETE FROM a WHERE a = b RETURNING NOTHING`},
 		{`DELETE FROM a WHERE a = b ORDER BY c LIMIT d RETURNING e`},
+		{`DELETE FROM a USING b WHERE a.c = b.c`},
+		{`DELETE FROM a AS x USING b AS y, c WHERE x.d = y.d AND y.e = c.e RETURNING x.d`},
+		{`DELETE FROM a USING b`},
 
 		{`DISCARD ALL`},
+		{`DISCARD TEMP`},
 
 		{`DROP DATABASE a`},
 		{`EXPLAIN DROP DATABASE a`},
@@ -345,6 +383,22 @@ func TestParse(t *testing.T) {
 		{`DROP VIEW IF EXISTS a, b RESTRICT`},
 		{`DROP VIEW a.b CASCADE`},
 		{`DROP VIEW a, b CASCADE`},
+		{`DROP MATERIALIZED VIEW a`},
+		{`DROP MATERIALIZED VIEW IF EXISTS a, b CASCADE`},
+
+		{`DROP FUNCTION f`},
+		{`DROP FUNCTION f()`},
+		{`DROP FUNCTION a.f(INT8, STRING)`},
+		{`DROP FUNCTION IF EXISTS f, g(INT8) RESTRICT`},
+		{`DROP FUNCTION f, a.g CASCADE`},
+		{`DROP TRIGGER a ON b`},
+		{`DROP TRIGGER IF EXISTS a ON b.c CASCADE`},
+		{`DROP TYPE a`},
+		{`DROP TYPE IF EXISTS a, b.c RESTRICT`},
+		{`DROP TYPE a CASCADE`},
+		{`DROP SCHEMA a`},
+		{`DROP SCHEMA IF EXISTS a, b RESTRICT`},
+		{`DROP SCHEMA a CASCADE`},
 		{`DROP SEQUENCE a`},
 		{`EXPLAIN DROP SEQUENCE a`},
 		{`DROP SEQUENCE a.b`},
@@ -462,6 +516,7 @@ func TestParse(t *testing.T) {
 		{`SHOW GRANTS ON TABLE foo, db.foo`},
 		{`SHOW GRANTS ON DATABASE foo, bar`},
 		{`SHOW GRANTS ON DATABASE foo FOR bar`},
+		{`SHOW GRANTS ON SCHEMA foo, bar`},
 		{`SHOW GRANTS FOR bar, baz`},
 
 		{`SHOW GRANTS ON ROLE`},
@@ -526,6 +581,10 @@ func TestParse(t *testing.T) {
 		{`GRANT SELECT, INSERT ON DATABASE bar TO foo, bar, baz`},
 		{`GRANT SELECT, INSERT ON DATABASE db1, db2 TO foo, bar, baz`},
 		{`GRANT SELECT, INSERT ON DATABASE db1, db2 TO "test-user"`},
+		{`GRANT EXECUTE ON FUNCTION f TO foo`},
+		{`GRANT ALL ON FUNCTION f(INT8), db.g TO foo, bar`},
+		{`GRANT CREATE ON SCHEMA sc TO foo`},
+		{`GRANT ALL ON SCHEMA sc1, sc2 TO foo, bar`},
 		{`GRANT rolea, roleb TO usera, userb`},
 		{`GRANT rolea, roleb TO usera, userb WITH ADMIN OPTION`},
 
@@ -537,6 +596,8 @@ func TestParse(t *testing.T) {
 		{`REVOKE ALL ON DATABASE foo FROM root, test`},
 		{`REVOKE SELECT, INSERT ON DATABASE bar FROM foo, bar, baz`},
 		{`REVOKE SELECT, INSERT ON DATABASE db1, db2 FROM foo, bar, baz`},
+		{`REVOKE CREATE ON SCHEMA sc FROM foo`},
+		{`REVOKE EXECUTE ON FUNCTION f(), g FROM foo`},
 		{`REVOKE rolea, roleb FROM usera, userb`},
 		{`REVOKE ADMIN OPTION FOR rolea, roleb FROM usera, userb`},
 
@@ -583,6 +644,10 @@ func TestParse(t *testing.T) {
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING 1, 2`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING a + b`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_pkey DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_pkey DO UPDATE SET a = 1`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) WHERE b > 2 DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) WHERE b > 2 DO UPDATE SET a = 1 WHERE a > 0`},
 
 		{`SELECT 1 + 1`},
 		{`SELECT -1`},
@@ -617,6 +682,7 @@ func TestParse(t *testing.T) {
 		{`SELECT 0x1`},
 		{`SELECT 'Deutsch' COLLATE "DE"`},
 		{`SELECT a @> b`},
+		{`SELECT a @@ b`},
 		{`SELECT a <@ b`},
 		{`SELECT a ? b`},
 		{`SELECT a ?| b`},
@@ -670,8 +736,14 @@ func TestParse(t *testing.T) {
 		{`SELECT BYTES 'foo', 'foo'::BYTES`},
 		{`SELECT DATE 'foo', 'foo'::DATE`},
 		{`SELECT TIME 'foo', 'foo'::TIME`},
+		{`SELECT TIMETZ 'foo', 'foo'::TIMETZ`},
 		{`SELECT TIMESTAMP 'foo', 'foo'::TIMESTAMP`},
 		{`SELECT TIMESTAMPTZ 'foo', 'foo'::TIMESTAMPTZ`},
+		{`SELECT TIME(3) 'foo', 'foo'::TIME(3)`},
+		{`SELECT TIMETZ(3) 'foo', 'foo'::TIMETZ(3)`},
+		{`SELECT TIMESTAMP(3) 'foo', 'foo'::TIMESTAMP(3)`},
+		{`SELECT TIMESTAMPTZ(0) 'foo', 'foo'::TIMESTAMPTZ(0)`},
+		{`SELECT 'foo'::INTERVAL(3)`},
 		{`SELECT JSONB 'foo', 'foo'::JSONB`},
 		{`SELECT SERIAL8 'foo', 'foo'::SERIAL8`},
 
@@ -691,6 +763,9 @@ func TestParse(t *testing.T) {
 		{`SELECT 1:::REGPROC`},
 		{`SELECT 1:::REGCLASS`},
 		{`SELECT 1:::REGNAMESPACE`},
+		{`SELECT 'foo'::mood, CAST('foo' AS mood), 'foo':::mood`},
+		{`SELECT 'foo'::public.mood`},
+		{`SELECT mood 'foo'`},
 
 		{`SELECT 'a' AS "12345"`},
 		{`SELECT 'a' AS clnm`},
@@ -727,6 +802,11 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM (SELECT 1 FROM t) WITH ORDINALITY`},
 		{`SELECT a FROM (SELECT 1 FROM t) WITH ORDINALITY AS bar`},
 		{`SELECT a FROM ROWS FROM (a(x), b(y), c(z))`},
+		{`SELECT a FROM t, LATERAL (SELECT * FROM u WHERE u.b = t.b)`},
+		{`SELECT a FROM t, LATERAL (SELECT 1 FROM u) WITH ORDINALITY AS bar (bar1, bar2)`},
+		{`SELECT a FROM t, LATERAL ROWS FROM (a(x), b(y))`},
+		{`SELECT a FROM t INNER JOIN LATERAL (SELECT * FROM u WHERE u.b = t.b LIMIT 3) AS u ON true`},
+		{`SELECT a FROM t LEFT JOIN LATERAL ROWS FROM (json_array_elements(t.j)) AS e ON true`},
 		{`SELECT a FROM t1, t2`},
 		{`SELECT a FROM t AS t1`},
 		{`SELECT a FROM t AS t1 (c1)`},
@@ -901,6 +981,10 @@ func TestParse(t *testing.T) {
 
 		{`SELECT avg(1) FILTER (WHERE a > b)`},
 		{`SELECT avg(1) FILTER (WHERE a > b) OVER (ORDER BY c)`},
+		{`SELECT percentile_disc(0.5) WITHIN GROUP (ORDER BY a)`},
+		{`SELECT percentile_cont(ARRAY[0.5, 0.99]) WITHIN GROUP (ORDER BY a DESC)`},
+		{`SELECT mode() WITHIN GROUP (ORDER BY a) FILTER (WHERE a > b)`},
+		{`SELECT rank(1, 'x') WITHIN GROUP (ORDER BY a, b)`},
 
 		{`SELECT a FROM t UNION SELECT 1 FROM t`},
 		{`SELECT a FROM t UNION SELECT 1 FROM t UNION SELECT 1 FROM t`},
@@ -928,6 +1012,22 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM t LIMIT a`},
 		{`SELECT a FROM t OFFSET b`},
 		{`SELECT a FROM t LIMIT a OFFSET b`},
+
+		{`SELECT a FROM t FOR UPDATE`},
+		{`SELECT a FROM t FOR NO KEY UPDATE`},
+		{`SELECT a FROM t FOR SHARE`},
+		{`SELECT a FROM t FOR KEY SHARE`},
+		{`SELECT a FROM t FOR UPDATE OF t`},
+		{`SELECT a FROM t, u FOR UPDATE OF t, db.public.u`},
+		{`SELECT a FROM t FOR UPDATE NOWAIT`},
+		{`SELECT a FROM t FOR SHARE SKIP LOCKED`},
+		{`SELECT a FROM t FOR UPDATE OF t SKIP LOCKED FOR SHARE OF u NOWAIT`},
+		{`SELECT a FROM t ORDER BY a LIMIT 1 FOR UPDATE`},
+		{`WITH cte AS (SELECT 1) SELECT a FROM t FOR UPDATE`},
+		{`WITH RECURSIVE cte (a) AS (SELECT 1 UNION ALL SELECT a + 1 FROM cte WHERE a < 10) SELECT * FROM cte`},
+		{`INSERT INTO t WITH RECURSIVE cte (a) AS (SELECT 1 UNION ALL SELECT a + 1 FROM cte) SELECT * FROM cte`},
+		{`SELECT a FROM (SELECT b FROM u FOR UPDATE) AS t FOR SHARE`},
+
 		{`SELECT DISTINCT * FROM t`},
 		{`SELECT DISTINCT a, b FROM t`},
 		{`SELECT DISTINCT ON (a, b) c FROM t`},
@@ -945,6 +1045,9 @@ func TestParse(t *testing.T) {
 		{`SET TRANSACTION PRIORITY LOW`},
 		{`SET TRANSACTION PRIORITY NORMAL`},
 		{`SET TRANSACTION PRIORITY HIGH`},
+		{`SET CONSTRAINTS ALL DEFERRED`},
+		{`SET CONSTRAINTS ALL IMMEDIATE`},
+		{`SET CONSTRAINTS a, b DEFERRED`},
 		{`SET TRANSACTION ISOLATION LEVEL SERIALIZABLE, PRIORITY HIGH`},
 
 		{`SET TRACING = off`},
@@ -1003,6 +1106,9 @@ func TestParse(t *testing.T) {
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING a, a + b`},
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING NOTHING`},
 		{`UPDATE a SET b = 3 WHERE a = b ORDER BY c LIMIT d RETURNING e`},
+		{`UPDATE a SET b = c.d FROM c WHERE a.e = c.e`},
+		{`UPDATE a AS x SET b = y.c FROM b AS y, (SELECT * FROM c) AS z WHERE x.d = y.d AND y.e = z.e RETURNING x.b`},
+		{`UPDATE a SET (b, c) = (d.b, d.c) FROM d JOIN e ON d.f = e.f WHERE a.g = d.g`},
 
 		{`UPDATE t AS "0" SET k = ''`},                 // "0" lost its quotes
 		{`SELECT * FROM "0" JOIN "0" USING (id, "0")`}, // last "0" lost its quotes.
@@ -1055,6 +1161,7 @@ func TestParse(t *testing.T) {
 		{`ALTER TABLE a ALTER COLUMN b SET DEFAULT NULL`},
 		{`ALTER TABLE a ALTER COLUMN b DROP DEFAULT`},
 		{`ALTER TABLE a ALTER COLUMN b DROP NOT NULL`},
+		{`ALTER TABLE a ALTER COLUMN b SET NOT NULL`},
 		{`ALTER TABLE a ALTER COLUMN b DROP STORED`},
 
 		{`ALTER TABLE a ALTER COLUMN b SET DATA TYPE INT8`},
@@ -1063,6 +1170,11 @@ func TestParse(t *testing.T) {
 
 		{`COPY t FROM STDIN`},
 		{`COPY t (a, b, c) FROM STDIN`},
+		{`COPY t TO STDOUT`},
+		{`COPY t (a, b, c) TO STDOUT`},
+		{`COPY (SELECT a, b FROM t) TO STDOUT`},
+		{`COPY t TO STDOUT WITH (FORMAT csv, DELIMITER '|', NULL 'n', HEADER)`},
+		{`COPY (VALUES (1)) TO STDOUT WITH (NULL '')`},
 
 		{`ALTER TABLE a SPLIT AT VALUES (1)`},
 		{`EXPLAIN ALTER TABLE a SPLIT AT VALUES (1)`},
@@ -1175,6 +1287,11 @@ func TestParse(t *testing.T) {
 		{`ALTER SEQUENCE IF EXISTS a INCREMENT BY 5 START WITH 1000`},
 		{`ALTER SEQUENCE IF EXISTS a NO CYCLE CACHE 1`},
 
+		{`ALTER TYPE a ADD VALUE 'b'`},
+		{`EXPLAIN ALTER TYPE a ADD VALUE 'b'`},
+		{`ALTER TYPE a.b ADD VALUE IF NOT EXISTS 'c' BEFORE 'd'`},
+		{`ALTER TYPE a ADD VALUE 'c' AFTER 'd'`},
+
 		{`EXPERIMENTAL SCRUB DATABASE x`},
 		{`EXPLAIN EXPERIMENTAL SCRUB DATABASE x`},
 		{`EXPERIMENTAL SCRUB DATABASE x AS OF SYSTEM TIME 1`},
@@ -1279,6 +1396,14 @@ func TestParse2(t *testing.T) {
 			`CREATE DATABASE a ENCODING = 'foo'`},
 		{`CREATE DATABASE a TEMPLATE = template0`,
 			`CREATE DATABASE a TEMPLATE = 'template0'`},
+		{`CREATE TEMP TABLE a (b INT8)`,
+			`CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE LOCAL TEMP TABLE a (b INT8)`,
+			`CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE GLOBAL TEMPORARY TABLE IF NOT EXISTS a AS SELECT * FROM b`,
+			`CREATE TEMPORARY TABLE IF NOT EXISTS a AS SELECT * FROM b`},
+		{`DISCARD TEMPORARY`,
+			`DISCARD TEMP`},
 		{`CREATE DATABASE a TEMPLATE = invalid`,
 			`CREATE DATABASE a TEMPLATE = 'invalid'`},
 		{`CREATE TABLE a (b INT, UNIQUE INDEX foo (b))`,
@@ -1287,6 +1412,10 @@ func TestParse2(t *testing.T) {
 			`CREATE TABLE a (b INT8, CONSTRAINT foo UNIQUE (b) INTERLEAVE IN PARENT c (d))`},
 		{`CREATE TABLE a (UNIQUE INDEX (b) PARTITION BY LIST (c) (PARTITION d VALUES IN (1)))`,
 			`CREATE TABLE a (UNIQUE (b) PARTITION BY LIST (c) (PARTITION d VALUES IN (1)))`},
+		{`CREATE TABLE a (b INT, INDEX (b) WHERE b > 0)`,
+			`CREATE TABLE a (b INT8, INDEX (b) WHERE b > 0)`},
+		{`CREATE TABLE a (b INT, UNIQUE INDEX foo (b) WHERE b > 0)`,
+			`CREATE TABLE a (b INT8, UNIQUE INDEX foo (b) WHERE b > 0)`},
 		{`CREATE INDEX ON a (b) COVERING (c)`, `CREATE INDEX ON a (b) STORING (c)`},
 
 		{`CREATE INDEX a ON b USING GIN (c)`,
@@ -1321,9 +1450,15 @@ func TestParse2(t *testing.T) {
 
 		{`SELECT TIMESTAMP WITHOUT TIME ZONE 'foo'`, `SELECT TIMESTAMP 'foo'`},
 		{`SELECT CAST('foo' AS TIMESTAMP WITHOUT TIME ZONE)`, `SELECT CAST('foo' AS TIMESTAMP)`},
+		{`SELECT TIME WITH TIME ZONE 'foo'`, `SELECT TIMETZ 'foo'`},
+		{`SELECT CAST('foo' AS TIME(3) WITH TIME ZONE)`, `SELECT CAST('foo' AS TIMETZ(3))`},
+		{`SELECT CAST('foo' AS TIME(3) WITHOUT TIME ZONE)`, `SELECT CAST('foo' AS TIME(3))`},
+		{`SELECT CAST('foo' AS TIMESTAMP(3) WITH TIME ZONE)`, `SELECT CAST('foo' AS TIMESTAMPTZ(3))`},
+		{`SELECT a AT TIME ZONE 'b'`, `SELECT timezone('b', a)`},
 		{`SELECT CAST(1 AS "timestamp")`, `SELECT CAST(1 AS TIMESTAMP)`},
 		{`SELECT CAST(1 AS _int8)`, `SELECT CAST(1 AS INT8[])`},
 		{`SELECT CAST(1 AS "_int8")`, `SELECT CAST(1 AS INT8[])`},
+		{`SELECT 'f'::"mood"`, `SELECT 'f'::mood`},
 
 		{`SELECT 'a' FROM t@{FORCE_INDEX=bar}`, `SELECT 'a' FROM t@bar`},
 		{`SELECT 'a' FROM t@{ASC,FORCE_INDEX=idx}`, `SELECT 'a' FROM t@{FORCE_INDEX=idx,ASC}`},
@@ -1416,6 +1551,8 @@ func TestParse2(t *testing.T) {
 			`SELECT current_timestamp()`},
 		{`SELECT CURRENT_DATE`,
 			`SELECT current_date()`},
+		{`SELECT CURRENT_TIME`,
+			`SELECT current_time()`},
 		{`SELECT POSITION(a IN b)`,
 			`SELECT strpos(b, a)`},
 		{`SELECT TRIM(BOTH a FROM b)`,
@@ -1455,6 +1592,14 @@ func TestParse2(t *testing.T) {
 			`SELECT a FROM t LIMIT 2 * a OFFSET b`},
 		{`SELECT a FROM t FETCH FIRST (2 * a) ROWS ONLY OFFSET b`,
 			`SELECT a FROM t LIMIT 2 * a OFFSET b`},
+		// The locking clause may come before or after LIMIT/OFFSET, but is
+		// always output last.
+		{`SELECT a FROM t FOR UPDATE LIMIT 1`,
+			`SELECT a FROM t LIMIT 1 FOR UPDATE`},
+		{`SELECT a FROM t ORDER BY a FOR SHARE NOWAIT OFFSET 2`,
+			`SELECT a FROM t ORDER BY a OFFSET 2 FOR SHARE NOWAIT`},
+		{`SELECT a FROM t FOR READ ONLY`,
+			`SELECT a FROM t`},
 		// Double negation. See #1800.
 		{`SELECT *,-/* comment */-5`,
 			`SELECT *, 5`},
@@ -1591,6 +1736,10 @@ func TestParse2(t *testing.T) {
 			`SELECT a FROM ROWS FROM (generate_series(1, 32)) AS s (x)`},
 		{`SELECT a FROM generate_series(1, 32) WITH ORDINALITY AS s (x)`,
 			`SELECT a FROM ROWS FROM (generate_series(1, 32)) WITH ORDINALITY AS s (x)`},
+		{`SELECT a FROM t, LATERAL generate_series(1, t.b) AS s (x)`,
+			`SELECT a FROM t, LATERAL ROWS FROM (generate_series(1, t.b)) AS s (x)`},
+		{`WITH RECURSIVE cte (a, b) AS (SELECT 1, 2 UNION SELECT a, b FROM cte), x AS (SELECT 1) SELECT * FROM cte, x`,
+			`WITH RECURSIVE cte (a, b) AS (SELECT 1, 2 UNION SELECT a, b FROM cte) , x AS (SELECT 1) SELECT * FROM cte, x`},
 
 		// Tuples
 		{`SELECT 1 IN (b)`, `SELECT 1 IN (b,)`},
@@ -1604,6 +1753,19 @@ func TestParse2(t *testing.T) {
 			`SHOW CREATE t`},
 		{`SHOW CREATE SEQUENCE t`,
 			`SHOW CREATE t`},
+		{`SHOW CREATE FUNCTION f`,
+			`SHOW CREATE FUNCTION f`},
+		{`SHOW CREATE FUNCTION db.f`,
+			`SHOW CREATE FUNCTION db.f`},
+
+		{`CREATE FUNCTION f(x INT) RETURNS INT AS 'SELECT x' LANGUAGE SQL`,
+			`CREATE FUNCTION f(x INT8) RETURNS INT8 LANGUAGE sql AS 'SELECT x'`},
+		{`CREATE FUNCTION f() RETURNS STRING STRICT VOLATILE LANGUAGE sql AS 'SELECT ''a'''`,
+			`CREATE FUNCTION f() RETURNS STRING LANGUAGE sql VOLATILE STRICT AS e'SELECT \'a\''`},
+		{`CREATE TRIGGER a BEFORE INSERT ON b EXECUTE PROCEDURE f(1, 2.5, x)`,
+			`CREATE TRIGGER a BEFORE INSERT ON b FOR EACH STATEMENT EXECUTE FUNCTION f('1', '2.5', 'x')`},
+		{`CREATE TRIGGER a AFTER UPDATE ON b FOR ROW EXECUTE FUNCTION f()`,
+			`CREATE TRIGGER a AFTER UPDATE ON b FOR EACH ROW EXECUTE FUNCTION f()`},
 		{`SHOW INDEX FROM t`,
 			`SHOW INDEXES FROM t`},
 		{`SHOW CONSTRAINT FROM t`,
@@ -1729,6 +1891,13 @@ func TestParse2(t *testing.T) {
 		{`ALTER INDEX i CONFIGURE ZONE USING foo = COPY FROM PARENT`,
 			`ALTER INDEX i CONFIGURE ZONE USING foo = COPY FROM PARENT`},
 
+		{`COPY t TO STDOUT (FORMAT 'csv')`,
+			`COPY t TO STDOUT WITH (FORMAT csv)`},
+		{`COPY t TO STDOUT CSV HEADER`,
+			`COPY t TO STDOUT WITH (FORMAT csv, HEADER)`},
+		{`COPY t TO STDOUT WITH DELIMITER AS '|' NULL ''`,
+			`COPY t TO STDOUT WITH (DELIMITER '|', NULL '')`},
+
 		// Alternative forms for table patterns.
 
 		{`SHOW GRANTS ON foo`,
@@ -1947,11 +2116,25 @@ func TestParse2(t *testing.T) {
 		},
 
 		{`ALTER TABLE a ALTER b DROP STORED`, `ALTER TABLE a ALTER COLUMN b DROP STORED`},
+		{
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) DEFERRABLE INITIALLY IMMEDIATE)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) DEFERRABLE)`,
+		},
+		{
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) INITIALLY DEFERRED)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) DEFERRABLE INITIALLY DEFERRED)`,
+		},
+		{
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) INITIALLY IMMEDIATE)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x))`,
+		},
+
 		{`ALTER TABLE a ADD b INT8`, `ALTER TABLE a ADD COLUMN b INT8`},
 		{`ALTER TABLE a ADD IF NOT EXISTS b INT8`, `ALTER TABLE a ADD COLUMN IF NOT EXISTS b INT8`},
 		{`ALTER TABLE a ADD b INT8 FAMILY fam_a`, `ALTER TABLE a ADD COLUMN b INT8 FAMILY fam_a`},
 		{`ALTER TABLE a DROP b`, `ALTER TABLE a DROP COLUMN b`},
 		{`ALTER TABLE a ALTER b DROP NOT NULL`, `ALTER TABLE a ALTER COLUMN b DROP NOT NULL`},
+		{`ALTER TABLE a ALTER b SET NOT NULL`, `ALTER TABLE a ALTER COLUMN b SET NOT NULL`},
 		{`ALTER TABLE a ALTER b TYPE INT8`, `ALTER TABLE a ALTER COLUMN b SET DATA TYPE INT8`},
 		{`EXPLAIN ANALYZE SELECT 1`, `EXPLAIN ANALYZE (DISTSQL) SELECT 1`},
 
@@ -2167,6 +2350,16 @@ HINT: try \h CREATE INDEX`},
 CREATE VIEW a
              ^
 HINT: try \h CREATE VIEW`},
+		{`CREATE FUNCTION f() RETURNS INT LANGUAGE sql LANGUAGE sql AS 'SELECT 1'`,
+			`conflicting or redundant options at or near "sql"
+CREATE FUNCTION f() RETURNS INT LANGUAGE sql LANGUAGE sql AS 'SELECT 1'
+                                                      ^
+`},
+		{`COPY t TO STDOUT WITH (HEADER, HEADER)`,
+			`conflicting or redundant options at or near "header"
+COPY t TO STDOUT WITH (HEADER, HEADER)
+                               ^
+`},
 		{`CREATE VIEW a () AS select * FROM b`,
 			`syntax error at or near ")"
 CREATE VIEW a () AS select * FROM b
@@ -2183,11 +2376,6 @@ HINT: try \h SELECT`},
 SELECT 1e-
        ^
 HINT: try \h SELECT`},
-		{"SELECT foo''",
-			`type does not exist at or near ""
-SELECT foo''
-          ^
-`},
 		{
 			`SELECT 0x FROM t`,
 			`invalid hexadecimal numeric literal
@@ -2278,20 +2466,6 @@ HINT: try \h INSERT`,
 ALTER TABLE t RENAME COLUMN x TO family
                                  ^
 HINT: try \h ALTER TABLE`,
-		},
-		{
-			`SELECT CAST(1.2+2.3 AS notatype)`,
-			`type does not exist at or near "notatype"
-SELECT CAST(1.2+2.3 AS notatype)
-                       ^
-`,
-		},
-		{
-			`SELECT ANNOTATE_TYPE(1.2+2.3, notatype)`,
-			`type does not exist at or near "notatype"
-SELECT ANNOTATE_TYPE(1.2+2.3, notatype)
-                              ^
-`,
 		},
 		{
 			`CREATE USER foo WITH PASSWORD`,
@@ -2354,13 +2528,6 @@ SELECT EXISTS(SELECT 1)[1]
 			`+ ANY <array> is invalid because "+" is not a boolean operator at or near "EOF"
 SELECT 1 + ANY ARRAY[1, 2, 3]
                              ^
-`,
-		},
-		{
-			`SELECT 'f'::"blah"`,
-			`type does not exist at or near "blah"
-SELECT 'f'::"blah"
-            ^
 `,
 		},
 		// Ensure that the support for ON ROLE <namelist> doesn't leak
@@ -2728,29 +2895,24 @@ func TestUnimplementedSyntax(t *testing.T) {
 		expected string
 	}{
 		{`ALTER TABLE a ALTER CONSTRAINT foo`, 31632, `alter constraint`},
-		{`ALTER TABLE a ALTER b SET NOT NULL`, 28751, ``},
 		{`ALTER TABLE a RENAME CONSTRAINT b TO c`, 32555, ``},
 
 		{`CREATE AGGREGATE a`, 0, `create aggregate`},
 		{`CREATE CAST a`, 0, `create cast`},
-		{`CREATE CONSTRAINT TRIGGER a`, 28296, `create constraint`},
 		{`CREATE CONVERSION a`, 0, `create conversion`},
 		{`CREATE DEFAULT CONVERSION a`, 0, `create def conv`},
 		{`CREATE EXTENSION a`, 0, `create extension a`},
 		{`CREATE FOREIGN DATA WRAPPER a`, 0, `create fdw`},
 		{`CREATE FOREIGN TABLE a`, 0, `create foreign table`},
-		{`CREATE FUNCTION a`, 17511, `create`},
-		{`CREATE OR REPLACE FUNCTION a`, 17511, `create`},
 		{`CREATE LANGUAGE a`, 17511, `create language a`},
-		{`CREATE MATERIALIZED VIEW a`, 24747, ``},
 		{`CREATE OPERATOR a`, 0, `create operator`},
 		{`CREATE PUBLICATION a`, 0, `create publication`},
 		{`CREATE RULE a`, 0, `create rule`},
-		{`CREATE SCHEMA a`, 26443, `create`},
 		{`CREATE SERVER a`, 0, `create server`},
 		{`CREATE SUBSCRIPTION a`, 0, `create subscription`},
 		{`CREATE TEXT SEARCH a`, 7821, `create text`},
-		{`CREATE TRIGGER a`, 28296, `create`},
+		{`CREATE TRIGGER a BEFORE TRUNCATE ON b EXECUTE FUNCTION f()`, 28296, `truncate`},
+		{`CREATE TRIGGER a BEFORE UPDATE OF c ON b EXECUTE FUNCTION f()`, 28296, `update of`},
 
 		{`DROP AGGREGATE a`, 0, `drop aggregate`},
 		{`DROP CAST a`, 0, `drop cast`},
@@ -2760,28 +2922,20 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`DROP EXTENSION a`, 0, `drop extension a`},
 		{`DROP FOREIGN TABLE a`, 0, `drop foreign table`},
 		{`DROP FOREIGN DATA WRAPPER a`, 0, `drop fdw`},
-		{`DROP FUNCTION a`, 17511, `drop `},
 		{`DROP LANGUAGE a`, 17511, `drop language a`},
 		{`DROP OPERATOR a`, 0, `drop operator`},
 		{`DROP PUBLICATION a`, 0, `drop publication`},
 		{`DROP RULE a`, 0, `drop rule`},
-		{`DROP SCHEMA a`, 26443, `drop`},
 		{`DROP SERVER a`, 0, `drop server`},
 		{`DROP SUBSCRIPTION a`, 0, `drop subscription`},
 		{`DROP TEXT SEARCH a`, 7821, `drop text`},
-		{`DROP TRIGGER a`, 28296, `drop`},
-		{`DROP TYPE a`, 27793, `drop type`},
 
 		{`DISCARD PLANS`, 0, `discard plans`},
 		{`DISCARD SEQUENCES`, 0, `discard sequences`},
-		{`DISCARD TEMP`, 0, `discard temp`},
-		{`DISCARD TEMPORARY`, 0, `discard temp`},
 
-		{`SET CONSTRAINTS foo`, 0, `set constraints`},
 		{`SET LOCAL foo = bar`, 32562, ``},
 		{`SET foo FROM CURRENT`, 0, `set from current`},
 
-		{`CREATE TEMP TABLE a(b INT8)`, 5807, ``},
 		{`CREATE UNLOGGED TABLE a(b INT8)`, 0, `create unlogged`},
 		{`CREATE TEMP VIEW a AS SELECT b`, 5807, ``},
 		{`CREATE TEMP SEQUENCE a`, 5807, ``},
@@ -2797,13 +2951,8 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE TABLE a(b INT8 REFERENCES c(x) MATCH PARTIAL`, 20305, `match partial`},
 		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) MATCH PARTIAL)`, 20305, `match partial`},
 
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE)`, 31632, `deferrable`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) INITIALLY DEFERRED)`, 31632, `initially deferred`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) INITIALLY IMMEDIATE)`, 31632, `initially immediate`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE INITIALLY DEFERRED)`, 31632, `initially deferred`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE INITIALLY IMMEDIATE)`, 31632, `initially immediate`},
-		{`CREATE TABLE a(b INT8, UNIQUE (b) DEFERRABLE)`, 31632, `deferrable`},
-		{`CREATE TABLE a(b INT8, CHECK (b > 0) DEFERRABLE)`, 31632, `deferrable`},
+		{`CREATE TABLE a(b INT8, UNIQUE (b) DEFERRABLE)`, 31632, `deferrable unique`},
+		{`CREATE TABLE a(b INT8, CHECK (b > 0) DEFERRABLE)`, 31632, `deferrable check`},
 
 		{`CREATE SEQUENCE a AS DOUBLE PRECISION`, 25110, `FLOAT8`},
 		{`CREATE SEQUENCE a OWNED BY b`, 26382, ``},
@@ -2812,13 +2961,11 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE RECURSIVE VIEW a AS SELECT b`, 0, `create recursive view`},
 
 		{`CREATE TYPE a AS (b)`, 27792, ``},
-		{`CREATE TYPE a AS ENUM (b)`, 24873, ``},
 		{`CREATE TYPE a AS RANGE b`, 27791, ``},
 		{`CREATE TYPE a (b)`, 27793, `base`},
 		{`CREATE TYPE a`, 27793, `shell`},
 		{`CREATE DOMAIN a`, 27796, `create`},
 
-		{`CREATE INDEX a ON b(c) WHERE d > 0`, 9683, ``},
 		{`CREATE INDEX a ON b USING HASH (c)`, 0, `index using hash`},
 		{`CREATE INDEX a ON b USING GIST (c)`, 0, `index using gist`},
 		{`CREATE INDEX a ON b USING SPGIST (c)`, 0, `index using spgist`},
@@ -2829,35 +2976,13 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE INDEX a ON b(foo(c))`, 9682, ``},
 
 		{`INSERT INTO foo(a, a.b) VALUES (1,2)`, 27792, ``},
-		{`INSERT INTO foo VALUES (1,2) ON CONFLICT ON CONSTRAINT a DO NOTHING`, 28161, ``},
 
-		{`SELECT * FROM ab, LATERAL (SELECT * FROM kv)`, 24560, `select`},
-		{`SELECT * FROM ab, LATERAL foo(a)`, 24560, `srf`},
 		{`SELECT max(a ORDER BY b) FROM ab`, 23620, ``},
 
-		{`SELECT * FROM a FOR UPDATE`, 6583, ``},
 		{`SELECT * FROM ROWS FROM (a(b) AS (d))`, 0, `ROWS FROM with col_def_list`},
 
-		{`SELECT 123 AT TIME ZONE 'b'`, 32005, ``},
-
 		{`SELECT 'a'::INTERVAL SECOND`, 0, `interval with unit qualifier`},
-		{`SELECT 'a'::INTERVAL(123)`, 32564, ``},
 		{`SELECT 'a'::INTERVAL SECOND(123)`, 32564, `interval second`},
-		{`SELECT INTERVAL(3) 'a'`, 32564, ``},
-
-		{`SELECT 'a'::TIMESTAMP(123)`, 32098, ``},
-		{`SELECT 'a'::TIMESTAMP(123) WITHOUT TIME ZONE`, 32098, ``},
-		{`SELECT 'a'::TIMESTAMPTZ(123)`, 32098, ``},
-		{`SELECT 'a'::TIMESTAMP(123) WITH TIME ZONE`, 32098, ``},
-		{`SELECT TIMESTAMP(3) 'a'`, 32098, ``},
-		{`SELECT TIMESTAMPTZ(3) 'a'`, 32098, ``},
-
-		{`SELECT 'a'::TIME(123)`, 32565, ``},
-		{`SELECT 'a'::TIME(123) WITHOUT TIME ZONE`, 32565, ``},
-		{`SELECT 'a'::TIMETZ(123)`, 26097, `type with precision`},
-		{`SELECT 'a'::TIME(123) WITH TIME ZONE`, 32565, ``},
-		{`SELECT TIME(3) 'a'`, 32565, ``},
-		{`SELECT TIMETZ(3) 'a'`, 26097, `type with precision`},
 
 		{`SELECT a(b) 'c'`, 0, `a(...) SCONST`},
 		{`SELECT (a,b) OVERLAPS (c,d)`, 0, `overlaps`},
@@ -2866,10 +2991,7 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`SELECT a(VARIADIC b)`, 0, `variadic`},
 		{`SELECT a(b, c, VARIADIC b)`, 0, `variadic`},
 		{`SELECT COLLATION FOR (a)`, 32563, ``},
-		{`SELECT CURRENT_TIME`, 26097, `current_time`},
-		{`SELECT CURRENT_TIME()`, 26097, `current_time`},
 		{`SELECT TREAT (a AS INT8)`, 0, `treat`},
-		{`SELECT a(b) WITHIN GROUP (ORDER BY c)`, 0, `within group`},
 
 		{`CREATE TABLE a(b BOX)`, 21286, `box`},
 		{`CREATE TABLE a(b CIDR)`, 18846, `cidr`},
@@ -2883,19 +3005,11 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE TABLE a(b PG_LSN)`, 0, `pg_lsn`},
 		{`CREATE TABLE a(b POINT)`, 21286, `point`},
 		{`CREATE TABLE a(b POLYGON)`, 21286, `polygon`},
-		{`CREATE TABLE a(b TSQUERY)`, 7821, `tsquery`},
-		{`CREATE TABLE a(b TSVECTOR)`, 7821, `tsvector`},
 		{`CREATE TABLE a(b TXID_SNAPSHOT)`, 0, `txid_snapshot`},
 		{`CREATE TABLE a(b XML)`, 0, `xml`},
-		{`CREATE TABLE a(b TIMETZ)`, 26097, `type`},
-
-		{`INSERT INTO a VALUES (1) ON CONFLICT (x) WHERE x > 3 DO NOTHING`, 32557, ``},
-
-		{`WITH RECURSIVE a AS (TABLE b) SELECT c`, 21085, ``},
 
 		{`UPDATE foo SET (a, a.b) = (1, 2)`, 27792, ``},
 		{`UPDATE foo SET a.b = 1`, 27792, ``},
-		{`UPDATE foo SET x = y FROM a, b`, 7841, ``},
 		{`UPDATE Foo SET x.y = z`, 27792, ``},
 
 		{`UPSERT INTO foo(a, a.b) VALUES (1,2)`, 27792, ``},
@@ -40,6 +40,10 @@ const MaxUint = ^uint(0)
 // MaxInt is the maximum value of an int.
 const MaxInt = int(MaxUint >> 1)
 
+// deferrableHint is the hint of the errors about DEFERRABLE constraints other
+// than foreign keys.
+const deferrableHint = "Only FOREIGN KEY constraints can be deferred. UNIQUE and CHECK constraints are always checked immediately."
+
 func unimplemented(sqllex sqlLexer, feature string) int {
     sqllex.(*lexer).Unimplemented(feature)
     return 1
@@ -54,6 +58,11 @@ func unimplementedWithIssueDetail(sqllex sqlLexer, issue int, detail string) int
     sqllex.(*lexer).UnimplementedWithIssueDetail(issue, detail)
     return 1
 }
+
+func unimplementedWithIssueDetailHint(sqllex sqlLexer, issue int, detail, feature, hint string) int {
+    sqllex.(*lexer).UnimplementedWithIssueDetailHint(issue, detail, feature, hint)
+    return 1
+}
 %}
 
 %{
@@ -427,6 +436,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -446,6 +458,48 @@ func (u *sqlSymUnion) resolvableFuncRefFromName() tree.ResolvableFunctionReferen
 func (u *sqlSymUnion) rowsFromExpr() *tree.RowsFromExpr {
     return u.val.(*tree.RowsFromExpr)
 }
+func (u *sqlSymUnion) lockingClause() tree.LockingClause {
+    return u.val.(tree.LockingClause)
+}
+func (u *sqlSymUnion) lockingItem() *tree.LockingItem {
+    return u.val.(*tree.LockingItem)
+}
+func (u *sqlSymUnion) lockingStrength() tree.LockingStrength {
+    return u.val.(tree.LockingStrength)
+}
+func (u *sqlSymUnion) lockingWaitPolicy() tree.LockingWaitPolicy {
+    return u.val.(tree.LockingWaitPolicy)
+}
+func (u *sqlSymUnion) funcArg() tree.FuncArg {
+    return u.val.(tree.FuncArg)
+}
+func (u *sqlSymUnion) funcArgs() tree.FuncArgs {
+    return u.val.(tree.FuncArgs)
+}
+func (u *sqlSymUnion) functionOptions() tree.FunctionOptions {
+    return u.val.(tree.FunctionOptions)
+}
+func (u *sqlSymUnion) copyOptions() tree.CopyOptions {
+    return u.val.(tree.CopyOptions)
+}
+func (u *sqlSymUnion) funcRef() tree.FuncRef {
+    return u.val.(tree.FuncRef)
+}
+func (u *sqlSymUnion) funcRefs() tree.FuncRefs {
+    return u.val.(tree.FuncRefs)
+}
+func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacement {
+    return u.val.(*tree.AlterTypeAddValuePlacement)
+}
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEvent {
+    return u.val.(tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
 func newNameFromStr(s string) *tree.Name {
     return (*tree.Name)(&s)
 }
@@ -459,7 +513,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <*tree.NumVal> ICONST FCONST
 %token <*tree.Placeholder> PLACEHOLDER
 %token <str> TYPECAST TYPEANNOTATE DOT_DOT
-%token <str> LESS_EQUALS GREATER_EQUALS NOT_EQUALS
+%token <str> LESS_EQUALS GREATER_EQUALS NOT_EQUALS AT_AT
 %token <str> NOT_REGMATCH REGIMATCH NOT_REGIMATCH
 %token <str> ERROR
 
@@ -468,27 +522,27 @@ func newNameFromStr(s string) *tree.Name {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ACTION ADD ADMIN AGGREGATE
+%token <str> ABORT ACTION ADD ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ANALYSE ANALYZE AND ANY ANNOTATE_TYPE ARRAY AS ASC
 %token <str> ASYMMETRIC AT
 
-%token <str> BACKUP BEGIN BETWEEN BIGINT BIGSERIAL BIT
+%token <str> BACKUP BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BIT
 %token <str> BLOB BOOL BOOLEAN BOTH BY BYTEA BYTES
 
-%token <str> CACHE CANCEL CASCADE CASE CAST CHANGEFEED CHAR
+%token <str> CACHE CALLED CANCEL CASCADE CASE CAST CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMIT
 %token <str> COMMITTED COMPACT CONCAT CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONSTRAINT CONSTRAINTS CONTAINS CONVERSION COPY COVERING CREATE
-%token <str> CROSS CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
+%token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT
-%token <str> DEALLOCATE DEFERRABLE DEFERRED DELETE DESC
+%token <str> DEALLOCATE DEFERRABLE DEFERRED DELETE DELIMITER DESC
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING END ENUM ESCAPE EXCEPT
+%token <str> EACH ELSE ENCODING END ENUM ESCAPE EXCEPT
 %token <str> EXISTS EXECUTE EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT
@@ -496,16 +550,16 @@ func newNameFromStr(s string) *tree.Name {
 
 %token <str> FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
 %token <str> FILES FILTER
-%token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE_INDEX FOREIGN FROM FULL FUNCTION
+%token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE_INDEX FOREIGN FORMAT FROM FULL FUNCTION
 
 %token <str> GLOBAL GRANT GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HAVING HIGH HISTOGRAM HOUR
+%token <str> HAVING HEADER HIGH HISTOGRAM HOUR
 
-%token <str> IMMEDIATE IMPORT INCREMENT INCREMENTAL IF IFERROR IFNULL ILIKE IN ISERROR
+%token <str> IMMEDIATE IMMUTABLE IMPORT INCREMENT INCREMENTAL IF IFERROR IFNULL ILIKE IN ISERROR
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS INET_CONTAINS_OR_CONTAINED_BY
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INJECT INTERLEAVE INITIALLY
-%token <str> INNER INSERT INT INT2VECTOR INT2 INT4 INT8 INT64 INTEGER
+%token <str> INNER INPUT INSERT INT INT2VECTOR INT2 INT4 INT8 INT64 INTEGER
 %token <str> INTERSECT INTERVAL INTO INVERTED IS ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -514,34 +568,34 @@ func newNameFromStr(s string) *tree.Name {
 
 %token <str> LANGUAGE LATERAL LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEFT LESS LEVEL LIKE LIMIT LIST LOCAL
-%token <str> LOCALTIME LOCALTIMESTAMP LOW LSHIFT
+%token <str> LOCALTIME LOCALTIMESTAMP LOCKED LOW LSHIFT
 
 %token <str> MATCH MATERIALIZED MINVALUE MAXVALUE MINUTE MONTH
 
 %token <str> NAN NAME NAMES NATURAL NEXT NO NO_INDEX_JOIN NORMAL
-%token <str> NOT NOTHING NOTNULL NULL NULLIF NUMERIC
+%token <str> NOT NOTHING NOTNULL NOWAIT NULL NULLIF NUMERIC
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR ON ONLY OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OUT OUTER OVER OVERLAPS OVERLAY OWNED OPERATOR
 
 %token <str> PARENT PARTIAL PARTITION PASSWORD PAUSE PHYSICAL PLACING
 %token <str> PLANS POSITION PRECEDING PRECISION PREPARE PRIMARY PRIORITY
-%token <str> PROCEDURAL PUBLICATION
+%token <str> PROCEDURAL PROCEDURE PUBLICATION
 
 %token <str> QUERIES QUERY
 
 %token <str> RANGE RANGES READ REAL RECURSIVE REF REFERENCES
-%token <str> REGCLASS REGPROC REGPROCEDURE REGNAMESPACE REGTYPE
+%token <str> REFRESH REGCLASS REGPROC REGPROCEDURE REGNAMESPACE REGTYPE
 %token <str> REMOVE_PATH RENAME REPEATABLE REPLACE
-%token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING REVOKE RIGHT
+%token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING RETURNS REVOKE RIGHT
 %token <str> ROLE ROLES ROLLBACK ROLLUP ROW ROWS RSHIFT RULE
 
 %token <str> SAVEPOINT SCATTER SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
 %token <str> SERIAL SERIAL2 SERIAL4 SERIAL8
 %token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETTING SETTINGS
-%token <str> SHOW SIMILAR SIMPLE SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
+%token <str> SHARE SHOW SIMILAR SIMPLE SKIP SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL STABLE
 
-%token <str> START STATISTICS STATUS STDIN STRICT STRING STORE STORED STORING SUBSTRING
+%token <str> START STATEMENT STATISTICS STATUS STDIN STDOUT STRICT STRING STORE STORED STORING SUBSTRING
 %token <str> SYMMETRIC SYNTAX SYSTEM SUBSCRIPTION
 
 %token <str> TABLE TABLES TEMP TEMPLATE TEMPORARY TESTING_RANGES EXPERIMENTAL_RANGES TESTING_RELOCATE EXPERIMENTAL_RELOCATE TEXT THEN
@@ -552,7 +606,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLOGGED
 %token <str> UPDATE UPSERT USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIRTUAL
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIRTUAL VOLATILE
 
 %token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
 
@@ -644,6 +698,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> comment_stmt
 %type <tree.Statement> commit_stmt
 %type <tree.Statement> copy_from_stmt
+%type <tree.Statement> copy_to_stmt
 
 %type <tree.Statement> create_stmt
 %type <tree.Statement> create_changefeed_stmt
@@ -655,9 +710,15 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> create_table_as_stmt
 %type <tree.Statement> create_user_stmt
 %type <tree.Statement> create_view_stmt
+%type <tree.Statement> create_function_stmt
+%type <tree.Statement> create_trigger_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_stats_stmt
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_schema_stmt
+%type <tree.Statement> alter_type_stmt
+%type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -669,6 +730,8 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_user_stmt
 %type <tree.Statement> drop_view_stmt
+%type <tree.Statement> drop_function_stmt
+%type <tree.Statement> drop_trigger_stmt
 %type <tree.Statement> drop_sequence_stmt
 
 %type <tree.Statement> explain_stmt
@@ -681,6 +744,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> insert_stmt
 %type <tree.Statement> import_stmt
 %type <tree.Statement> pause_stmt
+%type <tree.Statement> refresh_stmt
 %type <tree.Statement> release_stmt
 %type <tree.Statement> reset_stmt reset_session_stmt reset_csetting_stmt
 %type <tree.Statement> resume_stmt
@@ -695,6 +759,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -799,13 +864,13 @@ func newNameFromStr(s string) *tree.Name {
 %type <bool> distinct_clause
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns
-%type <tree.OrderBy> sort_clause opt_sort_clause
+%type <tree.OrderBy> sort_clause opt_sort_clause within_group_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params
 %type <tree.NameList> name_list privilege_list
 %type <[]int32> opt_array_bounds
-%type <*tree.From> from_clause update_from_clause
-%type <tree.TableExprs> from_list rowsfrom_list
+%type <*tree.From> from_clause
+%type <tree.TableExprs> from_list rowsfrom_list update_from_clause opt_using_clause
 %type <tree.TablePatterns> table_pattern_list single_table_pattern_list
 %type <tree.TableNames> table_name_list
 %type <tree.Exprs> expr_list opt_expr_list tuple1_ambiguous_values tuple1_unambiguous_values
@@ -816,9 +881,31 @@ func newNameFromStr(s string) *tree.Name {
 %type <*tree.UpdateExpr> set_clause multiple_set_clause
 %type <tree.ArraySubscripts> array_subscripts
 %type <tree.GroupBy> group_clause
-%type <*tree.Limit> select_limit
+%type <*tree.Limit> select_limit opt_select_limit
+%type <tree.LockingClause> for_locking_clause opt_for_locking_clause for_locking_items
+%type <*tree.LockingItem> for_locking_item
+%type <tree.LockingStrength> for_locking_strength
+%type <tree.LockingWaitPolicy> opt_nowait_or_skip
+%type <tree.TableNames> opt_locked_rels
 %type <tree.TableNames> relation_expr_list
 %type <tree.ReturningClause> returning_clause
+%type <bool> opt_or_replace
+%type <bool> opt_temp
+%type <bool> constraints_set_mode
+%type <tree.FuncArgs> opt_func_arg_list func_arg_list
+%type <tree.FuncArg> func_arg
+%type <tree.FunctionOptions> func_option_list func_option
+%type <tree.CopyOptions> opt_copy_options copy_option_list copy_option copy_legacy_option_list copy_legacy_option
+%type <bool> opt_constraint trigger_for_each
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvents> trigger_events
+%type <tree.TriggerEvent> trigger_event
+%type <[]string> opt_trigger_arg_list trigger_arg_list
+%type <str> trigger_arg
+%type <tree.FuncRefs> func_ref_list
+%type <tree.FuncRef> func_ref
+%type <[]string> opt_enum_val_list enum_val_list
+%type <*tree.AlterTypeAddValuePlacement> opt_add_val_placement
 
 %type <[]tree.SequenceOption> sequence_option_list opt_sequence_option_list
 %type <tree.SequenceOption> sequence_option_elem
@@ -848,8 +935,8 @@ func newNameFromStr(s string) *tree.Name {
 %type <empty> first_or_next
 
 %type <tree.Statement> insert_rest
-%type <tree.NameList> opt_conf_expr opt_col_def_list
-%type <*tree.OnConflict> on_conflict
+%type <tree.NameList> opt_col_def_list
+%type <*tree.OnConflict> on_conflict opt_conf_expr
 
 %type <tree.Statement> begin_transaction
 %type <tree.TransactionModes> transaction_mode_list transaction_mode
@@ -857,7 +944,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.NameList> opt_storing
 %type <*tree.ColumnTableDef> column_def
 %type <tree.TableDef> table_elem
-%type <tree.Expr> where_clause opt_where_clause
+%type <tree.Expr> where_clause opt_where_clause opt_idx_where
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
@@ -931,17 +1018,18 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.NamedColumnQualification> col_qualification
 %type <tree.ColumnQualification> col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
 %type <tree.Expr> func_application func_expr_common_subexpr special_function
 %type <tree.Expr> func_expr func_expr_windowless
 %type <empty> opt_with
+%type <empty> opt_as
 %type <*tree.With> with_clause opt_with_clause
 %type <[]*tree.CTE> cte_list
 %type <*tree.CTE> common_table_expr
 
-%type <empty> within_group_clause
 %type <tree.Expr> filter_clause
 %type <tree.Exprs> opt_partition_clause
 %type <tree.Window> window_clause window_definition_list
@@ -974,7 +1062,7 @@ func newNameFromStr(s string) *tree.Name {
 %left      AND
 %right     NOT
 %nonassoc  IS ISNULL NOTNULL   // IS sets precedence for IS NULL, etc
-%nonassoc  '<' '>' '=' LESS_EQUALS GREATER_EQUALS NOT_EQUALS CONTAINS CONTAINED_BY '?' JSON_SOME_EXISTS JSON_ALL_EXISTS
+%nonassoc  '<' '>' '=' LESS_EQUALS GREATER_EQUALS NOT_EQUALS CONTAINS CONTAINED_BY '?' JSON_SOME_EXISTS JSON_ALL_EXISTS AT_AT
 %nonassoc  '~' BETWEEN IN LIKE ILIKE SIMILAR NOT_REGMATCH REGIMATCH NOT_REGIMATCH NOT_LA
 %nonassoc  ESCAPE              // ESCAPE must be just above LIKE/ILIKE/SIMILAR
 %nonassoc  OVERLAPS
@@ -1041,6 +1129,7 @@ stmt:
   HELPTOKEN { return helpWith(sqllex, "") }
 | preparable_stmt  // help texts in sub-rule
 | copy_from_stmt
+| copy_to_stmt
 | comment_stmt
 | execute_stmt      // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt   // EXTEND WITH HELP: DEALLOCATE
@@ -1060,7 +1149,7 @@ stmt:
 
 // %Help: ALTER
 // %Category: Group
-// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER
+// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER TYPE
 alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_user_stmt     // EXTEND WITH HELP: ALTER USER
@@ -1073,6 +1162,7 @@ alter_ddl_stmt:
 | alter_sequence_stmt // EXTEND WITH HELP: ALTER SEQUENCE
 | alter_database_stmt // EXTEND WITH HELP: ALTER DATABASE
 | alter_range_stmt    // EXTEND WITH HELP: ALTER RANGE
+| alter_type_stmt     // EXTEND WITH HELP: ALTER TYPE
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -1126,6 +1216,50 @@ alter_table_stmt:
 | ALTER TABLE error     // SHOW HELP: ALTER TABLE
 | ALTER PARTITION error // SHOW HELP: ALTER TABLE
 
+// %Help: ALTER TYPE - change the definition of a type
+// %Category: DDL
+// %Text:
+// ALTER TYPE <typename> ADD VALUE [IF NOT EXISTS] <label> [ { BEFORE | AFTER } <label> ]
+// %SeeAlso: CREATE TYPE, DROP TYPE
+alter_type_stmt:
+  ALTER TYPE type_name ADD VALUE SCONST opt_add_val_placement
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName().ToTableName(),
+      Cmd: &tree.AlterTypeAddValue{
+        NewVal: $6,
+        Placement: $7.alterTypeAddValuePlacement(),
+      },
+    }
+  }
+| ALTER TYPE type_name ADD VALUE IF NOT EXISTS SCONST opt_add_val_placement
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName().ToTableName(),
+      Cmd: &tree.AlterTypeAddValue{
+        NewVal: $9,
+        IfNotExists: true,
+        Placement: $10.alterTypeAddValuePlacement(),
+      },
+    }
+  }
+| ALTER TYPE type_name RENAME VALUE error { return unimplementedWithIssueDetail(sqllex, 24873, "rename value") }
+| ALTER TYPE error // SHOW HELP: ALTER TYPE
+
+opt_add_val_placement:
+  BEFORE SCONST
+  {
+    $$.val = &tree.AlterTypeAddValuePlacement{Before: true, ExistingVal: $2}
+  }
+| AFTER SCONST
+  {
+    $$.val = &tree.AlterTypeAddValuePlacement{Before: false, ExistingVal: $2}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.AlterTypeAddValuePlacement)(nil)
+  }
+
 // %Help: ALTER VIEW - change the definition of a view
 // %Category: DDL
 // %Text:
@@ -1450,7 +1584,10 @@ alter_table_cmd:
     $$.val = &tree.AlterTableDropStored{Column: tree.Name($3)}
   }
   // ALTER TABLE <name> ALTER [COLUMN] <colname> SET NOT NULL
-| ALTER opt_column column_name SET NOT NULL { return unimplementedWithIssue(sqllex, 28751) }
+| ALTER opt_column column_name SET NOT NULL
+  {
+    $$.val = &tree.AlterTableSetNotNull{Column: tree.Name($3)}
+  }
   // ALTER TABLE <name> DROP [COLUMN] IF EXISTS <colname> [RESTRICT|CASCADE]
 | DROP opt_column IF EXISTS column_name opt_drop_behavior
   {
@@ -1836,6 +1973,106 @@ copy_from_stmt:
     }
   }
 
+copy_to_stmt:
+  COPY table_name opt_column_list TO STDOUT opt_copy_options
+  {
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyTo{
+       Table: name,
+       Columns: $3.nameList(),
+       Stdout: true,
+       Options: $6.copyOptions(),
+    }
+  }
+| COPY select_with_parens TO STDOUT opt_copy_options
+  {
+    $$.val = &tree.CopyTo{
+       Query: &tree.Select{Select: $2.selectStmt()},
+       Stdout: true,
+       Options: $5.copyOptions(),
+    }
+  }
+
+opt_copy_options:
+  opt_with '(' copy_option_list ')'
+  {
+    $$.val = $3.copyOptions()
+  }
+// The syntax used before PostgreSQL 9.0, which is still accepted by psql's
+// \copy command.
+| opt_with copy_legacy_option_list
+  {
+    $$.val = $2.copyOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.CopyOptions{}
+  }
+
+copy_option_list:
+  copy_option
+| copy_option_list ',' copy_option
+  {
+    opts := $1.copyOptions()
+    if err := opts.Combine($3.copyOptions()); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = opts
+  }
+
+copy_option:
+  FORMAT non_reserved_word_or_sconst
+  {
+    $$.val = tree.CopyOptions{DataFormat: tree.Name($2)}
+  }
+| DELIMITER SCONST
+  {
+    delimiter := $2
+    $$.val = tree.CopyOptions{Delimiter: &delimiter}
+  }
+| NULL SCONST
+  {
+    null := $2
+    $$.val = tree.CopyOptions{Null: &null}
+  }
+| HEADER
+  {
+    $$.val = tree.CopyOptions{Header: true}
+  }
+
+copy_legacy_option_list:
+  copy_legacy_option
+| copy_legacy_option_list copy_legacy_option
+  {
+    opts := $1.copyOptions()
+    if err := opts.Combine($2.copyOptions()); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = opts
+  }
+
+copy_legacy_option:
+  CSV
+  {
+    $$.val = tree.CopyOptions{DataFormat: "csv"}
+  }
+| DELIMITER opt_as SCONST
+  {
+    delimiter := $3
+    $$.val = tree.CopyOptions{Delimiter: &delimiter}
+  }
+| NULL opt_as SCONST
+  {
+    null := $3
+    $$.val = tree.CopyOptions{Null: &null}
+  }
+| HEADER
+  {
+    $$.val = tree.CopyOptions{Header: true}
+  }
+
 // %Help: CANCEL
 // %Category: Group
 // %Text: CANCEL JOBS, CANCEL QUERIES, CANCEL SESSIONS
@@ -1987,7 +2224,7 @@ comment_text:
 // %Text:
 // CREATE DATABASE, CREATE TABLE, CREATE INDEX, CREATE TABLE AS,
 // CREATE USER, CREATE VIEW, CREATE SEQUENCE, CREATE STATISTICS,
-// CREATE ROLE
+// CREATE ROLE, CREATE FUNCTION, CREATE TRIGGER, CREATE TYPE, CREATE SCHEMA
 create_stmt:
   create_user_stmt     // EXTEND WITH HELP: CREATE USER
 | create_role_stmt     // EXTEND WITH HELP: CREATE ROLE
@@ -1999,29 +2236,29 @@ create_stmt:
 create_unsupported:
   CREATE AGGREGATE error { return unimplemented(sqllex, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
-| CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
 | CREATE EXTENSION IF NOT EXISTS name error { return unimplemented(sqllex, "create extension " + $6) }
 | CREATE EXTENSION name error { return unimplemented(sqllex, "create extension " + $3) }
 | CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
-| CREATE FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "create function") }
-| CREATE OR REPLACE FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "create function") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
-| CREATE MATERIALIZED VIEW error { return unimplementedWithIssue(sqllex, 24747) }
 | CREATE OPERATOR error { return unimplemented(sqllex, "create operator") }
 | CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SCHEMA error { return unimplementedWithIssueDetail(sqllex, 26443, "create") }
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create") }
 
 opt_or_replace:
-  OR REPLACE {}
-| /* EMPTY */ {}
+  OR REPLACE
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
 
 opt_trusted:
   TRUSTED {}
@@ -2041,17 +2278,13 @@ drop_unsupported:
 | DROP EXTENSION name error { return unimplemented(sqllex, "drop extension " + $3) }
 | DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
-| DROP FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "drop function") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
 | DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SCHEMA error { return unimplementedWithIssueDetail(sqllex, 26443, "drop") }
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TYPE error { return unimplementedWithIssueDetail(sqllex, 27793, "drop type") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_changefeed_stmt
@@ -2061,9 +2294,12 @@ create_ddl_stmt:
 | create_table_as_stmt // EXTEND WITH HELP: CREATE TABLE
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_temp TABLE error   // SHOW HELP: CREATE TABLE
-| create_type_stmt     { /* SKIP DOC */ }
+| create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_schema_stmt   // EXTEND WITH HELP: CREATE SCHEMA
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
+| create_function_stmt // EXTEND WITH HELP: CREATE FUNCTION
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 
 // %Help: CREATE STATISTICS - create a new table statistic (experimental)
 // %Category: Experimental
@@ -2159,28 +2395,41 @@ opt_changefeed_sink:
 
 // %Help: DELETE - delete rows from a table
 // %Category: DML
-// %Text: DELETE FROM <tablename> [WHERE <expr>]
+// %Text: DELETE FROM <tablename> [[AS] <name>]
+//               [USING <source> [, ...]]
+//               [WHERE <expr>]
 //               [ORDER BY <exprs...>]
 //               [LIMIT <expr>]
 //               [RETURNING <exprs...>]
 // %SeeAlso: WEBDOCS/delete.html
 delete_stmt:
-  opt_with_clause DELETE FROM table_name_expr_opt_alias_idx opt_where_clause opt_sort_clause opt_limit_clause returning_clause
+  opt_with_clause DELETE FROM table_name_expr_opt_alias_idx opt_using_clause opt_where_clause opt_sort_clause opt_limit_clause returning_clause
   {
     $$.val = &tree.Delete{
       With: $1.with(),
       Table: $4.tblExpr(),
-      Where: tree.NewWhere(tree.AstWhere, $5.expr()),
-      OrderBy: $6.orderBy(),
-      Limit: $7.limit(),
-      Returning: $8.retClause(),
+      Using: $5.tblExprs(),
+      Where: tree.NewWhere(tree.AstWhere, $6.expr()),
+      OrderBy: $7.orderBy(),
+      Limit: $8.limit(),
+      Returning: $9.retClause(),
     }
   }
 | opt_with_clause DELETE error // SHOW HELP: DELETE
 
+opt_using_clause:
+  USING from_list
+  {
+    $$.val = $2.tblExprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TableExprs(nil)
+  }
+
 // %Help: DISCARD - reset the session to its initial state
 // %Category: Cfg
-// %Text: DISCARD ALL
+// %Text: DISCARD { ALL | TEMP | TEMPORARY }
 discard_stmt:
   DISCARD ALL
   {
@@ -2188,15 +2437,21 @@ discard_stmt:
   }
 | DISCARD PLANS { return unimplemented(sqllex, "discard plans") }
 | DISCARD SEQUENCES { return unimplemented(sqllex, "discard sequences") }
-| DISCARD TEMP { return unimplemented(sqllex, "discard temp") }
-| DISCARD TEMPORARY { return unimplemented(sqllex, "discard temp") }
+| DISCARD TEMP
+  {
+    $$.val = &tree.Discard{Mode: tree.DiscardModeTemp}
+  }
+| DISCARD TEMPORARY
+  {
+    $$.val = &tree.Discard{Mode: tree.DiscardModeTemp}
+  }
 | DISCARD error // SHOW HELP: DISCARD
 
 // %Help: DROP
 // %Category: Group
 // %Text:
 // DROP DATABASE, DROP INDEX, DROP TABLE, DROP VIEW, DROP SEQUENCE,
-// DROP USER, DROP ROLE
+// DROP USER, DROP ROLE, DROP FUNCTION, DROP TRIGGER, DROP TYPE, DROP SCHEMA
 drop_stmt:
   drop_ddl_stmt      // help texts in sub-rule
 | drop_role_stmt     // EXTEND WITH HELP: DROP ROLE
@@ -2210,10 +2465,14 @@ drop_ddl_stmt:
 | drop_table_stmt    // EXTEND WITH HELP: DROP TABLE
 | drop_view_stmt     // EXTEND WITH HELP: DROP VIEW
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
+| drop_function_stmt // EXTEND WITH HELP: DROP FUNCTION
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
-// %Text: DROP VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %Text: DROP [MATERIALIZED] VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
 // %SeeAlso: WEBDOCS/drop-index.html
 drop_view_stmt:
   DROP VIEW table_name_list opt_drop_behavior
@@ -2224,7 +2483,124 @@ drop_view_stmt:
   {
     $$.val = &tree.DropView{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
   }
+| DROP MATERIALIZED VIEW table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $4.tableNames(),
+      IfExists: false,
+      DropBehavior: $5.dropBehavior(),
+      IsMaterialized: true,
+    }
+  }
+| DROP MATERIALIZED VIEW IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $6.tableNames(),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+      IsMaterialized: true,
+    }
+  }
 | DROP VIEW error // SHOW HELP: DROP VIEW
+| DROP MATERIALIZED VIEW error // SHOW HELP: DROP VIEW
+
+// %Help: DROP FUNCTION - remove a function
+// %Category: DDL
+// %Text: DROP FUNCTION [IF EXISTS] <name> [ ( [<argtype> [, ...]] ) ] [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FUNCTION
+drop_function_stmt:
+  DROP FUNCTION func_ref_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{Functions: $3.funcRefs(), IfExists: false, DropBehavior: $4.dropBehavior()}
+  }
+| DROP FUNCTION IF EXISTS func_ref_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{Functions: $5.funcRefs(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+func_ref_list:
+  func_ref
+  {
+    $$.val = tree.FuncRefs{$1.funcRef()}
+  }
+| func_ref_list ',' func_ref
+  {
+    $$.val = append($1.funcRefs(), $3.funcRef())
+  }
+
+func_ref:
+  db_object_name
+  {
+    $$.val = tree.FuncRef{Name: $1.unresolvedObjectName().ToTableName()}
+  }
+| db_object_name '(' ')'
+  {
+    $$.val = tree.FuncRef{Name: $1.unresolvedObjectName().ToTableName(), HasArgTypes: true}
+  }
+| db_object_name '(' type_list ')'
+  {
+    $$.val = tree.FuncRef{
+      Name: $1.unresolvedObjectName().ToTableName(),
+      HasArgTypes: true,
+      ArgTypes: $3.colTypes(),
+    }
+  }
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [IF EXISTS] <name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      IfExists: false,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: DROP TYPE - remove a type
+// %Category: DDL
+// %Text: DROP TYPE [IF EXISTS] <typename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TYPE, ALTER TYPE
+drop_type_stmt:
+  DROP TYPE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{Names: $3.tableNames(), IfExists: false, DropBehavior: $4.dropBehavior()}
+  }
+| DROP TYPE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP TYPE error // SHOW HELP: DROP TYPE
+
+// %Help: DROP SCHEMA - remove a schema
+// %Category: DDL
+// %Text: DROP SCHEMA [IF EXISTS] <schemaname> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE SCHEMA
+drop_schema_stmt:
+  DROP SCHEMA name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropSchema{Names: $3.nameList(), IfExists: false, DropBehavior: $4.dropBehavior()}
+  }
+| DROP SCHEMA IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropSchema{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP SCHEMA error // SHOW HELP: DROP SCHEMA
 
 // %Help: DROP SEQUENCE - remove a sequence
 // %Category: DDL
@@ -2395,6 +2771,7 @@ preparable_stmt:
 | import_stmt       // EXTEND WITH HELP: IMPORT
 | insert_stmt       // EXTEND WITH HELP: INSERT
 | pause_stmt        // EXTEND WITH HELP: PAUSE JOBS
+| refresh_stmt      // EXTEND WITH HELP: REFRESH
 | reset_stmt        // help texts in sub-rule
 | restore_stmt      // EXTEND WITH HELP: RESTORE
 | resume_stmt       // EXTEND WITH HELP: RESUME JOBS
@@ -2504,6 +2881,7 @@ deallocate_stmt:
 //
 // Targets:
 //   DATABASE <databasename> [, ...]
+//   SCHEMA <schemaname> [, ...]
 //   [TABLE] [<databasename> .] { <tablename> | * } [, ...]
 //
 // %SeeAlso: REVOKE, WEBDOCS/grant.html
@@ -2535,6 +2913,7 @@ grant_stmt:
 //
 // Targets:
 //   DATABASE <databasename> [, <databasename>]...
+//   SCHEMA <schemaname> [, ...]
 //   [TABLE] [<databasename> .] { <tablename> | * } [, ...]
 //
 // %SeeAlso: GRANT, WEBDOCS/revoke.html
@@ -2635,8 +3014,8 @@ use_stmt:
 // SET remainder, e.g. SET TRANSACTION
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
 | SET LOCAL error { return unimplementedWithIssue(sqllex, 32562) }
 
 // SET SESSION / SET CLUSTER SETTING
@@ -2820,6 +3199,35 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// The mode lasts until the end of the current transaction. Only
+// DEFERRABLE foreign key constraints can be deferred.
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -3387,9 +3795,11 @@ show_transaction_stmt:
   }
 | SHOW TRANSACTION error // SHOW HELP: SHOW TRANSACTION
 
-// %Help: SHOW CREATE - display the CREATE statement for a table, sequence or view
+// %Help: SHOW CREATE - display the CREATE statement for a table, sequence, view or function
 // %Category: DDL
-// %Text: SHOW CREATE [ TABLE | SEQUENCE | VIEW ] <tablename>
+// %Text:
+// SHOW CREATE [ TABLE | SEQUENCE | VIEW ] <tablename>
+// SHOW CREATE FUNCTION <funcname>
 // %SeeAlso: WEBDOCS/show-create-table.html
 show_create_stmt:
   SHOW CREATE table_name
@@ -3403,6 +3813,11 @@ show_create_stmt:
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.ShowCreate{Name: name}
   }
+| SHOW CREATE FUNCTION db_object_name
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.ShowCreateFunction{Name: name}
+  }
 | SHOW CREATE error // SHOW HELP: SHOW CREATE
 
 create_kw:
@@ -3690,6 +4105,14 @@ targets:
   {
     $$.val = tree.TargetList{Databases: $2.nameList()}
   }
+| FUNCTION func_ref_list
+  {
+    $$.val = tree.TargetList{Functions: $2.funcRefs()}
+  }
+| SCHEMA name_list
+  {
+    $$.val = tree.TargetList{Schemas: $2.nameList()}
+  }
 
 // target_roles is the variant of targets which recognizes ON ROLES
 // with a name list. This cannot be included in targets directly
@@ -3733,11 +4156,23 @@ pause_stmt:
   }
 | PAUSE error // SHOW HELP: PAUSE JOBS
 
+// %Help: REFRESH - recompute the contents of a materialized view
+// %Category: DDL
+// %Text: REFRESH MATERIALIZED VIEW <viewname>
+// %SeeAlso: CREATE VIEW, SHOW JOBS
+refresh_stmt:
+  REFRESH MATERIALIZED VIEW view_name
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.RefreshMaterializedView{Name: name}
+  }
+| REFRESH error // SHOW HELP: REFRESH
+
 // %Help: CREATE TABLE - create a new table
 // %Category: DDL
 // %Text:
-// CREATE TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<interleave>]
-// CREATE TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source>
+// CREATE [TEMP] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<interleave>]
+// CREATE [TEMP] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source>
 //
 // Table elements:
 //    <name> <type> [<qualifiers...>]
@@ -3772,6 +4207,7 @@ create_table_stmt:
     $$.val = &tree.CreateTable{
       Table: name,
       IfNotExists: false,
+      Temporary: $2.bool(),
       Interleave: $8.interleave(),
       Defs: $6.tblDefs(),
       AsSource: nil,
@@ -3785,6 +4221,7 @@ create_table_stmt:
     $$.val = &tree.CreateTable{
       Table: name,
       IfNotExists: true,
+      Temporary: $2.bool(),
       Interleave: $11.interleave(),
       Defs: $9.tblDefs(),
       AsSource: nil,
@@ -3805,6 +4242,7 @@ create_table_as_stmt:
     $$.val = &tree.CreateTable{
       Table: name,
       IfNotExists: false,
+      Temporary: $2.bool(),
       Interleave: nil,
       Defs: nil,
       AsSource: $8.slct(),
@@ -3817,6 +4255,7 @@ create_table_as_stmt:
     $$.val = &tree.CreateTable{
       Table: name,
       IfNotExists: true,
+      Temporary: $2.bool(),
       Interleave: nil,
       Defs: nil,
       AsSource: $11.slct(),
@@ -3841,6 +4280,18 @@ opt_create_as_data:
  * so we'll probably continue to treat LOCAL as a noise word.
  */
 opt_temp:
+  TEMPORARY         { $$.val = true }
+| TEMP              { $$.val = true }
+| LOCAL TEMPORARY   { $$.val = true }
+| LOCAL TEMP        { $$.val = true }
+| GLOBAL TEMPORARY  { $$.val = true }
+| GLOBAL TEMP       { $$.val = true }
+| UNLOGGED          { return unimplemented(sqllex, "create unlogged") }
+| /*EMPTY*/         { $$.val = false }
+
+// opt_temp_unimplemented is used by the statements which cannot create
+// temporary objects yet.
+opt_temp_unimplemented:
   TEMPORARY         { return unimplementedWithIssue(sqllex, 5807) }
 | TEMP              { return unimplementedWithIssue(sqllex, 5807) }
 | LOCAL TEMPORARY   { return unimplementedWithIssue(sqllex, 5807) }
@@ -4106,7 +4557,7 @@ col_qualification_elem:
  }
 
 index_def:
-  INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by
+  INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
   {
     $$.val = &tree.IndexTableDef{
       Name:    tree.Name($2),
@@ -4114,9 +4565,10 @@ index_def:
       Storing: $6.nameList(),
       Interleave: $7.interleave(),
       PartitionBy: $8.partitionBy(),
+      Predicate: $9.expr(),
     }
   }
-| UNIQUE INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by
+| UNIQUE INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
   {
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef {
@@ -4125,6 +4577,7 @@ index_def:
         Storing: $7.nameList(),
         Interleave: $8.interleave(),
         PartitionBy: $9.partitionBy(),
+        Predicate: $10.expr(),
       },
     }
   }
@@ -4163,12 +4616,18 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.NotDeferrable {
+      return unimplementedWithIssueDetailHint(sqllex, 31632, "deferrable check", "DEFERRABLE CHECK constraints", deferrableHint)
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
   }
 | UNIQUE '(' index_params ')' opt_storing opt_interleave opt_partition_by  opt_deferrable
   {
+    if $8.constraintDeferrability() != tree.NotDeferrable {
+      return unimplementedWithIssueDetailHint(sqllex, 31632, "deferrable unique", "DEFERRABLE UNIQUE constraints", deferrableHint)
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef{
         Columns: $3.idxElems(),
@@ -4197,16 +4656,37 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
 
+// Unlike Postgres, we do not support NOT DEFERRABLE, which conflicts with
+// NOT VALID in ALTER TABLE ... ADD CONSTRAINT.
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.NotDeferrable
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.NotDeferrable
+  }
 
 storing:
   COVERING
@@ -4364,17 +4844,17 @@ numeric_only:
 //
 // %SeeAlso: CREATE TABLE
 create_sequence_stmt:
-  CREATE opt_temp SEQUENCE sequence_name opt_sequence_option_list
+  CREATE opt_temp_unimplemented SEQUENCE sequence_name opt_sequence_option_list
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateSequence{Name: name, Options: $5.seqOpts()}
   }
-| CREATE opt_temp SEQUENCE IF NOT EXISTS sequence_name opt_sequence_option_list
+| CREATE opt_temp_unimplemented SEQUENCE IF NOT EXISTS sequence_name opt_sequence_option_list
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateSequence{Name: name, Options: $8.seqOpts(), IfNotExists: true}
   }
-| CREATE opt_temp SEQUENCE error // SHOW HELP: CREATE SEQUENCE
+| CREATE opt_temp_unimplemented SEQUENCE error // SHOW HELP: CREATE SEQUENCE
 
 opt_sequence_option_list:
   sequence_option_list
@@ -4468,10 +4948,12 @@ role_or_group:
 
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
-// %Text: CREATE VIEW <viewname> [( <colnames...> )] AS <source>
-// %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
+// %Text:
+// CREATE VIEW <viewname> [( <colnames...> )] AS <source>
+// CREATE MATERIALIZED VIEW <viewname> [( <colnames...> )] AS <source>
+// %SeeAlso: CREATE TABLE, REFRESH, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
-  CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
+  CREATE opt_temp_unimplemented opt_view_recursive VIEW view_name opt_column_list AS select_stmt
   {
     name := $5.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
@@ -4480,20 +4962,294 @@ create_view_stmt:
       AsSource: $8.slct(),
     }
   }
-| CREATE OR REPLACE opt_temp opt_view_recursive VIEW error { return unimplementedWithIssue(sqllex, 24897) }
-| CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
+| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateView{
+      Name: name,
+      ColumnNames: $5.nameList(),
+      AsSource: $7.slct(),
+      Materialized: true,
+    }
+  }
+| CREATE MATERIALIZED VIEW error // SHOW HELP: CREATE VIEW
+| CREATE OR REPLACE opt_temp_unimplemented opt_view_recursive VIEW error { return unimplementedWithIssue(sqllex, 24897) }
+| CREATE opt_temp_unimplemented opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
 
 opt_view_recursive:
   /* EMPTY */ { /* no error */ }
 | RECURSIVE { return unimplemented(sqllex, "create recursive view") }
 
-// CREATE TYPE/DOMAIN is not yet supported by CockroachDB but we
-// want to report it with the right issue number.
+// %Help: CREATE FUNCTION - define a new function
+// %Category: DDL
+// %Text:
+// CREATE [OR REPLACE] FUNCTION <name> ( [ [<argname>] <argtype> [, ...] ] )
+//   RETURNS <rettype>
+//   LANGUAGE SQL
+//   [ IMMUTABLE | STABLE | VOLATILE ]
+//   [ CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT ]
+//   AS '<definition>'
+//
+// The definition is a single SQL query that returns one column. Arguments
+// can be referred to by name or by position ($1, $2, ...).
+//
+// Trigger functions are declared with RETURNS TRIGGER and take no
+// arguments. See CREATE TRIGGER.
+// %SeeAlso: DROP FUNCTION, SHOW CREATE FUNCTION, CREATE TRIGGER
+create_function_stmt:
+  CREATE opt_or_replace FUNCTION db_object_name '(' opt_func_arg_list ')' RETURNS typename func_option_list
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateFunction{
+      Name: name,
+      Replace: $2.bool(),
+      Args: $6.funcArgs(),
+      ReturnType: $9.colType(),
+      Options: $10.functionOptions(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION db_object_name '(' opt_func_arg_list ')' RETURNS TRIGGER func_option_list
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateFunction{
+      Name: name,
+      Replace: $2.bool(),
+      Args: $6.funcArgs(),
+      ReturnsTrigger: true,
+      Options: $10.functionOptions(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
+
+opt_func_arg_list:
+  func_arg_list
+| /* EMPTY */
+  {
+    $$.val = tree.FuncArgs(nil)
+  }
+
+func_arg_list:
+  func_arg
+  {
+    $$.val = tree.FuncArgs{$1.funcArg()}
+  }
+| func_arg_list ',' func_arg
+  {
+    $$.val = append($1.funcArgs(), $3.funcArg())
+  }
+
+func_arg:
+  typename
+  {
+    $$.val = tree.FuncArg{Type: $1.colType()}
+  }
+// Only plain identifiers are accepted as parameter names, since many
+// unreserved keywords are also type names.
+| IDENT typename
+  {
+    $$.val = tree.FuncArg{Name: tree.Name($1), Type: $2.colType()}
+  }
+
+func_option_list:
+  func_option
+| func_option_list func_option
+  {
+    opts := $1.functionOptions()
+    if err := opts.Combine($2.functionOptions()); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = opts
+  }
+
+func_option:
+  LANGUAGE name
+  {
+    $$.val = tree.FunctionOptions{Language: tree.Name($2)}
+  }
+| IMMUTABLE
+  {
+    $$.val = tree.FunctionOptions{Volatility: tree.FunctionImmutable}
+  }
+| STABLE
+  {
+    $$.val = tree.FunctionOptions{Volatility: tree.FunctionStable}
+  }
+| VOLATILE
+  {
+    $$.val = tree.FunctionOptions{Volatility: tree.FunctionVolatile}
+  }
+| CALLED ON NULL INPUT
+  {
+    $$.val = tree.FunctionOptions{NullInput: tree.FunctionCalledOnNullInput}
+  }
+| RETURNS NULL ON NULL INPUT
+  {
+    $$.val = tree.FunctionOptions{NullInput: tree.FunctionReturnsNullOnNullInput}
+  }
+| STRICT
+  {
+    $$.val = tree.FunctionOptions{NullInput: tree.FunctionStrict}
+  }
+| AS SCONST
+  {
+    body := $2
+    $$.val = tree.FunctionOptions{Body: &body}
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [CONSTRAINT] TRIGGER <name> { BEFORE | AFTER } <event> [ OR ... ]
+//   ON <tablename>
+//   [ FOR [ EACH ] { ROW | STATEMENT } ]
+//   EXECUTE { FUNCTION | PROCEDURE } <funcname> ( [<argument> [, ...]] )
+//
+// Events:
+//   INSERT
+//   UPDATE
+//   DELETE
+//
+// The function must be declared with RETURNS TRIGGER. Constraint triggers
+// must be AFTER ... FOR EACH ROW triggers.
+// %SeeAlso: DROP TRIGGER, CREATE FUNCTION
+create_trigger_stmt:
+  CREATE opt_constraint TRIGGER name trigger_action_time trigger_events ON table_name trigger_for_each EXECUTE function_or_procedure db_object_name '(' opt_trigger_arg_list ')'
+  {
+    if $2.bool() && ($5.triggerActionTime() != tree.TriggerAfter || !$9.bool()) {
+      sqllex.Error("constraint triggers must be AFTER ... FOR EACH ROW")
+      return 1
+    }
+    $$.val = &tree.CreateTrigger{
+      Name: tree.Name($4),
+      Constraint: $2.bool(),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      Table: $8.unresolvedObjectName().ToTableName(),
+      ForEachRow: $9.bool(),
+      FuncName: $12.unresolvedObjectName().ToTableName(),
+      Args: $14.strs(),
+    }
+  }
+| CREATE opt_constraint TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+opt_constraint:
+  CONSTRAINT
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerAfter
+  }
+
+trigger_events:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_events OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = tree.TriggerInsert
+  }
+| UPDATE
+  {
+    $$.val = tree.TriggerUpdate
+  }
+| DELETE
+  {
+    $$.val = tree.TriggerDelete
+  }
+| UPDATE OF error { return unimplementedWithIssueDetail(sqllex, 28296, "update of") }
+| TRUNCATE { return unimplementedWithIssueDetail(sqllex, 28296, "truncate") }
+
+// PostgreSQL defaults to statement-level triggers.
+trigger_for_each:
+  FOR opt_each ROW
+  {
+    $$.val = true
+  }
+| FOR opt_each STATEMENT
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+opt_trigger_arg_list:
+  trigger_arg_list
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+trigger_arg_list:
+  trigger_arg
+  {
+    $$.val = []string{$1}
+  }
+| trigger_arg_list ',' trigger_arg
+  {
+    $$.val = append($1.strs(), $3)
+  }
+
+// Trigger arguments are passed to the function as strings.
+trigger_arg:
+  non_reserved_word_or_sconst
+| ICONST
+  {
+    $$ = $1.numVal().OrigString
+  }
+| FCONST
+  {
+    $$ = $1.numVal().OrigString
+  }
+
+// %Help: CREATE TYPE - create a new type
+// %Category: DDL
+// %Text: CREATE TYPE <typename> AS ENUM ( [<label> [, ...]] )
+// %SeeAlso: ALTER TYPE, DROP TYPE
 create_type_stmt:
-  // Record/Composite types.
-  CREATE TYPE type_name AS '(' error      { return unimplementedWithIssue(sqllex, 27792) }
   // Enum types.
-| CREATE TYPE type_name AS ENUM '(' error { return unimplementedWithIssue(sqllex, 24873) }
+  CREATE TYPE type_name AS ENUM '(' opt_enum_val_list ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName().ToTableName(),
+      Variety: tree.Enum,
+      EnumLabels: $7.strs(),
+    }
+  }
+| CREATE TYPE type_name AS ENUM '(' error // SHOW HELP: CREATE TYPE
+  // The other kinds of types and domains are not yet supported by
+  // CockroachDB but we want to report them with the right issue number.
+  // Record/Composite types.
+| CREATE TYPE type_name AS '(' error      { return unimplementedWithIssue(sqllex, 27792) }
   // Range types.
 | CREATE TYPE type_name AS RANGE error    { return unimplementedWithIssue(sqllex, 27791) }
   // Base (primitive) types.
@@ -4502,6 +5258,24 @@ create_type_stmt:
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
   // Domain types.
 | CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+| CREATE TYPE error // SHOW HELP: CREATE TYPE
+
+opt_enum_val_list:
+  enum_val_list
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+enum_val_list:
+  SCONST
+  {
+    $$.val = []string{$1}
+  }
+| enum_val_list ',' SCONST
+  {
+    $$.val = append($1.strs(), $3)
+  }
 
 // %Help: CREATE INDEX - create a new index
 // %Category: DDL
@@ -4527,6 +5301,7 @@ create_index_stmt:
       Storing: $11.nameList(),
       Interleave: $12.interleave(),
       PartitionBy: $13.partitionBy(),
+      Predicate: $14.expr(),
       Inverted: $7.bool(),
     }
   }
@@ -4542,6 +5317,7 @@ create_index_stmt:
       Storing:     $14.nameList(),
       Interleave:  $15.interleave(),
       PartitionBy: $16.partitionBy(),
+      Predicate:   $17.expr(),
       Inverted:    $10.bool(),
     }
   }
@@ -4557,6 +5333,7 @@ create_index_stmt:
       Storing:     $11.nameList(),
       Interleave:  $12.interleave(),
       PartitionBy: $13.partitionBy(),
+      Predicate:   $14.expr(),
     }
   }
 | CREATE opt_unique INVERTED INDEX IF NOT EXISTS index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
@@ -4572,13 +5349,20 @@ create_index_stmt:
       Storing:     $14.nameList(),
       Interleave:  $15.interleave(),
       PartitionBy: $16.partitionBy(),
+      Predicate:   $17.expr(),
     }
   }
 | CREATE opt_unique INDEX error // SHOW HELP: CREATE INDEX
 
 opt_idx_where:
-  /* EMPTY */ { /* no error */ }
-| WHERE error { return unimplementedWithIssue(sqllex, 9683) }
+  WHERE a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
 
 opt_using_gin_btree:
   USING name
@@ -5007,6 +5791,21 @@ create_database_stmt:
    }
 | CREATE DATABASE error // SHOW HELP: CREATE DATABASE
 
+// %Help: CREATE SCHEMA - create a new schema
+// %Category: DDL
+// %Text: CREATE SCHEMA [IF NOT EXISTS] <schemaname>
+// %SeeAlso: DROP SCHEMA, GRANT
+create_schema_stmt:
+  CREATE SCHEMA name
+  {
+    $$.val = &tree.CreateSchema{Schema: tree.Name($3)}
+  }
+| CREATE SCHEMA IF NOT EXISTS name
+  {
+    $$.val = &tree.CreateSchema{Schema: tree.Name($6), IfNotExists: true}
+  }
+| CREATE SCHEMA error // SHOW HELP: CREATE SCHEMA
+
 opt_template_clause:
   TEMPLATE opt_equal non_reserved_word_or_sconst
   {
@@ -5056,7 +5855,8 @@ opt_equal:
 // %Text:
 // INSERT INTO <tablename> [[AS] <name>] [( <colnames...> )]
 //        <selectclause>
-//        [ON CONFLICT [( <colnames...> )] {DO UPDATE SET ... [WHERE <expr>] | DO NOTHING}]
+//        [ON CONFLICT [( <colnames...> ) [WHERE <expr>] | ON CONSTRAINT <name>]
+//          {DO UPDATE SET ... [WHERE <expr>] | DO NOTHING}]
 //        [RETURNING <exprs...>]
 // %SeeAlso: UPSERT, UPDATE, DELETE, WEBDOCS/insert.html
 insert_stmt:
@@ -5156,23 +5956,30 @@ insert_column_item:
 on_conflict:
   ON CONFLICT opt_conf_expr DO UPDATE SET set_clause_list opt_where_clause
   {
-    $$.val = &tree.OnConflict{Columns: $3.nameList(), Exprs: $7.updateExprs(), Where: tree.NewWhere(tree.AstWhere, $8.expr())}
+    oc := $3.onConflict()
+    oc.Exprs = $7.updateExprs()
+    oc.Where = tree.NewWhere(tree.AstWhere, $8.expr())
+    $$.val = oc
   }
 | ON CONFLICT opt_conf_expr DO NOTHING
   {
-    $$.val = &tree.OnConflict{Columns: $3.nameList(), DoNothing: true}
+    oc := $3.onConflict()
+    oc.DoNothing = true
+    $$.val = oc
   }
 
 opt_conf_expr:
-  '(' name_list ')'
+  '(' name_list ')' opt_where_clause
   {
-    $$.val = $2.nameList()
+    $$.val = &tree.OnConflict{Columns: $2.nameList(), ArbiterPredicate: $4.expr()}
+  }
+| ON CONSTRAINT constraint_name
+  {
+    $$.val = &tree.OnConflict{Constraint: tree.Name($3)}
   }
-| '(' name_list ')' where_clause { return unimplementedWithIssue(sqllex, 32557) }
-| ON CONSTRAINT constraint_name { return unimplementedWithIssue(sqllex, 28161) }
 | /* EMPTY */
   {
-    $$.val = tree.NameList(nil)
+    $$.val = &tree.OnConflict{}
   }
 
 returning_clause:
@@ -5195,6 +6002,7 @@ returning_clause:
 // %Text:
 // UPDATE <tablename> [[AS] <name>]
 //        SET ...
+//        [FROM <source> [, ...]]
 //        [WHERE <expr>]
 //        [ORDER BY <exprs...>]
 //        [LIMIT <expr>]
@@ -5208,6 +6016,7 @@ update_stmt:
       With: $1.with(),
       Table: $3.tblExpr(),
       Exprs: $5.updateExprs(),
+      From: $6.tblExprs(),
       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
       OrderBy: $8.orderBy(),
       Limit: $9.limit(),
@@ -5216,10 +6025,15 @@ update_stmt:
   }
 | opt_with_clause UPDATE error // SHOW HELP: UPDATE
 
-// Mark this as unimplemented until the normal from_clause is supported here.
 update_from_clause:
-  FROM from_list { return unimplementedWithIssue(sqllex, 7841) }
-| /* EMPTY */ {}
+  FROM from_list
+  {
+    $$.val = $2.tblExprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TableExprs(nil)
+  }
 
 set_clause_list:
   set_clause
@@ -5314,34 +6128,123 @@ select_with_parens:
 //      clause.
 //      - 2002-08-28 bjm
 select_no_parens:
-  simple_select opt_for
+  simple_select
   {
     $$.val = &tree.Select{Select: $1.selectStmt()}
   }
-| select_clause sort_clause opt_for
+| select_clause sort_clause
   {
     $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy()}
   }
-| select_clause opt_sort_clause select_limit opt_for
+| select_clause opt_sort_clause for_locking_clause opt_select_limit
+  {
+    $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy(), Limit: $4.limit(), Locking: $3.lockingClause()}
+  }
+| select_clause opt_sort_clause select_limit opt_for_locking_clause
   {
-    $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy(), Limit: $3.limit()}
+    $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy(), Limit: $3.limit(), Locking: $4.lockingClause()}
   }
-| with_clause select_clause opt_for
+| with_clause select_clause
   {
     $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt()}
   }
-| with_clause select_clause sort_clause opt_for
+| with_clause select_clause sort_clause
   {
     $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy()}
   }
-| with_clause select_clause opt_sort_clause select_limit opt_for
+| with_clause select_clause opt_sort_clause for_locking_clause opt_select_limit
   {
-    $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $4.limit()}
+    $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $5.limit(), Locking: $4.lockingClause()}
+  }
+| with_clause select_clause opt_sort_clause select_limit opt_for_locking_clause
+  {
+    $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $4.limit(), Locking: $5.lockingClause()}
   }
 
-opt_for:
-  /* EMPTY */ { /* no error */ }
-| FOR error { return unimplementedWithIssue(sqllex, 6583) }
+// The locking clause (FOR UPDATE etc) may be before or after LIMIT/OFFSET.
+// Multiple locking items may be specified; the strongest strength and wait
+// policy that applies to a given table is used.
+for_locking_clause:
+  for_locking_items
+  {
+    $$.val = $1.lockingClause()
+  }
+| FOR READ ONLY
+  {
+    $$.val = (tree.LockingClause)(nil)
+  }
+
+opt_for_locking_clause:
+  for_locking_clause
+  {
+    $$.val = $1.lockingClause()
+  }
+| /* EMPTY */
+  {
+    $$.val = (tree.LockingClause)(nil)
+  }
+
+for_locking_items:
+  for_locking_item
+  {
+    $$.val = tree.LockingClause{$1.lockingItem()}
+  }
+| for_locking_items for_locking_item
+  {
+    $$.val = append($1.lockingClause(), $2.lockingItem())
+  }
+
+for_locking_item:
+  for_locking_strength opt_locked_rels opt_nowait_or_skip
+  {
+    $$.val = &tree.LockingItem{
+      Strength:   $1.lockingStrength(),
+      Targets:    $2.tableNames(),
+      WaitPolicy: $3.lockingWaitPolicy(),
+    }
+  }
+
+for_locking_strength:
+  FOR UPDATE
+  {
+    $$.val = tree.ForUpdate
+  }
+| FOR NO KEY UPDATE
+  {
+    $$.val = tree.ForNoKeyUpdate
+  }
+| FOR SHARE
+  {
+    $$.val = tree.ForShare
+  }
+| FOR KEY SHARE
+  {
+    $$.val = tree.ForKeyShare
+  }
+
+opt_locked_rels:
+  /* EMPTY */
+  {
+    $$.val = tree.TableNames{}
+  }
+| OF table_name_list
+  {
+    $$.val = $2.tableNames()
+  }
+
+opt_nowait_or_skip:
+  /* EMPTY */
+  {
+    $$.val = tree.LockWaitBlock
+  }
+| SKIP LOCKED
+  {
+    $$.val = tree.LockWaitSkip
+  }
+| NOWAIT
+  {
+    $$.val = tree.LockWaitError
+  }
 
 select_clause:
 // We only provide help if an open parenthesis is provided, because
@@ -5400,6 +6303,7 @@ simple_select:
 //        [ ORDER BY <expr> [ ASC | DESC ] [, ...] ]
 //        [ LIMIT { <expr> | ALL } ]
 //        [ OFFSET <expr> [ ROW | ROWS ] ]
+//        [ FOR { UPDATE | NO KEY UPDATE | SHARE | KEY SHARE } [ OF <tablename> [, ...] ] [ NOWAIT | SKIP LOCKED ] [...] ]
 // %SeeAlso: WEBDOCS/select-clause.html
 simple_select_clause:
   SELECT opt_all_clause target_list
@@ -5508,7 +6412,10 @@ with_clause:
     /* SKIP DOC */
     $$.val = &tree.With{CTEList: $2.ctes()}
   }
-| WITH RECURSIVE cte_list { return unimplementedWithIssue(sqllex, 21085) }
+| WITH RECURSIVE cte_list
+  {
+    $$.val = &tree.With{Recursive: true, CTEList: $3.ctes()}
+  }
 
 cte_list:
   common_table_expr
@@ -5533,6 +6440,10 @@ opt_with:
   WITH {}
 | /* EMPTY */ {}
 
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
 opt_with_clause:
   with_clause
   {
@@ -5655,6 +6566,10 @@ opt_limit_clause:
   limit_clause
 | /* EMPTY */ { $$.val = (*tree.Limit)(nil) }
 
+opt_select_limit:
+  select_limit { $$.val = $1.limit() }
+| /* EMPTY */  { $$.val = (*tree.Limit)(nil) }
+
 limit_clause:
   LIMIT select_limit_value
   {
@@ -5873,6 +6788,7 @@ opt_index_flags:
 //   <tablename> [ @ { <idxname> | <indexhint> } ]
 //   <tablefunc> ( <exprs...> )
 //   ( { <selectclause> | <source> } )
+//   LATERAL { ( <selectclause> ) | <tablefunc> ( <exprs...> ) }
 //   <source> [AS] <alias> [( <colnames...> )]
 //   <source> { [INNER] | { LEFT | RIGHT | FULL } [OUTER] } JOIN <source> ON <expr>
 //   <source> { [INNER] | { LEFT | RIGHT | FULL } [OUTER] } JOIN <source> USING ( <colnames...> )
@@ -5920,7 +6836,15 @@ table_ref:
       As:         $3.aliasClause(),
     }
   }
-| LATERAL select_with_parens opt_ordinality opt_alias_clause { return unimplementedWithIssueDetail(sqllex, 24560, "select") }
+| LATERAL select_with_parens opt_ordinality opt_alias_clause
+  {
+    $$.val = &tree.AliasedTableExpr{
+      Expr:       &tree.Subquery{Select: $2.selectStmt()},
+      Ordinality: $3.bool(),
+      Lateral:    true,
+      As:         $4.aliasClause(),
+    }
+  }
 | joined_table
   {
     $$.val = $1.tblExpr()
@@ -5934,7 +6858,11 @@ table_ref:
     f := $1.tblExpr()
     $$.val = &tree.AliasedTableExpr{Expr: f, Ordinality: $2.bool(), As: $3.aliasClause()}
   }
-| LATERAL func_table opt_ordinality opt_alias_clause { return unimplementedWithIssueDetail(sqllex, 24560, "srf") }
+| LATERAL func_table opt_ordinality opt_alias_clause
+  {
+    f := $2.tblExpr()
+    $$.val = &tree.AliasedTableExpr{Expr: f, Ordinality: $3.bool(), Lateral: true, As: $4.aliasClause()}
+  }
 // The following syntax is a CockroachDB extension:
 //     SELECT ... FROM [ EXPLAIN .... ] WHERE ...
 //     SELECT ... FROM [ SHOW .... ] WHERE ...
@@ -6259,7 +7187,20 @@ simple_typename:
 | character_with_length
 | const_interval
 | const_interval interval_qualifier { return unimplemented(sqllex, "interval with unit qualifier") }
-| const_interval '(' ICONST ')' { return unimplementedWithIssue(sqllex, 32564) }
+| const_interval '(' iconst64 ')'
+  {
+    prec := $3.int64()
+    if err := coltypes.CheckTimePrecision(prec); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = &coltypes.TInterval{Precision: int(prec), PrecisionSet: true}
+  }
+| IDENT '.' IDENT
+  {
+    // A schema-qualified name can only refer to a user-defined type.
+    $$.val = &coltypes.TUserDefined{Schema: $1, Name: $3}
+  }
 
 // We have a separate const_typename to allow defaulting fixed-length types
 // such as CHAR() and BIT() to an unspecified length. SQL9x requires that these
@@ -6349,8 +7290,8 @@ const_typename:
     // See https://www.postgresql.org/docs/9.1/static/datatype-character.html
     // Postgres supports a special character type named "char" (with the quotes)
     // that is a single-character column type. It's used by system tables.
-    // Eventually this clause will be used to parse user-defined types as well,
-    // since their names can be quoted.
+    // Any other name which is not a known type name refers to a user-defined
+    // type, which is resolved during semantic analysis.
     if $1 == "char" {
       $$.val = coltypes.QChar
     } else {
@@ -6360,10 +7301,7 @@ const_typename:
       if !ok {
           switch unimp {
               case 0:
-                // Note: we can only report an unimplemented error for specific
-                // known type names. Anything else may return PII.
-                sqllex.Error("type does not exist")
-                return 1
+                $$.val = &coltypes.TUserDefined{Name: $1}
               case -1:
                 return unimplemented(sqllex, "type name " + $1)
               default:
@@ -6596,12 +7534,38 @@ const_datetime:
   }
 | TIME opt_timezone
   {
-    if $2.bool() { return unimplementedWithIssueDetail(sqllex, 26097, "type") }
-    $$.val = coltypes.Time
+    if $2.bool() {
+      $$.val = coltypes.TimeTZ
+    } else {
+      $$.val = coltypes.Time
+    }
+  }
+| TIME '(' iconst64 ')' opt_timezone
+  {
+    prec := $3.int64()
+    if err := coltypes.CheckTimePrecision(prec); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    if $5.bool() {
+      $$.val = &coltypes.TTimeTZ{Precision: int(prec), PrecisionSet: true}
+    } else {
+      $$.val = &coltypes.TTime{Precision: int(prec), PrecisionSet: true}
+    }
+  }
+| TIMETZ
+  {
+    $$.val = coltypes.TimeTZ
+  }
+| TIMETZ '(' iconst64 ')'
+  {
+    prec := $3.int64()
+    if err := coltypes.CheckTimePrecision(prec); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = &coltypes.TTimeTZ{Precision: int(prec), PrecisionSet: true}
   }
-| TIME '(' ICONST ')' opt_timezone   { return unimplementedWithIssue(sqllex, 32565) }
-| TIMETZ                             { return unimplementedWithIssueDetail(sqllex, 26097, "type") }
-| TIMETZ '(' ICONST ')'              { return unimplementedWithIssueDetail(sqllex, 26097, "type with precision") }
 | TIMESTAMP opt_timezone
   {
     if $2.bool() {
@@ -6610,12 +7574,32 @@ const_datetime:
       $$.val = coltypes.Timestamp
     }
   }
-| TIMESTAMP '(' ICONST ')' opt_timezone { return unimplementedWithIssue(sqllex, 32098) }
+| TIMESTAMP '(' iconst64 ')' opt_timezone
+  {
+    prec := $3.int64()
+    if err := coltypes.CheckTimePrecision(prec); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    if $5.bool() {
+      $$.val = &coltypes.TTimestampTZ{Precision: int(prec), PrecisionSet: true}
+    } else {
+      $$.val = &coltypes.TTimestamp{Precision: int(prec), PrecisionSet: true}
+    }
+  }
 | TIMESTAMPTZ
   {
     $$.val = coltypes.TimestampWithTZ
   }
-| TIMESTAMPTZ '(' ICONST ')'            { return unimplementedWithIssue(sqllex, 32098) }
+| TIMESTAMPTZ '(' iconst64 ')'
+  {
+    prec := $3.int64()
+    if err := coltypes.CheckTimePrecision(prec); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = &coltypes.TTimestampTZ{Precision: int(prec), PrecisionSet: true}
+  }
 
 opt_timezone:
   WITH_LA TIME ZONE { $$.val = true; }
@@ -6731,7 +7715,10 @@ a_expr:
   {
     $$.val = &tree.CollateExpr{Expr: $1.expr(), Locale: $3}
   }
-| a_expr AT TIME ZONE a_expr %prec AT { return unimplementedWithIssue(sqllex, 32005) }
+| a_expr AT TIME ZONE a_expr %prec AT
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("timezone"), Exprs: tree.Exprs{$5.expr(), $1.expr()}}
+  }
   // These operators must be called out explicitly in order to make use of
   // bison's automatic operator-precedence handling. All other operator names
   // are handled by the generic productions using "OP", below; and all those
@@ -6820,6 +7807,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: tree.ContainedBy, Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_AT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: tree.TSMatches, Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr '=' a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: tree.EQ, Left: $1.expr(), Right: $3.expr()}
@@ -7280,7 +8271,20 @@ d_expr:
   {
     $$.val = $1.expr()
   }
-| const_interval '(' ICONST ')' SCONST { return unimplementedWithIssue(sqllex, 32564) }
+| const_interval '(' iconst64 ')' SCONST
+  {
+    prec := $3.int64()
+    if err := coltypes.CheckTimePrecision(prec); err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    d, err := tree.ParseDInterval($5)
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = tree.RoundDatumToTimePrecision(d, int(prec))
+  }
 | TRUE
   {
     $$.val = tree.MakeDBool(true)
@@ -7384,6 +8388,19 @@ func_expr:
   func_application within_group_clause filter_clause over_clause
   {
     f := $1.expr().(*tree.FuncExpr)
+    w := $2.orderBy()
+    if len(w) > 0 {
+      if f.Type == tree.DistinctFuncType {
+        sqllex.Error("cannot use DISTINCT with WITHIN GROUP")
+        return 1
+      }
+      f.AggType = tree.OrderedSetAgg
+      f.OrderBy = w
+      // Ordered-set aggregates are resolved eagerly, since some of them share
+      // their name with a window function. If the name does not refer to an
+      // ordered-set aggregate, the error is reported during type checking.
+      _, _ = f.Func.ResolveOrderedSet()
+    }
     f.Filter = $3.expr()
     f.WindowDef = $4.windowDef()
     $$.val = f
@@ -7424,7 +8441,7 @@ func_expr_common_subexpr:
   }
 | CURRENT_TIME
   {
-    return unimplementedWithIssueDetail(sqllex, 26097, "current_time")
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1)}
   }
 | CURRENT_USER
   {
@@ -7504,7 +8521,7 @@ special_function:
 | CURRENT_TIMESTAMP '(' error { return helpWithFunctionByName(sqllex, $1) }
 | CURRENT_TIME '(' ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 26097, "current_time")
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1)}
   }
 | CURRENT_TIME '(' error { return helpWithFunctionByName(sqllex, $1) }
 | CURRENT_USER '(' ')'
@@ -7567,8 +8584,14 @@ special_function:
 
 // Aggregate decoration clauses
 within_group_clause:
-WITHIN GROUP '(' sort_clause ')' { return unimplemented(sqllex, "within group") }
-| /* EMPTY */ {}
+  WITHIN GROUP '(' sort_clause ')'
+  {
+    $$.val = $4.orderBy()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.OrderBy(nil)
+  }
 
 filter_clause:
   FILTER '(' WHERE a_expr ')'
@@ -8596,10 +9619,12 @@ unreserved_keyword:
 | ACTION
 | ADD
 | ADMIN
+| AFTER
 | AGGREGATE
 | ALTER
 | AT
 | BACKUP
+| BEFORE
 | BEGIN
 | BIGSERIAL
 | BLOB
@@ -8608,6 +9633,7 @@ unreserved_keyword:
 | BYTEA
 | BYTES
 | CACHE
+| CALLED
 | CANCEL
 | CASCADE
 | CHANGEFEED
@@ -8625,6 +9651,7 @@ unreserved_keyword:
 | CONVERSION
 | COPY
 | COVERING
+| CSV
 | CUBE
 | CURRENT
 | CYCLE
@@ -8636,10 +9663,12 @@ unreserved_keyword:
 | DEALLOCATE
 | DELETE
 | DEFERRED
+| DELIMITER
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENUM
 | ESCAPE
@@ -8660,20 +9689,24 @@ unreserved_keyword:
 | FLOAT8
 | FOLLOWING
 | FORCE_INDEX
+| FORMAT
 | FUNCTION
 | GLOBAL
 | GRANTS
 | GROUPS
+| HEADER
 | HIGH
 | HISTOGRAM
 | HOUR
 | IMMEDIATE
+| IMMUTABLE
 | IMPORT
 | INCREMENT
 | INCREMENTAL
 | INDEXES
 | INET
 | INJECT
+| INPUT
 | INSERT
 | INT2
 | INT2VECTOR
@@ -8698,6 +9731,7 @@ unreserved_keyword:
 | LEVEL
 | LIST
 | LOCAL
+| LOCKED
 | LOW
 | MATCH
 | MATERIALIZED
@@ -8712,6 +9746,7 @@ unreserved_keyword:
 | NO
 | NORMAL
 | NO_INDEX_JOIN
+| NOWAIT
 | OF
 | OFF
 | OID
@@ -8733,6 +9768,7 @@ unreserved_keyword:
 | PRECEDING
 | PREPARE
 | PRIORITY
+| PROCEDURE
 | PUBLICATION
 | QUERIES
 | QUERY
@@ -8741,6 +9777,7 @@ unreserved_keyword:
 | READ
 | RECURSIVE
 | REF
+| REFRESH
 | REGCLASS
 | REGPROC
 | REGPROCEDURE
@@ -8754,6 +9791,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESUME
+| RETURNS
 | REVOKE
 | ROLE
 | ROLES
@@ -8763,6 +9801,7 @@ unreserved_keyword:
 | RULE
 | SETTING
 | SETTINGS
+| SHARE
 | STATUS
 | SAVEPOINT
 | SCATTER
@@ -8784,12 +9823,16 @@ unreserved_keyword:
 | SET
 | SHOW
 | SIMPLE
+| SKIP
 | SMALLSERIAL
 | SNAPSHOT
 | SQL
+| STABLE
 | START
+| STATEMENT
 | STATISTICS
 | STDIN
+| STDOUT
 | STORE
 | STORED
 | STORING
@@ -8826,6 +9869,7 @@ unreserved_keyword:
 | VALUE
 | VARYING
 | VIEW
+| VOLATILE
 | WITHIN
 | WITHOUT
 | WRITE
@@ -26,6 +26,7 @@ import (
 	"unicode"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -448,6 +449,7 @@ var (
 	relKindTable    = tree.NewDString("r")
 	relKindIndex    = tree.NewDString("i")
 	relKindView     = tree.NewDString("v")
+	relKindMatView  = tree.NewDString("m")
 	relKindSequence = tree.NewDString("S")
 
 	relPersistencePermanent = tree.NewDString("p")
@@ -491,7 +493,9 @@ CREATE TABLE pg_catalog.pg_class (
 			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
 				// The only difference between tables, views and sequences is the relkind column.
 				relKind := relKindTable
-				if table.IsView() {
+				if table.MaterializedView() {
+					relKind = relKindMatView
+				} else if table.IsView() {
 					relKind = relKindView
 				} else if table.IsSequence() {
 					relKind = relKindSequence
@@ -520,7 +524,7 @@ CREATE TABLE pg_catalog.pg_class (
 					tree.DBoolFalse, // relhasoids
 					tree.MakeDBool(tree.DBool(table.IsPhysicalTable())), // relhaspkey
 					tree.DBoolFalse, // relhasrules
-					tree.DBoolFalse, // relhastriggers
+					tree.MakeDBool(tree.DBool(len(table.Triggers) > 0)), // relhastriggers
 					tree.DBoolFalse, // relhassubclass
 					zeroVal,         // relfrozenxid
 					tree.DNull,      // relacl
@@ -702,6 +706,8 @@ CREATE TABLE pg_catalog.pg_constraint (
 				consrc := tree.DNull
 				conbin := tree.DNull
 				condef := tree.DNull
+				condeferrable := tree.DBoolFalse
+				condeferred := tree.DBoolFalse
 
 				// Determine constraint kind-specific fields.
 				var err error
@@ -730,6 +736,8 @@ CREATE TABLE pg_catalog.pg_constraint (
 					confupdtype = fkActionNone
 					confdeltype = fkActionNone
 					confmatchtype = fkMatchTypeSimple
+					condeferrable = tree.MakeDBool(tree.DBool(con.FK.Deferrable))
+					condeferred = tree.MakeDBool(tree.DBool(con.FK.InitiallyDeferred))
 					columnIDs := con.Index.ColumnIDs
 					if int(con.FK.SharedPrefixLen) > len(columnIDs) {
 						return pgerror.NewAssertionErrorf(
@@ -785,8 +793,8 @@ CREATE TABLE pg_catalog.pg_constraint (
 					dNameOrNull(conName), // conname
 					namespaceOid,         // connamespace
 					contype,              // contype
-					tree.DBoolFalse,      // condeferrable
-					tree.DBoolFalse,      // condeferred
+					condeferrable,        // condeferrable
+					condeferred,          // condeferred
 					tree.MakeDBool(tree.DBool(!con.Unvalidated)), // convalidated
 					tblOid,         // conrelid
 					oidZero,        // contypid
@@ -1067,9 +1075,24 @@ CREATE TABLE pg_catalog.pg_enum (
   enumsortorder FLOAT,
   enumlabel STRING
 )`,
-	populate: func(_ context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Enum types are not currently supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
+			return forEachTypeDesc(ctx, p, db, func(typ *sqlbase.TypeDescriptor) error {
+				typOid := tree.NewDOid(tree.DInt(typ.EnumType().Oid()))
+				for i := range typ.EnumMembers {
+					if err := addRow(
+						h.EnumOid(typ, i),                // oid
+						typOid,                           // enumtypid
+						tree.NewDFloat(tree.DFloat(i+1)), // enumsortorder
+						tree.NewDString(typ.EnumMembers[i].LogicalRepresentation), // enumlabel
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
 	},
 }
 
@@ -1222,6 +1245,10 @@ CREATE TABLE pg_catalog.pg_index (
 					if err != nil {
 						return err
 					}
+					indpred := tree.DNull
+					if index.IsPartial() {
+						indpred = tree.NewDString(index.Predicate)
+					}
 					return addRow(
 						h.IndexOid(db, scName, table, index), // indexrelid
 						tableOid,                             // indrelid
@@ -1241,7 +1268,7 @@ CREATE TABLE pg_catalog.pg_index (
 						indclass,                                 // indclass
 						indoption,                                // indoption
 						tree.DNull,                               // indexprs
-						tree.DNull,                               // indpred
+						indpred,                                  // indpred
 					)
 				})
 			})
@@ -1341,6 +1368,13 @@ func indexDefFromDescriptor(
 		}
 		indexDef.Interleave = intlDef
 	}
+	if index.IsPartial() {
+		pred, err := parser.ParseExpr(index.Predicate)
+		if err != nil {
+			return "", err
+		}
+		indexDef.Predicate = pred
+	}
 	return indexDef.String(), nil
 }
 
@@ -1390,7 +1424,7 @@ CREATE TABLE pg_catalog.pg_namespace (
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
 		return forEachDatabaseDesc(ctx, p, dbContext, func(db *sqlbase.DatabaseDescriptor) error {
-			return forEachSchemaName(ctx, p, db, func(s string) error {
+			return forEachSchemaName(ctx, p, db, func(s string, _ *sqlbase.SchemaDescriptor) error {
 				return addRow(
 					h.NamespaceOid(db, s), // oid
 					tree.NewDString(s),    // nspname
@@ -1677,7 +1711,78 @@ CREATE TABLE pg_catalog.pg_proc (
 					}
 				}
 			}
-			return nil
+
+			// User-defined functions.
+			publicOid := h.NamespaceOid(db, tree.PublicSchema)
+			return forEachFunctionDesc(ctx, p, db, func(fn *sqlbase.FunctionDescriptor) error {
+				dArgTypes := tree.NewDArray(types.Oid)
+				argNames := tree.DNull
+				for i := range fn.Args {
+					argType := fn.Args[i].Type.ToDatumType()
+					if err := dArgTypes.Append(tree.NewDOid(tree.DInt(argType.Oid()))); err != nil {
+						return err
+					}
+				}
+				for i := range fn.Args {
+					if fn.Args[i].Name != "" {
+						// As in Postgres, proargnames is only populated if at
+						// least one of the parameters has a name.
+						ary := tree.NewDArray(types.String)
+						for j := range fn.Args {
+							if err := ary.Append(tree.NewDString(fn.Args[j].Name)); err != nil {
+								return err
+							}
+						}
+						argNames = ary
+						break
+					}
+				}
+				var volatile string
+				switch fn.Volatility {
+				case sqlbase.FunctionDescriptor_IMMUTABLE:
+					volatile = "i"
+				case sqlbase.FunctionDescriptor_STABLE:
+					volatile = "s"
+				default:
+					volatile = "v"
+				}
+				retOid := fn.ReturnType.ToDatumType().Oid()
+				if fn.ReturnsTrigger {
+					retOid = oid.T_trigger
+				}
+				return addRow(
+					h.UserDefinedFunctionOid(db, fn),        // oid
+					tree.NewDName(fn.Name),                  // proname
+					publicOid,                               // pronamespace
+					tree.DNull,                              // proowner
+					oidZero,                                 // prolang
+					tree.DNull,                              // procost
+					tree.DNull,                              // prorows
+					oidZero,                                 // provariadic
+					tree.DNull,                              // protransform
+					tree.DBoolFalse,                         // proisagg
+					tree.DBoolFalse,                         // proiswindow
+					tree.DBoolFalse,                         // prosecdef
+					tree.DBoolFalse,                         // proleakproof
+					tree.MakeDBool(tree.DBool(fn.Strict)),   // proisstrict
+					tree.DBoolFalse,                         // proretset
+					tree.NewDString(volatile),               // provolatile
+					tree.DNull,                              // proparallel
+					tree.NewDInt(tree.DInt(len(fn.Args))),   // pronargs
+					tree.NewDInt(tree.DInt(0)),              // pronargdefaults
+					tree.NewDOid(tree.DInt(retOid)),         // prorettype
+					tree.NewDOidVectorFromDArray(dArgTypes), // proargtypes
+					tree.DNull,                              // proallargtypes
+					tree.DNull,                              // proargmodes
+					argNames,                                // proargnames
+					tree.DNull,                              // proargdefaults
+					tree.DNull,                              // protrftypes
+					tree.NewDString(fn.Body),                // prosrc
+					tree.DNull,                              // probin
+					tree.DNull,                              // proconfig
+					tree.DNull,                              // proacl
+				)
+			})
 		})
 	},
 }
@@ -1963,11 +2068,97 @@ CREATE TABLE pg_catalog.pg_trigger (
 	tgnewtable NAME
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Triggers are unsupported.
-		return nil
+		h := makeOidHasher()
+		// Trigger functions live in the database of the tables of their
+		// triggers, so the functions are looked up one database at a time.
+		fns := make(map[sqlbase.ID]map[sqlbase.ID]*sqlbase.FunctionDescriptor)
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
+				if len(table.Triggers) == 0 {
+					return nil
+				}
+				dbFns, ok := fns[db.ID]
+				if !ok {
+					dbFns = make(map[sqlbase.ID]*sqlbase.FunctionDescriptor)
+					if err := forEachFunctionDesc(ctx, p, db, func(fn *sqlbase.FunctionDescriptor) error {
+						dbFns[fn.ID] = fn
+						return nil
+					}); err != nil {
+						return err
+					}
+					fns[db.ID] = dbFns
+				}
+				tableOid := defaultOid(table.ID)
+				for i := range table.Triggers {
+					trigger := &table.Triggers[i]
+					fnOid := oidZero
+					if fn, ok := dbFns[trigger.FunctionID]; ok {
+						fnOid = h.UserDefinedFunctionOid(db, fn)
+					}
+					var args []byte
+					for _, arg := range trigger.Args {
+						args = append(args, arg...)
+						args = append(args, 0)
+					}
+					if err := addRow(
+						h.TriggerOid(db, scName, table, trigger), // oid
+						tableOid,                                 // tgrelid
+						tree.NewDName(trigger.Name),              // tgname
+						fnOid,                                    // tgfoid
+						tree.NewDInt(tree.DInt(pgTriggerType(trigger))), // tgtype
+						tree.NewDString("O"),                            // tgenabled
+						tree.DBoolFalse,                                 // tgisinternal
+						oidZero,                                         // tgconstrrelid
+						oidZero,                                         // tgconstrindid
+						oidZero,                                         // tgconstraint
+						tree.DBoolFalse,                                 // tgdeferrable
+						tree.DBoolFalse,                                 // tginitdeferred
+						tree.NewDInt(tree.DInt(len(trigger.Args))),              // tgnargs
+						tree.NewDIntVectorFromDArray(tree.NewDArray(types.Int)), // tgattr
+						tree.NewDBytes(tree.DBytes(args)),                       // tgargs
+						tree.DNull,                                              // tgqual
+						tree.DNull,                                              // tgoldtable
+						tree.DNull,                                              // tgnewtable
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
 }
 
+// pgTriggerType returns the value of pg_trigger.tgtype for the given
+// trigger, which encodes its level, action time and events as in
+// src/include/catalog/pg_trigger.h.
+func pgTriggerType(trigger *sqlbase.TriggerDescriptor) int {
+	const (
+		tgTypeRow    = 1 << 0
+		tgTypeBefore = 1 << 1
+		tgTypeInsert = 1 << 2
+		tgTypeDelete = 1 << 3
+		tgTypeUpdate = 1 << 4
+	)
+	var typ int
+	if trigger.ForEachRow {
+		typ |= tgTypeRow
+	}
+	if trigger.ActionTime == sqlbase.TriggerDescriptor_BEFORE {
+		typ |= tgTypeBefore
+	}
+	for _, e := range trigger.Events {
+		switch e {
+		case sqlbase.TriggerDescriptor_INSERT:
+			typ |= tgTypeInsert
+		case sqlbase.TriggerDescriptor_DELETE:
+			typ |= tgTypeDelete
+		case sqlbase.TriggerDescriptor_UPDATE:
+			typ |= tgTypeUpdate
+		}
+	}
+	return typ
+}
+
 var (
 	typTypeBase      = tree.NewDString("b")
 	typTypeComposite = tree.NewDString("c")
@@ -1979,7 +2170,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typTypeComposite
 	_ = typTypeDomain
-	_ = typTypeEnum
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -2002,7 +2192,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryComposite
-	_ = typCategoryEnum
 	_ = typCategoryGeometric
 	_ = typCategoryRange
 	_ = typCategoryBitString
@@ -2122,7 +2311,48 @@ CREATE TABLE pg_catalog.pg_type (
 					return err
 				}
 			}
-			return nil
+
+			// User-defined types.
+			publicOid := h.NamespaceOid(db, tree.PublicSchema)
+			return forEachTypeDesc(ctx, p, db, func(typDesc *sqlbase.TypeDescriptor) error {
+				typ := typDesc.EnumType()
+				return addRow(
+					tree.NewDOid(tree.DInt(typ.Oid())), // oid
+					tree.NewDName(typDesc.Name),        // typname
+					publicOid,                          // typnamespace
+					tree.DNull,                         // typowner
+					typLen(typ),                        // typlen
+					typByVal(typ),                      // typbyval
+					typTypeEnum,                        // typtype
+					typCategoryEnum,                    // typcategory
+					tree.DBoolFalse,                    // typispreferred
+					tree.DBoolTrue,                     // typisdefined
+					typDelim,                           // typdelim
+					oidZero,                            // typrelid
+					oidZero,                            // typelem
+					oidZero,                            // typarray
+
+					// regproc references
+					h.RegProc("enum_in"),   // typinput
+					h.RegProc("enum_out"),  // typoutput
+					h.RegProc("enum_recv"), // typreceive
+					h.RegProc("enum_send"), // typsend
+					oidZero,                // typmodin
+					oidZero,                // typmodout
+					oidZero,                // typanalyze
+
+					tree.DNull,      // typalign
+					tree.DNull,      // typstorage
+					tree.DBoolFalse, // typnotnull
+					oidZero,         // typbasetype
+					negOneVal,       // typtypmod
+					zeroVal,         // typndims
+					oidZero,         // typcollation
+					tree.DNull,      // typdefaultbin
+					tree.DNull,      // typdefault
+					tree.DNull,      // typacl
+				)
+			})
 		})
 	},
 }
@@ -2210,7 +2440,6 @@ CREATE TABLE pg_catalog.pg_stat_activity (
 	},
 }
 
-//
 // See https://www.postgresql.org/docs/current/static/catalog-pg-seclabel.html
 var pgCatalogSecurityLabelTable = virtualSchemaTable{
 	schema: `
@@ -2283,6 +2512,7 @@ var datumToTypeCategory = map[reflect.Type]*tree.DString{
 	reflect.TypeOf(types.Bytes):       typCategoryUserDefined,
 	reflect.TypeOf(types.Date):        typCategoryDateTime,
 	reflect.TypeOf(types.Time):        typCategoryDateTime,
+	reflect.TypeOf(types.TimeTZ):      typCategoryDateTime,
 	reflect.TypeOf(types.Float):       typCategoryNumeric,
 	reflect.TypeOf(types.Int):         typCategoryNumeric,
 	reflect.TypeOf(types.Interval):    typCategoryTimespan,
@@ -2292,9 +2522,12 @@ var datumToTypeCategory = map[reflect.Type]*tree.DString{
 	reflect.TypeOf(types.Timestamp):   typCategoryDateTime,
 	reflect.TypeOf(types.TimestampTZ): typCategoryDateTime,
 	reflect.TypeOf(types.FamTuple):    typCategoryPseudo,
+	reflect.TypeOf(types.TSQuery):     typCategoryUserDefined,
+	reflect.TypeOf(types.TSVector):    typCategoryUserDefined,
 	reflect.TypeOf(types.Oid):         typCategoryNumeric,
 	reflect.TypeOf(types.UUID):        typCategoryUserDefined,
 	reflect.TypeOf(types.INet):        typCategoryNetworkAddr,
+	reflect.TypeOf(types.FamEnum):     typCategoryEnum,
 }
 
 func typCategory(typ types.T) tree.Datum {
@@ -2321,7 +2554,8 @@ CREATE TABLE pg_catalog.pg_views (
 		// because it does not distinguish views in separate databases.
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /*virtual schemas do not have views*/
 			func(db *sqlbase.DatabaseDescriptor, scName string, desc *sqlbase.TableDescriptor) error {
-				if !desc.IsView() {
+				// Like in postgres, materialized views are not listed here.
+				if !desc.IsView() || desc.MaterializedView() {
 					return nil
 				}
 				// Note that the view query printed will not include any column aliases
@@ -2353,16 +2587,15 @@ CREATE TABLE pg_catalog.pg_views (
 // are unique across all objects and that they are stable across accesses.
 //
 // The type has a few layers of methods:
-// - write<go_type> methods write concrete types to the underlying running hash.
-// - write<db_object> methods account for single database objects like TableDescriptors
-//   or IndexDescriptors in the running hash. These methods aim to write information
-//   that would uniquely fingerprint the object to the hash using the first layer of
-//   methods.
-// - <DB_Object>Oid methods use the second layer of methods to construct a unique
-//   object identifier for the provided database object. This object identifier will
-//   be returned as a *tree.DInt, and the running hash will be reset. These are the
-//   only methods that are part of the oidHasher's external facing interface.
-//
+//   - write<go_type> methods write concrete types to the underlying running hash.
+//   - write<db_object> methods account for single database objects like TableDescriptors
+//     or IndexDescriptors in the running hash. These methods aim to write information
+//     that would uniquely fingerprint the object to the hash using the first layer of
+//     methods.
+//   - <DB_Object>Oid methods use the second layer of methods to construct a unique
+//     object identifier for the provided database object. This object identifier will
+//     be returned as a *tree.DInt, and the running hash will be reset. These are the
+//     only methods that are part of the oidHasher's external facing interface.
 type oidHasher struct {
 	h hash.Hash32
 }
@@ -2414,6 +2647,9 @@ const (
 	userTypeTag
 	collationTypeTag
 	operatorTypeTag
+	userDefinedFunctionTypeTag
+	enumMemberTypeTag
+	triggerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -2543,6 +2779,39 @@ func (h oidHasher) BuiltinOid(name string, builtin *tree.Overload) *tree.DOid {
 	return h.getOid()
 }
 
+// UserDefinedFunctionOid returns the OID of a user-defined function.
+func (h oidHasher) UserDefinedFunctionOid(
+	db *sqlbase.DatabaseDescriptor, fn *sqlbase.FunctionDescriptor,
+) *tree.DOid {
+	h.writeTypeTag(userDefinedFunctionTypeTag)
+	h.writeDB(db)
+	h.writeUInt32(uint32(fn.ID))
+	return h.getOid()
+}
+
+// TriggerOid returns the OID of a trigger.
+func (h oidHasher) TriggerOid(
+	db *sqlbase.DatabaseDescriptor,
+	scName string,
+	table *sqlbase.TableDescriptor,
+	trigger *sqlbase.TriggerDescriptor,
+) *tree.DOid {
+	h.writeTypeTag(triggerTypeTag)
+	h.writeDB(db)
+	h.writeSchema(scName)
+	h.writeTable(table)
+	h.writeStr(trigger.Name)
+	return h.getOid()
+}
+
+// EnumOid returns the OID of the i-th member of an enum type.
+func (h oidHasher) EnumOid(typ *sqlbase.TypeDescriptor, i int) *tree.DOid {
+	h.writeTypeTag(enumMemberTypeTag)
+	h.writeUInt32(uint32(typ.ID))
+	h.writeStr(typ.EnumMembers[i].LogicalRepresentation)
+	return h.getOid()
+}
+
 func (h oidHasher) RegProc(name string) tree.Datum {
 	_, overloads := builtins.GetBuiltinProperties(name)
 	if len(overloads) == 0 {
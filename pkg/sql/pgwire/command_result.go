@@ -81,6 +81,10 @@ type commandResult struct {
 	// oids is a map from result column index to its Oid, similar to formatCodes
 	// (except oids must always be set).
 	oids []oid.Oid
+
+	// copyFormat is set for COPY TO statements, whose rows are sent with the
+	// Copy-out subprotocol.
+	copyFormat *sql.CopyFormat
 }
 
 func (c *conn) makeCommandResult(
@@ -90,7 +94,7 @@ func (c *conn) makeCommandResult(
 	formatCodes []pgwirebase.FormatCode,
 	conv sessiondata.DataConversionConfig,
 ) commandResult {
-	return commandResult{
+	r := commandResult{
 		conn:           c,
 		pos:            pos,
 		descOpt:        descOpt,
@@ -100,6 +104,14 @@ func (c *conn) makeCommandResult(
 		cmdCompleteTag: stmt.StatementTag(),
 		conv:           conv,
 	}
+	if cp, ok := stmt.(*tree.CopyTo); ok {
+		// Invalid options are reported when the statement is planned, in which
+		// case the statement does not produce any rows.
+		if format, err := sql.MakeCopyFormat(&cp.Options); err == nil {
+			r.copyFormat = &format
+		}
+	}
+	return r
 }
 
 func (c *conn) makeMiscResult(pos sql.CmdPos, typ completionMsgType) commandResult {
@@ -139,6 +151,9 @@ func (r *commandResult) Close(t sql.TransactionStatusIndicator) {
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
+		if r.copyFormat != nil {
+			r.conn.bufferCopyDone()
+		}
 		tag := cookTag(
 			r.cmdCompleteTag, r.conn.writerState.tagBuf[:0], r.stmtType, r.rowsAffected,
 		)
@@ -217,7 +232,11 @@ func (r *commandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	}
 	r.rowsAffected++
 
-	r.conn.bufferRow(ctx, row, r.formatCodes, r.conv, r.oids)
+	if r.copyFormat != nil {
+		r.conn.bufferCopyData(ctx, row, r.copyFormat, r.conv)
+	} else {
+		r.conn.bufferRow(ctx, row, r.formatCodes, r.conv, r.oids)
+	}
 	_ /* flushed */, err := r.conn.maybeFlush(r.pos)
 	return err
 }
@@ -225,7 +244,12 @@ func (r *commandResult) AddRow(ctx context.Context, row tree.Datums) error {
 // SetColumns is part of the CommandResult interface.
 func (r *commandResult) SetColumns(ctx context.Context, cols sqlbase.ResultColumns) {
 	r.conn.writerState.fi.registerCmd(r.pos)
-	if r.descOpt == sql.NeedRowDesc {
+	if r.copyFormat != nil {
+		r.conn.bufferCopyOutResponse(len(cols))
+		if r.copyFormat.Header {
+			r.conn.bufferCopyHeader(cols, r.copyFormat)
+		}
+	} else if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
 	r.oids = make([]oid.Oid, len(cols))
@@ -96,6 +96,9 @@ type conn struct {
 
 	readBuf    pgwirebase.ReadBuffer
 	msgBuilder writeBuffer
+	// copyBuf is used to format the values of the rows of COPY TO statements
+	// before they are escaped into CopyData messages.
+	copyBuf writeBuffer
 }
 
 // serveConn creates a conn that will serve the netConn. It returns once the
@@ -180,6 +183,7 @@ func newConn(netConn net.Conn, sArgs sql.SessionArgs, metrics *ServerMetrics) *c
 	c.writerState.fi.lastFlushed = -1
 	c.writerState.fi.cmdStarts = make(map[sql.CmdPos]int)
 	c.msgBuilder.init(metrics.BytesOutCount)
+	c.copyBuf.init(metrics.BytesOutCount)
 
 	return c
 }
@@ -603,6 +607,11 @@ func (c *conn) handleParse(
 		// https://www.postgresql.org/message-id/flat/CAMsr%2BYGvp2wRx9pPSxaKFdaObxX8DzWse%2BOkWk2xpXSvT0rq-g%40mail.gmail.com#CAMsr+YGvp2wRx9pPSxaKFdaObxX8DzWse+OkWk2xpXSvT0rq-g@mail.gmail.com
 		return c.stmtBuf.Push(ctx, sql.SendError{Err: fmt.Errorf("CopyFrom not supported in extended protocol mode")})
 	}
+	if _, ok := stmt.AST.(*tree.CopyTo); ok {
+		// COPY TO answers with a CopyOutResponse instead of a row description,
+		// which cannot be described to a client preparing the statement.
+		return c.stmtBuf.Push(ctx, sql.SendError{Err: fmt.Errorf("CopyTo not supported in extended protocol mode")})
+	}
 
 	return c.stmtBuf.Push(
 		ctx,
@@ -938,6 +947,130 @@ func (c *conn) bufferRow(
 	}
 }
 
+// bufferCopyOutResponse adds a CopyOutResponse message to the buffer. The
+// message starts the Copy-out subprotocol, in which the rows of a COPY TO
+// statement are sent as CopyData messages.
+func (c *conn) bufferCopyOutResponse(numCols int) {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyOutResponse)
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(int16(numCols))
+	for i := 0; i < numCols; i++ {
+		c.msgBuilder.putInt16(int16(pgwirebase.FormatText))
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(fmt.Sprintf("unexpected err from buffer: %s", err))
+	}
+}
+
+// bufferCopyHeader adds a CopyData message containing the names of the given
+// columns to the buffer.
+func (c *conn) bufferCopyHeader(cols sqlbase.ResultColumns, format *sql.CopyFormat) {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+	for i := range cols {
+		if i > 0 {
+			c.msgBuilder.writeByte(format.Delimiter)
+		}
+		writeCopyField(&c.msgBuilder, []byte(cols[i].Name), format)
+	}
+	c.msgBuilder.writeByte('\n')
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(fmt.Sprintf("unexpected err from buffer: %s", err))
+	}
+}
+
+// bufferCopyData serializes a row of a COPY TO statement as a CopyData message
+// and adds it to the buffer. The values are formatted like in the text
+// encoding of DataRow messages, and then escaped according to format.
+func (c *conn) bufferCopyData(
+	ctx context.Context,
+	row tree.Datums,
+	format *sql.CopyFormat,
+	conv sessiondata.DataConversionConfig,
+) {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+	for i, col := range row {
+		if i > 0 {
+			c.msgBuilder.writeByte(format.Delimiter)
+		}
+		if col == tree.DNull {
+			c.msgBuilder.writeString(format.Null)
+			continue
+		}
+		c.copyBuf.reset()
+		c.copyBuf.writeTextDatum(ctx, col, conv)
+		if c.copyBuf.err != nil {
+			c.msgBuilder.setError(c.copyBuf.err)
+			break
+		}
+		// Skip the length prefix of the value.
+		writeCopyField(&c.msgBuilder, c.copyBuf.wrapped.Bytes()[4:], format)
+	}
+	c.msgBuilder.writeByte('\n')
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(fmt.Sprintf("unexpected err from buffer: %s", err))
+	}
+}
+
+// writeCopyField writes a non-NULL value of a row of a COPY TO statement to b.
+//
+// In the text format, backslashes, the delimiter and the characters which
+// would break the line are escaped with a backslash. In the CSV format, the
+// value is quoted if it contains the delimiter, a quote or a line break, or if
+// it could be mistaken for a NULL value.
+func writeCopyField(b *writeBuffer, field []byte, format *sql.CopyFormat) {
+	if !format.CSV {
+		for _, ch := range field {
+			switch ch {
+			case '\b':
+				b.writeString(`\b`)
+			case '\f':
+				b.writeString(`\f`)
+			case '\n':
+				b.writeString(`\n`)
+			case '\r':
+				b.writeString(`\r`)
+			case '\t':
+				b.writeString(`\t`)
+			case '\v':
+				b.writeString(`\v`)
+			case '\\':
+				b.writeString(`\\`)
+			default:
+				if ch == format.Delimiter {
+					b.writeByte('\\')
+				}
+				b.writeByte(ch)
+			}
+		}
+		return
+	}
+
+	needsQuotes := string(field) == format.Null ||
+		bytes.IndexByte(field, format.Delimiter) >= 0 ||
+		bytes.ContainsAny(field, "\"\r\n")
+	if !needsQuotes {
+		b.write(field)
+		return
+	}
+	b.writeByte('"')
+	for _, ch := range field {
+		if ch == '"' {
+			b.writeByte('"')
+		}
+		b.writeByte(ch)
+	}
+	b.writeByte('"')
+}
+
+// bufferCopyDone adds a CopyDone message to the buffer. The message ends the
+// Copy-out subprotocol.
+func (c *conn) bufferCopyDone() {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDone)
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(fmt.Sprintf("unexpected err from buffer: %s", err))
+	}
+}
+
 func (c *conn) bufferReadyForQuery(txnStatus byte) {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgReady)
 	c.msgBuilder.writeByte(txnStatus)
@@ -1609,6 +1609,102 @@ func TestCmdCompleteVsEmptyStatements(t *testing.T) {
 	}
 }
 
+// TestPGWireCopyTo verifies that COPY TO STDOUT uses the Copy-out
+// subprotocol. lib/pq does not support it, so we use pgx.
+func TestPGWireCopyTo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.TODO())
+
+	if _, err := db.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (a INT PRIMARY KEY, b STRING, c BYTES);
+INSERT INTO d.t VALUES (1, 'foo', NULL), (2, e'tab\there', 'x'), (3, 'quote"comma,', NULL);
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	pgURL, cleanupFn := sqlutils.PGUrl(
+		t, s.ServingAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanupFn()
+	pgURL.Path = "d"
+	pgxConfig, err := pgx.ParseConnectionString(pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := pgx.Connect(pgxConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	testCases := []struct {
+		query    string
+		expected string
+		tag      pgx.CommandTag
+	}{
+		{
+			query:    `COPY t TO STDOUT`,
+			expected: "1\tfoo\t\\N\n2\ttab\\there\t\\\\x78\n3\tquote\"comma,\t\\N\n",
+			tag:      "COPY 3",
+		},
+		{
+			query:    `COPY t (b, a) TO STDOUT WITH CSV HEADER`,
+			expected: "b,a\nfoo,1\ntab\there,2\n\"quote\"\"comma,\",3\n",
+			tag:      "COPY 3",
+		},
+		{
+			query:    `COPY (SELECT a, NULL, '' FROM t WHERE a = 1) TO STDOUT WITH (FORMAT csv)`,
+			expected: "1,,\"\"\n",
+			tag:      "COPY 1",
+		},
+		{
+			query:    `COPY (SELECT a, NULL FROM t WHERE a < 3 ORDER BY a) TO STDOUT WITH (DELIMITER '|', NULL 'nil')`,
+			expected: "1|nil\n2|nil\n",
+			tag:      "COPY 2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			var buf strings.Builder
+			tag, err := conn.CopyToWriter(&buf, tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tag != tc.tag {
+				t.Errorf("expected tag %q, got %q", tc.tag, tag)
+			}
+			if out := buf.String(); out != tc.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tc.expected, out)
+			}
+		})
+	}
+
+	// The results of a large COPY are flushed to the client as they are
+	// produced.
+	t.Run("large", func(t *testing.T) {
+		var buf strings.Builder
+		tag, err := conn.CopyToWriter(&buf, `COPY (SELECT * FROM generate_series(1, 10000)) TO STDOUT`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tag != "COPY 10000" {
+			t.Errorf("expected tag COPY 10000, got %q", tag)
+		}
+		if n := strings.Count(buf.String(), "\n"); n != 10000 {
+			t.Errorf("expected 10000 lines, got %d", n)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var buf strings.Builder
+		_, err := conn.CopyToWriter(&buf, `COPY t TO STDOUT WITH (HEADER)`)
+		if !testutils.IsError(err, "COPY HEADER available only in CSV mode") {
+			t.Fatalf("expected error, got %v", err)
+		}
+	})
+}
+
 // Unfortunately lib/pq doesn't expose returned command tags directly, but we can test
 // the methods where it depends on their values (Begin, Commit, RowsAffected for INSERTs).
 func TestPGCommandTags(t *testing.T) {
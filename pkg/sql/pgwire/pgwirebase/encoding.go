@@ -264,6 +264,12 @@ func DecodeOidDatum(
 				return nil, errors.Errorf("could not parse string %q as time", b)
 			}
 			return d, nil
+		case oid.T_timetz:
+			d, err := tree.ParseDTimeTZ(ctx, string(b))
+			if err != nil {
+				return nil, errors.Errorf("could not parse string %q as timetz", b)
+			}
+			return d, nil
 
 		case oid.T_interval:
 			d, err := tree.ParseDInterval(string(b))
@@ -342,6 +348,16 @@ func DecodeOidDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(string(b))
+		case oid.T_tsvector:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSVector(string(b))
+		case oid.T_tsquery:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSQuery(string(b))
 		}
 		if _, ok := types.ArrayOids[id]; ok {
 			// Arrays come in in their string form, so we parse them as such and later
@@ -502,6 +518,14 @@ func DecodeOidDatum(
 			}
 			i := int64(binary.BigEndian.Uint64(b))
 			return tree.MakeDTime(timeofday.TimeOfDay(i)), nil
+		case oid.T_timetz:
+			if len(b) < 12 {
+				return nil, errors.Errorf("timetz requires 12 bytes for binary format")
+			}
+			i := int64(binary.BigEndian.Uint64(b))
+			// Postgres sends the offset in seconds west of UTC.
+			zone := int32(binary.BigEndian.Uint32(b[8:]))
+			return tree.MakeDTimeTZ(timeofday.TimeOfDay(i), -zone), nil
 		case oid.T_interval:
 			if len(b) < 16 {
 				return nil, errors.Errorf("interval requires 16 bytes for binary format")
@@ -585,6 +609,15 @@ func DecodeOidDatum(
 		}
 		return tree.NewDName(string(b)), nil
 	default:
+		if id >= types.UserDefinedTypeOIDOffset {
+			// Values of user-defined enum types are sent as their labels. They
+			// are converted to their actual datum form when the placeholder is
+			// evaluated.
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.NewDString(string(b)), nil
+		}
 		return nil, errors.Errorf("unsupported OID %v with format code %s", id, code)
 	}
 }
@@ -44,7 +44,10 @@ const (
 	ServerMsgBindComplete         ServerMessageType = '2'
 	ServerMsgCommandComplete      ServerMessageType = 'C'
 	ServerMsgCloseComplete        ServerMessageType = '3'
+	ServerMsgCopyData             ServerMessageType = 'd'
+	ServerMsgCopyDone             ServerMessageType = 'c'
 	ServerMsgCopyInResponse       ServerMessageType = 'G'
+	ServerMsgCopyOutResponse      ServerMessageType = 'H'
 	ServerMsgDataRow              ServerMessageType = 'D'
 	ServerMsgEmptyQuery           ServerMessageType = 'I'
 	ServerMsgErrorResponse        ServerMessageType = 'E'
@@ -7,10 +7,10 @@ import "strconv"
 const (
 	_ServerMessageType_name_0 = "ServerMsgParseCompleteServerMsgBindCompleteServerMsgCloseComplete"
 	_ServerMessageType_name_1 = "ServerMsgCommandCompleteServerMsgDataRowServerMsgErrorResponse"
-	_ServerMessageType_name_2 = "ServerMsgCopyInResponse"
-	_ServerMessageType_name_3 = "ServerMsgEmptyQuery"
-	_ServerMessageType_name_4 = "ServerMsgAuthServerMsgParameterStatusServerMsgRowDescription"
-	_ServerMessageType_name_5 = "ServerMsgReady"
+	_ServerMessageType_name_2 = "ServerMsgCopyInResponseServerMsgCopyOutResponseServerMsgEmptyQuery"
+	_ServerMessageType_name_3 = "ServerMsgAuthServerMsgParameterStatusServerMsgRowDescription"
+	_ServerMessageType_name_4 = "ServerMsgReady"
+	_ServerMessageType_name_5 = "ServerMsgCopyDoneServerMsgCopyData"
 	_ServerMessageType_name_6 = "ServerMsgNoData"
 	_ServerMessageType_name_7 = "ServerMsgParameterDescription"
 )
@@ -18,7 +18,9 @@ const (
 var (
 	_ServerMessageType_index_0 = [...]uint8{0, 22, 43, 65}
 	_ServerMessageType_index_1 = [...]uint8{0, 24, 40, 62}
-	_ServerMessageType_index_4 = [...]uint8{0, 13, 37, 60}
+	_ServerMessageType_index_2 = [...]uint8{0, 23, 47, 66}
+	_ServerMessageType_index_3 = [...]uint8{0, 13, 37, 60}
+	_ServerMessageType_index_5 = [...]uint8{0, 17, 34}
 )
 
 func (i ServerMessageType) String() string {
@@ -29,15 +31,17 @@ func (i ServerMessageType) String() string {
 	case 67 <= i && i <= 69:
 		i -= 67
 		return _ServerMessageType_name_1[_ServerMessageType_index_1[i]:_ServerMessageType_index_1[i+1]]
-	case i == 71:
-		return _ServerMessageType_name_2
-	case i == 73:
-		return _ServerMessageType_name_3
+	case 71 <= i && i <= 73:
+		i -= 71
+		return _ServerMessageType_name_2[_ServerMessageType_index_2[i]:_ServerMessageType_index_2[i+1]]
 	case 82 <= i && i <= 84:
 		i -= 82
-		return _ServerMessageType_name_4[_ServerMessageType_index_4[i]:_ServerMessageType_index_4[i+1]]
+		return _ServerMessageType_name_3[_ServerMessageType_index_3[i]:_ServerMessageType_index_3[i+1]]
 	case i == 90:
-		return _ServerMessageType_name_5
+		return _ServerMessageType_name_4
+	case 99 <= i && i <= 100:
+		i -= 99
+		return _ServerMessageType_name_5[_ServerMessageType_index_5[i]:_ServerMessageType_index_5[i+1]]
 	case i == 110:
 		return _ServerMessageType_name_6
 	case i == 116:
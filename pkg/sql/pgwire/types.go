@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/lib/pq/oid"
 	"github.com/pkg/errors"
 )
@@ -121,6 +122,9 @@ func (b *writeBuffer) writeTextDatum(
 	case *tree.DCollatedString:
 		b.writeLengthPrefixedString(v.Contents)
 
+	case *tree.DEnum:
+		b.writeLengthPrefixedString(v.LogicalRep)
+
 	case *tree.DDate:
 		t := timeutil.Unix(int64(*v)*secondsInDay, 0)
 		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
@@ -134,6 +138,12 @@ func (b *writeBuffer) writeTextDatum(
 		b.putInt32(int32(len(s)))
 		b.write(s)
 
+	case *tree.DTimeTZ:
+		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
+		s := formatTimeTZ(v, b.putbuf[4:4])
+		b.putInt32(int32(len(s)))
+		b.write(s)
+
 	case *tree.DTimestamp:
 		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
 		s := formatTs(v.Time, nil, b.putbuf[4:4])
@@ -152,6 +162,12 @@ func (b *writeBuffer) writeTextDatum(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DTSVector:
+		b.writeLengthPrefixedString(v.TSVector.String())
+
+	case *tree.DTSQuery:
+		b.writeLengthPrefixedString(v.TSQuery.String())
+
 	case *tree.DTuple:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -394,6 +410,9 @@ func (b *writeBuffer) writeBinaryDatum(
 	case *tree.DCollatedString:
 		b.writeLengthPrefixedString(v.Contents)
 
+	case *tree.DEnum:
+		b.writeLengthPrefixedString(v.LogicalRep)
+
 	case *tree.DTimestamp:
 		b.putInt32(8)
 		b.putInt64(timeToPgBinary(v.Time, nil))
@@ -410,6 +429,12 @@ func (b *writeBuffer) writeBinaryDatum(
 		b.putInt32(8)
 		b.putInt64(int64(*v))
 
+	case *tree.DTimeTZ:
+		// Postgres sends the offset in seconds west of UTC.
+		b.putInt32(12)
+		b.putInt64(int64(v.TimeOfDay))
+		b.putInt32(-v.OffsetSecs)
+
 	case *tree.DInterval:
 		b.putInt32(16)
 		b.putInt64(v.Nanos() / int64(time.Microsecond/time.Nanosecond))
@@ -457,6 +482,22 @@ func (b *writeBuffer) writeBinaryDatum(
 		// Postgres version number, as of writing, `1` is the only valid value.
 		b.writeByte(1)
 		b.writeString(s)
+	case *tree.DTSVector:
+		subWriter := newWriteBuffer(nil /* bytecount */)
+		subWriter.putInt32(int32(len(v.TSVector)))
+		for _, l := range v.TSVector {
+			subWriter.writeTerminatedString(l.Word)
+			subWriter.putInt16(int16(len(l.Positions)))
+			for _, p := range l.Positions {
+				subWriter.putInt16(int16(uint16(p.Weight)<<14 | p.Pos))
+			}
+		}
+		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
+	case *tree.DTSQuery:
+		subWriter := newWriteBuffer(nil /* bytecount */)
+		subWriter.putInt32(int32(countTSQueryItems(v.Root)))
+		writeTSQueryItems(subWriter, v.Root)
+		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.DInt))
@@ -470,6 +511,7 @@ const (
 	pgDateFormat              = "2006-01-02"
 	pgTimeStampFormatNoOffset = pgDateFormat + " " + pgTimeFormat
 	pgTimeStampFormat         = pgTimeStampFormatNoOffset + "-07:00"
+	pgTimeTZFormat            = pgTimeFormat + "-07:00"
 )
 
 // formatTime formats t into a format lib/pq understands, appending to the
@@ -479,6 +521,11 @@ func formatTime(t timeofday.TimeOfDay, tmp []byte) []byte {
 	return t.ToTime().AppendFormat(tmp, pgTimeFormat)
 }
 
+func formatTimeTZ(t *tree.DTimeTZ, tmp []byte) []byte {
+	loc := time.FixedZone("", int(t.OffsetSecs))
+	return t.ToTime().Add(-time.Duration(t.OffsetSecs)*time.Second).In(loc).AppendFormat(tmp, pgTimeTZFormat)
+}
+
 func formatTs(t time.Time, offset *time.Location, tmp []byte) (b []byte) {
 	var format string
 	if offset != nil {
@@ -537,3 +584,55 @@ func timeToPgBinary(t time.Time, offset *time.Location) int64 {
 func dateToPgBinary(d *tree.DDate) int32 {
 	return int32(*d) - pgwirebase.PGEpochJDateFromUnix
 }
+
+// The item types and operator codes of the Postgres binary format for
+// tsquery.
+const (
+	pgTSQueryVal = 1
+	pgTSQueryOpr = 2
+
+	pgTSQueryOpNot = 1
+	pgTSQueryOpAnd = 2
+	pgTSQueryOpOr  = 3
+)
+
+// countTSQueryItems returns the number of operands and operators in the
+// tsquery expression tree rooted at n.
+func countTSQueryItems(n *tsearch.QueryNode) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + countTSQueryItems(n.Left) + countTSQueryItems(n.Right)
+}
+
+// writeTSQueryItems writes the tsquery expression tree rooted at n in the
+// Postgres binary format. Like Postgres, the items are written in prefix
+// order, with the right operand of a binary operator before its left one.
+func writeTSQueryItems(b *writeBuffer, n *tsearch.QueryNode) {
+	if n == nil {
+		return
+	}
+	switch n.Op {
+	case tsearch.QueryLexeme:
+		b.writeByte(pgTSQueryVal)
+		b.writeByte(byte(n.Weights))
+		prefix := byte(0)
+		if n.Prefix {
+			prefix = 1
+		}
+		b.writeByte(prefix)
+		b.writeTerminatedString(n.Lexeme)
+		return
+	case tsearch.QueryNot:
+		b.writeByte(pgTSQueryOpr)
+		b.writeByte(pgTSQueryOpNot)
+	case tsearch.QueryAnd:
+		b.writeByte(pgTSQueryOpr)
+		b.writeByte(pgTSQueryOpAnd)
+	case tsearch.QueryOr:
+		b.writeByte(pgTSQueryOpr)
+		b.writeByte(pgTSQueryOpOr)
+	}
+	writeTSQueryItems(b, n.Right)
+	writeTSQueryItems(b, n.Left)
+}
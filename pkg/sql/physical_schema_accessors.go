@@ -69,9 +69,16 @@ func (a UncachedPhysicalAccessor) GetDatabaseDesc(
 }
 
 // IsValidSchema implements the SchemaAccessor interface.
-func (a UncachedPhysicalAccessor) IsValidSchema(dbDesc *DatabaseDescriptor, scName string) bool {
-	// At this point, only the public schema is recognized.
-	return scName == tree.PublicSchema
+func (a UncachedPhysicalAccessor) IsValidSchema(
+	ctx context.Context, txn *client.Txn, dbDesc *DatabaseDescriptor, scName string,
+) (bool, error) {
+	// Whether a temporary schema is visible to the current session is
+	// decided by the planner.
+	if scName == tree.PublicSchema || isTemporarySchemaName(scName) {
+		return true, nil
+	}
+	sc, err := getSchemaDesc(ctx, txn, dbDesc.ID, scName)
+	return sc != nil, err
 }
 
 // GetObjectNames implements the SchemaAccessor interface.
@@ -82,16 +89,19 @@ func (a UncachedPhysicalAccessor) GetObjectNames(
 	scName string,
 	flags DatabaseListFlags,
 ) (TableNames, error) {
-	if ok := a.IsValidSchema(dbDesc, scName); !ok {
-		if flags.required {
-			tn := tree.MakeTableNameWithSchema(tree.Name(dbDesc.Name), tree.Name(scName), "")
-			return nil, sqlbase.NewUnsupportedSchemaUsageError(tree.ErrString(&tn.TableNamePrefix))
+	log.Eventf(ctx, "fetching list of objects for %q", dbDesc.Name)
+	parentID, err := getSchemaNamespaceID(ctx, txn, dbDesc.ID, scName)
+	if err != nil {
+		return nil, err
+	}
+	if parentID == sqlbase.InvalidID {
+		// A temporary schema which does not exist yet has no objects.
+		if flags.required && !isTemporarySchemaName(scName) {
+			return nil, sqlbase.NewUndefinedSchemaError(scName)
 		}
 		return nil, nil
 	}
-
-	log.Eventf(ctx, "fetching list of objects for %q", dbDesc.Name)
-	prefix := sqlbase.MakeNameMetadataKey(dbDesc.ID, "")
+	prefix := sqlbase.MakeNameMetadataKey(parentID, "")
 	sr, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0)
 	if err != nil {
 		return nil, err
@@ -104,7 +114,12 @@ func (a UncachedPhysicalAccessor) GetObjectNames(
 		if err != nil {
 			return nil, err
 		}
-		tn := tree.MakeTableName(tree.Name(dbDesc.Name), tree.Name(tableName))
+		if isTemporarySchemaName(tableName) {
+			// Temporary schemas are stored alongside the objects of the
+			// public schema.
+			continue
+		}
+		tn := tree.MakeTableNameWithSchema(tree.Name(dbDesc.Name), tree.Name(scName), tree.Name(tableName))
 		tn.ExplicitCatalog = flags.explicitPrefix
 		tn.ExplicitSchema = flags.explicitPrefix
 		tableNames = append(tableNames, tn)
@@ -116,14 +131,6 @@ func (a UncachedPhysicalAccessor) GetObjectNames(
 func (a UncachedPhysicalAccessor) GetObjectDesc(
 	ctx context.Context, txn *client.Txn, name *ObjectName, flags ObjectLookupFlags,
 ) (ObjectDescriptor, *DatabaseDescriptor, error) {
-	// At this point, only the public schema is recognized.
-	if name.Schema() != tree.PublicSchema {
-		if flags.required {
-			return nil, nil, sqlbase.NewUnsupportedSchemaUsageError(tree.ErrString(name))
-		}
-		return nil, nil, nil
-	}
-
 	// Look up the database.
 	dbDesc, err := a.GetDatabaseDesc(ctx, txn, name.Catalog(), flags.CommonLookupFlags)
 	if dbDesc == nil || err != nil {
@@ -132,11 +139,24 @@ func (a UncachedPhysicalAccessor) GetObjectDesc(
 		return nil, dbDesc, err
 	}
 
+	parentID, err := getSchemaNamespaceID(ctx, txn, dbDesc.ID, name.Schema())
+	if err != nil {
+		return nil, nil, err
+	}
+	if parentID == sqlbase.InvalidID && flags.required && !isTemporarySchemaName(name.Schema()) {
+		return nil, nil, sqlbase.NewUndefinedSchemaError(name.Schema())
+	}
+
 	// Look up the table using the discovered database descriptor.
 	desc := &sqlbase.TableDescriptor{}
-	found, err := getDescriptor(ctx, txn,
-		tableKey{parentID: dbDesc.ID, name: name.Table()}, desc)
-	if err != nil {
+	found := false
+	if parentID != sqlbase.InvalidID {
+		found, err = getDescriptor(ctx, txn,
+			tableKey{parentID: parentID, name: name.Table()}, desc)
+	}
+	if err != nil && err != sqlbase.ErrDescriptorNotFound {
+		// ErrDescriptorNotFound means that the name refers to a function or
+		// a type.
 		return nil, nil, err
 	}
 
@@ -165,14 +165,19 @@ type planNodeFastPath interface {
 
 var _ planNode = &alterIndexNode{}
 var _ planNode = &alterSequenceNode{}
+var _ planNode = &alterTypeNode{}
 var _ planNode = &alterTableNode{}
 var _ planNode = &cancelQueriesNode{}
 var _ planNode = &cancelSessionsNode{}
 var _ planNode = &createDatabaseNode{}
+var _ planNode = &createFunctionNode{}
+var _ planNode = &createTypeNode{}
+var _ planNode = &createSchemaNode{}
 var _ planNode = &createIndexNode{}
 var _ planNode = &createSequenceNode{}
 var _ planNode = &createStatsNode{}
 var _ planNode = &createTableNode{}
+var _ planNode = &createTriggerNode{}
 var _ planNode = &CreateUserNode{}
 var _ planNode = &createViewNode{}
 var _ planNode = &delayedNode{}
@@ -180,9 +185,13 @@ var _ planNode = &deleteNode{}
 var _ planNode = &deleteRangeNode{}
 var _ planNode = &distinctNode{}
 var _ planNode = &dropDatabaseNode{}
+var _ planNode = &dropFunctionNode{}
+var _ planNode = &dropTypeNode{}
+var _ planNode = &dropSchemaNode{}
 var _ planNode = &dropIndexNode{}
 var _ planNode = &dropSequenceNode{}
 var _ planNode = &dropTableNode{}
+var _ planNode = &dropTriggerNode{}
 var _ planNode = &DropUserNode{}
 var _ planNode = &dropViewNode{}
 var _ planNode = &explainDistSQLNode{}
@@ -197,6 +206,8 @@ var _ planNode = &limitNode{}
 var _ planNode = &max1RowNode{}
 var _ planNode = &ordinalityNode{}
 var _ planNode = &projectSetNode{}
+var _ planNode = &recursiveCTENode{}
+var _ planNode = &refreshMaterializedViewNode{}
 var _ planNode = &relocateNode{}
 var _ planNode = &renameColumnNode{}
 var _ planNode = &renameDatabaseNode{}
@@ -207,6 +218,7 @@ var _ planNode = &rowCountNode{}
 var _ planNode = &scanNode{}
 var _ planNode = &scatterNode{}
 var _ planNode = &serializeNode{}
+var _ planNode = &setConstraintsNode{}
 var _ planNode = &sequenceSelectNode{}
 var _ planNode = &showFingerprintsNode{}
 var _ planNode = &showTraceNode{}
@@ -220,6 +232,7 @@ var _ planNode = &upsertNode{}
 var _ planNode = &valuesNode{}
 var _ planNode = &virtualTableNode{}
 var _ planNode = &windowNode{}
+var _ planNode = &workTableScanNode{}
 var _ planNode = &zeroNode{}
 
 var _ planNodeFastPath = &CreateUserNode{}
@@ -635,6 +648,8 @@ func (p *planner) newPlan(
 		return p.AlterTable(ctx, n)
 	case *tree.AlterSequence:
 		return p.AlterSequence(ctx, n)
+	case *tree.AlterType:
+		return p.AlterType(ctx, n)
 	case *tree.AlterUserSetPassword:
 		return p.AlterUserSetPassword(ctx, n)
 	case *tree.CancelQueries:
@@ -649,14 +664,20 @@ func (p *planner) newPlan(
 		return p.CommentOnTable(ctx, n)
 	case *tree.ControlJobs:
 		return p.ControlJobs(ctx, n)
+	case *tree.CopyTo:
+		return p.CopyTo(ctx, n)
 	case *tree.Scrub:
 		return p.Scrub(ctx, n)
 	case *tree.CreateDatabase:
 		return p.CreateDatabase(ctx, n)
+	case *tree.CreateFunction:
+		return p.CreateFunction(ctx, n)
 	case *tree.CreateIndex:
 		return p.CreateIndex(ctx, n)
 	case *tree.CreateTable:
 		return p.CreateTable(ctx, n)
+	case *tree.CreateTrigger:
+		return p.CreateTrigger(ctx, n)
 	case *tree.CreateUser:
 		return p.CreateUser(ctx, n)
 	case *tree.CreateView:
@@ -665,6 +686,10 @@ func (p *planner) newPlan(
 		return p.CreateSequence(ctx, n)
 	case *tree.CreateStats:
 		return p.CreateStatistics(ctx, n)
+	case *tree.CreateType:
+		return p.CreateType(ctx, n)
+	case *tree.CreateSchema:
+		return p.CreateSchema(ctx, n)
 	case *tree.Deallocate:
 		return p.Deallocate(ctx, n)
 	case *tree.Delete:
@@ -673,14 +698,22 @@ func (p *planner) newPlan(
 		return p.Discard(ctx, n)
 	case *tree.DropDatabase:
 		return p.DropDatabase(ctx, n)
+	case *tree.DropFunction:
+		return p.DropFunction(ctx, n)
 	case *tree.DropIndex:
 		return p.DropIndex(ctx, n)
 	case *tree.DropTable:
 		return p.DropTable(ctx, n)
+	case *tree.DropTrigger:
+		return p.DropTrigger(ctx, n)
 	case *tree.DropView:
 		return p.DropView(ctx, n)
 	case *tree.DropSequence:
 		return p.DropSequence(ctx, n)
+	case *tree.DropType:
+		return p.DropType(ctx, n)
+	case *tree.DropSchema:
+		return p.DropSchema(ctx, n)
 	case *tree.DropUser:
 		return p.DropUser(ctx, n)
 	case *tree.Explain:
@@ -693,6 +726,8 @@ func (p *planner) newPlan(
 		return p.newPlan(ctx, n.Select, desiredTypes)
 	case *tree.Relocate:
 		return p.Relocate(ctx, n)
+	case *tree.RefreshMaterializedView:
+		return p.RefreshMaterializedView(ctx, n)
 	case *tree.RenameColumn:
 		return p.RenameColumn(ctx, n)
 	case *tree.RenameDatabase:
@@ -716,6 +751,8 @@ func (p *planner) newPlan(
 		return p.SetZoneConfig(ctx, n)
 	case *tree.SetVar:
 		return p.SetVar(ctx, n)
+	case *tree.SetConstraints:
+		return p.SetConstraints(ctx, n)
 	case *tree.SetTransaction:
 		return p.SetTransaction(n)
 	case *tree.SetSessionCharacteristics:
@@ -730,6 +767,8 @@ func (p *planner) newPlan(
 		return p.ShowConstraints(ctx, n)
 	case *tree.ShowCreate:
 		return p.ShowCreate(ctx, n)
+	case *tree.ShowCreateFunction:
+		return p.ShowCreateFunction(ctx, n)
 	case *tree.ShowDatabases:
 		return p.ShowDatabases(ctx, n)
 	case *tree.ShowGrants:
@@ -852,6 +891,8 @@ func (p *planner) doPrepare(ctx context.Context, stmt tree.Statement) (planNode,
 		return p.ShowVar(ctx, n)
 	case *tree.ShowCreate:
 		return p.ShowCreate(ctx, n)
+	case *tree.ShowCreateFunction:
+		return p.ShowCreateFunction(ctx, n)
 	case *tree.ShowColumns:
 		return p.ShowColumns(ctx, n)
 	case *tree.ShowDatabases:
@@ -67,6 +67,8 @@ func getPlanColumns(plan planNode, mut bool) sqlbase.ResultColumns {
 		return n.columns
 	case *unionNode:
 		return n.columns
+	case *workTableScanNode:
+		return n.columns
 	case *valuesNode:
 		return n.columns
 	case *virtualTableNode:
@@ -124,6 +126,8 @@ func getPlanColumns(plan planNode, mut bool) sqlbase.ResultColumns {
 		return getPlanColumns(n.source.plan, mut)
 	case *max1RowNode:
 		return getPlanColumns(n.plan, mut)
+	case *recursiveCTENode:
+		return getPlanColumns(n.initial, mut)
 	case *limitNode:
 		return getPlanColumns(n.plan, mut)
 	case *spoolNode:
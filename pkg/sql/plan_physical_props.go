@@ -63,6 +63,7 @@ func planPhysicalProps(plan planNode) physicalProps {
 		return n.props
 	case *unionNode:
 		// TODO(knz): this can be ordered if the source is ordered already.
+	case *recursiveCTENode:
 	case *insertNode:
 		// TODO(knz): RETURNING is ordered by the PK.
 	case *updateNode, *upsertNode:
@@ -96,12 +97,17 @@ func planPhysicalProps(plan planNode) physicalProps {
 	case *DropUserNode:
 	case *alterIndexNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterTableNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueriesNode:
 	case *cancelSessionsNode:
 	case *controlJobsNode:
 	case *createDatabaseNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
+	case *createTypeNode:
+	case *createSchemaNode:
 	case *createIndexNode:
 	case *createSequenceNode:
 	case *createStatsNode:
@@ -110,12 +116,17 @@ func planPhysicalProps(plan planNode) physicalProps {
 	case *delayedNode:
 	case *deleteRangeNode:
 	case *dropDatabaseNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
+	case *dropTypeNode:
+	case *dropSchemaNode:
 	case *dropIndexNode:
 	case *dropSequenceNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *explainDistSQLNode:
 	case *hookFnNode:
+	case *refreshMaterializedViewNode:
 	case *relocateNode:
 	case *renameColumnNode:
 	case *renameDatabaseNode:
@@ -126,7 +137,9 @@ func planPhysicalProps(plan planNode) physicalProps {
 	case *scatterNode:
 	case *scrubNode:
 	case *sequenceSelectNode:
+	case *workTableScanNode:
 	case *setClusterSettingNode:
+	case *setConstraintsNode:
 	case *setVarNode:
 	case *setZoneConfigNode:
 	case *showFingerprintsNode:
@@ -36,7 +36,8 @@ func collectSpans(params runParams, plan planNode) (reads, writes roachpb.Spans,
 		*valuesNode,
 		*virtualTableNode,
 		*zeroNode,
-		*unaryNode:
+		*unaryNode,
+		*workTableScanNode:
 		return nil, nil, nil
 
 	case *scanNode:
@@ -99,6 +100,15 @@ func collectSpans(params runParams, plan planNode) (reads, writes roachpb.Spans,
 		return concatSpans(params, n.left.plan, n.right.plan)
 	case *unionNode:
 		return concatSpans(params, n.left, n.right)
+
+	case *recursiveCTENode:
+		// The plans for the recursive query are only created during execution,
+		// so we can't know which spans they will read.
+		_, writes, err := collectSpans(params, n.initial)
+		if err != nil {
+			return nil, nil, err
+		}
+		return roachpb.Spans{{Key: roachpb.KeyMin, EndKey: roachpb.KeyMax}}, writes, nil
 	}
 
 	panic(fmt.Sprintf("don't know how to collect spans for node %T", plan))
@@ -180,6 +190,10 @@ func insertNodeWithValuesSpans(
 		}
 		addWriteKey(primaryKey)
 		for _, secondaryKey := range secondaryKeys {
+			if secondaryKey.Key == nil {
+				// The row is not in this partial index.
+				continue
+			}
 			addWriteKey(secondaryKey.Key)
 		}
 
@@ -72,6 +72,15 @@ type extendedEvalContext struct {
 
 	SchemaChangers *schemaChangerCollection
 
+	// DeferredFKChecks accumulates the foreign key checks deferred to the
+	// end of the transaction. It is nil when the planner does not run on
+	// behalf of a session.
+	DeferredFKChecks *row.DeferredFKChecks
+
+	// SessionID is the ID of the session the planner runs in. It determines
+	// the name of the temporary schema of the session.
+	SessionID ClusterWideID
+
 	schemaAccessors *schemaInterface
 }
 
@@ -240,6 +249,7 @@ func newInternalPlanner(
 	p.semaCtx = tree.MakeSemaContext()
 	p.semaCtx.Location = &sd.DataConversion.Location
 	p.semaCtx.SearchPath = sd.SearchPath
+	p.semaCtx.TypeResolver = p
 
 	plannerMon := mon.MakeUnlimitedMonitor(ctx,
 		fmt.Sprintf("internal-planner.%s.%s", user, opName),
@@ -349,6 +359,17 @@ func (p *planner) Tables() *TableCollection {
 	return p.extendedEvalCtx.Tables
 }
 
+// deferredFKChecks returns the queue of foreign key checks deferred to the
+// end of the transaction, or nil if checks cannot be deferred. Checks are
+// never deferred in implicit transactions, which mutations can commit
+// along with their last batch of writes.
+func (p *planner) deferredFKChecks() *row.DeferredFKChecks {
+	if p.EvalContext().TxnImplicit {
+		return nil
+	}
+	return p.extendedEvalCtx.DeferredFKChecks
+}
+
 // ExecCfg implements the PlanHookState interface.
 func (p *planner) ExecCfg() *ExecutorConfig {
 	return p.extendedEvalCtx.ExecCfg
@@ -4,9 +4,9 @@ package privilege
 
 import "strconv"
 
-const _Kind_name = "ALLCREATEDROPGRANTSELECTINSERTDELETEUPDATE"
+const _Kind_name = "ALLCREATEDROPGRANTSELECTINSERTDELETEUPDATEEXECUTE"
 
-var _Kind_index = [...]uint8{0, 3, 9, 13, 18, 24, 30, 36, 42}
+var _Kind_index = [...]uint8{0, 3, 9, 13, 18, 24, 30, 36, 42, 49}
 
 func (i Kind) String() string {
 	i -= 1
@@ -40,12 +40,20 @@ const (
 	INSERT
 	DELETE
 	UPDATE
+	EXECUTE
 )
 
 // Predefined sets of privileges.
 var (
 	ReadData      = List{GRANT, SELECT}
 	ReadWriteData = List{GRANT, SELECT, INSERT, DELETE, UPDATE}
+
+	// DBTablePrivileges are the privileges that can be granted on databases,
+	// tables, views and sequences.
+	DBTablePrivileges = List{ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE}
+	// FunctionPrivileges are the privileges that can be granted on
+	// user-defined functions.
+	FunctionPrivileges = List{ALL, DROP, GRANT, EXECUTE}
 )
 
 // Mask returns the bitmask for a given privilege.
@@ -55,19 +63,20 @@ func (k Kind) Mask() uint32 {
 
 // ByValue is just an array of privilege kinds sorted by value.
 var ByValue = [...]Kind{
-	ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE,
+	ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE, EXECUTE,
 }
 
 // ByName is a map of string -> kind value.
 var ByName = map[string]Kind{
-	"ALL":    ALL,
-	"CREATE": CREATE,
-	"DROP":   DROP,
-	"GRANT":  GRANT,
-	"SELECT": SELECT,
-	"INSERT": INSERT,
-	"DELETE": DELETE,
-	"UPDATE": UPDATE,
+	"ALL":     ALL,
+	"CREATE":  CREATE,
+	"DROP":    DROP,
+	"GRANT":   GRANT,
+	"SELECT":  SELECT,
+	"INSERT":  INSERT,
+	"DELETE":  DELETE,
+	"UPDATE":  UPDATE,
+	"EXECUTE": EXECUTE,
 }
 
 // List is a list of privileges.
@@ -138,6 +147,16 @@ func (pl List) ToBitField() uint32 {
 	return ret
 }
 
+// Contains returns true if the list contains the given privilege.
+func (pl List) Contains(k Kind) bool {
+	for _, p := range pl {
+		if p == k {
+			return true
+		}
+	}
+	return false
+}
+
 // ListFromBitField takes a bitfield of privileges and
 // returns a list. It is ordered in increasing
 // value of privilege.Kind.
@@ -0,0 +1,409 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+)
+
+// recursiveCTENode implements the logic for a recursive CTE:
+//  1. Evaluate the initial query; emit the results and also save them in
+//     a "working" table.
+//  2. So long as the working table is not empty:
+//     - evaluate the recursive query, substituting the current contents of
+//       the working table for the recursive self-reference;
+//     - emit all resulting rows, and save them as the next iteration's
+//       working table.
+//
+// The plan for the recursive query is regenerated for every iteration using
+// genIterationFn, since planNodes can only be executed once.
+//
+// The working tables are stored in disk-backed row containers, so that large
+// recursions can spill to temporary storage. With UNION (as opposed to UNION
+// ALL) semantics, rows that were already emitted are discarded before they
+// are added to the working table; the set of emitted rows is kept in a
+// disk-backed hash row container as well.
+type recursiveCTENode struct {
+	initial planNode
+
+	// genIterationFn creates the plan for an iteration of the recursive query.
+	genIterationFn recursiveCTEIterationFn
+
+	// label is the name of the CTE; it is only used for display purposes.
+	label string
+
+	// deduplicate is set if the recursive query used UNION rather than UNION
+	// ALL.
+	deduplicate bool
+
+	run recursiveCTERun
+}
+
+// recursiveCTEIterationFn creates a plan for an iteration of the recursive
+// query of a recursive CTE. The given workTable node returns the rows that
+// were produced by the previous iteration.
+type recursiveCTEIterationFn func(params runParams, workTable planNode) (planNode, error)
+
+type recursiveCTERun struct {
+	types []sqlbase.ColumnType
+
+	// workingRows contains the rows produced by the previous iteration (or by
+	// the initial query), which are read by the current iteration.
+	workingRows *rowcontainer.DiskBackedRowContainer
+	// nextRows accumulates the rows produced by the current iteration, which
+	// will be read by the next iteration.
+	nextRows *rowcontainer.DiskBackedRowContainer
+
+	// memMonitor and diskMonitor account for the row containers.
+	memMonitor  *mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+
+	// iteration is the plan for the current iteration of the recursive query,
+	// or nil if the initial query is still being read.
+	iteration planNode
+	// initialDone is set once all the rows of the initial query were read.
+	initialDone bool
+	done        bool
+
+	// seen contains the rows that were already emitted when deduplicate is
+	// set. Each stored row is prefixed with a column holding the key encoding
+	// of the row's key-encodable columns, which is used as the bucket key (see
+	// markSeen).
+	seen      *rowcontainer.HashDiskBackedRowContainer
+	seenTypes []sqlbase.ColumnType
+	seenRow   sqlbase.EncDatumRow
+	// valueEncodedCols are the ordinals of the columns that have no key
+	// encoding, and that are thus compared datum by datum in markSeen.
+	valueEncodedCols []int
+	scratch          []byte
+	datumAlloc       sqlbase.DatumAlloc
+
+	encRow sqlbase.EncDatumRow
+	values tree.Datums
+}
+
+// seenEqCols are the equality columns of the rows stored in
+// recursiveCTERun.seen.
+var seenEqCols = []uint32{0}
+
+func (n *recursiveCTENode) startExec(params runParams) error {
+	cols := planColumns(n.initial)
+	n.run.types = make([]sqlbase.ColumnType, len(cols))
+	for i := range cols {
+		typ, err := sqlbase.DatumTypeToColumnType(cols[i].Typ)
+		if err != nil {
+			return err
+		}
+		n.run.types[i] = typ
+	}
+	n.run.encRow = make(sqlbase.EncDatumRow, len(cols))
+
+	// Limit the memory use of the working tables; they overflow to disk if
+	// this limit is not enough.
+	evalCtx := params.EvalContext()
+	distSQLSrv := params.ExecCfg().DistSQLSrv
+	limit := distsqlrun.GetWorkMemLimit(&params.ExecCfg().Settings.SV)
+	memMonitor := mon.MakeMonitorInheritWithLimit("recursive-cte-limited", limit, evalCtx.Mon)
+	memMonitor.Start(params.ctx, evalCtx.Mon, mon.BoundAccount{})
+	n.run.memMonitor = &memMonitor
+	n.run.diskMonitor = distsqlrun.NewMonitor(params.ctx, distSQLSrv.DiskMonitor, "recursive-cte-disk")
+
+	n.run.workingRows = &rowcontainer.DiskBackedRowContainer{}
+	n.run.workingRows.Init(
+		nil /* ordering */, n.run.types, evalCtx, distSQLSrv.TempStorage,
+		n.run.memMonitor, n.run.diskMonitor, 0, /* rowCapacity */
+	)
+	n.run.nextRows = &rowcontainer.DiskBackedRowContainer{}
+	n.run.nextRows.Init(
+		nil /* ordering */, n.run.types, evalCtx, distSQLSrv.TempStorage,
+		n.run.memMonitor, n.run.diskMonitor, 0, /* rowCapacity */
+	)
+
+	if n.deduplicate {
+		n.run.seenTypes = make([]sqlbase.ColumnType, 0, len(cols)+1)
+		n.run.seenTypes = append(n.run.seenTypes, sqlbase.ColumnType{SemanticType: sqlbase.ColumnType_BYTES})
+		n.run.seenTypes = append(n.run.seenTypes, n.run.types...)
+		for i := range n.run.types {
+			if sqlbase.MustBeValueEncoded(n.run.types[i].SemanticType) {
+				n.run.valueEncodedCols = append(n.run.valueEncodedCols, i)
+			}
+		}
+		seen := rowcontainer.MakeHashDiskBackedRowContainer(
+			nil /* mrc */, evalCtx, n.run.memMonitor, n.run.diskMonitor, distSQLSrv.TempStorage,
+		)
+		n.run.seen = &seen
+		if err := n.run.seen.Init(
+			params.ctx, false /* shouldMark */, n.run.seenTypes, seenEqCols, false, /* encodeNull */
+		); err != nil {
+			return err
+		}
+		n.run.seenRow = make(sqlbase.EncDatumRow, len(n.run.seenTypes))
+	}
+	return nil
+}
+
+func (n *recursiveCTENode) Next(params runParams) (bool, error) {
+	if err := params.p.cancelChecker.Check(); err != nil {
+		return false, err
+	}
+	for !n.run.done {
+		source := n.initial
+		if n.run.initialDone {
+			source = n.run.iteration
+		}
+		ok, err := source.Next(params)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			if err := n.nextIteration(params); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		n.run.values = source.Values()
+		if n.deduplicate {
+			isNew, err := n.markSeen(params, n.run.values)
+			if err != nil {
+				return false, err
+			}
+			if !isNew {
+				continue
+			}
+		}
+		for i, d := range n.run.values {
+			n.run.encRow[i] = sqlbase.DatumToEncDatum(n.run.types[i], d)
+		}
+		if err := n.run.nextRows.AddRow(params.ctx, n.run.encRow); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// nextIteration is called when the current source of rows (the initial query
+// or the previous iteration) is exhausted. If the source produced any rows,
+// they become the new working table and the plan for the next iteration is
+// created and started; otherwise, the recursion is done.
+func (n *recursiveCTENode) nextIteration(params runParams) error {
+	n.run.initialDone = true
+	if n.run.iteration != nil {
+		n.run.iteration.Close(params.ctx)
+		n.run.iteration = nil
+	}
+	if n.run.nextRows.Len() == 0 {
+		n.run.done = true
+		return nil
+	}
+
+	// Swap the containers, so that the rows that were just produced become
+	// the working table, and reuse the old working table for the next rows.
+	n.run.workingRows, n.run.nextRows = n.run.nextRows, n.run.workingRows
+	if err := n.run.nextRows.UnsafeReset(params.ctx); err != nil {
+		return err
+	}
+
+	workTable := &workTableScanNode{
+		columns: planColumns(n.initial),
+		label:   n.label,
+		types:   n.run.types,
+		rows:    n.run.workingRows,
+	}
+	plan, err := n.genIterationFn(params, workTable)
+	if err != nil {
+		return err
+	}
+	n.run.iteration = plan
+	return startExec(params, plan)
+}
+
+// markSeen records the given row as emitted. It returns false if the row was
+// already emitted before.
+//
+// The key-encodable columns of the row are encoded into a single byte string,
+// which is used as the bucket key of the seen container. Equal datums have
+// equal key encodings, so a row can only be a duplicate of a row in its
+// bucket. Columns of types that have no key encoding (such as JSONB and
+// arrays) are not part of the bucket key; they are compared with the
+// corresponding columns of the rows in the bucket instead, since their value
+// encoding can differ for equal datums (e.g. the JSON numbers 1 and 1.0).
+func (n *recursiveCTENode) markSeen(params runParams, row tree.Datums) (bool, error) {
+	n.run.scratch = n.run.scratch[:0]
+	for i, d := range row {
+		typ := &n.run.types[i]
+		n.run.seenRow[i+1] = sqlbase.DatumToEncDatum(*typ, d)
+		if sqlbase.MustBeValueEncoded(typ.SemanticType) {
+			continue
+		}
+		var err error
+		n.run.scratch, err = n.run.seenRow[i+1].Encode(
+			typ, &n.run.datumAlloc, sqlbase.DatumEncoding_ASCENDING_KEY, n.run.scratch,
+		)
+		if err != nil {
+			return false, err
+		}
+	}
+	n.run.seenRow[0] = sqlbase.DatumToEncDatum(
+		n.run.seenTypes[0], tree.NewDBytes(tree.DBytes(n.run.scratch)),
+	)
+
+	iter, err := n.run.seen.NewBucketIterator(params.ctx, n.run.seenRow, seenEqCols)
+	if err != nil {
+		return false, err
+	}
+	found, err := n.bucketContains(params.EvalContext(), iter, row)
+	// The iterator must be closed before adding to the container, which might
+	// spill to disk.
+	iter.Close()
+	if err != nil || found {
+		return false, err
+	}
+	if err := n.run.seen.AddRow(params.ctx, n.run.seenRow); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// bucketContains returns whether the bucket of the seen container that iter
+// iterates over contains a row equal to the given row. Only the columns that
+// are not part of the bucket key need to be compared.
+func (n *recursiveCTENode) bucketContains(
+	evalCtx *tree.EvalContext, iter rowcontainer.RowIterator, row tree.Datums,
+) (bool, error) {
+	for iter.Rewind(); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil || !ok {
+			return false, err
+		}
+		if len(n.run.valueEncodedCols) == 0 {
+			return true, nil
+		}
+		seenRow, err := iter.Row()
+		if err != nil {
+			return false, err
+		}
+		equal := true
+		for _, i := range n.run.valueEncodedCols {
+			ed := &seenRow[i+1]
+			if err := ed.EnsureDecoded(&n.run.types[i], &n.run.datumAlloc); err != nil {
+				return false, err
+			}
+			if ed.Datum.Compare(evalCtx, row[i]) != 0 {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true, nil
+		}
+	}
+}
+
+func (n *recursiveCTENode) Values() tree.Datums {
+	return n.run.values
+}
+
+func (n *recursiveCTENode) Close(ctx context.Context) {
+	n.initial.Close(ctx)
+	if n.run.iteration != nil {
+		n.run.iteration.Close(ctx)
+		n.run.iteration = nil
+	}
+	if n.run.workingRows != nil {
+		n.run.workingRows.Close(ctx)
+		n.run.nextRows.Close(ctx)
+		n.run.workingRows, n.run.nextRows = nil, nil
+	}
+	if n.run.seen != nil {
+		n.run.seen.Close(ctx)
+		n.run.seen = nil
+	}
+	if n.run.memMonitor != nil {
+		n.run.memMonitor.Stop(ctx)
+		n.run.memMonitor = nil
+	}
+	if n.run.diskMonitor != nil {
+		n.run.diskMonitor.Stop(ctx)
+		n.run.diskMonitor = nil
+	}
+}
+
+// workTableScanNode returns the rows of the working table of a recursive CTE.
+// It is the data source that the recursive query of the CTE refers to.
+type workTableScanNode struct {
+	columns sqlbase.ResultColumns
+
+	// label is the name of the CTE; it is only used for display purposes.
+	label string
+
+	types []sqlbase.ColumnType
+	// rows is the working table. It is nil when the node is only used for
+	// planning (see initWith).
+	rows *rowcontainer.DiskBackedRowContainer
+
+	run struct {
+		iter    rowcontainer.RowIterator
+		started bool
+		alloc   sqlbase.DatumAlloc
+		values  tree.Datums
+	}
+}
+
+func (n *workTableScanNode) startExec(params runParams) error {
+	n.run.iter = n.rows.NewIterator(params.ctx)
+	n.run.values = make(tree.Datums, len(n.columns))
+	return nil
+}
+
+func (n *workTableScanNode) Next(params runParams) (bool, error) {
+	if n.run.started {
+		n.run.iter.Next()
+	} else {
+		n.run.iter.Rewind()
+		n.run.started = true
+	}
+	if ok, err := n.run.iter.Valid(); err != nil || !ok {
+		return false, err
+	}
+	row, err := n.run.iter.Row()
+	if err != nil {
+		return false, err
+	}
+	for i := range row {
+		if err := row[i].EnsureDecoded(&n.types[i], &n.run.alloc); err != nil {
+			return false, err
+		}
+		n.run.values[i] = row[i].Datum
+	}
+	return true, nil
+}
+
+func (n *workTableScanNode) Values() tree.Datums {
+	return n.run.values
+}
+
+func (n *workTableScanNode) Close(ctx context.Context) {
+	if n.run.iter != nil {
+		n.run.iter.Close()
+		n.run.iter = nil
+	}
+}
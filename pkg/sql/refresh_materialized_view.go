@@ -0,0 +1,403 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/pkg/errors"
+)
+
+// refreshMaterializedViewNode represents a REFRESH MATERIALIZED VIEW
+// statement. Like createStatsNode, it starts a job during startExec and waits
+// for it to finish; the view is recomputed within the jobs framework.
+type refreshMaterializedViewNode struct {
+	n    *tree.RefreshMaterializedView
+	desc *sqlbase.MutableTableDescriptor
+}
+
+// RefreshMaterializedView recomputes the contents of a materialized view.
+// Privileges: CREATE on the view.
+//   Notes: postgres requires ownership of the view.
+func (p *planner) RefreshMaterializedView(
+	ctx context.Context, n *tree.RefreshMaterializedView,
+) (planNode, error) {
+	desc, err := p.ResolveMutableTableDescriptor(ctx, &n.Name, true /* required */, requireViewDesc)
+	if err != nil {
+		return nil, err
+	}
+	if !desc.MaterializedView() {
+		return nil, pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"%q is not a materialized view", tree.ErrString(&n.Name))
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return &refreshMaterializedViewNode{n: n, desc: desc}, nil
+}
+
+func (n *refreshMaterializedViewNode) startExec(params runParams) error {
+	if !params.p.ExtendedEvalContext().TxnImplicit {
+		return errors.Errorf("REFRESH MATERIALIZED VIEW cannot be used inside a transaction")
+	}
+	if !params.p.ExecCfg().Settings.Version.IsActive(cluster.VersionMaterializedViews) {
+		return errors.Errorf(`REFRESH MATERIALIZED VIEW requires all nodes to be upgraded to %s`,
+			cluster.VersionByKey(cluster.VersionMaterializedViews),
+		)
+	}
+
+	_, errCh, err := params.p.ExecCfg().JobRegistry.StartJob(params.ctx, nil /* resultsCh */, jobs.Record{
+		Description:   tree.AsStringWithFlags(n.n, tree.FmtAlwaysQualifyTableNames),
+		Username:      params.p.User(),
+		DescriptorIDs: sqlbase.IDs{n.desc.ID},
+		Details:       jobspb.RefreshMaterializedViewDetails{TableID: n.desc.ID},
+		Progress:      jobspb.RefreshMaterializedViewProgress{},
+	})
+	if err != nil {
+		return err
+	}
+	return <-errCh
+}
+
+func (*refreshMaterializedViewNode) Next(runParams) (bool, error) { return false, nil }
+func (*refreshMaterializedViewNode) Values() tree.Datums          { return tree.Datums{} }
+func (*refreshMaterializedViewNode) Close(context.Context)        {}
+
+// The contents of a materialized view are refreshed without rewriting them in
+// a single, potentially huge, transaction. The job first allocates a new
+// primary index ID for the view and records it in its details. The results of
+// the query, read at a fixed timestamp, are written in batches under that
+// index ID, which no reader knows about yet. Finally the new index is swapped
+// in for the old one by a descriptor change, the same way schema changes
+// publish new descriptor versions; concurrent readers see either the old or
+// the new contents of the view. The old index is garbage collected like a
+// dropped index, once the GC TTL has passed.
+
+// refreshMaterializedView writes the current results of the query of the
+// materialized view with the given ID into the primary index with the given
+// ID, which is not part of the descriptor of the view. It returns the number
+// of rows written.
+//
+// Like CREATE TABLE ... AS, which computes the initial contents of the view,
+// the results of the query are streamed into the index and written in batches
+// as they are produced, rather than buffered.
+func refreshMaterializedView(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	user string,
+	id sqlbase.ID,
+	indexID sqlbase.IndexID,
+) (int, error) {
+	// The query is read at a fixed timestamp, so that it sees a consistent
+	// snapshot of the database without ever having to be retried.
+	txn := client.NewTxn(ctx, execCfg.DB, execCfg.NodeID.Get(), client.RootTxn)
+	txn.SetFixedTimestamp(ctx, execCfg.Clock.Now())
+
+	tableDesc, err := sqlbase.GetTableDescFromID(ctx, txn, id)
+	if err != nil {
+		return 0, err
+	}
+	if tableDesc.Dropped() {
+		return 0, errors.Errorf("materialized view %q is being dropped", tableDesc.Name)
+	}
+	if !tableDesc.MaterializedView() {
+		return 0, pgerror.NewAssertionErrorf("%q is not a materialized view", tableDesc.Name)
+	}
+
+	// The rows of the view are keyed by the hidden rowid column that was added
+	// to its primary index when it was created. The query produces the values
+	// of all the other columns, in order.
+	if len(tableDesc.PrimaryIndex.ColumnIDs) != 1 || len(tableDesc.Indexes) != 0 {
+		return 0, pgerror.NewAssertionErrorf(
+			"materialized view %q has unexpected indexes", tableDesc.Name)
+	}
+	rowIDColIdx, ok := tableDesc.ColumnIdxMap()[tableDesc.PrimaryIndex.ColumnIDs[0]]
+	if !ok || !tableDesc.Columns[rowIDColIdx].Hidden || tableDesc.Columns[rowIDColIdx].DefaultExpr == nil {
+		return 0, pgerror.NewAssertionErrorf(
+			"materialized view %q has an unexpected primary key", tableDesc.Name)
+	}
+
+	// Clear whatever a previous attempt of the job left in the new index.
+	newIndexSpan := tableDesc.IndexSpan(indexID)
+	{
+		var b client.Batch
+		b.AddRawRequest(&roachpb.ClearRangeRequest{
+			RequestHeader: roachpb.RequestHeaderFromSpan(newIndexSpan),
+		})
+		if err := execCfg.DB.Run(ctx, &b); err != nil {
+			return 0, err
+		}
+	}
+
+	// The rows are encoded as if the new index was the primary index of the
+	// view.
+	tableDesc.PrimaryIndex.ID = indexID
+	desc := sqlbase.NewImmutableTableDescriptor(*tableDesc)
+
+	stmt, err := parser.ParseOne(desc.ViewQuery)
+	if err != nil {
+		return 0, err
+	}
+	localPlanner, cleanup := newInternalPlanner(
+		"refresh-materialized-view", txn, user, &MemoryMetrics{}, execCfg,
+	)
+	defer cleanup()
+	evalCtx := localPlanner.ExtendedEvalContext()
+
+	var txCtx transform.ExprTransformContext
+	defaultExprs, err := sqlbase.MakeDefaultExprs(desc.Columns, &txCtx, &evalCtx.EvalContext)
+	if err != nil {
+		return 0, err
+	}
+
+	// The view query refers to fully qualified names, so it can be planned
+	// without a current database.
+	localPlanner.stmt = &Statement{Statement: stmt}
+	localPlanner.runWithOptions(resolveFlags{skipCache: true}, func() {
+		err = localPlanner.makePlan(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer localPlanner.curPlan.close(ctx)
+	if len(planColumns(localPlanner.curPlan.plan)) != len(desc.Columns)-1 {
+		return 0, pgerror.NewAssertionErrorf(
+			"the query of materialized view %q does not match its columns", desc.Name)
+	}
+
+	ri, err := row.MakeInserter(
+		nil /* txn */, desc, nil /* fkTables */, desc.Columns, row.SkipFKs, &sqlbase.DatumAlloc{},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// The rows are written outside of the transaction of the query, in
+	// batches of maxInsertBatchSize rows. Nobody reads the new index until it
+	// is swapped in, so the batches don't need to be atomic with each other.
+	rowsWritten := 0
+	batchSize := 0
+	b := &client.Batch{}
+	flush := func(ctx context.Context) error {
+		if batchSize == 0 {
+			return nil
+		}
+		if err := execCfg.DB.Run(ctx, b); err != nil {
+			return err
+		}
+		b = &client.Batch{}
+		batchSize = 0
+		return nil
+	}
+	rowBuffer := make(tree.Datums, len(desc.Columns))
+	rw := newCallbackResultWriter(func(ctx context.Context, r tree.Datums) error {
+		copy(rowBuffer[:rowIDColIdx], r[:rowIDColIdx])
+		copy(rowBuffer[rowIDColIdx+1:], r[rowIDColIdx:])
+		var err error
+		rowBuffer[rowIDColIdx], err = defaultExprs[rowIDColIdx].Eval(&evalCtx.EvalContext)
+		if err != nil {
+			return err
+		}
+		if err := ri.InsertRow(
+			ctx, b, rowBuffer, true /* overwrite */, row.SkipFKs, false, /* traceKV */
+		); err != nil {
+			return err
+		}
+		rowsWritten++
+		if batchSize++; batchSize >= maxInsertBatchSize {
+			return flush(ctx)
+		}
+		return nil
+	})
+	recv := MakeDistSQLReceiver(
+		ctx,
+		rw,
+		tree.Rows,
+		execCfg.RangeDescriptorCache,
+		execCfg.LeaseHolderCache,
+		txn,
+		func(ts hlc.Timestamp) {
+			_ = execCfg.Clock.Update(ts)
+		},
+		evalCtx.Tracing,
+	)
+	defer recv.Release()
+
+	// The rows are written by the receiver, so the query has to be planned
+	// locally.
+	planCtx := execCfg.DistSQLPlanner.newLocalPlanningCtx(ctx, evalCtx)
+	planCtx.isLocal = true
+	planCtx.planner = localPlanner
+	planCtx.stmtType = recv.stmtType
+	if len(localPlanner.curPlan.subqueryPlans) != 0 {
+		evalCtxFactory := func() *extendedEvalContext {
+			subqueryEvalCtx := *evalCtx
+			return &subqueryEvalCtx
+		}
+		if !execCfg.DistSQLPlanner.PlanAndRunSubqueries(
+			ctx, localPlanner, evalCtxFactory, localPlanner.curPlan.subqueryPlans, recv,
+			false, /* maybeDistribute */
+		) {
+			return 0, rw.Err()
+		}
+	}
+	execCfg.DistSQLPlanner.PlanAndRun(
+		ctx, evalCtx, planCtx, txn, localPlanner.curPlan.plan, recv,
+	)
+	if err := rw.Err(); err != nil {
+		return 0, err
+	}
+	if err := flush(ctx); err != nil {
+		return 0, err
+	}
+	// The transaction only read.
+	if err := txn.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return rowsWritten, nil
+}
+
+// refreshMaterializedViewResumer implements the jobs.Resumer interface for
+// REFRESH MATERIALIZED VIEW jobs.
+type refreshMaterializedViewResumer struct{}
+
+var _ jobs.Resumer = &refreshMaterializedViewResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+func (r *refreshMaterializedViewResumer) Resume(
+	ctx context.Context, job *jobs.Job, phs interface{}, resultsCh chan<- tree.Datums,
+) error {
+	p := phs.(*planner)
+	execCfg := p.ExecCfg()
+	details := job.Details().(jobspb.RefreshMaterializedViewDetails)
+
+	// Allocate the ID of the new index, unless a previous attempt of the job
+	// already did.
+	if details.IndexID == 0 {
+		var indexID sqlbase.IndexID
+		if _, err := execCfg.LeaseManager.Publish(ctx, details.TableID,
+			func(desc *sqlbase.MutableTableDescriptor) error {
+				indexID = desc.NextIndexID
+				desc.NextIndexID++
+				return nil
+			}, nil /* logEvent */); err != nil {
+			return err
+		}
+		details.IndexID = indexID
+		if err := job.SetDetails(ctx, details); err != nil {
+			return err
+		}
+	}
+
+	rowsWritten, err := refreshMaterializedView(
+		ctx, execCfg, p.User(), details.TableID, details.IndexID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Swap the new index in, and schedule the old one for GC.
+	if _, err := execCfg.LeaseManager.Publish(ctx, details.TableID,
+		func(desc *sqlbase.MutableTableDescriptor) error {
+			if desc.Dropped() {
+				return errors.Errorf("materialized view %q is being dropped", desc.Name)
+			}
+			desc.GCMutations = append(desc.GCMutations, sqlbase.TableDescriptor_GCDescriptorMutation{
+				IndexID:  desc.PrimaryIndex.ID,
+				DropTime: timeutil.Now().UnixNano(),
+			})
+			desc.PrimaryIndex.ID = details.IndexID
+			return nil
+		}, nil /* logEvent */); err != nil {
+		return err
+	}
+
+	// The contents of the view may have changed completely, which calls for
+	// new statistics.
+	execCfg.StatsRefresher.NotifyMutation(&execCfg.Settings.SV, details.TableID, rowsWritten)
+	return nil
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface.
+func (r *refreshMaterializedViewResumer) OnFailOrCancel(
+	ctx context.Context, txn *client.Txn, job *jobs.Job,
+) error {
+	details := job.Details().(jobspb.RefreshMaterializedViewDetails)
+	if details.IndexID == 0 {
+		return nil
+	}
+	// Needed to trigger the schema change manager.
+	if err := txn.SetSystemConfigTrigger(); err != nil {
+		return err
+	}
+	desc, err := sqlbase.GetMutableTableDescFromID(ctx, txn, details.TableID)
+	if err == sqlbase.ErrDescriptorNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if desc.Dropped() || desc.PrimaryIndex.ID == details.IndexID {
+		// Either the whole view is going away, or the new index was swapped
+		// in before the job failed.
+		return nil
+	}
+	// The new index was never visible, so it doesn't need to be kept around
+	// for the GC TTL. A DropTime of 1ns past the epoch lets the schema change
+	// manager clear it as soon as possible.
+	desc.GCMutations = append(desc.GCMutations, sqlbase.TableDescriptor_GCDescriptorMutation{
+		IndexID:  details.IndexID,
+		DropTime: 1,
+	})
+	if err := maybeIncrementVersion(ctx, desc, txn); err != nil {
+		return err
+	}
+	return txn.Put(ctx, sqlbase.MakeDescMetadataKey(desc.ID), sqlbase.WrapDescriptor(desc))
+}
+
+// OnSuccess is part of the jobs.Resumer interface.
+func (r *refreshMaterializedViewResumer) OnSuccess(
+	ctx context.Context, txn *client.Txn, job *jobs.Job,
+) error {
+	return nil
+}
+
+// OnTerminal is part of the jobs.Resumer interface.
+func (r *refreshMaterializedViewResumer) OnTerminal(
+	ctx context.Context, job *jobs.Job, status jobs.Status, resultsCh chan<- tree.Datums,
+) {
+}
+
+func init() {
+	jobs.AddResumeHook(func(typ jobspb.Type, settings *cluster.Settings) jobs.Resumer {
+		if typ != jobspb.TypeRefreshMaterializedView {
+			return nil
+		}
+		return &refreshMaterializedViewResumer{}
+	})
+}
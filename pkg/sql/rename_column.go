@@ -67,6 +67,9 @@ func (n *renameColumnNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if columnTypeChangeInProgress(tableDesc, col.ID) {
+		return fmt.Errorf("column %q in the middle of changing type, try again later", col.Name)
+	}
 
 	for _, tableRef := range tableDesc.DependedOnBy {
 		found := false
@@ -140,6 +143,28 @@ func (n *renameColumnNode) startExec(params runParams) error {
 		}
 	}
 
+	// Rename the column in the predicates of partial indexes.
+	renameInPredicate := func(idx *sqlbase.IndexDescriptor) error {
+		if !idx.IsPartial() {
+			return nil
+		}
+		var err error
+		idx.Predicate, err = renameIn(idx.Predicate)
+		return err
+	}
+	for i := range tableDesc.Indexes {
+		if err := renameInPredicate(&tableDesc.Indexes[i]); err != nil {
+			return err
+		}
+	}
+	for _, m := range tableDesc.Mutations {
+		if idx := m.GetIndex(); idx != nil {
+			if err := renameInPredicate(idx); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Rename the column in the indexes.
 	tableDesc.RenameColumnDescriptor(col, string(n.n.NewName))
 
@@ -20,8 +20,10 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
@@ -107,11 +109,38 @@ func (n *renameTableNode) startExec(params runParams) error {
 		return nil
 	}
 
+	newSchemaIsTemporary := newTn.Schema() == sessiondata.PgTempSchemaName || isTemporarySchemaName(newTn.Schema())
+	if tableDesc.Temporary {
+		// Temporary tables stay in the temporary schema of their database.
+		if targetDbDesc.ID != prevDbDesc.ID || (newTn.ExplicitSchema && !newSchemaIsTemporary) {
+			return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"cannot move temporary table %q out of its temporary schema", oldTn.Table())
+		}
+	} else if newSchemaIsTemporary {
+		return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+			"cannot move permanent table %q into a temporary schema", oldTn.Table())
+	} else if newTn.ExplicitSchema || targetDbDesc.ID != prevDbDesc.ID {
+		// An unqualified new name keeps the table in its schema; otherwise
+		// the table moves to the schema of the new name.
+		scDesc, err := resolveTargetSchema(ctx, p.txn, targetDbDesc, newTn)
+		if err != nil {
+			return err
+		}
+		tableDesc.UnexposedParentSchemaID = 0
+		if scDesc != nil {
+			if err := p.CheckPrivilege(ctx, scDesc, privilege.CREATE); err != nil {
+				return err
+			}
+			tableDesc.UnexposedParentSchemaID = scDesc.ID
+		}
+	}
+
+	prevParentID := tableDesc.GetNamespaceParentID()
 	tableDesc.SetName(newTn.Table())
 	tableDesc.ParentID = targetDbDesc.ID
 
 	descKey := sqlbase.MakeDescMetadataKey(tableDesc.GetID())
-	newTbKey := tableKey{targetDbDesc.ID, newTn.Table()}.Key()
+	newTbKey := tableKey{tableDesc.GetNamespaceParentID(), newTn.Table()}.Key()
 
 	if err := tableDesc.Validate(ctx, p.txn, p.EvalContext().Settings); err != nil {
 		return err
@@ -121,7 +150,7 @@ func (n *renameTableNode) startExec(params runParams) error {
 	descDesc := sqlbase.WrapDescriptor(tableDesc)
 
 	renameDetails := sqlbase.TableDescriptor_NameInfo{
-		ParentID: prevDbDesc.ID,
+		ParentID: prevParentID,
 		Name:     oldTn.Table()}
 	tableDesc.DrainingNames = append(tableDesc.DrainingNames, renameDetails)
 	if err := p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID); err != nil {
@@ -90,6 +90,11 @@ type renderNode struct {
 	_ util.NoCopy
 }
 
+// lockingNotSupportedError is returned when a SELECT statement with a
+// row-level locking clause is planned without the cost-based optimizer.
+var lockingNotSupportedError = pgerror.UnimplementedWithIssueError(6583,
+	"SELECT FOR UPDATE/SHARE requires the cost-based optimizer")
+
 // Select selects rows from a SELECT/UNION/VALUES, ordering and/or limiting them.
 func (p *planner) Select(
 	ctx context.Context, n *tree.Select, desiredTypes []types.T,
@@ -99,7 +104,14 @@ func (p *planner) Select(
 	orderBy := n.OrderBy
 	with := n.With
 
+	if n.Locking != nil {
+		return nil, lockingNotSupportedError
+	}
+
 	for s, ok := wrapped.(*tree.ParenSelect); ok; s, ok = wrapped.(*tree.ParenSelect) {
+		if s.Select.Locking != nil {
+			return nil, lockingNotSupportedError
+		}
 		wrapped = s.Select.Select
 		if s.Select.With != nil {
 			if with != nil {
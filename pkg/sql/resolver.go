@@ -78,6 +78,13 @@ func GetObjectNames(
 	scName string,
 	explicitPrefix bool,
 ) (res TableNames, err error) {
+	if scName == sessiondata.PgTempSchemaName {
+		scName = sc.CurrentSearchPath().GetTemporarySchemaName()
+		if scName == "" {
+			// The session has no temporary schema yet.
+			return nil, nil
+		}
+	}
 	return sc.LogicalSchemaAccessor().GetObjectNames(ctx, txn, dbDesc, scName,
 		DatabaseListFlags{
 			CommonLookupFlags: sc.CommonLookupFlags(true /*required*/),
@@ -218,7 +225,7 @@ func ResolveTargetObject(
 			"cannot create %q because the target database or schema does not exist",
 			tree.ErrString(tn)).SetHintf("verify that the current database and search_path are valid and/or the target database exists")
 	}
-	if tn.Schema() != tree.PublicSchema {
+	if isVirtualSchemaName(tn.Schema()) {
 		return nil, pgerror.NewErrorf(pgerror.CodeInvalidNameError,
 			"schema cannot be modified: %q", tree.ErrString(&tn.TableNamePrefix))
 	}
@@ -262,13 +269,28 @@ func (p *planner) LookupSchema(
 	if err != nil || dbDesc == nil {
 		return false, nil, err
 	}
-	return sc.IsValidSchema(dbDesc, scName), dbDesc, nil
+	if isTemporarySchemaName(scName) || scName == sessiondata.PgTempSchemaName {
+		// Only the temporary schema of the current session is visible.
+		return p.isOwnTemporarySchema(scName), dbDesc, nil
+	}
+	found, err = sc.IsValidSchema(ctx, p.txn, dbDesc, scName)
+	return found, dbDesc, err
 }
 
 // LookupObject implements the tree.TableNameExistingResolver interface.
 func (p *planner) LookupObject(
 	ctx context.Context, requireMutable bool, dbName, scName, tbName string,
 ) (found bool, objMeta tree.NameResolutionResult, err error) {
+	if scName == sessiondata.PgTempSchemaName {
+		scName = p.SessionData().SearchPath.GetTemporarySchemaName()
+		if scName == "" {
+			// The session has no temporary schema yet.
+			return false, nil, nil
+		}
+	} else if isTemporarySchemaName(scName) && scName != p.SessionData().SearchPath.GetTemporarySchemaName() {
+		// The temporary schemas of other sessions are not visible.
+		return false, nil, nil
+	}
 	sc := p.LogicalSchemaAccessor()
 	p.tableName = tree.MakeTableNameWithSchema(tree.Name(dbName), tree.Name(scName), tree.Name(tbName))
 	objDesc, _, err := sc.GetObjectDesc(ctx, p.txn, &p.tableName, p.ObjectLookupFlags(false /*required*/, requireMutable))
@@ -311,6 +333,40 @@ func getDescriptorsFromTargetList(
 		return descs, nil
 	}
 
+	if targets.Functions != nil {
+		descs := make([]sqlbase.DescriptorProto, 0, len(targets.Functions))
+		for i := range targets.Functions {
+			ref := &targets.Functions[i]
+			desc, err := p.resolveFunction(ctx, &ref.Name, true /* required */)
+			if err != nil {
+				return nil, err
+			}
+			if ref.HasArgTypes && !functionArgTypesMatch(desc, ref.ArgTypes) {
+				return nil, sqlbase.NewUndefinedFunctionError(ref)
+			}
+			descs = append(descs, desc)
+		}
+		if len(descs) == 0 {
+			return nil, errNoMatch
+		}
+		return descs, nil
+	}
+
+	if targets.Schemas != nil {
+		descs := make([]sqlbase.DescriptorProto, 0, len(targets.Schemas))
+		for _, schema := range targets.Schemas {
+			desc, err := p.resolveSchemaDesc(ctx, string(schema), true /* required */)
+			if err != nil {
+				return nil, err
+			}
+			descs = append(descs, desc)
+		}
+		if len(descs) == 0 {
+			return nil, errNoMatch
+		}
+		return descs, nil
+	}
+
 	if len(targets.Tables) == 0 {
 		return nil, errNoTable
 	}
@@ -383,7 +439,7 @@ func findTableContainingIndex(
 	result = nil
 	for i := range tns {
 		tn := &tns[i]
-		tableDesc, err := ResolveMutableExistingObject(ctx, sc, tn, true, anyDescType)
+		tableDesc, err := ResolveMutableExistingObject(ctx, sc, tn, false /*required*/, anyDescType)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -551,7 +607,23 @@ func expandTableGlob(
 		return nil, sqlbase.NewInvalidWildcardError(tree.ErrString(glob))
 	}
 
-	return GetObjectNames(ctx, txn, sc, descI.(*DatabaseDescriptor), glob.Schema(), glob.ExplicitSchema)
+	names, err := GetObjectNames(ctx, txn, sc, descI.(*DatabaseDescriptor), glob.Schema(), glob.ExplicitSchema)
+	if err != nil {
+		return nil, err
+	}
+	// The namespace of the database also contains user-defined functions,
+	// types and schemas, which are not matched by the pattern.
+	tableNames := names[:0]
+	for i := range names {
+		desc, err := ResolveExistingObject(ctx, sc, &names[i], false /*required*/, anyDescType)
+		if err != nil {
+			return nil, err
+		}
+		if desc != nil {
+			tableNames = append(tableNames, names[i])
+		}
+	}
+	return tableNames, nil
 }
 
 // fkSelfResolver is a SchemaResolver that inserts itself between a
@@ -592,11 +664,17 @@ func (r *fkSelfResolver) LookupObject(
 //
 // It only reveals physical descriptors (not virtual descriptors).
 type internalLookupCtx struct {
-	dbNames map[sqlbase.ID]string
-	dbIDs   []sqlbase.ID
-	dbDescs map[sqlbase.ID]*DatabaseDescriptor
-	tbDescs map[sqlbase.ID]*TableDescriptor
-	tbIDs   []sqlbase.ID
+	dbNames  map[sqlbase.ID]string
+	dbIDs    []sqlbase.ID
+	dbDescs  map[sqlbase.ID]*DatabaseDescriptor
+	tbDescs  map[sqlbase.ID]*TableDescriptor
+	tbIDs    []sqlbase.ID
+	fnDescs  map[sqlbase.ID]*sqlbase.FunctionDescriptor
+	fnIDs    []sqlbase.ID
+	typDescs map[sqlbase.ID]*sqlbase.TypeDescriptor
+	typIDs   []sqlbase.ID
+	scDescs  map[sqlbase.ID]*sqlbase.SchemaDescriptor
+	scIDs    []sqlbase.ID
 }
 
 // tableLookupFn can be used to retrieve a table descriptor and its corresponding
@@ -609,7 +687,10 @@ func newInternalLookupCtx(
 	dbNames := make(map[sqlbase.ID]string)
 	dbDescs := make(map[sqlbase.ID]*DatabaseDescriptor)
 	tbDescs := make(map[sqlbase.ID]*TableDescriptor)
-	var tbIDs, dbIDs []sqlbase.ID
+	fnDescs := make(map[sqlbase.ID]*sqlbase.FunctionDescriptor)
+	typDescs := make(map[sqlbase.ID]*sqlbase.TypeDescriptor)
+	scDescs := make(map[sqlbase.ID]*sqlbase.SchemaDescriptor)
+	var tbIDs, dbIDs, fnIDs, typIDs, scIDs []sqlbase.ID
 	// Record database descriptors for name lookups.
 	for _, desc := range descs {
 		switch d := desc.(type) {
@@ -625,14 +706,35 @@ func newInternalLookupCtx(
 				// Only make the table visible for iteration if the prefix was included.
 				tbIDs = append(tbIDs, d.ID)
 			}
+		case *sqlbase.FunctionDescriptor:
+			fnDescs[d.ID] = d
+			if prefix == nil || prefix.ID == d.ParentID {
+				fnIDs = append(fnIDs, d.ID)
+			}
+		case *sqlbase.TypeDescriptor:
+			typDescs[d.ID] = d
+			if prefix == nil || prefix.ID == d.ParentID {
+				typIDs = append(typIDs, d.ID)
+			}
+		case *sqlbase.SchemaDescriptor:
+			scDescs[d.ID] = d
+			if prefix == nil || prefix.ID == d.ParentID {
+				scIDs = append(scIDs, d.ID)
+			}
 		}
 	}
 	return &internalLookupCtx{
-		dbNames: dbNames,
-		dbDescs: dbDescs,
-		tbDescs: tbDescs,
-		tbIDs:   tbIDs,
-		dbIDs:   dbIDs,
+		dbNames:  dbNames,
+		dbDescs:  dbDescs,
+		tbDescs:  tbDescs,
+		tbIDs:    tbIDs,
+		dbIDs:    dbIDs,
+		fnDescs:  fnDescs,
+		fnIDs:    fnIDs,
+		typDescs: typDescs,
+		typIDs:   typIDs,
+		scDescs:  scDescs,
+		scIDs:    scIDs,
 	}
 }
 
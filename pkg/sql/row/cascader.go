@@ -47,6 +47,10 @@ type cascader struct {
 	updaterRowFetchers map[TableID]Fetcher                    // RowFetchers for rowUpdaters by Table ID
 	originalRows       map[TableID]*rowcontainer.RowContainer // Original values for rows that have been updated by Table ID
 	updatedRows        map[TableID]*rowcontainer.RowContainer // New values for rows that have been updated by Table ID
+
+	// deferred, if set, collects the FK existence checks of the cascaded
+	// writes that are deferred to the end of the transaction.
+	deferred *DeferredFKChecks
 }
 
 // makeDeleteCascader only creates a cascader if there is a chance that there is
@@ -371,6 +375,8 @@ func (c *cascader) addIndexPKRowFetcher(
 	var rowFetcher Fetcher
 	if err := rowFetcher.Init(
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
@@ -434,6 +440,8 @@ func (c *cascader) addRowDeleter(
 	var rowFetcher Fetcher
 	if err := rowFetcher.Init(
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
@@ -442,6 +450,8 @@ func (c *cascader) addRowDeleter(
 		return Deleter{}, Fetcher{}, err
 	}
 
+	rowDeleter.DeferFKChecks(c.deferred)
+
 	// Cache both the fetcher and deleter.
 	c.rowDeleters[table.ID] = rowDeleter
 	c.deleterRowFetchers[table.ID] = rowFetcher
@@ -494,6 +504,8 @@ func (c *cascader) addRowUpdater(
 	var rowFetcher Fetcher
 	if err := rowFetcher.Init(
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
@@ -502,6 +514,8 @@ func (c *cascader) addRowUpdater(
 		return Updater{}, Fetcher{}, err
 	}
 
+	rowUpdater.DeferFKChecks(c.deferred)
+
 	// Cache the updater and the fetcher.
 	c.rowUpdaters[table.ID] = rowUpdater
 	c.updaterRowFetchers[table.ID] = rowFetcher
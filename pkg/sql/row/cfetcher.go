@@ -158,6 +158,14 @@ type CFetcher struct {
 	// or not when StartScan is invoked.
 	reverse bool
 
+	// lockStr represents the row-level locking mode to use when fetching
+	// rows.
+	lockStr sqlbase.ScanLockingStrength
+
+	// lockWaitPolicy represents the policy to be used for handling conflicting
+	// locks held by other active transactions.
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy
+
 	// maxKeysPerRow memoizes the maximum number of keys per row
 	// out of all the tables. This is used to calculate the kvBatchFetcher's
 	// firstBatchLimit.
@@ -223,14 +231,20 @@ type CFetcher struct {
 // non-primary index, tables.ValNeededForCol can only refer to columns in the
 // index.
 func (rf *CFetcher) Init(
-	reverse,
-	returnRangeInfo bool, isCheck bool, tables ...FetcherTableArgs,
+	reverse bool,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
+	returnRangeInfo bool,
+	isCheck bool,
+	tables ...FetcherTableArgs,
 ) error {
 	if len(tables) == 0 {
 		panic("no tables to fetch from")
 	}
 
 	rf.reverse = reverse
+	rf.lockStr = lockStr
+	rf.lockWaitPolicy = lockWaitPolicy
 	rf.returnRangeInfo = returnRangeInfo
 
 	if len(tables) > 1 {
@@ -422,7 +436,16 @@ func (rf *CFetcher) StartScan(
 		firstBatchLimit++
 	}
 
-	f, err := makeKVBatchFetcher(txn, spans, rf.reverse, limitBatches, firstBatchLimit, rf.returnRangeInfo)
+	f, err := makeKVBatchFetcher(
+		txn,
+		spans,
+		rf.reverse,
+		limitBatches,
+		firstBatchLimit,
+		rf.lockStr,
+		rf.lockWaitPolicy,
+		rf.returnRangeInfo,
+	)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -112,7 +113,13 @@ func NewUniquenessConstraintViolationError(
 		ValNeededForCol:  valNeededForCol,
 	}
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &sqlbase.DatumAlloc{}, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&sqlbase.DatumAlloc{},
+		tableArgs,
 	); err != nil {
 		return err
 	}
@@ -141,3 +148,15 @@ func NewUniquenessConstraintViolationError(
 		strings.Join(valStrs, ","),
 		index.Name)
 }
+
+// newLockNotAvailableError creates an error that represents an inability to
+// lock a row because it is locked by another transaction and the scan was
+// instructed not to wait (NOWAIT).
+func newLockNotAvailableError(wiErr *roachpb.WriteIntentError) error {
+	var key roachpb.Key
+	if len(wiErr.Intents) > 0 {
+		key = wiErr.Intents[0].Key
+	}
+	return pgerror.NewErrorf(pgerror.CodeLockNotAvailableError,
+		"could not obtain lock on row %s", keys.PrettyPrint(nil /* valDirs */, key))
+}
@@ -180,6 +180,14 @@ type Fetcher struct {
 	// or not when StartScan is invoked.
 	reverse bool
 
+	// lockStr represents the row-level locking mode to use when fetching
+	// rows.
+	lockStr sqlbase.ScanLockingStrength
+
+	// lockWaitPolicy represents the policy to be used for handling conflicting
+	// locks held by other active transactions.
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy
+
 	// maxKeysPerRow memoizes the maximum number of keys per row
 	// out of all the tables. This is used to calculate the kvBatchFetcher's
 	// firstBatchLimit.
@@ -243,7 +251,10 @@ func (rf *Fetcher) Reset() {
 // non-primary index, tables.ValNeededForCol can only refer to columns in the
 // index.
 func (rf *Fetcher) Init(
-	reverse, returnRangeInfo bool,
+	reverse bool,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
+	returnRangeInfo bool,
 	isCheck bool,
 	alloc *sqlbase.DatumAlloc,
 	tables ...FetcherTableArgs,
@@ -253,6 +264,8 @@ func (rf *Fetcher) Init(
 	}
 
 	rf.reverse = reverse
+	rf.lockStr = lockStr
+	rf.lockWaitPolicy = lockWaitPolicy
 	rf.returnRangeInfo = returnRangeInfo
 	rf.alloc = alloc
 	rf.isCheck = isCheck
@@ -463,7 +476,16 @@ func (rf *Fetcher) StartScan(
 		firstBatchLimit++
 	}
 
-	f, err := makeKVBatchFetcher(txn, spans, rf.reverse, limitBatches, firstBatchLimit, rf.returnRangeInfo)
+	f, err := makeKVBatchFetcher(
+		txn,
+		spans,
+		rf.reverse,
+		limitBatches,
+		firstBatchLimit,
+		rf.lockStr,
+		rf.lockWaitPolicy,
+		rf.returnRangeInfo,
+	)
 	if err != nil {
 		return err
 	}
@@ -98,7 +98,12 @@ func TestRowFetcherMVCCMetadata(t *testing.T) {
 	}
 	var rf row.Fetcher
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, true /* isCheck */, &sqlbase.DatumAlloc{},
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		true,  /* isCheck */
+		&sqlbase.DatumAlloc{},
 		args...,
 	); err != nil {
 		t.Fatal(err)
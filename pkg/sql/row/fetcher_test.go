@@ -74,7 +74,12 @@ func initFetcher(
 
 	fetcherArgs := makeFetcherArgs(entries)
 
-	if err := fetcher.Init(reverseScan, false /*reverse*/, false, /* isCheck */
+	if err := fetcher.Init(
+		reverseScan,
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
 		alloc, fetcherArgs...); err != nil {
 		return nil, err
 	}
@@ -1042,7 +1047,12 @@ func TestRowFetcherReset(t *testing.T) {
 	// didn't reset.
 
 	fetcherArgs := makeFetcherArgs(args)
-	if err := resetFetcher.Init(false, false /*reverse*/, false, /* isCheck */
+	if err := resetFetcher.Init(
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
 		&da, fetcherArgs...); err != nil {
 		t.Fatal(err)
 	}
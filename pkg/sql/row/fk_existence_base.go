@@ -82,6 +82,31 @@ type fkExistenceCheckBaseHelper struct {
 	// mutatedIdx is the descriptor for the target index being mutated.
 	// Stored only for error messages.
 	mutatedIdx *sqlbase.IndexDescriptor
+
+	// constraintName is the name of the FK constraint. It is used to
+	// look up the mode set by SET CONSTRAINTS.
+	constraintName string
+	// deferrable and initiallyDeferred are the attributes of the FK
+	// constraint that determine whether its checks can be, and are by
+	// default, deferred to the end of the transaction.
+	deferrable        bool
+	initiallyDeferred bool
+
+	// The following fields are only set for deferrable constraints.
+	// Deferred checks are evaluated when the transaction commits, at
+	// which point the mutated row may have been modified again. The
+	// checks thus also look up the mutated values in the mutated index.
+	//
+	// mutatedTable is the descriptor of the mutated table.
+	mutatedTable *sqlbase.ImmutableTableDescriptor
+	// mutatedRf is the row fetcher used to look up rows in the mutated
+	// index.
+	mutatedRf *Fetcher
+	// mutatedPrefix is the pre-computed KV key prefix for mutatedIdx.
+	mutatedPrefix []byte
+	// mutatedIDs maps column IDs in index mutatedIdx to positions of
+	// the `row` array provided to each FK existence check.
+	mutatedIDs map[sqlbase.ColumnID]int
 }
 
 // makeFkExistenceCheckBaseHelper instanciates a FK helper.
@@ -99,6 +124,8 @@ type fkExistenceCheckBaseHelper struct {
 //   This is used to derive the searched table/index,
 //   and determine the MATCH style.
 //
+// - mutatedTable is the table being mutated.
+//
 // - writeIdx is the target index being mutated. This is used
 //   to determine prefixLen in combination with searchIdx.
 //
@@ -114,6 +141,7 @@ type fkExistenceCheckBaseHelper struct {
 func makeFkExistenceCheckBaseHelper(
 	txn *client.Txn,
 	otherTables FkTableMetadata,
+	mutatedTable *sqlbase.ImmutableTableDescriptor,
 	mutatedIdx *sqlbase.IndexDescriptor,
 	ref sqlbase.ForeignKeyReference,
 	colMap map[sqlbase.ColumnID]int,
@@ -147,20 +175,12 @@ func makeFkExistenceCheckBaseHelper(
 	searchPrefix := sqlbase.MakeIndexKeyPrefix(searchTable.TableDesc(), ref.Index)
 
 	// Initialize the row fetcher.
-	tableArgs := FetcherTableArgs{
-		Desc:             searchTable,
-		Index:            searchIdx,
-		ColIdxMap:        searchTable.ColumnIdxMap(),
-		IsSecondaryIndex: searchIdx.ID != searchTable.PrimaryIndex.ID,
-		Cols:             searchTable.Columns,
-	}
-	rf := &Fetcher{}
-	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, alloc, tableArgs); err != nil {
+	rf, err := makeFkFetcher(searchTable, searchIdx, alloc)
+	if err != nil {
 		return ret, err
 	}
 
-	return fkExistenceCheckBaseHelper{
+	ret = fkExistenceCheckBaseHelper{
 		txn:          txn,
 		dir:          dir,
 		rf:           rf,
@@ -171,7 +191,64 @@ func makeFkExistenceCheckBaseHelper(
 		prefixLen:    prefixLen,
 		searchPrefix: searchPrefix,
 		mutatedIdx:   mutatedIdx,
-	}, nil
+	}
+
+	// Determine the attributes of the constraint. They are only stored
+	// on the referencing side: for backward checks, the searched index
+	// holds the constraint.
+	constraint := ref
+	if dir == CheckDeletes {
+		constraint = searchIdx.ForeignKey
+	}
+	ret.constraintName = constraint.Name
+	// Like in Postgres, RESTRICT actions are checked immediately even if
+	// the constraint is deferrable.
+	ret.deferrable = constraint.Deferrable &&
+		!(dir == CheckDeletes && (constraint.OnDelete == sqlbase.ForeignKeyReference_RESTRICT ||
+			constraint.OnUpdate == sqlbase.ForeignKeyReference_RESTRICT))
+	ret.initiallyDeferred = constraint.InitiallyDeferred
+	if ret.deferrable {
+		ret.mutatedTable = mutatedTable
+		ret.mutatedPrefix = sqlbase.MakeIndexKeyPrefix(mutatedTable.TableDesc(), mutatedIdx.ID)
+		ret.mutatedIDs = make(map[sqlbase.ColumnID]int, prefixLen)
+		for i, colID := range mutatedIdx.ColumnIDs[:prefixLen] {
+			ret.mutatedIDs[colID] = ids[searchIdx.ColumnIDs[i]]
+		}
+		if ret.mutatedRf, err = makeFkFetcher(mutatedTable, mutatedIdx, alloc); err != nil {
+			return ret, err
+		}
+	}
+
+	return ret, nil
+}
+
+// makeFkFetcher initializes a row fetcher for the FK existence checks
+// that look up rows in the given index.
+func makeFkFetcher(
+	table *sqlbase.ImmutableTableDescriptor,
+	idx *sqlbase.IndexDescriptor,
+	alloc *sqlbase.DatumAlloc,
+) (*Fetcher, error) {
+	tableArgs := FetcherTableArgs{
+		Desc:             table,
+		Index:            idx,
+		ColIdxMap:        table.ColumnIdxMap(),
+		IsSecondaryIndex: idx.ID != table.PrimaryIndex.ID,
+		Cols:             table.Columns,
+	}
+	rf := &Fetcher{}
+	if err := rf.Init(
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		alloc,
+		tableArgs,
+	); err != nil {
+		return nil, err
+	}
+	return rf, nil
 }
 
 // computeFkCheckColumnIDs determines the set of column IDs to use for
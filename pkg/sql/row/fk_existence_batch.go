@@ -41,6 +41,11 @@ type fkExistenceBatchChecker struct {
 	// batchIdxToFk maps the index of the check request/response in the kv batch
 	// to the fkExistenceCheckBaseHelper that created it.
 	batchIdxToFk []*fkExistenceCheckBaseHelper
+
+	// deferred, if set, collects the checks of the deferrable constraints
+	// that are deferred to the end of the transaction instead of being
+	// added to the batch.
+	deferred *DeferredFKChecks
 }
 
 // reset starts a new batch.
@@ -53,6 +58,12 @@ func (f *fkExistenceBatchChecker) reset() {
 func (f *fkExistenceBatchChecker) addCheck(
 	ctx context.Context, row tree.Datums, source *fkExistenceCheckBaseHelper, traceKV bool,
 ) error {
+	if row != nil && f.deferred != nil && f.deferred.isDeferred(source) {
+		if traceKV {
+			log.VEventf(ctx, 2, "FKScan deferred for constraint %s", source.constraintName)
+		}
+		return f.deferred.add(ctx, source, row)
+	}
 	span, err := source.spanForValues(row)
 	if err != nil {
 		return err
@@ -103,37 +114,13 @@ func (f *fkExistenceBatchChecker) runCheck(
 		case CheckInserts:
 			// If we're inserting, then there's a violation if the scan found nothing.
 			if fk.rf.kvEnd {
-				// TODO(knz): re-allocating a datum slice in every check
-				// is super inefficient and expensive. Factor this.
-				fkValues := make(tree.Datums, fk.prefixLen)
-
-				for valueIdx, colID := range fk.searchIdx.ColumnIDs[:fk.prefixLen] {
-					fkValues[valueIdx] = newRow[fk.ids[colID]]
-				}
-				return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
-					"foreign key violation: value %s not found in %s@%s %s (txn=%s)",
-					fkValues, fk.searchTable.Name, fk.searchIdx.Name, fk.searchIdx.ColumnNames[:fk.prefixLen], f.txn.ID())
+				return fk.violationError(newRow)
 			}
 
 		case CheckDeletes:
 			// If we're deleting, then there's a violation if the scan found something.
 			if !fk.rf.kvEnd {
-				if oldRow == nil {
-					return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
-						"foreign key violation: non-empty columns %s referenced in table %q",
-						fk.mutatedIdx.ColumnNames[:fk.prefixLen], fk.searchTable.Name)
-				}
-
-				// TODO(knz): re-allocating a datum slice in every check
-				// is super inefficient and expensive. Factor this.
-				fkValues := make(tree.Datums, fk.prefixLen)
-
-				for valueIdx, colID := range fk.searchIdx.ColumnIDs[:fk.prefixLen] {
-					fkValues[valueIdx] = oldRow[fk.ids[colID]]
-				}
-				return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
-					"foreign key violation: values %v in columns %s referenced in table %q",
-					fkValues, fk.mutatedIdx.ColumnNames[:fk.prefixLen], fk.searchTable.Name)
+				return fk.violationError(oldRow)
 			}
 
 		default:
@@ -144,6 +131,34 @@ func (f *fkExistenceBatchChecker) runCheck(
 	return nil
 }
 
+// violationError returns the error reported when the check of the FK
+// constraint fails for the given values of the mutated row: the new
+// values for forward checks and the old values for backward checks.
+// For backward checks, row is nil when the entire index is checked.
+func (f *fkExistenceCheckBaseHelper) violationError(row tree.Datums) error {
+	if f.dir == CheckDeletes && row == nil {
+		return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
+			"foreign key violation: non-empty columns %s referenced in table %q",
+			f.mutatedIdx.ColumnNames[:f.prefixLen], f.searchTable.Name)
+	}
+
+	// TODO(knz): re-allocating a datum slice in every check
+	// is super inefficient and expensive. Factor this.
+	fkValues := make(tree.Datums, f.prefixLen)
+
+	for valueIdx, colID := range f.searchIdx.ColumnIDs[:f.prefixLen] {
+		fkValues[valueIdx] = row[f.ids[colID]]
+	}
+	if f.dir == CheckInserts {
+		return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
+			"foreign key violation: value %s not found in %s@%s %s (txn=%s)",
+			fkValues, f.searchTable.Name, f.searchIdx.Name, f.searchIdx.ColumnNames[:f.prefixLen], f.txn.ID())
+	}
+	return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
+		"foreign key violation: values %v in columns %s referenced in table %q",
+		fkValues, f.mutatedIdx.ColumnNames[:f.prefixLen], f.searchTable.Name)
+}
+
 // SpanKVFetcher is an kvBatchFetcher that returns a set slice of kvs.
 type SpanKVFetcher struct {
 	KVs []roachpb.KeyValue
@@ -0,0 +1,283 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package row
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// ConstraintMode is the checking mode of deferrable constraints, as set
+// by SET CONSTRAINTS.
+type ConstraintMode int
+
+const (
+	// ConstraintModeDefault checks a constraint according to its
+	// INITIALLY IMMEDIATE or INITIALLY DEFERRED attribute.
+	ConstraintModeDefault ConstraintMode = iota
+	// ConstraintModeImmediate checks a constraint as rows are written.
+	ConstraintModeImmediate
+	// ConstraintModeDeferred checks a constraint when the transaction
+	// commits.
+	ConstraintModeDeferred
+)
+
+// deferredFKCheckBatchSize is the maximum number of deferred checks
+// evaluated in a single KV batch.
+const deferredFKCheckBatchSize = 1000
+
+// sizeOfDeferredFKCheck is the memory size of a deferredFKCheck, not
+// counting the datums of its row.
+const sizeOfDeferredFKCheck = int64(unsafe.Sizeof(deferredFKCheck{}))
+
+// DeferredFKChecks accumulates the FK existence checks of a transaction
+// that are deferred until it commits, along with the modes of the
+// deferrable constraints set by SET CONSTRAINTS.
+//
+// A deferred check does not remember the row that was written. Instead,
+// it looks up the written values on both sides of the constraint when it
+// is evaluated: there is a violation if the values can be found in the
+// referencing index but not in the referenced index. This way, the rows
+// that are modified again, or whose writes are rolled back to a
+// savepoint, after the check was queued are handled correctly.
+//
+// The memory used by the pending checks is accounted for in the monitor
+// passed to Init, so that a transaction that defers too many checks fails
+// instead of exhausting the memory of the node.
+//
+// DeferredFKChecks is safe for concurrent use, as statements executed in
+// parallel can queue checks at the same time.
+type DeferredFKChecks struct {
+	mu struct {
+		syncutil.Mutex
+
+		// acc accounts for the memory used by checks.
+		acc mon.BoundAccount
+
+		// allMode is the mode set by SET CONSTRAINTS ALL.
+		allMode ConstraintMode
+		// modes maps constraint names to the mode set for them by SET
+		// CONSTRAINTS since the last SET CONSTRAINTS ALL.
+		modes map[string]ConstraintMode
+		// checks is the list of deferred checks, in the order in which
+		// they were queued.
+		checks []deferredFKCheck
+	}
+}
+
+// deferredFKCheck is a deferred FK existence check for a mutated row.
+type deferredFKCheck struct {
+	fk *fkExistenceCheckBaseHelper
+	// row is the mutated row: the new values for forward checks and the old
+	// values for backward checks.
+	row tree.Datums
+}
+
+// memUsage returns the memory size of the check.
+func (c *deferredFKCheck) memUsage() int64 {
+	sz := sizeOfDeferredFKCheck + rowcontainer.SizeOfDatum*int64(len(c.row))
+	for _, d := range c.row {
+		sz += int64(d.Size())
+	}
+	return sz
+}
+
+// Init binds the memory account of the checks to the given monitor. It
+// must be called before any check is queued.
+func (d *DeferredFKChecks) Init(monitor *mon.BytesMonitor) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.acc = monitor.MakeBoundAccount()
+}
+
+// SetAllModes sets the mode of all the deferrable constraints.
+func (d *DeferredFKChecks) SetAllModes(mode ConstraintMode) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.allMode = mode
+	d.mu.modes = nil
+}
+
+// SetMode sets the mode of the deferrable constraints with the given name.
+func (d *DeferredFKChecks) SetMode(name string, mode ConstraintMode) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.mu.modes == nil {
+		d.mu.modes = make(map[string]ConstraintMode)
+	}
+	d.mu.modes[name] = mode
+}
+
+// Len returns the number of pending checks.
+func (d *DeferredFKChecks) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.mu.checks)
+}
+
+// Reset discards the pending checks and the constraint modes, and
+// releases the memory used by the checks. It is called when a transaction
+// starts or restarts.
+func (d *DeferredFKChecks) Reset(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.allMode = ConstraintModeDefault
+	d.mu.modes = nil
+	d.mu.checks = nil
+	d.mu.acc.Clear(ctx)
+}
+
+// isDeferred returns true if the checks of the given FK constraint are
+// currently deferred.
+func (d *DeferredFKChecks) isDeferred(fk *fkExistenceCheckBaseHelper) bool {
+	if !fk.deferrable {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	mode, ok := d.mu.modes[fk.constraintName]
+	if !ok {
+		mode = d.mu.allMode
+	}
+	switch mode {
+	case ConstraintModeImmediate:
+		return false
+	case ConstraintModeDeferred:
+		return true
+	default:
+		return fk.initiallyDeferred
+	}
+}
+
+// add queues a check for the given FK constraint and mutated row. An
+// error is returned if the memory budget of the checks is exceeded.
+func (d *DeferredFKChecks) add(
+	ctx context.Context, fk *fkExistenceCheckBaseHelper, row tree.Datums,
+) error {
+	// The row is copied as writers reuse their row buffers.
+	c := deferredFKCheck{fk: fk, row: append(tree.Datums(nil), row...)}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.mu.acc.Grow(ctx, c.memUsage()); err != nil {
+		return err
+	}
+	d.mu.checks = append(d.mu.checks, c)
+	return nil
+}
+
+// Run evaluates the pending checks of the constraints whose name is
+// accepted by filter, or all the pending checks if filter is nil. The
+// checks are discarded if none of them fails; otherwise, a
+// pgerror.CodeForeignKeyViolationError is returned for the first failing
+// check in order of addition.
+func (d *DeferredFKChecks) Run(
+	ctx context.Context, txn *client.Txn, filter func(name string) bool,
+) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var toRun, remaining []deferredFKCheck
+	var toRunSize int64
+	for _, c := range d.mu.checks {
+		if filter == nil || filter(c.fk.constraintName) {
+			toRun = append(toRun, c)
+			toRunSize += c.memUsage()
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	for len(toRun) > 0 {
+		n := len(toRun)
+		if n > deferredFKCheckBatchSize {
+			n = deferredFKCheckBatchSize
+		}
+		if err := runDeferredFKChecks(ctx, txn, toRun[:n]); err != nil {
+			return err
+		}
+		toRun = toRun[n:]
+	}
+	d.mu.checks = remaining
+	d.mu.acc.Shrink(ctx, toRunSize)
+	return nil
+}
+
+// runDeferredFKChecks evaluates the given checks in a single KV batch.
+// Each check looks up the mutated values in both the searched and the
+// mutated index.
+func runDeferredFKChecks(ctx context.Context, txn *client.Txn, checks []deferredFKCheck) error {
+	var ba roachpb.BatchRequest
+	addScan := func(span roachpb.Span) {
+		var r roachpb.RequestUnion
+		r.MustSetInner(&roachpb.ScanRequest{RequestHeader: roachpb.RequestHeaderFromSpan(span)})
+		ba.Requests = append(ba.Requests, r)
+	}
+	for _, c := range checks {
+		searchSpan, err := c.fk.spanForValues(c.row)
+		if err != nil {
+			return err
+		}
+		mutatedSpan, err := c.fk.mutatedSpanForValues(c.row)
+		if err != nil {
+			return err
+		}
+		addScan(searchSpan)
+		addScan(mutatedSpan)
+	}
+
+	br, pErr := txn.Send(ctx, ba)
+	if pErr != nil {
+		return pErr.GoError()
+	}
+
+	var fetcher SpanKVFetcher
+	found := func(rf *Fetcher, resp roachpb.ResponseUnion) (bool, error) {
+		fetcher.KVs = resp.GetInner().(*roachpb.ScanResponse).Rows
+		if err := rf.StartScanFrom(ctx, &fetcher); err != nil {
+			return false, err
+		}
+		return !rf.kvEnd, nil
+	}
+	for i, c := range checks {
+		foundSearched, err := found(c.fk.rf, br.Responses[2*i])
+		if err != nil {
+			return err
+		}
+		foundMutated, err := found(c.fk.mutatedRf, br.Responses[2*i+1])
+		if err != nil {
+			return err
+		}
+		// There is a violation if the values are still referenced but the
+		// referenced row does not exist. For forward checks, the mutated
+		// index is the referencing index; for backward checks, it is the
+		// referenced index.
+		var violation bool
+		if c.fk.dir == CheckInserts {
+			violation = foundMutated && !foundSearched
+		} else {
+			violation = foundSearched && !foundMutated
+		}
+		if violation {
+			return c.fk.violationError(c.row)
+		}
+	}
+	return nil
+}
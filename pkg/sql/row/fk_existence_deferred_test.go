@@ -0,0 +1,79 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package row
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+)
+
+// TestDeferredFKChecksMemoryLimit verifies that the deferred FK checks are
+// accounted for in the memory monitor of the session, and that queuing a
+// check fails once the memory budget is exhausted.
+func TestDeferredFKChecksMemoryLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	const limit = 10 << 10
+	monitor := mon.MakeMonitorWithLimit("test", mon.MemoryResource, limit,
+		nil /* curCount */, nil /* maxHist */, 1 /* increment */, limit /* noteworthy */, st)
+	monitor.Start(ctx, nil /* pool */, mon.MakeStandaloneBudget(limit))
+	defer monitor.Stop(ctx)
+
+	var d DeferredFKChecks
+	d.Init(&monitor)
+	fk := &fkExistenceCheckBaseHelper{constraintName: "fk"}
+	row := tree.Datums{tree.NewDInt(1), tree.NewDString("a")}
+
+	queueUntilFull := func() int {
+		for i := 0; ; i++ {
+			if err := d.add(ctx, fk, row); err != nil {
+				if pgErr, ok := pgerror.GetPGCause(err); !ok || pgErr.Code != pgerror.CodeOutOfMemoryError {
+					t.Fatalf("expected out of memory error, got %v", err)
+				}
+				return i
+			}
+			if i > limit {
+				t.Fatal("queued checks beyond the memory limit")
+			}
+		}
+	}
+
+	n := queueUntilFull()
+	if n == 0 {
+		t.Fatal("no check could be queued")
+	}
+	if l := d.Len(); l != n {
+		t.Fatalf("expected %d pending checks, got %d", n, l)
+	}
+
+	// Resetting the checks releases their memory, after which as many checks
+	// can be queued again.
+	d.Reset(ctx)
+	if b := monitor.AllocBytes(); b != 0 {
+		t.Fatalf("expected no memory to be allocated after reset, got %d bytes", b)
+	}
+	if m := queueUntilFull(); m != n {
+		t.Fatalf("expected %d checks to be queued after reset, got %d", n, m)
+	}
+	d.Reset(ctx)
+}
@@ -62,7 +62,7 @@ func makeFkExistenceCheckHelperForDelete(
 				// and thus does not need to be checked for FK violations.
 				continue
 			}
-			fk, err := makeFkExistenceCheckBaseHelper(txn, otherTables, idx, ref, colMap, alloc, CheckDeletes)
+			fk, err := makeFkExistenceCheckBaseHelper(txn, otherTables, table, idx, ref, colMap, alloc, CheckDeletes)
 			if err == errSkipUnusedFK {
 				continue
 			}
@@ -65,6 +65,14 @@ func (f fkExistenceCheckBaseHelper) spanForValues(values tree.Datums) (roachpb.S
 	return roachpb.Span{Key: key, EndKey: key.PrefixEnd()}, nil
 }
 
+// mutatedSpanForValues produces the access span for a tuple of columns
+// in the mutated index of a deferrable FK constraint.
+func (f *fkExistenceCheckBaseHelper) mutatedSpanForValues(values tree.Datums) (roachpb.Span, error) {
+	span, _, err := sqlbase.EncodePartialIndexSpan(
+		f.mutatedTable.TableDesc(), f.mutatedIdx, f.prefixLen, f.mutatedIDs, values, f.mutatedPrefix)
+	return span, err
+}
+
 // collectSpansForValuesWithFKMap produce r/w access spans for all the
 // given FK constraints when the accessed values are not known.
 func collectSpansWithFKMap(fks map[sqlbase.IndexID][]fkExistenceCheckBaseHelper) roachpb.Spans {
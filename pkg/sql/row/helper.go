@@ -30,6 +30,11 @@ type rowHelper struct {
 	Indexes      []sqlbase.IndexDescriptor
 	indexEntries []sqlbase.IndexEntry
 
+	// predicates is used to leave out the entries of partial indexes for the
+	// rows that do not satisfy their predicate. It is nil if the helper writes
+	// entries for all the indexes; see initPartialIndexPredicates.
+	predicates *sqlbase.PartialIndexPredicates
+
 	// Computed during initialization for pretty-printing.
 	primIndexValDirs []encoding.Direction
 	secIndexValDirs  [][]encoding.Direction
@@ -57,6 +62,17 @@ func newRowHelper(
 	return rh
 }
 
+// initPartialIndexPredicates makes the helper leave out the entries of the
+// partial indexes among its indexes when the row does not satisfy the
+// predicate of the index: encodeSecondaryIndexes returns an entry with a nil
+// key for such an index. Helpers that are only used to delete rows don't need
+// this, since deleting an index entry that does not exist is harmless.
+func (rh *rowHelper) initPartialIndexPredicates() error {
+	var err error
+	rh.predicates, err = sqlbase.NewPartialIndexPredicates(rh.TableDesc.TableDesc(), rh.Indexes)
+	return err
+}
+
 // encodeIndexes encodes the primary and secondary index keys. The
 // secondaryIndexEntries are only valid until the next call to encodeIndexes or
 // encodeSecondaryIndexes.
@@ -93,6 +109,19 @@ func (rh *rowHelper) encodeSecondaryIndexes(
 	if err != nil {
 		return nil, err
 	}
+	if rh.predicates != nil {
+		// Partial indexes cannot be inverted indexes, so their entries are
+		// the ones at the same positions as the indexes.
+		for i := range rh.Indexes {
+			ok, err := rh.predicates.Satisfies(&rh.Indexes[i], colIDtoRowIndex, values)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				rh.indexEntries[i] = sqlbase.IndexEntry{}
+			}
+		}
+	}
 	return rh.indexEntries, nil
 }
 
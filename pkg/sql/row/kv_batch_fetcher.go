@@ -50,6 +50,11 @@ type txnKVFetcher struct {
 	firstBatchLimit int64
 	useBatchLimit   bool
 	reverse         bool
+	// lockStr represents the locking mode to use when fetching KVs.
+	lockStr sqlbase.ScanLockingStrength
+	// lockWaitPolicy represents the policy to be used for handling conflicting
+	// locks held by other active transactions.
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy
 	// returnRangeInfo, if set, causes the kvBatchFetcher to populate rangeInfos.
 	// See also rowFetcher.returnRangeInfo.
 	returnRangeInfo bool
@@ -142,6 +147,8 @@ func makeKVBatchFetcher(
 	reverse bool,
 	useBatchLimit bool,
 	firstBatchLimit int64,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
 	returnRangeInfo bool,
 ) (txnKVFetcher, error) {
 	if firstBatchLimit < 0 || (!useBatchLimit && firstBatchLimit != 0) {
@@ -193,6 +200,8 @@ func makeKVBatchFetcher(
 		reverse:         reverse,
 		useBatchLimit:   useBatchLimit,
 		firstBatchLimit: firstBatchLimit,
+		lockStr:         lockStr,
+		lockWaitPolicy:  lockWaitPolicy,
 		returnRangeInfo: returnRangeInfo,
 	}, nil
 }
@@ -202,11 +211,14 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 	var ba roachpb.BatchRequest
 	ba.Header.MaxSpanRequestKeys = f.getBatchSize()
 	ba.Header.ReturnRangeInfo = f.returnRangeInfo
+	ba.Header.WaitPolicy = f.lockWaitPolicy.ToWaitPolicy()
+	keyLocking := f.lockStr.ToKeyLockingStrength()
 	ba.Requests = make([]roachpb.RequestUnion, len(f.spans))
 	if f.reverse {
 		scans := make([]roachpb.ReverseScanRequest, len(f.spans))
 		for i := range f.spans {
 			scans[i].ScanFormat = roachpb.BATCH_RESPONSE
+			scans[i].KeyLocking = keyLocking
 			scans[i].SetSpan(f.spans[i])
 			ba.Requests[i].MustSetInner(&scans[i])
 		}
@@ -214,6 +226,7 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 		scans := make([]roachpb.ScanRequest, len(f.spans))
 		for i := range f.spans {
 			scans[i].ScanFormat = roachpb.BATCH_RESPONSE
+			scans[i].KeyLocking = keyLocking
 			scans[i].SetSpan(f.spans[i])
 			ba.Requests[i].MustSetInner(&scans[i])
 		}
@@ -241,6 +254,10 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 
 	br, err := f.txn.Send(ctx, ba)
 	if err != nil {
+		if wiErr, ok := err.GetDetail().(*roachpb.WriteIntentError); ok &&
+			f.lockWaitPolicy == sqlbase.ScanLockingWaitPolicy_ERROR {
+			return newLockNotAvailableError(wiErr)
+		}
 		return err.GoError()
 	}
 	if br != nil {
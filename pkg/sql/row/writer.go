@@ -76,6 +76,10 @@ func MakeInserter(
 		}
 	}
 
+	if err := ri.Helper.initPartialIndexPredicates(); err != nil {
+		return Inserter{}, err
+	}
+
 	if checkFKs == CheckFKs {
 		var err error
 		if ri.Fks, err = makeFkExistenceCheckHelperForInsert(txn, tableDesc, fkTables,
@@ -191,6 +195,10 @@ func (ri *Inserter) InsertRow(
 	putFn = insertInvertedPutFn
 	for i := range secondaryIndexEntries {
 		e := &secondaryIndexEntries[i]
+		if e.Key == nil {
+			// The row is not in this partial index.
+			continue
+		}
 		putFn(ctx, b, &e.Key, &e.Value, traceKV)
 	}
 
@@ -342,6 +350,15 @@ func (ri *Inserter) EncodeIndexesForRow(
 	return ri.Helper.encodeIndexes(ri.InsertColIDtoRowIndex, values)
 }
 
+// DeferFKChecks makes the Inserter queue the FK existence checks of the
+// deferrable constraints in d, instead of running them, when the current
+// mode of the constraint says so.
+func (ri *Inserter) DeferFKChecks(d *DeferredFKChecks) {
+	if ri.Fks.checker != nil {
+		ri.Fks.checker.deferred = d
+	}
+}
+
 // Updater abstracts the key/value operations for updating table rows.
 type Updater struct {
 	Helper                rowHelper
@@ -446,6 +463,30 @@ func makeUpdaterWithoutCascader(
 		}
 	}
 
+	writableIndexes := tableDesc.WritableIndexes()
+	predicates, err := sqlbase.NewPartialIndexPredicates(tableDesc.TableDesc(), writableIndexes)
+	if err != nil {
+		return Updater{}, err
+	}
+
+	// runOverIndexColumns runs fn over the columns of the index, as well as
+	// the columns referenced by its predicate if it is a writable partial
+	// index: updating these columns can add the row to the index or remove
+	// it from the index.
+	runOverIndexColumns := func(index *sqlbase.IndexDescriptor, fn func(sqlbase.ColumnID) error) error {
+		if err := index.RunOverAllColumns(fn); err != nil {
+			return err
+		}
+		if predicates != nil && index.IsPartial() {
+			for _, id := range predicates.ColumnIDs(index) {
+				if err := fn(id); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
 	// Secondary indexes needing updating.
 	needsUpdate := func(index sqlbase.IndexDescriptor) bool {
 		if updateType == UpdaterOnlyColumns {
@@ -456,7 +497,7 @@ func makeUpdaterWithoutCascader(
 		if primaryKeyColChange {
 			return true
 		}
-		return index.RunOverAllColumns(func(id sqlbase.ColumnID) error {
+		return runOverIndexColumns(&index, func(id sqlbase.ColumnID) error {
 			if _, ok := updateColIDtoRowIndex[id]; ok {
 				return returnTruePseudoError
 			}
@@ -464,7 +505,6 @@ func makeUpdaterWithoutCascader(
 		}) != nil
 	}
 
-	writableIndexes := tableDesc.WritableIndexes()
 	includeIndexes := make([]sqlbase.IndexDescriptor, 0, len(writableIndexes))
 	for _, index := range writableIndexes {
 		if needsUpdate(index) {
@@ -489,6 +529,9 @@ func makeUpdaterWithoutCascader(
 	var deleteOnlyHelper *rowHelper
 	if len(deleteOnlyIndexes) > 0 {
 		rh := newRowHelper(tableDesc, deleteOnlyIndexes)
+		if err := rh.initPartialIndexPredicates(); err != nil {
+			return Updater{}, err
+		}
 		deleteOnlyHelper = &rh
 	}
 
@@ -501,12 +544,14 @@ func makeUpdaterWithoutCascader(
 		marshaled:             make([]roachpb.Value, len(updateCols)),
 		newValues:             make([]tree.Datum, len(tableCols)),
 	}
+	if err := ru.Helper.initPartialIndexPredicates(); err != nil {
+		return Updater{}, err
+	}
 
 	if primaryKeyColChange {
 		// These fields are only used when the primary key is changing.
 		// When changing the primary key, we delete the old values and reinsert
 		// them, so request them all.
-		var err error
 		if ru.rd, err = makeRowDeleterWithoutCascader(
 			txn, tableDesc, fkTables, tableCols, SkipFKs, alloc,
 		); err != nil {
@@ -566,8 +611,8 @@ func makeUpdaterWithoutCascader(
 
 		// Fetch all columns from indices that are being update so that they can
 		// be used to create the new kv pairs for those indices.
-		for _, index := range includeIndexes {
-			if err := index.RunOverAllColumns(maybeAddCol); err != nil {
+		for i := range includeIndexes {
+			if err := runOverIndexColumns(&includeIndexes[i], maybeAddCol); err != nil {
 				return Updater{}, err
 			}
 		}
@@ -578,7 +623,6 @@ func makeUpdaterWithoutCascader(
 		}
 	}
 
-	var err error
 	if ru.Fks, err = makeFkExistenceCheckHelperForUpdate(txn, tableDesc, fkTables,
 		ru.FetchColIDtoRowIndex, alloc); err != nil {
 		return Updater{}, err
@@ -738,13 +782,22 @@ func (ru *Updater) UpdateRow(
 			continue
 		}
 
+		// The entries of a partial index have a nil key if the row is not in
+		// the index.
 		var expValue interface{}
 		if !bytes.Equal(newSecondaryIndexEntry.Key, oldSecondaryIndexEntry.Key) {
 			ru.Fks.addCheckForIndex(ru.Helper.Indexes[i].ID, ru.Helper.Indexes[i].Type)
-			if traceKV {
-				log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(ru.Helper.secIndexValDirs[i], oldSecondaryIndexEntry.Key))
+			if oldSecondaryIndexEntry.Key != nil {
+				if traceKV {
+					log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(ru.Helper.secIndexValDirs[i], oldSecondaryIndexEntry.Key))
+				}
+				batch.Del(oldSecondaryIndexEntry.Key)
+			}
+			if newSecondaryIndexEntry.Key == nil {
+				continue
 			}
-			batch.Del(oldSecondaryIndexEntry.Key)
+		} else if oldSecondaryIndexEntry.Key == nil {
+			continue
 		} else if !newSecondaryIndexEntry.Value.EqualData(oldSecondaryIndexEntry.Value) {
 			expValue = &oldSecondaryIndexEntry.Value
 		} else {
@@ -761,6 +814,10 @@ func (ru *Updater) UpdateRow(
 	// indexed will be handled separately.
 	if ru.DeleteHelper != nil {
 		for _, deletedSecondaryIndexEntry := range deleteOldSecondaryIndexEntries {
+			if deletedSecondaryIndexEntry.Key == nil {
+				// The old row was not in the partial index.
+				continue
+			}
 			if traceKV {
 				log.VEventf(ctx, 2, "Del %s", deletedSecondaryIndexEntry.Key)
 			}
@@ -825,6 +882,18 @@ func (ru *Updater) IsColumnOnlyUpdate() bool {
 	return !ru.primaryKeyColChange && ru.DeleteHelper == nil && len(ru.Helper.Indexes) == 0
 }
 
+// DeferFKChecks makes the Updater, and the writers used to cascade its
+// updates, queue the FK existence checks of the deferrable constraints in
+// d. See Inserter.DeferFKChecks.
+func (ru *Updater) DeferFKChecks(d *DeferredFKChecks) {
+	if ru.Fks.checker != nil {
+		ru.Fks.checker.deferred = d
+	}
+	if ru.cascader != nil {
+		ru.cascader.deferred = d
+	}
+}
+
 // Deleter abstracts the key/value operations for deleting table rows.
 type Deleter struct {
 	Helper               rowHelper
@@ -877,6 +946,10 @@ func makeRowDeleterWithoutCascader(
 	alloc *sqlbase.DatumAlloc,
 ) (Deleter, error) {
 	indexes := tableDesc.DeletableIndexes()
+	predicates, err := sqlbase.NewPartialIndexPredicates(tableDesc.TableDesc(), indexes)
+	if err != nil {
+		return Deleter{}, err
+	}
 
 	fetchCols := requestedCols[:len(requestedCols):len(requestedCols)]
 	fetchColIDtoRowIndex := ColIDtoRowIndexFromCols(fetchCols)
@@ -909,6 +982,15 @@ func makeRowDeleterWithoutCascader(
 				return Deleter{}, err
 			}
 		}
+		// The columns of the predicate of a partial index are needed to
+		// determine whether the row has an entry in the index.
+		if predicates != nil && index.IsPartial() {
+			for _, colID := range predicates.ColumnIDs(&index) {
+				if err := maybeAddCol(colID); err != nil {
+					return Deleter{}, err
+				}
+			}
+		}
 	}
 
 	rd := Deleter{
@@ -916,8 +998,10 @@ func makeRowDeleterWithoutCascader(
 		FetchCols:            fetchCols,
 		FetchColIDtoRowIndex: fetchColIDtoRowIndex,
 	}
+	if err := rd.Helper.initPartialIndexPredicates(); err != nil {
+		return Deleter{}, err
+	}
 	if checkFKs == CheckFKs {
-		var err error
 		if rd.Fks, err = makeFkExistenceCheckHelperForDelete(txn, tableDesc, fkTables,
 			fetchColIDtoRowIndex, alloc); err != nil {
 			return Deleter{}, err
@@ -927,6 +1011,18 @@ func makeRowDeleterWithoutCascader(
 	return rd, nil
 }
 
+// DeferFKChecks makes the Deleter, and the writers used to cascade its
+// deletions, queue the FK existence checks of the deferrable constraints in
+// d. See Inserter.DeferFKChecks.
+func (rd *Deleter) DeferFKChecks(d *DeferredFKChecks) {
+	if rd.Fks.checker != nil {
+		rd.Fks.checker.deferred = d
+	}
+	if rd.cascader != nil {
+		rd.cascader.deferred = d
+	}
+}
+
 // DeleteRow adds to the batch the kv operations necessary to delete a table row
 // with the given values. It also will cascade as required and check for
 // orphaned rows. The bytesMonitor is only used if cascading/fk checking and can
@@ -943,8 +1039,13 @@ func (rd *Deleter) DeleteRow(
 		return err
 	}
 
-	// Delete the row from any secondary indices.
+	// Delete the row from any secondary indices. Partial indexes only have an
+	// entry for the row if it satisfies their predicate; deleting the key
+	// otherwise could remove the entry of another row in a unique index.
 	for i, secondaryIndexEntry := range secondaryIndexEntries {
+		if secondaryIndexEntry.Key == nil {
+			continue
+		}
 		if traceKV {
 			log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(rd.Helper.secIndexValDirs[i], secondaryIndexEntry.Key))
 		}
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -121,6 +122,11 @@ type scanNode struct {
 
 	// Indicates if this scan is the source for a delete node.
 	isDeleteSource bool
+
+	// lockingStrength and lockingWaitPolicy represent the row-level locking
+	// mode of the Scan.
+	lockingStrength   sqlbase.ScanLockingStrength
+	lockingWaitPolicy sqlbase.ScanLockingWaitPolicy
 }
 
 // scanVisibility represents which table columns should be included in a scan.
@@ -219,8 +225,15 @@ func (n *scanNode) startExec(params runParams) error {
 		Cols:             n.cols,
 		ValNeededForCol:  n.valNeededForCol.Copy(),
 	}
-	return n.run.fetcher.Init(n.reverse, false, /* returnRangeInfo */
-		false /* isCheck */, &params.p.alloc, tableArgs)
+	return n.run.fetcher.Init(
+		n.reverse,
+		n.lockingStrength,
+		n.lockingWaitPolicy,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&params.p.alloc,
+		tableArgs,
+	)
 }
 
 func (n *scanNode) Close(context.Context) {
@@ -268,6 +281,23 @@ func (n *scanNode) disableBatchLimit() {
 	n.softLimit = 0
 }
 
+// setLocking configures the row-level locking mode of the scan. A nil locking
+// item leaves the scan non-locking.
+func (n *scanNode) setLocking(locking *tree.LockingItem) error {
+	if locking == nil {
+		return nil
+	}
+	if locking.WaitPolicy == tree.LockWaitSkip && len(n.desc.Families) > 1 {
+		// Rows that span multiple column families are stored in multiple keys.
+		// Skipping some of those keys but not others would return partial rows.
+		return pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+			"SKIP LOCKED is not supported on tables with multiple column families")
+	}
+	n.lockingStrength = sqlbase.ToScanLockingStrength(locking.Strength)
+	n.lockingWaitPolicy = sqlbase.ToScanLockingWaitPolicy(locking.WaitPolicy)
+	return nil
+}
+
 // canParallelize returns true if this scanNode can be parallelized at the
 // distSender level safely.
 func (n *scanNode) canParallelize() bool {
@@ -644,6 +644,11 @@ func (sc *SchemaChanger) maybeGCMutations(
 			return nil
 		},
 		func(txn *client.Txn) error {
+			if mutation.JobID == 0 {
+				// The index was not dropped by a schema change job; for
+				// example, it held the old contents of a materialized view.
+				return nil
+			}
 			job, err := sc.jobRegistry.LoadJobWithTxn(ctx, mutation.JobID, txn)
 			if err != nil {
 				return err
@@ -966,11 +971,15 @@ func (sc *SchemaChanger) waitToUpdateLeases(ctx context.Context, tableID sqlbase
 func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescriptor, error) {
 	isRollback := false
 	jobSucceeded := true
+	// The resume spans of the mutations queued with the same mutation ID
+	// while completing the mutations.
+	var resumeSpanList []jobspb.ResumeSpanList
 	now := timeutil.Now().UnixNano()
 	return sc.leaseMgr.Publish(ctx, sc.tableID, func(desc *sqlbase.MutableTableDescriptor) error {
 		// Reset vars here because update function can be called multiple times in a retry.
 		isRollback = false
 		jobSucceeded = true
+		resumeSpanList = nil
 
 		i := 0
 		for _, mutation := range desc.Mutations {
@@ -1006,6 +1015,21 @@ func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescr
 		// Trim the executed mutations from the descriptor.
 		desc.Mutations = desc.Mutations[i:]
 
+		// Completing a computed column swap queues the drop of the replaced
+		// column and indexes with the same mutation ID. They are run by the
+		// same job, which is therefore not done yet.
+		for _, mutation := range desc.Mutations {
+			if mutation.MutationID != sc.mutationID {
+				break
+			}
+			resumeSpanList = append(resumeSpanList, jobspb.ResumeSpanList{
+				ResumeSpans: []roachpb.Span{desc.PrimaryIndexSpan()},
+			})
+		}
+		if resumeSpanList != nil {
+			return nil
+		}
+
 		for i, g := range desc.MutationJobs {
 			if g.MutationID == sc.mutationID {
 				// Trim the executed mutation group from the descriptor.
@@ -1015,6 +1039,11 @@ func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescr
 		}
 		return nil
 	}, func(txn *client.Txn) error {
+		if resumeSpanList != nil {
+			return sc.job.WithTxn(txn).SetDetails(
+				ctx, jobspb.SchemaChangeDetails{ResumeSpanList: resumeSpanList},
+			)
+		}
 		if jobSucceeded {
 			if err := sc.job.WithTxn(txn).Succeeded(ctx, jobs.NoopFn); err != nil {
 				return errors.Wrapf(err, "failed to mark job %d as successful", *sc.job.ID())
@@ -1095,8 +1124,15 @@ func (sc *SchemaChanger) runStateMachineAndBackfill(
 	}
 
 	// Mark the mutations as completed.
-	_, err := sc.done(ctx)
-	return err
+	desc, err := sc.done(ctx)
+	if err != nil {
+		return err
+	}
+	// Run the mutations queued by done() with the same mutation ID.
+	if len(desc.Mutations) > 0 && desc.Mutations[0].MutationID == sc.mutationID {
+		return sc.runStateMachineAndBackfill(ctx, lease, evalCtx)
+	}
+	return nil
 }
 
 // reverseMutations reverses the direction of all the mutations with the
@@ -1298,7 +1334,7 @@ func (sc *SchemaChanger) dropValidatingConstraint(
 	desc *MutableTableDescriptor, constraint *sqlbase.ConstraintToValidate,
 ) error {
 	switch constraint.ConstraintType {
-	case sqlbase.ConstraintToValidate_CHECK:
+	case sqlbase.ConstraintToValidate_CHECK, sqlbase.ConstraintToValidate_NOT_NULL:
 		check, err := desc.FindCheckByName(constraint.Name)
 		if err != nil {
 			return err
@@ -4047,3 +4047,123 @@ CREATE TABLE t.test (k INT PRIMARY KEY, v INT);
 		t.Fatalf("descriptor broken %d, %d", len(tableDesc.Indexes), len(tableDesc.Mutations))
 	}
 }
+
+// Test that while a column type change is being completed, the rows written
+// by the nodes that use the descriptor version following the swap of the old
+// and new columns can still be read by the nodes that use the version
+// preceding it.
+func TestAlterColumnTypeMixedVersions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	params, _ := tests.CreateTestServerParams()
+	swapNotification := make(chan struct{})
+	continueNotification := make(chan struct{})
+	var publishCount int64
+	params.Knobs = base.TestingKnobs{
+		SQLSchemaChanger: &sql.SchemaChangerTestingKnobs{
+			RunBeforePublishWriteAndDelete: func() {
+				// The mutations queued by the swap are run by a second pass
+				// of the state machine.
+				if atomic.AddInt64(&publishCount, 1) == 2 {
+					close(swapNotification)
+					<-continueNotification
+				}
+			},
+		},
+		// Disable backfill migrations, we still need the jobs table migration.
+		SQLMigrationManager: &sqlmigrations.MigrationManagerTestingKnobs{
+			DisableBackfillMigrations: true,
+		},
+	}
+	server, sqlDB, kvDB := serverutils.StartServer(t, params)
+	defer server.Stopper().Stop(context.TODO())
+
+	if _, err := sqlDB.Exec(`
+CREATE DATABASE t;
+CREATE TABLE t.test (k INT PRIMARY KEY, v INT, INDEX v_idx (v));
+INSERT INTO t.test VALUES (1, 1);
+`); err != nil {
+		t.Fatal(err)
+	}
+	oldDesc := sqlbase.GetTableDescriptor(kvDB, "t", "test")
+	oldIndex, _, err := oldDesc.FindIndexByName("v_idx")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := sqlDB.Exec(`ALTER TABLE t.test ALTER COLUMN v TYPE STRING`); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// The new column has replaced the old one, which is being dropped but is
+	// still used by the nodes that have a lease on the previous version.
+	<-swapNotification
+	tableDesc := sqlbase.GetTableDescriptor(kvDB, "t", "test")
+	if col, _, err := tableDesc.FindColumnByName("v"); err != nil {
+		t.Fatal(err)
+	} else if col.Type.SemanticType != sqlbase.ColumnType_STRING {
+		t.Fatalf("expected column v to be a STRING, got %s", col.Type.SQLString())
+	}
+	if _, err := sqlDB.Exec(`
+INSERT INTO t.test VALUES (2, '2');
+UPDATE t.test SET v = '10' WHERE k = 1;
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the old index with the previous version of the descriptor. It
+	// must reflect the rows written since the swap.
+	prefix := roachpb.Key(sqlbase.MakeIndexKeyPrefix(oldDesc, oldIndex.ID))
+	kvs, err := kvDB.Scan(context.TODO(), prefix, prefix.PrefixEnd(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	colTypes := []sqlbase.ColumnType{oldDesc.Columns[1].Type}
+	dirs := []sqlbase.IndexDescriptor_Direction{sqlbase.IndexDescriptor_ASC}
+	var alloc sqlbase.DatumAlloc
+	var values []string
+	for _, kv := range kvs {
+		vals := make([]sqlbase.EncDatum, 1)
+		if _, ok, err := sqlbase.DecodeIndexKey(
+			oldDesc, oldIndex, colTypes, vals, dirs, kv.Key,
+		); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Fatalf("key %s does not match index %s", kv.Key, oldIndex.Name)
+		}
+		if err := vals[0].EnsureDecoded(&colTypes[0], &alloc); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, vals[0].Datum.String())
+	}
+	if expected := []string{"2", "10"}; fmt.Sprint(values) != fmt.Sprint(expected) {
+		t.Fatalf("expected old index values %v, got %v", expected, values)
+	}
+
+	close(continueNotification)
+	wg.Wait()
+
+	rows, err := sqlDB.Query(`SELECT v FROM t.test ORDER BY k`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var results []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, v)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"10", "2"}; fmt.Sprint(results) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, results)
+	}
+}
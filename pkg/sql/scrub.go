@@ -178,10 +178,14 @@ func (n *scrubNode) startScrubDatabase(ctx context.Context, p *planner, name *tr
 	for i := range tbNames {
 		tableName := &tbNames[i]
 		objDesc, _, err := p.LogicalSchemaAccessor().GetObjectDesc(ctx, p.txn,
-			tableName, p.ObjectLookupFlags(true /*required*/, false /*requireMutable*/))
+			tableName, p.ObjectLookupFlags(false /*required*/, false /*requireMutable*/))
 		if err != nil {
 			return err
 		}
+		if objDesc == nil {
+			// The name refers to a user-defined function.
+			continue
+		}
 		tableDesc := objDesc.(*sqlbase.ImmutableTableDescriptor)
 		// Skip non-tables and don't throw an error if we encounter one.
 		if !tableDesc.IsTable() {
@@ -303,15 +303,22 @@ func createIndexCheckQuery(
 		asOfClauseStr = fmt.Sprintf("AS OF SYSTEM TIME %d", asOf.WallTime)
 	}
 
+	// A partial index only contains the rows that satisfy its predicate, so
+	// only these rows are compared.
+	var whereClauseStr string
+	if indexDesc.IsPartial() {
+		whereClauseStr = fmt.Sprintf("WHERE %s", indexDesc.Predicate)
+	}
+
 	// We need to make sure we can handle the non-public column `rowid`
 	// that is created for implicit primary keys. In order to do so, the
 	// rendered columns need to explicit in the inner selects.
 	const checkIndexQuery = `
 				SELECT %[1]s, %[2]s
 				FROM
-					(SELECT %[9]s FROM %[3]s@{FORCE_INDEX=[1]} %[10]s ORDER BY %[5]s) AS leftside
+					(SELECT %[9]s FROM %[3]s@{FORCE_INDEX=[1]} %[10]s %[11]s ORDER BY %[5]s) AS leftside
 				FULL OUTER JOIN
-					(SELECT %[9]s FROM %[3]s@{FORCE_INDEX=[%[4]d]} %[10]s ORDER BY %[5]s) AS rightside
+					(SELECT %[9]s FROM %[3]s@{FORCE_INDEX=[%[4]d]} %[10]s %[11]s ORDER BY %[5]s) AS rightside
 					ON %[6]s
 				WHERE (%[7]s) OR
 							(%[8]s)`
@@ -326,5 +333,6 @@ func createIndexCheckQuery(
 		tableColumnsIsNullPredicate("rightside", tableDesc.PrimaryIndex.ColumnNames, "AND", true /* isNull */), // 8
 		strings.Join(columnNames, ","), // 9
 		asOfClauseStr,                  // 10
+		whereClauseStr,                 // 11
 	)
 }
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"unsafe"
 
 	"github.com/cockroachdb/apd"
@@ -297,6 +298,161 @@ var aggregates = map[string]builtinDefinition{
 			newAnyNotNullAggregate,
 			"Returns an arbitrary not-NULL value, or NULL if none exists.",
 		))),
+
+	// The *_impl aggregates implement the ordered-set and hypothetical-set
+	// aggregates defined in orderedSetAggregates below. Their first argument
+	// is the WITHIN GROUP sort expression, which provides the values to
+	// aggregate. It is followed by the direct arguments of the ordered-set
+	// aggregate, which must be constant, and by a constant boolean which is
+	// true if the WITHIN GROUP ordering is descending. They are private, since
+	// they are only planned by the optimizer.
+
+	// percentile_disc_impl and percentile_cont_impl accept a NULL fraction.
+	"percentile_disc_impl": makePrivate(collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.Float, types.Bool}, t,
+				newPercentileDiscAggregate, "Implements percentile_disc.")
+		},
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.TArray{Typ: types.Float}, types.Bool},
+				types.TArray{Typ: t}, newPercentileDiscAggregate, "Implements percentile_disc.")
+		},
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.TArray{Typ: types.Decimal}, types.Bool},
+				types.TArray{Typ: t}, newPercentileDiscAggregate, "Implements percentile_disc.")
+		},
+	)),
+
+	"percentile_cont_impl": makePrivate(collectOverloads(aggPropsNullableArgs(), percentileContTypes,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.Float, types.Bool}, t,
+				newPercentileContAggregate, "Implements percentile_cont.")
+		},
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.TArray{Typ: types.Float}, types.Bool},
+				types.TArray{Typ: t}, newPercentileContAggregate, "Implements percentile_cont.")
+		},
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.TArray{Typ: types.Decimal}, types.Bool},
+				types.TArray{Typ: t}, newPercentileContAggregate, "Implements percentile_cont.")
+		},
+	)),
+
+	"mode_impl": makePrivate(collectOverloads(aggProps(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, types.Bool}, t, newModeAggregate, "Implements mode.")
+		})),
+
+	// The hypothetical-set aggregates take NULL values into account.
+	"rank_impl": makePrivate(collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, t, types.Bool}, types.Int,
+				newHypotheticalRankAggregate, "Implements the rank hypothetical-set aggregate.")
+		})),
+
+	"dense_rank_impl": makePrivate(collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, t, types.Bool}, types.Int,
+				newHypotheticalDenseRankAggregate, "Implements the dense_rank hypothetical-set aggregate.")
+		})),
+
+	"percent_rank_impl": makePrivate(collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, t, types.Bool}, types.Float,
+				newHypotheticalPercentRankAggregate, "Implements the percent_rank hypothetical-set aggregate.")
+		})),
+
+	"cume_dist_impl": makePrivate(collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t, t, types.Bool}, types.Float,
+				newHypotheticalCumeDistAggregate, "Implements the cume_dist hypothetical-set aggregate.")
+		})),
+}
+
+// percentileContTypes are the types that percentile_cont can interpolate.
+var percentileContTypes = []types.T{types.Float, types.Interval}
+
+// orderedSetAggregates are the ordered-set and hypothetical-set aggregate
+// functions, which must be applied with a WITHIN GROUP clause:
+//
+//   percentile_disc(0.5) WITHIN GROUP (ORDER BY x)
+//   rank(3) WITHIN GROUP (ORDER BY x DESC)
+//
+// Their arguments are the direct arguments of the function followed by the
+// WITHIN GROUP sort expression. These definitions are only used to type check
+// the function applications; the optimizer plans the corresponding *_impl
+// aggregates above to compute them.
+//
+// See tree.OrderedSetFunDefs.
+var orderedSetAggregates = map[string]builtinDefinition{
+	"percentile_disc": collectOverloads(aggProps(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{types.Float, t}, t,
+				"Discrete percentile: returns the first input value whose position in the ordering "+
+					"equals or exceeds the specified fraction.")
+		},
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{types.TArray{Typ: types.Float}, t}, types.TArray{Typ: t},
+				"Discrete percentile: returns an array of the input values whose positions in the "+
+					"ordering equal or exceed each of the specified fractions.")
+		},
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{types.TArray{Typ: types.Decimal}, t}, types.TArray{Typ: t},
+				"Discrete percentile: returns an array of the input values whose positions in the "+
+					"ordering equal or exceed each of the specified fractions.")
+		},
+	),
+
+	"percentile_cont": collectOverloads(aggProps(), percentileContTypes,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{types.Float, t}, t,
+				"Continuous percentile: returns a value corresponding to the specified fraction in "+
+					"the ordering, interpolating between adjacent input values if needed.")
+		},
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{types.TArray{Typ: types.Float}, t}, types.TArray{Typ: t},
+				"Continuous percentile: returns an array of the values corresponding to each of the "+
+					"specified fractions in the ordering, interpolating between adjacent input values if needed.")
+		},
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{types.TArray{Typ: types.Decimal}, t}, types.TArray{Typ: t},
+				"Continuous percentile: returns an array of the values corresponding to each of the "+
+					"specified fractions in the ordering, interpolating between adjacent input values if needed.")
+		},
+	),
+
+	"mode": collectOverloads(aggProps(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{t}, t,
+				"Returns the most frequent input value, choosing the first one in the ordering "+
+					"if there are multiple equally-frequent values.")
+		}),
+
+	"rank": collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{t, t}, types.Int,
+				"Calculates the rank of the hypothetical row, with gaps for duplicate rows.")
+		}),
+
+	"dense_rank": collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{t, t}, types.Int,
+				"Calculates the rank of the hypothetical row, without gaps.")
+		}),
+
+	"percent_rank": collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{t, t}, types.Float,
+				"Calculates the relative rank of the hypothetical row, ranging from 0 to 1.")
+		}),
+
+	"cume_dist": collectOverloads(aggPropsNullableArgs(), types.AnyNonArray,
+		func(t types.T) tree.Overload {
+			return makeOrderedSetOverload([]types.T{t, t}, types.Float,
+				"Calculates the cumulative distribution of the hypothetical row: "+
+					"(number of rows preceding or peer with it) / (total rows), "+
+					"counting the hypothetical row itself.")
+		}),
 }
 
 // AnyNotNull is the name of the aggregate returned by NewAnyNotNullAggregate.
@@ -321,6 +477,21 @@ func makeAggOverload(
 	)
 }
 
+// makeOrderedSetOverload returns an overload of an ordered-set aggregate. The
+// overload is only used for type checking, so it has no constructors.
+func makeOrderedSetOverload(in []types.T, ret types.T, info string) tree.Overload {
+	argTypes := make(tree.ArgTypes, len(in))
+	for i, typ := range in {
+		argTypes[i].Name = fmt.Sprintf("arg%d", i+1)
+		argTypes[i].Typ = typ
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(ret),
+		Info:       info,
+	}
+}
+
 func makeAggOverloadWithReturnType(
 	in []types.T,
 	retType tree.ReturnTyper,
@@ -405,6 +576,13 @@ var _ tree.AggregateFunc = &boolOrAggregate{}
 var _ tree.AggregateFunc = &bytesXorAggregate{}
 var _ tree.AggregateFunc = &intXorAggregate{}
 var _ tree.AggregateFunc = &jsonAggregate{}
+var _ tree.AggregateFunc = &percentileDiscAggregate{}
+var _ tree.AggregateFunc = &percentileContAggregate{}
+var _ tree.AggregateFunc = &modeAggregate{}
+var _ tree.AggregateFunc = &hypotheticalRankAggregate{}
+var _ tree.AggregateFunc = &hypotheticalDenseRankAggregate{}
+var _ tree.AggregateFunc = &hypotheticalPercentRankAggregate{}
+var _ tree.AggregateFunc = &hypotheticalCumeDistAggregate{}
 
 const sizeOfArrayAggregate = int64(unsafe.Sizeof(arrayAggregate{}))
 const sizeOfAvgAggregate = int64(unsafe.Sizeof(avgAggregate{}))
@@ -433,6 +611,13 @@ const sizeOfBoolOrAggregate = int64(unsafe.Sizeof(boolOrAggregate{}))
 const sizeOfBytesXorAggregate = int64(unsafe.Sizeof(bytesXorAggregate{}))
 const sizeOfIntXorAggregate = int64(unsafe.Sizeof(intXorAggregate{}))
 const sizeOfJSONAggregate = int64(unsafe.Sizeof(jsonAggregate{}))
+const sizeOfPercentileDiscAggregate = int64(unsafe.Sizeof(percentileDiscAggregate{}))
+const sizeOfPercentileContAggregate = int64(unsafe.Sizeof(percentileContAggregate{}))
+const sizeOfModeAggregate = int64(unsafe.Sizeof(modeAggregate{}))
+const sizeOfHypotheticalRankAggregate = int64(unsafe.Sizeof(hypotheticalRankAggregate{}))
+const sizeOfHypotheticalDenseRankAggregate = int64(unsafe.Sizeof(hypotheticalDenseRankAggregate{}))
+const sizeOfHypotheticalPercentRankAggregate = int64(unsafe.Sizeof(hypotheticalPercentRankAggregate{}))
+const sizeOfHypotheticalCumeDistAggregate = int64(unsafe.Sizeof(hypotheticalCumeDistAggregate{}))
 
 // See NewAnyNotNullAggregate.
 type anyNotNullAggregate struct {
@@ -1940,3 +2125,483 @@ func (a *jsonAggregate) Close(ctx context.Context) {
 func (a *jsonAggregate) Size() int64 {
 	return sizeOfJSONAggregate
 }
+
+// orderedSetArgs returns the constant arguments passed to the *_impl
+// aggregates: the direct argument of the ordered-set aggregate, if any, and
+// whether the WITHIN GROUP ordering is descending.
+func orderedSetArgs(arguments tree.Datums) (arg tree.Datum, desc bool) {
+	arg = tree.DNull
+	if n := len(arguments); n > 0 {
+		if b, ok := arguments[n-1].(*tree.DBool); ok {
+			desc = bool(*b)
+		}
+		if n > 1 {
+			arg = arguments[0]
+		}
+	}
+	return arg, desc
+}
+
+// sizeOfDatum is the memory size of a Datum reference.
+const sizeOfDatum = int64(unsafe.Sizeof(tree.Datum(nil)))
+
+// orderedSetValues buffers the values aggregated by an ordered-set aggregate
+// and sorts them according to the WITHIN GROUP ordering. The memory used by
+// the buffered values and by the slice holding them is accounted for against
+// the aggregate's memory monitor.
+type orderedSetValues struct {
+	evalCtx *tree.EvalContext
+	desc    bool
+	values  tree.Datums
+	sorted  bool
+	acc     mon.BoundAccount
+}
+
+func makeOrderedSetValues(evalCtx *tree.EvalContext, desc bool) orderedSetValues {
+	return orderedSetValues{
+		evalCtx: evalCtx,
+		desc:    desc,
+		acc:     evalCtx.Mon.MakeBoundAccount(),
+	}
+}
+
+func (v *orderedSetValues) add(ctx context.Context, datum tree.Datum) error {
+	usage := int64(datum.Size())
+	if len(v.values) == cap(v.values) {
+		// Grow the slice ourselves so that its new capacity is accounted for.
+		newCap := 2*cap(v.values) + 1
+		usage += sizeOfDatum * int64(newCap-cap(v.values))
+		if err := v.acc.Grow(ctx, usage); err != nil {
+			return err
+		}
+		values := make(tree.Datums, len(v.values), newCap)
+		copy(values, v.values)
+		v.values = values
+	} else if err := v.acc.Grow(ctx, usage); err != nil {
+		return err
+	}
+	v.values = append(v.values, datum)
+	v.sorted = false
+	return nil
+}
+
+func (v *orderedSetValues) compare(a, b tree.Datum) int {
+	c := a.Compare(v.evalCtx, b)
+	if v.desc {
+		return -c
+	}
+	return c
+}
+
+func (v *orderedSetValues) sort() {
+	if v.sorted {
+		return
+	}
+	sort.Slice(v.values, func(i, j int) bool {
+		return v.compare(v.values[i], v.values[j]) < 0
+	})
+	v.sorted = true
+}
+
+func (v *orderedSetValues) close(ctx context.Context) {
+	v.values = nil
+	v.acc.Close(ctx)
+}
+
+// percentileFraction returns the value of a percentile fraction, which must be
+// between 0 and 1.
+func percentileFraction(d tree.Datum) (float64, error) {
+	var f float64
+	switch t := d.(type) {
+	case *tree.DFloat:
+		f = float64(*t)
+	case *tree.DDecimal:
+		var err error
+		if f, err = t.Float64(); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, pgerror.NewAssertionErrorf("unexpected percentile fraction %s", d)
+	}
+	if !(f >= 0 && f <= 1) {
+		return 0, pgerror.NewErrorf(pgerror.CodeNumericValueOutOfRangeError,
+			"percentile value %g is not between 0 and 1", f)
+	}
+	return f, nil
+}
+
+// percentileResult computes the result of a percentile aggregate for the
+// given fraction, or for each of the elements of the given array of
+// fractions. The result of an array of fractions is an array of values of
+// type typ.
+func percentileResult(
+	fraction tree.Datum, typ types.T, fn func(f float64) (tree.Datum, error),
+) (tree.Datum, error) {
+	if fraction == tree.DNull {
+		return tree.DNull, nil
+	}
+	arr, ok := fraction.(*tree.DArray)
+	if !ok {
+		f, err := percentileFraction(fraction)
+		if err != nil {
+			return nil, err
+		}
+		return fn(f)
+	}
+	res := tree.NewDArray(typ)
+	for _, elem := range arr.Array {
+		d := tree.Datum(tree.DNull)
+		if elem != tree.DNull {
+			f, err := percentileFraction(elem)
+			if err != nil {
+				return nil, err
+			}
+			if d, err = fn(f); err != nil {
+				return nil, err
+			}
+		}
+		if err := res.Append(d); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// percentileDiscAggregate buffers its non-NULL input values and returns the
+// first value whose position in the sorted values equals or exceeds the
+// requested fraction.
+type percentileDiscAggregate struct {
+	orderedSetValues
+	typ      types.T
+	fraction tree.Datum
+}
+
+func newPercentileDiscAggregate(
+	params []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	fraction, desc := orderedSetArgs(arguments)
+	return &percentileDiscAggregate{
+		orderedSetValues: makeOrderedSetValues(evalCtx, desc),
+		typ:              params[0],
+		fraction:         fraction,
+	}
+}
+
+// Add buffers the passed datum.
+func (a *percentileDiscAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if datum == tree.DNull {
+		return nil
+	}
+	return a.add(ctx, datum)
+}
+
+// Result returns the discrete percentile of the buffered values.
+func (a *percentileDiscAggregate) Result() (tree.Datum, error) {
+	if len(a.values) == 0 {
+		return tree.DNull, nil
+	}
+	a.sort()
+	return percentileResult(a.fraction, a.typ, func(f float64) (tree.Datum, error) {
+		idx := int(math.Ceil(f*float64(len(a.values)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return a.values[idx], nil
+	})
+}
+
+// Close allows the aggregate to release the memory it requested during
+// operation.
+func (a *percentileDiscAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *percentileDiscAggregate) Size() int64 {
+	return sizeOfPercentileDiscAggregate
+}
+
+// percentileContAggregate buffers its non-NULL input values and returns the
+// value corresponding to the requested fraction of the sorted values,
+// interpolating linearly between the two closest values.
+type percentileContAggregate struct {
+	orderedSetValues
+	typ      types.T
+	fraction tree.Datum
+}
+
+func newPercentileContAggregate(
+	params []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	fraction, desc := orderedSetArgs(arguments)
+	return &percentileContAggregate{
+		orderedSetValues: makeOrderedSetValues(evalCtx, desc),
+		typ:              params[0],
+		fraction:         fraction,
+	}
+}
+
+// Add buffers the passed datum.
+func (a *percentileContAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if datum == tree.DNull {
+		return nil
+	}
+	return a.add(ctx, datum)
+}
+
+// Result returns the continuous percentile of the buffered values.
+func (a *percentileContAggregate) Result() (tree.Datum, error) {
+	if len(a.values) == 0 {
+		return tree.DNull, nil
+	}
+	a.sort()
+	return percentileResult(a.fraction, a.typ, func(f float64) (tree.Datum, error) {
+		pos := f * float64(len(a.values)-1)
+		lo, hi := int(math.Floor(pos)), int(math.Ceil(pos))
+		if lo == hi {
+			return a.values[lo], nil
+		}
+		frac := pos - float64(lo)
+		switch lv := a.values[lo].(type) {
+		case *tree.DFloat:
+			hv := a.values[hi].(*tree.DFloat)
+			return tree.NewDFloat(*lv + tree.DFloat(frac)*(*hv-*lv)), nil
+		case *tree.DInterval:
+			hv := a.values[hi].(*tree.DInterval)
+			return &tree.DInterval{Duration: lv.Duration.Add(hv.Duration.Sub(lv.Duration).MulFloat(frac))}, nil
+		default:
+			return nil, pgerror.NewAssertionErrorf("unexpected percentile_cont input %s", lv)
+		}
+	})
+}
+
+// Close allows the aggregate to release the memory it requested during
+// operation.
+func (a *percentileContAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *percentileContAggregate) Size() int64 {
+	return sizeOfPercentileContAggregate
+}
+
+// modeAggregate buffers its non-NULL input values and returns the most
+// frequent one. Ties are broken in favor of the value that comes first in the
+// WITHIN GROUP ordering.
+type modeAggregate struct {
+	orderedSetValues
+}
+
+func newModeAggregate(
+	_ []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	_, desc := orderedSetArgs(arguments)
+	return &modeAggregate{orderedSetValues: makeOrderedSetValues(evalCtx, desc)}
+}
+
+// Add buffers the passed datum.
+func (a *modeAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if datum == tree.DNull {
+		return nil
+	}
+	return a.add(ctx, datum)
+}
+
+// Result returns the most frequent buffered value.
+func (a *modeAggregate) Result() (tree.Datum, error) {
+	if len(a.values) == 0 {
+		return tree.DNull, nil
+	}
+	a.sort()
+	mode, modeCount := a.values[0], 0
+	for i := 0; i < len(a.values); {
+		j := i + 1
+		for j < len(a.values) && a.compare(a.values[i], a.values[j]) == 0 {
+			j++
+		}
+		if j-i > modeCount {
+			mode, modeCount = a.values[i], j-i
+		}
+		i = j
+	}
+	return mode, nil
+}
+
+// Close allows the aggregate to release the memory it requested during
+// operation.
+func (a *modeAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *modeAggregate) Size() int64 {
+	return sizeOfModeAggregate
+}
+
+// hypotheticalSetAggregate is the common base of the hypothetical-set
+// aggregates, which compute the position the hypothetical row arg would have
+// if it were added to the aggregated values. It counts the values that
+// precede the hypothetical row and the values that are its peers in the
+// WITHIN GROUP ordering. NULL values are ordered like in ORDER BY.
+type hypotheticalSetAggregate struct {
+	evalCtx   *tree.EvalContext
+	arg       tree.Datum
+	desc      bool
+	rows      int
+	preceding int
+	peers     int
+}
+
+func makeHypotheticalSetAggregate(
+	evalCtx *tree.EvalContext, arguments tree.Datums,
+) hypotheticalSetAggregate {
+	arg, desc := orderedSetArgs(arguments)
+	return hypotheticalSetAggregate{evalCtx: evalCtx, arg: arg, desc: desc}
+}
+
+// compare compares the passed datum to the hypothetical row.
+func (a *hypotheticalSetAggregate) compare(datum tree.Datum) int {
+	c := datum.Compare(a.evalCtx, a.arg)
+	if a.desc {
+		return -c
+	}
+	return c
+}
+
+// Add counts the passed datum.
+func (a *hypotheticalSetAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	a.rows++
+	if c := a.compare(datum); c < 0 {
+		a.preceding++
+	} else if c == 0 {
+		a.peers++
+	}
+	return nil
+}
+
+// Close is part of the tree.AggregateFunc interface.
+func (a *hypotheticalSetAggregate) Close(context.Context) {}
+
+// hypotheticalRankAggregate computes the rank of the hypothetical row, with
+// gaps for duplicate rows.
+type hypotheticalRankAggregate struct {
+	hypotheticalSetAggregate
+}
+
+func newHypotheticalRankAggregate(
+	_ []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	return &hypotheticalRankAggregate{makeHypotheticalSetAggregate(evalCtx, arguments)}
+}
+
+// Result returns the rank of the hypothetical row.
+func (a *hypotheticalRankAggregate) Result() (tree.Datum, error) {
+	return tree.NewDInt(tree.DInt(a.preceding + 1)), nil
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *hypotheticalRankAggregate) Size() int64 {
+	return sizeOfHypotheticalRankAggregate
+}
+
+// hypotheticalDenseRankAggregate computes the rank of the hypothetical row,
+// without gaps. It buffers the values that precede the hypothetical row in
+// order to count the distinct ones.
+type hypotheticalDenseRankAggregate struct {
+	hypotheticalSetAggregate
+	precedingValues orderedSetValues
+}
+
+func newHypotheticalDenseRankAggregate(
+	_ []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	a := &hypotheticalDenseRankAggregate{
+		hypotheticalSetAggregate: makeHypotheticalSetAggregate(evalCtx, arguments),
+	}
+	a.precedingValues = makeOrderedSetValues(evalCtx, a.desc)
+	return a
+}
+
+// Add buffers the passed datum if it precedes the hypothetical row.
+func (a *hypotheticalDenseRankAggregate) Add(
+	ctx context.Context, datum tree.Datum, _ ...tree.Datum,
+) error {
+	if a.compare(datum) < 0 {
+		return a.precedingValues.add(ctx, datum)
+	}
+	return nil
+}
+
+// Result returns the dense rank of the hypothetical row.
+func (a *hypotheticalDenseRankAggregate) Result() (tree.Datum, error) {
+	v := &a.precedingValues
+	v.sort()
+	rank := 1
+	for i := range v.values {
+		if i == 0 || v.compare(v.values[i-1], v.values[i]) != 0 {
+			rank++
+		}
+	}
+	return tree.NewDInt(tree.DInt(rank)), nil
+}
+
+// Close allows the aggregate to release the memory it requested during
+// operation.
+func (a *hypotheticalDenseRankAggregate) Close(ctx context.Context) {
+	a.precedingValues.close(ctx)
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *hypotheticalDenseRankAggregate) Size() int64 {
+	return sizeOfHypotheticalDenseRankAggregate
+}
+
+// hypotheticalPercentRankAggregate computes the relative rank of the
+// hypothetical row: (rank - 1) / (total rows - 1), where the total includes
+// the hypothetical row.
+type hypotheticalPercentRankAggregate struct {
+	hypotheticalSetAggregate
+}
+
+func newHypotheticalPercentRankAggregate(
+	_ []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	return &hypotheticalPercentRankAggregate{makeHypotheticalSetAggregate(evalCtx, arguments)}
+}
+
+// Result returns the relative rank of the hypothetical row.
+func (a *hypotheticalPercentRankAggregate) Result() (tree.Datum, error) {
+	if a.rows == 0 {
+		return tree.NewDFloat(0), nil
+	}
+	return tree.NewDFloat(tree.DFloat(a.preceding) / tree.DFloat(a.rows)), nil
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *hypotheticalPercentRankAggregate) Size() int64 {
+	return sizeOfHypotheticalPercentRankAggregate
+}
+
+// hypotheticalCumeDistAggregate computes the cumulative distribution of the
+// hypothetical row: (rows preceding or peer with it) / (total rows), where
+// both counts include the hypothetical row.
+type hypotheticalCumeDistAggregate struct {
+	hypotheticalSetAggregate
+}
+
+func newHypotheticalCumeDistAggregate(
+	_ []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	return &hypotheticalCumeDistAggregate{makeHypotheticalSetAggregate(evalCtx, arguments)}
+}
+
+// Result returns the cumulative distribution of the hypothetical row.
+func (a *hypotheticalCumeDistAggregate) Result() (tree.Datum, error) {
+	return tree.NewDFloat(tree.DFloat(a.preceding+a.peers+1) / tree.DFloat(a.rows+1)), nil
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *hypotheticalCumeDistAggregate) Size() int64 {
+	return sizeOfHypotheticalCumeDistAggregate
+}
@@ -22,9 +22,11 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 )
 
@@ -274,6 +276,39 @@ func testArrayAggAliasedTypeOverload(t *testing.T, expected types.T) {
 	}
 }
 
+// TestOrderedSetAggregateMemoryLimit verifies that the values buffered by an
+// ordered-set aggregate, including the slice holding them, are accounted for
+// against the memory monitor.
+func TestOrderedSetAggregateMemoryLimit(t *testing.T) {
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	const limit = 10 << 10
+	monitor := mon.MakeMonitorWithLimit("test", mon.MemoryResource, limit,
+		nil /* curCount */, nil /* maxHist */, 1 /* increment */, limit /* noteworthy */, st)
+	evalCtx := tree.MakeTestingEvalContextWithMon(st, &monitor)
+	defer evalCtx.Stop(ctx)
+
+	a := newPercentileDiscAggregate(
+		[]types.T{types.Int}, &evalCtx, tree.Datums{tree.NewDFloat(0.5), tree.DBoolFalse},
+	).(*percentileDiscAggregate)
+	defer a.Close(ctx)
+	for i := 0; ; i++ {
+		if err := a.Add(ctx, tree.NewDInt(tree.DInt(i))); err != nil {
+			if pgErr, ok := pgerror.GetPGCause(err); !ok || pgErr.Code != pgerror.CodeOutOfMemoryError {
+				t.Fatalf("expected out of memory error, got %v", err)
+			}
+			break
+		}
+		if i > limit {
+			t.Fatal("buffered values beyond the memory limit")
+		}
+		minUsage := int64(len(a.values))*int64(tree.NewDInt(0).Size()) + int64(cap(a.values))*sizeOfDatum
+		if used := a.acc.Used(); used < minUsage {
+			t.Fatalf("%d values accounted for as %d bytes, expected at least %d", len(a.values), used, minUsage)
+		}
+	}
+}
+
 func runBenchmarkAggregate(
 	b *testing.B,
 	aggFunc func([]types.T, *tree.EvalContext, tree.Datums) tree.AggregateFunc,
@@ -58,6 +58,13 @@ func init() {
 		}
 	}
 
+	tree.OrderedSetFunDefs = make(map[string]*tree.FunctionDefinition)
+	for name, def := range orderedSetAggregates {
+		// See the comment about row dependence in initAggregateBuiltins.
+		def.props.NeedsRepeatedEvaluation = true
+		tree.OrderedSetFunDefs[name] = tree.NewFunctionDefinition(name, &def.props, def.overloads)
+	}
+
 	// Generate missing categories.
 	for _, name := range AllBuiltinNames {
 		def := builtins[name]
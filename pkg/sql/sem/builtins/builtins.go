@@ -54,6 +54,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/knz/strtime"
 	"github.com/pkg/errors"
@@ -85,6 +86,7 @@ const (
 	categorySystemInfo    = "System info"
 	categoryGenerator     = "Set-returning"
 	categoryJSON          = "JSONB"
+	categoryTextSearch    = "Full text search"
 )
 
 func categorizeType(t types.T) string {
@@ -1713,6 +1715,98 @@ CockroachDB supports the following flags:
 		},
 	),
 
+	"current_time": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categoryDateAndTime,
+			Impure:   true,
+		},
+		tree.Overload{
+			Types:             tree.ArgTypes{},
+			ReturnType:        tree.FixedReturnType(types.TimeTZ),
+			PreferredOverload: true,
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				t := ctx.GetTxnTimestamp(time.Microsecond).Time
+				return tree.MakeDTimeTZFromTime(t.In(ctx.GetLocation())), nil
+			},
+			Info: "Returns the time of the current transaction." + txnTSContextDoc,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{},
+			ReturnType: tree.FixedReturnType(types.Time),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				t := ctx.GetTxnTimestamp(time.Microsecond).Time
+				return tree.MakeDTime(timeofday.FromTime(t.In(ctx.GetLocation()))), nil
+			},
+			Info: "Returns the time of the current transaction." + txnTSContextDoc,
+		},
+	),
+
+	// timezone implements the AT TIME ZONE operator.
+	"timezone": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categoryDateAndTime,
+			Impure:   true,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.String}, {"value", types.Timestamp}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				loc, err := timeZoneToLocation(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				t := args[1].(*tree.DTimestamp).Time
+				_, offset := t.In(loc).Zone()
+				return tree.MakeDTimestampTZ(t.Add(-time.Duration(offset)*time.Second), time.Microsecond), nil
+			},
+			Info: "Treats the timestamp `value` as a local time in the time zone `timezone` " +
+				"and returns the corresponding timestamptz.",
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.String}, {"value", types.TimestampTZ}},
+			ReturnType: tree.FixedReturnType(types.Timestamp),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				loc, err := timeZoneToLocation(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				t := args[1].(*tree.DTimestampTZ).Time
+				_, offset := t.In(loc).Zone()
+				return tree.MakeDTimestamp(t.UTC().Add(time.Duration(offset)*time.Second), time.Microsecond), nil
+			},
+			Info: "Converts the timestamptz `value` to the local time in the time zone `timezone`.",
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.String}, {"value", types.TimeTZ}},
+			ReturnType: tree.FixedReturnType(types.TimeTZ),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				loc, err := timeZoneToLocation(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return timeTZAtTimeZone(ctx, args[1].(*tree.DTimeTZ), loc), nil
+			},
+			Info: "Converts the timetz `value` to the time zone `timezone`. The offset of a " +
+				"time zone with daylight-saving rules is the one in effect at the time of " +
+				"the current transaction.",
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.String}, {"value", types.Time}},
+			ReturnType: tree.FixedReturnType(types.TimeTZ),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				loc, err := timeZoneToLocation(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				_, offset := ctx.GetTxnTimestamp(time.Microsecond).Time.In(ctx.GetLocation()).Zone()
+				t := tree.MakeDTimeTZ(timeofday.TimeOfDay(*args[1].(*tree.DTime)), int32(offset))
+				return timeTZAtTimeZone(ctx, t, loc), nil
+			},
+			Info: "Treats the time `value` as a local time in the session time zone and " +
+				"converts it to the time zone `timezone`.",
+		},
+	),
+
 	"now":                   txnTSImpl,
 	"current_timestamp":     txnTSImpl,
 	"transaction_timestamp": txnTSImpl,
@@ -2648,6 +2742,107 @@ may increase either contention or retry errors, or both.`,
 
 	"jsonb_array_length": makeBuiltin(jsonProps(), jsonArrayLengthImpl),
 
+	// Full text search functions.
+
+	// https://www.postgresql.org/docs/10/static/functions-textsearch.html
+	"to_tsvector": makeBuiltin(fullTextSearchProps(),
+		makeTextSearchOverloads(types.TSVector,
+			func(config, text string) (tree.Datum, error) {
+				v, err := tsearch.ToTSVector(config, text)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			"Converts the document `text` to a tsvector, normalizing its words to "+
+				"lexemes and recording their positions.",
+		)...,
+	),
+
+	"to_tsquery": makeBuiltin(fullTextSearchProps(),
+		makeTextSearchOverloads(types.TSQuery,
+			func(config, text string) (tree.Datum, error) {
+				q, err := tsearch.ToTSQuery(config, text)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			"Converts `text`, which must use the tsquery operators, to a tsquery, "+
+				"normalizing its operands to lexemes.",
+		)...,
+	),
+
+	"plainto_tsquery": makeBuiltin(fullTextSearchProps(),
+		makeTextSearchOverloads(types.TSQuery,
+			func(config, text string) (tree.Datum, error) {
+				q, err := tsearch.PlainToTSQuery(config, text)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			"Converts `text` to a tsquery matching the documents which contain all "+
+				"of its words. Punctuation and operators in `text` are ignored.",
+		)...,
+	),
+
+	"ts_rank": makeBuiltin(fullTextSearchProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsRank(tsearch.DefaultRankWeights, args[0], args[1], 0 /* method */), nil
+			},
+			Info: "Ranks how relevant `vector` is to `query`.",
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"vector", types.TSVector}, {"query", types.TSQuery}, {"normalization", types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				method := int(tree.MustBeDInt(args[2]))
+				return tsRank(tsearch.DefaultRankWeights, args[0], args[1], method), nil
+			},
+			Info: "Ranks how relevant `vector` is to `query`. `normalization` specifies " +
+				"how the rank is adjusted for the length of the document.",
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"weights", types.TArray{Typ: types.Float}}, {"vector", types.TSVector}, {"query", types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				weights, err := tsRankWeights(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				return tsRank(weights, args[1], args[2], 0 /* method */), nil
+			},
+			Info: "Ranks how relevant `vector` is to `query`. `weights` are the weights " +
+				"of the D, C, B and A positions of lexemes.",
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"weights", types.TArray{Typ: types.Float}}, {"vector", types.TSVector},
+				{"query", types.TSQuery}, {"normalization", types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				weights, err := tsRankWeights(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				method := int(tree.MustBeDInt(args[3]))
+				return tsRank(weights, args[1], args[2], method), nil
+			},
+			Info: "Ranks how relevant `vector` is to `query`. `weights` are the weights " +
+				"of the D, C, B and A positions of lexemes, and `normalization` specifies " +
+				"how the rank is adjusted for the length of the document.",
+		},
+	),
+
 	// Metadata functions.
 
 	// https://www.postgresql.org/docs/10/static/functions-info.html
@@ -3124,6 +3319,24 @@ var ceilImpl = makeBuiltin(defProps(),
 	}, "Calculates the smallest integer greater than `val`."),
 )
 
+// timeZoneToLocation loads the time zone named by the AT TIME ZONE operator.
+func timeZoneToLocation(zone string) (*time.Location, error) {
+	loc, err := timeutil.TimeZoneStringToLocation(zone)
+	if err != nil {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+			"time zone %q not recognized", zone)
+	}
+	return loc, nil
+}
+
+// timeTZAtTimeZone converts t to the offset which loc has at the time of the
+// current transaction.
+func timeTZAtTimeZone(ctx *tree.EvalContext, t *tree.DTimeTZ, loc *time.Location) *tree.DTimeTZ {
+	_, offset := ctx.GetTxnTimestamp(time.Microsecond).Time.In(loc).Zone()
+	delta := duration.MakeDuration((int64(offset)-int64(t.OffsetSecs))*int64(time.Second), 0, 0)
+	return tree.MakeDTimeTZ(t.TimeOfDay.Add(delta), int32(offset))
+}
+
 var txnTSContextDoc = `
 
 The value is based on a timestamp picked when the transaction starts
@@ -3370,6 +3583,71 @@ var jsonTypeOfImpl = tree.Overload{
 	Info: "Returns the type of the outermost JSON value as a text string.",
 }
 
+func fullTextSearchProps() tree.FunctionProperties {
+	return tree.FunctionProperties{
+		Category: categoryTextSearch,
+	}
+}
+
+// makeTextSearchOverloads returns the overloads of a function which converts
+// text to a text search type, with and without an explicit text search
+// configuration.
+func makeTextSearchOverloads(
+	retType types.T, fn func(config, text string) (tree.Datum, error), info string,
+) []tree.Overload {
+	return []tree.Overload{
+		{
+			Types:      tree.ArgTypes{{"text", types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return fn(tsearch.DefaultConfig, string(tree.MustBeDString(args[0])))
+			},
+			Info: info + " Uses the " + tsearch.DefaultConfig + " text search configuration.",
+		},
+		{
+			Types:      tree.ArgTypes{{"config", types.String}, {"text", types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return fn(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+			},
+			Info: info + " Uses the text search configuration `config`.",
+		},
+	}
+}
+
+// tsRank implements ts_rank for the tsvector and tsquery datums v and q.
+func tsRank(weights [4]float64, v, q tree.Datum, method int) tree.Datum {
+	vector := tree.MustBeDTSVector(v).TSVector
+	query := tree.MustBeDTSQuery(q).TSQuery
+	return tree.NewDFloat(tree.DFloat(tsearch.Rank(weights, vector, query, method)))
+}
+
+// tsRankWeights returns the weights passed to ts_rank as an array. Like in
+// Postgres, negative weights are replaced by the default ones.
+func tsRankWeights(arr *tree.DArray) ([4]float64, error) {
+	var weights [4]float64
+	if arr.Len() < len(weights) {
+		return weights, pgerror.NewError(pgerror.CodeArraySubscriptError,
+			"array of weight is too short")
+	}
+	for i := range weights {
+		if arr.Array[i] == tree.DNull {
+			return weights, pgerror.NewError(pgerror.CodeNullValueNotAllowedError,
+				"array of weight must not contain nulls")
+		}
+		w := float64(*arr.Array[i].(*tree.DFloat))
+		if w > 1 {
+			return weights, pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+				"weight out of range")
+		}
+		if w < 0 {
+			w = tsearch.DefaultRankWeights[i]
+		}
+		weights[i] = w
+	}
+	return weights, nil
+}
+
 func jsonProps() tree.FunctionProperties {
 	return tree.FunctionProperties{
 		Category: categoryJSON,
@@ -4403,7 +4681,7 @@ func asJSONBuildObjectKey(d tree.Datum) (string, error) {
 		return string(*t), nil
 	case *tree.DCollatedString:
 		return t.Contents, nil
-	case *tree.DBool, *tree.DInt, *tree.DFloat, *tree.DDecimal, *tree.DTimestamp, *tree.DTimestampTZ, *tree.DDate, *tree.DUuid, *tree.DInterval, *tree.DBytes, *tree.DIPAddr, *tree.DOid, *tree.DTime:
+	case *tree.DBool, *tree.DInt, *tree.DFloat, *tree.DDecimal, *tree.DTimestamp, *tree.DTimestampTZ, *tree.DDate, *tree.DUuid, *tree.DInterval, *tree.DBytes, *tree.DIPAddr, *tree.DOid, *tree.DTime, *tree.DTimeTZ:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	default:
 		return "", pgerror.NewAssertionErrorf("unexpected type %T for key value", d)
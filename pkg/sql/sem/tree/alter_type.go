@@ -0,0 +1,75 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lex"
+
+// AlterType represents an ALTER TYPE statement.
+type AlterType struct {
+	Type TableName
+	Cmd  AlterTypeCmd
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterType) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TYPE ")
+	ctx.FormatNode(&node.Type)
+	ctx.FormatNode(node.Cmd)
+}
+
+// AlterTypeCmd represents a type modification operation.
+type AlterTypeCmd interface {
+	NodeFormatter
+	// Placeholder function to ensure that only desired types
+	// (AlterType*) conform to the AlterTypeCmd interface.
+	alterTypeCmd()
+}
+
+func (*AlterTypeAddValue) alterTypeCmd() {}
+
+var _ AlterTypeCmd = &AlterTypeAddValue{}
+
+// AlterTypeAddValue represents an ALTER TYPE ADD VALUE command.
+type AlterTypeAddValue struct {
+	NewVal      string
+	IfNotExists bool
+	// Placement is nil if the value is added after all the existing values.
+	Placement *AlterTypeAddValuePlacement
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTypeAddValue) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ADD VALUE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	lex.EncodeSQLStringWithFlags(&ctx.Buffer, node.NewVal, ctx.flags.EncodeFlags())
+	if node.Placement != nil {
+		if node.Placement.Before {
+			ctx.WriteString(" BEFORE ")
+		} else {
+			ctx.WriteString(" AFTER ")
+		}
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, node.Placement.ExistingVal, ctx.flags.EncodeFlags())
+	}
+}
+
+// AlterTypeAddValuePlacement represents the placement clause of an ALTER TYPE
+// ADD VALUE command: the new value is placed either before or after an
+// existing value.
+type AlterTypeAddValuePlacement struct {
+	Before      bool
+	ExistingVal string
+}
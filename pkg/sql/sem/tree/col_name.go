@@ -17,6 +17,7 @@ package tree
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 )
 
@@ -72,6 +73,14 @@ func ComputeColNameInternal(sp sessiondata.SearchPath, target Expr) (int, string
 	case *FuncExpr:
 		fd, err := e.Func.Resolve(sp)
 		if err != nil {
+			// The name may refer to a user-defined function, which cannot be
+			// resolved without a catalog. Use the name as written; if there is
+			// no such function, the error is reported during type checking.
+			if pgErr, ok := pgerror.GetPGCause(err); ok && pgErr.Code == pgerror.CodeUndefinedFunctionError {
+				if un, ok := e.Func.FunctionReference.(*UnresolvedName); ok {
+					return 2, un.Parts[0], nil
+				}
+			}
 			return 0, "", err
 		}
 		return 2, fd.Name, nil
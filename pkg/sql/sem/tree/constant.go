@@ -325,7 +325,12 @@ func (expr *NumVal) ResolveAsType(ctx *SemaContext, typ types.T) (Datum, error)
 func intersectTypeSlices(xs, ys []types.T) (out []types.T) {
 	for _, x := range xs {
 		for _, y := range ys {
-			if x == y {
+			if xe, ok := x.(types.TEnum); ok {
+				// TEnum is not comparable with ==.
+				if ye, ok := y.(types.TEnum); ok && xe.TypeID == ye.TypeID {
+					out = append(out, x)
+				}
+			} else if x == y {
 				out = append(out, x)
 			}
 		}
@@ -414,13 +419,19 @@ var (
 		types.Decimal,
 		types.Date,
 		types.Time,
+		types.TimeTZ,
 		types.Timestamp,
 		types.TimestampTZ,
 		types.Interval,
 		types.UUID,
 		types.INet,
 		types.JSON,
+		types.TSVector,
+		types.TSQuery,
 		types.BitArray,
+		// A string literal can only become an enum value in a context which
+		// provides the enum type, e.g. in a comparison with an enum column.
+		types.FamEnum,
 	}
 	// StrValAvailBytes is the set of types convertible to byte array.
 	StrValAvailBytes = []types.T{types.Bytes, types.UUID, types.String}
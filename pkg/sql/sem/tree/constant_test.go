@@ -212,6 +212,13 @@ func mustParseDTime(t *testing.T, s string) tree.Datum {
 	}
 	return d
 }
+func mustParseDTimeTZ(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDTimeTZ(nil, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
 func mustParseDTimestamp(t *testing.T, s string) tree.Datum {
 	d, err := tree.ParseDTimestamp(nil, s, time.Millisecond)
 	if err != nil {
@@ -247,6 +254,7 @@ var parseFuncs = map[types.T]func(*testing.T, string) tree.Datum{
 	types.Bool:        mustParseDBool,
 	types.Date:        mustParseDDate,
 	types.Time:        mustParseDTime,
+	types.TimeTZ:      mustParseDTimeTZ,
 	types.Timestamp:   mustParseDTimestamp,
 	types.TimestampTZ: mustParseDTimestampTZ,
 	types.Interval:    mustParseDInterval,
@@ -285,11 +293,11 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 		},
 		{
 			c:            tree.NewStrVal("2010-09-28 12:00:00.1"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Time, types.Timestamp, types.TimestampTZ, types.Date),
+			parseOptions: typeSet(types.String, types.Bytes, types.Time, types.TimeTZ, types.Timestamp, types.TimestampTZ, types.Date),
 		},
 		{
 			c:            tree.NewStrVal("2006-07-08T00:00:00.000000123Z"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Time, types.Timestamp, types.TimestampTZ, types.Date),
+			parseOptions: typeSet(types.String, types.Bytes, types.Time, types.TimeTZ, types.Timestamp, types.TimestampTZ, types.Date),
 		},
 		{
 			c:            tree.NewStrVal("PT12H2M"),
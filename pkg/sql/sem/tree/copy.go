@@ -14,6 +14,11 @@
 
 package tree
 
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
 // CopyFrom represents a COPY FROM statement.
 type CopyFrom struct {
 	Table   TableName
@@ -35,3 +40,110 @@ func (node *CopyFrom) Format(ctx *FmtCtx) {
 		ctx.WriteString("STDIN")
 	}
 }
+
+// CopyTo represents a COPY TO statement.
+type CopyTo struct {
+	// Table and Columns are set for the COPY table TO form.
+	Table   TableName
+	Columns NameList
+	// Query is set for the COPY (query) TO form.
+	Query   *Select
+	Stdout  bool
+	Options CopyOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CopyTo) Format(ctx *FmtCtx) {
+	ctx.WriteString("COPY ")
+	if node.Query != nil {
+		ctx.FormatNode(node.Query)
+	} else {
+		ctx.FormatNode(&node.Table)
+		if len(node.Columns) > 0 {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteString(")")
+		}
+	}
+	ctx.WriteString(" TO ")
+	if node.Stdout {
+		ctx.WriteString("STDOUT")
+	}
+	if node.Options != (CopyOptions{}) {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
+
+// CopyOptions holds the options of a COPY TO statement. Each option is unset
+// if it has its zero value.
+type CopyOptions struct {
+	// DataFormat is the name of the data format. The text format is used if
+	// it is unset.
+	DataFormat Name
+	Delimiter  *string
+	Null       *string
+	// Header is set if the output starts with a line containing the names of
+	// the columns.
+	Header bool
+}
+
+// Combine merges the options in other into o. It returns an error if an
+// option is specified twice.
+func (o *CopyOptions) Combine(other CopyOptions) error {
+	if other.DataFormat != "" {
+		if o.DataFormat != "" {
+			return errConflictingCopyOptions
+		}
+		o.DataFormat = other.DataFormat
+	}
+	if other.Delimiter != nil {
+		if o.Delimiter != nil {
+			return errConflictingCopyOptions
+		}
+		o.Delimiter = other.Delimiter
+	}
+	if other.Null != nil {
+		if o.Null != nil {
+			return errConflictingCopyOptions
+		}
+		o.Null = other.Null
+	}
+	if other.Header {
+		if o.Header {
+			return errConflictingCopyOptions
+		}
+		o.Header = true
+	}
+	return nil
+}
+
+var errConflictingCopyOptions = pgerror.NewError(
+	pgerror.CodeSyntaxError, "conflicting or redundant options")
+
+// Format implements the NodeFormatter interface.
+func (o *CopyOptions) Format(ctx *FmtCtx) {
+	sep := ""
+	if o.DataFormat != "" {
+		ctx.WriteString("FORMAT ")
+		ctx.FormatNode(&o.DataFormat)
+		sep = ", "
+	}
+	if o.Delimiter != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("DELIMITER ")
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, *o.Delimiter, ctx.flags.EncodeFlags())
+		sep = ", "
+	}
+	if o.Null != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("NULL ")
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, *o.Null, ctx.flags.EncodeFlags())
+		sep = ", "
+	}
+	if o.Header {
+		ctx.WriteString(sep)
+		ctx.WriteString("HEADER")
+	}
+}
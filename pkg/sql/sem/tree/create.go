@@ -110,6 +110,8 @@ type CreateIndex struct {
 	Storing     NameList
 	Interleave  *InterleaveDef
 	PartitionBy *PartitionBy
+	// Predicate, if set, restricts the index to the rows that satisfy it.
+	Predicate Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -145,6 +147,10 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // TableDef represents a column, index or constraint definition within a CREATE
@@ -519,6 +525,8 @@ type IndexTableDef struct {
 	Interleave  *InterleaveDef
 	Inverted    bool
 	PartitionBy *PartitionBy
+	// Predicate, if set, restricts the index to the rows that satisfy it.
+	Predicate Expr
 }
 
 // SetName implements the TableDef interface.
@@ -550,6 +558,10 @@ func (node *IndexTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // ConstraintTableDef represents a constraint definition within a CREATE TABLE
@@ -572,6 +584,12 @@ type UniqueConstraintTableDef struct {
 
 // Format implements the NodeFormatter interface.
 func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Predicate != nil && !node.PrimaryKey {
+		// A partial unique index cannot be written as a constraint.
+		ctx.WriteString("UNIQUE ")
+		node.IndexTableDef.Format(ctx)
+		return
+	}
 	if node.Name != "" {
 		ctx.WriteString("CONSTRAINT ")
 		ctx.FormatNode(&node.Name)
@@ -596,6 +614,10 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // ReferenceAction is the method used to maintain referential integrity through
@@ -663,14 +685,36 @@ func (c CompositeKeyMatchMethod) String() string {
 	return compositeKeyMatchMethodName[c]
 }
 
+// ConstraintDeferrability describes whether the checking of a constraint
+// can be deferred to the end of the transaction.
+type ConstraintDeferrability int
+
+// The values for ConstraintDeferrability.
+const (
+	NotDeferrable ConstraintDeferrability = iota
+	DeferrableInitiallyImmediate
+	DeferrableInitiallyDeferred
+)
+
+// Format implements the NodeFormatter interface.
+func (d ConstraintDeferrability) Format(ctx *FmtCtx) {
+	switch d {
+	case DeferrableInitiallyImmediate:
+		ctx.WriteString(" DEFERRABLE")
+	case DeferrableInitiallyDeferred:
+		ctx.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	}
+}
+
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name     Name
-	Table    TableName
-	FromCols NameList
-	ToCols   NameList
-	Actions  ReferenceActions
-	Match    CompositeKeyMatchMethod
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -698,6 +742,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(node.Deferrability)
 }
 
 // SetName implements the TableDef interface.
@@ -876,6 +921,7 @@ func (node *RangePartition) Format(ctx *FmtCtx) {
 // CreateTable represents a CREATE TABLE statement.
 type CreateTable struct {
 	IfNotExists   bool
+	Temporary     bool
 	Table         TableName
 	Interleave    *InterleaveDef
 	PartitionBy   *PartitionBy
@@ -892,7 +938,11 @@ func (node *CreateTable) As() bool {
 
 // Format implements the NodeFormatter interface.
 func (node *CreateTable) Format(ctx *FmtCtx) {
-	ctx.WriteString("CREATE TABLE ")
+	ctx.WriteString("CREATE ")
+	if node.Temporary {
+		ctx.WriteString("TEMPORARY ")
+	}
+	ctx.WriteString("TABLE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
 	}
@@ -1142,11 +1192,19 @@ type CreateView struct {
 	Name        TableName
 	ColumnNames NameList
 	AsSource    *Select
+	// Materialized is set for CREATE MATERIALIZED VIEW: the results of the
+	// query are stored like the rows of a table, and are only recomputed by
+	// REFRESH MATERIALIZED VIEW.
+	Materialized bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *CreateView) Format(ctx *FmtCtx) {
-	ctx.WriteString("CREATE VIEW ")
+	ctx.WriteString("CREATE ")
+	if node.Materialized {
+		ctx.WriteString("MATERIALIZED ")
+	}
+	ctx.WriteString("VIEW ")
 	ctx.FormatNode(&node.Name)
 
 	if len(node.ColumnNames) > 0 {
@@ -1160,6 +1218,328 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.AsSource)
 }
 
+// TriggerActionTime is the time at which a trigger fires relative to the
+// modification of the rows.
+type TriggerActionTime int
+
+// TriggerActionTime values.
+const (
+	TriggerBefore TriggerActionTime = iota
+	TriggerAfter
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerBefore: "BEFORE",
+	TriggerAfter:  "AFTER",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEvent is a kind of modification that fires a trigger.
+type TriggerEvent int
+
+// TriggerEvent values.
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+var triggerEventName = [...]string{
+	TriggerInsert: "INSERT",
+	TriggerUpdate: "UPDATE",
+	TriggerDelete: "DELETE",
+}
+
+func (e TriggerEvent) String() string {
+	return triggerEventName[e]
+}
+
+// TriggerEvents is a list of trigger events, as in INSERT OR UPDATE.
+type TriggerEvents []TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, e := range *node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(e.String())
+	}
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name       Name
+	Constraint bool
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      TableName
+	// ForEachRow is set for FOR EACH ROW; statement-level triggers are the
+	// default.
+	ForEachRow bool
+	FuncName   TableName
+	Args       []string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Constraint {
+		ctx.WriteString("CONSTRAINT ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.ForEachRow {
+		ctx.WriteString(" FOR EACH ROW")
+	} else {
+		ctx.WriteString(" FOR EACH STATEMENT")
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteByte('(')
+	for i, arg := range node.Args {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, arg, ctx.flags.EncodeFlags())
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateTypeVariety represents a particular variety of user-defined type.
+type CreateTypeVariety int
+
+const (
+	_ CreateTypeVariety = iota
+	// Enum represents an ENUM user-defined type.
+	Enum
+)
+
+// CreateType represents a CREATE TYPE statement.
+type CreateType struct {
+	TypeName TableName
+	Variety  CreateTypeVariety
+	// EnumLabels is set when this represents a CREATE TYPE ... AS ENUM
+	// statement.
+	EnumLabels []string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateType) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TYPE ")
+	ctx.FormatNode(&node.TypeName)
+	ctx.WriteString(" ")
+	switch node.Variety {
+	case Enum:
+		ctx.WriteString("AS ENUM (")
+		for i, label := range node.EnumLabels {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			lex.EncodeSQLStringWithFlags(&ctx.Buffer, label, ctx.flags.EncodeFlags())
+		}
+		ctx.WriteString(")")
+	}
+}
+
+// CreateSchema represents a CREATE SCHEMA statement.
+type CreateSchema struct {
+	IfNotExists bool
+	Schema      Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateSchema) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SCHEMA ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Schema)
+}
+
+// CreateFunction represents a CREATE FUNCTION statement.
+type CreateFunction struct {
+	Name       TableName
+	Replace    bool
+	Args       FuncArgs
+	ReturnType coltypes.T
+	// ReturnsTrigger is set for RETURNS TRIGGER, in which case ReturnType is
+	// nil.
+	ReturnsTrigger bool
+	Options        FunctionOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("FUNCTION ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Args)
+	ctx.WriteString(") RETURNS ")
+	if node.ReturnsTrigger {
+		ctx.WriteString("TRIGGER")
+	} else {
+		node.ReturnType.Format(&ctx.Buffer, ctx.flags.EncodeFlags())
+	}
+	ctx.FormatNode(&node.Options)
+}
+
+// FuncArg is a parameter in a CREATE FUNCTION statement. The name is
+// optional.
+type FuncArg struct {
+	Name Name
+	Type coltypes.T
+}
+
+// Format implements the NodeFormatter interface.
+func (node *FuncArg) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	node.Type.Format(&ctx.Buffer, ctx.flags.EncodeFlags())
+}
+
+// FuncArgs is a list of function parameters.
+type FuncArgs []FuncArg
+
+// Format implements the NodeFormatter interface.
+func (node *FuncArgs) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// FunctionVolatility is the volatility attribute of a user-defined function.
+type FunctionVolatility int
+
+// FunctionVolatility values.
+const (
+	// FunctionVolatilityUnspecified is the default; it behaves as
+	// FunctionVolatile.
+	FunctionVolatilityUnspecified FunctionVolatility = iota
+	FunctionVolatile
+	FunctionStable
+	FunctionImmutable
+)
+
+var functionVolatilityName = [...]string{
+	FunctionVolatile:  "VOLATILE",
+	FunctionStable:    "STABLE",
+	FunctionImmutable: "IMMUTABLE",
+}
+
+func (v FunctionVolatility) String() string {
+	return functionVolatilityName[v]
+}
+
+// FunctionNullInputBehavior indicates how a user-defined function behaves
+// when one of its arguments is NULL.
+type FunctionNullInputBehavior int
+
+// FunctionNullInputBehavior values.
+const (
+	// FunctionNullInputUnspecified is the default; it behaves as
+	// FunctionCalledOnNullInput.
+	FunctionNullInputUnspecified FunctionNullInputBehavior = iota
+	// FunctionCalledOnNullInput indicates that the body is evaluated even
+	// when some of the arguments are NULL.
+	FunctionCalledOnNullInput
+	// FunctionReturnsNullOnNullInput indicates that the function returns NULL
+	// without evaluating the body when any of the arguments is NULL.
+	FunctionReturnsNullOnNullInput
+	// FunctionStrict is a synonym for FunctionReturnsNullOnNullInput.
+	FunctionStrict
+)
+
+var functionNullInputName = [...]string{
+	FunctionCalledOnNullInput:      "CALLED ON NULL INPUT",
+	FunctionReturnsNullOnNullInput: "RETURNS NULL ON NULL INPUT",
+	FunctionStrict:                 "STRICT",
+}
+
+func (b FunctionNullInputBehavior) String() string {
+	return functionNullInputName[b]
+}
+
+// FunctionOptions holds the options that follow the signature in a CREATE
+// FUNCTION statement. Each option is unset if it has its zero value.
+type FunctionOptions struct {
+	Language   Name
+	Volatility FunctionVolatility
+	NullInput  FunctionNullInputBehavior
+	Body       *string
+}
+
+// Combine merges the options in other into o. It returns an error if an
+// option is specified twice.
+func (o *FunctionOptions) Combine(other FunctionOptions) error {
+	if other.Language != "" {
+		if o.Language != "" {
+			return errConflictingFunctionOptions
+		}
+		o.Language = other.Language
+	}
+	if other.Volatility != FunctionVolatilityUnspecified {
+		if o.Volatility != FunctionVolatilityUnspecified {
+			return errConflictingFunctionOptions
+		}
+		o.Volatility = other.Volatility
+	}
+	if other.NullInput != FunctionNullInputUnspecified {
+		if o.NullInput != FunctionNullInputUnspecified {
+			return errConflictingFunctionOptions
+		}
+		o.NullInput = other.NullInput
+	}
+	if other.Body != nil {
+		if o.Body != nil {
+			return errConflictingFunctionOptions
+		}
+		o.Body = other.Body
+	}
+	return nil
+}
+
+var errConflictingFunctionOptions = pgerror.NewError(
+	pgerror.CodeSyntaxError, "conflicting or redundant options")
+
+// Format implements the NodeFormatter interface.
+func (o *FunctionOptions) Format(ctx *FmtCtx) {
+	if o.Language != "" {
+		ctx.WriteString(" LANGUAGE ")
+		ctx.FormatNode(&o.Language)
+	}
+	if o.Volatility != FunctionVolatilityUnspecified {
+		ctx.WriteByte(' ')
+		ctx.WriteString(o.Volatility.String())
+	}
+	if o.NullInput != FunctionNullInputUnspecified {
+		ctx.WriteByte(' ')
+		ctx.WriteString(o.NullInput.String())
+	}
+	if o.Body != nil {
+		ctx.WriteString(" AS ")
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, *o.Body, ctx.flags.EncodeFlags())
+	}
+}
+
 // CreateStats represents a CREATE STATISTICS statement.
 type CreateStats struct {
 	Name        Name
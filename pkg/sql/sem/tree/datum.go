@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/lib/pq/oid"
@@ -1254,6 +1255,144 @@ func (d *DCollatedString) IsComposite() bool {
 	return true
 }
 
+// DEnum is the Datum for values of user-defined enum types. The struct members
+// are intended to be immutable.
+type DEnum struct {
+	EnumTyp types.TEnum
+	// PhysicalRep is the encoded value, which determines the sort order of the
+	// value. It is the representation stored on disk.
+	PhysicalRep []byte
+	// LogicalRep is the label of the value.
+	LogicalRep string
+}
+
+// MakeDEnumFromPhysicalRepresentation creates a DEnum of the given type from
+// its encoded value.
+func MakeDEnumFromPhysicalRepresentation(typ types.TEnum, rep []byte) (*DEnum, error) {
+	for i := range typ.PhysicalReps {
+		if bytes.Equal(typ.PhysicalReps[i], rep) {
+			return &DEnum{EnumTyp: typ, PhysicalRep: typ.PhysicalReps[i], LogicalRep: typ.LogicalReps[i]}, nil
+		}
+	}
+	return nil, pgerror.NewAssertionErrorf("could not find %v in enum type %s", rep, typ)
+}
+
+// MakeDEnumFromLogicalRepresentation creates a DEnum of the given type from
+// its label. Read-only values cannot be created this way.
+func MakeDEnumFromLogicalRepresentation(typ types.TEnum, rep string) (*DEnum, error) {
+	for i := range typ.LogicalReps {
+		if typ.LogicalReps[i] == rep {
+			if typ.IsMemberReadOnly != nil && typ.IsMemberReadOnly[i] {
+				return nil, pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+					"enum value %q is not yet public", rep)
+			}
+			return &DEnum{EnumTyp: typ, PhysicalRep: typ.PhysicalReps[i], LogicalRep: typ.LogicalReps[i]}, nil
+		}
+	}
+	return nil, pgerror.NewErrorf(pgerror.CodeInvalidTextRepresentationError,
+		"invalid input value for enum %s: %q", typ, rep)
+}
+
+// AmbiguousFormat implements the Datum interface. An enum value is formatted
+// as its label, which can only be typed as an enum value in a context which
+// expects the enum type.
+func (*DEnum) AmbiguousFormat() bool { return false }
+
+// Format implements the NodeFormatter interface.
+func (d *DEnum) Format(ctx *FmtCtx) {
+	buf, f := &ctx.Buffer, ctx.flags
+	if f.HasFlags(fmtRawStrings) {
+		buf.WriteString(d.LogicalRep)
+	} else {
+		lex.EncodeSQLStringWithFlags(buf, d.LogicalRep, f.EncodeFlags())
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DEnum) ResolvedType() types.T {
+	return d.EnumTyp
+}
+
+// Compare implements the Datum interface.
+func (d *DEnum) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DEnum)
+	if !ok || d.EnumTyp.TypeID != v.EnumTyp.TypeID {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	return bytes.Compare(d.PhysicalRep, v.PhysicalRep)
+}
+
+// ordinal returns the position of the value in the list of values of its
+// type.
+func (d *DEnum) ordinal() int {
+	for i := range d.EnumTyp.PhysicalReps {
+		if bytes.Equal(d.EnumTyp.PhysicalReps[i], d.PhysicalRep) {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("could not find %s in enum type %s", d.LogicalRep, d.EnumTyp))
+}
+
+// enumValueAt returns the value of the type of the receiver at the given
+// position.
+func (d *DEnum) enumValueAt(i int) *DEnum {
+	return &DEnum{EnumTyp: d.EnumTyp, PhysicalRep: d.EnumTyp.PhysicalReps[i], LogicalRep: d.EnumTyp.LogicalReps[i]}
+}
+
+// Prev implements the Datum interface.
+func (d *DEnum) Prev(_ *EvalContext) (Datum, bool) {
+	i := d.ordinal()
+	if i == 0 {
+		return nil, false
+	}
+	return d.enumValueAt(i - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DEnum) Next(_ *EvalContext) (Datum, bool) {
+	i := d.ordinal()
+	if i == len(d.EnumTyp.PhysicalReps)-1 {
+		return nil, false
+	}
+	return d.enumValueAt(i + 1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DEnum) IsMax(_ *EvalContext) bool {
+	return d.ordinal() == len(d.EnumTyp.PhysicalReps)-1
+}
+
+// IsMin implements the Datum interface.
+func (d *DEnum) IsMin(_ *EvalContext) bool {
+	return d.ordinal() == 0
+}
+
+// Min implements the Datum interface.
+func (d *DEnum) Min(_ *EvalContext) (Datum, bool) {
+	if len(d.EnumTyp.PhysicalReps) == 0 {
+		return nil, false
+	}
+	return d.enumValueAt(0), true
+}
+
+// Max implements the Datum interface.
+func (d *DEnum) Max(_ *EvalContext) (Datum, bool) {
+	if len(d.EnumTyp.PhysicalReps) == 0 {
+		return nil, false
+	}
+	return d.enumValueAt(len(d.EnumTyp.PhysicalReps) - 1), true
+}
+
+// Size implements the Datum interface. The type of the value is shared
+// between all the values of the type, so it is not accounted for.
+func (d *DEnum) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.PhysicalRep)) + uintptr(len(d.LogicalRep))
+}
+
 // DBytes is the bytes Datum. The underlying type is a string because we want
 // the immutability, but this may contain arbitrary bytes.
 type DBytes string
@@ -1877,6 +2016,204 @@ func (d *DTime) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// Round returns a new DTime rounded to the given precision. A value which
+// would round up to midnight of the next day is truncated instead.
+func (d *DTime) Round(precision time.Duration) *DTime {
+	return MakeDTime(timeofday.TimeOfDay(*d).Round(precision))
+}
+
+// DTimeTZ is the time with time zone Datum. It holds the local time of day
+// along with the offset of its time zone from UTC.
+type DTimeTZ struct {
+	timeofday.TimeOfDay
+	// OffsetSecs is the offset of the time zone, in seconds east of UTC.
+	OffsetSecs int32
+}
+
+// MakeDTimeTZ creates a DTimeTZ from a TimeOfDay and the offset of its time
+// zone, in seconds east of UTC.
+func MakeDTimeTZ(t timeofday.TimeOfDay, offsetSecs int32) *DTimeTZ {
+	return &DTimeTZ{TimeOfDay: t, OffsetSecs: offsetSecs}
+}
+
+// MakeDTimeTZFromTime creates a DTimeTZ from the time of day and the time zone
+// offset of a time.Time.
+func MakeDTimeTZFromTime(t time.Time) *DTimeTZ {
+	_, offset := t.Zone()
+	return MakeDTimeTZ(timeofday.FromTime(t), int32(offset))
+}
+
+// ParseDTimeTZ parses and returns the *DTimeTZ Datum value represented by the
+// provided string, or an error if parsing is unsuccessful. If the string does
+// not specify a time zone, the offset of the session time zone at the current
+// date is used.
+func ParseDTimeTZ(ctx ParseTimeContext, s string) (*DTimeTZ, error) {
+	now := relativeParseTime(ctx)
+	// The parser resolves named time zones using a dummy date; use the offset
+	// in effect now instead, like Postgres does.
+	_, offset := now.Zone()
+	now = now.In(time.FixedZone("", offset))
+	t, err := pgdate.ParseTime(now, 0 /* mode */, s)
+	if err != nil {
+		// Build our own error message to avoid exposing the dummy date.
+		return nil, makeParseError(s, types.TimeTZ, nil)
+	}
+	return MakeDTimeTZFromTime(t), nil
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTimeTZ) ResolvedType() types.T {
+	return types.TimeTZ
+}
+
+// UTCMicros returns the time of day of d in UTC, in microseconds. The result
+// is not normalized into a single day.
+func (d *DTimeTZ) UTCMicros() int64 {
+	return int64(d.TimeOfDay) - int64(d.OffsetSecs)*int64(time.Second/time.Microsecond)
+}
+
+// Compare implements the Datum interface.
+func (d *DTimeTZ) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTimeTZ)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	// Like Postgres, compare the times in UTC first, then order times in
+	// zones further east of UTC first.
+	if l, r := d.UTCMicros(), v.UTCMicros(); l < r {
+		return -1
+	} else if l > r {
+		return 1
+	}
+	if d.OffsetSecs > v.OffsetSecs {
+		return -1
+	} else if d.OffsetSecs < v.OffsetSecs {
+		return 1
+	}
+	return 0
+}
+
+// Prev implements the Datum interface.
+func (d *DTimeTZ) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTimeTZ) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTimeTZ) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTimeTZ) IsMin(_ *EvalContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTimeTZ) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTimeTZ) Min(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTimeTZ) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTimeTZ) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lex.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.TimeOfDay.String())
+	formatZoneOffset(&ctx.Buffer, d.OffsetSecs)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// formatZoneOffset writes a time zone offset like Postgres does: the hours
+// are always written, and the minutes and seconds only when non-zero.
+func formatZoneOffset(buf *bytes.Buffer, offsetSecs int32) {
+	if offsetSecs < 0 {
+		buf.WriteByte('-')
+		offsetSecs = -offsetSecs
+	} else {
+		buf.WriteByte('+')
+	}
+	hours, mins, secs := offsetSecs/3600, (offsetSecs/60)%60, offsetSecs%60
+	fmt.Fprintf(buf, "%02d", hours)
+	if mins != 0 || secs != 0 {
+		fmt.Fprintf(buf, ":%02d", mins)
+	}
+	if secs != 0 {
+		fmt.Fprintf(buf, ":%02d", secs)
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTimeTZ) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// Round returns a new DTimeTZ rounded to the given precision. A value which
+// would round up to midnight of the next day is truncated instead.
+func (d *DTimeTZ) Round(precision time.Duration) *DTimeTZ {
+	return MakeDTimeTZ(d.TimeOfDay.Round(precision), d.OffsetSecs)
+}
+
+// makeDTimestampTZFromDateAndTimeTZ returns the DTimestampTZ at the time of
+// day and in the time zone of t on the date d.
+func makeDTimestampTZFromDateAndTimeTZ(d *DDate, t *DTimeTZ) *DTimestampTZ {
+	year, month, day := timeutil.Unix(int64(*d)*SecondsInDay, 0).Date()
+	loc := time.FixedZone("", int(t.OffsetSecs))
+	return MakeDTimestampTZ(time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(),
+		t.Microsecond()*int(time.Microsecond), loc), time.Microsecond)
+}
+
+// TimePrecisionToRoundDuration returns the duration to which the values of a
+// TIME, TIMETZ, TIMESTAMP, TIMESTAMPTZ or INTERVAL type of the given
+// precision are rounded.
+func TimePrecisionToRoundDuration(precision int) time.Duration {
+	d := time.Second
+	for i := 0; i < precision && d > time.Microsecond; i++ {
+		d /= 10
+	}
+	return d
+}
+
+// RoundDatumToTimePrecision rounds the seconds of a DTime, DTimeTZ,
+// DTimestamp, DTimestampTZ or DInterval to the given number of fractional
+// digits. Other datums are returned unchanged.
+func RoundDatumToTimePrecision(d Datum, precision int) Datum {
+	roundTo := TimePrecisionToRoundDuration(precision)
+	switch t := d.(type) {
+	case *DTime:
+		return t.Round(roundTo)
+	case *DTimeTZ:
+		return t.Round(roundTo)
+	case *DTimestamp:
+		return MakeDTimestamp(t.Time, roundTo)
+	case *DTimestampTZ:
+		return MakeDTimestampTZ(t.Time, roundTo)
+	case *DInterval:
+		return t.Round(roundTo)
+	}
+	return d
+}
+
 // DTimestamp is the timestamp Datum.
 type DTimestamp struct {
 	time.Time
@@ -2327,6 +2664,14 @@ func (d *DInterval) Min(_ *EvalContext) (Datum, bool) {
 	return dMinInterval, true
 }
 
+// Round returns a new DInterval whose seconds are rounded to the given
+// precision.
+func (d *DInterval) Round(precision time.Duration) *DInterval {
+	ret := &DInterval{Duration: d.Duration}
+	ret.SetNanos(time.Duration(d.Nanos()).Round(precision).Nanoseconds())
+	return ret
+}
+
 // ValueAsString returns the interval as a string (e.g. "1h2m").
 func (d *DInterval) ValueAsString() string {
 	return d.Duration.String()
@@ -2425,6 +2770,8 @@ func AsJSON(d Datum) (json.JSON, error) {
 		return json.FromString(string(*t)), nil
 	case *DCollatedString:
 		return json.FromString(t.Contents), nil
+	case *DEnum:
+		return json.FromString(t.LogicalRep), nil
 	case *DJSON:
 		return t.JSON, nil
 	case *DArray:
@@ -2456,8 +2803,12 @@ func AsJSON(d Datum) (json.JSON, error) {
 	case *DTimestamp:
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(t.UTC().Format("2006-01-02T15:04:05.999999999")), nil
-	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DBitArray:
+	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings)), nil
+	case *DTSVector:
+		return json.FromString(t.TSVector.String()), nil
+	case *DTSQuery:
+		return json.FromString(t.TSQuery.String()), nil
 	default:
 		if d == DNull {
 			return json.NullJSONValue, nil
@@ -2543,6 +2894,224 @@ func (d *DJSON) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.JSON.Size()
 }
 
+// DTSVector is the tsvector Datum: a document preprocessed for text search.
+type DTSVector struct{ tsearch.TSVector }
+
+// NewDTSVector is a helper routine to create a DTSVector initialized from its
+// argument.
+func NewDTSVector(v tsearch.TSVector) *DTSVector {
+	return &DTSVector{v}
+}
+
+// ParseDTSVector takes the text format of a tsvector and returns a DTSVector
+// value.
+func ParseDTSVector(s string) (*DTSVector, error) {
+	v, err := tsearch.ParseTSVector(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDTSVector(v), nil
+}
+
+// AsDTSVector attempts to retrieve a *DTSVector from an Expr, returning a
+// *DTSVector and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSVector wrapped by a *DOidWrapper is possible.
+func AsDTSVector(e Expr) (*DTSVector, bool) {
+	switch t := e.(type) {
+	case *DTSVector:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSVector(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSVector attempts to retrieve a DTSVector from an Expr, panicking if
+// the assertion fails.
+func MustBeDTSVector(e Expr) DTSVector {
+	v, ok := AsDTSVector(e)
+	if !ok {
+		panic(pgerror.NewAssertionErrorf("expected *DTSVector, found %T", e))
+	}
+	return *v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSVector) ResolvedType() types.T {
+	return types.TSVector
+}
+
+// Compare implements the Datum interface.
+func (d *DTSVector) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTSVector)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	return d.TSVector.Compare(v.TSVector)
+}
+
+// Prev implements the Datum interface.
+func (d *DTSVector) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSVector) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSVector) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSVector) IsMin(_ *EvalContext) bool {
+	return len(d.TSVector) == 0
+}
+
+// Max implements the Datum interface.
+func (d *DTSVector) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSVector) Min(_ *EvalContext) (Datum, bool) {
+	return &DTSVector{}, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSVector) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSVector) Format(ctx *FmtCtx) {
+	s := d.TSVector.String()
+	if ctx.flags.HasFlags(fmtRawStrings) {
+		ctx.WriteString(s)
+	} else {
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTSVector) Size() uintptr {
+	return d.TSVector.Size()
+}
+
+// DTSQuery is the tsquery Datum: a text search query.
+type DTSQuery struct{ tsearch.TSQuery }
+
+// NewDTSQuery is a helper routine to create a DTSQuery initialized from its
+// argument.
+func NewDTSQuery(q tsearch.TSQuery) *DTSQuery {
+	return &DTSQuery{q}
+}
+
+// ParseDTSQuery takes the text format of a tsquery and returns a DTSQuery
+// value.
+func ParseDTSQuery(s string) (*DTSQuery, error) {
+	q, err := tsearch.ParseTSQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDTSQuery(q), nil
+}
+
+// AsDTSQuery attempts to retrieve a *DTSQuery from an Expr, returning a
+// *DTSQuery and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSQuery wrapped by a *DOidWrapper is possible.
+func AsDTSQuery(e Expr) (*DTSQuery, bool) {
+	switch t := e.(type) {
+	case *DTSQuery:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSQuery(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSQuery attempts to retrieve a DTSQuery from an Expr, panicking if
+// the assertion fails.
+func MustBeDTSQuery(e Expr) DTSQuery {
+	q, ok := AsDTSQuery(e)
+	if !ok {
+		panic(pgerror.NewAssertionErrorf("expected *DTSQuery, found %T", e))
+	}
+	return *q
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSQuery) ResolvedType() types.T {
+	return types.TSQuery
+}
+
+// Compare implements the Datum interface.
+func (d *DTSQuery) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTSQuery)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	return d.TSQuery.Compare(v.TSQuery)
+}
+
+// Prev implements the Datum interface.
+func (d *DTSQuery) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSQuery) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSQuery) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSQuery) IsMin(_ *EvalContext) bool {
+	return d.Root == nil
+}
+
+// Max implements the Datum interface.
+func (d *DTSQuery) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSQuery) Min(_ *EvalContext) (Datum, bool) {
+	return &DTSQuery{}, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSQuery) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSQuery) Format(ctx *FmtCtx) {
+	s := d.TSQuery.String()
+	if ctx.flags.HasFlags(fmtRawStrings) {
+		ctx.WriteString(s)
+	} else {
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTSQuery) Size() uintptr {
+	return d.TSQuery.Size()
+}
+
 // DTuple is the tuple Datum.
 type DTuple struct {
 	D Datums
@@ -3511,6 +4080,9 @@ func DatumTypeSize(t types.T) (uintptr, bool) {
 	case types.TCollatedString:
 		return unsafe.Sizeof(DCollatedString{"", "", nil}), variableSize
 
+	case types.TEnum:
+		return unsafe.Sizeof(DEnum{}), variableSize
+
 	case types.TTuple:
 		sz := uintptr(0)
 		variable := false
@@ -3553,10 +4125,13 @@ var baseDatumTypeSizes = map[types.T]struct {
 	types.Bytes:       {unsafe.Sizeof(DBytes("")), variableSize},
 	types.Date:        {unsafe.Sizeof(DDate(0)), fixedSize},
 	types.Time:        {unsafe.Sizeof(DTime(0)), fixedSize},
+	types.TimeTZ:      {unsafe.Sizeof(DTimeTZ{}), fixedSize},
 	types.Timestamp:   {unsafe.Sizeof(DTimestamp{}), fixedSize},
 	types.TimestampTZ: {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.Interval:    {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JSON:        {unsafe.Sizeof(DJSON{}), variableSize},
+	types.TSVector:    {unsafe.Sizeof(DTSVector{}), variableSize},
+	types.TSQuery:     {unsafe.Sizeof(DTSQuery{}), variableSize},
 	types.UUID:        {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INet:        {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	// TODO(jordan,justin): This seems suspicious.
@@ -23,6 +23,8 @@
 
 package tree
 
+import "github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+
 // DropBehavior represents options for dropping schema elements.
 type DropBehavior int
 
@@ -105,14 +107,39 @@ func (node *DropTable) Format(ctx *FmtCtx) {
 
 // DropView represents a DROP VIEW statement.
 type DropView struct {
+	Names          TableNames
+	IfExists       bool
+	DropBehavior   DropBehavior
+	IsMaterialized bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropView) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP ")
+	if node.IsMaterialized {
+		ctx.WriteString("MATERIALIZED ")
+	}
+	ctx.WriteString("VIEW ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropType represents a DROP TYPE statement.
+type DropType struct {
 	Names        TableNames
 	IfExists     bool
 	DropBehavior DropBehavior
 }
 
 // Format implements the NodeFormatter interface.
-func (node *DropView) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP VIEW ")
+func (node *DropType) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TYPE ")
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -123,6 +150,107 @@ func (node *DropView) Format(ctx *FmtCtx) {
 	}
 }
 
+// DropSchema represents a DROP SCHEMA statement.
+type DropSchema struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropSchema) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP SCHEMA ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropFunction represents a DROP FUNCTION statement.
+type DropFunction struct {
+	Functions    FuncRefs
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP FUNCTION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Functions)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	Name         Name
+	Table        TableName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// FuncRef refers to a user-defined function by name, optionally followed by
+// the types of its parameters.
+type FuncRef struct {
+	Name TableName
+	// HasArgTypes is set if the argument list was specified, even if it is
+	// empty.
+	HasArgTypes bool
+	ArgTypes    []coltypes.T
+}
+
+// Format implements the NodeFormatter interface.
+func (node *FuncRef) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Name)
+	if node.HasArgTypes {
+		ctx.WriteByte('(')
+		for i, typ := range node.ArgTypes {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			typ.Format(&ctx.Buffer, ctx.flags.EncodeFlags())
+		}
+		ctx.WriteByte(')')
+	}
+}
+
+// FuncRefs is a list of function references.
+type FuncRefs []FuncRef
+
+// Format implements the NodeFormatter interface.
+func (node *FuncRefs) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
 // DropSequence represents a DROP SEQUENCE statement.
 type DropSequence struct {
 	Names        TableNames
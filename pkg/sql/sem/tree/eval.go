@@ -44,6 +44,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/lib/pq/oid"
 	"github.com/pkg/errors"
@@ -573,6 +574,40 @@ var BinOps = map[BinaryOperator]binOpOverload{
 				return MakeDTime(t.Add(left.(*DInterval).Duration)), nil
 			},
 		},
+		&BinOp{
+			LeftType:   types.Date,
+			RightType:  types.TimeTZ,
+			ReturnType: types.TimestampTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return makeDTimestampTZFromDateAndTimeTZ(left.(*DDate), right.(*DTimeTZ)), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Date,
+			ReturnType: types.TimestampTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return makeDTimestampTZFromDateAndTimeTZ(right.(*DDate), left.(*DTimeTZ)), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Interval,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := left.(*DTimeTZ)
+				return MakeDTimeTZ(t.TimeOfDay.Add(right.(*DInterval).Duration), t.OffsetSecs), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.Interval,
+			RightType:  types.TimeTZ,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := right.(*DTimeTZ)
+				return MakeDTimeTZ(t.TimeOfDay.Add(left.(*DInterval).Duration), t.OffsetSecs), nil
+			},
+		},
 		&BinOp{
 			LeftType:   types.Timestamp,
 			RightType:  types.Interval,
@@ -809,6 +844,15 @@ var BinOps = map[BinaryOperator]binOpOverload{
 				return MakeDTime(t.Add(right.(*DInterval).Duration.Mul(-1))), nil
 			},
 		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Interval,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := left.(*DTimeTZ)
+				return MakeDTimeTZ(t.TimeOfDay.Add(right.(*DInterval).Duration.Mul(-1)), t.OffsetSecs), nil
+			},
+		},
 		&BinOp{
 			LeftType:   types.Timestamp,
 			RightType:  types.Interval,
@@ -1696,6 +1740,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEqFn(types.Date, types.Date),
 		makeEqFn(types.Decimal, types.Decimal),
 		makeEqFn(types.FamCollatedString, types.FamCollatedString),
+		makeEqFn(types.FamEnum, types.FamEnum),
 		makeEqFn(types.Float, types.Float),
 		makeEqFn(types.INet, types.INet),
 		makeEqFn(types.Int, types.Int),
@@ -1704,8 +1749,11 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEqFn(types.Oid, types.Oid),
 		makeEqFn(types.String, types.String),
 		makeEqFn(types.Time, types.Time),
+		makeEqFn(types.TimeTZ, types.TimeTZ),
 		makeEqFn(types.Timestamp, types.Timestamp),
 		makeEqFn(types.TimestampTZ, types.TimestampTZ),
+		makeEqFn(types.TSQuery, types.TSQuery),
+		makeEqFn(types.TSVector, types.TSVector),
 		makeEqFn(types.UUID, types.UUID),
 		makeEqFn(types.BitArray, types.BitArray),
 
@@ -1740,6 +1788,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLtFn(types.Date, types.Date),
 		makeLtFn(types.Decimal, types.Decimal),
 		makeLtFn(types.FamCollatedString, types.FamCollatedString),
+		makeLtFn(types.FamEnum, types.FamEnum),
 		makeLtFn(types.Float, types.Float),
 		makeLtFn(types.INet, types.INet),
 		makeLtFn(types.Int, types.Int),
@@ -1747,6 +1796,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLtFn(types.Oid, types.Oid),
 		makeLtFn(types.String, types.String),
 		makeLtFn(types.Time, types.Time),
+		makeLtFn(types.TimeTZ, types.TimeTZ),
 		makeLtFn(types.Timestamp, types.Timestamp),
 		makeLtFn(types.TimestampTZ, types.TimestampTZ),
 		makeLtFn(types.UUID, types.UUID),
@@ -1783,6 +1833,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLeFn(types.Date, types.Date),
 		makeLeFn(types.Decimal, types.Decimal),
 		makeLeFn(types.FamCollatedString, types.FamCollatedString),
+		makeLeFn(types.FamEnum, types.FamEnum),
 		makeLeFn(types.Float, types.Float),
 		makeLeFn(types.INet, types.INet),
 		makeLeFn(types.Int, types.Int),
@@ -1790,6 +1841,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLeFn(types.Oid, types.Oid),
 		makeLeFn(types.String, types.String),
 		makeLeFn(types.Time, types.Time),
+		makeLeFn(types.TimeTZ, types.TimeTZ),
 		makeLeFn(types.Timestamp, types.Timestamp),
 		makeLeFn(types.TimestampTZ, types.TimestampTZ),
 		makeLeFn(types.UUID, types.UUID),
@@ -1834,6 +1886,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeIsFn(types.Date, types.Date),
 		makeIsFn(types.Decimal, types.Decimal),
 		makeIsFn(types.FamCollatedString, types.FamCollatedString),
+		makeIsFn(types.FamEnum, types.FamEnum),
 		makeIsFn(types.Float, types.Float),
 		makeIsFn(types.INet, types.INet),
 		makeIsFn(types.Int, types.Int),
@@ -1842,8 +1895,11 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeIsFn(types.Oid, types.Oid),
 		makeIsFn(types.String, types.String),
 		makeIsFn(types.Time, types.Time),
+		makeIsFn(types.TimeTZ, types.TimeTZ),
 		makeIsFn(types.Timestamp, types.Timestamp),
 		makeIsFn(types.TimestampTZ, types.TimestampTZ),
+		makeIsFn(types.TSQuery, types.TSQuery),
+		makeIsFn(types.TSVector, types.TSVector),
 		makeIsFn(types.UUID, types.UUID),
 		makeIsFn(types.BitArray, types.BitArray),
 
@@ -1881,6 +1937,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEvalTupleIn(types.Date),
 		makeEvalTupleIn(types.Decimal),
 		makeEvalTupleIn(types.FamCollatedString),
+		makeEvalTupleIn(types.FamEnum),
 		makeEvalTupleIn(types.FamTuple),
 		makeEvalTupleIn(types.Float),
 		makeEvalTupleIn(types.INet),
@@ -1890,8 +1947,11 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEvalTupleIn(types.Oid),
 		makeEvalTupleIn(types.String),
 		makeEvalTupleIn(types.Time),
+		makeEvalTupleIn(types.TimeTZ),
 		makeEvalTupleIn(types.Timestamp),
 		makeEvalTupleIn(types.TimestampTZ),
+		makeEvalTupleIn(types.TSQuery),
+		makeEvalTupleIn(types.TSVector),
 		makeEvalTupleIn(types.UUID),
 		makeEvalTupleIn(types.BitArray),
 	},
@@ -2039,6 +2099,23 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 			},
 		},
 	},
+
+	TSMatches: {
+		&CmpOp{
+			LeftType:  types.TSVector,
+			RightType: types.TSQuery,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(tsearch.Match(left.(*DTSVector).TSVector, right.(*DTSQuery).TSQuery))), nil
+			},
+		},
+		&CmpOp{
+			LeftType:  types.TSQuery,
+			RightType: types.TSVector,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(tsearch.Match(right.(*DTSVector).TSVector, left.(*DTSQuery).TSQuery))), nil
+			},
+		},
+	},
 }
 
 // This map contains the inverses for operators in the CmpOps map that have
@@ -2884,6 +2961,18 @@ func (expr *CastExpr) Eval(ctx *EvalContext) (Datum, error) {
 // PerformCast performs a cast from the provided Datum to the specified
 // CastTargetType.
 func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, error) {
+	res, err := performCast(ctx, d, t)
+	if err != nil {
+		return nil, err
+	}
+	// Casts to a time type with a precision round the result.
+	if precision, ok := coltypes.TimePrecision(t); ok {
+		return RoundDatumToTimePrecision(res, precision), nil
+	}
+	return res, nil
+}
+
+func performCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, error) {
 	switch typ := t.(type) {
 	case *coltypes.TBitArray:
 		switch v := d.(type) {
@@ -3096,7 +3185,7 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 				ctx.SessionData.DataConversion.GetFloatPrec(), 64)
 		case *DBool, *DInt, *DDecimal:
 			s = d.String()
-		case *DTimestamp, *DTimestampTZ, *DDate, *DTime:
+		case *DTimestamp, *DTimestampTZ, *DDate, *DTime, *DTimeTZ:
 			s = AsStringWithFlags(d, FmtBareStrings)
 		case *DTuple:
 			s = AsStringWithFlags(d, FmtPgwireText)
@@ -3115,6 +3204,8 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			s = string(*t)
 		case *DCollatedString:
 			s = t.Contents
+		case *DEnum:
+			s = t.LogicalRep
 		case *DBytes:
 			s = lex.EncodeByteArrayToRawBytes(string(*t),
 				ctx.SessionData.DataConversion.BytesEncodeFormat, false /* skipHexPrefix */)
@@ -3122,6 +3213,10 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			s = t.name
 		case *DJSON:
 			s = t.JSON.String()
+		case *DTSVector:
+			s = t.TSVector.String()
+		case *DTSQuery:
+			s = t.TSQuery.String()
 		}
 		switch c := t.(type) {
 		case *coltypes.TString:
@@ -3200,6 +3295,8 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			return ParseDTime(ctx, d.Contents)
 		case *DTime:
 			return d, nil
+		case *DTimeTZ:
+			return MakeDTime(d.TimeOfDay), nil
 		case *DTimestamp:
 			return MakeDTime(timeofday.FromTime(d.Time)), nil
 		case *DTimestampTZ:
@@ -3208,6 +3305,23 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			return MakeDTime(timeofday.Min.Add(d.Duration)), nil
 		}
 
+	case *coltypes.TTimeTZ:
+		switch d := d.(type) {
+		case *DString:
+			return ParseDTimeTZ(ctx, string(*d))
+		case *DCollatedString:
+			return ParseDTimeTZ(ctx, d.Contents)
+		case *DTime:
+			// Like Postgres, use the offset of the session time zone at the
+			// current date.
+			_, offset := ctx.GetRelativeParseTime().Zone()
+			return MakeDTimeTZ(timeofday.TimeOfDay(*d), int32(offset)), nil
+		case *DTimeTZ:
+			return d, nil
+		case *DTimestampTZ:
+			return MakeDTimeTZFromTime(d.Time.In(ctx.GetLocation())), nil
+		}
+
 	case *coltypes.TTimestamp:
 		// TODO(knz): Timestamp from float, decimal.
 		switch d := d.(type) {
@@ -3286,6 +3400,20 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 		case *DJSON:
 			return v, nil
 		}
+	case *coltypes.TTSVector:
+		switch v := d.(type) {
+		case *DString:
+			return ParseDTSVector(string(*v))
+		case *DTSVector:
+			return v, nil
+		}
+	case *coltypes.TTSQuery:
+		switch v := d.(type) {
+		case *DString:
+			return ParseDTSQuery(string(*v))
+		case *DTSQuery:
+			return v, nil
+		}
 	case *coltypes.TArray:
 		switch v := d.(type) {
 		case *DString:
@@ -3309,6 +3437,22 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			}
 			return dcast, nil
 		}
+	case *coltypes.TUserDefined:
+		enumTyp, ok := typ.Typ.(types.TEnum)
+		if !ok {
+			break
+		}
+		switch v := d.(type) {
+		case *DString:
+			return MakeDEnumFromLogicalRepresentation(enumTyp, string(*v))
+		case *DCollatedString:
+			return MakeDEnumFromLogicalRepresentation(enumTyp, v.Contents)
+		case *DEnum:
+			if v.EnumTyp.TypeID == enumTyp.TypeID {
+				return d, nil
+			}
+		}
+
 	case *coltypes.TOid:
 		switch v := d.(type) {
 		case *DOid:
@@ -3381,7 +3525,7 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 				return queryOid(ctx, typ, NewDString(funcDef.Name))
 			case coltypes.RegType:
 				colType, err := ctx.Planner.ParseType(s)
-				if err == nil {
+				if err == nil && !isUnresolvedTypeReference(colType) {
 					datumType := coltypes.CastTargetToDatumType(colType)
 					return &DOid{semanticType: typ, DInt: DInt(datumType.Oid()), name: datumType.SQLName()}, nil
 				}
@@ -3907,6 +4051,11 @@ func (t *DTime) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DTimeTZ) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DFloat) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -3932,6 +4081,16 @@ func (t *DJSON) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DTSVector) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
+// Eval implements the TypedExpr interface.
+func (t *DTSQuery) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t dNull) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -3947,6 +4106,11 @@ func (t *DCollatedString) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DEnum) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DTimestamp) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
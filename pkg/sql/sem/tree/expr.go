@@ -316,6 +316,7 @@ const (
 	JSONExists
 	JSONSomeExists
 	JSONAllExists
+	TSMatches
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -365,6 +366,7 @@ var comparisonOpName = [...]string{
 	JSONExists:        "?",
 	JSONSomeExists:    "?|",
 	JSONAllExists:     "?&",
+	TSMatches:         "@@",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -1222,6 +1224,12 @@ type FuncExpr struct {
 	Filter    Expr
 	WindowDef *WindowDef
 
+	// AggType is used to specify the type of aggregation.
+	AggType AggType
+	// OrderBy is used for ordered-set aggregates:
+	// percentile_disc(0.5) WITHIN GROUP (ORDER BY k)
+	OrderBy OrderBy
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1328,6 +1336,21 @@ var funcTypeName = [...]string{
 	AllFuncType:      "ALL",
 }
 
+// AggType specifies the type of aggregation.
+type AggType int
+
+// FuncExpr.AggType
+const (
+	// GeneralAgg is used for general-purpose aggregate functions and for
+	// function calls that are not aggregations.
+	GeneralAgg AggType = iota
+	// OrderedSetAgg is used for ordered-set and hypothetical-set aggregate
+	// functions, which receive the values to aggregate through a WITHIN GROUP
+	// clause.
+	// percentile_disc(0.50) WITHIN GROUP (ORDER BY col1)
+	OrderedSetAgg
+)
+
 // Format implements the NodeFormatter interface.
 func (node *FuncExpr) Format(ctx *FmtCtx) {
 	var typ string
@@ -1345,6 +1368,11 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 	ctx.WriteString(typ)
 	ctx.FormatNode(&node.Exprs)
 	ctx.WriteByte(')')
+	if node.AggType == OrderedSetAgg {
+		ctx.WriteString(" WITHIN GROUP (")
+		ctx.FormatNode(&node.OrderBy)
+		ctx.WriteByte(')')
+	}
 	if ctx.HasFlags(FmtParsable) && node.typ != nil {
 		if node.fnProps.AmbiguousReturnType {
 			if typ, err := coltypes.DatumTypeToColumnType(node.typ); err == nil {
@@ -1500,13 +1528,15 @@ var (
 	decimalCastTypes = []types.T{types.Unknown, types.Bool, types.Int, types.Float, types.Decimal, types.String, types.FamCollatedString,
 		types.Timestamp, types.TimestampTZ, types.Date, types.Interval}
 	stringCastTypes = []types.T{types.Unknown, types.Bool, types.Int, types.Float, types.Decimal, types.String, types.FamCollatedString,
-		types.BitArray,
+		types.BitArray, types.FamEnum,
 		types.FamArray, types.FamTuple,
-		types.Bytes, types.Timestamp, types.TimestampTZ, types.Interval, types.UUID, types.Date, types.Time, types.Oid, types.INet, types.JSON}
+		types.Bytes, types.Timestamp, types.TimestampTZ, types.Interval, types.UUID, types.Date, types.Time, types.TimeTZ, types.Oid, types.INet, types.JSON,
+		types.TSVector, types.TSQuery}
 	bytesCastTypes = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Bytes, types.UUID}
 	dateCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Date, types.Timestamp, types.TimestampTZ, types.Int}
 	timeCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Time,
-		types.Timestamp, types.TimestampTZ, types.Interval}
+		types.TimeTZ, types.Timestamp, types.TimestampTZ, types.Interval}
+	timeTZCastTypes    = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Time, types.TimeTZ, types.TimestampTZ}
 	timestampCastTypes = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Date, types.Timestamp, types.TimestampTZ, types.Int}
 	intervalCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Int, types.Time, types.Interval, types.Float, types.Decimal}
 	oidCastTypes       = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Int, types.Oid}
@@ -1514,6 +1544,9 @@ var (
 	inetCastTypes      = []types.T{types.Unknown, types.String, types.FamCollatedString, types.INet}
 	arrayCastTypes     = []types.T{types.Unknown, types.String}
 	jsonCastTypes      = []types.T{types.Unknown, types.String, types.JSON}
+	tsVectorCastTypes  = []types.T{types.Unknown, types.String, types.TSVector}
+	tsQueryCastTypes   = []types.T{types.Unknown, types.String, types.TSQuery}
+	enumCastTypes      = []types.T{types.Unknown, types.String, types.FamCollatedString, types.FamEnum}
 )
 
 // validCastTypes returns a set of types that can be cast into the provided type.
@@ -1537,12 +1570,18 @@ func validCastTypes(t types.T) []types.T {
 		return dateCastTypes
 	case types.Time:
 		return timeCastTypes
+	case types.TimeTZ:
+		return timeTZCastTypes
 	case types.Timestamp, types.TimestampTZ:
 		return timestampCastTypes
 	case types.Interval:
 		return intervalCastTypes
 	case types.JSON:
 		return jsonCastTypes
+	case types.TSVector:
+		return tsVectorCastTypes
+	case types.TSQuery:
+		return tsQueryCastTypes
 	case types.UUID:
 		return uuidCastTypes
 	case types.INet:
@@ -1554,6 +1593,8 @@ func validCastTypes(t types.T) []types.T {
 		// directly to collated string.
 		if t.FamilyEqual(types.FamCollatedString) {
 			return stringCastTypes
+		} else if t.FamilyEqual(types.FamEnum) {
+			return enumCastTypes
 		} else if t.FamilyEqual(types.FamArray) {
 			ret := make([]types.T, len(arrayCastTypes))
 			copy(ret, arrayCastTypes)
@@ -1710,15 +1751,19 @@ func (node *DBool) String() string            { return AsString(node) }
 func (node *DBytes) String() string           { return AsString(node) }
 func (node *DDate) String() string            { return AsString(node) }
 func (node *DTime) String() string            { return AsString(node) }
+func (node *DTimeTZ) String() string          { return AsString(node) }
 func (node *DDecimal) String() string         { return AsString(node) }
 func (node *DFloat) String() string           { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
 func (node *DInterval) String() string        { return AsString(node) }
 func (node *DJSON) String() string            { return AsString(node) }
+func (node *DTSVector) String() string        { return AsString(node) }
+func (node *DTSQuery) String() string         { return AsString(node) }
 func (node *DUuid) String() string            { return AsString(node) }
 func (node *DIPAddr) String() string          { return AsString(node) }
 func (node *DString) String() string          { return AsString(node) }
 func (node *DCollatedString) String() string  { return AsString(node) }
+func (node *DEnum) String() string            { return AsString(node) }
 func (node *DTimestamp) String() string       { return AsString(node) }
 func (node *DTimestampTZ) String() string     { return AsString(node) }
 func (node *DTuple) String() string           { return AsString(node) }
@@ -136,6 +136,12 @@ func NewFunctionDefinition(
 // for every builtin function. Initialized by builtins.init().
 var FunDefs map[string]*FunctionDefinition
 
+// OrderedSetFunDefs holds pre-allocated FunctionDefinition instances for the
+// builtin ordered-set and hypothetical-set aggregate functions, which can only
+// be applied with a WITHIN GROUP clause. Some of them share their name with a
+// window function in FunDefs. Initialized by builtins.init().
+var OrderedSetFunDefs map[string]*FunctionDefinition
+
 // Format implements the NodeFormatter interface.
 func (fd *FunctionDefinition) Format(ctx *FmtCtx) {
 	ctx.WriteString(fd.Name)
@@ -65,6 +65,32 @@ func (fn *ResolvableFunctionReference) Resolve(
 	}
 }
 
+// ResolveOrderedSet is like Resolve, but resolves the reference to an
+// ordered-set or hypothetical-set aggregate function. It is used for function
+// applications with a WITHIN GROUP clause.
+func (fn *ResolvableFunctionReference) ResolveOrderedSet() (*FunctionDefinition, error) {
+	switch t := fn.FunctionReference.(type) {
+	case *FunctionDefinition:
+		fd, ok := OrderedSetFunDefs[t.Name]
+		if !ok {
+			return nil, newNotOrderedSetError(t.Name)
+		}
+		fn.FunctionReference = fd
+		return fd, nil
+	case *UnresolvedName:
+		fd, err := t.ResolveOrderedSetFunction()
+		if err != nil {
+			return nil, err
+		}
+		fn.FunctionReference = fd
+		return fd, nil
+	default:
+		return nil, pgerror.NewAssertionErrorf("unknown function name type: %+v (%T)",
+			fn.FunctionReference, fn.FunctionReference,
+		)
+	}
+}
+
 // WrapFunction creates a new ResolvableFunctionReference
 // holding a pre-resolved function. Helper for grammar rules.
 func WrapFunction(n string) ResolvableFunctionReference {
@@ -37,6 +37,8 @@ type Grant struct {
 type TargetList struct {
 	Databases NameList
 	Tables    TablePatterns
+	Functions FuncRefs
+	Schemas   NameList
 
 	// ForRoles and Roles are used internally in the parser and not used
 	// in the AST. Therefore they do not participate in pretty-printing,
@@ -50,6 +52,12 @@ func (tl *TargetList) Format(ctx *FmtCtx) {
 	if tl.Databases != nil {
 		ctx.WriteString("DATABASE ")
 		ctx.FormatNode(&tl.Databases)
+	} else if tl.Functions != nil {
+		ctx.WriteString("FUNCTION ")
+		ctx.FormatNode(&tl.Functions)
+	} else if tl.Schemas != nil {
+		ctx.WriteString("SCHEMA ")
+		ctx.FormatNode(&tl.Schemas)
 	} else {
 		ctx.WriteString("TABLE ")
 		ctx.FormatNode(&tl.Tables)
@@ -472,6 +472,10 @@ func (n *UnresolvedName) ResolveFunction(
 			}
 		}
 		if !found {
+			if _, ok := OrderedSetFunDefs[function]; ok {
+				return nil, pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+					"WITHIN GROUP is required for ordered-set aggregate %s", function)
+			}
 			extraMsg := ""
 			// Try a little harder.
 			if rdef, ok := FunDefs[strings.ToLower(function)]; ok {
@@ -485,6 +489,30 @@ func (n *UnresolvedName) ResolveFunction(
 	return def, nil
 }
 
+// ResolveOrderedSetFunction is like ResolveFunction, but looks up the
+// ordered-set and hypothetical-set aggregate functions, which can only be
+// applied with a WITHIN GROUP clause. These functions only live in the
+// global namespace.
+func (n *UnresolvedName) ResolveOrderedSetFunction() (*FunctionDefinition, error) {
+	if n.NumParts > 3 || len(n.Parts[0]) == 0 || n.Star {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidNameError,
+			"invalid function name: %s", n)
+	}
+
+	function, prefix := n.Parts[0], n.Parts[1]
+	if prefix == "" || prefix == sessiondata.PgCatalogName {
+		if d, ok := OrderedSetFunDefs[function]; ok {
+			return d, nil
+		}
+	}
+	return nil, newNotOrderedSetError(ErrString(n))
+}
+
+func newNotOrderedSetError(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+		"%s is not an ordered-set aggregate, so it cannot have WITHIN GROUP", name)
+}
+
 func newInvColRef(fmt string, n *UnresolvedName) error {
 	return pgerror.NewErrorWithDepthf(1, pgerror.CodeInvalidColumnReferenceError, fmt, n)
 }
@@ -769,6 +769,11 @@ func checkReturn(
 		p := o.params()
 		for _, i := range s.constIdxs {
 			des := p.GetAt(i)
+			if des.FamilyEqual(types.FamEnum) && des.IsAmbiguous() {
+				// The overload accepts values of any enum type, so the constant
+				// takes the type of the other enum arguments.
+				des = enumTypeOfArgs(s, des)
+			}
 			typ, err := s.exprs[i].TypeCheck(ctx, des)
 			if err != nil {
 				return s.typedExprs, nil, true, errors.Wrap(err, "error type checking constant value")
@@ -795,6 +800,27 @@ func checkReturn(
 	}
 }
 
+// enumTypeOfArgs returns the type of the resolved arguments of the given
+// state which are enum values. If there are none, or if they are not all of
+// the same type, the given default type is returned.
+func enumTypeOfArgs(s typeCheckOverloadState, def types.T) types.T {
+	var res types.T
+	for _, i := range s.resolvableIdxs {
+		typ := s.typedExprs[i].ResolvedType()
+		if !typ.FamilyEqual(types.FamEnum) {
+			continue
+		}
+		if res != nil && !res.Equivalent(typ) {
+			return def
+		}
+		res = typ
+	}
+	if res == nil {
+		return def
+	}
+	return res
+}
+
 func formatCandidates(prefix string, candidates []overloadImpl) string {
 	var buf bytes.Buffer
 	for _, candidate := range candidates {
@@ -86,10 +86,16 @@ func parseStringAs(t types.T, s string, ctx ParseTimeContext) (Datum, error) {
 		return ParseDInterval(s)
 	case types.JSON:
 		return ParseDJSON(s)
+	case types.TSQuery:
+		return ParseDTSQuery(s)
+	case types.TSVector:
+		return ParseDTSVector(s)
 	case types.String:
 		return NewDString(s), nil
 	case types.Time:
 		return ParseDTime(ctx, s)
+	case types.TimeTZ:
+		return ParseDTimeTZ(ctx, s)
 	case types.Timestamp:
 		return ParseDTimestamp(ctx, s, time.Microsecond)
 	case types.TimestampTZ:
@@ -97,6 +103,13 @@ func parseStringAs(t types.T, s string, ctx ParseTimeContext) (Datum, error) {
 	case types.UUID:
 		return ParseDUuidFromString(s)
 	default:
+		if typ, ok := t.(types.TEnum); ok {
+			if typ.TypeID == 0 {
+				// The labels of the wildcard enum type are unknown.
+				return nil, makeParseError(s, typ, nil)
+			}
+			return MakeDEnumFromLogicalRepresentation(typ, s)
+		}
 		return nil, nil
 	}
 }
@@ -41,6 +41,8 @@ func (d *DTuple) pgwireFormat(ctx *FmtCtx) {
 			pgwireFormatStringInTuple(&ctx.Buffer, string(*dv))
 		case *DCollatedString:
 			pgwireFormatStringInTuple(&ctx.Buffer, dv.Contents)
+		case *DEnum:
+			pgwireFormatStringInTuple(&ctx.Buffer, dv.LogicalRep)
 			// Bytes cannot use the default case because they will be incorrectly
 			// double escaped.
 		case *DBytes:
@@ -49,6 +51,10 @@ func (d *DTuple) pgwireFormat(ctx *FmtCtx) {
 			var buf bytes.Buffer
 			dv.JSON.Format(&buf)
 			pgwireFormatStringInTuple(&ctx.Buffer, buf.String())
+		case *DTSVector:
+			pgwireFormatStringInTuple(&ctx.Buffer, dv.TSVector.String())
+		case *DTSQuery:
+			pgwireFormatStringInTuple(&ctx.Buffer, dv.TSQuery.String())
 		default:
 			s := AsStringWithFlags(v, ctx.flags)
 			pgwireFormatStringInTuple(&ctx.Buffer, s)
@@ -453,6 +453,26 @@ func (node *Limit) docTable(p *PrettyCfg) []pretty.RLTableRow {
 	return res
 }
 
+func (node *LockingClause) docTable(p *PrettyCfg) []pretty.RLTableRow {
+	items := make([]pretty.RLTableRow, 0, len(*node))
+	for _, n := range *node {
+		items = append(items, n.docTable(p)...)
+	}
+	return items
+}
+
+func (node *LockingItem) docTable(p *PrettyCfg) []pretty.RLTableRow {
+	items := make([]pretty.RLTableRow, 0, 3)
+	items = append(items, p.row(node.Strength.String(), pretty.Nil))
+	if len(node.Targets) > 0 {
+		items = append(items, p.row("OF", p.Doc(&node.Targets)))
+	}
+	if node.WaitPolicy != LockWaitBlock {
+		items = append(items, p.row(node.WaitPolicy.String(), pretty.Nil))
+	}
+	return items
+}
+
 func (node *OrderBy) doc(p *PrettyCfg) pretty.Doc {
 	return p.unrow(node.docRow(p))
 }
@@ -484,6 +504,7 @@ func (node *Select) docTable(p *PrettyCfg) []pretty.RLTableRow {
 	}
 	items = append(items, node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
+	items = append(items, node.Locking.docTable(p)...)
 	return items
 }
 
@@ -565,7 +586,11 @@ func (node *With) docRow(p *PrettyCfg) pretty.RLTableRow {
 			pretty.Bracket("AS (", p.Doc(cte.Stmt), ")"),
 		)
 	}
-	return p.row("WITH", pretty.Join(",", d...))
+	kw := "WITH"
+	if node.Recursive {
+		kw = "WITH RECURSIVE"
+	}
+	return p.row(kw, pretty.Join(",", d...))
 }
 
 func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
@@ -605,6 +630,9 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.Lateral {
+		d = pretty.ConcatSpace(pretty.Text("LATERAL"), d)
+	}
 	return d
 }
 
@@ -623,6 +651,12 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 	} else {
 		d = pretty.Concat(d, pretty.Text("()"))
 	}
+	if node.AggType == OrderedSetAgg {
+		d = pretty.Fold(pretty.ConcatSpace,
+			d,
+			pretty.Text("WITHIN GROUP"),
+			pretty.Bracket("(", p.Doc(&node.OrderBy), ")"))
+	}
 	if node.Filter != nil {
 		d = pretty.Fold(pretty.ConcatSpace,
 			d,
@@ -796,10 +830,16 @@ func (node *Insert) doc(p *PrettyCfg) pretty.Doc {
 
 	if node.OnConflict != nil && !node.OnConflict.IsUpsertAlias() {
 		cond := pretty.Nil
+		if node.OnConflict.Constraint != "" {
+			cond = pretty.ConcatSpace(pretty.Text("ON CONSTRAINT"), p.Doc(&node.OnConflict.Constraint))
+		}
 		if len(node.OnConflict.Columns) > 0 {
 			cond = pretty.Bracket("(", p.Doc(&node.OnConflict.Columns), ")")
 		}
 		items = append(items, p.row("ON CONFLICT", cond))
+		if node.OnConflict.ArbiterPredicate != nil {
+			items = append(items, p.row("WHERE", p.Doc(node.OnConflict.ArbiterPredicate)))
+		}
 
 		if node.OnConflict.DoNothing {
 			items = append(items, p.row("DO", pretty.Text("NOTHING")))
@@ -942,7 +982,11 @@ func (node *Update) doc(p *PrettyCfg) pretty.Doc {
 	items = append(items,
 		node.With.docRow(p),
 		p.row("UPDATE", p.Doc(node.Table)),
-		p.row("SET", p.Doc(&node.Exprs)),
+		p.row("SET", p.Doc(&node.Exprs)))
+	if len(node.From) > 0 {
+		items = append(items, p.row("FROM", node.From.doc(p)))
+	}
+	items = append(items,
 		node.Where.docRow(p),
 		node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
@@ -954,7 +998,11 @@ func (node *Delete) doc(p *PrettyCfg) pretty.Doc {
 	items := make([]pretty.RLTableRow, 6)
 	items = append(items,
 		node.With.docRow(p),
-		p.row("DELETE FROM", p.Doc(node.Table)),
+		p.row("DELETE FROM", p.Doc(node.Table)))
+	if len(node.Using) > 0 {
+		items = append(items, p.row("USING", node.Using.doc(p)))
+	}
+	items = append(items,
 		node.Where.docRow(p),
 		node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
@@ -1016,6 +1064,9 @@ func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
 
 func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	title := "CREATE TABLE "
+	if node.Temporary {
+		title = "CREATE TEMPORARY TABLE "
+	}
 	if node.IfNotExists {
 		title += "IF NOT EXISTS "
 	}
@@ -1054,8 +1105,12 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 }
 
 func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
+	title := "CREATE VIEW"
+	if node.Materialized {
+		title = "CREATE MATERIALIZED VIEW"
+	}
 	d := pretty.ConcatSpace(
-		pretty.Text("CREATE VIEW"),
+		pretty.Text(title),
 		p.Doc(&node.Name),
 	)
 	if len(node.ColumnNames) > 0 {
@@ -1271,6 +1326,9 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionBy != nil {
 		docs = append(docs, p.Doc(node.PartitionBy))
 	}
+	if node.Predicate != nil {
+		docs = append(docs, p.nestUnder(pretty.Text("WHERE"), p.Doc(node.Predicate)))
+	}
 	return pretty.Group(pretty.Stack(docs...))
 }
 
@@ -274,6 +274,17 @@ func (node *ShowCreate) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Name)
 }
 
+// ShowCreateFunction represents a SHOW CREATE FUNCTION statement.
+type ShowCreateFunction struct {
+	Name TableName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowCreateFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW CREATE FUNCTION ")
+	ctx.FormatNode(&node.Name)
+}
+
 // ShowSyntax represents a SHOW SYNTAX statement.
 // This the most lightweight thing that can be done on a statement
 // server-side: just report the statement that was entered without
@@ -208,6 +208,12 @@ func (*AlterSequence) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterSequence) StatementTag() string { return "ALTER SEQUENCE" }
 
+// StatementType implements the Statement interface.
+func (*AlterType) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterType) StatementTag() string { return "ALTER TYPE" }
+
 // StatementType implements the Statement interface.
 func (*AlterUserSetPassword) StatementType() StatementType { return RowsAffected }
 
@@ -268,6 +274,12 @@ func (*CopyFrom) StatementType() StatementType { return CopyIn }
 // StatementTag returns a short string identifying the type of statement.
 func (*CopyFrom) StatementTag() string { return "COPY" }
 
+// StatementType implements the Statement interface.
+func (*CopyTo) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CopyTo) StatementTag() string { return "COPY" }
+
 // StatementType implements the Statement interface.
 func (*CreateChangefeed) StatementType() StatementType { return Rows }
 
@@ -330,7 +342,36 @@ func (*CreateRole) hiddenFromShowQueries() {}
 func (*CreateView) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*CreateView) StatementTag() string { return "CREATE VIEW" }
+func (n *CreateView) StatementTag() string {
+	if n.Materialized {
+		return "CREATE MATERIALIZED VIEW"
+	}
+	return "CREATE VIEW"
+}
+
+// StatementType implements the Statement interface.
+func (*CreateFunction) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateFunction) StatementTag() string { return "CREATE FUNCTION" }
+
+// StatementType implements the Statement interface.
+func (*CreateTrigger) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateTrigger) StatementTag() string { return "CREATE TRIGGER" }
+
+// StatementType implements the Statement interface.
+func (*CreateType) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateType) StatementTag() string { return "CREATE TYPE" }
+
+// StatementType implements the Statement interface.
+func (*CreateSchema) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateSchema) StatementTag() string { return "CREATE SCHEMA" }
 
 // StatementType implements the Statement interface.
 func (*CreateSequence) StatementType() StatementType { return DDL }
@@ -390,7 +431,36 @@ func (*DropTable) StatementTag() string { return "DROP TABLE" }
 func (*DropView) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropView) StatementTag() string { return "DROP VIEW" }
+func (n *DropView) StatementTag() string {
+	if n.IsMaterialized {
+		return "DROP MATERIALIZED VIEW"
+	}
+	return "DROP VIEW"
+}
+
+// StatementType implements the Statement interface.
+func (*DropFunction) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropFunction) StatementTag() string { return "DROP FUNCTION" }
+
+// StatementType implements the Statement interface.
+func (*DropTrigger) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropTrigger) StatementTag() string { return "DROP TRIGGER" }
+
+// StatementType implements the Statement interface.
+func (*DropType) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropType) StatementTag() string { return "DROP TYPE" }
+
+// StatementType implements the Statement interface.
+func (*DropSchema) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropSchema) StatementTag() string { return "DROP SCHEMA" }
 
 // StatementType implements the Statement interface.
 func (*DropSequence) StatementType() StatementType { return DDL }
@@ -503,6 +573,12 @@ func (n *RenameTable) StatementTag() string {
 	return "RENAME TABLE"
 }
 
+// StatementType implements the Statement interface.
+func (*RefreshMaterializedView) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*RefreshMaterializedView) StatementTag() string { return "REFRESH MATERIALIZED VIEW" }
+
 // StatementType implements the Statement interface.
 func (*Relocate) StatementType() StatementType { return Rows }
 
@@ -588,6 +664,12 @@ func (*SetClusterSetting) StatementType() StatementType { return Ack }
 // StatementTag returns a short string identifying the type of statement.
 func (*SetClusterSetting) StatementTag() string { return "SET CLUSTER SETTING" }
 
+// StatementType implements the Statement interface.
+func (*SetConstraints) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*SetConstraints) StatementTag() string { return "SET CONSTRAINTS" }
+
 // StatementType implements the Statement interface.
 func (*SetTransaction) StatementType() StatementType { return Ack }
 
@@ -643,6 +725,12 @@ func (*ShowCreate) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowCreate) StatementTag() string { return "SHOW CREATE" }
 
+// StatementType implements the Statement interface.
+func (*ShowCreateFunction) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowCreateFunction) StatementTag() string { return "SHOW CREATE FUNCTION" }
+
 // StatementType implements the Statement interface.
 func (*ShowBackup) StatementType() StatementType { return Rows }
 
@@ -825,11 +913,14 @@ func (n *AlterTableDropConstraint) String() string  { return AsString(n) }
 func (n *AlterTableDropNotNull) String() string     { return AsString(n) }
 func (n *AlterTableDropStored) String() string      { return AsString(n) }
 func (n *AlterTableSetDefault) String() string      { return AsString(n) }
+func (n *AlterTableSetNotNull) String() string      { return AsString(n) }
 func (n *CommentOnColumn) String() string           { return AsString(n) }
 func (n *CommentOnDatabase) String() string         { return AsString(n) }
 func (n *CommentOnTable) String() string            { return AsString(n) }
 func (n *AlterUserSetPassword) String() string      { return AsString(n) }
 func (n *AlterSequence) String() string             { return AsString(n) }
+func (n *AlterType) String() string                 { return AsString(n) }
+func (n *AlterTypeAddValue) String() string         { return AsString(n) }
 func (n *Backup) String() string                    { return AsString(n) }
 func (n *BeginTransaction) String() string          { return AsString(n) }
 func (n *ControlJobs) String() string               { return AsString(n) }
@@ -837,11 +928,16 @@ func (n *CancelQueries) String() string             { return AsString(n) }
 func (n *CancelSessions) String() string            { return AsString(n) }
 func (n *CommitTransaction) String() string         { return AsString(n) }
 func (n *CopyFrom) String() string                  { return AsString(n) }
+func (n *CopyTo) String() string                    { return AsString(n) }
 func (n *CreateChangefeed) String() string          { return AsString(n) }
 func (n *CreateDatabase) String() string            { return AsString(n) }
+func (n *CreateFunction) String() string            { return AsString(n) }
 func (n *CreateIndex) String() string               { return AsString(n) }
 func (n *CreateRole) String() string                { return AsString(n) }
 func (n *CreateTable) String() string               { return AsString(n) }
+func (n *CreateTrigger) String() string             { return AsString(n) }
+func (n *CreateType) String() string                { return AsString(n) }
+func (n *CreateSchema) String() string              { return AsString(n) }
 func (n *CreateSequence) String() string            { return AsString(n) }
 func (n *CreateStats) String() string               { return AsString(n) }
 func (n *CreateUser) String() string                { return AsString(n) }
@@ -849,9 +945,13 @@ func (n *CreateView) String() string                { return AsString(n) }
 func (n *Deallocate) String() string                { return AsString(n) }
 func (n *Delete) String() string                    { return AsString(n) }
 func (n *DropDatabase) String() string              { return AsString(n) }
+func (n *DropFunction) String() string              { return AsString(n) }
 func (n *DropIndex) String() string                 { return AsString(n) }
 func (n *DropRole) String() string                  { return AsString(n) }
 func (n *DropTable) String() string                 { return AsString(n) }
+func (n *DropTrigger) String() string               { return AsString(n) }
+func (n *DropType) String() string                  { return AsString(n) }
+func (n *DropSchema) String() string                { return AsString(n) }
 func (n *DropView) String() string                  { return AsString(n) }
 func (n *DropSequence) String() string              { return AsString(n) }
 func (n *DropUser) String() string                  { return AsString(n) }
@@ -864,6 +964,7 @@ func (n *Insert) String() string                    { return AsString(n) }
 func (n *Import) String() string                    { return AsString(n) }
 func (n *ParenSelect) String() string               { return AsString(n) }
 func (n *Prepare) String() string                   { return AsString(n) }
+func (n *RefreshMaterializedView) String() string   { return AsString(n) }
 func (n *ReleaseSavepoint) String() string          { return AsString(n) }
 func (n *Relocate) String() string                  { return AsString(n) }
 func (n *RenameColumn) String() string              { return AsString(n) }
@@ -883,6 +984,7 @@ func (n *SelectClause) String() string              { return AsString(n) }
 func (n *SetClusterSetting) String() string         { return AsString(n) }
 func (n *SetZoneConfig) String() string             { return AsString(n) }
 func (n *SetSessionCharacteristics) String() string { return AsString(n) }
+func (n *SetConstraints) String() string            { return AsString(n) }
 func (n *SetTransaction) String() string            { return AsString(n) }
 func (n *SetTracing) String() string                { return AsString(n) }
 func (n *SetVar) String() string                    { return AsString(n) }
@@ -891,6 +993,7 @@ func (n *ShowClusterSetting) String() string        { return AsString(n) }
 func (n *ShowColumns) String() string               { return AsString(n) }
 func (n *ShowConstraints) String() string           { return AsString(n) }
 func (n *ShowCreate) String() string                { return AsString(n) }
+func (n *ShowCreateFunction) String() string        { return AsString(n) }
 func (n *ShowDatabases) String() string             { return AsString(n) }
 func (n *ShowGrants) String() string                { return AsString(n) }
 func (n *ShowHistogram) String() string             { return AsString(n) }
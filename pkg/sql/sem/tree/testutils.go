@@ -62,6 +62,8 @@ func SampleDatum(t types.T) Datum {
 		return NewDDate(123123)
 	case types.Time:
 		return MakeDTime(timeofday.FromInt(789))
+	case types.TimeTZ:
+		return MakeDTimeTZ(timeofday.FromInt(345), 5*60*60)
 	case types.Timestamp:
 		return MakeDTimestamp(timeutil.Unix(123, 123), time.Second)
 	case types.TimestampTZ:
@@ -78,6 +80,12 @@ func SampleDatum(t types.T) Datum {
 	case types.JSON:
 		j, _ := ParseDJSON(`{"a": "b"}`)
 		return j
+	case types.TSVector:
+		v, _ := ParseDTSVector(`'cat':3 'fat':2`)
+		return v
+	case types.TSQuery:
+		q, _ := ParseDTSQuery(`'fat' & 'cat'`)
+		return q
 	case types.Oid:
 		return NewDOid(DInt(1009))
 	default:
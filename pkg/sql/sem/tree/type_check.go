@@ -57,9 +57,53 @@ type SemaContext struct {
 	// globally for the entire txn and this field would not be needed.
 	AsOfTimestamp *hlc.Timestamp
 
+	// TypeResolver is used to resolve references to user-defined types. If
+	// it is nil, such references cannot be resolved.
+	TypeResolver TypeReferenceResolver
+
 	Properties SemaProperties
 }
 
+// TypeReferenceResolver resolves references to user-defined types.
+type TypeReferenceResolver interface {
+	// ResolveType returns the user-defined type with the given name.
+	ResolveType(name *TableName) (types.T, error)
+}
+
+// ResolveType resolves the given cast target type if it is a reference to a
+// user-defined type. Other types are returned unchanged.
+//
+// References that were already resolved are resolved again if a resolver is
+// available, since the type may have been altered in the meantime.
+func (sc *SemaContext) ResolveType(t coltypes.CastTargetType) (coltypes.CastTargetType, error) {
+	ref, ok := t.(*coltypes.TUserDefined)
+	if !ok {
+		return t, nil
+	}
+	if sc == nil || sc.TypeResolver == nil {
+		if ref.IsResolved() {
+			return t, nil
+		}
+		return nil, NewUndefinedTypeError(ref)
+	}
+	tn := MakeUnqualifiedTableName(Name(ref.Name))
+	if ref.Schema != "" {
+		tn.SchemaName = Name(ref.Schema)
+		tn.ExplicitSchema = true
+	}
+	typ, err := sc.TypeResolver.ResolveType(&tn)
+	if err != nil {
+		return nil, err
+	}
+	return &coltypes.TUserDefined{Schema: ref.Schema, Name: ref.Name, Typ: typ}, nil
+}
+
+// NewUndefinedTypeError creates an error that represents a reference to a
+// type that does not exist.
+func NewUndefinedTypeError(name fmt.Stringer) error {
+	return pgerror.NewErrorf(pgerror.CodeUndefinedObjectError, "type %q does not exist", name)
+}
+
 // SemaProperties is a holder for required and derived properties
 // during semantic analysis. It provides scoping semantics via its
 // Restore() method, see below.
@@ -406,6 +450,10 @@ func isCastDeepValid(castFrom, castTo types.T) bool {
 	if castTo.FamilyEqual(types.FamArray) && castFrom.FamilyEqual(types.FamArray) {
 		return isCastDeepValid(castFrom.(types.TArray).Typ, castTo.(types.TArray).Typ)
 	}
+	if castTo.FamilyEqual(types.FamEnum) && castFrom.FamilyEqual(types.FamEnum) {
+		// Values can only be cast to their own enum type.
+		return castFrom.Equivalent(castTo)
+	}
 	for _, t := range validCastTypes(castTo) {
 		if castFrom.FamilyEqual(t) {
 			return true
@@ -416,6 +464,11 @@ func isCastDeepValid(castFrom, castTo types.T) bool {
 
 // TypeCheck implements the Expr interface.
 func (expr *CastExpr) TypeCheck(ctx *SemaContext, _ types.T) (TypedExpr, error) {
+	castTarget, err := ctx.ResolveType(expr.Type)
+	if err != nil {
+		return nil, err
+	}
+	expr.Type = castTarget
 	returnType := expr.castType()
 
 	// The desired type provided to a CastExpr is ignored. Instead,
@@ -433,9 +486,13 @@ func (expr *CastExpr) TypeCheck(ctx *SemaContext, _ types.T) (TypedExpr, error)
 			// If the type doesn't have any possible parameters (like length,
 			// precision), the CastExpr becomes a no-op and can be elided.
 			switch expr.Type.(type) {
-			case *coltypes.TBool, *coltypes.TDate, *coltypes.TTime, *coltypes.TTimestamp, *coltypes.TTimestampTZ,
-				*coltypes.TInterval, *coltypes.TBytes:
+			case *coltypes.TBool, *coltypes.TDate, *coltypes.TBytes:
 				return expr.Expr.TypeCheck(ctx, returnType)
+			case *coltypes.TTime, *coltypes.TTimeTZ, *coltypes.TTimestamp, *coltypes.TTimestampTZ,
+				*coltypes.TInterval:
+				if _, ok := coltypes.TimePrecision(expr.Type); !ok {
+					return expr.Expr.TypeCheck(ctx, returnType)
+				}
 			}
 		}
 	case ctx.isUnresolvedPlaceholder(expr.Expr):
@@ -495,6 +552,11 @@ func (expr *IndirectionExpr) TypeCheck(ctx *SemaContext, desired types.T) (Typed
 
 // TypeCheck implements the Expr interface.
 func (expr *AnnotateTypeExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, error) {
+	annotTarget, err := ctx.ResolveType(expr.Type)
+	if err != nil {
+		return nil, err
+	}
+	expr.Type = annotTarget
 	annotType := expr.annotationType()
 	subExpr, err := typeCheckAndRequire(ctx, expr.Expr, annotType,
 		fmt.Sprintf("type annotation for %v as %s, found", expr.Expr, annotType))
@@ -650,12 +712,13 @@ func (expr *ComparisonExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedE
 }
 
 var (
-	errOrderByIndexInWindow = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "ORDER BY INDEX in window definition is not supported")
-	errStarNotAllowed       = pgerror.NewError(pgerror.CodeSyntaxError, "cannot use \"*\" in this context")
-	errInvalidDefaultUsage  = pgerror.NewError(pgerror.CodeSyntaxError, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
-	errInvalidMaxUsage      = pgerror.NewError(pgerror.CodeSyntaxError, "MAXVALUE can only appear within a range partition expression")
-	errInvalidMinUsage      = pgerror.NewError(pgerror.CodeSyntaxError, "MINVALUE can only appear within a range partition expression")
-	errPrivateFunction      = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "function reserved for internal use")
+	errOrderByIndexInWindow      = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "ORDER BY INDEX in window definition is not supported")
+	errOrderByIndexInWithinGroup = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "ORDER BY INDEX in WITHIN GROUP is not supported")
+	errStarNotAllowed            = pgerror.NewError(pgerror.CodeSyntaxError, "cannot use \"*\" in this context")
+	errInvalidDefaultUsage       = pgerror.NewError(pgerror.CodeSyntaxError, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
+	errInvalidMaxUsage           = pgerror.NewError(pgerror.CodeSyntaxError, "MAXVALUE can only appear within a range partition expression")
+	errInvalidMinUsage           = pgerror.NewError(pgerror.CodeSyntaxError, "MINVALUE can only appear within a range partition expression")
+	errPrivateFunction           = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "function reserved for internal use")
 )
 
 // NewAggInAggError creates an error for the case when an aggregate function is
@@ -756,11 +819,22 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 	if ctx != nil {
 		searchPath = ctx.SearchPath
 	}
-	def, err := expr.Func.Resolve(searchPath)
+	var def *FunctionDefinition
+	var err error
+	if expr.AggType == OrderedSetAgg {
+		def, err = expr.Func.ResolveOrderedSet()
+	} else {
+		def, err = expr.Func.Resolve(searchPath)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if expr.AggType == OrderedSetAgg && expr.IsWindowFunctionApplication() {
+		return nil, pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"OVER is not supported for ordered-set aggregate %s", def.Name)
+	}
+
 	if err := ctx.checkFunctionUsage(expr, def); err != nil {
 		return nil, errors.Wrapf(err, "%s()", def.Name)
 	}
@@ -777,7 +851,22 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 		ctx.Properties.Derived.inFuncExpr = true
 	}
 
-	typedSubExprs, fns, err := typeCheckOverloadedExprs(ctx, desired, def.Definition, false, expr.Exprs...)
+	// The arguments of an ordered-set aggregate are its direct arguments
+	// followed by the WITHIN GROUP sort expressions, which provide the values
+	// to aggregate.
+	args := expr.Exprs
+	if expr.AggType == OrderedSetAgg {
+		args = make(Exprs, 0, len(expr.Exprs)+len(expr.OrderBy))
+		args = append(args, expr.Exprs...)
+		for _, orderBy := range expr.OrderBy {
+			if orderBy.OrderType != OrderByColumn {
+				return nil, errOrderByIndexInWithinGroup
+			}
+			args = append(args, orderBy.Expr)
+		}
+	}
+
+	typedSubExprs, fns, err := typeCheckOverloadedExprs(ctx, desired, def.Definition, false, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s()", def.Name)
 	}
@@ -798,7 +887,7 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 	// TODO(nvanbenschoten): now that we can distinguish these, we can improve the
 	//   error message the two report (e.g. "add casts please")
 	if len(fns) != 1 {
-		typeNames := make([]string, 0, len(expr.Exprs))
+		typeNames := make([]string, 0, len(args))
 		for _, expr := range typedSubExprs {
 			typeNames = append(typeNames, expr.ResolvedType().String())
 		}
@@ -873,13 +962,17 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 	}
 
 	for i, subExpr := range typedSubExprs {
-		expr.Exprs[i] = subExpr
+		if i < len(expr.Exprs) {
+			expr.Exprs[i] = subExpr
+		} else {
+			expr.OrderBy[i-len(expr.Exprs)].Expr = subExpr
+		}
 	}
 	expr.fn = overloadImpl
 	expr.fnProps = &def.FunctionProperties
 	expr.typ = overloadImpl.returnType()(typedSubExprs)
 	if expr.typ == UnknownReturnType {
-		typeNames := make([]string, 0, len(expr.Exprs))
+		typeNames := make([]string, 0, len(args))
 		for _, expr := range typedSubExprs {
 			typeNames = append(typeNames, expr.ResolvedType().String())
 		}
@@ -1342,6 +1435,10 @@ func (d *DString) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { retu
 // identity function for Datum.
 func (d *DCollatedString) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DEnum) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DBytes) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
@@ -1362,6 +1459,10 @@ func (d *DDate) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return
 // identity function for Datum.
 func (d *DTime) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DTimeTZ) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTimestamp) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
@@ -1378,6 +1479,14 @@ func (d *DInterval) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { re
 // identity function for Datum.
 func (d *DJSON) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DTSVector) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DTSQuery) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTuple) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
@@ -1680,9 +1789,10 @@ func typeCheckComparisonOp(
 	// Throw a typing error if overload resolution found either no compatible candidates
 	// or if it found an ambiguity.
 	collationMismatch := leftReturn.FamilyEqual(types.FamCollatedString) && !leftReturn.Equivalent(rightReturn)
-	if len(fns) != 1 || collationMismatch {
+	enumMismatch := leftReturn.FamilyEqual(types.FamEnum) && !leftReturn.Equivalent(rightReturn)
+	if len(fns) != 1 || collationMismatch || enumMismatch {
 		sig := fmt.Sprintf(compSignatureFmt, leftReturn, op, rightReturn)
-		if len(fns) == 0 || collationMismatch {
+		if len(fns) == 0 || collationMismatch || enumMismatch {
 			return nil, nil, nil, false,
 				pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError, unsupportedCompErrFmt, sig)
 		}
@@ -2066,6 +2176,13 @@ const (
 	conflictingCasts
 )
 
+// isUnresolvedTypeReference returns true if the given type is a reference to
+// a user-defined type which has not been resolved yet.
+func isUnresolvedTypeReference(t coltypes.CastTargetType) bool {
+	ref, ok := t.(*coltypes.TUserDefined)
+	return ok && !ref.IsResolved()
+}
+
 func (v *placeholderAnnotationVisitor) setErr(idx types.PlaceholderIdx, err error) {
 	if v.err == nil || v.errIdx >= idx {
 		v.err = err
@@ -2076,6 +2193,11 @@ func (v *placeholderAnnotationVisitor) setErr(idx types.PlaceholderIdx, err erro
 func (v *placeholderAnnotationVisitor) VisitPre(expr Expr) (recurse bool, newExpr Expr) {
 	switch t := expr.(type) {
 	case *AnnotateTypeExpr:
+		if isUnresolvedTypeReference(t.Type) {
+			// The type is not known yet; the placeholder is handled as if it
+			// were not annotated.
+			break
+		}
 		if arg, ok := t.Expr.(*Placeholder); ok {
 			annotationType := t.annotationType()
 			switch v.state[arg.Idx] {
@@ -2111,6 +2233,10 @@ func (v *placeholderAnnotationVisitor) VisitPre(expr Expr) (recurse bool, newExp
 		}
 
 	case *CastExpr:
+		if isUnresolvedTypeReference(t.Type) {
+			// Ditto.
+			break
+		}
 		if arg, ok := t.Expr.(*Placeholder); ok {
 			castType := t.castType()
 			switch v.state[arg.Idx] {
@@ -325,6 +325,15 @@ func (expr *FuncExpr) Walk(v Visitor) Expr {
 			ret.Filter = e
 		}
 	}
+	if len(expr.OrderBy) > 0 {
+		order, changed := walkOrderBy(v, expr.OrderBy)
+		if changed {
+			if ret == expr {
+				ret = expr.copyNode()
+			}
+			ret.OrderBy = order
+		}
+	}
 	return ret
 }
 
@@ -622,6 +631,9 @@ func (expr *DDate) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DTime) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DTimeTZ) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DFloat) Walk(_ Visitor) Expr { return expr }
 
@@ -637,6 +649,12 @@ func (expr *DInterval) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DJSON) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DTSVector) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DTSQuery) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DUuid) Walk(_ Visitor) Expr { return expr }
 
@@ -652,6 +670,9 @@ func (expr *DString) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DCollatedString) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DEnum) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DTimestamp) Walk(_ Visitor) Expr { return expr }
 
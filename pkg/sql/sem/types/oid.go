@@ -74,6 +74,8 @@ var OidToType = map[oid.Oid]T{
 	oid.T__date:        TArray{Date},
 	oid.T_time:         Time,
 	oid.T__time:        TArray{Time},
+	oid.T_timetz:       TimeTZ,
+	oid.T__timetz:      TArray{TimeTZ},
 	oid.T_float4:       typeFloat4,
 	oid.T__float4:      TArray{typeFloat4},
 	oid.T_float8:       Float,
@@ -113,6 +115,8 @@ var OidToType = map[oid.Oid]T{
 	oid.T_bit:          typeBit,
 	oid.T__bit:         TArray{typeBit},
 	oid.T_jsonb:        JSON,
+	oid.T_tsvector:     TSVector,
+	oid.T_tsquery:      TSQuery,
 	oid.T_int2vector:   IntVector,
 	oid.T_oidvector:    OidVector,
 	oid.T_regclass:     RegClass,
@@ -145,6 +149,7 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_oid:         oid.T__oid,
 	oid.T_text:        oid.T__text,
 	oid.T_time:        oid.T__time,
+	oid.T_timetz:      oid.T__timetz,
 	oid.T_timestamp:   oid.T__timestamp,
 	oid.T_timestamptz: oid.T__timestamptz,
 	oid.T_varbit:      oid.T__varbit,
@@ -68,6 +68,8 @@ var (
 	Date T = tDate{}
 	// Time is the type of a DTime. Can be compared with ==.
 	Time T = tTime{}
+	// TimeTZ is the type of a DTimeTZ. Can be compared with ==.
+	TimeTZ T = tTimeTZ{}
 	// Timestamp is the type of a DTimestamp. Can be compared with ==.
 	Timestamp T = tTimestamp{}
 	// TimestampTZ is the type of a DTimestampTZ. Can be compared with ==.
@@ -76,6 +78,10 @@ var (
 	Interval T = tInterval{}
 	// JSON is the type of a DJSON. Can be compared with ==.
 	JSON T = tJSON{}
+	// TSVector is the type of a DTSVector. Can be compared with ==.
+	TSVector T = tTSVector{}
+	// TSQuery is the type of a DTSQuery. Can be compared with ==.
+	TSQuery T = tTSQuery{}
 	// UUID is the type of a DUuid. Can be compared with ==.
 	UUID T = tUUID{}
 	// INet is the type of a DIPAddr. Can be compared with ==.
@@ -97,12 +103,15 @@ var (
 		Bytes,
 		Date,
 		Time,
+		TimeTZ,
 		Timestamp,
 		TimestampTZ,
 		Interval,
 		UUID,
 		INet,
 		JSON,
+		TSVector,
+		TSQuery,
 		Oid,
 	}
 
@@ -113,6 +122,8 @@ var (
 	FamTuple T = TTuple{}
 	// FamArray is the type family of a DArray. CANNOT be compared with ==.
 	FamArray T = TArray{}
+	// FamEnum is the type family of a DEnum. CANNOT be compared with ==.
+	FamEnum T = TEnum{}
 	// FamPlaceholder is the type family of a placeholder. CANNOT be compared
 	// with ==.
 	FamPlaceholder T = TPlaceholder{}
@@ -252,6 +263,15 @@ func (tTime) Oid() oid.Oid             { return oid.T_time }
 func (tTime) SQLName() string          { return "time" }
 func (tTime) IsAmbiguous() bool        { return false }
 
+type tTimeTZ struct{}
+
+func (tTimeTZ) String() string           { return "timetz" }
+func (tTimeTZ) Equivalent(other T) bool  { return UnwrapType(other) == TimeTZ || other == Any }
+func (tTimeTZ) FamilyEqual(other T) bool { return UnwrapType(other) == TimeTZ }
+func (tTimeTZ) Oid() oid.Oid             { return oid.T_timetz }
+func (tTimeTZ) SQLName() string          { return "time with time zone" }
+func (tTimeTZ) IsAmbiguous() bool        { return false }
+
 type tTimestamp struct{}
 
 func (tTimestamp) String() string { return "timestamp" }
@@ -300,6 +320,30 @@ func (tJSON) Oid() oid.Oid             { return oid.T_jsonb }
 func (tJSON) SQLName() string          { return "json" }
 func (tJSON) IsAmbiguous() bool        { return false }
 
+type tTSVector struct{}
+
+func (tTSVector) String() string { return "tsvector" }
+func (tTSVector) Equivalent(other T) bool {
+	return UnwrapType(other) == TSVector || other == Any
+}
+
+func (tTSVector) FamilyEqual(other T) bool { return UnwrapType(other) == TSVector }
+func (tTSVector) Oid() oid.Oid             { return oid.T_tsvector }
+func (tTSVector) SQLName() string          { return "tsvector" }
+func (tTSVector) IsAmbiguous() bool        { return false }
+
+type tTSQuery struct{}
+
+func (tTSQuery) String() string { return "tsquery" }
+func (tTSQuery) Equivalent(other T) bool {
+	return UnwrapType(other) == TSQuery || other == Any
+}
+
+func (tTSQuery) FamilyEqual(other T) bool { return UnwrapType(other) == TSQuery }
+func (tTSQuery) Oid() oid.Oid             { return oid.T_tsquery }
+func (tTSQuery) SQLName() string          { return "tsquery" }
+func (tTSQuery) IsAmbiguous() bool        { return false }
+
 type tUUID struct{}
 
 func (tUUID) String() string           { return "uuid" }
@@ -493,6 +537,67 @@ func (a TArray) IsAmbiguous() bool {
 	return a.Typ == nil || a.Typ.IsAmbiguous()
 }
 
+// UserDefinedTypeOIDOffset is added to the descriptor ID of a user-defined type
+// to form its OID, so that the OIDs of user-defined types never collide with
+// the OIDs of the builtin types.
+const UserDefinedTypeOIDOffset = 100000
+
+// TEnum is the type of a DEnum, a value of a user-defined enum type.
+type TEnum struct {
+	// TypeID is the ID of the descriptor of the enum type. The zero TypeID is
+	// a wildcard which is equivalent to every enum type.
+	TypeID uint32
+	// Name is the name of the enum type.
+	Name string
+	// LogicalReps are the labels of the values of the type, in the order in
+	// which they are sorted.
+	LogicalReps []string
+	// PhysicalReps are the encoded values of the type, in the same order as
+	// LogicalReps. They sort bytewise in the same order as the values.
+	PhysicalReps [][]byte
+	// IsMemberReadOnly indicates, in the same order as LogicalReps, which
+	// values were just added to the type and cannot be created from their
+	// label yet. It is nil if all the values are writable.
+	IsMemberReadOnly []bool
+}
+
+// String implements the fmt.Stringer interface.
+func (t TEnum) String() string {
+	if t.TypeID == 0 {
+		return "anyenum"
+	}
+	return t.Name
+}
+
+// Equivalent implements the T interface.
+func (t TEnum) Equivalent(other T) bool {
+	if other == Any {
+		return true
+	}
+	u, ok := UnwrapType(other).(TEnum)
+	return ok && (t.TypeID == 0 || u.TypeID == 0 || t.TypeID == u.TypeID)
+}
+
+// FamilyEqual implements the T interface.
+func (TEnum) FamilyEqual(other T) bool {
+	_, ok := UnwrapType(other).(TEnum)
+	return ok
+}
+
+// Oid implements the T interface.
+func (t TEnum) Oid() oid.Oid {
+	if t.TypeID == 0 {
+		return oid.T_anyenum
+	}
+	return oid.Oid(t.TypeID + UserDefinedTypeOIDOffset)
+}
+
+// SQLName implements the T interface.
+func (t TEnum) SQLName() string { return t.String() }
+
+// IsAmbiguous implements the T interface.
+func (t TEnum) IsAmbiguous() bool { return t.TypeID == 0 }
+
 type tAny struct{}
 
 func (tAny) String() string           { return "anyelement" }
@@ -517,10 +622,12 @@ func IsStringType(t T) bool {
 // can be used in TArray.
 func IsValidArrayElementType(t T) bool {
 	switch t {
-	case JSON:
+	case JSON, TSVector, TSQuery:
 		return false
 	default:
-		return true
+		// Arrays of user-defined types would need their own OIDs.
+		_, isEnum := UnwrapType(t).(TEnum)
+		return !isEnum
 	}
 }
 
@@ -532,6 +639,8 @@ func IsDateTimeType(t T) bool {
 		return true
 	case Time:
 		return true
+	case TimeTZ:
+		return true
 	case Timestamp:
 		return true
 	case TimestampTZ:
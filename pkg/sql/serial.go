@@ -96,6 +96,14 @@ func (p *planner) processSerialInColumnDef(
 	// The constraint on the name is that an object of this name must not exist already.
 	seqName := tree.NewUnqualifiedTableName(
 		tree.Name(tableName.Table() + "_" + string(d.Name) + "_seq"))
+	// The sequence of a table in a user-defined schema is created in the same
+	// schema.
+	inUserSchema := tableName.SchemaName != "" && tableName.Schema() != tree.PublicSchema
+	if inUserSchema {
+		seqName.TableNamePrefix = tableName.TableNamePrefix
+		seqName.ExplicitCatalog = true
+		seqName.ExplicitSchema = true
+	}
 
 	// The first step in the search is to prepare the seqName to fill in
 	// the catalog/schema parent. This is what ResolveUncachedDatabase does.
@@ -122,9 +130,18 @@ func (p *planner) processSerialInColumnDef(
 		}
 	}
 
+	// The sequence is referred to by its schema-qualified name when it does
+	// not live in the public schema, so that it can be found regardless of
+	// the search path.
+	seqRef := seqName.Table()
+	if inUserSchema {
+		ref := tree.MakeTableNameWithSchema("", seqName.SchemaName, seqName.TableName)
+		ref.ExplicitSchema = true
+		seqRef = tree.AsString(&ref)
+	}
 	defaultExpr := &tree.FuncExpr{
 		Func:  tree.WrapFunction("nextval"),
-		Exprs: tree.Exprs{tree.NewStrVal(seqName.Table())},
+		Exprs: tree.Exprs{tree.NewStrVal(seqRef)},
 	}
 
 	seqType := ""
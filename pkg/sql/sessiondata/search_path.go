@@ -26,11 +26,18 @@ const DefaultDatabaseName = "defaultdb"
 // PgCatalogName is the name of the pg_catalog system schema.
 const PgCatalogName = "pg_catalog"
 
+// PgTempSchemaName is the alias for the temporary schema of the current
+// session.
+const PgTempSchemaName = "pg_temp"
+
 // SearchPath represents a list of namespaces to search builtins in.
 // The names must be normalized (as per Name.Normalize) already.
 type SearchPath struct {
 	paths             []string
 	containsPgCatalog bool
+	// tempSchemaName is the name of the temporary schema of the session, or
+	// empty if the session has not created a temporary schema yet.
+	tempSchemaName string
 }
 
 // MakeSearchPath returns a new immutable SearchPath struct. The paths slice
@@ -49,25 +56,41 @@ func MakeSearchPath(paths []string) SearchPath {
 	}
 }
 
+// WithTemporarySchemaName returns a new immutable SearchPath struct with
+// the given temporary schema name, which is searched before any other schema
+// when resolving existing objects.
+func (s SearchPath) WithTemporarySchemaName(tempSchemaName string) SearchPath {
+	s.tempSchemaName = tempSchemaName
+	return s
+}
+
+// GetTemporarySchemaName returns the name of the temporary schema of the
+// session, or the empty string if there is none.
+func (s SearchPath) GetTemporarySchemaName() string {
+	return s.tempSchemaName
+}
+
 // Iter returns an iterator through the search path. We must include the
 // implicit pg_catalog at the beginning of the search path, unless it has been
-// explicitly set later by the user.
+// explicitly set later by the user. The temporary schema of the session, if
+// any, is searched before everything else.
 // "The system catalog schema, pg_catalog, is always searched, whether it is
 // mentioned in the path or not. If it is mentioned in the path then it will be
 // searched in the specified order. If pg_catalog is not in the path then it
 // will be searched before searching any of the path items."
 // - https://www.postgresql.org/docs/9.1/static/runtime-config-client.html
 func (s SearchPath) Iter() SearchPathIter {
-	if s.containsPgCatalog {
-		return SearchPathIter{paths: s.paths, i: 0}
+	return SearchPathIter{
+		paths:             s.paths,
+		implicitPgCatalog: !s.containsPgCatalog,
+		tempSchemaName:    s.tempSchemaName,
 	}
-	return SearchPathIter{paths: s.paths, i: -1}
 }
 
 // IterWithoutImplicitPGCatalog is the same as Iter, but does not include the
-// implicit pg_catalog.
+// implicit pg_catalog nor the temporary schema.
 func (s SearchPath) IterWithoutImplicitPGCatalog() SearchPathIter {
-	return SearchPathIter{paths: s.paths, i: 0}
+	return SearchPathIter{paths: s.paths}
 }
 
 // GetPathArray returns the underlying path array of this SearchPath. The
@@ -78,7 +101,7 @@ func (s SearchPath) GetPathArray() []string {
 
 // Equals returns true if two SearchPaths are the same.
 func (s SearchPath) Equals(other *SearchPath) bool {
-	if s.containsPgCatalog != other.containsPgCatalog {
+	if s.containsPgCatalog != other.containsPgCatalog || s.tempSchemaName != other.tempSchemaName {
 		return false
 	}
 	if len(s.paths) != len(other.paths) {
@@ -104,14 +127,20 @@ func (s SearchPath) String() string {
 // iterator, and then repeatedly call the Next method in order to iterate over
 // each search path.
 type SearchPathIter struct {
-	paths []string
-	i     int
+	paths             []string
+	implicitPgCatalog bool
+	tempSchemaName    string
+	i                 int
 }
 
 // Next returns the next search path, or false if there are no remaining paths.
 func (iter *SearchPathIter) Next() (path string, ok bool) {
-	if iter.i == -1 {
-		iter.i++
+	if iter.tempSchemaName != "" {
+		path, iter.tempSchemaName = iter.tempSchemaName, ""
+		return path, true
+	}
+	if iter.implicitPgCatalog {
+		iter.implicitPgCatalog = false
 		return PgCatalogName, true
 	}
 	if iter.i < len(iter.paths) {
@@ -25,18 +25,22 @@ import (
 func TestImpliedSearchPath(t *testing.T) {
 	testCases := []struct {
 		explicitSearchPath                         []string
+		tempSchemaName                             string
 		expectedSearchPath                         []string
 		expectedSearchPathWithoutImplicitPgCatalog []string
 	}{
-		{[]string{}, []string{`pg_catalog`}, []string{}},
-		{[]string{`pg_catalog`}, []string{`pg_catalog`}, []string{`pg_catalog`}},
-		{[]string{`foobar`, `pg_catalog`}, []string{`foobar`, `pg_catalog`}, []string{`foobar`, `pg_catalog`}},
-		{[]string{`foobar`}, []string{`pg_catalog`, `foobar`}, []string{`foobar`}},
+		{[]string{}, ``, []string{`pg_catalog`}, []string{}},
+		{[]string{`pg_catalog`}, ``, []string{`pg_catalog`}, []string{`pg_catalog`}},
+		{[]string{`foobar`, `pg_catalog`}, ``, []string{`foobar`, `pg_catalog`}, []string{`foobar`, `pg_catalog`}},
+		{[]string{`foobar`}, ``, []string{`pg_catalog`, `foobar`}, []string{`foobar`}},
+		{[]string{}, `pg_temp_1`, []string{`pg_temp_1`, `pg_catalog`}, []string{}},
+		{[]string{`foobar`, `pg_catalog`}, `pg_temp_1`, []string{`pg_temp_1`, `foobar`, `pg_catalog`}, []string{`foobar`, `pg_catalog`}},
+		{[]string{`foobar`}, `pg_temp_1`, []string{`pg_temp_1`, `pg_catalog`, `foobar`}, []string{`foobar`}},
 	}
 
 	for _, tc := range testCases {
-		t.Run(strings.Join(tc.explicitSearchPath, ","), func(t *testing.T) {
-			searchPath := MakeSearchPath(tc.explicitSearchPath)
+		t.Run(strings.Join(tc.explicitSearchPath, ",")+tc.tempSchemaName, func(t *testing.T) {
+			searchPath := MakeSearchPath(tc.explicitSearchPath).WithTemporarySchemaName(tc.tempSchemaName)
 			actualSearchPath := make([]string, 0)
 			iter := searchPath.Iter()
 			for p, ok := iter.Next(); ok; p, ok = iter.Next() {
@@ -47,8 +51,8 @@ func TestImpliedSearchPath(t *testing.T) {
 			}
 		})
 
-		t.Run(strings.Join(tc.explicitSearchPath, ",")+"/no-pg-catalog", func(t *testing.T) {
-			searchPath := MakeSearchPath(tc.explicitSearchPath)
+		t.Run(strings.Join(tc.explicitSearchPath, ",")+tc.tempSchemaName+"/no-pg-catalog", func(t *testing.T) {
+			searchPath := MakeSearchPath(tc.explicitSearchPath).WithTemporarySchemaName(tc.tempSchemaName)
 			actualSearchPath := make([]string, 0)
 			iter := searchPath.IterWithoutImplicitPGCatalog()
 			for p, ok := iter.Next(); ok; p, ok = iter.Next() {
@@ -80,4 +84,8 @@ func TestSearchPathEquals(t *testing.T) {
 
 	d := MakeSearchPath([]string{"x"})
 	assert.False(t, a1.Equals(&d))
+
+	e := a1.WithTemporarySchemaName("pg_temp_1")
+	assert.False(t, a1.Equals(&e))
+	assert.True(t, e.Equals(&e))
 }
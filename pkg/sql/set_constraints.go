@@ -0,0 +1,133 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// setConstraintsNode represents a SET CONSTRAINTS statement.
+type setConstraintsNode struct {
+	n        *tree.SetConstraints
+	deferred *row.DeferredFKChecks
+}
+
+// SetConstraints sets the checking mode of deferrable constraints until the
+// end of the current transaction.
+// Privileges: None.
+//   notes: the constraints are looked up in the tables visible to the user.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	deferred := p.deferredFKChecks()
+	if deferred == nil {
+		return nil, pgerror.NewErrorf(pgerror.CodeNoActiveSQLTransactionError,
+			"SET CONSTRAINTS can only be used in transaction blocks")
+	}
+	if err := p.checkDeferrableConstraints(ctx, n.Names); err != nil {
+		return nil, err
+	}
+	return &setConstraintsNode{n: n, deferred: deferred}, nil
+}
+
+// checkDeferrableConstraints checks that each of the given names designates
+// a deferrable foreign key constraint of a table in the current database.
+// UNIQUE, PRIMARY KEY and CHECK constraints are never deferrable.
+func (p *planner) checkDeferrableConstraints(ctx context.Context, names tree.NameList) error {
+	if len(names) == 0 {
+		return nil
+	}
+	dbDesc, err := p.ResolveUncachedDatabaseByName(ctx, p.CurrentDatabase(), true /* required */)
+	if err != nil {
+		return err
+	}
+
+	// found maps the names of the constraints that were found to whether at
+	// least one of the constraints with that name is deferrable.
+	found := make(map[string]bool, len(names))
+	addConstraint := func(constraintName string, deferrable bool) {
+		for _, name := range names {
+			if string(name) == constraintName {
+				found[constraintName] = found[constraintName] || deferrable
+			}
+		}
+	}
+	if err := forEachTableDesc(ctx, p, dbDesc, hideVirtual,
+		func(_ *sqlbase.DatabaseDescriptor, _ string, table *sqlbase.TableDescriptor) error {
+			for _, check := range table.Checks {
+				addConstraint(check.Name, false /* deferrable */)
+			}
+			return table.ForeachNonDropIndex(func(idx *sqlbase.IndexDescriptor) error {
+				if idx.Unique {
+					addConstraint(idx.Name, false /* deferrable */)
+				}
+				if fk := &idx.ForeignKey; fk.IsSet() {
+					addConstraint(fk.Name, fk.Deferrable)
+				}
+				return nil
+			})
+		},
+	); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		deferrable, ok := found[string(name)]
+		if !ok {
+			return pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+				"constraint %q does not exist", tree.ErrString(&name))
+		}
+		if !deferrable {
+			return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+				"constraint %q is not deferrable", tree.ErrString(&name))
+		}
+	}
+	return nil
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	mode := row.ConstraintModeImmediate
+	if n.n.Deferred {
+		mode = row.ConstraintModeDeferred
+	}
+
+	// Set the modes, then, when switching to IMMEDIATE, run the checks that
+	// were deferred so far for the affected constraints.
+	var filter func(name string) bool
+	if len(n.n.Names) == 0 {
+		n.deferred.SetAllModes(mode)
+	} else {
+		names := make(map[string]struct{}, len(n.n.Names))
+		for _, name := range n.n.Names {
+			n.deferred.SetMode(string(name), mode)
+			names[string(name)] = struct{}{}
+		}
+		filter = func(name string) bool {
+			_, ok := names[name]
+			return ok
+		}
+	}
+	if mode != row.ConstraintModeImmediate {
+		return nil
+	}
+	return n.deferred.Run(params.ctx, params.p.txn, filter)
+}
+
+func (*setConstraintsNode) Next(runParams) (bool, error) { return false, nil }
+func (*setConstraintsNode) Values() tree.Datums          { return tree.Datums{} }
+func (*setConstraintsNode) Close(context.Context)        {}
@@ -70,6 +70,12 @@ var supportedZoneConfigOptions = map[tree.Name]struct {
 		c.Constraints = constraintsList.Constraints
 		c.InheritedConstraints = false
 	}},
+	"num_voters": {types.Int, func(c *config.ZoneConfig, d tree.Datum) { c.NumVoters = proto.Int32(int32(tree.MustBeDInt(d))) }},
+	"voter_constraints": {types.String, func(c *config.ZoneConfig, d tree.Datum) {
+		constraintsList := config.ConstraintsList{Constraints: c.VoterConstraints}
+		loadYAML(&constraintsList, string(tree.MustBeDString(d)))
+		c.VoterConstraints = constraintsList.Constraints
+	}},
 	"lease_preferences": {types.String, func(c *config.ZoneConfig, d tree.Datum) {
 		loadYAML(&c.LeasePreferences, string(tree.MustBeDString(d)))
 		c.InheritedLeasePreferences = false
@@ -639,6 +645,14 @@ func writeZoneConfig(
 		}
 	}
 
+	if zone.NumVoters != nil || len(zone.VoterConstraints) > 0 {
+		st := execCfg.Settings
+		if !st.Version.IsActive(cluster.VersionNonVotingReplicas) {
+			return 0, pgerror.NewError(pgerror.CodeCheckViolationError,
+				"cluster version does not support zone configs with non-voting replicas")
+		}
+	}
+
 	if zone.IsSubzonePlaceholder() && len(zone.Subzones) == 0 {
 		return execCfg.InternalExecutor.Exec(ctx, "delete-zone", txn,
 			"DELETE FROM system.zones WHERE id = $1", targetID)
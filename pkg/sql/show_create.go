@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/pkg/errors"
@@ -46,14 +47,22 @@ func ShowCreateView(
 	ctx context.Context, tn *tree.Name, desc *sqlbase.TableDescriptor,
 ) (string, error) {
 	f := tree.NewFmtCtx(tree.FmtSimple)
-	f.WriteString("CREATE VIEW ")
+	f.WriteString("CREATE ")
+	if desc.MaterializedView() {
+		f.WriteString("MATERIALIZED ")
+	}
+	f.WriteString("VIEW ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
+	sep := ""
 	for i := range desc.Columns {
-		if i > 0 {
-			f.WriteString(", ")
+		// Materialized views store their rows with a hidden rowid column.
+		if desc.Columns[i].Hidden {
+			continue
 		}
+		f.WriteString(sep)
 		f.FormatNameP(&desc.Columns[i].Name)
+		sep = ", "
 	}
 	f.WriteString(") AS ")
 	f.WriteString(desc.ViewQuery)
@@ -118,9 +127,76 @@ func printForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+		if fk.InitiallyDeferred {
+			buf.WriteString(" INITIALLY DEFERRED")
+		}
+	}
 	return nil
 }
 
+// ShowCreateFunction implements the SHOW CREATE FUNCTION statement.
+// Privileges: Any privilege on function.
+func (p *planner) ShowCreateFunction(
+	ctx context.Context, n *tree.ShowCreateFunction,
+) (planNode, error) {
+	tn := n.Name
+	desc, err := p.resolveFunction(ctx, &tn, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckAnyPrivilege(ctx, desc); err != nil {
+		return nil, err
+	}
+	stmt, err := ShowCreateFunction(ctx, &tn, desc)
+	if err != nil {
+		return nil, err
+	}
+	const showCreateQuery = `SELECT %s AS function_name, %s AS create_statement`
+	fullQuery := fmt.Sprintf(showCreateQuery,
+		lex.EscapeSQLString(tn.String()), lex.EscapeSQLString(stmt))
+	return p.delegateQuery(ctx, "SHOW CREATE FUNCTION", fullQuery,
+		func(_ context.Context) error { return nil }, nil)
+}
+
+// ShowCreateFunction returns a valid SQL representation of the CREATE
+// FUNCTION statement used to create the given function.
+func ShowCreateFunction(
+	ctx context.Context, tn tree.NodeFormatter, desc *sqlbase.FunctionDescriptor,
+) (string, error) {
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	f.WriteString("CREATE FUNCTION ")
+	f.FormatNode(tn)
+	f.WriteByte('(')
+	for i := range desc.Args {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		if desc.Args[i].Name != "" {
+			f.FormatNameP(&desc.Args[i].Name)
+			f.WriteByte(' ')
+		}
+		f.WriteString(desc.Args[i].Type.SQLString())
+	}
+	f.WriteString(") RETURNS ")
+	if desc.ReturnsTrigger {
+		f.WriteString("TRIGGER")
+	} else {
+		f.WriteString(desc.ReturnType.SQLString())
+	}
+	f.WriteString(" LANGUAGE ")
+	f.WriteString(desc.Language)
+	f.WriteByte(' ')
+	f.WriteString(desc.Volatility.String())
+	if desc.Strict {
+		f.WriteString(" STRICT")
+	}
+	f.WriteString(" AS ")
+	lex.EncodeSQLString(&f.Buffer, desc.Body)
+	return f.CloseAndGetString(), nil
+}
+
 // ShowCreateSequence returns a valid SQL representation of the
 // CREATE SEQUENCE statement used to create the given sequence.
 func ShowCreateSequence(
@@ -159,7 +235,11 @@ func ShowCreateTable(
 	a := &sqlbase.DatumAlloc{}
 
 	f := tree.NewFmtCtx(tree.FmtSimple)
-	f.WriteString("CREATE TABLE ")
+	f.WriteString("CREATE ")
+	if desc.Temporary {
+		f.WriteString("TEMP ")
+	}
+	f.WriteString("TABLE ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
 	primaryKeyIsOnVisibleColumn := false
@@ -206,6 +286,10 @@ func ShowCreateTable(
 			); err != nil {
 				return "", err
 			}
+			if idx.IsPartial() {
+				f.WriteString(" WHERE ")
+				f.WriteString(idx.Predicate)
+			}
 		}
 	}
 
@@ -224,6 +308,9 @@ func ShowCreateTable(
 	}
 
 	for _, e := range desc.Checks {
+		if e.IsNonNullConstraint {
+			continue
+		}
 		f.WriteString(",\n\t")
 		if len(e.Name) > 0 {
 			f.WriteString("CONSTRAINT ")
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -29,6 +30,11 @@ import (
 //   Notes: postgres does not have a SHOW GRANTS statement.
 //          mysql only returns the user's privileges.
 func (p *planner) ShowGrants(ctx context.Context, n *tree.ShowGrants) (planNode, error) {
+	if n.Targets != nil && n.Targets.Functions != nil {
+		return nil, pgerror.UnimplementedWithIssueError(17511,
+			"SHOW GRANTS ON FUNCTION is not supported; use pg_catalog.pg_proc instead")
+	}
+
 	var params []string
 	var initCheck func(context.Context) error
 
@@ -77,6 +83,28 @@ FROM "".information_schema.table_privileges`
 		} else {
 			fmt.Fprintf(&cond, `WHERE database_name IN (%s)`, strings.Join(params, ","))
 		}
+	} else if n.Targets != nil && n.Targets.Schemas != nil {
+		// Get grants of user-defined schemas of the current database from
+		// information_schema.schema_privileges as well.
+		scNames := n.Targets.Schemas.ToStrings()
+
+		initCheck = func(ctx context.Context) error {
+			for _, sc := range scNames {
+				if _, err := p.resolveSchemaDesc(ctx, sc, true /* required */); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, sc := range scNames {
+			params = append(params, lex.EscapeSQLString(sc))
+		}
+
+		fmt.Fprint(&source, dbPrivQuery)
+		orderBy = "1,2,3,4"
+		fmt.Fprintf(&cond, `WHERE database_name = %s AND schema_name IN (%s)`,
+			lex.EscapeSQLString(p.CurrentDatabase()), strings.Join(params, ","))
 	} else {
 		fmt.Fprint(&source, tablePrivQuery)
 		orderBy = "1,2,3,4,5"
@@ -20,6 +20,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 )
 
@@ -40,6 +41,11 @@ func (p *planner) ShowTables(ctx context.Context, n *tree.ShowTables) (planNode,
 		return nil, sqlbase.NewInvalidWildcardError(tree.ErrString(&n.TableNamePrefix))
 	}
 
+	scName := n.Schema()
+	if scName == sessiondata.PgTempSchemaName {
+		scName = p.temporarySchemaName()
+	}
+
 	var query string
 	if n.WithComment {
 		// TODO(knz): this 3-way join is painful and would really benefit
@@ -67,7 +73,7 @@ ORDER BY
 		query = fmt.Sprintf(
 			getTablesQuery,
 			&n.CatalogName,
-			lex.EscapeSQLString(n.Schema()),
+			lex.EscapeSQLString(scName),
 			lex.EscapeSQLString(sqlbase.TableDescriptor_PUBLIC.String()),
 			lex.EscapeSQLString(n.CatalogName.Normalize()))
 
@@ -79,7 +85,7 @@ ORDER BY
 ORDER BY table_schema, table_name`
 
 		query = fmt.Sprintf(getTablesQuery,
-			&n.CatalogName, lex.EscapeSQLString(n.Schema()))
+			&n.CatalogName, lex.EscapeSQLString(scName))
 	}
 
 	return p.delegateQuery(ctx, "SHOW TABLES",
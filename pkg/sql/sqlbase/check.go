@@ -78,7 +78,7 @@ func NewEvalCheckHelper(
 		return nil, nil
 	}
 
-	c := &CheckHelper{}
+	c := &CheckHelper{tableDesc: tableDesc}
 	c.cols = tableDesc.Columns
 	c.sourceInfo = NewSourceInfoForSingleTable(
 		tree.MakeUnqualifiedTableName(tree.Name(tableDesc.Name)),
@@ -190,15 +190,14 @@ func (c *CheckHelper) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
 func (c *CheckHelper) CheckEval(ctx *tree.EvalContext) error {
 	ctx.PushIVarContainer(c)
 	defer func() { ctx.PopIVarContainer() }()
-	for _, expr := range c.Exprs {
+	for i, expr := range c.Exprs {
 		if d, err := expr.Eval(ctx); err != nil {
 			return err
 		} else if res, err := tree.GetBool(d); err != nil {
 			return err
 		} else if !res && d != tree.DNull {
 			// Failed to satisfy CHECK constraint.
-			return pgerror.NewErrorf(pgerror.CodeCheckViolationError,
-				"failed to satisfy CHECK constraint (%s)", expr)
+			return c.violationError(&c.tableDesc.AllChecks()[i], expr.String())
 		}
 	}
 	return nil
@@ -222,9 +221,21 @@ func (c *CheckHelper) CheckInput(checkVals tree.Datums) error {
 			return err
 		} else if !res && checkVals[i] != tree.DNull {
 			// Failed to satisfy CHECK constraint.
-			return pgerror.NewErrorf(pgerror.CodeCheckViolationError,
-				"failed to satisfy CHECK constraint (%s)", check.Expr)
+			return c.violationError(&check, check.Expr)
 		}
 	}
 	return nil
 }
+
+// violationError returns the error reported for a row that does not satisfy
+// the given check constraint. Check constraints that enforce a NOT NULL
+// constraint being validated report the same error as non-nullable columns.
+func (c *CheckHelper) violationError(check *TableDescriptor_CheckConstraint, expr string) error {
+	if check.IsNonNullConstraint && len(check.ColumnIDs) == 1 {
+		if col, err := c.tableDesc.FindColumnByID(check.ColumnIDs[0]); err == nil {
+			return NewNonNullViolationError(col.Name)
+		}
+	}
+	return pgerror.NewErrorf(pgerror.CodeCheckViolationError,
+		"failed to satisfy CHECK constraint (%s)", expr)
+}
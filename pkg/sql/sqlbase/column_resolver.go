@@ -265,6 +265,12 @@ func newAmbiguousSourceError(tn *tree.TableName) error {
 // NameResolutionResult implements the tree.NameResolutionResult interface.
 func (*TableDescriptor) NameResolutionResult() {}
 
+// NameResolutionResult implements the tree.NameResolutionResult interface.
+func (*FunctionDescriptor) NameResolutionResult() {}
+
+// NameResolutionResult implements the tree.NameResolutionResult interface.
+func (*TypeDescriptor) NameResolutionResult() {}
+
 // SchemaMeta implements the tree.SchemaMeta interface.
 func (*DatabaseDescriptor) SchemaMeta() {}
 
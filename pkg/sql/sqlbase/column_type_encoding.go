@@ -27,6 +27,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
 )
@@ -104,6 +106,15 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeVarintAscending(b, int64(*t)), nil
 		}
 		return encoding.EncodeVarintDescending(b, int64(*t)), nil
+	case *tree.DTimeTZ:
+		// Times are ordered by their UTC time first and then by their offset,
+		// with the zones further east of UTC first.
+		if dir == encoding.Ascending {
+			b = encoding.EncodeVarintAscending(b, t.UTCMicros())
+			return encoding.EncodeVarintAscending(b, -int64(t.OffsetSecs)), nil
+		}
+		b = encoding.EncodeVarintDescending(b, t.UTCMicros())
+		return encoding.EncodeVarintDescending(b, -int64(t.OffsetSecs)), nil
 	case *tree.DTimestamp:
 		if dir == encoding.Ascending {
 			return encoding.EncodeTimeAscending(b, t.Time), nil
@@ -144,6 +155,11 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeBytesAscending(b, t.Key), nil
 		}
 		return encoding.EncodeBytesDescending(b, t.Key), nil
+	case *tree.DEnum:
+		if dir == encoding.Ascending {
+			return encoding.EncodeBytesAscending(b, t.PhysicalRep), nil
+		}
+		return encoding.EncodeBytesDescending(b, t.PhysicalRep), nil
 	case *tree.DBitArray:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBitArrayAscending(b, t.BitArray), nil
@@ -240,7 +256,8 @@ func DecodeTableKey(
 			rkey, r, err = encoding.DecodeUnsafeStringDescending(key, nil)
 		}
 		return a.NewDName(tree.DString(r)), rkey, err
-	case types.JSON:
+	case types.JSON, types.TSVector:
+		// The keys of inverted indexes cannot be decoded.
 		return tree.DNull, []byte{}, nil
 	case types.Bytes:
 		var r []byte
@@ -266,6 +283,24 @@ func DecodeTableKey(
 			rkey, t, err = encoding.DecodeVarintDescending(key)
 		}
 		return a.NewDTime(tree.DTime(t)), rkey, err
+	case types.TimeTZ:
+		var utcMicros, negOffset int64
+		if dir == encoding.Ascending {
+			rkey, utcMicros, err = encoding.DecodeVarintAscending(key)
+			if err != nil {
+				return nil, nil, err
+			}
+			rkey, negOffset, err = encoding.DecodeVarintAscending(rkey)
+		} else {
+			rkey, utcMicros, err = encoding.DecodeVarintDescending(key)
+			if err != nil {
+				return nil, nil, err
+			}
+			rkey, negOffset, err = encoding.DecodeVarintDescending(rkey)
+		}
+		offsetSecs := int32(-negOffset)
+		t := timeofday.TimeOfDay(utcMicros + int64(offsetSecs)*int64(time.Second/time.Microsecond))
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeOfDay: t, OffsetSecs: offsetSecs}), rkey, err
 	case types.Timestamp:
 		var t time.Time
 		if dir == encoding.Ascending {
@@ -332,6 +367,18 @@ func DecodeTableKey(
 				return nil, nil, err
 			}
 			return tree.NewDCollatedString(r, t.Locale, &a.env), rkey, err
+		case types.TEnum:
+			var r []byte
+			if dir == encoding.Ascending {
+				rkey, r, err = encoding.DecodeBytesAscending(key, nil)
+			} else {
+				rkey, r, err = encoding.DecodeBytesDescending(key, nil)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			d, err := tree.MakeDEnumFromPhysicalRepresentation(t, r)
+			return d, rkey, err
 		}
 		return nil, nil, errors.Errorf("TODO(pmattis): decoded index key: %s", valType)
 	}
@@ -373,6 +420,8 @@ func EncodeTableValue(
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(*t)), nil
 	case *tree.DTime:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(*t)), nil
+	case *tree.DTimeTZ:
+		return encoding.EncodeTimeTZValue(appendTo, uint32(colID), int64(t.TimeOfDay), t.OffsetSecs), nil
 	case *tree.DTimestamp:
 		return encoding.EncodeTimeValue(appendTo, uint32(colID), t.Time), nil
 	case *tree.DTimestampTZ:
@@ -389,6 +438,10 @@ func EncodeTableValue(
 			return nil, err
 		}
 		return encoding.EncodeJSONValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DTSVector:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), tsearch.EncodeTSVector(scratch[:0], t.TSVector)), nil
+	case *tree.DTSQuery:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), tsearch.EncodeTSQuery(scratch[:0], t.TSQuery)), nil
 	case *tree.DArray:
 		a, err := encodeArray(t, scratch)
 		if err != nil {
@@ -399,6 +452,8 @@ func EncodeTableValue(
 		return encodeTuple(t, appendTo, uint32(colID), scratch)
 	case *tree.DCollatedString:
 		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Contents)), nil
+	case *tree.DEnum:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), t.PhysicalRep), nil
 	case *tree.DOid:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.DInt)), nil
 	}
@@ -485,6 +540,12 @@ func decodeUntaggedDatum(a *DatumAlloc, t types.T, buf []byte) (tree.Datum, []by
 			return nil, b, err
 		}
 		return a.NewDTime(tree.DTime(data)), b, nil
+	case types.TimeTZ:
+		b, micros, offsetSecs, err := encoding.DecodeUntaggedTimeTZValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeOfDay: timeofday.TimeOfDay(micros), OffsetSecs: offsetSecs}), b, nil
 	case types.Timestamp:
 		b, data, err := encoding.DecodeUntaggedTimeValue(buf)
 		if err != nil {
@@ -516,6 +577,26 @@ func decodeUntaggedDatum(a *DatumAlloc, t types.T, buf []byte) (tree.Datum, []by
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.TSVector:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		v, err := tsearch.DecodeTSVector(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTSVector(tree.DTSVector{TSVector: v}), b, nil
+	case types.TSQuery:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		q, err := tsearch.DecodeTSQuery(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTSQuery(tree.DTSQuery{TSQuery: q}), b, nil
 	case types.Oid:
 		b, data, err := encoding.DecodeUntaggedIntValue(buf)
 		return a.NewDOid(tree.MakeDOid(tree.DInt(data))), b, err
@@ -534,6 +615,13 @@ func decodeUntaggedDatum(a *DatumAlloc, t types.T, buf []byte) (tree.Datum, []by
 		case types.TCollatedString:
 			b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 			return tree.NewDCollatedString(string(data), typ.Locale, &a.env), b, err
+		case types.TEnum:
+			b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+			if err != nil {
+				return nil, b, err
+			}
+			d, err := tree.MakeDEnumFromPhysicalRepresentation(typ, data)
+			return d, b, err
 		case types.TArray:
 			return decodeArray(a, typ.Typ, buf)
 		case types.TTuple:
@@ -630,6 +718,11 @@ func MarshalColumnValue(col ColumnDescriptor, val tree.Datum) (roachpb.Value, er
 			r.SetInt(int64(*v))
 			return r, nil
 		}
+	case ColumnType_TIMETZ:
+		if v, ok := val.(*tree.DTimeTZ); ok {
+			r.SetBytes(encoding.EncodeUntaggedTimeTZValue(nil, int64(v.TimeOfDay), v.OffsetSecs))
+			return r, nil
+		}
 	case ColumnType_TIMESTAMP:
 		if v, ok := val.(*tree.DTimestamp); ok {
 			r.SetTime(v.Time)
@@ -665,6 +758,16 @@ func MarshalColumnValue(col ColumnDescriptor, val tree.Datum) (roachpb.Value, er
 			r.SetBytes(data)
 			return r, nil
 		}
+	case ColumnType_TSVECTOR:
+		if v, ok := val.(*tree.DTSVector); ok {
+			r.SetBytes(tsearch.EncodeTSVector(nil, v.TSVector))
+			return r, nil
+		}
+	case ColumnType_TSQUERY:
+		if v, ok := val.(*tree.DTSQuery); ok {
+			r.SetBytes(tsearch.EncodeTSQuery(nil, v.TSQuery))
+			return r, nil
+		}
 	case ColumnType_ARRAY:
 		if v, ok := val.(*tree.DArray); ok {
 			if err := checkElementType(v.ParamTyp, col.Type); err != nil {
@@ -698,6 +801,11 @@ func MarshalColumnValue(col ColumnDescriptor, val tree.Datum) (roachpb.Value, er
 			r.SetInt(int64(v.DInt))
 			return r, nil
 		}
+	case ColumnType_ENUM:
+		if v, ok := val.(*tree.DEnum); ok {
+			r.SetBytes(v.PhysicalRep)
+			return r, nil
+		}
 	default:
 		return r, pgerror.NewAssertionErrorf("unsupported column type: %s", col.Type.SemanticType)
 	}
@@ -771,6 +879,16 @@ func UnmarshalColumnValue(a *DatumAlloc, typ ColumnType, value roachpb.Value) (t
 			return nil, err
 		}
 		return a.NewDTime(tree.DTime(v)), nil
+	case ColumnType_TIMETZ:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		_, micros, offsetSecs, err := encoding.DecodeUntaggedTimeTZValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeOfDay: timeofday.TimeOfDay(micros), OffsetSecs: offsetSecs}), nil
 	case ColumnType_TIMESTAMP:
 		v, err := value.GetTime()
 		if err != nil {
@@ -795,6 +913,12 @@ func UnmarshalColumnValue(a *DatumAlloc, typ ColumnType, value roachpb.Value) (t
 			return nil, err
 		}
 		return tree.NewDCollatedString(string(v), *typ.Locale, &a.env), nil
+	case ColumnType_ENUM:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.MakeDEnumFromPhysicalRepresentation(typ.ToDatumType().(types.TEnum), v)
 	case ColumnType_UUID:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -828,6 +952,26 @@ func UnmarshalColumnValue(a *DatumAlloc, typ ColumnType, value roachpb.Value) (t
 			return nil, err
 		}
 		return a.NewDOid(tree.MakeDOid(tree.DInt(v))), nil
+	case ColumnType_TSVECTOR:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		d, err := tsearch.DecodeTSVector(v)
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDTSVector(tree.DTSVector{TSVector: d}), nil
+	case ColumnType_TSQUERY:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		q, err := tsearch.DecodeTSQuery(v)
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDTSQuery(tree.DTSQuery{TSQuery: q}), nil
 	default:
 		return nil, errors.Errorf("unsupported column type: %s", typ.SemanticType)
 	}
@@ -1064,6 +1208,8 @@ func datumTypeToArrayElementEncodingType(t types.T) (encoding.Type, error) {
 	// persisted with incorrect elementType values.
 	case types.Date, types.Time:
 		return encoding.Int, nil
+	case types.TimeTZ:
+		return encoding.TimeTZ, nil
 	case types.Interval:
 		return encoding.Duration, nil
 	case types.Bool:
@@ -1126,6 +1272,8 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedIntValue(b, int64(*t)), nil
 	case *tree.DTime:
 		return encoding.EncodeUntaggedIntValue(b, int64(*t)), nil
+	case *tree.DTimeTZ:
+		return encoding.EncodeUntaggedTimeTZValue(b, int64(t.TimeOfDay), t.OffsetSecs), nil
 	case *tree.DTimestamp:
 		return encoding.EncodeUntaggedTimeValue(b, t.Time), nil
 	case *tree.DTimestampTZ:
@@ -71,6 +71,19 @@ func DatumTypeToColumnType(ptyp types.T) (ColumnType, error) {
 	case types.TCollatedString:
 		ctyp.SemanticType = ColumnType_COLLATEDSTRING
 		ctyp.Locale = &t.Locale
+	case types.TEnum:
+		if t.TypeID == 0 {
+			return ColumnType{}, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"unsupported result type: %s", t)
+		}
+		ctyp.SemanticType = ColumnType_ENUM
+		ctyp.EnumMetadata = &EnumMetadata{
+			TypeID:           ID(t.TypeID),
+			TypeName:         t.Name,
+			LogicalReps:      t.LogicalReps,
+			PhysicalReps:     t.PhysicalReps,
+			IsMemberReadOnly: t.IsMemberReadOnly,
+		}
 	case types.TArray:
 		ctyp.SemanticType = ColumnType_ARRAY
 		contents, err := datumTypeToColumnSemanticType(t.Typ)
@@ -182,17 +195,27 @@ func PopulateTypeAttrs(base ColumnType, typ coltypes.T) (ColumnType, error) {
 			return ColumnType{}, errors.Errorf("vectors of type %s are unsupported", t.ParamType)
 		}
 
+	case *coltypes.TUserDefined:
+		if !t.IsResolved() {
+			return ColumnType{}, tree.NewUndefinedTypeError(t)
+		}
+
+	case *coltypes.TTime, *coltypes.TTimeTZ, *coltypes.TTimestamp,
+		*coltypes.TTimestampTZ, *coltypes.TInterval:
+		if prec, ok := coltypes.TimePrecision(t); ok {
+			base.Precision = int32(prec)
+			base.TimePrecisionIsSet = true
+		}
+
 	case *coltypes.TBool:
 	case *coltypes.TBytes:
 	case *coltypes.TDate:
 	case *coltypes.TIPAddr:
-	case *coltypes.TInterval:
 	case *coltypes.TJSON:
 	case *coltypes.TName:
 	case *coltypes.TOid:
-	case *coltypes.TTime:
-	case *coltypes.TTimestamp:
-	case *coltypes.TTimestampTZ:
+	case *coltypes.TTSQuery:
+	case *coltypes.TTSVector:
 	case *coltypes.TUUID:
 	default:
 		return ColumnType{}, errors.Errorf("unexpected type %T", t)
@@ -301,8 +324,15 @@ func (c *ColumnType) SQLString() string {
 			}
 			return fmt.Sprintf("%s(%d)", c.SemanticType.String(), c.Precision)
 		}
+	case ColumnType_TIME, ColumnType_TIMETZ, ColumnType_TIMESTAMP,
+		ColumnType_TIMESTAMPTZ, ColumnType_INTERVAL:
+		if c.TimePrecisionIsSet {
+			return fmt.Sprintf("%s(%d)", c.SemanticType.String(), c.Precision)
+		}
 	case ColumnType_ARRAY:
 		return c.elementColumnType().SQLString() + "[]"
+	case ColumnType_ENUM:
+		return (&coltypes.TUserDefined{Name: c.EnumMetadata.TypeName}).String()
 	}
 	if c.VisibleType != ColumnType_NONE {
 		return c.VisibleType.String()
@@ -363,6 +393,8 @@ func (c *ColumnType) InformationSchemaVisibleType() string {
 		return "numeric"
 	case ColumnType_TIMESTAMPTZ:
 		return "timestamp with time zone"
+	case ColumnType_TIMETZ:
+		return "time with time zone"
 	case ColumnType_BYTES:
 		return "bytea"
 	case ColumnType_NULL:
@@ -371,6 +403,8 @@ func (c *ColumnType) InformationSchemaVisibleType() string {
 		return "record"
 	case ColumnType_ARRAY:
 		return "ARRAY"
+	case ColumnType_ENUM:
+		return "USER-DEFINED"
 	}
 
 	// The name of the remaining semantic type constants are suitable
@@ -484,6 +518,28 @@ func (c *ColumnType) NumericScale() (int32, bool) {
 	return 0, false
 }
 
+// DatetimePrecision returns the declared or implicit number of fractional
+// digits of the seconds of date and time data types. Returns false if the
+// data type is not a date or time type.
+//
+// This is used to populate information_schema.columns.datetime_precision;
+// do not modify this function unless you also check that the values
+// generated in information_schema are compatible with client
+// expectations.
+func (c *ColumnType) DatetimePrecision() (int32, bool) {
+	switch c.SemanticType {
+	case ColumnType_DATE:
+		return 0, true
+	case ColumnType_TIME, ColumnType_TIMETZ, ColumnType_TIMESTAMP,
+		ColumnType_TIMESTAMPTZ, ColumnType_INTERVAL:
+		if c.TimePrecisionIsSet {
+			return c.Precision, true
+		}
+		return coltypes.MaxTimePrecision, true
+	}
+	return 0, false
+}
+
 // FloatProperties returns the width and precision for a FLOAT column type.
 func (c *ColumnType) FloatProperties() (int32, int32) {
 	switch c.VisibleType {
@@ -529,6 +585,8 @@ func datumTypeToColumnSemanticType(ptyp types.T) (ColumnType_SemanticType, error
 		return ColumnType_DATE, nil
 	case types.Time:
 		return ColumnType_TIME, nil
+	case types.TimeTZ:
+		return ColumnType_TIMETZ, nil
 	case types.Timestamp:
 		return ColumnType_TIMESTAMP, nil
 	case types.TimestampTZ:
@@ -549,6 +607,10 @@ func datumTypeToColumnSemanticType(ptyp types.T) (ColumnType_SemanticType, error
 		return ColumnType_OIDVECTOR, nil
 	case types.JSON:
 		return ColumnType_JSONB, nil
+	case types.TSVector:
+		return ColumnType_TSVECTOR, nil
+	case types.TSQuery:
+		return ColumnType_TSQUERY, nil
 	default:
 		if ptyp.FamilyEqual(types.FamCollatedString) {
 			return ColumnType_COLLATEDSTRING, nil
@@ -556,6 +618,9 @@ func datumTypeToColumnSemanticType(ptyp types.T) (ColumnType_SemanticType, error
 		if ptyp.FamilyEqual(types.FamTuple) {
 			return ColumnType_TUPLE, nil
 		}
+		if ptyp.FamilyEqual(types.FamEnum) {
+			return ColumnType_ENUM, nil
+		}
 		if wrapper, ok := ptyp.(types.TOidWrapper); ok {
 			return datumTypeToColumnSemanticType(wrapper.T)
 		}
@@ -586,6 +651,8 @@ func columnSemanticTypeToDatumType(c *ColumnType, k ColumnType_SemanticType) typ
 		return types.Date
 	case ColumnType_TIME:
 		return types.Time
+	case ColumnType_TIMETZ:
+		return types.TimeTZ
 	case ColumnType_TIMESTAMP:
 		return types.Timestamp
 	case ColumnType_TIMESTAMPTZ:
@@ -598,6 +665,10 @@ func columnSemanticTypeToDatumType(c *ColumnType, k ColumnType_SemanticType) typ
 		return types.INet
 	case ColumnType_JSONB:
 		return types.JSON
+	case ColumnType_TSVECTOR:
+		return types.TSVector
+	case ColumnType_TSQUERY:
+		return types.TSQuery
 	case ColumnType_TUPLE:
 		return types.FamTuple
 	case ColumnType_COLLATEDSTRING:
@@ -605,6 +676,17 @@ func columnSemanticTypeToDatumType(c *ColumnType, k ColumnType_SemanticType) typ
 			panic("locale is required for COLLATEDSTRING")
 		}
 		return types.TCollatedString{Locale: *c.Locale}
+	case ColumnType_ENUM:
+		if c.EnumMetadata == nil {
+			panic("enum metadata is required for ENUM")
+		}
+		return types.TEnum{
+			TypeID:           uint32(c.EnumMetadata.TypeID),
+			Name:             c.EnumMetadata.TypeName,
+			LogicalReps:      c.EnumMetadata.LogicalReps,
+			PhysicalReps:     c.EnumMetadata.PhysicalReps,
+			IsMemberReadOnly: c.EnumMetadata.IsMemberReadOnly,
+		}
 	case ColumnType_NAME:
 		return types.Name
 	case ColumnType_OID:
@@ -728,6 +810,11 @@ func LimitValueWidth(
 			}
 			return &outDec, nil
 		}
+	case ColumnType_TIME, ColumnType_TIMETZ, ColumnType_TIMESTAMP,
+		ColumnType_TIMESTAMPTZ, ColumnType_INTERVAL:
+		if typ.TimePrecisionIsSet {
+			return tree.RoundDatumToTimePrecision(inVal, int(typ.Precision)), nil
+		}
 	case ColumnType_ARRAY:
 		if inArr, ok := inVal.(*tree.DArray); ok {
 			var outArr *tree.DArray
@@ -748,7 +835,7 @@ func LimitValueWidth(
 					}
 				}
 				if outArr != nil {
-					outArr.Array[i] = inElem
+					outArr.Array[i] = outElem
 				}
 			}
 			if outArr != nil {
@@ -28,12 +28,15 @@ type DatumAlloc struct {
 	ddecimalAlloc     []tree.DDecimal
 	ddateAlloc        []tree.DDate
 	dtimeAlloc        []tree.DTime
+	dtimeTZAlloc      []tree.DTimeTZ
 	dtimestampAlloc   []tree.DTimestamp
 	dtimestampTzAlloc []tree.DTimestampTZ
 	dintervalAlloc    []tree.DInterval
 	duuidAlloc        []tree.DUuid
 	dipnetAlloc       []tree.DIPAddr
 	djsonAlloc        []tree.DJSON
+	dtsvectorAlloc    []tree.DTSVector
+	dtsqueryAlloc     []tree.DTSQuery
 	dtupleAlloc       []tree.DTuple
 	doidAlloc         []tree.DOid
 	scratch           []byte
@@ -159,6 +162,18 @@ func (a *DatumAlloc) NewDTime(v tree.DTime) *tree.DTime {
 	return r
 }
 
+// NewDTimeTZ allocates a DTimeTZ.
+func (a *DatumAlloc) NewDTimeTZ(v tree.DTimeTZ) *tree.DTimeTZ {
+	buf := &a.dtimeTZAlloc
+	if len(*buf) == 0 {
+		*buf = make([]tree.DTimeTZ, datumAllocSize)
+	}
+	r := &(*buf)[0]
+	*r = v
+	*buf = (*buf)[1:]
+	return r
+}
+
 // NewDTimestamp allocates a DTimestamp.
 func (a *DatumAlloc) NewDTimestamp(v tree.DTimestamp) *tree.DTimestamp {
 	buf := &a.dtimestampAlloc
@@ -231,6 +246,30 @@ func (a *DatumAlloc) NewDJSON(v tree.DJSON) *tree.DJSON {
 	return r
 }
 
+// NewDTSVector allocates a DTSVector.
+func (a *DatumAlloc) NewDTSVector(v tree.DTSVector) *tree.DTSVector {
+	buf := &a.dtsvectorAlloc
+	if len(*buf) == 0 {
+		*buf = make([]tree.DTSVector, datumAllocSize)
+	}
+	r := &(*buf)[0]
+	*r = v
+	*buf = (*buf)[1:]
+	return r
+}
+
+// NewDTSQuery allocates a DTSQuery.
+func (a *DatumAlloc) NewDTSQuery(v tree.DTSQuery) *tree.DTSQuery {
+	buf := &a.dtsqueryAlloc
+	if len(*buf) == 0 {
+		*buf = make([]tree.DTSQuery, datumAllocSize)
+	}
+	r := &(*buf)[0]
+	*r = v
+	*buf = (*buf)[1:]
+	return r
+}
+
 // NewDTuple allocates a DTuple.
 func (a *DatumAlloc) NewDTuple(v tree.DTuple) *tree.DTuple {
 	buf := &a.dtupleAlloc
@@ -82,11 +82,11 @@ func NewInvalidSchemaDefinitionError(err error) error {
 	return pgerror.NewError(pgerror.CodeInvalidSchemaDefinitionError, err.Error())
 }
 
-// NewUnsupportedSchemaUsageError creates an error for an invalid
-// schema use, e.g. mydb.someschema.tbl.
-func NewUnsupportedSchemaUsageError(name string) error {
+// NewUndefinedSchemaError creates an error for a reference to a schema
+// which does not exist, e.g. mydb.someschema.tbl.
+func NewUndefinedSchemaError(name string) error {
 	return pgerror.NewErrorf(pgerror.CodeInvalidSchemaNameError,
-		"unsupported schema specification: %q", name)
+		"schema %q does not exist", name)
 }
 
 // NewCCLRequiredError creates an error for when a CCL feature is used in an OSS
@@ -140,6 +140,28 @@ func NewRelationAlreadyExistsError(name string) error {
 	return pgerror.NewErrorf(pgerror.CodeDuplicateRelationError, "relation %q already exists", name)
 }
 
+// NewUndefinedFunctionError creates an error that represents a missing
+// user-defined function.
+func NewUndefinedFunctionError(name tree.NodeFormatter) error {
+	return pgerror.NewErrorf(pgerror.CodeUndefinedFunctionError,
+		"function %q does not exist", tree.ErrString(name))
+}
+
+// NewFunctionAlreadyExistsError creates an error for a preexisting function.
+func NewFunctionAlreadyExistsError(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeDuplicateFunctionError, "function %q already exists", name)
+}
+
+// NewTypeAlreadyExistsError creates an error for a preexisting type.
+func NewTypeAlreadyExistsError(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError, "type %q already exists", name)
+}
+
+// NewSchemaAlreadyExistsError creates an error for a preexisting schema.
+func NewSchemaAlreadyExistsError(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeDuplicateSchemaError, "schema %q already exists", name)
+}
+
 // NewWrongObjectTypeError creates a wrong object type error.
 func NewWrongObjectTypeError(name *tree.TableName, desiredObjType string) error {
 	return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError, "%q is not a %s",
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/pkg/errors"
 )
 
@@ -693,10 +694,11 @@ func EncodeInvertedIndexKeys(
 	return EncodeInvertedIndexTableKeys(val, keyPrefix)
 }
 
-// EncodeInvertedIndexTableKeys encodes the paths in a JSON `val` and
-// concatenates it with `inKey`and returns a list of buffers per
-// path. The encoded values is guaranteed to be lexicographically
-// sortable, but not guaranteed to be round-trippable during decoding.
+// EncodeInvertedIndexTableKeys encodes the paths in a JSON `val`, or the
+// lexemes in a tsvector `val`, and concatenates it with `inKey`and returns a
+// list of buffers per path or lexeme. The encoded values is guaranteed to be
+// lexicographically sortable, but not guaranteed to be round-trippable during
+// decoding.
 func EncodeInvertedIndexTableKeys(val tree.Datum, inKey []byte) (key [][]byte, err error) {
 	if val == tree.DNull {
 		return [][]byte{encoding.EncodeNullAscending(inKey)}, nil
@@ -704,8 +706,10 @@ func EncodeInvertedIndexTableKeys(val tree.Datum, inKey []byte) (key [][]byte, e
 	switch t := tree.UnwrapDatum(nil, val).(type) {
 	case *tree.DJSON:
 		return json.EncodeInvertedIndexKeys(inKey, (t.JSON))
+	case *tree.DTSVector:
+		return tsearch.EncodeInvertedIndexKeys(inKey, t.TSVector), nil
 	}
-	return nil, pgerror.NewAssertionErrorf("trying to apply inverted index to non JSON type")
+	return nil, pgerror.NewAssertionErrorf("trying to apply inverted index to non JSON or tsvector type")
 }
 
 // EncodeSecondaryIndex encodes key/values for a secondary
@@ -0,0 +1,87 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sqlbase
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// ToScanLockingStrength converts a tree.LockingStrength to its corresponding
+// ScanLockingStrength.
+func ToScanLockingStrength(s tree.LockingStrength) ScanLockingStrength {
+	switch s {
+	case tree.ForNone:
+		return ScanLockingStrength_FOR_NONE
+	case tree.ForKeyShare:
+		return ScanLockingStrength_FOR_KEY_SHARE
+	case tree.ForShare:
+		return ScanLockingStrength_FOR_SHARE
+	case tree.ForNoKeyUpdate:
+		return ScanLockingStrength_FOR_NO_KEY_UPDATE
+	case tree.ForUpdate:
+		return ScanLockingStrength_FOR_UPDATE
+	default:
+		panic(fmt.Sprintf("unknown locking strength %s", s))
+	}
+}
+
+// ToScanLockingWaitPolicy converts a tree.LockingWaitPolicy to its
+// corresponding ScanLockingWaitPolicy.
+func ToScanLockingWaitPolicy(wp tree.LockingWaitPolicy) ScanLockingWaitPolicy {
+	switch wp {
+	case tree.LockWaitBlock:
+		return ScanLockingWaitPolicy_BLOCK
+	case tree.LockWaitSkip:
+		return ScanLockingWaitPolicy_SKIP
+	case tree.LockWaitError:
+		return ScanLockingWaitPolicy_ERROR
+	default:
+		panic(fmt.Sprintf("unknown locking wait policy %s", wp))
+	}
+}
+
+// ToKeyLockingStrength returns the strength of the KV-level locks that a scan
+// with the provided row-level locking strength acquires. The KV layer only
+// supports exclusive locks, so the shared row-level locking modes are upgraded
+// to exclusive locks, see ScanLockingStrength.
+func (s ScanLockingStrength) ToKeyLockingStrength() roachpb.KeyLockingStrength {
+	switch s {
+	case ScanLockingStrength_FOR_NONE:
+		return roachpb.NO_KEY_LOCKING
+	case ScanLockingStrength_FOR_KEY_SHARE, ScanLockingStrength_FOR_SHARE,
+		ScanLockingStrength_FOR_NO_KEY_UPDATE, ScanLockingStrength_FOR_UPDATE:
+		return roachpb.EXCLUSIVE_KEY_LOCKING
+	default:
+		panic(fmt.Sprintf("unknown locking strength %s", s))
+	}
+}
+
+// ToWaitPolicy returns the KV-level wait policy corresponding to the
+// row-level locking wait policy.
+func (wp ScanLockingWaitPolicy) ToWaitPolicy() roachpb.WaitPolicy {
+	switch wp {
+	case ScanLockingWaitPolicy_BLOCK:
+		return roachpb.BLOCK_ON_CONFLICT
+	case ScanLockingWaitPolicy_SKIP:
+		return roachpb.SKIP_ON_CONFLICT
+	case ScanLockingWaitPolicy_ERROR:
+		return roachpb.ERROR_ON_CONFLICT
+	default:
+		panic(fmt.Sprintf("unknown locking wait policy %s", wp))
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sqlbase
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/pkg/errors"
+)
+
+// PartialIndexPredicates evaluates the predicates of the partial indexes of a
+// table, to determine which of these indexes contain an entry for a given
+// row. A nil *PartialIndexPredicates is valid and treats every index as a
+// full index.
+//
+// The predicates of partial indexes only contain immutable expressions (this
+// is checked when the index is created), so they are evaluated in an
+// EvalContext that is independent of the session.
+type PartialIndexPredicates struct {
+	exprs   map[IndexID]tree.TypedExpr
+	ivars   RowIndexedVarContainer
+	evalCtx tree.EvalContext
+}
+
+// NewPartialIndexPredicates parses and type checks the predicates of the
+// partial indexes among the given indexes of the table. It returns nil if none
+// of the indexes is a partial index.
+func NewPartialIndexPredicates(
+	tableDesc *TableDescriptor, indexes []IndexDescriptor,
+) (*PartialIndexPredicates, error) {
+	var p *PartialIndexPredicates
+	for i := range indexes {
+		index := &indexes[i]
+		if !index.IsPartial() {
+			continue
+		}
+		if p == nil {
+			p = &PartialIndexPredicates{
+				exprs: make(map[IndexID]tree.TypedExpr),
+				ivars: RowIndexedVarContainer{Cols: tableDesc.Columns},
+				evalCtx: tree.EvalContext{
+					SessionData: &sessiondata.SessionData{},
+					Context:     context.TODO(),
+				},
+			}
+		}
+		expr, err := parsePartialIndexPredicate(tableDesc, index)
+		if err != nil {
+			return nil, err
+		}
+		p.exprs[index.ID] = expr
+	}
+	return p, nil
+}
+
+// parsePartialIndexPredicate returns the type checked predicate of the given
+// partial index, in which column references are indexed vars that refer to
+// the columns of the table.
+func parsePartialIndexPredicate(
+	tableDesc *TableDescriptor, index *IndexDescriptor,
+) (tree.TypedExpr, error) {
+	raw, err := parser.ParseExpr(index.Predicate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse predicate of index %q", index.Name)
+	}
+
+	iv := &descContainer{tableDesc.Columns}
+	ivarHelper := tree.MakeIndexedVarHelper(iv, len(tableDesc.Columns))
+	source := NewSourceInfoForSingleTable(
+		tree.MakeUnqualifiedTableName(tree.Name(tableDesc.Name)),
+		ResultColumnsFromColDescs(tableDesc.Columns),
+	)
+	expr, _, _, err := ResolveNames(raw, MakeMultiSourceInfo(source), ivarHelper, DefaultSearchPath)
+	if err != nil {
+		return nil, err
+	}
+
+	semaCtx := tree.MakeSemaContext()
+	semaCtx.IVarContainer = iv
+	typedExpr, err := tree.TypeCheckAndRequire(expr, &semaCtx, types.Bool, "index predicate")
+	if err != nil {
+		return nil, err
+	}
+	return typedExpr, nil
+}
+
+// Satisfies returns true if the given index has an entry for the row, i.e. if
+// the index is not a partial index or if the row satisfies the predicate of
+// the index. colMap maps ColumnIDs to indices in values.
+func (p *PartialIndexPredicates) Satisfies(
+	index *IndexDescriptor, colMap map[ColumnID]int, values []tree.Datum,
+) (bool, error) {
+	if p == nil || !index.IsPartial() {
+		return true, nil
+	}
+	expr, ok := p.exprs[index.ID]
+	if !ok {
+		return false, errors.Errorf("missing predicate for partial index %q", index.Name)
+	}
+	p.ivars.CurSourceRow = values
+	p.ivars.Mapping = colMap
+	p.evalCtx.IVarContainer = &p.ivars
+	d, err := expr.Eval(&p.evalCtx)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
+
+// ColumnIDs returns the IDs of the columns referenced by the predicate of the
+// given index, which must be a partial index.
+func (p *PartialIndexPredicates) ColumnIDs(index *IndexDescriptor) []ColumnID {
+	v := predicateColumnsVisitor{cols: p.ivars.Cols}
+	tree.WalkExprConst(&v, p.exprs[index.ID])
+	return v.ids
+}
+
+// PredicateUsesColumn returns whether the predicate of the index uses the
+// specified column. It returns false if the index is not a partial index.
+func (desc *IndexDescriptor) PredicateUsesColumn(
+	tableDesc *TableDescriptor, colID ColumnID,
+) (bool, error) {
+	if !desc.IsPartial() {
+		return false, nil
+	}
+	expr, err := parsePartialIndexPredicate(tableDesc, desc)
+	if err != nil {
+		return false, err
+	}
+	v := predicateColumnsVisitor{cols: tableDesc.Columns}
+	tree.WalkExprConst(&v, expr)
+	for _, id := range v.ids {
+		if id == colID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// predicateColumnsVisitor collects the IDs of the columns that are referenced
+// by indexed vars in an expression.
+type predicateColumnsVisitor struct {
+	cols []ColumnDescriptor
+	ids  []ColumnID
+}
+
+var _ tree.Visitor = &predicateColumnsVisitor{}
+
+// VisitPre is part of the Visitor interface.
+func (v *predicateColumnsVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if ivar, ok := expr.(*tree.IndexedVar); ok {
+		v.ids = append(v.ids, v.cols[ivar.Idx].ID)
+		return false, expr
+	}
+	return true, expr
+}
+
+// VisitPost is part of the Visitor interface.
+func (*predicateColumnsVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
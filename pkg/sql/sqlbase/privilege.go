@@ -140,7 +140,12 @@ func (p *PrivilegeDescriptor) Grant(user string, privList privilege.List) {
 }
 
 // Revoke removes privileges from this descriptor for a given list of users.
-func (p *PrivilegeDescriptor) Revoke(user string, privList privilege.List) {
+// validPrivs is the set of privileges that apply to the kind of object the
+// descriptor belongs to; a user holding ALL is left with the remainder of
+// that set.
+func (p *PrivilegeDescriptor) Revoke(
+	user string, privList privilege.List, validPrivs privilege.List,
+) {
 	userPriv, ok := p.findUser(user)
 	if !ok || userPriv.Privileges == 0 {
 		// Removing privileges from a user without privileges is a no-op.
@@ -160,7 +165,7 @@ func (p *PrivilegeDescriptor) Revoke(user string, privList privilege.List) {
 		// User has 'ALL' privilege. Remove it and set
 		// all other privileges one.
 		userPriv.Privileges = 0
-		for _, v := range privilege.ByValue {
+		for _, v := range validPrivs {
 			if v != privilege.ALL {
 				userPriv.Privileges |= v.Mask()
 			}
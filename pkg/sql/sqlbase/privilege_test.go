@@ -99,7 +99,7 @@ func TestPrivilege(t *testing.T) {
 				descriptor.Grant(tc.grantee, tc.grant)
 			}
 			if tc.revoke != nil {
-				descriptor.Revoke(tc.grantee, tc.revoke)
+				descriptor.Revoke(tc.grantee, tc.revoke, privilege.DBTablePrivileges)
 			}
 		}
 		show := descriptor.Show()
@@ -205,7 +205,7 @@ func TestPrivilegeValidate(t *testing.T) {
 	if err := descriptor.Validate(id); err != nil {
 		t.Fatal(err)
 	}
-	descriptor.Revoke(security.RootUser, privilege.List{privilege.SELECT})
+	descriptor.Revoke(security.RootUser, privilege.List{privilege.SELECT}, privilege.DBTablePrivileges)
 	if err := descriptor.Validate(id); err == nil {
 		t.Fatal("unexpected success")
 	}
@@ -215,7 +215,7 @@ func TestPrivilegeValidate(t *testing.T) {
 	if err := descriptor.Validate(id); err == nil {
 		t.Fatal("unexpected success")
 	}
-	descriptor.Revoke(security.RootUser, privilege.List{privilege.ALL})
+	descriptor.Revoke(security.RootUser, privilege.List{privilege.ALL}, privilege.DBTablePrivileges)
 	if err := descriptor.Validate(id); err == nil {
 		t.Fatal("unexpected success")
 	}
@@ -277,7 +277,8 @@ func TestSystemPrivilegeValidate(t *testing.T) {
 		}
 
 		// Valid: foo can have privileges revoked, including privileges it doesn't currently have.
-		descriptor.Revoke("foo", privilege.List{privilege.GRANT, privilege.UPDATE, privilege.ALL})
+		descriptor.Revoke("foo", privilege.List{privilege.GRANT, privilege.UPDATE, privilege.ALL},
+			privilege.DBTablePrivileges)
 		if err := descriptor.Validate(id); err != nil {
 			t.Fatal(err)
 		}
@@ -298,14 +299,14 @@ func TestSystemPrivilegeValidate(t *testing.T) {
 
 		// Invalid: root's invalid privileges are revoked and replaced with allowable privileges,
 		// but admin is still wrong.
-		descriptor.Revoke(security.RootUser, privilege.List{privilege.UPDATE})
+		descriptor.Revoke(security.RootUser, privilege.List{privilege.UPDATE}, privilege.DBTablePrivileges)
 		descriptor.Grant(security.RootUser, privilege.List{privilege.SELECT, privilege.GRANT})
 		if err := descriptor.Validate(id); !testutils.IsError(err, adminWrongPrivilegesErr) {
 			t.Fatalf("expected err=%s, got err=%v", adminWrongPrivilegesErr, err)
 		}
 
 		// Valid: admin's invalid privileges are revoked and replaced with allowable privileges.
-		descriptor.Revoke(AdminRole, privilege.List{privilege.UPDATE})
+		descriptor.Revoke(AdminRole, privilege.List{privilege.UPDATE}, privilege.DBTablePrivileges)
 		descriptor.Grant(AdminRole, privilege.List{privilege.SELECT, privilege.GRANT})
 		if err := descriptor.Validate(id); err != nil {
 			t.Fatal(err)
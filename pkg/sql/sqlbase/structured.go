@@ -281,6 +281,23 @@ func GetDatabaseDescFromID(
 	return db, nil
 }
 
+// GetSchemaDescFromID retrieves the descriptor of the user-defined schema
+// with the ID passed in using an existing txn. Returns an error if the
+// descriptor doesn't exist or if it exists and is not a schema.
+func GetSchemaDescFromID(ctx context.Context, txn *client.Txn, id ID) (*SchemaDescriptor, error) {
+	desc := &Descriptor{}
+	descKey := MakeDescMetadataKey(id)
+
+	if err := txn.GetProto(ctx, descKey, desc); err != nil {
+		return nil, err
+	}
+	sc := desc.GetSchema()
+	if sc == nil {
+		return nil, ErrDescriptorNotFound
+	}
+	return sc, nil
+}
+
 // GetTableDescFromID retrieves the table descriptor for the table
 // ID passed in using an existing txn. Returns an error if the
 // descriptor doesn't exist or if it exists and is not a table.
@@ -476,6 +493,12 @@ func (desc *IndexDescriptor) IsInterleaved() bool {
 	return len(desc.Interleave.Ancestors) > 0 || len(desc.InterleavedBy) > 0
 }
 
+// IsPartial returns whether the index is a partial index, i.e. whether it only
+// contains entries for the rows that satisfy its predicate.
+func (desc *IndexDescriptor) IsPartial() bool {
+	return desc.Predicate != ""
+}
+
 // SetID implements the DescriptorProto interface.
 func (desc *TableDescriptor) SetID(id ID) {
 	desc.ID = id
@@ -509,6 +532,31 @@ func (desc *TableDescriptor) IsView() bool {
 	return desc.ViewQuery != ""
 }
 
+// MaterializedView returns true if the TableDescriptor describes a
+// materialized view. Materialized views are views, but their rows are
+// stored in the KV layer like those of a table.
+func (desc *TableDescriptor) MaterializedView() bool {
+	return desc.IsMaterializedView
+}
+
+// HasBeforeRowTriggers returns true if the TableDescriptor has BEFORE ...
+// FOR EACH ROW triggers that fire for the given event. Such triggers can
+// modify the rows being written.
+func (desc *TableDescriptor) HasBeforeRowTriggers(event TriggerDescriptor_Event) bool {
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		if t.ActionTime != TriggerDescriptor_BEFORE || !t.ForEachRow {
+			continue
+		}
+		for _, e := range t.Events {
+			if e == event {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // IsSequence returns true if the TableDescriptor actually describes a
 // Sequence resource rather than a Table.
 func (desc *TableDescriptor) IsSequence() bool {
@@ -533,10 +581,11 @@ func IsVirtualTable(id ID) bool {
 // physical Table that needs to be stored in the kv layer, as opposed to a
 // different resource like a view or a virtual table. Physical tables have
 // primary keys, column families, and indexes (unlike virtual tables).
-// Sequences count as physical tables because their values are stored in
-// the KV layer.
+// Sequences and materialized views count as physical tables because their
+// values are stored in the KV layer.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
-	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable())
+	return desc.IsSequence() || desc.MaterializedView() ||
+		(desc.IsTable() && !desc.IsVirtualTable())
 }
 
 // KeysPerRow returns the maximum number of keys used to encode a row for the
@@ -798,7 +847,9 @@ func DatumTypeHasCompositeKeyEncoding(typ types.T) bool {
 func MustBeValueEncoded(semanticType ColumnType_SemanticType) bool {
 	return semanticType == ColumnType_ARRAY ||
 		semanticType == ColumnType_JSONB ||
-		semanticType == ColumnType_TUPLE
+		semanticType == ColumnType_TUPLE ||
+		semanticType == ColumnType_TSVECTOR ||
+		semanticType == ColumnType_TSQUERY
 }
 
 // HasOldStoredColumns returns whether the index has stored columns in the old
@@ -843,6 +894,11 @@ func (desc *MutableTableDescriptor) allocateIndexIDs(columnNames map[string]Colu
 			isCompositeColumn[col.ID] = struct{}{}
 		}
 	}
+	for _, m := range desc.Mutations {
+		if col := m.GetColumn(); col != nil && HasCompositeKeyEncoding(col.Type.SemanticType) {
+			isCompositeColumn[col.ID] = struct{}{}
+		}
+	}
 
 	// Populate IDs.
 	for _, index := range indexes {
@@ -1176,6 +1232,34 @@ func (desc *TableDescriptor) validateCrossReferences(ctx context.Context, txn *c
 	return nil
 }
 
+// validateTriggers validates the triggers of the table descriptor.
+func (desc *TableDescriptor) validateTriggers() error {
+	if len(desc.Triggers) > 0 && !desc.IsTable() {
+		return fmt.Errorf("triggers are only supported on tables, not on %q", desc.Name)
+	}
+	names := make(map[string]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		if err := validateName(t.Name, "trigger"); err != nil {
+			return err
+		}
+		if _, ok := names[t.Name]; ok {
+			return fmt.Errorf("duplicate trigger name: %q", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if len(t.Events) == 0 {
+			return fmt.Errorf("trigger %q has no events", t.Name)
+		}
+		if t.FunctionID == 0 {
+			return fmt.Errorf("invalid function ID %d for trigger %q", t.FunctionID, t.Name)
+		}
+		if t.IsConstraint && (t.ActionTime != TriggerDescriptor_AFTER || !t.ForEachRow) {
+			return fmt.Errorf("constraint trigger %q is not an AFTER ROW trigger", t.Name)
+		}
+	}
+	return nil
+}
+
 // ValidateTable validates that the table descriptor is well formed. Checks
 // include validating the table, column and index names, verifying that column
 // names and index names are unique and verifying that column IDs and index IDs
@@ -1204,6 +1288,16 @@ func (desc *TableDescriptor) ValidateTable(st *cluster.Settings) error {
 	if desc.ParentID == 0 {
 		return fmt.Errorf("invalid parent ID %d", desc.ParentID)
 	}
+	if desc.Temporary && desc.UnexposedParentSchemaID == 0 {
+		return fmt.Errorf("invalid temporary schema ID %d for temporary table",
+			desc.UnexposedParentSchemaID)
+	}
+	if desc.IsMaterializedView && !desc.IsView() {
+		return fmt.Errorf("materialized view %q has no view query", desc.Name)
+	}
+	if err := desc.validateTriggers(); err != nil {
+		return err
+	}
 
 	// We maintain forward compatibility, so if you see this error message with a
 	// version older that what this client supports, then there's a
@@ -1270,6 +1364,28 @@ func (desc *TableDescriptor) ValidateTable(st *cluster.Settings) error {
 				}
 			}
 		}
+		if !st.Version.IsActive(cluster.VersionTimeTZ) {
+			for _, def := range desc.AllNonDropColumns() {
+				if def.Type.SemanticType == ColumnType_TIMETZ ||
+					(def.Type.ArrayContents != nil && *def.Type.ArrayContents == ColumnType_TIMETZ) {
+					return fmt.Errorf("cluster version does not support TIMETZ (required: %s)",
+						cluster.VersionByKey(cluster.VersionTimeTZ))
+				}
+				if def.Type.TimePrecisionIsSet {
+					return fmt.Errorf("cluster version does not support time precision (required: %s)",
+						cluster.VersionByKey(cluster.VersionTimeTZ))
+				}
+			}
+		}
+		if !st.Version.IsActive(cluster.VersionTextSearch) {
+			for _, def := range desc.AllNonDropColumns() {
+				switch def.Type.SemanticType {
+				case ColumnType_TSVECTOR, ColumnType_TSQUERY:
+					return fmt.Errorf("cluster version does not support %s (required: %s)",
+						def.Type.SemanticType, cluster.VersionByKey(cluster.VersionTextSearch))
+				}
+			}
+		}
 	}
 
 	for _, m := range desc.Mutations {
@@ -1290,6 +1406,11 @@ func (desc *TableDescriptor) ValidateTable(st *cluster.Settings) error {
 			if unSetEnums {
 				return errors.Errorf("mutation in state %s, direction %s, constraint %v", m.State, m.Direction, desc.Constraint.Name)
 			}
+		case *DescriptorMutation_ComputedColumnSwap:
+			if unSetEnums {
+				return errors.Errorf("mutation in state %s, direction %s, computed column swap %d -> %d",
+					m.State, m.Direction, desc.ComputedColumnSwap.OldColumnID, desc.ComputedColumnSwap.NewColumnID)
+			}
 		default:
 			return errors.Errorf("mutation in state %s, direction %s, and no column/index descriptor", m.State, m.Direction)
 		}
@@ -1700,7 +1821,7 @@ func columnTypeIsIndexable(t ColumnType) bool {
 // columnTypeIsInvertedIndexable returns whether the type t is valid to be indexed
 // using an inverted index.
 func columnTypeIsInvertedIndexable(t ColumnType) bool {
-	return t.SemanticType == ColumnType_JSONB
+	return t.SemanticType == ColumnType_JSONB || t.SemanticType == ColumnType_TSVECTOR
 }
 
 func notIndexableError(cols []ColumnDescriptor, inverted bool) error {
@@ -2200,9 +2321,28 @@ func (desc *MutableTableDescriptor) MakeMutationComplete(m DescriptorMutation) e
 						break
 					}
 				}
+			case ConstraintToValidate_NOT_NULL:
+				// The column is made non-nullable and the check constraint
+				// that enforced the constraint during validation is removed.
+				col, err := desc.FindActiveColumnByID(t.Constraint.NotNullColumn)
+				if err != nil {
+					return err
+				}
+				col.Nullable = false
+				for i, c := range desc.Checks {
+					if c.Name == t.Constraint.Name {
+						desc.Checks = append(desc.Checks[:i], desc.Checks[i+1:]...)
+						break
+					}
+				}
 			default:
 				return errors.Errorf("unsupported constraint type: %d", t.Constraint.ConstraintType)
 			}
+
+		case *DescriptorMutation_ComputedColumnSwap:
+			if err := desc.performComputedColumnSwap(t.ComputedColumnSwap, m.MutationID); err != nil {
+				return err
+			}
 		}
 
 	case DescriptorMutation_DROP:
@@ -2216,6 +2356,105 @@ func (desc *MutableTableDescriptor) MakeMutationComplete(m DescriptorMutation) e
 	return nil
 }
 
+// performComputedColumnSwap completes a computed column swap. The new column
+// takes the name, position and default of the old column, and the new indexes
+// take the names and positions of the indexes they replace. The old column
+// and indexes are removed from the table, and DROP mutations for them are
+// queued with the given mutation ID. The old column is computed from the new
+// one while it is dropped.
+func (desc *MutableTableDescriptor) performComputedColumnSwap(
+	swap *ComputedColumnSwap, mutationID MutationID,
+) error {
+	oldCol, err := desc.FindActiveColumnByID(swap.OldColumnID)
+	if err != nil {
+		return err
+	}
+	newCol, err := desc.FindActiveColumnByID(swap.NewColumnID)
+	if err != nil {
+		return err
+	}
+	oldName, newName := oldCol.Name, newCol.Name
+	desc.RenameColumnDescriptor(*oldCol, newName)
+	desc.RenameColumnDescriptor(*newCol, oldName)
+	newCol.ComputeExpr = nil
+	newCol.DefaultExpr = swap.DefaultExpr
+
+	var drops []DescriptorMutation
+	columns := make([]ColumnDescriptor, 0, len(desc.Columns)-1)
+	for i := range desc.Columns {
+		switch col := &desc.Columns[i]; col.ID {
+		case swap.OldColumnID:
+			columns = append(columns, *newCol)
+			// Nodes that still use the previous version of the descriptor read
+			// and write the old column as the public one. It is computed from
+			// the new column until it is no longer readable, so that the two
+			// versions see the same values.
+			col.ComputeExpr = swap.InverseExpr
+			col.DefaultExpr = nil
+			drops = append(drops, DescriptorMutation{
+				Descriptor_: &DescriptorMutation_Column{Column: col},
+			})
+		case swap.NewColumnID:
+		default:
+			columns = append(columns, *col)
+		}
+	}
+	desc.Columns = columns
+
+	if len(swap.OldIndexIDs) != len(swap.NewIndexIDs) {
+		return errors.Errorf("mismatched old (%d) and new (%d) index IDs",
+			len(swap.OldIndexIDs), len(swap.NewIndexIDs))
+	}
+	replacements := make(map[IndexID]*IndexDescriptor, len(swap.OldIndexIDs))
+	for i := range swap.OldIndexIDs {
+		oldIdx, err := desc.FindIndexByID(swap.OldIndexIDs[i])
+		if err != nil {
+			return err
+		}
+		newIdx, err := desc.FindIndexByID(swap.NewIndexIDs[i])
+		if err != nil {
+			return err
+		}
+		oldIdx.Name, newIdx.Name = newIdx.Name, oldIdx.Name
+		replacements[oldIdx.ID] = newIdx
+	}
+	isReplacement := make(map[IndexID]bool, len(swap.NewIndexIDs))
+	for _, id := range swap.NewIndexIDs {
+		isReplacement[id] = true
+	}
+	indexes := make([]IndexDescriptor, 0, len(desc.Indexes)-len(replacements))
+	for i := range desc.Indexes {
+		idx := &desc.Indexes[i]
+		if newIdx, ok := replacements[idx.ID]; ok {
+			indexes = append(indexes, *newIdx)
+			drops = append(drops, DescriptorMutation{
+				Descriptor_: &DescriptorMutation_Index{Index: idx},
+			})
+		} else if !isReplacement[idx.ID] {
+			indexes = append(indexes, *idx)
+		}
+	}
+	desc.Indexes = indexes
+
+	// Queue the drops right after the other mutations with the same ID. The
+	// mutations are copied into a new slice so that callers that are
+	// iterating over them are not affected.
+	for i := range drops {
+		drops[i].State = DescriptorMutation_DELETE_AND_WRITE_ONLY
+		drops[i].Direction = DescriptorMutation_DROP
+		drops[i].MutationID = mutationID
+	}
+	pos := 0
+	for pos < len(desc.Mutations) && desc.Mutations[pos].MutationID <= mutationID {
+		pos++
+	}
+	mutations := make([]DescriptorMutation, 0, len(desc.Mutations)+len(drops))
+	mutations = append(mutations, desc.Mutations[:pos]...)
+	mutations = append(mutations, drops...)
+	desc.Mutations = append(mutations, desc.Mutations[pos:]...)
+	return nil
+}
+
 // AddCheckValidationMutation adds a check constraint mutation to desc.Mutations.
 func (desc *MutableTableDescriptor) AddCheckValidationMutation(name string) {
 	m := DescriptorMutation{
@@ -2229,6 +2468,33 @@ func (desc *MutableTableDescriptor) AddCheckValidationMutation(name string) {
 	desc.addMutation(m)
 }
 
+// AddNotNullValidationMutation adds a mutation to desc.Mutations that
+// validates the check constraint enforcing that the given column is not NULL,
+// and then makes the column non-nullable.
+func (desc *MutableTableDescriptor) AddNotNullValidationMutation(ckName string, colID ColumnID) {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_Constraint{
+			Constraint: &ConstraintToValidate{
+				ConstraintType: ConstraintToValidate_NOT_NULL,
+				Name:           ckName,
+				NotNullColumn:  colID,
+			},
+		},
+		Direction: DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
+// AddComputedColumnSwapMutation adds a computed column swap mutation to
+// desc.Mutations.
+func (desc *MutableTableDescriptor) AddComputedColumnSwapMutation(swap *ComputedColumnSwap) {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_ComputedColumnSwap{ComputedColumnSwap: swap},
+		Direction:   DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
 // AddColumnMutation adds a column mutation to desc.Mutations.
 func (desc *MutableTableDescriptor) AddColumnMutation(
 	c ColumnDescriptor, direction DescriptorMutation_Direction,
@@ -2430,6 +2696,172 @@ func (desc *DatabaseDescriptor) Validate() error {
 	return desc.Privileges.Validate(desc.GetID())
 }
 
+// SetID implements the DescriptorProto interface.
+func (desc *FunctionDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *FunctionDescriptor) TypeName() string {
+	return "function"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *FunctionDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// This is a stub until per-function auditing is implemented.
+func (desc *FunctionDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the function descriptor is well formed.
+func (desc *FunctionDescriptor) Validate() error {
+	if err := validateName(desc.Name, "function"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid function ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d for function %q", desc.ParentID, desc.Name)
+	}
+	if desc.Language != "sql" {
+		return fmt.Errorf("unsupported language %q for function %q", desc.Language, desc.Name)
+	}
+	if desc.ReturnsTrigger && len(desc.Args) > 0 {
+		return fmt.Errorf("trigger function %q cannot have arguments", desc.Name)
+	}
+	if !desc.ReturnsTrigger && len(desc.TriggerTableIDs) > 0 {
+		return fmt.Errorf("function %q is used by triggers but does not return type trigger", desc.Name)
+	}
+	seen := make(map[string]struct{}, len(desc.Args))
+	for i := range desc.Args {
+		name := desc.Args[i].Name
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("parameter name %q used more than once", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
+// ArgTypes returns the datum types of the function parameters.
+func (desc *FunctionDescriptor) ArgTypes() []types.T {
+	typs := make([]types.T, len(desc.Args))
+	for i := range desc.Args {
+		typs[i] = desc.Args[i].Type.ToDatumType()
+	}
+	return typs
+}
+
+// SetID implements the DescriptorProto interface.
+func (desc *TypeDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *TypeDescriptor) TypeName() string {
+	return "type"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *TypeDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// This is a stub until per-type auditing is implemented.
+func (desc *TypeDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the type descriptor is well formed.
+func (desc *TypeDescriptor) Validate() error {
+	if err := validateName(desc.Name, "type"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid type ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d for type %q", desc.ParentID, desc.Name)
+	}
+	seen := make(map[string]struct{}, len(desc.EnumMembers))
+	for i := range desc.EnumMembers {
+		m := &desc.EnumMembers[i]
+		if _, ok := seen[m.LogicalRepresentation]; ok {
+			return fmt.Errorf("enum label %q used more than once", m.LogicalRepresentation)
+		}
+		seen[m.LogicalRepresentation] = struct{}{}
+		if i > 0 && bytes.Compare(desc.EnumMembers[i-1].PhysicalRepresentation, m.PhysicalRepresentation) >= 0 {
+			return fmt.Errorf("enum members of type %q are not sorted", desc.Name)
+		}
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
+// EnumType returns the types.T of the values of the enum type.
+func (desc *TypeDescriptor) EnumType() types.TEnum {
+	typ := types.TEnum{
+		TypeID:       uint32(desc.ID),
+		Name:         desc.Name,
+		LogicalReps:  make([]string, len(desc.EnumMembers)),
+		PhysicalReps: make([][]byte, len(desc.EnumMembers)),
+	}
+	for i := range desc.EnumMembers {
+		typ.LogicalReps[i] = desc.EnumMembers[i].LogicalRepresentation
+		typ.PhysicalReps[i] = desc.EnumMembers[i].PhysicalRepresentation
+		if desc.EnumMembers[i].Capability == TypeDescriptor_EnumMember_READ_ONLY {
+			if typ.IsMemberReadOnly == nil {
+				typ.IsMemberReadOnly = make([]bool, len(desc.EnumMembers))
+			}
+			typ.IsMemberReadOnly[i] = true
+		}
+	}
+	return typ
+}
+
+// SetID implements the DescriptorProto interface.
+func (desc *SchemaDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *SchemaDescriptor) TypeName() string {
+	return "schema"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *SchemaDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// This is a stub until per-schema auditing is implemented.
+func (desc *SchemaDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the schema descriptor is well formed.
+func (desc *SchemaDescriptor) Validate() error {
+	if err := validateName(desc.Name, "schema"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid schema ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d for schema %q", desc.ParentID, desc.Name)
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
 // GetID returns the ID of the descriptor.
 func (desc *Descriptor) GetID() ID {
 	switch t := desc.Union.(type) {
@@ -2437,6 +2869,12 @@ func (desc *Descriptor) GetID() ID {
 		return t.Table.ID
 	case *Descriptor_Database:
 		return t.Database.ID
+	case *Descriptor_Function:
+		return t.Function.ID
+	case *Descriptor_Type:
+		return t.Type.ID
+	case *Descriptor_Schema:
+		return t.Schema.ID
 	default:
 		return 0
 	}
@@ -2449,6 +2887,12 @@ func (desc *Descriptor) GetName() string {
 		return t.Table.Name
 	case *Descriptor_Database:
 		return t.Database.Name
+	case *Descriptor_Function:
+		return t.Function.Name
+	case *Descriptor_Type:
+		return t.Type.Name
+	case *Descriptor_Schema:
+		return t.Schema.Name
 	default:
 		return ""
 	}
@@ -2512,7 +2956,18 @@ func (desc TableDescriptor) GetDescMetadataKey() roachpb.Key {
 
 // GetNameMetadataKey returns the namespace key for the table.
 func (desc TableDescriptor) GetNameMetadataKey() roachpb.Key {
-	return MakeNameMetadataKey(desc.ParentID, desc.Name)
+	return MakeNameMetadataKey(desc.GetNamespaceParentID(), desc.Name)
+}
+
+// GetNamespaceParentID returns the ID under which the name of the table is
+// stored in system.namespace. This is the ID of the parent database, except
+// for tables in temporary and user-defined schemas which are named under
+// their schema.
+func (desc *TableDescriptor) GetNamespaceParentID() ID {
+	if desc.UnexposedParentSchemaID != 0 {
+		return desc.UnexposedParentSchemaID
+	}
+	return desc.ParentID
 }
 
 // SQLString returns the SQL statement describing the column.
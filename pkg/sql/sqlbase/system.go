@@ -224,6 +224,20 @@ CREATE TABLE system.comments (
    comment   STRING NOT NULL, -- the comment
    PRIMARY KEY (type, object_id, sub_id)
 );`
+
+	// protected_ts_records contains the protected timestamp records, each of
+	// which prevents the GC of MVCC data at or above its timestamp in the
+	// spans it lists.
+	ProtectedTimestampsRecordsTableSchema = `
+CREATE TABLE system.protected_ts_records (
+   id        UUID NOT NULL,
+   ts        DECIMAL NOT NULL,
+   meta_type STRING NOT NULL, -- used to interpret meta, e.g. "jobs"
+   meta      BYTES,
+   spans     BYTES NOT NULL,  -- encoded ptpb.Spans
+   PRIMARY KEY (id),
+   FAMILY "primary" (id, ts, meta_type, meta, spans)
+);`
 )
 
 func pk(name string) IndexDescriptor {
@@ -258,12 +272,13 @@ var SystemAllowedPrivileges = map[ID]privilege.List{
 	// users will be able to modify system tables' schemas at will. CREATE and
 	// DROP privileges are allowed on the above system tables for backwards
 	// compatibility reasons only!
-	keys.JobsTableID:            privilege.ReadWriteData,
-	keys.WebSessionsTableID:     privilege.ReadWriteData,
-	keys.TableStatisticsTableID: privilege.ReadWriteData,
-	keys.LocationsTableID:       privilege.ReadWriteData,
-	keys.RoleMembersTableID:     privilege.ReadWriteData,
-	keys.CommentsTableID:        privilege.ReadWriteData,
+	keys.JobsTableID:                       privilege.ReadWriteData,
+	keys.WebSessionsTableID:                privilege.ReadWriteData,
+	keys.TableStatisticsTableID:            privilege.ReadWriteData,
+	keys.LocationsTableID:                  privilege.ReadWriteData,
+	keys.RoleMembersTableID:                privilege.ReadWriteData,
+	keys.CommentsTableID:                   privilege.ReadWriteData,
+	keys.ProtectedTimestampsRecordsTableID: privilege.ReadWriteData,
 }
 
 // Helpers used to make some of the TableDescriptor literals below more concise.
@@ -273,6 +288,8 @@ var (
 	colTypeString    = ColumnType{SemanticType: ColumnType_STRING}
 	colTypeBytes     = ColumnType{SemanticType: ColumnType_BYTES}
 	colTypeTimestamp = ColumnType{SemanticType: ColumnType_TIMESTAMP}
+	colTypeUUID      = ColumnType{SemanticType: ColumnType_UUID}
+	colTypeDecimal   = ColumnType{SemanticType: ColumnType_DECIMAL}
 	colTypeIntArray  = ColumnType{
 		SemanticType:    ColumnType_ARRAY,
 		ArrayContents:   &colTypeInt.SemanticType,
@@ -869,6 +886,37 @@ var (
 		FormatVersion:  InterleavedFormatVersion,
 		NextMutationID: 1,
 	}
+
+	// ProtectedTimestampsRecordsTable is the descriptor for the
+	// protected_ts_records table.
+	ProtectedTimestampsRecordsTable = TableDescriptor{
+		Name:     "protected_ts_records",
+		ID:       keys.ProtectedTimestampsRecordsTableID,
+		ParentID: keys.SystemDatabaseID,
+		Version:  1,
+		Columns: []ColumnDescriptor{
+			{Name: "id", ID: 1, Type: colTypeUUID},
+			{Name: "ts", ID: 2, Type: colTypeDecimal},
+			{Name: "meta_type", ID: 3, Type: colTypeString},
+			{Name: "meta", ID: 4, Type: colTypeBytes, Nullable: true},
+			{Name: "spans", ID: 5, Type: colTypeBytes},
+		},
+		NextColumnID: 6,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"id", "ts", "meta_type", "meta", "spans"},
+				ColumnIDs:   []ColumnID{1, 2, 3, 4, 5},
+			},
+		},
+		NextFamilyID:   1,
+		PrimaryIndex:   pk("id"),
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.ProtectedTimestampsRecordsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
 )
 
 // Create a kv pair for the zone config for the given key and config value.
@@ -915,6 +963,10 @@ func addSystemDatabaseToSchema(target *MetadataSchema) {
 	// was introduced, but it's also created as a migration for older clusters.
 	target.AddDescriptor(keys.SystemDatabaseID, &CommentsTable)
 
+	// The ProtectedTimestampsRecordsTable has been introduced in 2.2. It is
+	// also created as a migration for older clusters.
+	target.AddDescriptor(keys.SystemDatabaseID, &ProtectedTimestampsRecordsTable)
+
 	target.AddSplitIDs(keys.PseudoTableIDs...)
 
 	// Adding a new system table? It should be added here to the metadata schema,
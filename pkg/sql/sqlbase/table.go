@@ -64,6 +64,55 @@ func SanitizeVarFreeExpr(
 	return typedExpr, nil
 }
 
+// foldUserDefinedTypeCasts replaces the casts of constant values to
+// user-defined types in the given expression with their results. Enum values
+// are formatted as plain string literals, which take the type of the column
+// when the stored expression is type checked again. An error is returned if
+// the expression contains other references to user-defined types.
+func foldUserDefinedTypeCasts(expr tree.TypedExpr, context string) (tree.TypedExpr, error) {
+	v := userDefinedTypeCastFolder{}
+	newExpr, _ := tree.WalkExpr(&v, expr)
+	if v.unsupported {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"%s expressions can only refer to user-defined types in casts of constant values", context)
+	}
+	return newExpr.(tree.TypedExpr), nil
+}
+
+// userDefinedTypeCastFolder is the visitor used by foldUserDefinedTypeCasts.
+type userDefinedTypeCastFolder struct {
+	// unsupported is set if a reference to a user-defined type that cannot
+	// be folded was found.
+	unsupported bool
+}
+
+var _ tree.Visitor = &userDefinedTypeCastFolder{}
+
+// VisitPre is part of the Visitor interface.
+func (v *userDefinedTypeCastFolder) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	var typ coltypes.CastTargetType
+	var inner tree.Expr
+	switch t := expr.(type) {
+	case *tree.CastExpr:
+		typ, inner = t.Type, t.Expr
+	case *tree.AnnotateTypeExpr:
+		typ, inner = t.Type, t.Expr
+	default:
+		return true, expr
+	}
+	if _, ok := typ.(*coltypes.TUserDefined); !ok {
+		return true, expr
+	}
+	if d, ok := inner.(*tree.DEnum); ok {
+		return false, d
+	}
+	v.unsupported = true
+	return false, expr
+}
+
+// VisitPost is part of the Visitor interface.
+func (*userDefinedTypeCastFolder) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
 // MakeColumnDefDescs creates the column descriptor for a column, as well as the
 // index descriptor if the column is a primary key or unique.
 //
@@ -105,6 +154,13 @@ func MakeColumnDefDescs(
 		Nullable: d.Nullable.Nullability != tree.NotNull && !d.PrimaryKey,
 	}
 
+	// Resolve references to user-defined types.
+	colType, err := semaCtx.ResolveType(d.Type)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	d.Type = colType.(coltypes.T)
+
 	// Set Type.SemanticType and Type.Locale.
 	colDatumType := coltypes.CastTargetToDatumType(d.Type)
 	colTyp, err := DatumTypeToColumnType(colDatumType)
@@ -126,6 +182,11 @@ func MakeColumnDefDescs(
 		); err != nil {
 			return nil, nil, nil, err
 		}
+		// Stored expressions are type checked without a way to resolve
+		// user-defined types, so casts to such types must be folded away.
+		if typedExpr, err = foldUserDefinedTypeCasts(typedExpr, "DEFAULT"); err != nil {
+			return nil, nil, nil, err
+		}
 		// We keep the type checked expression so that the type annotation
 		// gets properly stored.
 		d.DefaultExpr.Expr = typedExpr
@@ -342,6 +403,10 @@ func (desc *TableDescriptor) collectConstraintInfo(
 	}
 
 	for _, c := range desc.Checks {
+		if c.IsNonNullConstraint {
+			// NOT NULL constraints being validated are not visible to users.
+			continue
+		}
 		if _, ok := info[c.Name]; ok {
 			return nil, errors.Errorf("duplicate constraint name: %q", c.Name)
 		}
@@ -440,6 +440,14 @@ func TestMarshalColumnValue(t *testing.T) {
 			datum: tree.MakeDTime(timeofday.FromInt(314159)),
 			exp:   func() (v roachpb.Value) { v.SetInt(314159); return }(),
 		},
+		{
+			kind:  ColumnType_TIMETZ,
+			datum: tree.MakeDTimeTZ(timeofday.FromInt(314159), -3600),
+			exp: func() (v roachpb.Value) {
+				v.SetBytes(encoding.EncodeUntaggedTimeTZValue(nil, 314159, -3600))
+				return
+			}(),
+		},
 		{
 			kind:  ColumnType_TIMESTAMP,
 			datum: tree.MakeDTimestamp(timeutil.Unix(314159, 1000), time.Microsecond),
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
 )
@@ -119,6 +120,9 @@ func RandDatumWithNullChance(rng *rand.Rand, typ ColumnType, nullChance int) tre
 		return tree.NewDDate(tree.DDate(rng.Intn(10000)))
 	case ColumnType_TIME:
 		return tree.MakeDTime(timeofday.Random(rng))
+	case ColumnType_TIMETZ:
+		// Offsets are multiples of 15 minutes between -15:45 and +15:45.
+		return tree.MakeDTimeTZ(timeofday.Random(rng), int32((rng.Intn(127)-63)*15*60))
 	case ColumnType_TIMESTAMP:
 		return &tree.DTimestamp{Time: timeutil.Unix(rng.Int63n(1000000), rng.Int63n(1000000))}
 	case ColumnType_INTERVAL:
@@ -139,6 +143,10 @@ func RandDatumWithNullChance(rng *rand.Rand, typ ColumnType, nullChance int) tre
 			return nil
 		}
 		return &tree.DJSON{JSON: j}
+	case ColumnType_TSVECTOR:
+		return tree.NewDTSVector(randTSVector(rng))
+	case ColumnType_TSQUERY:
+		return tree.NewDTSQuery(randTSQuery(rng, 3 /* depth */))
 	case ColumnType_TUPLE:
 		tuple := tree.DTuple{D: make(tree.Datums, len(typ.TupleContents))}
 		for i, internalType := range typ.TupleContents {
@@ -218,6 +226,53 @@ func RandDatumWithNullChance(rng *rand.Rand, typ ColumnType, nullChance int) tre
 	}
 }
 
+// randLexeme returns a random lexeme from a small alphabet, so that random
+// tsvectors and tsqueries often have lexemes in common.
+func randLexeme(rng *rand.Rand) string {
+	p := make([]byte, 1+rng.Intn(3))
+	for i := range p {
+		p[i] = byte('a' + rng.Intn(4))
+	}
+	return string(p)
+}
+
+func randTSVector(rng *rand.Rand) tsearch.TSVector {
+	lexemes := make([]tsearch.Lexeme, rng.Intn(10))
+	for i := range lexemes {
+		lexemes[i].Word = randLexeme(rng)
+		for j := rng.Intn(3); j > 0; j-- {
+			lexemes[i].Positions = append(lexemes[i].Positions, tsearch.Position{
+				Pos:    uint16(1 + rng.Intn(tsearch.MaxPosition)),
+				Weight: tsearch.Weight(rng.Intn(4)),
+			})
+		}
+	}
+	return tsearch.MakeTSVector(lexemes)
+}
+
+func randTSQuery(rng *rand.Rand, depth int) tsearch.TSQuery {
+	var gen func(depth int) *tsearch.QueryNode
+	gen = func(depth int) *tsearch.QueryNode {
+		if depth == 0 || rng.Intn(2) == 0 {
+			return &tsearch.QueryNode{
+				Op:      tsearch.QueryLexeme,
+				Lexeme:  randLexeme(rng),
+				Prefix:  rng.Intn(4) == 0,
+				Weights: tsearch.WeightSet(rng.Intn(16)),
+			}
+		}
+		switch rng.Intn(3) {
+		case 0:
+			return &tsearch.QueryNode{Op: tsearch.QueryNot, Left: gen(depth - 1)}
+		case 1:
+			return &tsearch.QueryNode{Op: tsearch.QueryAnd, Left: gen(depth - 1), Right: gen(depth - 1)}
+		default:
+			return &tsearch.QueryNode{Op: tsearch.QueryOr, Left: gen(depth - 1), Right: gen(depth - 1)}
+		}
+	}
+	return tsearch.TSQuery{Root: gen(depth)}
+}
+
 var (
 	columnSemanticTypes []ColumnType_SemanticType
 	// arrayElemSemanticTypes contains all of the semantic types that are valid
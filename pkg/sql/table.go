@@ -93,7 +93,7 @@ func GetKeysForTableDescriptor(
 	tableDesc *sqlbase.TableDescriptor,
 ) (zoneKey roachpb.Key, nameKey roachpb.Key, descKey roachpb.Key) {
 	zoneKey = config.MakeZoneKey(uint32(tableDesc.ID))
-	nameKey = sqlbase.MakeNameMetadataKey(tableDesc.ParentID, tableDesc.GetName())
+	nameKey = tableDesc.GetNameMetadataKey()
 	descKey = sqlbase.MakeDescMetadataKey(tableDesc.ID)
 	return
 }
@@ -200,13 +200,6 @@ func (tc *TableCollection) getMutableTableDescriptor(
 		log.Infof(ctx, "reading mutable descriptor on table '%s'", tn)
 	}
 
-	if tn.SchemaName != tree.PublicSchemaName {
-		if flags.required {
-			return nil, nil, sqlbase.NewUnsupportedSchemaUsageError(tree.ErrString(tn))
-		}
-		return nil, nil, nil
-	}
-
 	refuseFurtherLookup, dbID, err := tc.getUncommittedDatabaseID(tn.Catalog(), flags.required)
 	if refuseFurtherLookup || err != nil {
 		return nil, nil, err
@@ -223,7 +216,23 @@ func (tc *TableCollection) getMutableTableDescriptor(
 		}
 	}
 
-	if refuseFurtherLookup, table, err := tc.getUncommittedTable(dbID, tn, flags.required); refuseFurtherLookup || err != nil {
+	parentID, err := getSchemaNamespaceID(ctx, txn, dbID, tn.Schema())
+	if err != nil {
+		return nil, nil, err
+	}
+	if parentID == sqlbase.InvalidID {
+		// The schema does not exist in this database. Temporary schemas are
+		// only created along with the first temporary table of a session.
+		if flags.required {
+			if isTemporarySchemaName(tn.Schema()) {
+				return nil, nil, sqlbase.NewUndefinedRelationError(tn)
+			}
+			return nil, nil, sqlbase.NewUndefinedSchemaError(tn.Schema())
+		}
+		return nil, nil, nil
+	}
+
+	if refuseFurtherLookup, table, err := tc.getUncommittedTable(parentID, tn, flags.required); refuseFurtherLookup || err != nil {
 		return nil, nil, err
 	} else if mut := table.MutableTableDescriptor; mut != nil {
 		log.VEventf(ctx, 2, "found uncommitted table %d", mut.ID)
@@ -256,13 +265,6 @@ func (tc *TableCollection) getTableVersion(
 		log.Infof(ctx, "planner acquiring lease on table '%s'", tn)
 	}
 
-	if tn.SchemaName != tree.PublicSchemaName {
-		if flags.required {
-			return nil, nil, sqlbase.NewUnsupportedSchemaUsageError(tree.ErrString(tn))
-		}
-		return nil, nil, nil
-	}
-
 	refuseFurtherLookup, dbID, err := tc.getUncommittedDatabaseID(tn.Catalog(), flags.required)
 	if refuseFurtherLookup || err != nil {
 		return nil, nil, err
@@ -279,6 +281,22 @@ func (tc *TableCollection) getTableVersion(
 		}
 	}
 
+	parentID, err := getSchemaNamespaceID(ctx, txn, dbID, tn.Schema())
+	if err != nil {
+		return nil, nil, err
+	}
+	if parentID == sqlbase.InvalidID {
+		// The schema does not exist in this database. Temporary schemas are
+		// only created along with the first temporary table of a session.
+		if flags.required {
+			if isTemporarySchemaName(tn.Schema()) {
+				return nil, nil, sqlbase.NewUndefinedRelationError(tn)
+			}
+			return nil, nil, sqlbase.NewUndefinedSchemaError(tn.Schema())
+		}
+		return nil, nil, nil
+	}
+
 	// TODO(vivek): Ideally we'd avoid caching for only the
 	// system.descriptor and system.lease tables, because they are
 	// used for acquiring leases, creating a chicken&egg problem.
@@ -289,7 +307,7 @@ func (tc *TableCollection) getTableVersion(
 	avoidCache := flags.avoidCached || testDisableTableLeases ||
 		(tn.Catalog() == sqlbase.SystemDB.Name && tn.TableName.String() != sqlbase.RoleMembersTable.Name)
 
-	if refuseFurtherLookup, table, err := tc.getUncommittedTable(dbID, tn, flags.required); refuseFurtherLookup || err != nil {
+	if refuseFurtherLookup, table, err := tc.getUncommittedTable(parentID, tn, flags.required); refuseFurtherLookup || err != nil {
 		return nil, nil, err
 	} else if immut := table.ImmutableTableDescriptor; immut != nil {
 		// If not forcing to resolve using KV, tables being added aren't visible.
@@ -324,14 +342,14 @@ func (tc *TableCollection) getTableVersion(
 	// transaction.
 	for _, table := range tc.leasedTables {
 		if table.Name == string(tn.TableName) &&
-			table.ParentID == dbID {
+			table.GetNamespaceParentID() == parentID {
 			log.VEventf(ctx, 2, "found table in table collection for table '%s'", tn)
 			return table, nil, nil
 		}
 	}
 
 	origTimestamp := txn.OrigTimestamp()
-	table, expiration, err := tc.leaseMgr.AcquireByName(ctx, origTimestamp, dbID, tn.Table())
+	table, expiration, err := tc.leaseMgr.AcquireByName(ctx, origTimestamp, parentID, tn.Table())
 	if err != nil {
 		// Read the descriptor from the store in the face of some specific errors
 		// because of a known limitation of AcquireByName. See the known
@@ -577,7 +595,7 @@ func (tc *TableCollection) getUncommittedDatabaseID(
 // cache and go to KV (where the descriptor prior to the DROP may
 // still exist).
 func (tc *TableCollection) getUncommittedTable(
-	dbID sqlbase.ID, tn *tree.TableName, required bool,
+	parentID sqlbase.ID, tn *tree.TableName, required bool,
 ) (refuseFurtherLookup bool, table uncommittedTable, err error) {
 	// Walk latest to earliest so that a DROP TABLE followed by a CREATE TABLE
 	// with the same name will result in the CREATE TABLE being seen.
@@ -591,7 +609,7 @@ func (tc *TableCollection) getUncommittedTable(
 		// effect of it.
 		for _, drain := range mutTbl.DrainingNames {
 			if drain.Name == string(tn.TableName) &&
-				drain.ParentID == dbID {
+				drain.ParentID == parentID {
 				// Table name has gone away.
 				if required {
 					// If it's required here, say it doesn't exist.
@@ -605,7 +623,7 @@ func (tc *TableCollection) getUncommittedTable(
 
 		// Do we know about a table with this name?
 		if mutTbl.Name == string(tn.TableName) &&
-			mutTbl.ParentID == dbID {
+			mutTbl.GetNamespaceParentID() == parentID {
 			// Right state?
 			if err = filterTableState(mutTbl.TableDesc()); err != nil && err != errTableAdding {
 				if !required {
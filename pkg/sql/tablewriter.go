@@ -131,6 +131,8 @@ type tableWriterBase struct {
 	b *client.Batch
 	// batchSize is the current batch size (when known).
 	batchSize int
+	// triggers fires the triggers of the table, if any.
+	triggers *triggerRunner
 }
 
 func (tb *tableWriterBase) init(txn *client.Txn) {
@@ -154,10 +156,52 @@ func (tb *tableWriterBase) flushAndStartNewBatch(
 // curBatchSize shares the common curBatchSize() code between extendedTableWriters().
 func (tb *tableWriterBase) curBatchSize() int { return tb.batchSize }
 
+// fireBeforeRow fires the triggers before the modification of a row. See
+// triggerRunner.beforeRow. If BEFORE ROW triggers fire, the current batch is
+// flushed first, so that they observe the rows already modified by the
+// statement.
+func (tb *tableWriterBase) fireBeforeRow(
+	ctx context.Context,
+	tableDesc *sqlbase.ImmutableTableDescriptor,
+	event sqlbase.TriggerDescriptor_Event,
+	oldRow, newRow tree.Datums,
+	colIdx map[sqlbase.ColumnID]int,
+) (tree.Datums, error) {
+	if tb.batchSize > 0 && tb.triggers.hasBeforeRow(event) {
+		if err := tb.flushAndStartNewBatch(ctx, tableDesc); err != nil {
+			return nil, err
+		}
+	}
+	return tb.triggers.beforeRow(ctx, tb.txn, event, oldRow, newRow, colIdx)
+}
+
+// closeTriggers frees the resources held by the triggerRunner, if any.
+func (tb *tableWriterBase) closeTriggers(ctx context.Context) {
+	if tb.triggers != nil {
+		tb.triggers.close(ctx)
+	}
+}
+
 // finalize shares the common finalize code between extendedTableWriters.
 func (tb *tableWriterBase) finalize(
 	ctx context.Context, autoCommit autoCommitOpt, tableDesc *sqlbase.ImmutableTableDescriptor,
 ) (err error) {
+	if tb.triggers != nil {
+		// The AFTER triggers observe the effects of the statement, so the
+		// batch must be run before they fire, and the transaction can only be
+		// committed afterwards.
+		if err := tb.txn.Run(ctx, tb.b); err != nil {
+			return row.ConvertBatchError(ctx, tableDesc, tb.b)
+		}
+		if err := tb.triggers.fireAfter(ctx, tb.txn); err != nil {
+			return err
+		}
+		if autoCommit == autoCommitEnabled {
+			return tb.txn.Commit(ctx)
+		}
+		return nil
+	}
+
 	if autoCommit == autoCommitEnabled {
 		// An auto-txn can commit the transaction with the batch. This is an
 		// optimization to avoid an extra round-trip to the transaction
@@ -65,9 +65,27 @@ func (td *tableDeleter) finalize(
 // atBatchEnd is part of the extendedTableWriter interface.
 func (td *tableDeleter) atBatchEnd(_ context.Context, _ bool) error { return nil }
 
+// beforeRow fires the triggers before the deletion of a row. It returns false
+// if a trigger skipped the row.
+func (td *tableDeleter) beforeRow(ctx context.Context, values tree.Datums) (bool, error) {
+	if td.triggers == nil {
+		return true, nil
+	}
+	res, err := td.fireBeforeRow(ctx, td.rd.Helper.TableDesc, sqlbase.TriggerDescriptor_DELETE,
+		values, nil /* newRow */, td.rd.FetchColIDtoRowIndex)
+	return res != nil, err
+}
+
 func (td *tableDeleter) row(ctx context.Context, values tree.Datums, traceKV bool) error {
 	td.batchSize++
-	return td.rd.DeleteRow(ctx, td.b, values, row.CheckFKs, traceKV)
+	if err := td.rd.DeleteRow(ctx, td.b, values, row.CheckFKs, traceKV); err != nil {
+		return err
+	}
+	if td.triggers != nil {
+		return td.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_DELETE,
+			values, nil /* newRow */, td.rd.FetchColIDtoRowIndex)
+	}
+	return nil
 }
 
 // fastPathDeleteAvailable returns true if the fastDelete optimization can be used.
@@ -91,6 +109,12 @@ func fastPathDeleteAvailable(ctx context.Context, desc *ImmutableTableDescriptor
 		}
 		return false
 	}
+	if len(desc.Triggers) > 0 {
+		if log.V(2) {
+			log.Info(ctx, "delete forced to scan: table has triggers")
+		}
+		return false
+	}
 	return true
 }
 
@@ -168,7 +192,13 @@ func (td *tableDeleter) deleteAllRowsScan(
 		ValNeededForCol: valNeededForCol,
 	}
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, td.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		td.alloc,
+		tableArgs,
 	); err != nil {
 		return resume, err
 	}
@@ -295,7 +325,13 @@ func (td *tableDeleter) deleteIndexScan(
 		ValNeededForCol: valNeededForCol,
 	}
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, td.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		td.alloc,
+		tableArgs,
 	); err != nil {
 		return resume, err
 	}
@@ -333,4 +369,6 @@ func (td *tableDeleter) fkSpanCollector() row.FkSpanCollector {
 	return td.rd.Fks
 }
 
-func (td *tableDeleter) close(_ context.Context) {}
+func (td *tableDeleter) close(ctx context.Context) {
+	td.closeTriggers(ctx)
+}
@@ -39,10 +39,27 @@ func (ti *tableInserter) init(txn *client.Txn, _ *tree.EvalContext) error {
 	return nil
 }
 
+// beforeRow fires the triggers before the insertion of a row. It returns the
+// row to insert, or nil if a trigger skipped the row.
+func (ti *tableInserter) beforeRow(ctx context.Context, values tree.Datums) (tree.Datums, error) {
+	if ti.triggers == nil {
+		return values, nil
+	}
+	return ti.fireBeforeRow(ctx, ti.tableDesc(), sqlbase.TriggerDescriptor_INSERT,
+		nil /* oldRow */, values, ti.ri.InsertColIDtoRowIndex)
+}
+
 // row is part of the tableWriter interface.
 func (ti *tableInserter) row(ctx context.Context, values tree.Datums, traceKV bool) error {
 	ti.batchSize++
-	return ti.ri.InsertRow(ctx, ti.b, values, false /* overwrite */, row.CheckFKs, traceKV)
+	if err := ti.ri.InsertRow(ctx, ti.b, values, false /* overwrite */, row.CheckFKs, traceKV); err != nil {
+		return err
+	}
+	if ti.triggers != nil {
+		return ti.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_INSERT,
+			nil /* oldRow */, values, ti.ri.InsertColIDtoRowIndex)
+	}
+	return nil
 }
 
 // atBatchEnd is part of the extendedTableWriter interface.
@@ -71,7 +88,9 @@ func (ti *tableInserter) fkSpanCollector() row.FkSpanCollector {
 }
 
 // close is part of the tableWriter interface.
-func (ti *tableInserter) close(_ context.Context) {}
+func (ti *tableInserter) close(ctx context.Context) {
+	ti.closeTriggers(ctx)
+}
 
 // walkExprs is part of the tableWriter interface.
 func (ti *tableInserter) walkExprs(_ func(desc string, index int, expr tree.TypedExpr)) {}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -47,12 +48,55 @@ func (tu *tableUpdater) row(context.Context, tree.Datums, bool) error {
 	panic("unimplemented")
 }
 
+// beforeRow fires the triggers before the update of a row. The values of the
+// updated columns in updateValues are replaced by the values set by the
+// triggers. It returns false if a trigger skipped the row.
+func (tu *tableUpdater) beforeRow(
+	ctx context.Context, oldValues, updateValues tree.Datums,
+) (bool, error) {
+	if tu.triggers == nil {
+		return true, nil
+	}
+	colIdx := tu.ru.FetchColIDtoRowIndex
+	newValues := make(tree.Datums, len(oldValues))
+	copy(newValues, oldValues)
+	for i := range tu.ru.UpdateCols {
+		newValues[colIdx[tu.ru.UpdateCols[i].ID]] = updateValues[i]
+	}
+	newValues, err := tu.fireBeforeRow(
+		ctx, tu.tableDesc(), sqlbase.TriggerDescriptor_UPDATE, oldValues, newValues, colIdx)
+	if err != nil || newValues == nil {
+		return false, err
+	}
+	for i := range tu.ru.FetchCols {
+		col := &tu.ru.FetchCols[i]
+		if j, ok := tu.ru.UpdateColIDtoRowIndex[col.ID]; ok {
+			updateValues[j] = newValues[i]
+		} else if newValues[i].Compare(tu.triggers.evalCtx, oldValues[i]) != 0 {
+			return false, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"BEFORE UPDATE triggers cannot modify column %q, which is not updated by the statement",
+				col.Name)
+		}
+	}
+	return true, nil
+}
+
 // rowForUpdate extends row() from the tableWriter interface.
 func (tu *tableUpdater) rowForUpdate(
 	ctx context.Context, oldValues, updateValues tree.Datums, traceKV bool,
 ) (tree.Datums, error) {
 	tu.batchSize++
-	return tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, row.CheckFKs, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, row.CheckFKs, traceKV)
+	if err != nil {
+		return nil, err
+	}
+	if tu.triggers != nil {
+		if err := tu.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_UPDATE,
+			oldValues, newValues, tu.ru.FetchColIDtoRowIndex); err != nil {
+			return nil, err
+		}
+	}
+	return newValues, nil
 }
 
 // atBatchEnd is part of the extendedTableWriter interface.
@@ -81,7 +125,9 @@ func (tu *tableUpdater) fkSpanCollector() row.FkSpanCollector {
 }
 
 // close is part of the tableWriter interface.
-func (tu *tableUpdater) close(_ context.Context) {}
+func (tu *tableUpdater) close(ctx context.Context) {
+	tu.closeTriggers(ctx)
+}
 
 // walkExprs is part of the tableWriter interface.
 func (tu *tableUpdater) walkExprs(_ func(desc string, index int, expr tree.TypedExpr)) {}
@@ -64,6 +64,10 @@ type tableUpserterBase struct {
 
 	// For allocation avoidance.
 	indexKeyPrefix []byte
+
+	// deferredFKChecks, if set, collects the foreign key checks of the
+	// updates that are deferred to the end of the transaction.
+	deferredFKChecks *row.DeferredFKChecks
 }
 
 func (tu *tableUpserterBase) init(txn *client.Txn, evalCtx *tree.EvalContext) error {
@@ -119,6 +123,11 @@ func (tu *tableUpserterBase) tableDesc() *sqlbase.ImmutableTableDescriptor {
 
 // row is part of the tableWriter interface.
 func (tu *tableUpserterBase) row(ctx context.Context, row tree.Datums, traceKV bool) error {
+	if tu.triggers != nil {
+		if err := tu.triggers.fireBeforeStatement(ctx, tu.txn); err != nil {
+			return err
+		}
+	}
 	tu.batchSize++
 	_, err := tu.insertRows.AddRow(ctx, row)
 	return err
@@ -151,6 +160,7 @@ func (tu *tableUpserterBase) curBatchSize() int { return tu.insertRows.Len() }
 
 // close is part of the tableWriter interface.
 func (tu *tableUpserterBase) close(ctx context.Context) {
+	tu.closeTriggers(ctx)
 	tu.insertRows.Close(ctx)
 	if tu.existingRows != nil {
 		tu.existingRows.Close(ctx)
@@ -307,6 +317,7 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 		if err != nil {
 			return err
 		}
+		tu.ru.DeferFKChecks(tu.deferredFKChecks)
 
 		// t.ru.fetchCols can also contain columns undergoing mutation.
 		tu.fetchCols = tu.ru.FetchCols
@@ -334,7 +345,13 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 	}
 
 	if err := tu.fetcher.Init(
-		false /* reverse */, false /*returnRangeInfo*/, false /* isCheck */, tu.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		tu.alloc,
+		tableArgs,
 	); err != nil {
 		return err
 	}
@@ -593,6 +610,12 @@ func (tu *tableUpserter) updateConflictingRow(
 	if err != nil {
 		return nil, err
 	}
+	if tu.triggers != nil {
+		if err := tu.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_UPDATE,
+			conflictingRowValues, updatedRow, tu.ru.FetchColIDtoRowIndex); err != nil {
+			return nil, err
+		}
+	}
 
 	// Keep the slice for reuse.
 	tu.updateValues = updateValues[:0]
@@ -685,6 +708,12 @@ func (tu *tableUpserter) insertNonConflictingRow(
 		ctx, b, insertRow, false /* ignoreConflicts */, row.CheckFKs, traceKV); err != nil {
 		return nil, err
 	}
+	if tu.triggers != nil {
+		if err := tu.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_INSERT,
+			nil /* oldRow */, insertRow, tu.ri.InsertColIDtoRowIndex); err != nil {
+			return nil, err
+		}
+	}
 
 	// We may not know the conflictingRowPK yet for the new row, for
 	// example when the conflicting index was a secondary index.
@@ -91,7 +91,11 @@ func (tu *optTableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) err
 		evalCtx,
 		tu.alloc,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	tu.ru.DeferFKChecks(tu.deferredFKChecks)
+	return nil
 }
 
 // desc is part of the tableWriter interface.
@@ -99,6 +103,11 @@ func (*optTableUpserter) desc() string { return "opt upserter" }
 
 // row is part of the tableWriter interface.
 func (tu *optTableUpserter) row(ctx context.Context, row tree.Datums, traceKV bool) error {
+	if tu.triggers != nil {
+		if err := tu.triggers.fireBeforeStatement(ctx, tu.txn); err != nil {
+			return err
+		}
+	}
 	tu.batchSize++
 	tu.resultCount++
 
@@ -153,6 +162,12 @@ func (tu *optTableUpserter) insertNonConflictingRow(
 		ctx, b, insertRow, overwrite, row.CheckFKs, traceKV); err != nil {
 		return err
 	}
+	if tu.triggers != nil {
+		if err := tu.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_INSERT,
+			nil /* oldRow */, insertRow, tu.ri.InsertColIDtoRowIndex); err != nil {
+			return err
+		}
+	}
 
 	if !tu.collectRows {
 		return nil
@@ -186,10 +201,16 @@ func (tu *optTableUpserter) updateConflictingRow(
 	// Queue the update in KV. This also returns an "update row"
 	// containing the updated values for every column in the
 	// table. This is useful for RETURNING, which we collect below.
-	_, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, row.CheckFKs, traceKV)
+	newRow, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, row.CheckFKs, traceKV)
 	if err != nil {
 		return err
 	}
+	if tu.triggers != nil {
+		if err := tu.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_UPDATE,
+			fetchRow, newRow, tu.ru.FetchColIDtoRowIndex); err != nil {
+			return err
+		}
+	}
 
 	// We only need a result row if we're collecting rows.
 	if !tu.collectRows {
@@ -38,6 +38,10 @@ type strictTableUpserter struct {
 
 	// internal state
 	conflictIndexes []sqlbase.IndexDescriptor
+	// predicates is set if some of the conflict indexes are partial indexes.
+	// An insert row can only conflict with the rows of a partial index if it
+	// satisfies the predicate of the index.
+	predicates *sqlbase.PartialIndexPredicates
 }
 
 // desc is part of the tableWriter interface.
@@ -72,6 +76,12 @@ func (tu *strictTableUpserter) atBatchEnd(ctx context.Context, traceKV bool) err
 		if err := tu.ri.InsertRow(ctx, tu.b, insertRow, true, row.CheckFKs, traceKV); err != nil {
 			return err
 		}
+		if tu.triggers != nil {
+			if err := tu.triggers.afterRow(ctx, sqlbase.TriggerDescriptor_INSERT,
+				nil /* oldRow */, insertRow, tu.ri.InsertColIDtoRowIndex); err != nil {
+				return err
+			}
+		}
 
 		tu.resultCount++
 
@@ -110,7 +120,8 @@ func (tu *strictTableUpserter) getUniqueIndexes() (err error) {
 			tu.conflictIndexes = append(tu.conflictIndexes, index)
 		}
 	}
-	return nil
+	tu.predicates, err = sqlbase.NewPartialIndexPredicates(tableDesc.TableDesc(), tu.conflictIndexes)
+	return err
 }
 
 // getConflictingRows returns all of the the rows that are in conflict.
@@ -130,6 +141,10 @@ func (tu *strictTableUpserter) getConflictingRows(
 	// For every row there will be 1 + len(tu.conflictIndexes) requests/responses.
 	b := tu.txn.NewBatch()
 
+	// skipRequests contains the requests for the partial indexes whose
+	// predicate is not satisfied by the insert row; their results are ignored.
+	var skipRequests map[int]struct{}
+
 	for i := 0; i < tu.insertRows.Len(); i++ {
 		row := tu.insertRows.At(i)
 
@@ -148,9 +163,21 @@ func (tu *strictTableUpserter) getConflictingRows(
 
 		// Ditto for secondary indexes.
 
-		for _, idx := range tu.conflictIndexes {
+		for j := range tu.conflictIndexes {
+			idx := &tu.conflictIndexes[j]
+			ok, err := tu.predicates.Satisfies(idx, tu.ri.InsertColIDtoRowIndex, row)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				if skipRequests == nil {
+					skipRequests = make(map[int]struct{})
+				}
+				skipRequests[i*(1+len(tu.conflictIndexes))+1+j] = struct{}{}
+			}
+
 			entries, err := sqlbase.EncodeSecondaryIndex(
-				tableDesc.TableDesc(), &idx, tu.ri.InsertColIDtoRowIndex, row)
+				tableDesc.TableDesc(), idx, tu.ri.InsertColIDtoRowIndex, row)
 			if err != nil {
 				return nil, err
 			}
@@ -199,6 +226,9 @@ func (tu *strictTableUpserter) getConflictingRows(
 		startRequestIdx := reqsPerRow * insertRowIdx
 		endRequestIdx := startRequestIdx + reqsPerRow
 		for requestIdx := startRequestIdx; requestIdx < endRequestIdx; requestIdx++ {
+			if _, ok := skipRequests[requestIdx]; ok {
+				continue
+			}
 			row := b.Results[requestIdx].Rows[0]
 			// If any of the result values are not nil, the row exists in storage.
 			// Mark it as "conflicting" so that the caller knows to not insert it.
@@ -232,6 +262,9 @@ func (tu *strictTableUpserter) getConflictingRows(
 			startRequestIdx := reqsPerRow * insertRowIdx
 			endRequestIdx := startRequestIdx + reqsPerRow
 			for requestIdx := startRequestIdx; requestIdx < endRequestIdx; requestIdx++ {
+				if _, ok := skipRequests[requestIdx]; ok {
+					continue
+				}
 				seenKeys[string(b.Results[requestIdx].Rows[0].Key)] = struct{}{}
 			}
 		}
@@ -0,0 +1,425 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uint128"
+	"github.com/pkg/errors"
+)
+
+// Temporary tables live in the temporary schema of the session which
+// created them, named pg_temp_<hi>_<lo> after the ID of the session.
+// Temporary schemas do not have a descriptor: a temporary schema is a
+// namespace entry under its database which maps the name of the schema to a
+// unique ID, and the names of the temporary tables are stored under that ID
+// instead of the ID of the database (see TableDescriptor.GetNamespaceParentID).
+// This lets several sessions use the same names for their temporary tables.
+//
+// The temporary schema of a session is created the first time the session
+// creates a temporary table in a database. It is then searched before any
+// other schema when resolving names in that session, and is invisible to
+// all other sessions.
+//
+// The temporary tables of a session are dropped when the session closes.
+// Sessions which do not get a chance to clean up after themselves, for
+// example because their node died, are taken care of by a background task
+// which periodically looks for the temporary schemas of sessions that are not
+// running anymore.
+
+const temporarySchemaPrefix = "pg_temp_"
+
+var tempObjectCleanupInterval = settings.RegisterValidatedDurationSetting(
+	"sql.temp_object_cleaner.cleanup_interval",
+	"how often to clean up the temporary tables of sessions which are not running anymore",
+	30*time.Minute,
+	func(d time.Duration) error {
+		if d <= 0 {
+			return errors.Errorf("cannot set sql.temp_object_cleaner.cleanup_interval to a non-positive duration: %s", d)
+		}
+		return nil
+	},
+)
+
+// temporarySchemaName returns the name of the temporary schema of the session
+// with the given ID.
+func temporarySchemaName(sessionID ClusterWideID) string {
+	return fmt.Sprintf("%s%d_%d", temporarySchemaPrefix, sessionID.Hi, sessionID.Lo)
+}
+
+// temporarySchemaSessionID returns the ID of the session which owns the
+// temporary schema with the given name. It returns false if the name is not
+// the name of a temporary schema.
+func temporarySchemaSessionID(scName string) (ClusterWideID, bool) {
+	if !strings.HasPrefix(scName, temporarySchemaPrefix) {
+		return ClusterWideID{}, false
+	}
+	parts := strings.Split(scName[len(temporarySchemaPrefix):], "_")
+	if len(parts) != 2 {
+		return ClusterWideID{}, false
+	}
+	hi, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return ClusterWideID{}, false
+	}
+	lo, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return ClusterWideID{}, false
+	}
+	return ClusterWideID{Uint128: uint128.Uint128{Hi: hi, Lo: lo}}, true
+}
+
+// isTemporarySchemaName returns true if the given name is the name of the
+// temporary schema of some session.
+func isTemporarySchemaName(scName string) bool {
+	_, ok := temporarySchemaSessionID(scName)
+	return ok
+}
+
+// getTemporarySchemaID returns the ID of the temporary schema with the given
+// name in the database with the given ID, or InvalidID if there is no such
+// schema.
+func getTemporarySchemaID(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID, scName string,
+) (sqlbase.ID, error) {
+	key := tableKey{parentID: dbID, name: scName}.Key()
+	log.Eventf(ctx, "looking up temporary schema ID for name key %q", key)
+	gr, err := txn.Get(ctx, key)
+	if err != nil || !gr.Exists() {
+		return sqlbase.InvalidID, err
+	}
+	return sqlbase.ID(gr.ValueInt()), nil
+}
+
+// namespaceEntry is a name stored in system.namespace, along with the ID it
+// maps to.
+type namespaceEntry struct {
+	name string
+	id   sqlbase.ID
+}
+
+// getNamespaceEntries returns all the names stored under the given parent ID
+// in system.namespace.
+func getNamespaceEntries(
+	ctx context.Context, txn *client.Txn, parentID sqlbase.ID,
+) ([]namespaceEntry, error) {
+	prefix := sqlbase.MakeNameMetadataKey(parentID, "")
+	kvs, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]namespaceEntry, len(kvs))
+	for i, kv := range kvs {
+		_, name, err := encoding.DecodeUnsafeStringAscending(bytes.TrimPrefix(kv.Key, prefix), nil)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = namespaceEntry{name: name, id: sqlbase.ID(kv.ValueInt())}
+	}
+	return entries, nil
+}
+
+// getTemporarySchemas returns the temporary schemas of all the sessions in
+// the database with the given ID.
+func getTemporarySchemas(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID,
+) ([]namespaceEntry, error) {
+	entries, err := getNamespaceEntries(ctx, txn, dbID)
+	if err != nil {
+		return nil, err
+	}
+	schemas := entries[:0]
+	for _, e := range entries {
+		if isTemporarySchemaName(e.name) {
+			schemas = append(schemas, e)
+		}
+	}
+	return schemas, nil
+}
+
+// temporarySchemaName returns the name of the temporary schema of the
+// session, whether or not it has been created already.
+func (p *planner) temporarySchemaName() string {
+	return temporarySchemaName(p.ExtendedEvalContext().SessionID)
+}
+
+// isOwnTemporarySchema returns true if the given schema name refers to the
+// temporary schema of the session, either by name or through the pg_temp
+// alias.
+func (p *planner) isOwnTemporarySchema(scName string) bool {
+	return scName == sessiondata.PgTempSchemaName || scName == p.temporarySchemaName()
+}
+
+// getOrCreateTemporarySchema returns the ID of the temporary schema of the
+// session in the database with the given ID, creating the schema if it does
+// not exist yet.
+func (p *planner) getOrCreateTemporarySchema(
+	ctx context.Context, dbID sqlbase.ID,
+) (sqlbase.ID, error) {
+	scName := p.temporarySchemaName()
+	id, err := getTemporarySchemaID(ctx, p.txn, dbID, scName)
+	if err != nil || id != sqlbase.InvalidID {
+		return id, err
+	}
+
+	id, err = GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+	if err != nil {
+		return sqlbase.InvalidID, err
+	}
+	key := tableKey{parentID: dbID, name: scName}.Key()
+	if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "CPut %s -> %d", key, id)
+	}
+	if err := p.txn.CPut(ctx, key, id, nil); err != nil {
+		return sqlbase.InvalidID, err
+	}
+	p.sessionDataMutator.SetTemporarySchemaName(scName)
+	return id, nil
+}
+
+// getTemporaryTableNames returns the qualified names of the temporary tables
+// of the session, in all databases.
+func (p *planner) getTemporaryTableNames(ctx context.Context) (tree.TableNames, error) {
+	scName := p.SessionData().SearchPath.GetTemporarySchemaName()
+	if scName == "" {
+		// The session never created a temporary schema.
+		return nil, nil
+	}
+	dbs, err := getNamespaceEntries(ctx, p.txn, keys.RootNamespaceID)
+	if err != nil {
+		return nil, err
+	}
+	var names tree.TableNames
+	for _, db := range dbs {
+		scID, err := getTemporarySchemaID(ctx, p.txn, db.id, scName)
+		if err != nil {
+			return nil, err
+		}
+		if scID == sqlbase.InvalidID {
+			continue
+		}
+		tables, err := getNamespaceEntries(ctx, p.txn, scID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tables {
+			names = append(names, tree.MakeTableNameWithSchema(
+				tree.Name(db.name), tree.Name(scName), tree.Name(t.name)))
+		}
+	}
+	return names, nil
+}
+
+// cleanupTemporarySchema drops all the tables of the given temporary schema
+// of the given database, then removes the schema itself.
+func cleanupTemporarySchema(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *client.Txn,
+	dbName string,
+	dbID sqlbase.ID,
+	sc namespaceEntry,
+) error {
+	tables, err := getNamespaceEntries(ctx, txn, sc.id)
+	if err != nil {
+		return err
+	}
+	if len(tables) > 0 {
+		p, cleanup := newInternalPlanner(
+			"drop-temp-tables", txn, security.RootUser, &MemoryMetrics{}, execCfg)
+		defer cleanup()
+		p.avoidCachedDescriptors = true
+		// Resolve the names of the tables as the session which created them.
+		p.sessionDataMutator.SetTemporarySchemaName(sc.name)
+
+		n := &tree.DropTable{IfExists: true, DropBehavior: tree.DropCascade}
+		for _, t := range tables {
+			n.Names = append(n.Names, tree.MakeTableNameWithSchema(
+				tree.Name(dbName), tree.Name(sc.name), tree.Name(t.name)))
+		}
+		plan, err := p.DropTable(ctx, n)
+		if err != nil {
+			return err
+		}
+		defer plan.Close(ctx)
+		params := runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p}
+		if err := startExec(params, plan); err != nil {
+			return err
+		}
+	}
+	return txn.Del(ctx, tableKey{parentID: dbID, name: sc.name}.Key())
+}
+
+// cleanupSessionTempObjects drops the temporary tables and schemas of the
+// session with the given ID, in all databases.
+func cleanupSessionTempObjects(
+	ctx context.Context, execCfg *ExecutorConfig, sessionID ClusterWideID,
+) error {
+	scName := temporarySchemaName(sessionID)
+	var dbs []namespaceEntry
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		var err error
+		dbs, err = getNamespaceEntries(ctx, txn, keys.RootNamespaceID)
+		return err
+	}); err != nil {
+		return err
+	}
+	for _, db := range dbs {
+		if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			scID, err := getTemporarySchemaID(ctx, txn, db.id, scName)
+			if err != nil || scID == sqlbase.InvalidID {
+				return err
+			}
+			return cleanupTemporarySchema(
+				ctx, execCfg, txn, db.name, db.id, namespaceEntry{name: scName, id: scID})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupOrphanedTemporarySchemas drops the temporary schemas, and their
+// tables, of all the sessions which are not running anymore.
+func cleanupOrphanedTemporarySchemas(ctx context.Context, execCfg *ExecutorConfig) error {
+	type tempSchema struct {
+		dbName string
+		dbID   sqlbase.ID
+		namespaceEntry
+	}
+	var schemas []tempSchema
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		schemas = schemas[:0]
+		dbs, err := getNamespaceEntries(ctx, txn, keys.RootNamespaceID)
+		if err != nil {
+			return err
+		}
+		for _, db := range dbs {
+			dbSchemas, err := getTemporarySchemas(ctx, txn, db.id)
+			if err != nil {
+				return err
+			}
+			for _, sc := range dbSchemas {
+				schemas = append(schemas, tempSchema{dbName: db.name, dbID: db.id, namespaceEntry: sc})
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(schemas) == 0 || execCfg.StatusServer == nil {
+		return nil
+	}
+
+	// Find out which sessions are still running. A session on a node which
+	// could not be reached is considered to be running, unless its node is not
+	// live anymore.
+	response, err := execCfg.StatusServer.ListSessions(ctx, &serverpb.ListSessionsRequest{})
+	if err != nil {
+		return err
+	}
+	activeSessions := make(map[uint128.Uint128]struct{}, len(response.Sessions))
+	for _, session := range response.Sessions {
+		activeSessions[BytesToClusterWideID(session.ID).Uint128] = struct{}{}
+	}
+	unreachableNodes := make(map[roachpb.NodeID]struct{}, len(response.Errors))
+	for _, e := range response.Errors {
+		if isLive, err := execCfg.DistSQLPlanner.nodeHealth.isLive(e.NodeID); err != nil || isLive {
+			unreachableNodes[e.NodeID] = struct{}{}
+		}
+	}
+
+	for _, sc := range schemas {
+		sessionID, _ := temporarySchemaSessionID(sc.name)
+		if _, ok := activeSessions[sessionID.Uint128]; ok {
+			continue
+		}
+		if _, ok := unreachableNodes[roachpb.NodeID(sessionID.GetNodeID())]; ok {
+			continue
+		}
+		log.Infof(ctx, "cleaning up temporary schema %s.%s", sc.dbName, sc.name)
+		if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			// Check that the schema was not removed concurrently, for example
+			// by another node.
+			scID, err := getTemporarySchemaID(ctx, txn, sc.dbID, sc.name)
+			if err != nil || scID != sc.id {
+				return err
+			}
+			return cleanupTemporarySchema(ctx, execCfg, txn, sc.dbName, sc.dbID, sc.namespaceEntry)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PeriodicallyCleanupTemporaryObjects runs a loop which drops the temporary
+// tables of sessions which are not running anymore, for example because
+// their node died before they could clean up after themselves.
+func (s *Server) PeriodicallyCleanupTemporaryObjects(ctx context.Context, stopper *stop.Stopper) {
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(tempObjectCleanupInterval.Get(&s.cfg.Settings.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+			}
+			if err := cleanupOrphanedTemporarySchemas(ctx, s.cfg); err != nil {
+				log.Warningf(ctx, "failed to clean up temporary schemas: %v", err)
+			}
+		}
+	})
+}
+
+// isTemporaryTable returns true if the given CREATE TABLE statement creates a
+// temporary table, either because TEMPORARY was specified or because the
+// table was qualified with a temporary schema.
+func isTemporaryTable(n *tree.CreateTable) bool {
+	scName := n.Table.Schema()
+	return n.Temporary || scName == sessiondata.PgTempSchemaName || isTemporarySchemaName(scName)
+}
+
+// checkNotTemporarySchema returns an error if the given name is qualified with
+// a temporary schema. Only tables can be temporary at this point.
+func checkNotTemporarySchema(tn *tree.TableName, kind string) error {
+	if scName := tn.Schema(); scName == sessiondata.PgTempSchemaName || isTemporarySchemaName(scName) {
+		return pgerror.UnimplementedWithIssueErrorf(5807, "temporary %ss", kind)
+	}
+	return nil
+}
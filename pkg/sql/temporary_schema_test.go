@@ -0,0 +1,157 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/pkg/errors"
+)
+
+// tableIsDropped returns nil if the table with the given ID has been dropped.
+func tableIsDropped(ctx context.Context, kvDB *client.DB, id sqlbase.ID) error {
+	return kvDB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		desc, err := sqlbase.GetTableDescFromID(ctx, txn, id)
+		if err == sqlbase.ErrDescriptorNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !desc.Dropped() {
+			return errors.Errorf("table %d is not dropped", id)
+		}
+		return nil
+	})
+}
+
+// TestTemporarySchemaDroppedOnSessionClose verifies that the temporary tables
+// and schema of a session are dropped when the session closes.
+func TestTemporarySchemaDroppedOnSessionClose(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, db, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connDB := sqlutils.MakeSQLRunner(conn)
+	connDB.Exec(t, `CREATE TEMP TABLE d.tmp (a INT PRIMARY KEY)`)
+	connDB.Exec(t, `INSERT INTO d.tmp VALUES (1)`)
+
+	var tableID sqlbase.ID
+	sqlDB.QueryRow(t, `SELECT id FROM system.namespace WHERE name = 'tmp'`).Scan(&tableID)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM system.namespace WHERE name LIKE 'pg_temp_%'`, [][]string{{"1"}})
+
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The session is closed asynchronously by the server.
+	sqlDB.CheckQueryResultsRetry(t,
+		`SELECT count(*) FROM system.namespace WHERE name LIKE 'pg_temp_%'`, [][]string{{"0"}})
+	if err := tableIsDropped(ctx, kvDB, tableID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCleanupOrphanedTemporarySchemas verifies that the background cleanup of
+// temporary schemas drops the schemas of the sessions which are not running
+// anymore, along with their tables, and leaves the others alone.
+func TestCleanupOrphanedTemporarySchemas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, db, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	execCfg := s.ExecutorConfig().(ExecutorConfig)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+
+	var dbID sqlbase.ID
+	sqlDB.QueryRow(t, `SELECT id FROM system.namespace WHERE "parentID" = 0 AND name = 'd'`).Scan(&dbID)
+
+	deadConn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer deadConn.Close()
+	liveConn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer liveConn.Close()
+
+	// Create a temporary table, and hand its schema over to a session which
+	// does not exist, as if the session had died before it could clean up
+	// after itself.
+	sqlutils.MakeSQLRunner(deadConn).Exec(t, `CREATE TEMP TABLE d.dead (a INT PRIMARY KEY)`)
+	var deadSchemaName string
+	var deadSchemaID, deadTableID sqlbase.ID
+	sqlDB.QueryRow(t,
+		`SELECT name, id FROM system.namespace WHERE name LIKE 'pg_temp_%'`,
+	).Scan(&deadSchemaName, &deadSchemaID)
+	sqlDB.QueryRow(t, `SELECT id FROM system.namespace WHERE name = 'dead'`).Scan(&deadTableID)
+	orphanedSchemaName := temporarySchemaName(
+		GenerateClusterWideID(hlc.Timestamp{WallTime: 1}, s.NodeID()))
+	if err := kvDB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		b := txn.NewBatch()
+		b.Del(tableKey{parentID: dbID, name: deadSchemaName}.Key())
+		b.CPut(tableKey{parentID: dbID, name: orphanedSchemaName}.Key(), deadSchemaID, nil)
+		return txn.CommitInBatch(ctx, b)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	liveDB := sqlutils.MakeSQLRunner(liveConn)
+	liveDB.Exec(t, `CREATE TEMP TABLE d.live (a INT PRIMARY KEY)`)
+	liveDB.Exec(t, `INSERT INTO d.live VALUES (1)`)
+	var liveSchemaName string
+	sqlDB.QueryRow(t,
+		`SELECT name FROM system.namespace WHERE name LIKE 'pg_temp_%' AND name != $1`,
+		orphanedSchemaName,
+	).Scan(&liveSchemaName)
+
+	if err := cleanupOrphanedTemporarySchemas(ctx, &execCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT name FROM system.namespace WHERE name LIKE 'pg_temp_%'`,
+		[][]string{{liveSchemaName}})
+	if err := tableIsDropped(ctx, kvDB, deadTableID); err != nil {
+		t.Fatal(err)
+	}
+	liveDB.CheckQueryResults(t, `SELECT * FROM d.live`, [][]string{{"1"}})
+
+	// Cleaning up again is a no-op.
+	if err := cleanupOrphanedTemporarySchemas(ctx, &execCfg); err != nil {
+		t.Fatal(err)
+	}
+	liveDB.CheckQueryResults(t, `SELECT * FROM d.live`, [][]string{{"1"}})
+}
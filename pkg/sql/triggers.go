@@ -0,0 +1,755 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// Triggers are stored in the descriptor of the table they are defined on.
+// Each trigger executes a trigger function: a user-defined function declared
+// with RETURNS TRIGGER, which cannot be called directly. The tableWriters
+// fire the triggers through a triggerRunner, which evaluates the bodies of
+// the trigger functions with the internal executor of the session, in the
+// transaction of the statement that modifies the table.
+//
+// The body of a trigger function accesses the row being modified through the
+// data sources new and old, which contain at most one row each, and the
+// trigger context through the single row of tg, whose columns mirror the TG_*
+// variables of PL/pgSQL. For example, the body of a trigger function executed
+// by a row-level trigger trig on the table t (a INT, b STRING):
+//
+//   SELECT new.a, upper(new.b) FROM new
+//
+// is evaluated as:
+//
+//   WITH new (a, b) AS (SELECT $1::INT, $2::STRING WHERE $5::BOOL),
+//        old (a, b) AS (SELECT $3::INT, $4::STRING WHERE $6::BOOL),
+//        tg (tg_name, tg_when, tg_level, tg_op, tg_table_name,
+//            tg_table_schema, tg_nargs, tg_argv) AS (VALUES
+//          ('trig', 'BEFORE', 'ROW', $7::STRING, 't', 'public', 0, ARRAY[]))
+//   SELECT new.a, upper(new.b) FROM new
+//
+// The result of a BEFORE ROW trigger determines what happens to the row being
+// modified: the first row returned by the function replaces the row being
+// inserted or updated, and if the function returns no rows the modification
+// of the row is skipped. The results of the other triggers are ignored.
+//
+// The rows exposed to triggers contain the visible columns of the table.
+//
+// BEFORE ROW triggers are not supported with UPSERT and INSERT ... ON
+// CONFLICT, which are rejected on tables with BEFORE INSERT triggers, or
+// BEFORE UPDATE triggers unless the statement does nothing on conflict. The
+// conflicting rows are determined from the rows produced by the statement,
+// before the writers could fire the triggers: a BEFORE INSERT trigger which
+// modified the conflict key would bypass the ON CONFLICT clause. Likewise,
+// the writers only fetch and write the columns assigned by the statement, so
+// a BEFORE UPDATE trigger could not modify the other columns.
+// AFTER ROW and statement-level triggers fire normally.
+
+// parseTriggerFunctionBody parses the body of a trigger function, which must
+// be a single SELECT, INSERT, UPDATE or DELETE statement.
+func parseTriggerFunctionBody(body string) (tree.Statement, error) {
+	stmt, err := parser.ParseOne(body)
+	if err != nil {
+		return nil, err
+	}
+	switch stmt.AST.(type) {
+	case *tree.Select, *tree.Insert, *tree.Update, *tree.Delete:
+		return stmt.AST, nil
+	}
+	return nil, pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+		"the body of a trigger function must be a single query, not %s", stmt.AST.StatementTag())
+}
+
+// triggerColumns returns the columns of the rows exposed to the triggers of
+// the given table.
+func triggerColumns(desc *sqlbase.TableDescriptor) []sqlbase.ColumnDescriptor {
+	var cols []sqlbase.ColumnDescriptor
+	for _, col := range desc.Columns {
+		if !col.Hidden {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// numTriggerPlaceholders returns the number of placeholders in the query
+// built by makeTriggerQuery for a table with the given number of trigger
+// columns.
+func numTriggerPlaceholders(numCols int) int {
+	if numCols == 0 {
+		return 1
+	}
+	return 2*numCols + 3
+}
+
+// makeTriggerQuery returns the query that runs the function of the given
+// trigger on the given table. The query expects the placeholders described
+// by triggerQueryArgs.
+func makeTriggerQuery(
+	trigger *sqlbase.TriggerDescriptor,
+	fn *sqlbase.FunctionDescriptor,
+	desc *sqlbase.TableDescriptor,
+	cols []sqlbase.ColumnDescriptor,
+) (string, error) {
+	body, err := parseTriggerFunctionBody(fn.Body)
+	if err != nil {
+		return "", err
+	}
+
+	placeholder := func(idx int, typ types.T) (tree.Expr, error) {
+		colTyp, err := coltypes.DatumTypeToColumnType(typ)
+		if err != nil {
+			return nil, err
+		}
+		return &tree.CastExpr{
+			Expr:       &tree.Placeholder{Idx: types.PlaceholderIdx(idx)},
+			Type:       colTyp,
+			SyntaxMode: tree.CastShort,
+		}, nil
+	}
+
+	var ctes []*tree.CTE
+	opIdx := 0
+	if n := len(cols); n > 0 {
+		names := make(tree.NameList, n)
+		for i := range cols {
+			names[i] = tree.Name(cols[i].Name)
+		}
+		// rowCTE returns the definition of the new or old data source, whose
+		// values are given by the placeholders starting at firstIdx, and
+		// which contains a row if the placeholder at presentIdx is true.
+		rowCTE := func(name tree.Name, firstIdx, presentIdx int) (*tree.CTE, error) {
+			exprs := make(tree.SelectExprs, n)
+			for i := range cols {
+				expr, err := placeholder(firstIdx+i, cols[i].Type.ToDatumType())
+				if err != nil {
+					return nil, err
+				}
+				exprs[i].Expr = expr
+			}
+			present, err := placeholder(presentIdx, types.Bool)
+			if err != nil {
+				return nil, err
+			}
+			return &tree.CTE{
+				Name: tree.AliasClause{Alias: name, Cols: names},
+				Stmt: &tree.Select{Select: &tree.SelectClause{
+					Exprs: exprs,
+					Where: tree.NewWhere(tree.AstWhere, present),
+				}},
+			}, nil
+		}
+		newCTE, err := rowCTE("new", 0, 2*n)
+		if err != nil {
+			return "", err
+		}
+		oldCTE, err := rowCTE("old", n, 2*n+1)
+		if err != nil {
+			return "", err
+		}
+		ctes = append(ctes, newCTE, oldCTE)
+		opIdx = 2*n + 2
+	}
+
+	op, err := placeholder(opIdx, types.String)
+	if err != nil {
+		return "", err
+	}
+	level := "STATEMENT"
+	if trigger.ForEachRow {
+		level = "ROW"
+	}
+	argv := tree.NewDArray(types.String)
+	for _, arg := range trigger.Args {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			return "", err
+		}
+	}
+	ctes = append(ctes, &tree.CTE{
+		Name: tree.AliasClause{Alias: "tg", Cols: tree.NameList{
+			"tg_name", "tg_when", "tg_level", "tg_op",
+			"tg_table_name", "tg_table_schema", "tg_nargs", "tg_argv",
+		}},
+		Stmt: &tree.Select{Select: &tree.ValuesClause{Rows: []tree.Exprs{{
+			tree.NewDString(trigger.Name),
+			tree.NewDString(trigger.ActionTime.String()),
+			tree.NewDString(level),
+			op,
+			tree.NewDString(desc.Name),
+			tree.NewDString(tree.PublicSchema),
+			tree.NewDInt(tree.DInt(len(trigger.Args))),
+			argv,
+		}}}},
+	})
+
+	var with **tree.With
+	switch t := body.(type) {
+	case *tree.Select:
+		with = &t.With
+	case *tree.Insert:
+		with = &t.With
+	case *tree.Update:
+		with = &t.With
+	case *tree.Delete:
+		with = &t.With
+	}
+	if *with == nil {
+		*with = &tree.With{}
+	}
+	(*with).CTEList = append(ctes, (*with).CTEList...)
+	return tree.AsStringWithFlags(body, tree.FmtParsable), nil
+}
+
+// triggerQueryArgs returns the arguments of the query built by
+// makeTriggerQuery: the values of the new row, then of the old row, whether
+// each of them is present, and the name of the event. oldRow and newRow are
+// in the order of the trigger columns, and are nil if not present.
+func triggerQueryArgs(
+	numCols int, event sqlbase.TriggerDescriptor_Event, oldRow, newRow tree.Datums,
+) []interface{} {
+	qargs := make([]interface{}, 0, numTriggerPlaceholders(numCols))
+	if numCols > 0 {
+		for _, row := range []tree.Datums{newRow, oldRow} {
+			for i := 0; i < numCols; i++ {
+				if row == nil {
+					qargs = append(qargs, tree.DNull)
+				} else {
+					qargs = append(qargs, row[i])
+				}
+			}
+		}
+		qargs = append(qargs, newRow != nil, oldRow != nil)
+	}
+	return append(qargs, event.String())
+}
+
+// triggerFiresOn returns true if the given trigger fires for the given
+// event.
+func triggerFiresOn(trigger *sqlbase.TriggerDescriptor, event sqlbase.TriggerDescriptor_Event) bool {
+	for _, e := range trigger.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// firingTrigger is a trigger prepared for execution by a triggerRunner.
+type firingTrigger struct {
+	desc   *sqlbase.TriggerDescriptor
+	opName string
+	query  string
+}
+
+// triggerRunner fires the triggers of a table for the modifications performed
+// by a statement. It is created by the planner, and used by the tableWriter
+// that performs the modifications:
+//
+// - beforeRow is called for every row, before the row is written. It fires
+//   the BEFORE STATEMENT triggers before the first row, then the BEFORE ROW
+//   triggers for the row.
+// - afterRow is called for every row written, and queues the row for the
+//   AFTER ROW triggers.
+// - fireAfter is called once all the rows have been written. It fires the
+//   AFTER ROW triggers for the queued rows, then the AFTER STATEMENT
+//   triggers.
+type triggerRunner struct {
+	evalCtx *tree.EvalContext
+	ie      *SessionBoundInternalExecutor
+	desc    *sqlbase.ImmutableTableDescriptor
+
+	// cols are the columns of the rows exposed to the triggers.
+	cols []sqlbase.ColumnDescriptor
+
+	// events are the kinds of modifications performed by the statement.
+	events []sqlbase.TriggerDescriptor_Event
+
+	// triggers are the triggers that fire for any of the events, in the order
+	// in which they fire.
+	triggers []firingTrigger
+
+	// firedBeforeStatement is set once the BEFORE STATEMENT triggers have
+	// fired.
+	firedBeforeStatement bool
+
+	// afterRowEvents is the set of events that fire AFTER ROW triggers.
+	afterRowEvents map[sqlbase.TriggerDescriptor_Event]bool
+
+	// afterRows holds, for the events that fire AFTER ROW triggers, the old
+	// and new values of the modified rows, concatenated.
+	afterRows map[sqlbase.TriggerDescriptor_Event]*rowcontainer.RowContainer
+
+	// The following fields are used to validate the rows modified by BEFORE
+	// ROW triggers.
+	computeExprs  []tree.TypedExpr
+	checkHelper   *sqlbase.CheckHelper
+	ivarContainer sqlbase.RowIndexedVarContainer
+}
+
+// newTriggerRunner returns a triggerRunner that fires the triggers of the
+// given table for the given events, or nil if no trigger fires for them.
+func (p *planner) newTriggerRunner(
+	ctx context.Context,
+	desc *sqlbase.ImmutableTableDescriptor,
+	events ...sqlbase.TriggerDescriptor_Event,
+) (*triggerRunner, error) {
+	if len(desc.Triggers) == 0 {
+		return nil, nil
+	}
+	r := &triggerRunner{
+		evalCtx: p.EvalContext(),
+		ie:      p.ExtendedEvalContext().InternalExecutor.(*SessionBoundInternalExecutor),
+		desc:    desc,
+		cols:    triggerColumns(desc.TableDesc()),
+		events:  events,
+	}
+	beforeRow := false
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		fires := false
+		for _, e := range events {
+			if !triggerFiresOn(t, e) {
+				continue
+			}
+			fires = true
+			if !t.ForEachRow {
+				continue
+			}
+			if t.ActionTime == sqlbase.TriggerDescriptor_BEFORE {
+				beforeRow = true
+			} else {
+				if r.afterRowEvents == nil {
+					r.afterRowEvents = make(map[sqlbase.TriggerDescriptor_Event]bool)
+				}
+				r.afterRowEvents[e] = true
+			}
+		}
+		if !fires {
+			continue
+		}
+		fn := &sqlbase.FunctionDescriptor{}
+		if err := getDescriptorByID(ctx, p.txn, t.FunctionID, fn); err != nil {
+			return nil, err
+		}
+		query, err := makeTriggerQuery(t, fn, desc.TableDesc(), r.cols)
+		if err != nil {
+			return nil, err
+		}
+		r.triggers = append(r.triggers, firingTrigger{
+			desc:   t,
+			opName: fmt.Sprintf("trigger-%s", t.Name),
+			query:  query,
+		})
+	}
+	if len(r.triggers) == 0 {
+		return nil, nil
+	}
+
+	if beforeRow {
+		tn := tree.MakeUnqualifiedTableName(tree.Name(desc.Name))
+		var err error
+		r.computeExprs, err = sqlbase.MakeComputedExprs(
+			desc.Columns, desc, &tn, &p.txCtx, p.EvalContext(), false /* addingCols */)
+		if err != nil {
+			return nil, err
+		}
+		r.ivarContainer.Cols = desc.Columns
+		r.checkHelper, err = sqlbase.NewEvalCheckHelper(ctx, p.analyzeExpr, desc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// hasBeforeRow returns true if BEFORE ROW triggers fire for the given event.
+func (r *triggerRunner) hasBeforeRow(event sqlbase.TriggerDescriptor_Event) bool {
+	for i := range r.triggers {
+		t := r.triggers[i].desc
+		if t.ActionTime == sqlbase.TriggerDescriptor_BEFORE && t.ForEachRow && triggerFiresOn(t, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// fire runs the function of the given trigger and returns its result. oldRow
+// and newRow are in the order of the trigger columns, and are nil if not
+// present.
+func (r *triggerRunner) fire(
+	ctx context.Context,
+	txn *client.Txn,
+	t *firingTrigger,
+	event sqlbase.TriggerDescriptor_Event,
+	oldRow, newRow tree.Datums,
+) ([]tree.Datums, error) {
+	// Triggers can modify tables with triggers, so bound the nesting depth
+	// to stop runaway recursion. The depth is shared with calls to
+	// user-defined functions, which are evaluated the same way.
+	depth, _ := ctx.Value(functionCallDepthKey{}).(int)
+	if depth >= maxFunctionCallDepth {
+		return nil, pgerror.NewErrorf(pgerror.CodeStatementTooComplexError,
+			"triggers nested too deeply (more than %d levels)", maxFunctionCallDepth)
+	}
+	ctx = context.WithValue(ctx, functionCallDepthKey{}, depth+1)
+
+	rows, _, err := r.ie.Query(
+		ctx, t.opName, txn, t.query, triggerQueryArgs(len(r.cols), event, oldRow, newRow)...)
+	return rows, err
+}
+
+// fireStatement fires the statement-level triggers with the given action
+// time.
+func (r *triggerRunner) fireStatement(
+	ctx context.Context, txn *client.Txn, actionTime sqlbase.TriggerDescriptor_ActionTime,
+) error {
+	for _, e := range r.events {
+		for i := range r.triggers {
+			t := &r.triggers[i]
+			if t.desc.ActionTime != actionTime || t.desc.ForEachRow || !triggerFiresOn(t.desc, e) {
+				continue
+			}
+			if _, err := r.fire(ctx, txn, t, e, nil /* oldRow */, nil /* newRow */); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fireBeforeStatement fires the BEFORE STATEMENT triggers, unless they have
+// already fired.
+func (r *triggerRunner) fireBeforeStatement(ctx context.Context, txn *client.Txn) error {
+	if r.firedBeforeStatement {
+		return nil
+	}
+	r.firedBeforeStatement = true
+	return r.fireStatement(ctx, txn, sqlbase.TriggerDescriptor_BEFORE)
+}
+
+// triggerRow reshapes a row in the layout given by colIdx to the order of the
+// trigger columns.
+func (r *triggerRunner) triggerRow(row tree.Datums, colIdx map[sqlbase.ColumnID]int) tree.Datums {
+	if row == nil {
+		return nil
+	}
+	res := make(tree.Datums, len(r.cols))
+	for i := range r.cols {
+		if idx, ok := colIdx[r.cols[i].ID]; ok {
+			res[i] = row[idx]
+		} else {
+			res[i] = tree.DNull
+		}
+	}
+	return res
+}
+
+// beforeRow fires the BEFORE ROW triggers for the modification of a row,
+// after firing the BEFORE STATEMENT triggers if this is the first modified
+// row. oldRow and newRow are in the layout given by colIdx; oldRow is nil for
+// INSERT, and newRow is nil for DELETE.
+//
+// It returns the row to write, or nil if a trigger skipped the modification
+// of the row. For INSERT and UPDATE, this is newRow as modified by the
+// triggers, which is validated again against the schema of the table if it
+// was modified; newRow itself is not modified. For DELETE, this is oldRow.
+func (r *triggerRunner) beforeRow(
+	ctx context.Context,
+	txn *client.Txn,
+	event sqlbase.TriggerDescriptor_Event,
+	oldRow, newRow tree.Datums,
+	colIdx map[sqlbase.ColumnID]int,
+) (tree.Datums, error) {
+	if err := r.fireBeforeStatement(ctx, txn); err != nil {
+		return nil, err
+	}
+
+	result := newRow
+	if event == sqlbase.TriggerDescriptor_DELETE {
+		result = oldRow
+	}
+	tgOld, tgNew := r.triggerRow(oldRow, colIdx), r.triggerRow(newRow, colIdx)
+	modified := false
+	for i := range r.triggers {
+		t := &r.triggers[i]
+		if t.desc.ActionTime != sqlbase.TriggerDescriptor_BEFORE || !t.desc.ForEachRow ||
+			!triggerFiresOn(t.desc, event) {
+			continue
+		}
+		rows, err := r.fire(ctx, txn, t, event, tgOld, tgNew)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		if event == sqlbase.TriggerDescriptor_DELETE {
+			continue
+		}
+
+		// The row returned by the trigger replaces the new row, and is passed
+		// to the next trigger.
+		row := rows[0]
+		if err := r.checkRowStructure(row); err != nil {
+			return nil, err
+		}
+		for j, d := range row {
+			if d.Compare(r.evalCtx, tgNew[j]) == 0 {
+				continue
+			}
+			idx, ok := colIdx[r.cols[j].ID]
+			if !ok {
+				return nil, pgerror.NewAssertionErrorf(
+					"column %q modified by trigger %q is not written", r.cols[j].Name, t.desc.Name)
+			}
+			if !modified {
+				result = append(tree.Datums(nil), newRow...)
+				modified = true
+			}
+			result[idx] = d
+			tgNew[j] = d
+		}
+	}
+	if modified {
+		if err := r.validateRow(result, colIdx); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// checkRowStructure checks that a row returned by a trigger has the columns
+// of the table.
+func (r *triggerRunner) checkRowStructure(row tree.Datums) error {
+	mismatch := len(row) != len(r.cols)
+	for i := 0; !mismatch && i < len(row); i++ {
+		mismatch = row[i] != tree.DNull && !row[i].ResolvedType().Equivalent(r.cols[i].Type.ToDatumType())
+	}
+	if mismatch {
+		return pgerror.NewError(pgerror.CodeDatatypeMismatchError,
+			"returned row structure does not match the structure of the triggering table")
+	}
+	return nil
+}
+
+// validateRow checks a row modified by BEFORE ROW triggers against the schema
+// of the table, like INSERT and UPDATE check the rows they write: computed
+// columns are computed again, then the NOT NULL, width and CHECK constraints
+// are verified.
+func (r *triggerRunner) validateRow(row tree.Datums, colIdx map[sqlbase.ColumnID]int) error {
+	if r.computeExprs != nil {
+		r.ivarContainer.CurSourceRow = row
+		r.ivarContainer.Mapping = colIdx
+		r.evalCtx.PushIVarContainer(&r.ivarContainer)
+		for i := range r.desc.Columns {
+			col := &r.desc.Columns[i]
+			idx, ok := colIdx[col.ID]
+			if !col.IsComputed() || !ok {
+				continue
+			}
+			d, err := r.computeExprs[i].Eval(r.evalCtx)
+			if err != nil {
+				r.evalCtx.PopIVarContainer()
+				return errors.Wrapf(err, "computed column %s", tree.ErrString((*tree.Name)(&col.Name)))
+			}
+			row[idx] = d
+		}
+		r.evalCtx.PopIVarContainer()
+	}
+
+	for _, col := range r.desc.WritableColumns() {
+		idx, ok := colIdx[col.ID]
+		if !ok {
+			continue
+		}
+		if row[idx] == tree.DNull {
+			if !col.Nullable {
+				return sqlbase.NewNonNullViolationError(col.Name)
+			}
+			continue
+		}
+		d, err := sqlbase.LimitValueWidth(col.Type, row[idx], &col.Name)
+		if err != nil {
+			return err
+		}
+		row[idx] = d
+	}
+
+	if r.checkHelper != nil {
+		if err := r.checkHelper.LoadEvalRow(colIdx, row, false); err != nil {
+			return err
+		}
+		if err := r.checkHelper.CheckEval(r.evalCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterRow queues a modified row for the AFTER ROW triggers. oldRow and newRow
+// are in the layout given by colIdx; oldRow is nil for INSERT, and newRow is
+// nil for DELETE.
+func (r *triggerRunner) afterRow(
+	ctx context.Context,
+	event sqlbase.TriggerDescriptor_Event,
+	oldRow, newRow tree.Datums,
+	colIdx map[sqlbase.ColumnID]int,
+) error {
+	if !r.afterRowEvents[event] {
+		return nil
+	}
+	rows := r.afterRows[event]
+	if rows == nil {
+		if r.afterRows == nil {
+			r.afterRows = make(map[sqlbase.TriggerDescriptor_Event]*rowcontainer.RowContainer)
+		}
+		cols := append(append([]sqlbase.ColumnDescriptor(nil), r.cols...), r.cols...)
+		rows = rowcontainer.NewRowContainer(
+			r.evalCtx.Mon.MakeBoundAccount(), sqlbase.ColTypeInfoFromColDescs(cols), 0,
+		)
+		r.afterRows[event] = rows
+	}
+	row := make(tree.Datums, 2*len(r.cols))
+	for i := range row {
+		row[i] = tree.DNull
+	}
+	if oldRow != nil {
+		copy(row, r.triggerRow(oldRow, colIdx))
+	}
+	if newRow != nil {
+		copy(row[len(r.cols):], r.triggerRow(newRow, colIdx))
+	}
+	_, err := rows.AddRow(ctx, row)
+	return err
+}
+
+// fireAfter fires the AFTER ROW triggers for the rows queued by afterRow, then
+// the AFTER STATEMENT triggers. The BEFORE STATEMENT triggers fire first if
+// the statement did not modify any row.
+func (r *triggerRunner) fireAfter(ctx context.Context, txn *client.Txn) error {
+	if err := r.fireBeforeStatement(ctx, txn); err != nil {
+		return err
+	}
+	n := len(r.cols)
+	for _, e := range r.events {
+		rows := r.afterRows[e]
+		if rows == nil {
+			continue
+		}
+		for i := 0; i < rows.Len(); i++ {
+			row := rows.At(i)
+			var oldRow, newRow tree.Datums
+			if e != sqlbase.TriggerDescriptor_INSERT {
+				oldRow = row[:n:n]
+			}
+			if e != sqlbase.TriggerDescriptor_DELETE {
+				newRow = row[n:]
+			}
+			for j := range r.triggers {
+				t := &r.triggers[j]
+				if t.desc.ActionTime != sqlbase.TriggerDescriptor_AFTER || !t.desc.ForEachRow ||
+					!triggerFiresOn(t.desc, e) {
+					continue
+				}
+				if _, err := r.fire(ctx, txn, t, e, oldRow, newRow); err != nil {
+					return err
+				}
+			}
+		}
+		rows.Clear(ctx)
+	}
+	return r.fireStatement(ctx, txn, sqlbase.TriggerDescriptor_AFTER)
+}
+
+// close frees the resources held by the triggerRunner.
+func (r *triggerRunner) close(ctx context.Context) {
+	for _, rows := range r.afterRows {
+		rows.Close(ctx)
+	}
+	r.afterRows = nil
+}
+
+// appendTriggerUpdateExprs extends the SET clause of an UPDATE on a table with
+// BEFORE UPDATE row-level triggers, so that the triggers can modify any column
+// of the updated rows. Each visible column that is not assigned by the
+// statement, is not computed and is not part of the primary key is assigned its
+// current value.
+func appendTriggerUpdateExprs(
+	desc *sqlbase.TableDescriptor, exprs tree.UpdateExprs,
+) tree.UpdateExprs {
+	if !desc.HasBeforeRowTriggers(sqlbase.TriggerDescriptor_UPDATE) {
+		return exprs
+	}
+	assigned := make(map[tree.Name]struct{})
+	for _, expr := range exprs {
+		for _, name := range expr.Names {
+			assigned[name] = struct{}{}
+		}
+	}
+	var pkCols util.FastIntSet
+	for _, id := range desc.PrimaryIndex.ColumnIDs {
+		pkCols.Add(int(id))
+	}
+	res := append(tree.UpdateExprs(nil), exprs...)
+	for _, col := range triggerColumns(desc) {
+		name := tree.Name(col.Name)
+		if _, ok := assigned[name]; ok || col.IsComputed() || pkCols.Contains(int(col.ID)) {
+			continue
+		}
+		res = append(res, &tree.UpdateExpr{
+			Names: tree.NameList{name},
+			Expr:  &tree.ColumnItem{ColumnName: name},
+		})
+	}
+	return res
+}
+
+// newUpsertTriggerRunner returns a triggerRunner for an UPSERT or INSERT ...
+// ON CONFLICT statement, which inserts rows, and updates the conflicting rows
+// unless doNothing is set. It returns an error if BEFORE ROW triggers would
+// fire, since they are not supported by such statements.
+func (p *planner) newUpsertTriggerRunner(
+	ctx context.Context, desc *sqlbase.ImmutableTableDescriptor, doNothing bool,
+) (*triggerRunner, error) {
+	events := []sqlbase.TriggerDescriptor_Event{sqlbase.TriggerDescriptor_INSERT}
+	if !doNothing {
+		events = append(events, sqlbase.TriggerDescriptor_UPDATE)
+	}
+	r, err := p.newTriggerRunner(ctx, desc, events...)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	for _, e := range events {
+		if r.hasBeforeRow(e) {
+			return nil, pgerror.UnimplementedWithIssueErrorf(28296,
+				"BEFORE %s row-level triggers are not supported with UPSERT or INSERT ... ON CONFLICT", e)
+		}
+	}
+	return r, nil
+}
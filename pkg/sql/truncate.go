@@ -267,7 +267,7 @@ func (p *planner) truncateTable(
 	newTableDesc.Mutations = nil
 	newTableDesc.GCMutations = nil
 	newTableDesc.ModificationTime = p.txn.CommitTimestamp()
-	tKey := tableKey{parentID: newTableDesc.ParentID, name: newTableDesc.Name}
+	tKey := tableKey{parentID: newTableDesc.GetNamespaceParentID(), name: newTableDesc.Name}
 	key := tKey.Key()
 	if err := p.createDescriptorWithID(
 		ctx, key, newID, newTableDesc, p.ExtendedEvalContext().Settings); err != nil {
@@ -279,6 +279,17 @@ func (p *planner) truncateTable(
 		return err
 	}
 
+	// Point the functions executed by the triggers at the new table.
+	if err := updateTriggerFunctions(ctx, p, tableDesc.TableDesc(), func(fn *sqlbase.FunctionDescriptor) {
+		for i := range fn.TriggerTableIDs {
+			if fn.TriggerTableIDs[i] == tableDesc.ID {
+				fn.TriggerTableIDs[i] = newID
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
 	// Copy the zone config.
 	b = &client.Batch{}
 	b.Get(zoneKey)
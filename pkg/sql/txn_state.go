@@ -112,6 +112,15 @@ type txnState struct {
 	// activeSavepointName stores the name of the active savepoint,
 	// or is empty if no savepoint is active.
 	activeSavepointName tree.Name
+
+	// savepoints is the stack of regular (i.e. not cockroach_restart)
+	// savepoints established in the current transaction, innermost last.
+	savepoints savepointStack
+
+	// numDDL counts the DDL statements executed in the current transaction.
+	// Savepoints record it so that rollbacks across schema changes, which are
+	// not supported, can be detected.
+	numDDL int
 }
 
 // txnType represents the type of a SQL transaction.
@@ -225,6 +234,8 @@ func (ts *txnState) resetForNewSQLTxn(
 
 	// Discard the old schemaChangers, if any.
 	ts.schemaChangers = schemaChangerCollection{}
+	ts.savepoints = nil
+	ts.numDDL = 0
 }
 
 // finishSQLTxn finalizes a transaction's results and closes the root span for
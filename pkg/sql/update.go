@@ -62,6 +62,11 @@ func (p *planner) Update(
 		return nil, pgerror.NewDangerousStatementErrorf("UPDATE without WHERE clause")
 	}
 
+	if len(n.From) > 0 {
+		return nil, pgerror.UnimplementedWithIssueErrorf(7841,
+			"UPDATE ... FROM is only supported by the cost-based optimizer")
+	}
+
 	// CTE analysis.
 	resetter, err := p.initWith(ctx, n.With)
 	if err != nil {
@@ -120,8 +125,9 @@ func (p *planner) Update(
 	}
 
 	// Extract all the LHS column names, and verify that the arity of
-	// the LHS and RHS match when assigning tuples.
-	names, setExprs, err := p.namesForExprs(ctx, n.Exprs)
+	// the LHS and RHS match when assigning tuples. If the table has
+	// triggers, all the columns they can modify are assigned.
+	names, setExprs, err := p.namesForExprs(ctx, appendTriggerUpdateExprs(desc.TableDesc(), n.Exprs))
 	if err != nil {
 		return nil, err
 	}
@@ -169,9 +175,9 @@ func (p *planner) Update(
 	rowsNeeded := resultsNeeded(n.Returning)
 
 	var requestedCols []sqlbase.ColumnDescriptor
-	if rowsNeeded {
+	if rowsNeeded || len(desc.Triggers) > 0 {
 		// TODO(dan): This could be made tighter, just the rows needed for RETURNING
-		// exprs.
+		// exprs. Triggers see the whole rows.
 		requestedCols = desc.Columns
 	} else if len(desc.AllChecks()) > 0 {
 		// Request any columns we'll need when validating check constraints. We
@@ -224,6 +230,7 @@ func (p *planner) Update(
 	if err != nil {
 		return nil, err
 	}
+	ru.DeferFKChecks(p.deferredFKChecks())
 
 	tracing.AnnotateTrace()
 
@@ -379,12 +386,17 @@ func (p *planner) Update(
 		updateColsIdx[col.ID] = i
 	}
 
+	triggers, err := p.newTriggerRunner(ctx, desc, sqlbase.TriggerDescriptor_UPDATE)
+	if err != nil {
+		return nil, err
+	}
+
 	un := updateNodePool.Get().(*updateNode)
 	*un = updateNode{
 		source:  rows,
 		columns: columns,
 		run: updateRun{
-			tu:           tableUpdater{ru: ru},
+			tu:           tableUpdater{tableWriterBase: tableWriterBase{triggers: triggers}, ru: ru},
 			checkHelper:  checkHelper,
 			rowsNeeded:   rowsNeeded,
 			computedCols: computedCols,
@@ -550,8 +562,6 @@ func (u *updateNode) BatchedNext(params runParams) (bool, error) {
 			return false, err
 		}
 
-		u.run.rowCount++
-
 		// Are we done yet with the current batch?
 		if u.run.tu.curBatchSize() >= maxUpdateBatchSize {
 			break
@@ -701,11 +711,18 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		}
 	}
 
+	// Fire the BEFORE triggers, if any. They can modify the updated values or
+	// skip the update of the row.
+	if keep, err := u.run.tu.beforeRow(params.ctx, oldValues, u.run.updateValues); err != nil || !keep {
+		return err
+	}
+
 	// Queue the insert in the KV batch.
 	newValues, err := u.run.tu.rowForUpdate(params.ctx, oldValues, u.run.updateValues, u.run.traceKV)
 	if err != nil {
 		return err
 	}
+	u.run.rowCount++
 
 	// If result rows need to be accumulated, do it.
 	if u.run.rows != nil {
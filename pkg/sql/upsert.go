@@ -71,6 +71,11 @@ func (p *planner) newUpsertNode(
 		return nil, err
 	}
 
+	triggers, err := p.newUpsertTriggerRunner(ctx, desc, n.OnConflict.DoNothing)
+	if err != nil {
+		return nil, err
+	}
+
 	// Instantiate the upsert node.
 	un := upsertNodePool.Get().(*upsertNode)
 	*un = upsertNode{
@@ -101,18 +106,21 @@ func (p *planner) newUpsertNode(
 		if conflictIndex == nil {
 			un.run.tw = &strictTableUpserter{
 				tableUpserterBase: tableUpserterBase{
-					ri:          ri,
-					collectRows: needRows,
-					alloc:       &p.alloc,
+					tableWriterBase: tableWriterBase{triggers: triggers},
+					ri:              ri,
+					collectRows:     needRows,
+					alloc:           &p.alloc,
 				},
 			}
 		} else {
 			un.run.tw = &tableUpserter{
 				conflictIndex: *conflictIndex,
 				tableUpserterBase: tableUpserterBase{
-					ri:          ri,
-					collectRows: needRows,
-					alloc:       &p.alloc,
+					tableWriterBase:  tableWriterBase{triggers: triggers},
+					ri:               ri,
+					collectRows:      needRows,
+					alloc:            &p.alloc,
+					deferredFKChecks: p.deferredFKChecks(),
 				},
 			}
 		}
@@ -200,7 +208,9 @@ func (p *planner) newUpsertNode(
 			len(ri.InsertCols) == len(desc.Columns) &&
 			// We cannot use the fast path if we also have a RETURNING clause, because
 			// RETURNING wants to see only the updated rows.
-			!needRows
+			!needRows &&
+			// Triggers need to see the inserted and updated rows.
+			triggers == nil
 
 		if enableFastPath {
 			// We then use the super-simple, super-fast writer. There's not
@@ -214,9 +224,11 @@ func (p *planner) newUpsertNode(
 			// General/slow path.
 			un.run.tw = &tableUpserter{
 				tableUpserterBase: tableUpserterBase{
-					ri:          ri,
-					alloc:       &p.alloc,
-					collectRows: needRows,
+					tableWriterBase:  tableWriterBase{triggers: triggers},
+					ri:               ri,
+					alloc:            &p.alloc,
+					collectRows:      needRows,
+					deferredFKChecks: p.deferredFKChecks(),
 				},
 				anyComputed:   len(computeExprs) >= 0,
 				fkTables:      fkTables,
@@ -814,7 +826,9 @@ func upsertExprsAndIndex(
 	// General case: INSERT with an ON CONFLICT clause.
 
 	indexMatch := func(index sqlbase.IndexDescriptor) bool {
-		if !index.Unique {
+		// A partial unique index only ensures the uniqueness of the rows that
+		// satisfy its predicate, so it cannot be used to detect conflicts.
+		if !index.Unique || index.IsPartial() {
 			return false
 		}
 		if len(index.ColumnNames) != len(onConflict.Columns) {
@@ -0,0 +1,153 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// User-defined schemas are stored as SchemaDescriptors. Like a temporary
+// schema, a user-defined schema is a namespace entry under its database which
+// maps the name of the schema to the ID of its descriptor, and the names of
+// the objects in the schema are stored under that ID instead of the ID of the
+// database (see TableDescriptor.GetNamespaceParentID). As a consequence, a
+// schema and a table of the public schema cannot have the same name.
+//
+// Like user-defined types, schemas are not leased: they are read in the
+// current transaction every time a name is resolved in them.
+//
+// At this point, only tables, views and sequences can be created in
+// user-defined schemas.
+
+// getSchemaDesc looks up the user-defined schema with the given name in the
+// database with the given ID. It returns nil if there is no such schema,
+// including when the name refers to an object of the public schema.
+func getSchemaDesc(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID, name string,
+) (*sqlbase.SchemaDescriptor, error) {
+	key := tableKey{parentID: dbID, name: name}.Key()
+	log.Eventf(ctx, "looking up schema ID for name key %q", key)
+	gr, err := txn.Get(ctx, key)
+	if err != nil || !gr.Exists() {
+		return nil, err
+	}
+	desc := &sqlbase.Descriptor{}
+	if err := txn.GetProto(ctx, sqlbase.MakeDescMetadataKey(sqlbase.ID(gr.ValueInt())), desc); err != nil {
+		return nil, err
+	}
+	sc := desc.GetSchema()
+	if sc == nil {
+		return nil, nil
+	}
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// getSchemaNamespaceID returns the ID under which the names of the objects in
+// the given schema of the database with the given ID are stored in
+// system.namespace. This is the ID of the database itself for the public
+// schema, and the ID of the schema for temporary and user-defined schemas.
+// InvalidID is returned if the schema does not exist.
+//
+// The IDs of temporary and user-defined schemas are not cached, so resolving
+// names in these schemas costs additional KV lookups.
+func getSchemaNamespaceID(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID, scName string,
+) (sqlbase.ID, error) {
+	if scName == tree.PublicSchema {
+		return dbID, nil
+	}
+	if isTemporarySchemaName(scName) {
+		return getTemporarySchemaID(ctx, txn, dbID, scName)
+	}
+	sc, err := getSchemaDesc(ctx, txn, dbID, scName)
+	if err != nil || sc == nil {
+		return sqlbase.InvalidID, err
+	}
+	return sc.ID, nil
+}
+
+// isVirtualSchemaName returns true if the given name is the name of a virtual
+// schema, like pg_catalog.
+func isVirtualSchemaName(scName string) bool {
+	switch scName {
+	case informationSchemaName, pgCatalogName, crdbInternalName:
+		return true
+	}
+	return false
+}
+
+// resolveTargetSchema returns the descriptor of the user-defined schema in
+// which an object with the given name is about to be created, or nil if the
+// object is created in the public schema or in a temporary schema. The name
+// must have been resolved with ResolveTargetObject.
+func resolveTargetSchema(
+	ctx context.Context, txn *client.Txn, dbDesc *DatabaseDescriptor, tn *tree.TableName,
+) (*sqlbase.SchemaDescriptor, error) {
+	scName := tn.Schema()
+	if scName == tree.PublicSchema || scName == sessiondata.PgTempSchemaName || isTemporarySchemaName(scName) {
+		return nil, nil
+	}
+	sc, err := getSchemaDesc(ctx, txn, dbDesc.ID, scName)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, sqlbase.NewUndefinedSchemaError(scName)
+	}
+	return sc, nil
+}
+
+// checkPublicSchema returns an error if the given name is qualified with a
+// user-defined schema. Only tables, views and sequences can be created in
+// user-defined schemas at this point.
+func checkPublicSchema(tn *tree.TableName, kind string) error {
+	if tn.Schema() != tree.PublicSchema {
+		return pgerror.UnimplementedWithIssueErrorf(26443, "%ss in user-defined schemas", kind)
+	}
+	return nil
+}
+
+// resolveSchemaDesc looks up the user-defined schema with the given name in
+// the current database. If required is true, an error is returned if the
+// schema does not exist.
+func (p *planner) resolveSchemaDesc(
+	ctx context.Context, name string, required bool,
+) (*sqlbase.SchemaDescriptor, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, errNoDatabase
+	}
+	dbDesc, err := p.ResolveUncachedDatabaseByName(ctx, p.CurrentDatabase(), true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := getSchemaDesc(ctx, p.txn, dbDesc.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil && required {
+		return nil, sqlbase.NewUndefinedSchemaError(name)
+	}
+	return sc, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// User-defined types are stored as TypeDescriptors. Like user-defined
+// functions, they live in the namespace of their parent database and are not
+// leased: they are read in the current transaction every time they are
+// resolved.
+//
+// The columns of a user-defined type hold a copy of the members of the type
+// in their ColumnType, so that their values can be decoded without looking up
+// the type. When the type is altered, the copies are updated in all the
+// tables that reference it.
+
+// getTypeDesc looks up the type with the given name in the database with the
+// given ID. It returns nil if there is no such type, including when the name
+// refers to a table or a function.
+func getTypeDesc(
+	ctx context.Context, txn *client.Txn, parentID sqlbase.ID, name string,
+) (*sqlbase.TypeDescriptor, error) {
+	key := tableKey{parentID: parentID, name: name}.Key()
+	log.Eventf(ctx, "looking up type ID for name key %q", key)
+	gr, err := txn.Get(ctx, key)
+	if err != nil || !gr.Exists() {
+		return nil, err
+	}
+	desc := &sqlbase.Descriptor{}
+	if err := txn.GetProto(ctx, sqlbase.MakeDescMetadataKey(sqlbase.ID(gr.ValueInt())), desc); err != nil {
+		return nil, err
+	}
+	typ := desc.GetType()
+	if typ == nil {
+		return nil, nil
+	}
+	if err := typ.Validate(); err != nil {
+		return nil, err
+	}
+	return typ, nil
+}
+
+// typeResolver is a tree.TableNameExistingResolver which looks up
+// user-defined types instead of tables.
+type typeResolver struct {
+	p *planner
+}
+
+// LookupObject implements the tree.TableNameExistingResolver interface.
+func (r typeResolver) LookupObject(
+	ctx context.Context, _ bool, dbName, scName, obName string,
+) (found bool, objMeta tree.NameResolutionResult, err error) {
+	// At this point, types can only be created in the public schema.
+	if scName != tree.PublicSchema {
+		return false, nil, nil
+	}
+	p := r.p
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(
+		ctx, p.txn, dbName, p.CommonLookupFlags(false /* required */))
+	if err != nil || dbDesc == nil {
+		return false, nil, err
+	}
+	typ, err := getTypeDesc(ctx, p.txn, dbDesc.ID, obName)
+	if err != nil || typ == nil {
+		return false, nil, err
+	}
+	return true, typ, nil
+}
+
+// resolveTypeDesc looks up an existing user-defined type. If required is
+// true, an error is returned if the type does not exist.
+//
+// The type name is modified in-place with the result of the name
+// resolution, if successful.
+func (p *planner) resolveTypeDesc(
+	ctx context.Context, tn *tree.TableName, required bool,
+) (*sqlbase.TypeDescriptor, error) {
+	found, desc, err := tn.ResolveExisting(
+		ctx, typeResolver{p: p}, false /* requireMutable */, p.CurrentDatabase(), p.CurrentSearchPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if required {
+			return nil, tree.NewUndefinedTypeError(tn)
+		}
+		return nil, nil
+	}
+	return desc.(*sqlbase.TypeDescriptor), nil
+}
+
+// ResolveType implements the tree.TypeReferenceResolver interface.
+func (p *planner) ResolveType(name *tree.TableName) (types.T, error) {
+	tn := *name
+	desc, err := p.resolveTypeDesc(p.EvalContext().Context, &tn, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	return desc.EnumType(), nil
+}
+
+// getTablesReferencingType returns the IDs of the tables which have columns
+// of the given type, including columns that are being added or dropped.
+func getTablesReferencingType(
+	ctx context.Context, txn *client.Txn, typeID sqlbase.ID,
+) ([]sqlbase.ID, error) {
+	descs, err := GetAllDescriptors(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	var ids []sqlbase.ID
+	for _, desc := range descs {
+		table, ok := desc.(*sqlbase.TableDescriptor)
+		if !ok || table.Dropped() {
+			continue
+		}
+		references := false
+		for i := range table.Columns {
+			references = references || columnHasType(&table.Columns[i], typeID)
+		}
+		for i := range table.Mutations {
+			if col := table.Mutations[i].GetColumn(); col != nil {
+				references = references || columnHasType(col, typeID)
+			}
+		}
+		if references {
+			ids = append(ids, table.ID)
+		}
+	}
+	return ids, nil
+}
+
+// columnHasType returns true if the column is of the given user-defined
+// type.
+func columnHasType(col *sqlbase.ColumnDescriptor, typeID sqlbase.ID) bool {
+	return col.Type.SemanticType == sqlbase.ColumnType_ENUM && col.Type.EnumMetadata.TypeID == typeID
+}
@@ -309,6 +309,17 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 	case *max1RowNode:
 		n.plan = v.visit(n.plan)
 
+	case *recursiveCTENode:
+		if v.observer.attr != nil {
+			v.observer.attr(name, "label", n.label)
+		}
+		n.initial = v.visit(n.initial)
+
+	case *workTableScanNode:
+		if v.observer.attr != nil {
+			v.observer.attr(name, "label", n.label)
+		}
+
 	case *distinctNode:
 		if v.observer.attr == nil {
 			n.plan = v.visit(n.plan)
@@ -700,77 +711,90 @@ func colListStr(cols []int) string {
 // strings are constant and not precomputed so that the type names can
 // be changed without changing the output of "EXPLAIN".
 var planNodeNames = map[reflect.Type]string{
-	reflect.TypeOf(&alterIndexNode{}):           "alter index",
-	reflect.TypeOf(&alterSequenceNode{}):        "alter sequence",
-	reflect.TypeOf(&alterTableNode{}):           "alter table",
-	reflect.TypeOf(&alterUserSetPasswordNode{}): "alter user",
-	reflect.TypeOf(&commentOnColumnNode{}):      "comment on column",
-	reflect.TypeOf(&commentOnDatabaseNode{}):    "comment on database",
-	reflect.TypeOf(&commentOnTableNode{}):       "comment on table",
-	reflect.TypeOf(&cancelQueriesNode{}):        "cancel queries",
-	reflect.TypeOf(&cancelSessionsNode{}):       "cancel sessions",
-	reflect.TypeOf(&controlJobsNode{}):          "control jobs",
-	reflect.TypeOf(&createDatabaseNode{}):       "create database",
-	reflect.TypeOf(&createIndexNode{}):          "create index",
-	reflect.TypeOf(&createSequenceNode{}):       "create sequence",
-	reflect.TypeOf(&createStatsNode{}):          "create statistics",
-	reflect.TypeOf(&createTableNode{}):          "create table",
-	reflect.TypeOf(&CreateUserNode{}):           "create user/role",
-	reflect.TypeOf(&createViewNode{}):           "create view",
-	reflect.TypeOf(&delayedNode{}):              "virtual table",
-	reflect.TypeOf(&deleteNode{}):               "delete",
-	reflect.TypeOf(&deleteRangeNode{}):          "delete range",
-	reflect.TypeOf(&distinctNode{}):             "distinct",
-	reflect.TypeOf(&dropDatabaseNode{}):         "drop database",
-	reflect.TypeOf(&dropIndexNode{}):            "drop index",
-	reflect.TypeOf(&dropSequenceNode{}):         "drop sequence",
-	reflect.TypeOf(&dropTableNode{}):            "drop table",
-	reflect.TypeOf(&DropUserNode{}):             "drop user/role",
-	reflect.TypeOf(&dropViewNode{}):             "drop view",
-	reflect.TypeOf(&explainDistSQLNode{}):       "explain distsql",
-	reflect.TypeOf(&explainPlanNode{}):          "explain plan",
-	reflect.TypeOf(&filterNode{}):               "filter",
-	reflect.TypeOf(&groupNode{}):                "group",
-	reflect.TypeOf(&hookFnNode{}):               "plugin",
-	reflect.TypeOf(&indexJoinNode{}):            "index-join",
-	reflect.TypeOf(&insertNode{}):               "insert",
-	reflect.TypeOf(&joinNode{}):                 "join",
-	reflect.TypeOf(&limitNode{}):                "limit",
-	reflect.TypeOf(&lookupJoinNode{}):           "lookup-join",
-	reflect.TypeOf(&max1RowNode{}):              "max1row",
-	reflect.TypeOf(&ordinalityNode{}):           "ordinality",
-	reflect.TypeOf(&projectSetNode{}):           "project set",
-	reflect.TypeOf(&relocateNode{}):             "relocate",
-	reflect.TypeOf(&renameColumnNode{}):         "rename column",
-	reflect.TypeOf(&renameDatabaseNode{}):       "rename database",
-	reflect.TypeOf(&renameIndexNode{}):          "rename index",
-	reflect.TypeOf(&renameTableNode{}):          "rename table",
-	reflect.TypeOf(&renderNode{}):               "render",
-	reflect.TypeOf(&rowCountNode{}):             "count",
-	reflect.TypeOf(&rowSourceToPlanNode{}):      "row source to plan node",
-	reflect.TypeOf(&scanNode{}):                 "scan",
-	reflect.TypeOf(&scatterNode{}):              "scatter",
-	reflect.TypeOf(&scrubNode{}):                "scrub",
-	reflect.TypeOf(&sequenceSelectNode{}):       "sequence select",
-	reflect.TypeOf(&serializeNode{}):            "run",
-	reflect.TypeOf(&setClusterSettingNode{}):    "set cluster setting",
-	reflect.TypeOf(&setVarNode{}):               "set",
-	reflect.TypeOf(&setZoneConfigNode{}):        "configure zone",
-	reflect.TypeOf(&showFingerprintsNode{}):     "showFingerprints",
-	reflect.TypeOf(&showTraceNode{}):            "show trace for",
-	reflect.TypeOf(&showTraceReplicaNode{}):     "replica trace",
-	reflect.TypeOf(&showZoneConfigNode{}):       "show zone configuration",
-	reflect.TypeOf(&sortNode{}):                 "sort",
-	reflect.TypeOf(&splitNode{}):                "split",
-	reflect.TypeOf(&spoolNode{}):                "spool",
-	reflect.TypeOf(&truncateNode{}):             "truncate",
-	reflect.TypeOf(&unaryNode{}):                "emptyrow",
-	reflect.TypeOf(&unionNode{}):                "union",
-	reflect.TypeOf(&updateNode{}):               "update",
-	reflect.TypeOf(&upsertNode{}):               "upsert",
-	reflect.TypeOf(&valuesNode{}):               "values",
-	reflect.TypeOf(&virtualTableNode{}):         "virtual table values",
-	reflect.TypeOf(&windowNode{}):               "window",
-	reflect.TypeOf(&zeroNode{}):                 "norows",
-	reflect.TypeOf(&zigzagJoinNode{}):           "zigzag-join",
+	reflect.TypeOf(&alterIndexNode{}):              "alter index",
+	reflect.TypeOf(&alterSequenceNode{}):           "alter sequence",
+	reflect.TypeOf(&alterTypeNode{}):               "alter type",
+	reflect.TypeOf(&alterTableNode{}):              "alter table",
+	reflect.TypeOf(&alterUserSetPasswordNode{}):    "alter user",
+	reflect.TypeOf(&commentOnColumnNode{}):         "comment on column",
+	reflect.TypeOf(&commentOnDatabaseNode{}):       "comment on database",
+	reflect.TypeOf(&commentOnTableNode{}):          "comment on table",
+	reflect.TypeOf(&cancelQueriesNode{}):           "cancel queries",
+	reflect.TypeOf(&cancelSessionsNode{}):          "cancel sessions",
+	reflect.TypeOf(&controlJobsNode{}):             "control jobs",
+	reflect.TypeOf(&createDatabaseNode{}):          "create database",
+	reflect.TypeOf(&createFunctionNode{}):          "create function",
+	reflect.TypeOf(&createTypeNode{}):              "create type",
+	reflect.TypeOf(&createSchemaNode{}):            "create schema",
+	reflect.TypeOf(&createIndexNode{}):             "create index",
+	reflect.TypeOf(&createSequenceNode{}):          "create sequence",
+	reflect.TypeOf(&createStatsNode{}):             "create statistics",
+	reflect.TypeOf(&createTableNode{}):             "create table",
+	reflect.TypeOf(&createTriggerNode{}):           "create trigger",
+	reflect.TypeOf(&CreateUserNode{}):              "create user/role",
+	reflect.TypeOf(&createViewNode{}):              "create view",
+	reflect.TypeOf(&delayedNode{}):                 "virtual table",
+	reflect.TypeOf(&deleteNode{}):                  "delete",
+	reflect.TypeOf(&deleteRangeNode{}):             "delete range",
+	reflect.TypeOf(&distinctNode{}):                "distinct",
+	reflect.TypeOf(&dropDatabaseNode{}):            "drop database",
+	reflect.TypeOf(&dropFunctionNode{}):            "drop function",
+	reflect.TypeOf(&dropTypeNode{}):                "drop type",
+	reflect.TypeOf(&dropSchemaNode{}):              "drop schema",
+	reflect.TypeOf(&dropIndexNode{}):               "drop index",
+	reflect.TypeOf(&dropSequenceNode{}):            "drop sequence",
+	reflect.TypeOf(&dropTableNode{}):               "drop table",
+	reflect.TypeOf(&dropTriggerNode{}):             "drop trigger",
+	reflect.TypeOf(&DropUserNode{}):                "drop user/role",
+	reflect.TypeOf(&dropViewNode{}):                "drop view",
+	reflect.TypeOf(&explainDistSQLNode{}):          "explain distsql",
+	reflect.TypeOf(&explainPlanNode{}):             "explain plan",
+	reflect.TypeOf(&filterNode{}):                  "filter",
+	reflect.TypeOf(&groupNode{}):                   "group",
+	reflect.TypeOf(&hookFnNode{}):                  "plugin",
+	reflect.TypeOf(&indexJoinNode{}):               "index-join",
+	reflect.TypeOf(&insertNode{}):                  "insert",
+	reflect.TypeOf(&joinNode{}):                    "join",
+	reflect.TypeOf(&limitNode{}):                   "limit",
+	reflect.TypeOf(&lookupJoinNode{}):              "lookup-join",
+	reflect.TypeOf(&max1RowNode{}):                 "max1row",
+	reflect.TypeOf(&ordinalityNode{}):              "ordinality",
+	reflect.TypeOf(&projectSetNode{}):              "project set",
+	reflect.TypeOf(&recursiveCTENode{}):            "recursive cte",
+	reflect.TypeOf(&refreshMaterializedViewNode{}): "refresh materialized view",
+	reflect.TypeOf(&relocateNode{}):                "relocate",
+	reflect.TypeOf(&renameColumnNode{}):            "rename column",
+	reflect.TypeOf(&renameDatabaseNode{}):          "rename database",
+	reflect.TypeOf(&renameIndexNode{}):             "rename index",
+	reflect.TypeOf(&renameTableNode{}):             "rename table",
+	reflect.TypeOf(&renderNode{}):                  "render",
+	reflect.TypeOf(&rowCountNode{}):                "count",
+	reflect.TypeOf(&rowSourceToPlanNode{}):         "row source to plan node",
+	reflect.TypeOf(&scanNode{}):                    "scan",
+	reflect.TypeOf(&scatterNode{}):                 "scatter",
+	reflect.TypeOf(&scrubNode{}):                   "scrub",
+	reflect.TypeOf(&sequenceSelectNode{}):          "sequence select",
+	reflect.TypeOf(&serializeNode{}):               "run",
+	reflect.TypeOf(&setClusterSettingNode{}):       "set cluster setting",
+	reflect.TypeOf(&setConstraintsNode{}):          "set constraints",
+	reflect.TypeOf(&setVarNode{}):                  "set",
+	reflect.TypeOf(&setZoneConfigNode{}):           "configure zone",
+	reflect.TypeOf(&showFingerprintsNode{}):        "showFingerprints",
+	reflect.TypeOf(&showTraceNode{}):               "show trace for",
+	reflect.TypeOf(&showTraceReplicaNode{}):        "replica trace",
+	reflect.TypeOf(&showZoneConfigNode{}):          "show zone configuration",
+	reflect.TypeOf(&sortNode{}):                    "sort",
+	reflect.TypeOf(&splitNode{}):                   "split",
+	reflect.TypeOf(&spoolNode{}):                   "spool",
+	reflect.TypeOf(&truncateNode{}):                "truncate",
+	reflect.TypeOf(&unaryNode{}):                   "emptyrow",
+	reflect.TypeOf(&unionNode{}):                   "union",
+	reflect.TypeOf(&updateNode{}):                  "update",
+	reflect.TypeOf(&upsertNode{}):                  "upsert",
+	reflect.TypeOf(&valuesNode{}):                  "values",
+	reflect.TypeOf(&virtualTableNode{}):            "virtual table values",
+	reflect.TypeOf(&windowNode{}):                  "window",
+	reflect.TypeOf(&workTableScanNode{}):           "working table scan",
+	reflect.TypeOf(&zeroNode{}):                    "norows",
+	reflect.TypeOf(&zigzagJoinNode{}):              "zigzag-join",
 }
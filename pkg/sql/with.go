@@ -19,6 +19,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 )
 
@@ -114,7 +115,13 @@ func (p *planner) initWith(ctx context.Context, with *tree.With) (func(p *planne
 					"WITH query name %s specified more than once",
 					cte.Name.Alias)
 			}
-			ctePlan, err := p.newPlan(ctx, cte.Stmt, nil)
+			var ctePlan planNode
+			var err error
+			if with.Recursive {
+				ctePlan, err = p.planRecursiveCTE(ctx, cte)
+			} else {
+				ctePlan, err = p.newPlan(ctx, cte.Stmt, nil)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -167,3 +174,159 @@ func (p *planner) getCTEDataSource(tn *tree.TableName) (planDataSource, bool, er
 	}
 	return planDataSource{}, false, nil
 }
+
+// recursiveCTEUnion returns the UNION clause of the given CTE statement if
+// the statement has the form of a recursive query:
+//
+//   <initial query> UNION [ALL] <recursive query>
+//
+// Only the recursive query can refer to the CTE itself.
+func recursiveCTEUnion(stmt tree.Statement) (*tree.UnionClause, bool) {
+	sel, ok := stmt.(*tree.Select)
+	if !ok {
+		return nil, false
+	}
+	for {
+		if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+			return nil, false
+		}
+		paren, ok := sel.Select.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+	}
+	clause, ok := sel.Select.(*tree.UnionClause)
+	if !ok || clause.Type != tree.UnionOp {
+		return nil, false
+	}
+	return clause, true
+}
+
+// planRecursiveCTE plans a CTE defined in a WITH RECURSIVE clause. If the CTE
+// refers to itself, the result is a recursiveCTENode; otherwise, the CTE is
+// planned like a regular CTE.
+func (p *planner) planRecursiveCTE(ctx context.Context, cte *tree.CTE) (planNode, error) {
+	clause, ok := recursiveCTEUnion(cte.Stmt)
+	if !ok {
+		return p.newPlan(ctx, cte.Stmt, nil /* desiredTypes */)
+	}
+
+	initial, err := p.newPlan(ctx, clause.Left, nil /* desiredTypes */)
+	if err != nil {
+		return nil, err
+	}
+	initial, err = p.hideHiddenColumns(ctx, initial, planColumns(initial))
+	if err != nil {
+		initial.Close(ctx)
+		return nil, err
+	}
+	cols := planColumns(initial)
+	desiredTypes := make([]types.T, len(cols))
+	for i := range cols {
+		desiredTypes[i] = cols[i].Typ
+	}
+
+	// Plan the recursive query once with the CTE name bound to the working
+	// table, to find out whether the CTE is actually recursive and to validate
+	// the recursive query. The plans that are executed are created anew for
+	// each iteration by genIterationFn below.
+	name := cte.Name.Alias
+	frame := cteNameEnvironmentFrame{
+		name: cteSource{plan: &workTableScanNode{columns: cols, label: string(name)}, alias: cte.Name},
+	}
+	unusedCTEs := p.curPlan.cteNameEnvironment.unusedNames()
+	numSubqueries := len(p.curPlan.subqueryPlans)
+	p.curPlan.cteNameEnvironment = p.curPlan.cteNameEnvironment.push(frame)
+	recursive, err := p.newPlan(ctx, clause.Right, desiredTypes)
+	p.curPlan.cteNameEnvironment = p.curPlan.cteNameEnvironment.pop()
+	if err != nil {
+		initial.Close(ctx)
+		return nil, err
+	}
+	if !frame[name].used {
+		// The CTE does not refer to itself.
+		return p.newUnionNode(clause.Type, clause.All, initial, recursive)
+	}
+	recursive.Close(ctx)
+
+	if len(p.curPlan.subqueryPlans) != numSubqueries {
+		initial.Close(ctx)
+		return nil, pgerror.UnimplementedWithIssueErrorf(21085,
+			"subqueries are not supported in the recursive query of %q", tree.ErrString(&name))
+	}
+	if len(p.curPlan.cteNameEnvironment.unusedNames()) != len(unusedCTEs) {
+		initial.Close(ctx)
+		return nil, pgerror.UnimplementedWithIssueErrorf(21085,
+			"recursive query %q cannot refer to other common table expressions", tree.ErrString(&name))
+	}
+	if err := checkRecursiveCTETypes(name, cols, planColumns(recursive)); err != nil {
+		initial.Close(ctx)
+		return nil, err
+	}
+
+	genIterationFn := func(params runParams, workTable planNode) (planNode, error) {
+		p := params.p
+		defer func(env cteNameEnvironment) {
+			p.curPlan.cteNameEnvironment = env
+		}(p.curPlan.cteNameEnvironment)
+		p.curPlan.cteNameEnvironment = cteNameEnvironment{
+			{name: cteSource{plan: workTable, alias: cte.Name}},
+		}
+
+		plan, err := p.newPlan(params.ctx, clause.Right, desiredTypes)
+		if err != nil {
+			return nil, err
+		}
+		plan, err = p.hideHiddenColumns(params.ctx, plan, planColumns(plan))
+		if err != nil {
+			plan.Close(params.ctx)
+			return nil, err
+		}
+		plan, err = p.optimizePlan(params.ctx, plan, allColumns(plan))
+		if err != nil {
+			plan.Close(params.ctx)
+			return nil, err
+		}
+		return plan, nil
+	}
+
+	return &recursiveCTENode{
+		initial:        initial,
+		genIterationFn: genIterationFn,
+		label:          string(name),
+		deduplicate:    !clause.All,
+	}, nil
+}
+
+// unusedNames returns the names of the CTEs in the environment that have not
+// been used yet.
+func (e cteNameEnvironment) unusedNames() []tree.Name {
+	var names []tree.Name
+	for _, frame := range e {
+		for name, src := range frame {
+			if !src.used {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// checkRecursiveCTETypes ensures that the columns produced by the recursive
+// query of a recursive CTE match the columns of its initial query.
+func checkRecursiveCTETypes(name tree.Name, initial, recursive sqlbase.ResultColumns) error {
+	if len(initial) != len(recursive) {
+		return pgerror.NewErrorf(pgerror.CodeSyntaxError,
+			"each UNION query must have the same number of columns: %d vs %d",
+			len(initial), len(recursive))
+	}
+	for i := range initial {
+		if !initial[i].Typ.Equivalent(recursive[i].Typ) && recursive[i].Typ != types.Unknown {
+			return pgerror.NewErrorf(pgerror.CodeDatatypeMismatchError,
+				"recursive query %q column %d has type %s in non-recursive term but type %s overall",
+				tree.ErrString(&name), i+1, initial[i].Typ, recursive[i].Typ)
+		}
+	}
+	return nil
+}
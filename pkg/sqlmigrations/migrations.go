@@ -202,6 +202,13 @@ var backwardCompatibleMigrations = []migrationDescriptor{
 		includedInBootstrap: true,
 		newDescriptorIDs:    staticIDs(keys.CommentsTableID),
 	},
+	{
+		// Introduced in v2.2.
+		name:                "create system.protected_ts_records table",
+		workFn:              createProtectedTimestampsRecordsTable,
+		includedInBootstrap: true,
+		newDescriptorIDs:    staticIDs(keys.ProtectedTimestampsRecordsTableID),
+	},
 }
 
 func staticIDs(ids ...sqlbase.ID) func(ctx context.Context, db db) ([]sqlbase.ID, error) {
@@ -543,6 +550,10 @@ func createCommentTable(ctx context.Context, r runner) error {
 	return createSystemTable(ctx, r, sqlbase.CommentsTable)
 }
 
+func createProtectedTimestampsRecordsTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, sqlbase.ProtectedTimestampsRecordsTable)
+}
+
 var reportingOptOut = envutil.EnvOrDefaultBool("COCKROACH_SKIP_ENABLING_DIAGNOSTIC_REPORTING", false)
 
 func runStmtAsRootWithRetry(
@@ -29,8 +29,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 const (
@@ -51,12 +53,16 @@ const (
 	minReplicaWeight = 0.001
 
 	// priorities for various repair operations.
-	addDeadReplacementPriority            float64 = 12000
-	addMissingReplicaPriority             float64 = 10000
-	addDecommissioningReplacementPriority float64 = 5000
-	removeDeadReplicaPriority             float64 = 1000
-	removeDecommissioningReplicaPriority  float64 = 200
-	removeExtraReplicaPriority            float64 = 100
+	finalizeAtomicReplicationChangePriority float64 = 12002
+	removeLearnerReplicaPriority            float64 = 12001
+	addDeadReplacementPriority              float64 = 12000
+	addMissingReplicaPriority               float64 = 10000
+	addDecommissioningReplacementPriority   float64 = 5000
+	removeDeadReplicaPriority               float64 = 1000
+	removeDecommissioningReplicaPriority    float64 = 200
+	removeExtraReplicaPriority              float64 = 100
+	addNonVoterPriority                     float64 = 60
+	removeNonVoterPriority                  float64 = 50
 )
 
 // MinLeaseTransferStatsDuration configures the minimum amount of time a
@@ -103,15 +109,23 @@ const (
 	AllocatorRemoveDead
 	AllocatorRemoveDecommissioning
 	AllocatorConsiderRebalance
+	AllocatorRemoveLearner
+	AllocatorFinalizeAtomicReplicationChange
+	AllocatorAddNonVoter
+	AllocatorRemoveNonVoter
 )
 
 var allocatorActionNames = map[AllocatorAction]string{
-	AllocatorNoop:                  "noop",
-	AllocatorRemove:                "remove",
-	AllocatorAdd:                   "add",
-	AllocatorRemoveDead:            "remove dead",
-	AllocatorRemoveDecommissioning: "remove decommissioning",
-	AllocatorConsiderRebalance:     "consider rebalance",
+	AllocatorNoop:                            "noop",
+	AllocatorRemove:                          "remove",
+	AllocatorAdd:                             "add",
+	AllocatorRemoveDead:                      "remove dead",
+	AllocatorRemoveDecommissioning:           "remove decommissioning",
+	AllocatorConsiderRebalance:               "consider rebalance",
+	AllocatorRemoveLearner:                   "remove learner",
+	AllocatorFinalizeAtomicReplicationChange: "finalize conf change",
+	AllocatorAddNonVoter:                     "add non-voter",
+	AllocatorRemoveNonVoter:                  "remove non-voter",
 }
 
 func (a AllocatorAction) String() string {
@@ -288,6 +302,72 @@ func GetNeededReplicas(zoneConfigReplicaCount int32, clusterNodes int) int {
 	return need
 }
 
+// GetNeededNonVoters calculates the number of non-voting replicas a range
+// should have given its zone config, the number of voters it needs and the
+// number of nodes available for up-replication. Non-voters can't share a node
+// with another replica of the range, and voters take precedence.
+func GetNeededNonVoters(numVoters, zoneConfigNonVoterCount, clusterNodes int) int {
+	need := zoneConfigNonVoterCount
+	if clusterNodes-numVoters < need {
+		need = clusterNodes - numVoters
+	}
+	if need < 0 {
+		need = 0
+	}
+	return need
+}
+
+// voterZone returns the zone config that the voting replicas of a range are
+// allocated against. It only differs from zone if num_voters is set, in which
+// case it asks for num_voters replicas satisfying the voter constraints.
+func voterZone(zone *config.ZoneConfig) *config.ZoneConfig {
+	if zone.NumVoters == nil {
+		return zone
+	}
+	vz := *zone
+	vz.NumReplicas = proto.Int32(zone.GetNumVoters())
+	vz.Constraints = zone.GetVoterConstraints()
+	vz.NumVoters = nil
+	vz.VoterConstraints = nil
+	return &vz
+}
+
+// withoutNonVoters returns rangeInfo with the non-voting replicas removed from
+// its range descriptor, along with the removed replicas. Decisions about
+// voters are made on the result, so that non-voters neither satisfy voter
+// constraints nor count towards the locality diversity of the voters.
+func withoutNonVoters(rangeInfo RangeInfo) (RangeInfo, []roachpb.ReplicaDescriptor) {
+	nonVoters := rangeInfo.Desc.NonVoters()
+	if len(nonVoters) == 0 {
+		return rangeInfo, nil
+	}
+	desc := *rangeInfo.Desc
+	desc.Replicas = nil
+	for _, repl := range rangeInfo.Desc.Replicas {
+		if repl.GetType() != roachpb.ReplicaType_NON_VOTER {
+			desc.Replicas = append(desc.Replicas, repl)
+		}
+	}
+	rangeInfo.Desc = &desc
+	return rangeInfo, nonVoters
+}
+
+// excludeNodesOf returns the store list without the stores on the nodes of
+// the given replicas. A node can only hold a single replica of a range, so
+// these stores are not valid targets for a new replica.
+func excludeNodesOf(sl StoreList, replicas []roachpb.ReplicaDescriptor) StoreList {
+	if len(replicas) == 0 {
+		return sl
+	}
+	var stores []roachpb.StoreDescriptor
+	for _, store := range sl.stores {
+		if !nodeHasReplica(store.Node.NodeID, replicas) {
+			stores = append(stores, store)
+		}
+	}
+	return makeStoreList(stores)
+}
+
 // ComputeAction determines the exact operation needed to repair the
 // supplied range, as governed by the supplied zone configuration. It
 // returns the required action that should be taken and a priority.
@@ -300,10 +380,34 @@ func (a *Allocator) ComputeAction(
 	}
 	// TODO(mrtracy): Handle non-homogeneous and mismatched attribute sets.
 
-	have := len(rangeInfo.Desc.Replicas)
-	decommissioningReplicas := a.storePool.decommissioningReplicas(rangeInfo.Desc.RangeID, rangeInfo.Desc.Replicas)
+	// A range in a joint configuration is in the middle of an atomic
+	// replication change (see Replica.changeReplicasAtomic). Finding one here
+	// means that the change was abandoned midway, and it is completed before
+	// anything else is done. Demoting voters still count towards the quorum
+	// of the outgoing configuration, so this has to come before the learner
+	// check below.
+	if rangeInfo.Desc.InAtomicReplicationChange() {
+		priority := finalizeAtomicReplicationChangePriority
+		log.VEventf(ctx, 3, "AllocatorFinalizeAtomicReplicationChange - priority=%.2f", priority)
+		return AllocatorFinalizeAtomicReplicationChange, priority
+	}
+
+	// Learners are only expected to exist while a replica is being added (see
+	// Replica.addReplicaViaLearner). A learner found here was most likely left
+	// behind by an addition that failed midway, and it is removed before any
+	// other action is considered. Learners don't vote, so they are excluded
+	// from the replica counts below.
+	if learners := rangeInfo.Desc.Learners(); len(learners) > 0 {
+		priority := removeLearnerReplicaPriority
+		log.VEventf(ctx, 3, "AllocatorRemoveLearner - learners=%v, priority=%.2f", learners, priority)
+		return AllocatorRemoveLearner, priority
+	}
+
+	voterReplicas := rangeInfo.Desc.Voters()
+	have := len(voterReplicas)
+	decommissioningReplicas := a.storePool.decommissioningReplicas(rangeInfo.Desc.RangeID, voterReplicas)
 	clusterNodes := a.storePool.ClusterNodeCount()
-	need := GetNeededReplicas(*zone.NumReplicas, clusterNodes)
+	need := GetNeededReplicas(zone.GetNumVoters(), clusterNodes)
 	desiredQuorum := computeQuorum(need)
 	quorum := computeQuorum(have)
 
@@ -326,7 +430,7 @@ func (a *Allocator) ComputeAction(
 		return AllocatorAdd, priority
 	}
 
-	liveReplicas, deadReplicas := a.storePool.liveAndDeadReplicas(rangeInfo.Desc.RangeID, rangeInfo.Desc.Replicas)
+	liveReplicas, deadReplicas := a.storePool.liveAndDeadReplicas(rangeInfo.Desc.RangeID, voterReplicas)
 	if len(liveReplicas) < quorum {
 		// Do not take any removal action if we do not have a quorum of live
 		// replicas.
@@ -380,6 +484,29 @@ func (a *Allocator) ComputeAction(
 		return AllocatorRemove, priority
 	}
 
+	// The voters are in order. Non-voting replicas don't affect the
+	// availability of the range, so they are only taken care of now.
+	nonVoterReplicas := rangeInfo.Desc.NonVoters()
+	haveNonVoters := len(nonVoterReplicas)
+	needNonVoters := GetNeededNonVoters(need, int(zone.GetNumNonVoters()), clusterNodes)
+	_, deadNonVoters := a.storePool.liveAndDeadReplicas(rangeInfo.Desc.RangeID, nonVoterReplicas)
+	decommissioningNonVoters := a.storePool.decommissioningReplicas(rangeInfo.Desc.RangeID, nonVoterReplicas)
+	replaceNonVoters := len(deadNonVoters) + len(decommissioningNonVoters)
+	if haveNonVoters < needNonVoters || (haveNonVoters == needNonVoters && replaceNonVoters > 0) {
+		// Dead and decommissioning non-voters are replaced before they are
+		// removed, like voters.
+		priority := addNonVoterPriority
+		log.VEventf(ctx, 3, "AllocatorAddNonVoter - need=%d, have=%d, replace=%d, priority=%.2f",
+			needNonVoters, haveNonVoters, replaceNonVoters, priority)
+		return AllocatorAddNonVoter, priority
+	}
+	if haveNonVoters > needNonVoters || replaceNonVoters > 0 {
+		priority := removeNonVoterPriority
+		log.VEventf(ctx, 3, "AllocatorRemoveNonVoter - need=%d, have=%d, replace=%d, priority=%.2f",
+			needNonVoters, haveNonVoters, replaceNonVoters, priority)
+		return AllocatorRemoveNonVoter, priority
+	}
+
 	// Nothing needs to be done, but we may want to rebalance.
 	return AllocatorConsiderRebalance, 0
 }
@@ -389,7 +516,7 @@ type decisionDetails struct {
 	Existing string `json:",omitempty"`
 }
 
-// AllocateTarget returns a suitable store for a new allocation with the
+// AllocateTarget returns a suitable store for a new voting replica with the
 // required attributes. Nodes already accommodating existing replicas are ruled
 // out as targets. The range ID of the replica being allocated for is also
 // passed in to ensure that we don't try to replace an existing dead replica on
@@ -399,8 +526,31 @@ func (a *Allocator) AllocateTarget(
 	zone *config.ZoneConfig,
 	existing []roachpb.ReplicaDescriptor,
 	rangeInfo RangeInfo,
+) (*roachpb.StoreDescriptor, string, error) {
+	rangeInfo, nonVoters := withoutNonVoters(rangeInfo)
+	return a.allocateTarget(ctx, voterZone(zone), existing, nonVoters, rangeInfo)
+}
+
+// AllocateNonVoterTarget returns a suitable store for a new non-voting
+// replica. Non-voters are allocated against the zone's constraints, which
+// apply to all the replicas of the range.
+func (a *Allocator) AllocateNonVoterTarget(
+	ctx context.Context, zone *config.ZoneConfig, rangeInfo RangeInfo,
+) (*roachpb.StoreDescriptor, string, error) {
+	return a.allocateTarget(ctx, zone, rangeInfo.Desc.Replicas, nil /* excluded */, rangeInfo)
+}
+
+// allocateTarget returns a suitable store for a new replica, given the
+// existing replicas that are subject to the same constraints as the new one.
+// Nodes holding either an existing or an excluded replica are ruled out.
+func (a *Allocator) allocateTarget(
+	ctx context.Context,
+	zone *config.ZoneConfig,
+	existing, excluded []roachpb.ReplicaDescriptor,
+	rangeInfo RangeInfo,
 ) (*roachpb.StoreDescriptor, string, error) {
 	sl, aliveStoreCount, throttledStoreCount := a.storePool.getStoreList(rangeInfo.Desc.RangeID, storeFilterThrottled)
+	sl = excludeNodesOf(sl, excluded)
 
 	target, details := a.allocateTargetFromList(
 		ctx, sl, zone, existing, rangeInfo, a.scorerOptions())
@@ -469,16 +619,37 @@ func (a Allocator) simulateRemoveTarget(
 	return a.RemoveTarget(ctx, zone, candidates, rangeInfo)
 }
 
-// RemoveTarget returns a suitable replica to remove from the provided replica
-// set. It first attempts to randomly select a target from the set of stores
-// that have greater than the average number of replicas. Failing that, it
-// falls back to selecting a random target from any of the existing
+// RemoveTarget returns a suitable voting replica to remove from the provided
+// replica set. It first attempts to randomly select a target from the set of
+// stores that have greater than the average number of replicas. Failing that,
+// it falls back to selecting a random target from any of the existing
 // replicas.
 func (a Allocator) RemoveTarget(
 	ctx context.Context,
 	zone *config.ZoneConfig,
 	candidates []roachpb.ReplicaDescriptor,
 	rangeInfo RangeInfo,
+) (roachpb.ReplicaDescriptor, string, error) {
+	rangeInfo, _ = withoutNonVoters(rangeInfo)
+	return a.removeTarget(ctx, voterZone(zone), candidates, rangeInfo)
+}
+
+// RemoveNonVoterTarget returns a suitable non-voting replica to remove from
+// the provided replica set, which should only contain non-voters.
+func (a Allocator) RemoveNonVoterTarget(
+	ctx context.Context,
+	zone *config.ZoneConfig,
+	candidates []roachpb.ReplicaDescriptor,
+	rangeInfo RangeInfo,
+) (roachpb.ReplicaDescriptor, string, error) {
+	return a.removeTarget(ctx, zone, candidates, rangeInfo)
+}
+
+func (a Allocator) removeTarget(
+	ctx context.Context,
+	zone *config.ZoneConfig,
+	candidates []roachpb.ReplicaDescriptor,
+	rangeInfo RangeInfo,
 ) (roachpb.ReplicaDescriptor, string, error) {
 	if len(candidates) == 0 {
 		return roachpb.ReplicaDescriptor{}, "", errors.Errorf("must supply at least one candidate replica to allocator.RemoveTarget()")
@@ -529,8 +700,9 @@ func (a Allocator) RemoveTarget(
 // information about the range being considered for rebalancing.
 //
 // The existing replicas modulo any store with dead replicas are candidates for
-// rebalancing. Note that rebalancing is accomplished by first adding a new
-// replica to the range, then removing the most undesirable replica.
+// rebalancing. Besides the target, the replica to remove once the target has
+// been added is returned, so that the caller can swap one for the other in a
+// single atomic replication change. Only voting replicas are rebalanced.
 //
 // Simply ignoring a rebalance opportunity in the event that the target chosen
 // by AllocateTarget() doesn't fit balancing criteria is perfectly fine, as
@@ -543,8 +715,11 @@ func (a Allocator) RebalanceTarget(
 	raftStatus *raft.Status,
 	rangeInfo RangeInfo,
 	filter storeFilter,
-) (*roachpb.StoreDescriptor, string) {
+) (add *roachpb.StoreDescriptor, remove roachpb.ReplicaDescriptor, details string) {
+	rangeInfo, nonVoters := withoutNonVoters(rangeInfo)
+	zone = voterZone(zone)
 	sl, _, _ := a.storePool.getStoreList(rangeInfo.Desc.RangeID, filter)
+	sl = excludeNodesOf(sl, nonVoters)
 
 	// We're going to add another replica to the range which will change the
 	// quorum size. Verify that the number of existing live replicas is sufficient
@@ -570,7 +745,7 @@ func (a Allocator) RebalanceTarget(
 		if numLiveReplicas < newQuorum {
 			// Don't rebalance as we won't be able to make quorum after the rebalance
 			// until the new replica has been caught up.
-			return nil, ""
+			return nil, roachpb.ReplicaDescriptor{}, ""
 		}
 	}
 
@@ -588,7 +763,7 @@ func (a Allocator) RebalanceTarget(
 	)
 
 	if len(results) == 0 {
-		return nil, ""
+		return nil, roachpb.ReplicaDescriptor{}, ""
 	}
 
 	// Deep-copy the Replicas slice since we'll mutate it in the loop below.
@@ -600,10 +775,11 @@ func (a Allocator) RebalanceTarget(
 	// If we would, we don't want to actually rebalance to that target.
 	var target *candidate
 	var existingCandidates candidateList
+	var removeReplica roachpb.ReplicaDescriptor
 	for {
 		target, existingCandidates = bestRebalanceTarget(a.randGen, results)
 		if target == nil {
-			return nil, ""
+			return nil, roachpb.ReplicaDescriptor{}, ""
 		}
 
 		// Add a fake new replica to our copy of the range descriptor so that we can
@@ -627,7 +803,9 @@ func (a Allocator) RebalanceTarget(
 				raftStatus, desc.Replicas, newReplica.ReplicaID)
 		}
 
-		removeReplica, removeDetails, err := a.simulateRemoveTarget(
+		var removeDetails string
+		var err error
+		removeReplica, removeDetails, err = a.simulateRemoveTarget(
 			ctx,
 			target.store.StoreID,
 			zone,
@@ -635,7 +813,7 @@ func (a Allocator) RebalanceTarget(
 			rangeInfo)
 		if err != nil {
 			log.Warningf(ctx, "simulating RemoveTarget failed: %s", err)
-			return nil, ""
+			return nil, roachpb.ReplicaDescriptor{}, ""
 		}
 		if target.store.StoreID != removeReplica.StoreID {
 			break
@@ -648,16 +826,16 @@ func (a Allocator) RebalanceTarget(
 
 	// Compile the details entry that will be persisted into system.rangelog for
 	// debugging/auditability purposes.
-	details := decisionDetails{
+	dd := decisionDetails{
 		Target:   target.compactString(options),
 		Existing: existingCandidates.compactString(options),
 	}
-	detailsBytes, err := json.Marshal(details)
+	detailsBytes, err := json.Marshal(dd)
 	if err != nil {
 		log.Warningf(ctx, "failed to marshal details for choosing rebalance target: %s", err)
 	}
 
-	return &target.store, string(detailsBytes)
+	return &target.store, removeReplica, string(detailsBytes)
 }
 
 func (a *Allocator) scorerOptions() scorerOptions {
@@ -1187,7 +1365,7 @@ func replicaIsBehind(raftStatus *raft.Status, replicaID roachpb.ReplicaID) bool
 	// behind the actual commit index of the range.
 	if progress, ok := raftStatus.Progress[uint64(replicaID)]; ok {
 		if uint64(replicaID) == raftStatus.Lead ||
-			(progress.State == raft.ProgressStateReplicate &&
+			(progress.State == tracker.StateReplicate &&
 				progress.Match >= raftStatus.Commit) {
 			return false
 		}
@@ -1205,8 +1383,8 @@ func simulateFilterUnremovableReplicas(
 	brandNewReplicaID roachpb.ReplicaID,
 ) []roachpb.ReplicaDescriptor {
 	status := *raftStatus
-	status.Progress[uint64(brandNewReplicaID)] = raft.Progress{
-		State: raft.ProgressStateReplicate,
+	status.Progress[uint64(brandNewReplicaID)] = tracker.Progress{
+		State: tracker.StateReplicate,
 		Match: status.Commit,
 	}
 	return filterUnremovableReplicas(&status, replicas, brandNewReplicaID)
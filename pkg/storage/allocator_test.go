@@ -48,6 +48,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 const firstRange = roachpb.RangeID(1)
@@ -658,7 +659,7 @@ func TestAllocatorMultipleStoresPerNode(t *testing.T) {
 				tc.existing, result, err, tc.expectTarget)
 		}
 
-		result, details := a.RebalanceTarget(
+		result, _, details := a.RebalanceTarget(
 			context.Background(),
 			config.EmptyCompleteZoneConfig(),
 			nil, /* raftStatus */
@@ -728,7 +729,7 @@ func TestAllocatorRebalance(t *testing.T) {
 
 	// Every rebalance target must be either store 1 or 2.
 	for i := 0; i < 10; i++ {
-		result, _ := a.RebalanceTarget(
+		result, _, _ := a.RebalanceTarget(
 			ctx,
 			config.EmptyCompleteZoneConfig(),
 			nil,
@@ -872,15 +873,15 @@ func TestAllocatorRebalanceTarget(t *testing.T) {
 	rangeInfo := rangeInfoForRepl(repl, desc)
 
 	status := &raft.Status{
-		Progress: make(map[uint64]raft.Progress),
+		Progress: make(map[uint64]tracker.Progress),
 	}
 	for _, replica := range replicas {
-		status.Progress[uint64(replica.NodeID)] = raft.Progress{
+		status.Progress[uint64(replica.NodeID)] = tracker.Progress{
 			Match: 10,
 		}
 	}
 	for i := 0; i < 10; i++ {
-		result, details := a.RebalanceTarget(
+		result, _, details := a.RebalanceTarget(
 			context.Background(),
 			config.EmptyCompleteZoneConfig(),
 			status,
@@ -899,7 +900,7 @@ func TestAllocatorRebalanceTarget(t *testing.T) {
 	stores[2].Capacity.RangeCount = 46
 	sg.GossipStores(stores, t)
 	for i := 0; i < 10; i++ {
-		result, details := a.RebalanceTarget(
+		result, _, details := a.RebalanceTarget(
 			context.Background(),
 			config.EmptyCompleteZoneConfig(),
 			status,
@@ -915,7 +916,7 @@ func TestAllocatorRebalanceTarget(t *testing.T) {
 	stores[1].Capacity.RangeCount = 44
 	sg.GossipStores(stores, t)
 	for i := 0; i < 10; i++ {
-		result, details := a.RebalanceTarget(
+		result, _, details := a.RebalanceTarget(
 			context.Background(),
 			config.EmptyCompleteZoneConfig(),
 			status,
@@ -991,7 +992,7 @@ func TestAllocatorRebalanceDeadNodes(t *testing.T) {
 
 	for _, c := range testCases {
 		t.Run("", func(t *testing.T) {
-			result, _ := a.RebalanceTarget(
+			result, _, _ := a.RebalanceTarget(
 				ctx,
 				config.EmptyCompleteZoneConfig(),
 				nil,
@@ -1183,7 +1184,7 @@ func TestAllocatorRebalanceByCount(t *testing.T) {
 
 	// Every rebalance target must be store 4 (or nil for case of missing the only option).
 	for i := 0; i < 10; i++ {
-		result, _ := a.RebalanceTarget(
+		result, _, _ := a.RebalanceTarget(
 			ctx,
 			config.EmptyCompleteZoneConfig(),
 			nil,
@@ -1464,7 +1465,7 @@ func TestAllocatorRebalanceDifferentLocalitySizes(t *testing.T) {
 	}
 
 	for i, tc := range testCases {
-		result, details := a.RebalanceTarget(
+		result, _, details := a.RebalanceTarget(
 			ctx,
 			config.EmptyCompleteZoneConfig(),
 			nil, /* raftStatus */
@@ -1532,7 +1533,7 @@ func TestAllocatorRebalanceDifferentLocalitySizes(t *testing.T) {
 
 	for i, tc := range testCases2 {
 		log.Infof(ctx, "case #%d", i)
-		result, details := a.RebalanceTarget(
+		result, _, details := a.RebalanceTarget(
 			ctx,
 			config.EmptyCompleteZoneConfig(),
 			nil, /* raftStatus */
@@ -2237,7 +2238,7 @@ func TestAllocatorRebalanceTargetLocality(t *testing.T) {
 				StoreID: storeID,
 			}
 		}
-		targetStore, details := a.RebalanceTarget(
+		targetStore, _, details := a.RebalanceTarget(
 			context.Background(),
 			config.EmptyCompleteZoneConfig(),
 			nil,
@@ -3637,7 +3638,7 @@ func TestRebalanceCandidatesNumReplicasConstraints(t *testing.T) {
 		} else {
 			// Also verify that RebalanceTarget picks out one of the best options as
 			// the final rebalance choice.
-			target, details := a.RebalanceTarget(
+			target, _, details := a.RebalanceTarget(
 				context.Background(), zone, nil, rangeInfo, storeFilterThrottled)
 			var found bool
 			if target == nil && len(tc.validTargets) == 0 {
@@ -4602,6 +4603,199 @@ func TestAllocatorComputeActionRemoveDead(t *testing.T) {
 	}
 }
 
+// TestAllocatorComputeActionRemoveLearner verifies that a learner left behind
+// by an abandoned replica addition is removed before any other action is
+// taken, and that learners don't count towards the replication factor.
+func TestAllocatorComputeActionRemoveLearner(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	zone := config.ZoneConfig{
+		NumReplicas: proto.Int32(3),
+	}
+	desc := roachpb.RangeDescriptor{
+		Replicas: []roachpb.ReplicaDescriptor{
+			{StoreID: 1, NodeID: 1, ReplicaID: 1},
+			{StoreID: 2, NodeID: 2, ReplicaID: 2},
+			{StoreID: 3, NodeID: 3, ReplicaID: 3, Type: roachpb.ReplicaType_LEARNER.Enum()},
+		},
+	}
+
+	stopper, _, sp, a, _ := createTestAllocator(10, false /* deterministic */)
+	ctx := context.Background()
+	defer stopper.Stop(ctx)
+
+	live, dead := []roachpb.StoreID{1, 2}, []roachpb.StoreID{3}
+	mockStorePool(sp, live, nil, dead, nil, nil, nil)
+	action, priority := a.ComputeAction(ctx, &zone, RangeInfo{Desc: &desc})
+	if action != AllocatorRemoveLearner {
+		t.Fatalf("expected %s, got %s", AllocatorRemoveLearner, action)
+	}
+	if priority != removeLearnerReplicaPriority {
+		t.Fatalf("expected priority %.2f, got %.2f", removeLearnerReplicaPriority, priority)
+	}
+
+	// Once the learner is gone, the range is missing a voter.
+	desc.Replicas = desc.Replicas[:2]
+	if action, _ := a.ComputeAction(ctx, &zone, RangeInfo{Desc: &desc}); action != AllocatorAdd {
+		t.Fatalf("expected %s, got %s", AllocatorAdd, action)
+	}
+}
+
+func TestAllocatorComputeActionNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	zone := config.ZoneConfig{
+		NumReplicas: proto.Int32(5),
+		NumVoters:   proto.Int32(3),
+	}
+	nonVoter := roachpb.ReplicaType_NON_VOTER.Enum()
+	testCases := []struct {
+		desc           roachpb.RangeDescriptor
+		live, dead     []roachpb.StoreID
+		expectedAction AllocatorAction
+	}{
+		// Voters are replenished before any non-voters are added.
+		{
+			desc: roachpb.RangeDescriptor{
+				Replicas: []roachpb.ReplicaDescriptor{
+					{StoreID: 1, NodeID: 1, ReplicaID: 1},
+					{StoreID: 2, NodeID: 2, ReplicaID: 2},
+				},
+			},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorAdd,
+		},
+		{
+			desc: roachpb.RangeDescriptor{
+				Replicas: []roachpb.ReplicaDescriptor{
+					{StoreID: 1, NodeID: 1, ReplicaID: 1},
+					{StoreID: 2, NodeID: 2, ReplicaID: 2},
+					{StoreID: 3, NodeID: 3, ReplicaID: 3},
+					{StoreID: 4, NodeID: 4, ReplicaID: 4, Type: nonVoter},
+				},
+			},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorAddNonVoter,
+		},
+		{
+			desc: roachpb.RangeDescriptor{
+				Replicas: []roachpb.ReplicaDescriptor{
+					{StoreID: 1, NodeID: 1, ReplicaID: 1},
+					{StoreID: 2, NodeID: 2, ReplicaID: 2},
+					{StoreID: 3, NodeID: 3, ReplicaID: 3},
+					{StoreID: 4, NodeID: 4, ReplicaID: 4, Type: nonVoter},
+					{StoreID: 5, NodeID: 5, ReplicaID: 5, Type: nonVoter},
+				},
+			},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorConsiderRebalance,
+		},
+		// A dead non-voter is replaced if there is a spare node.
+		{
+			desc: roachpb.RangeDescriptor{
+				Replicas: []roachpb.ReplicaDescriptor{
+					{StoreID: 1, NodeID: 1, ReplicaID: 1},
+					{StoreID: 2, NodeID: 2, ReplicaID: 2},
+					{StoreID: 3, NodeID: 3, ReplicaID: 3},
+					{StoreID: 4, NodeID: 4, ReplicaID: 4, Type: nonVoter},
+					{StoreID: 5, NodeID: 5, ReplicaID: 5, Type: nonVoter},
+				},
+			},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 6},
+			dead:           []roachpb.StoreID{5},
+			expectedAction: AllocatorAddNonVoter,
+		},
+		// Otherwise it is removed.
+		{
+			desc: roachpb.RangeDescriptor{
+				Replicas: []roachpb.ReplicaDescriptor{
+					{StoreID: 1, NodeID: 1, ReplicaID: 1},
+					{StoreID: 2, NodeID: 2, ReplicaID: 2},
+					{StoreID: 3, NodeID: 3, ReplicaID: 3},
+					{StoreID: 4, NodeID: 4, ReplicaID: 4, Type: nonVoter},
+					{StoreID: 5, NodeID: 5, ReplicaID: 5, Type: nonVoter},
+				},
+			},
+			live:           []roachpb.StoreID{1, 2, 3, 4},
+			dead:           []roachpb.StoreID{5},
+			expectedAction: AllocatorRemoveNonVoter,
+		},
+		// Non-voters in excess of the zone config are removed.
+		{
+			desc: roachpb.RangeDescriptor{
+				Replicas: []roachpb.ReplicaDescriptor{
+					{StoreID: 1, NodeID: 1, ReplicaID: 1},
+					{StoreID: 2, NodeID: 2, ReplicaID: 2},
+					{StoreID: 3, NodeID: 3, ReplicaID: 3},
+					{StoreID: 4, NodeID: 4, ReplicaID: 4, Type: nonVoter},
+					{StoreID: 5, NodeID: 5, ReplicaID: 5, Type: nonVoter},
+					{StoreID: 6, NodeID: 6, ReplicaID: 6, Type: nonVoter},
+				},
+			},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5, 6},
+			expectedAction: AllocatorRemoveNonVoter,
+		},
+	}
+
+	var numNodes int
+	stopper, _, _, sp, _ := createTestStorePool(
+		TestTimeUntilStoreDeadOff, false, /* deterministic */
+		func() int { return numNodes },
+		storagepb.NodeLivenessStatus_LIVE)
+	a := MakeAllocator(sp, func(string) (time.Duration, bool) {
+		return 0, true
+	})
+
+	ctx := context.Background()
+	defer stopper.Stop(ctx)
+
+	for i, tc := range testCases {
+		numNodes = len(tc.live)
+		mockStorePool(sp, tc.live, nil, tc.dead, nil, nil, nil)
+		action, _ := a.ComputeAction(ctx, &zone, RangeInfo{Desc: &tc.desc})
+		if tc.expectedAction != action {
+			t.Errorf("%d: expected %s, got %s", i, tc.expectedAction, action)
+		}
+	}
+}
+
+func TestAllocatorComputeActionFinalizeAtomicReplicationChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	zone := config.ZoneConfig{
+		NumReplicas: proto.Int32(3),
+	}
+	desc := roachpb.RangeDescriptor{
+		Replicas: []roachpb.ReplicaDescriptor{
+			{StoreID: 1, NodeID: 1, ReplicaID: 1},
+			{StoreID: 2, NodeID: 2, ReplicaID: 2},
+			{StoreID: 3, NodeID: 3, ReplicaID: 3, Type: roachpb.ReplicaType_VOTER_DEMOTING.Enum()},
+			{StoreID: 4, NodeID: 4, ReplicaID: 4, Type: roachpb.ReplicaType_VOTER_INCOMING.Enum()},
+		},
+	}
+
+	stopper, _, sp, a, _ := createTestAllocator(10, false /* deterministic */)
+	ctx := context.Background()
+	defer stopper.Stop(ctx)
+
+	live := []roachpb.StoreID{1, 2, 3, 4}
+	mockStorePool(sp, live, nil, nil, nil, nil, nil)
+	action, priority := a.ComputeAction(ctx, &zone, RangeInfo{Desc: &desc})
+	if action != AllocatorFinalizeAtomicReplicationChange {
+		t.Fatalf("expected %s, got %s", AllocatorFinalizeAtomicReplicationChange, action)
+	}
+	if priority != finalizeAtomicReplicationChangePriority {
+		t.Fatalf("expected priority %.2f, got %.2f", finalizeAtomicReplicationChangePriority, priority)
+	}
+
+	// Once the joint configuration is left, the demoted voter is a learner.
+	desc.Replicas[2].Type = roachpb.ReplicaType_LEARNER.Enum()
+	desc.Replicas[3].Type = nil
+	if action, _ := a.ComputeAction(ctx, &zone, RangeInfo{Desc: &desc}); action != AllocatorRemoveLearner {
+		t.Fatalf("expected %s, got %s", AllocatorRemoveLearner, action)
+	}
+}
+
 func TestAllocatorComputeActionDecommission(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -5036,6 +5230,30 @@ func TestAllocatorGetNeededReplicas(t *testing.T) {
 	}
 }
 
+func TestAllocatorGetNeededNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		numVoters, zoneNonVoters, availNodes int
+		expected                             int
+	}{
+		{3, 0, 5, 0},
+		{3, 2, 5, 2},
+		{3, 2, 4, 1},
+		{3, 2, 3, 0},
+		{5, 2, 3, 0},
+		{1, 4, 10, 4},
+	}
+
+	for _, tc := range testCases {
+		if e, a := tc.expected, GetNeededNonVoters(tc.numVoters, tc.zoneNonVoters, tc.availNodes); e != a {
+			t.Errorf(
+				"GetNeededNonVoters(numVoters=%d, zoneNonVoters=%d, availNodes=%d) got %d; want %d",
+				tc.numVoters, tc.zoneNonVoters, tc.availNodes, a, e)
+		}
+	}
+}
+
 func makeDescriptor(storeList []roachpb.StoreID) roachpb.RangeDescriptor {
 	desc := roachpb.RangeDescriptor{
 		EndKey: roachpb.RKey(keys.SystemPrefix),
@@ -5209,18 +5427,18 @@ func TestFilterBehindReplicas(t *testing.T) {
 	for _, c := range testCases {
 		t.Run("", func(t *testing.T) {
 			status := &raft.Status{
-				Progress: make(map[uint64]raft.Progress),
+				Progress: make(map[uint64]tracker.Progress),
 			}
 			status.Lead = c.leader
 			status.Commit = c.commit
 			var replicas []roachpb.ReplicaDescriptor
 			for j, v := range c.progress {
-				p := raft.Progress{
+				p := tracker.Progress{
 					Match: v,
-					State: raft.ProgressStateReplicate,
+					State: tracker.StateReplicate,
 				}
 				if v == 0 {
-					p.State = raft.ProgressStateProbe
+					p.State = tracker.StateProbe
 				}
 				replicaID := uint64(j + 1)
 				status.Progress[replicaID] = p
@@ -5277,7 +5495,7 @@ func TestFilterUnremovableReplicas(t *testing.T) {
 	for _, c := range testCases {
 		t.Run("", func(t *testing.T) {
 			status := &raft.Status{
-				Progress: make(map[uint64]raft.Progress),
+				Progress: make(map[uint64]tracker.Progress),
 			}
 			// Use an invalid replica ID for the leader. TestFilterBehindReplicas covers
 			// valid replica IDs.
@@ -5285,12 +5503,12 @@ func TestFilterUnremovableReplicas(t *testing.T) {
 			status.Commit = c.commit
 			var replicas []roachpb.ReplicaDescriptor
 			for j, v := range c.progress {
-				p := raft.Progress{
+				p := tracker.Progress{
 					Match: v,
-					State: raft.ProgressStateReplicate,
+					State: tracker.StateReplicate,
 				}
 				if v == 0 {
-					p.State = raft.ProgressStateProbe
+					p.State = tracker.StateProbe
 				}
 				replicaID := uint64(j + 1)
 				status.Progress[replicaID] = p
@@ -5332,7 +5550,7 @@ func TestSimulateFilterUnremovableReplicas(t *testing.T) {
 	for _, c := range testCases {
 		t.Run("", func(t *testing.T) {
 			status := &raft.Status{
-				Progress: make(map[uint64]raft.Progress),
+				Progress: make(map[uint64]tracker.Progress),
 			}
 			// Use an invalid replica ID for the leader. TestFilterBehindReplicas covers
 			// valid replica IDs.
@@ -5340,12 +5558,12 @@ func TestSimulateFilterUnremovableReplicas(t *testing.T) {
 			status.Commit = c.commit
 			var replicas []roachpb.ReplicaDescriptor
 			for j, v := range c.progress {
-				p := raft.Progress{
+				p := tracker.Progress{
 					Match: v,
-					State: raft.ProgressStateReplicate,
+					State: tracker.StateReplicate,
 				}
 				if v == 0 {
-					p.State = raft.ProgressStateProbe
+					p.State = tracker.StateProbe
 				}
 				replicaID := uint64(j + 1)
 				status.Progress[replicaID] = p
@@ -5478,7 +5696,7 @@ func TestAllocatorRebalanceAway(t *testing.T) {
 				},
 			}
 
-			actual, _ := a.RebalanceTarget(
+			actual, _, _ := a.RebalanceTarget(
 				ctx,
 				&config.ZoneConfig{NumReplicas: proto.Int32(0), Constraints: []config.Constraints{constraints}},
 				nil,
@@ -5641,7 +5859,7 @@ func TestAllocatorFullDisks(t *testing.T) {
 				ts := &testStores[k]
 				// Rebalance until there's no more rebalancing to do.
 				if ts.Capacity.RangeCount > 0 {
-					target, details := alloc.RebalanceTarget(
+					target, _, details := alloc.RebalanceTarget(
 						ctx,
 						config.EmptyCompleteZoneConfig(),
 						nil,
@@ -5767,7 +5985,7 @@ func Example_rebalancing() {
 		// Next loop through test stores and maybe rebalance.
 		for j := 0; j < len(testStores); j++ {
 			ts := &testStores[j]
-			target, details := alloc.RebalanceTarget(
+			target, _, details := alloc.RebalanceTarget(
 				context.Background(),
 				config.EmptyCompleteZoneConfig(),
 				nil,
@@ -91,7 +91,10 @@ func BeginTransaction(
 			// txn.
 			return result.Result{}, roachpb.NewTransactionAbortedError(roachpb.ABORT_REASON_ABORTED_RECORD_FOUND)
 
-		case roachpb.PENDING:
+		case roachpb.PENDING, roachpb.STAGING:
+			// A STAGING record is treated like a PENDING one. If the
+			// transaction has since moved to a later epoch, its staged commit
+			// can no longer succeed.
 			if h.Txn.Epoch > existingTxn.Epoch {
 				// On a transaction retry there will be an extant txn record
 				// but this run should have an upgraded epoch. The extant txn
@@ -236,7 +236,10 @@ func evalEndTransaction(
 			return result.FromEndTxn(reply.Txn, true /* alwaysReturn */, args.Poison),
 				roachpb.NewTransactionAbortedError(roachpb.ABORT_REASON_ABORTED_RECORD_FOUND)
 
-		case roachpb.PENDING:
+		case roachpb.PENDING, roachpb.STAGING:
+			// A STAGING transaction record can be committed explicitly, rolled
+			// back or staged again by its coordinator, so it is treated like a
+			// PENDING record here.
 			if h.Txn.Epoch < reply.Txn.Epoch {
 				// TODO(tschottdorf): this leaves the Txn record (and more
 				// importantly, intents) dangling; we can't currently write on
@@ -295,7 +298,33 @@ func evalEndTransaction(
 				reply.Txn))
 		}
 
+		if args.IsParallelCommit() {
+			// The transaction is performing a parallel commit: its final writes
+			// are still in-flight, so the transaction record is moved to the
+			// STAGING status instead of being committed. The transaction is
+			// implicitly committed once all in-flight writes have succeeded at
+			// or below the staging timestamp. Its intents are not resolved
+			// until the commit is made explicit, either by the coordinator or
+			// by the transaction recovery procedure.
+			if args.InternalCommitTrigger != nil {
+				return result.Result{}, errors.Errorf(
+					"cannot perform parallel commit with commit trigger: %s", args)
+			}
+			reply.Txn.Status = roachpb.STAGING
+			reply.Txn.InFlightWrites = args.InFlightWrites
+			reply.Txn.Intents = args.IntentSpans
+			reply.StagingTimestamp = reply.Txn.Timestamp
+			txnRecord := reply.Txn.AsRecord()
+			if err := engine.MVCCPutProto(
+				ctx, batch, ms, key, hlc.Timestamp{}, nil /* txn */, &txnRecord,
+			); err != nil {
+				return result.Result{}, err
+			}
+			return result.Result{}, nil
+		}
+
 		reply.Txn.Status = roachpb.COMMITTED
+		reply.Txn.InFlightWrites = nil
 
 		// Merge triggers must run before intent resolution as the merge trigger
 		// itself contains intents, in the RightData snapshot, that will be owned
@@ -319,6 +348,7 @@ func evalEndTransaction(
 		}
 	} else {
 		reply.Txn.Status = roachpb.ABORTED
+		reply.Txn.InFlightWrites = nil
 	}
 
 	desc := cArgs.EvalCtx.Desc()
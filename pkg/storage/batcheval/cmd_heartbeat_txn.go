@@ -77,7 +77,7 @@ func HeartbeatTxn(
 		}
 	}
 
-	if txn.Status == roachpb.PENDING {
+	if !txn.Status.IsFinalized() {
 		txn.LastHeartbeat.Forward(args.Now)
 		txnRecord := txn.AsRecord()
 		if err := engine.MVCCPutProto(ctx, batch, cArgs.Stats, key, hlc.Timestamp{}, nil, &txnRecord); err != nil {
@@ -87,7 +87,8 @@ func evalNewLease(
 
 	// Verify that requesting replica is part of the current replica set.
 	desc := rec.Desc()
-	if _, ok := desc.GetReplicaDescriptor(lease.Replica.StoreID); !ok {
+	repDesc, ok := desc.GetReplicaDescriptor(lease.Replica.StoreID)
+	if !ok {
 		return newFailedLeaseTrigger(isTransfer),
 			&roachpb.LeaseRejectedError{
 				Existing:  prevLease,
@@ -95,6 +96,24 @@ func evalNewLease(
 				Message:   "replica not found",
 			}
 	}
+	// Learners and non-voters don't vote and can't be relied upon to have the
+	// latest committed state, so they must never hold the lease.
+	switch repDesc.GetType() {
+	case roachpb.ReplicaType_LEARNER:
+		return newFailedLeaseTrigger(isTransfer),
+			&roachpb.LeaseRejectedError{
+				Existing:  prevLease,
+				Requested: lease,
+				Message:   "replica is a learner",
+			}
+	case roachpb.ReplicaType_NON_VOTER:
+		return newFailedLeaseTrigger(isTransfer),
+			&roachpb.LeaseRejectedError{
+				Existing:  prevLease,
+				Requested: lease,
+				Message:   "replica is a non-voter",
+			}
+	}
 
 	// Requests should not set the sequence number themselves. Set the sequence
 	// number here based on whether the lease is equivalent to the one it's
@@ -68,6 +68,12 @@ func declareKeysPushTransaction(
 // Txn already committed/aborted: If the pushee txn is committed or
 // aborted return success.
 //
+// Txn staging: If the pushee txn is performing a parallel commit, it
+// may already be implicitly committed. A push which would otherwise
+// succeed returns an IndeterminateCommitError instead, which prompts
+// the pusher to run the transaction recovery procedure to determine
+// the outcome of the commit.
+//
 // Txn record expired: If the pushee txn is pending, its last
 // heartbeat timestamp is observed to determine the latest client
 // activity. This heartbeat is forwarded by the conflicting intent's
@@ -176,7 +182,7 @@ func PushTxn(
 	}
 
 	// If already committed or aborted, return success.
-	if reply.PusheeTxn.Status != roachpb.PENDING {
+	if reply.PusheeTxn.Status.IsFinalized() {
 		// Trivial noop.
 		return result.Result{}, nil
 	}
@@ -189,12 +195,27 @@ func PushTxn(
 	}
 
 	// The pusher might be aware of a newer version of the pushee.
+	knownHigherTimestamp := reply.PusheeTxn.Timestamp.Less(args.PusheeTxn.Timestamp)
+	knownHigherEpoch := reply.PusheeTxn.Epoch < args.PusheeTxn.Epoch
 	reply.PusheeTxn.Timestamp.Forward(args.PusheeTxn.Timestamp)
-	if reply.PusheeTxn.Epoch < args.PusheeTxn.Epoch {
+	if knownHigherEpoch {
 		reply.PusheeTxn.Epoch = args.PusheeTxn.Epoch
 	}
 	reply.PusheeTxn.UpgradePriority(args.PusheeTxn.Priority)
 
+	// If the pusher is aware that the pushee's recorded attempt at a parallel
+	// commit failed, because it found one of its intents at a higher epoch or
+	// timestamp than the STAGING transaction record, then the pushee's commit
+	// status is not indeterminate and it can be treated as PENDING. A push
+	// must not move the record back to the PENDING status however, as that
+	// would allow a commit to succeed without the knowledge of the recovery
+	// procedure, so timestamp pushes are upgraded to aborts.
+	if reply.PusheeTxn.Status == roachpb.STAGING && (knownHigherTimestamp || knownHigherEpoch) {
+		reply.PusheeTxn.Status = roachpb.PENDING
+		reply.PusheeTxn.InFlightWrites = nil
+		args.PushType = roachpb.PUSH_ABORT
+	}
+
 	var pusherWins bool
 	var reason string
 
@@ -235,6 +256,17 @@ func PushTxn(
 		return result.Result{}, err
 	}
 
+	// If the pushee is STAGING, it may be implicitly committed. It can't be
+	// pushed or aborted until its commit status has been determined by the
+	// transaction recovery procedure.
+	if reply.PusheeTxn.Status == roachpb.STAGING {
+		err := roachpb.NewIndeterminateCommitError(reply.PusheeTxn)
+		if log.V(1) {
+			log.Infof(ctx, "%v", err)
+		}
+		return result.Result{}, err
+	}
+
 	// Upgrade priority of pushed transaction to one less than pusher's.
 	reply.PusheeTxn.UpgradePriority(args.PusherTxn.Priority - 1)
 
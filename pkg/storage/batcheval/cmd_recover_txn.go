@@ -0,0 +1,169 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package batcheval
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	RegisterCommand(roachpb.RecoverTxn, declareKeysRecoverTransaction, RecoverTxn)
+}
+
+func declareKeysRecoverTransaction(
+	_ roachpb.RangeDescriptor, header roachpb.Header, req roachpb.Request, spans *spanset.SpanSet,
+) {
+	rr := req.(*roachpb.RecoverTxnRequest)
+	spans.Add(spanset.SpanReadWrite, roachpb.Span{Key: keys.TransactionKey(rr.Txn.Key, rr.Txn.ID)})
+}
+
+// RecoverTxn attempts to recover the specified transaction from an
+// indeterminate commit state. Transactions enter this state when abandoned
+// after updating their transaction record with a STAGING status. The RecoverTxn
+// operation is invoked by a caller who encounters a transaction in this state
+// after they have already queried all of the STAGING transaction's declared
+// in-flight writes. The caller specifies whether all of these in-flight writes
+// were found to have succeeded. If all of the in-flight writes succeeded, the
+// transaction is implicitly committed and the record is moved to the COMMITTED
+// status. If not, the caller has prevented the missing write from ever
+// succeeding, so the transaction can never become implicitly committed at its
+// current epoch and timestamp and the record is moved to the ABORTED status.
+//
+// The operation does nothing if the transaction record is found to have been
+// finalized or to have moved on to a later epoch or timestamp in the meantime.
+func RecoverTxn(
+	ctx context.Context, batch engine.ReadWriter, cArgs CommandArgs, resp roachpb.Response,
+) (result.Result, error) {
+	args := cArgs.Args.(*roachpb.RecoverTxnRequest)
+	h := cArgs.Header
+	reply := resp.(*roachpb.RecoverTxnResponse)
+
+	if h.Txn != nil {
+		return result.Result{}, ErrTransactionUnsupported
+	}
+	if !bytes.Equal(args.Key, args.Txn.Key) {
+		return result.Result{}, errors.Errorf("request key %s does not match txn key %s", args.Key, args.Txn.Key)
+	}
+	key := keys.TransactionKey(args.Txn.Key, args.Txn.ID)
+
+	// Fetch the transaction record.
+	if ok, err := engine.MVCCGetProto(
+		ctx, batch, key, hlc.Timestamp{}, &reply.RecoveredTxn, engine.MVCCGetOptions{},
+	); err != nil {
+		return result.Result{}, err
+	} else if !ok {
+		// The transaction record must have been removed after the transaction
+		// was finalized. If all of its in-flight writes were found, it must have
+		// committed. If not, it may have committed and had its writes resolved
+		// or it may have been aborted. Either way, its intents can no longer
+		// commit, so we report it as ABORTED. Synthesize the transaction
+		// from the provided TxnMeta.
+		reply.RecoveredTxn.TxnMeta = args.Txn
+		if args.ImplicitlyCommitted {
+			reply.RecoveredTxn.Status = roachpb.COMMITTED
+		} else {
+			reply.RecoveredTxn.Status = roachpb.ABORTED
+		}
+		return result.Result{}, nil
+	}
+
+	// Determine whether to continue with recovery based on the state of the
+	// transaction record and whether or not the transaction was found to be
+	// implicitly committed.
+	if args.ImplicitlyCommitted {
+		// Finding all in-flight writes means that the transaction was at one
+		// point implicitly committed. It can't have changed its epoch or
+		// timestamp since, and the only other status it can be in is
+		// COMMITTED.
+		switch reply.RecoveredTxn.Status {
+		case roachpb.PENDING, roachpb.ABORTED:
+			return result.Result{}, errors.Errorf(
+				"programming error: found %s record for implicitly committed transaction: %v",
+				reply.RecoveredTxn.Status, reply.RecoveredTxn,
+			)
+		case roachpb.STAGING, roachpb.COMMITTED:
+			if was, is := args.Txn.Epoch, reply.RecoveredTxn.Epoch; was != is {
+				return result.Result{}, errors.Errorf(
+					"programming error: epoch change by implicitly committed transaction: %v->%v", was, is,
+				)
+			}
+			if was, is := args.Txn.Timestamp, reply.RecoveredTxn.Timestamp; was != is {
+				return result.Result{}, errors.Errorf(
+					"programming error: timestamp change by implicitly committed transaction: %v->%v", was, is,
+				)
+			}
+			if reply.RecoveredTxn.Status == roachpb.COMMITTED {
+				// The commit was already made explicit.
+				return result.Result{}, nil
+			}
+		}
+	} else {
+		// Did the transaction move to a later epoch or timestamp, in which case
+		// it is allowed to keep trying to commit?
+		legalChange := args.Txn.Epoch < reply.RecoveredTxn.Epoch ||
+			args.Txn.Timestamp.Less(reply.RecoveredTxn.Timestamp)
+
+		switch reply.RecoveredTxn.Status {
+		case roachpb.ABORTED, roachpb.COMMITTED:
+			// The transaction was finalized by some other process. A COMMITTED
+			// record is possible even though an in-flight write was prevented,
+			// because QueryIntent can't distinguish a missing write from one
+			// which was already resolved after the commit was made explicit.
+			return result.Result{}, nil
+		case roachpb.PENDING:
+			if args.Txn.Epoch < reply.RecoveredTxn.Epoch {
+				// The transaction moved on to a new epoch. Recovery is not
+				// needed.
+				return result.Result{}, nil
+			}
+			return result.Result{}, errors.Errorf(
+				"programming error: cannot recover PENDING transaction in same epoch: %v", reply.RecoveredTxn,
+			)
+		case roachpb.STAGING:
+			if legalChange {
+				// The transaction was restarted or staged again. Recovery is
+				// not needed.
+				return result.Result{}, nil
+			}
+		}
+	}
+
+	// Recover the transaction based on whether or not all of its in-flight
+	// writes succeeded. Its in-flight writes are already included in its
+	// intent spans, which are resolved by the caller.
+	if args.ImplicitlyCommitted {
+		reply.RecoveredTxn.Status = roachpb.COMMITTED
+	} else {
+		reply.RecoveredTxn.Status = roachpb.ABORTED
+	}
+	reply.RecoveredTxn.InFlightWrites = nil
+	txnRecord := reply.RecoveredTxn.AsRecord()
+	if err := engine.MVCCPutProto(ctx, batch, cArgs.Stats, key, hlc.Timestamp{}, nil, &txnRecord); err != nil {
+		return result.Result{}, err
+	}
+
+	result := result.Result{}
+	result.Local.UpdatedTxns = &[]*roachpb.Transaction{&reply.RecoveredTxn}
+	return result, nil
+}
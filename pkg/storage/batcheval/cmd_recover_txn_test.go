@@ -0,0 +1,149 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package batcheval
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecoverTxn tests that RecoverTxn moves a STAGING transaction record to
+// the COMMITTED status if all of its in-flight writes were found and to the
+// ABORTED status if not.
+func TestRecoverTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	k := roachpb.Key("a")
+	ts := hlc.Timestamp{WallTime: 1}
+	txn := roachpb.MakeTransaction("test", k, 0, ts, 0)
+	txn.Status = roachpb.STAGING
+	txn.InFlightWrites = []roachpb.SequencedWrite{{Key: k, Sequence: 1}}
+
+	for _, implicitlyCommitted := range []bool{false, true} {
+		t.Run(fmt.Sprintf("implicitlyCommitted=%t", implicitlyCommitted), func(t *testing.T) {
+			db := engine.NewInMem(roachpb.Attributes{}, 10<<20)
+			defer db.Close()
+
+			// Write the STAGING transaction record.
+			txnKey := keys.TransactionKey(txn.Key, txn.ID)
+			txnRecord := txn.AsRecord()
+			if err := engine.MVCCPutProto(ctx, db, nil, txnKey, hlc.Timestamp{}, nil, &txnRecord); err != nil {
+				t.Fatal(err)
+			}
+
+			// Recover the transaction.
+			var resp roachpb.RecoverTxnResponse
+			if _, err := RecoverTxn(ctx, db, CommandArgs{
+				Args: &roachpb.RecoverTxnRequest{
+					RequestHeader:       roachpb.RequestHeader{Key: txn.Key},
+					Txn:                 txn.TxnMeta,
+					ImplicitlyCommitted: implicitlyCommitted,
+				},
+			}, &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			expStatus := roachpb.ABORTED
+			if implicitlyCommitted {
+				expStatus = roachpb.COMMITTED
+			}
+			require.Equal(t, expStatus, resp.RecoveredTxn.Status)
+			require.Nil(t, resp.RecoveredTxn.InFlightWrites)
+
+			// Verify that the transaction record was updated.
+			var foundRecord roachpb.TransactionRecord
+			if ok, err := engine.MVCCGetProto(
+				ctx, db, txnKey, hlc.Timestamp{}, &foundRecord, engine.MVCCGetOptions{},
+			); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Fatalf("transaction record not found")
+			}
+			require.Equal(t, expStatus, foundRecord.Status)
+			require.Nil(t, foundRecord.InFlightWrites)
+		})
+	}
+}
+
+// TestRecoverTxnRecordChanged tests that RecoverTxn does not modify a
+// transaction record that was restarted or staged at a later timestamp after
+// one of its in-flight writes was prevented.
+func TestRecoverTxnRecordChanged(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	k := roachpb.Key("a")
+	ts := hlc.Timestamp{WallTime: 1}
+	txn := roachpb.MakeTransaction("test", k, 0, ts, 0)
+	txn.Status = roachpb.STAGING
+
+	for _, tc := range []struct {
+		name   string
+		change func(*roachpb.Transaction)
+	}{
+		{
+			name: "restarted",
+			change: func(txn *roachpb.Transaction) {
+				txn.Status = roachpb.PENDING
+				txn.Epoch++
+			},
+		},
+		{
+			name: "restaged",
+			change: func(txn *roachpb.Transaction) {
+				txn.Timestamp = txn.Timestamp.Add(1, 0)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db := engine.NewInMem(roachpb.Attributes{}, 10<<20)
+			defer db.Close()
+
+			// Write the changed transaction record.
+			changedTxn := txn.Clone()
+			tc.change(&changedTxn)
+			txnKey := keys.TransactionKey(txn.Key, txn.ID)
+			txnRecord := changedTxn.AsRecord()
+			if err := engine.MVCCPutProto(ctx, db, nil, txnKey, hlc.Timestamp{}, nil, &txnRecord); err != nil {
+				t.Fatal(err)
+			}
+
+			// Attempt to recover the transaction at its original epoch and
+			// timestamp.
+			var resp roachpb.RecoverTxnResponse
+			if _, err := RecoverTxn(ctx, db, CommandArgs{
+				Args: &roachpb.RecoverTxnRequest{
+					RequestHeader:       roachpb.RequestHeader{Key: txn.Key},
+					Txn:                 txn.TxnMeta,
+					ImplicitlyCommitted: false,
+				},
+			}, &resp); err != nil {
+				t.Fatal(err)
+			}
+			require.Equal(t, changedTxn.Status, resp.RecoveredTxn.Status)
+			require.Equal(t, changedTxn.Epoch, resp.RecoveredTxn.Epoch)
+			require.Equal(t, changedTxn.Timestamp, resp.RecoveredTxn.Timestamp)
+		})
+	}
+}
@@ -38,6 +38,10 @@ func ReverseScan(
 	h := cArgs.Header
 	reply := resp.(*roachpb.ReverseScanResponse)
 
+	if args.KeyLocking != roachpb.NO_KEY_LOCKING {
+		return lockingScan(ctx, batch, cArgs, args.Span(), args.ScanFormat, true /* reverse */, reply)
+	}
+
 	var err error
 	var intents []roachpb.Intent
 	var resumeSpan *roachpb.Span
@@ -38,6 +38,10 @@ func Scan(
 	h := cArgs.Header
 	reply := resp.(*roachpb.ScanResponse)
 
+	if args.KeyLocking != roachpb.NO_KEY_LOCKING {
+		return lockingScan(ctx, batch, cArgs, args.Span(), args.ScanFormat, false /* reverse */, reply)
+	}
+
 	var err error
 	var intents []roachpb.Intent
 	var resumeSpan *roachpb.Span
@@ -0,0 +1,221 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package batcheval
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/pkg/errors"
+)
+
+// lockingScan evaluates a Scan or ReverseScan request that locks the keys
+// that it returns. See scanAndLock.
+func lockingScan(
+	ctx context.Context,
+	batch engine.ReadWriter,
+	cArgs CommandArgs,
+	span roachpb.Span,
+	format roachpb.ScanFormat,
+	reverse bool,
+	resp roachpb.Response,
+) (result.Result, error) {
+	rows, resumeSpan, err := scanAndLock(ctx, batch, cArgs, span, reverse)
+	if err != nil {
+		return result.Result{}, err
+	}
+
+	var batchResponses [][]byte
+	numKeys := int64(len(rows))
+	switch format {
+	case roachpb.BATCH_RESPONSE:
+		batchResponses = [][]byte{encodeScanBatchResponse(rows)}
+		rows = nil
+	case roachpb.KEY_VALUES:
+	default:
+		panic(fmt.Sprintf("Unknown scanFormat %d", format))
+	}
+	switch reply := resp.(type) {
+	case *roachpb.ScanResponse:
+		reply.Rows = rows
+		reply.BatchResponses = batchResponses
+	case *roachpb.ReverseScanResponse:
+		reply.Rows = rows
+		reply.BatchResponses = batchResponses
+	default:
+		panic(fmt.Sprintf("unexpected response type %T", resp))
+	}
+
+	header := resp.Header()
+	header.NumKeys = numKeys
+	if resumeSpan != nil {
+		header.ResumeSpan = resumeSpan
+		header.ResumeReason = roachpb.RESUME_KEY_LIMIT
+	}
+	resp.SetHeader(header)
+	return result.Result{}, nil
+}
+
+// scanAndLock scans the provided span in the requested direction and locks
+// each of the keys that it returns by laying down a lock-only intent carrying
+// the key's current value (see engine.MVCCLock). At most cArgs.MaxKeys keys
+// are returned; if the limit is reached, a resume span covering the remainder
+// of the span is returned.
+//
+// If the request's wait policy is SKIP_ON_CONFLICT, keys holding conflicting
+// intents are omitted from the result instead of causing a WriteIntentError.
+// Because the request holds write latches over the entire span, no new
+// intents can appear while it is evaluating.
+func scanAndLock(
+	ctx context.Context,
+	batch engine.ReadWriter,
+	cArgs CommandArgs,
+	span roachpb.Span,
+	reverse bool,
+) ([]roachpb.KeyValue, *roachpb.Span, error) {
+	h := cArgs.Header
+	if h.Txn == nil {
+		return nil, nil, errors.Errorf("locking scans must be transactional")
+	}
+	if h.ReadConsistency != roachpb.CONSISTENT {
+		return nil, nil, errors.Errorf("locking scans must be performed with %s reads",
+			roachpb.CONSISTENT)
+	}
+	skipLocked := h.WaitPolicy == roachpb.SKIP_ON_CONFLICT
+	opts := engine.MVCCScanOptions{
+		IgnoreSequence: shouldIgnoreSequenceNums(cArgs.EvalCtx),
+		Txn:            h.Txn,
+		Reverse:        reverse,
+	}
+
+	var rows []roachpb.KeyValue
+	maxKeys := cArgs.MaxKeys
+	// remaining holds the spans that still need to be scanned, in scan order.
+	remaining := []roachpb.Span{span}
+	for len(remaining) > 0 {
+		cur := remaining[0]
+		remaining = remaining[1:]
+		if maxKeys <= 0 {
+			resume := lockingResumeSpan(span, cur, reverse)
+			return rows, &resume, nil
+		}
+
+		curRows, resumeSpan, _, err := engine.MVCCScan(
+			ctx, batch, cur.Key, cur.EndKey, maxKeys, h.Timestamp, opts,
+		)
+		if err != nil {
+			wiErr, ok := err.(*roachpb.WriteIntentError)
+			if !ok || !skipLocked {
+				return nil, nil, err
+			}
+			// Scan around the locked keys.
+			split, ok := splitAroundIntents(cur, wiErr.Intents, reverse)
+			if !ok {
+				return nil, nil, err
+			}
+			remaining = append(split, remaining...)
+			continue
+		}
+		maxKeys -= int64(len(curRows))
+
+		for _, kv := range curRows {
+			// Lock the key with a lock-only intent carrying its current value.
+			val := roachpb.Value{RawBytes: kv.Value.RawBytes}
+			if err := engine.MVCCLock(
+				ctx, batch, cArgs.Stats, kv.Key, h.Timestamp, val, h.Txn,
+			); err != nil {
+				// An intent above our read timestamp will not have been noticed
+				// by the scan, but prevents us from locking the key.
+				if _, ok := err.(*roachpb.WriteIntentError); ok && skipLocked {
+					continue
+				}
+				return nil, nil, err
+			}
+			rows = append(rows, kv)
+		}
+
+		if resumeSpan != nil {
+			resume := lockingResumeSpan(span, *resumeSpan, reverse)
+			return rows, &resume, nil
+		}
+	}
+	return rows, nil, nil
+}
+
+// lockingResumeSpan returns the portion of span that still needs to be
+// scanned, given that rest is the first unscanned sub-span of span.
+func lockingResumeSpan(span, rest roachpb.Span, reverse bool) roachpb.Span {
+	if reverse {
+		return roachpb.Span{Key: span.Key, EndKey: rest.EndKey}
+	}
+	return roachpb.Span{Key: rest.Key, EndKey: span.EndKey}
+}
+
+// splitAroundIntents splits span into the sub-spans that exclude the keys of
+// the provided point intents. The sub-spans are returned in scan order. The
+// returned boolean is false if none of the intents fall within span.
+func splitAroundIntents(
+	span roachpb.Span, intents []roachpb.Intent, reverse bool,
+) ([]roachpb.Span, bool) {
+	keys := make([]roachpb.Key, 0, len(intents))
+	for _, intent := range intents {
+		if span.ContainsKey(intent.Key) {
+			keys = append(keys, intent.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, false
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Compare(keys[j]) < 0 })
+
+	split := make([]roachpb.Span, 0, len(keys)+1)
+	start := span.Key
+	for _, k := range keys {
+		if start.Compare(k) < 0 {
+			split = append(split, roachpb.Span{Key: start, EndKey: k})
+		}
+		start = k.Next()
+	}
+	if start.Compare(span.EndKey) < 0 {
+		split = append(split, roachpb.Span{Key: start, EndKey: span.EndKey})
+	}
+	if reverse {
+		for i, j := 0, len(split)-1; i < j; i, j = i+1, j-1 {
+			split[i], split[j] = split[j], split[i]
+		}
+	}
+	return split, true
+}
+
+// encodeScanBatchResponse encodes the provided rows in the format returned by
+// engine.MVCCScanToBytes.
+func encodeScanBatchResponse(rows []roachpb.KeyValue) []byte {
+	var buf []byte
+	var lenBuf [8]byte
+	for _, kv := range rows {
+		key := engine.EncodeKey(engine.MVCCKey{Key: kv.Key, Timestamp: kv.Value.Timestamp})
+		binary.LittleEndian.PutUint32(lenBuf[0:4], uint32(len(kv.Value.RawBytes)))
+		binary.LittleEndian.PutUint32(lenBuf[4:8], uint32(len(key)))
+		buf = append(buf, lenBuf[:]...)
+		buf = append(buf, key...)
+		buf = append(buf, kv.Value.RawBytes...)
+	}
+	return buf
+}
@@ -0,0 +1,255 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package batcheval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAroundIntents(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	sp := func(key, endKey string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(key), EndKey: roachpb.Key(endKey)}
+	}
+	intents := func(keys ...string) []roachpb.Intent {
+		var ret []roachpb.Intent
+		for _, k := range keys {
+			ret = append(ret, roachpb.Intent{Span: roachpb.Span{Key: roachpb.Key(k)}})
+		}
+		return ret
+	}
+	// next returns the key immediately following k.
+	next := func(k string) string { return k + "\x00" }
+
+	testCases := []struct {
+		span    roachpb.Span
+		intents []roachpb.Intent
+		reverse bool
+		expOK   bool
+		exp     []roachpb.Span
+	}{
+		// Intents outside of the span are ignored.
+		{span: sp("b", "d"), intents: intents("a", "d", "e")},
+		{
+			span:    sp("a", "e"),
+			intents: intents("c"),
+			expOK:   true,
+			exp:     []roachpb.Span{sp("a", "c"), sp(next("c"), "e")},
+		},
+		// Intents are sorted and an intent at the start of the span leaves no
+		// empty sub-span behind.
+		{
+			span:    sp("a", "e"),
+			intents: intents("d", "a", "b"),
+			expOK:   true,
+			exp:     []roachpb.Span{sp(next("a"), "b"), sp(next("b"), "d"), sp(next("d"), "e")},
+		},
+		// An intent on the last key of the span.
+		{
+			span:    sp("a", next("d")),
+			intents: intents("d"),
+			expOK:   true,
+			exp:     []roachpb.Span{sp("a", "d")},
+		},
+		// Reverse scans return the sub-spans in reverse order.
+		{
+			span:    sp("a", "e"),
+			intents: intents("b", "c", "z"),
+			reverse: true,
+			expOK:   true,
+			exp:     []roachpb.Span{sp(next("c"), "e"), sp(next("b"), "c"), sp("a", "b")},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			split, ok := splitAroundIntents(tc.span, tc.intents, tc.reverse)
+			require.Equal(t, tc.expOK, ok)
+			require.Equal(t, tc.exp, split)
+		})
+	}
+}
+
+// TestLockingScanWaitPolicies verifies how locking scans treat the intents of
+// other transactions under each of the wait policies. Conflicting intents
+// cause a WriteIntentError, which the replica handles according to the wait
+// policy, except with SKIP_ON_CONFLICT, in which case the scan omits the
+// locked keys and locks the rest.
+func TestLockingScanWaitPolicies(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	db := engine.NewInMem(roachpb.Attributes{}, 10<<20)
+	defer db.Close()
+
+	ts1 := hlc.Timestamp{WallTime: 1}
+	ts2 := hlc.Timestamp{WallTime: 2}
+	ts3 := hlc.Timestamp{WallTime: 3}
+	ts4 := hlc.Timestamp{WallTime: 4}
+	keys := []string{"a", "b", "c", "d", "e"}
+	for _, k := range keys {
+		v := roachpb.MakeValueFromString(k)
+		require.NoError(t, engine.MVCCPut(ctx, db, nil, roachpb.Key(k), ts1, v, nil))
+	}
+	// Another transaction holds intents on "b" and "d" below the read
+	// timestamp of the locking scans, and on "c" above it. The intent on "c"
+	// is not seen by the scan itself, but prevents the key from being locked.
+	other := roachpb.MakeTransaction("other", roachpb.Key("b"), roachpb.NormalUserPriority, ts2, 0)
+	for _, k := range []string{"b", "d"} {
+		v := roachpb.MakeValueFromString("other")
+		require.NoError(t, engine.MVCCPut(ctx, db, nil, roachpb.Key(k), ts2, v, &other))
+	}
+	otherAbove := roachpb.MakeTransaction(
+		"other", roachpb.Key("c"), roachpb.NormalUserPriority, ts4, 0,
+	)
+	v := roachpb.MakeValueFromString("other")
+	require.NoError(t, engine.MVCCPut(ctx, db, nil, roachpb.Key("c"), ts4, v, &otherAbove))
+
+	span := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("f")}
+	scan := func(
+		batch engine.ReadWriter,
+		txn *roachpb.Transaction,
+		sp roachpb.Span,
+		reverse bool,
+		maxKeys int64,
+		wp roachpb.WaitPolicy,
+	) ([]roachpb.KeyValue, *roachpb.Span, error) {
+		var h roachpb.Header
+		h.Txn = txn
+		h.Timestamp = txn.Timestamp
+		h.WaitPolicy = wp
+		cArgs := CommandArgs{
+			EvalCtx: &mockEvalCtx{clusterSettings: cluster.MakeTestingClusterSettings()},
+			Header:  h,
+			MaxKeys: maxKeys,
+			Stats:   &enginepb.MVCCStats{},
+		}
+		reqHeader := roachpb.RequestHeader{Key: sp.Key, EndKey: sp.EndKey}
+		if reverse {
+			cArgs.Args = &roachpb.ReverseScanRequest{
+				RequestHeader: reqHeader,
+				KeyLocking:    roachpb.EXCLUSIVE_KEY_LOCKING,
+			}
+			var resp roachpb.ReverseScanResponse
+			if _, err := ReverseScan(ctx, batch, cArgs, &resp); err != nil {
+				return nil, nil, err
+			}
+			return resp.Rows, resp.ResumeSpan, nil
+		}
+		cArgs.Args = &roachpb.ScanRequest{
+			RequestHeader: reqHeader,
+			KeyLocking:    roachpb.EXCLUSIVE_KEY_LOCKING,
+		}
+		var resp roachpb.ScanResponse
+		if _, err := Scan(ctx, batch, cArgs, &resp); err != nil {
+			return nil, nil, err
+		}
+		return resp.Rows, resp.ResumeSpan, nil
+	}
+	rowKeys := func(rows []roachpb.KeyValue) []string {
+		var ret []string
+		for _, kv := range rows {
+			ret = append(ret, string(kv.Key))
+		}
+		return ret
+	}
+	// lockedBy returns the keys whose intents belong to the given transaction.
+	lockedBy := func(batch engine.Reader, txn *roachpb.Transaction) []string {
+		var ret []string
+		for _, k := range keys {
+			_, intent, err := engine.MVCCGet(
+				ctx, batch, roachpb.Key(k), ts4.Next(), engine.MVCCGetOptions{Inconsistent: true},
+			)
+			require.NoError(t, err)
+			if intent != nil && intent.Txn.ID == txn.ID {
+				ret = append(ret, k)
+			}
+		}
+		return ret
+	}
+
+	for _, wp := range []roachpb.WaitPolicy{roachpb.BLOCK_ON_CONFLICT, roachpb.ERROR_ON_CONFLICT} {
+		t.Run(wp.String(), func(t *testing.T) {
+			for _, reverse := range []bool{false, true} {
+				batch := db.NewBatch()
+				defer batch.Close()
+				txn := roachpb.MakeTransaction(
+					"locker", roachpb.Key("a"), roachpb.NormalUserPriority, ts3, 0,
+				)
+				_, _, err := scan(batch, &txn, span, reverse, math.MaxInt64, wp)
+				wiErr, ok := err.(*roachpb.WriteIntentError)
+				require.True(t, ok, "expected WriteIntentError, got %v", err)
+				require.NotEmpty(t, wiErr.Intents)
+				for _, intent := range wiErr.Intents {
+					require.Equal(t, other.ID, intent.Txn.ID)
+				}
+				// No keys were locked.
+				require.Empty(t, lockedBy(batch, &txn))
+			}
+		})
+	}
+
+	t.Run(roachpb.SKIP_ON_CONFLICT.String(), func(t *testing.T) {
+		for _, tc := range []struct {
+			reverse bool
+			maxKeys int64
+			exp     []string
+		}{
+			{reverse: false, maxKeys: math.MaxInt64, exp: []string{"a", "e"}},
+			{reverse: true, maxKeys: math.MaxInt64, exp: []string{"e", "a"}},
+			{reverse: false, maxKeys: 1, exp: []string{"a", "e"}},
+			{reverse: true, maxKeys: 1, exp: []string{"e", "a"}},
+		} {
+			t.Run(fmt.Sprintf("reverse=%t,maxKeys=%d", tc.reverse, tc.maxKeys), func(t *testing.T) {
+				batch := db.NewBatch()
+				defer batch.Close()
+				txn := roachpb.MakeTransaction(
+					"locker", roachpb.Key("a"), roachpb.NormalUserPriority, ts3, 0,
+				)
+
+				// Keep scanning the resume span until the whole span is covered.
+				var got []string
+				sp := span
+				for i := 0; ; i++ {
+					require.True(t, i < len(keys), "scan did not terminate")
+					rows, resume, err := scan(
+						batch, &txn, sp, tc.reverse, tc.maxKeys, roachpb.SKIP_ON_CONFLICT,
+					)
+					require.NoError(t, err)
+					require.True(t, int64(len(rows)) <= tc.maxKeys)
+					got = append(got, rowKeys(rows)...)
+					if resume == nil {
+						break
+					}
+					require.True(t, span.Contains(*resume), "resume span %s not in %s", resume, span)
+					sp = *resume
+				}
+				require.Equal(t, tc.exp, got)
+				require.Equal(t, []string{"a", "e"}, lockedBy(batch, &txn))
+			})
+		}
+	})
+}
@@ -2238,10 +2238,11 @@ func TestStoreRangeMergeReadoptedBothFollowers(t *testing.T) {
 
 	addLHSRepl2 := func() error {
 		for r := retry.StartWithCtx(ctx, retry.Options{}); r.Next(); {
-			err := lhsRepl0.ChangeReplicas(ctx, roachpb.ADD_REPLICA, roachpb.ReplicationTarget{
-				NodeID:  store2.Ident.NodeID,
-				StoreID: store2.Ident.StoreID,
-			}, lhsRepl0.Desc(), storagepb.ReasonUnknown, t.Name())
+			err := lhsRepl0.ChangeReplicas(ctx, lhsRepl0.Desc(), storagepb.ReasonUnknown, t.Name(),
+				roachpb.MakeReplicationChanges(roachpb.ADD_REPLICA, roachpb.ReplicationTarget{
+					NodeID:  store2.Ident.NodeID,
+					StoreID: store2.Ident.StoreID,
+				}))
 			if !testutils.IsError(err, "store busy applying snapshots") {
 				return err
 			}
@@ -2345,11 +2346,12 @@ func TestStoreRangeReadoptedLHSFollower(t *testing.T) {
 		// immediately because there are no overlapping replicas that would interfere
 		// with the widening of the existing LHS replica.
 		if err := mtc.dbs[0].AdminChangeReplicas(
-			ctx, lhsDesc.StartKey.AsRawKey(), roachpb.ADD_REPLICA,
-			[]roachpb.ReplicationTarget{{
-				NodeID:  mtc.idents[2].NodeID,
-				StoreID: mtc.idents[2].StoreID,
-			}},
+			ctx, lhsDesc.StartKey.AsRawKey(), roachpb.MakeReplicationChanges(
+				roachpb.ADD_REPLICA,
+				roachpb.ReplicationTarget{
+					NodeID:  mtc.idents[2].NodeID,
+					StoreID: mtc.idents[2].StoreID,
+				}),
 		); !testutils.IsError(err, "cannot apply snapshot: snapshot intersects existing range") {
 			t.Fatal(err)
 		}
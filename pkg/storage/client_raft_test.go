@@ -280,14 +280,15 @@ func TestReplicateRange(t *testing.T) {
 
 	if err := repl.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  mtc.stores[1].Ident.NodeID,
-			StoreID: mtc.stores[1].Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  mtc.stores[1].Ident.NodeID,
+				StoreID: mtc.stores[1].Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -370,14 +371,15 @@ func TestRestoreReplicas(t *testing.T) {
 
 	if err := firstRng.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  mtc.stores[1].Ident.NodeID,
-			StoreID: mtc.stores[1].Ident.StoreID,
-		},
 		firstRng.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  mtc.stores[1].Ident.NodeID,
+				StoreID: mtc.stores[1].Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -464,14 +466,15 @@ func TestFailedReplicaChange(t *testing.T) {
 
 	if err := repl.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  mtc.stores[1].Ident.NodeID,
-			StoreID: mtc.stores[1].Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  mtc.stores[1].Ident.NodeID,
+				StoreID: mtc.stores[1].Ident.StoreID,
+			}),
 	); !testutils.IsError(err, "boom") {
 		t.Fatalf("did not get expected error: %v", err)
 	}
@@ -492,14 +495,15 @@ func TestFailedReplicaChange(t *testing.T) {
 
 	if err := repl.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  mtc.stores[1].Ident.NodeID,
-			StoreID: mtc.stores[1].Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  mtc.stores[1].Ident.NodeID,
+				StoreID: mtc.stores[1].Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -565,14 +569,15 @@ func TestReplicateAfterTruncation(t *testing.T) {
 	// Now add the second replica.
 	if err := repl.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  mtc.stores[1].Ident.NodeID,
-			StoreID: mtc.stores[1].Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  mtc.stores[1].Ident.NodeID,
+				StoreID: mtc.stores[1].Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -1025,14 +1030,15 @@ func TestReplicateAfterRemoveAndSplit(t *testing.T) {
 
 		return rep2.ChangeReplicas(
 			context.Background(),
-			roachpb.ADD_REPLICA,
-			roachpb.ReplicationTarget{
-				NodeID:  mtc.stores[2].Ident.NodeID,
-				StoreID: mtc.stores[2].Ident.StoreID,
-			},
 			&desc,
 			storagepb.ReasonRangeUnderReplicated,
 			"",
+			roachpb.MakeReplicationChanges(
+				roachpb.ADD_REPLICA,
+				roachpb.ReplicationTarget{
+					NodeID:  mtc.stores[2].Ident.NodeID,
+					StoreID: mtc.stores[2].Ident.StoreID,
+				}),
 		)
 	}
 
@@ -1646,14 +1652,15 @@ func TestChangeReplicasDescriptorInvariant(t *testing.T) {
 	addReplica := func(storeNum int, desc *roachpb.RangeDescriptor) error {
 		return repl.ChangeReplicas(
 			context.Background(),
-			roachpb.ADD_REPLICA,
-			roachpb.ReplicationTarget{
-				NodeID:  mtc.stores[storeNum].Ident.NodeID,
-				StoreID: mtc.stores[storeNum].Ident.StoreID,
-			},
 			desc,
 			storagepb.ReasonRangeUnderReplicated,
 			"",
+			roachpb.MakeReplicationChanges(
+				roachpb.ADD_REPLICA,
+				roachpb.ReplicationTarget{
+					NodeID:  mtc.stores[storeNum].Ident.NodeID,
+					StoreID: mtc.stores[storeNum].Ident.StoreID,
+				}),
 		)
 	}
 
@@ -2719,14 +2726,15 @@ func TestRemovePlaceholderRace(t *testing.T) {
 			for {
 				if err := repl.ChangeReplicas(
 					ctx,
-					action,
-					roachpb.ReplicationTarget{
-						NodeID:  mtc.stores[1].Ident.NodeID,
-						StoreID: mtc.stores[1].Ident.StoreID,
-					},
 					repl.Desc(),
 					storagepb.ReasonUnknown,
 					"",
+					roachpb.MakeReplicationChanges(
+						action,
+						roachpb.ReplicationTarget{
+							NodeID:  mtc.stores[1].Ident.NodeID,
+							StoreID: mtc.stores[1].Ident.StoreID,
+						}),
 				); err != nil {
 					if storage.IsSnapshotError(err) {
 						continue
@@ -2751,13 +2759,23 @@ func (ncc *noConfChangeTestHandler) HandleRaftRequest(
 	respStream storage.RaftMessageResponseStream,
 ) *roachpb.Error {
 	for i, e := range req.Message.Entries {
-		if e.Type == raftpb.EntryConfChange {
-			var cc raftpb.ConfChange
-			if err := protoutil.Unmarshal(e.Data, &cc); err != nil {
-				panic(err)
+		if e.Type == raftpb.EntryConfChange || e.Type == raftpb.EntryConfChangeV2 {
+			var encodedCtx []byte
+			if e.Type == raftpb.EntryConfChange {
+				var cc raftpb.ConfChange
+				if err := protoutil.Unmarshal(e.Data, &cc); err != nil {
+					panic(err)
+				}
+				encodedCtx = cc.Context
+			} else {
+				var cc raftpb.ConfChangeV2
+				if err := protoutil.Unmarshal(e.Data, &cc); err != nil {
+					panic(err)
+				}
+				encodedCtx = cc.Context
 			}
 			var ccCtx storage.ConfChangeContext
-			if err := protoutil.Unmarshal(cc.Context, &ccCtx); err != nil {
+			if err := protoutil.Unmarshal(encodedCtx, &ccCtx); err != nil {
 				panic(err)
 			}
 			var command storagepb.RaftCommand
@@ -2822,14 +2840,15 @@ func TestReplicaGCRace(t *testing.T) {
 	// range descriptor, preventing it from learning of the new NextReplicaID.
 	if err := repl.ChangeReplicas(
 		ctx,
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  toStore.Ident.NodeID,
-			StoreID: toStore.Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  toStore.Ident.NodeID,
+				StoreID: toStore.Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -2880,14 +2899,15 @@ func TestReplicaGCRace(t *testing.T) {
 	// Remove the victim replica and manually GC it.
 	if err := repl.ChangeReplicas(
 		ctx,
-		roachpb.REMOVE_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  toStore.Ident.NodeID,
-			StoreID: toStore.Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeOverReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.REMOVE_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  toStore.Ident.NodeID,
+				StoreID: toStore.Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -3887,11 +3907,12 @@ func TestFailedPreemptiveSnapshot(t *testing.T) {
 	const expErr = "snapshot failed: failed to resolve n3: unknown peer 3"
 	if err := rep.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{NodeID: 3, StoreID: 3},
 		rep.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{NodeID: 3, StoreID: 3}),
 	); !testutils.IsError(err, expErr) {
 		t.Fatalf("expected %s; got %v", expErr, err)
 	} else if !storage.IsSnapshotError(err) {
@@ -4205,14 +4226,15 @@ func TestStoreRangeRemovalCompactionSuggestion(t *testing.T) {
 	deleteStore := mtc.stores[2]
 	if err := repl.ChangeReplicas(
 		ctx,
-		roachpb.REMOVE_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  deleteStore.Ident.NodeID,
-			StoreID: deleteStore.Ident.StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRebalance,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.REMOVE_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  deleteStore.Ident.NodeID,
+				StoreID: deleteStore.Ident.StoreID,
+			}),
 	); err != nil {
 		t.Fatal(err)
 	}
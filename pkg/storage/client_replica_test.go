@@ -1637,14 +1637,15 @@ func TestDrainRangeRejection(t *testing.T) {
 	mtc.stores[drainingIdx].SetDraining(true)
 	if err := repl.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  mtc.idents[drainingIdx].NodeID,
-			StoreID: mtc.idents[drainingIdx].StoreID,
-		},
 		repl.Desc(),
 		storagepb.ReasonRangeUnderReplicated,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  mtc.idents[drainingIdx].NodeID,
+				StoreID: mtc.idents[drainingIdx].StoreID,
+			}),
 	); !testutils.IsError(err, "store is draining") {
 		t.Fatalf("unexpected error: %v", err)
 	}
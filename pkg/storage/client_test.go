@@ -1107,11 +1107,12 @@ func (m *multiTestContext) changeReplicas(
 	}
 	for r := retry.Start(retryOpts); r.Next(); {
 		err := m.dbs[0].AdminChangeReplicas(
-			ctx, startKey.AsRawKey(), changeType,
-			[]roachpb.ReplicationTarget{{
-				NodeID:  m.idents[dest].NodeID,
-				StoreID: m.idents[dest].StoreID,
-			}},
+			ctx, startKey.AsRawKey(), roachpb.MakeReplicationChanges(
+				changeType,
+				roachpb.ReplicationTarget{
+					NodeID:  m.idents[dest].NodeID,
+					StoreID: m.idents[dest].StoreID,
+				}),
 		)
 
 		if err == nil || testutils.IsError(err, alreadyDoneErr) {
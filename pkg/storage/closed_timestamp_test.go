@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -209,3 +210,107 @@ CREATE TABLE cttest.kv (id INT PRIMARY KEY, value STRING);
 		}
 	}
 }
+
+// TestClosedTimestampCanServeOnNonVoter verifies that a non-voting replica
+// receives closed timestamp updates from the leaseholder and uses them to
+// serve follower reads, even though it never takes part in write quorums and
+// can't hold the lease itself.
+func TestClosedTimestampCanServeOnNonVoter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	if util.RaceEnabled {
+		// See TestClosedTimestampCanServe.
+		t.Skip("skipping under race")
+	}
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	if _, err := tc.ServerConn(0).Exec(`
+SET CLUSTER SETTING kv.closed_timestamp.target_duration = '300ms';
+SET CLUSTER SETTING kv.closed_timestamp.close_fraction = 0.3;
+SET CLUSTER SETTING kv.closed_timestamp.follower_reads_enabled = true;
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Set up a range with voters on the first two nodes and a non-voter on
+	// the third.
+	key := roachpb.Key("a")
+	if _, _, err := tc.SplitRange(key); err != nil {
+		t.Fatal(err)
+	}
+	desc, err := tc.AddReplicas(key, tc.Target(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonVoterTarget := tc.Target(2)
+	if err := tc.Server(0).DB().AdminChangeReplicas(
+		ctx, key, roachpb.MakeReplicationChanges(roachpb.ADD_NON_VOTER, nonVoterTarget),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if desc, err = tc.LookupRange(key); err != nil {
+		t.Fatal(err)
+	}
+	nonVoters := desc.NonVoters()
+	require.Len(t, nonVoters, 1)
+	require.Equal(t, nonVoterTarget.StoreID, nonVoters[0].StoreID)
+
+	// Transfer the lease to turn it into an epoch based one, which is required
+	// for follower reads (see TestClosedTimestampCanServe).
+	if err := tc.TransferRangeLease(desc, tc.Target(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	var nonVoter *storage.Replica
+	testutils.SucceedsSoon(t, func() error {
+		var err error
+		nonVoter, err = tc.Server(2).GetStores().(*storage.Stores).GetReplicaForRangeID(desc.RangeID)
+		if err != nil {
+			return err
+		}
+		if lease, _ := nonVoter.GetLease(); lease.Epoch == 0 {
+			return errors.Errorf("lease %s is not epoch based yet", lease)
+		}
+		return nil
+	})
+
+	if err := tc.Server(0).DB().Put(ctx, key, "foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	var baRead roachpb.BatchRequest
+	baRead.Header.RangeID = desc.RangeID
+	r := &roachpb.ScanRequest{}
+	r.Key = desc.StartKey.AsRawKey()
+	r.EndKey = desc.EndKey.AsRawKey()
+	baRead.Add(r)
+	baRead.Timestamp = hlc.Timestamp{WallTime: timeutil.Now().UnixNano()}
+
+	// The non-voter can only serve the read once the closed timestamp it
+	// received from the leaseholder's node has passed the read timestamp.
+	testutils.SucceedsSoon(t, func() error {
+		resp, pErr := nonVoter.Send(ctx, baRead)
+		if pErr != nil {
+			if tErr, ok := pErr.GetDetail().(*roachpb.NotLeaseHolderError); ok {
+				return tErr
+			}
+			t.Fatal(errors.Wrapf(pErr.GoError(), "on %s", nonVoter))
+		}
+		rows := resp.Responses[0].GetInner().(*roachpb.ScanResponse).Rows
+		// Should see the write.
+		if len(rows) != 1 {
+			t.Fatalf("expected one row, but got %d", len(rows))
+		}
+		return nil
+	})
+
+	// The read was served as a follower read, not by acquiring the lease.
+	if lease, _ := nonVoter.GetLease(); lease.Replica.StoreID == nonVoterTarget.StoreID {
+		t.Fatalf("non-voter acquired the lease %s", lease)
+	}
+}
@@ -132,6 +132,12 @@ func (meta MVCCMetadata) IsInline() bool {
 	return meta.RawBytes != nil
 }
 
+// IsLockOnly returns true if the metadata describes an intent that was laid
+// down to lock the key rather than to write it. See MVCCMetadata.LockOnly.
+func (meta MVCCMetadata) IsLockOnly() bool {
+	return meta.LockOnly != nil && *meta.LockOnly
+}
+
 // AddToIntentHistory adds the sequence and value to the intent history.
 func (meta *MVCCMetadata) AddToIntentHistory(seq int32, val []byte) {
 	meta.IntentHistory = append(meta.IntentHistory,
@@ -44,3 +44,19 @@ func (op *MVCCLogicalOp) MustSetValue(value interface{}) {
 		panic(fmt.Sprintf("%T excludes %T", op, value))
 	}
 }
+
+// Contains returns whether the given sequence number falls into the range.
+func (r IgnoredSeqNumRange) Contains(seq int32) bool {
+	return r.Start <= seq && seq <= r.End
+}
+
+// TxnSeqIsIgnored returns whether the given sequence number falls into any
+// of the provided ignored ranges.
+func TxnSeqIsIgnored(seq int32, ignored []IgnoredSeqNumRange) bool {
+	for _, r := range ignored {
+		if r.Contains(seq) {
+			return true
+		}
+	}
+	return false
+}
@@ -29,12 +29,18 @@ type GarbageCollector struct {
 	policy    config.GCPolicy
 }
 
+// CalculateThreshold calculates the GC threshold given the policy and the
+// current view of time.
+func CalculateThreshold(now hlc.Timestamp, policy config.GCPolicy) hlc.Timestamp {
+	ttlNanos := int64(policy.TTLSeconds) * 1E9
+	return hlc.Timestamp{WallTime: now.WallTime - ttlNanos}
+}
+
 // MakeGarbageCollector allocates and returns a new GC, with expiration
 // computed based on current time and policy.TTLSeconds.
 func MakeGarbageCollector(now hlc.Timestamp, policy config.GCPolicy) GarbageCollector {
-	ttlNanos := int64(policy.TTLSeconds) * 1E9
 	return GarbageCollector{
-		Threshold: hlc.Timestamp{WallTime: now.WallTime - ttlNanos},
+		Threshold: CalculateThreshold(now, policy),
 		policy:    policy,
 	}
 }
@@ -951,6 +951,9 @@ type putBuffer struct {
 	newMeta enginepb.MVCCMetadata
 	ts      hlc.LegacyTimestamp
 	tmpbuf  []byte
+	// lockOnly is set by MVCCLock to mark the intent written by
+	// mvccPutInternal as a lock-only intent.
+	lockOnly bool
 }
 
 var putBufferPool = sync.Pool{
@@ -1055,6 +1058,50 @@ func MVCCBlindPut(
 	return mvccPutUsingIter(ctx, engine, nil, ms, key, timestamp, value, txn, nil /* valueFn */)
 }
 
+// MVCCLock locks the key on behalf of the transaction by laying down a
+// lock-only intent carrying value, which must be the value of the key's latest
+// committed version as read by the transaction. A lock-only intent conflicts
+// with other transactions like any other intent, but it is removed instead of
+// being committed when its transaction commits, so locking a key does not
+// create a new version of it that rangefeeds and the GC queue would have to
+// process. See MVCCMetadata.LockOnly.
+//
+// If the key already holds an intent of the current epoch of the transaction,
+// the key is already locked and the intent is left untouched, so that a write
+// of the transaction is never turned into a lock-only intent.
+func MVCCLock(
+	ctx context.Context,
+	eng ReadWriter,
+	ms *enginepb.MVCCStats,
+	key roachpb.Key,
+	timestamp hlc.Timestamp,
+	value roachpb.Value,
+	txn *roachpb.Transaction,
+) error {
+	if txn == nil {
+		return errors.Errorf("%q: locks can only be acquired by transactions", key)
+	}
+	if value.Timestamp != (hlc.Timestamp{}) {
+		return errors.Errorf("cannot have timestamp set in value on Lock")
+	}
+	iter := eng.NewIterator(IterOptions{Prefix: true})
+	defer iter.Close()
+
+	buf := newPutBuffer()
+	defer buf.release()
+
+	metaKey := MakeMVCCMetadataKey(key)
+	ok, _, _, err := mvccGetMetadata(iter, metaKey, &buf.meta)
+	if err != nil {
+		return err
+	}
+	if ok && buf.meta.Txn != nil && buf.meta.Txn.ID == txn.ID && buf.meta.Txn.Epoch == txn.Epoch {
+		return nil
+	}
+	buf.lockOnly = true
+	return mvccPutInternal(ctx, eng, iter, ms, key, timestamp, value.RawBytes, txn, buf, nil /* valueFn */)
+}
+
 // MVCCDelete marks the key deleted so that it will not be returned in
 // future get responses.
 //
@@ -1350,6 +1397,10 @@ func mvccPutInternal(
 	buf.newMeta = enginepb.MVCCMetadata{
 		IntentHistory: buf.meta.IntentHistory,
 	}
+	if buf.lockOnly {
+		lockOnly := true
+		buf.newMeta.LockOnly = &lockOnly
+	}
 
 	var maybeTooOldErr error
 	var prevValSize int64
@@ -2249,7 +2300,25 @@ func mvccResolveWriteIntent(
 	// possibility. We treat such intents as uncommitted.
 	epochsMatch := meta.Txn.Epoch == intent.Txn.Epoch
 	timestampsValid := !intent.Txn.Timestamp.Less(hlc.Timestamp(meta.Timestamp))
-	commit := intent.Status == roachpb.COMMITTED && epochsMatch && timestampsValid
+
+	// If the transaction rolled back some of the writes it made to this key
+	// (see ROLLBACK TO SAVEPOINT), rewind the intent to its latest surviving
+	// value before deciding what to do with it. If none of the writes
+	// survive, the intent is aborted below regardless of the status.
+	var rewound, rolledBack bool
+	if epochsMatch && len(intent.IgnoredSeqNums) > 0 {
+		rewound, rolledBack, origMetaKeySize, origMetaValSize, err = mvccRollbackIgnoredWrites(
+			engine, ms, metaKey, meta, origMetaKeySize, origMetaValSize, intent.IgnoredSeqNums, buf,
+		)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// A lock-only intent is removed rather than committed: the key's latest
+	// committed version already carries its value.
+	commit := intent.Status == roachpb.COMMITTED && epochsMatch && timestampsValid && !rolledBack &&
+		!meta.IsLockOnly()
 
 	// Note the small difference to commit epoch handling here: We allow
 	// a push from a previous epoch to move a newer intent. That's not
@@ -2278,7 +2347,7 @@ func mvccResolveWriteIntent(
 	// testing.
 	pushed := intent.Status == roachpb.PENDING &&
 		hlc.Timestamp(meta.Timestamp).Less(intent.Txn.Timestamp) &&
-		meta.Txn.Epoch >= intent.Txn.Epoch
+		meta.Txn.Epoch >= intent.Txn.Epoch && !rolledBack
 
 	// If we're committing, or if the commit timestamp of the intent has been moved forward, and if
 	// the proposed epoch matches the existing epoch: update the meta.Txn. For commit, it's set to
@@ -2375,8 +2444,8 @@ func mvccResolveWriteIntent(
 
 	// There's nothing to do if meta's epoch is greater than or equal txn's epoch
 	// and the state is still PENDING.
-	if intent.Status == roachpb.PENDING && meta.Txn.Epoch >= intent.Txn.Epoch {
-		return false, nil
+	if intent.Status == roachpb.PENDING && meta.Txn.Epoch >= intent.Txn.Epoch && !rolledBack {
+		return rewound, nil
 	}
 
 	// First clear the intent value.
@@ -2432,6 +2501,76 @@ func mvccResolveWriteIntent(
 	return true, nil
 }
 
+// mvccRollbackIgnoredWrites rewinds the intent described by meta so that it
+// no longer reflects writes performed at any of the ignored sequence numbers.
+// Ignored entries are pruned from the intent history and, if the intent's own
+// value was written at an ignored sequence number, the latest surviving value
+// from the history takes its place at the intent's timestamp.
+//
+// Returns whether the intent was rewritten, in which case meta and the
+// returned metadata sizes describe the new intent. If every write the
+// transaction made to the key has been ignored, nothing is rewritten and
+// removeIntent is returned as true; the caller is expected to abort the
+// intent instead.
+func mvccRollbackIgnoredWrites(
+	engine ReadWriter,
+	ms *enginepb.MVCCStats,
+	metaKey MVCCKey,
+	meta *enginepb.MVCCMetadata,
+	origMetaKeySize, origMetaValSize int64,
+	ignored []enginepb.IgnoredSeqNumRange,
+	buf *putBuffer,
+) (rewritten, removeIntent bool, metaKeySize, metaValSize int64, err error) {
+	seqIgnored := enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, ignored)
+	var history []enginepb.MVCCMetadata_SequencedIntent
+	for _, e := range meta.IntentHistory {
+		if !enginepb.TxnSeqIsIgnored(e.Sequence, ignored) {
+			history = append(history, e)
+		}
+	}
+	if !seqIgnored && len(history) == len(meta.IntentHistory) {
+		// Nothing written to this key was rolled back.
+		return false, false, origMetaKeySize, origMetaValSize, nil
+	}
+	if seqIgnored && len(history) == 0 {
+		return false, true, origMetaKeySize, origMetaValSize, nil
+	}
+
+	buf.newMeta = *meta
+	txnMeta := *meta.Txn
+	buf.newMeta.Txn = &txnMeta
+	if seqIgnored {
+		// Restore the latest surviving value. It replaces the current
+		// version in place, so the intent keeps its timestamp.
+		restored := history[len(history)-1]
+		history = history[:len(history)-1]
+		versionKey := MVCCKey{Key: metaKey.Key, Timestamp: hlc.Timestamp(meta.Timestamp)}
+		if err := engine.Put(versionKey, restored.Value); err != nil {
+			return false, false, 0, 0, err
+		}
+		txnMeta.Sequence = restored.Sequence
+		buf.newMeta.ValBytes = int64(len(restored.Value))
+		buf.newMeta.Deleted = len(restored.Value) == 0
+	}
+	buf.newMeta.IntentHistory = history
+
+	metaKeySize, metaValSize, err = buf.putMeta(engine, metaKey, &buf.newMeta)
+	if err != nil {
+		return false, false, 0, 0, err
+	}
+	if ms != nil {
+		ms.Add(updateStatsOnPut(metaKey.Key, 0 /* prevValSize */, origMetaKeySize, origMetaValSize,
+			metaKeySize, metaValSize, meta, &buf.newMeta))
+	}
+	engine.LogLogicalOp(MVCCUpdateIntentOpType, MVCCLogicalOpDetails{
+		Txn:       txnMeta,
+		Key:       metaKey.Key,
+		Timestamp: hlc.Timestamp(meta.Timestamp),
+	})
+	*meta = buf.newMeta
+	return true, false, metaKeySize, metaValSize, nil
+}
+
 // IterAndBuf used to pass iterators and buffers between MVCC* calls, allowing
 // reuse without the callers needing to know the particulars.
 type IterAndBuf struct {
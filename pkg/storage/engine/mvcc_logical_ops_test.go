@@ -15,6 +15,7 @@
 package engine
 
 import (
+	"bytes"
 	"context"
 	"math"
 	"reflect"
@@ -167,3 +168,112 @@ func TestMVCCOpLogWriter(t *testing.T) {
 		t.Errorf("expected logical ops %+v, found %+v", exp, ops)
 	}
 }
+
+// TestMVCCOpLogWriterLockOnlyIntent verifies that committing a lock-only
+// intent does not write a new version of the locked key or emit a commit
+// operation, unless the locking transaction later wrote to the key.
+func TestMVCCOpLogWriterLockOnlyIntent(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	engine := createTestEngine()
+	defer engine.Close()
+
+	batch := engine.NewBatch()
+	ol := NewOpLoggerBatch(batch)
+	defer ol.Close()
+
+	// Write two values, lock both of them, and then write to the second.
+	for _, key := range []roachpb.Key{testKey1, testKey2} {
+		if err := MVCCPut(ctx, ol, nil, key, hlc.Timestamp{Logical: 1}, value1, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txn1ts := makeTxn(*txn1, hlc.Timestamp{Logical: 2})
+	for _, key := range []roachpb.Key{testKey1, testKey2} {
+		if err := MVCCLock(ctx, ol, nil, key, txn1ts.OrigTimestamp, value1, txn1ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Locking a key that is already locked by the transaction is a no-op.
+	if err := MVCCLock(ctx, ol, nil, testKey1, txn1ts.OrigTimestamp, value1, txn1ts); err != nil {
+		t.Fatal(err)
+	}
+	txn1ts.Sequence++
+	if err := MVCCPut(ctx, ol, nil, testKey2, txn1ts.OrigTimestamp, value2, txn1ts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Commit the transaction.
+	txn1CommitTS := *txn1Commit
+	txn1CommitTS.Timestamp = hlc.Timestamp{Logical: 2}
+	if _, _, err := MVCCResolveWriteIntentRange(ctx, ol, nil, roachpb.Intent{
+		Span:   roachpb.Span{Key: testKey1, EndKey: testKey2.Next()},
+		Txn:    txn1CommitTS.TxnMeta,
+		Status: txn1CommitTS.Status,
+	}, math.MaxInt64); err != nil {
+		t.Fatal(err)
+	}
+
+	// The locked key still holds its original version, while the written key
+	// holds the transaction's value.
+	for _, tc := range []struct {
+		key   roachpb.Key
+		ts    hlc.Timestamp
+		value roachpb.Value
+	}{
+		{testKey1, hlc.Timestamp{Logical: 1}, value1},
+		{testKey2, hlc.Timestamp{Logical: 2}, value2},
+	} {
+		val, intent, err := MVCCGet(ctx, ol, tc.key, hlc.Timestamp{Logical: 3}, MVCCGetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if intent != nil {
+			t.Fatalf("%s: unexpected intent %v", tc.key, intent)
+		}
+		if val == nil || val.Timestamp != tc.ts || !bytes.Equal(val.RawBytes, tc.value.RawBytes) {
+			t.Fatalf("%s: expected %v at %s, found %v", tc.key, tc.value, tc.ts, val)
+		}
+	}
+
+	makeOp := func(val interface{}) enginepb.MVCCLogicalOp {
+		var op enginepb.MVCCLogicalOp
+		op.MustSetValue(val)
+		return op
+	}
+	exp := []enginepb.MVCCLogicalOp{
+		makeOp(&enginepb.MVCCWriteValueOp{
+			Key:       testKey1,
+			Timestamp: hlc.Timestamp{Logical: 1},
+		}),
+		makeOp(&enginepb.MVCCWriteValueOp{
+			Key:       testKey2,
+			Timestamp: hlc.Timestamp{Logical: 1},
+		}),
+		makeOp(&enginepb.MVCCWriteIntentOp{
+			TxnID:     txn1.ID,
+			TxnKey:    txn1.Key,
+			Timestamp: hlc.Timestamp{Logical: 2},
+		}),
+		makeOp(&enginepb.MVCCWriteIntentOp{
+			TxnID:     txn1.ID,
+			TxnKey:    txn1.Key,
+			Timestamp: hlc.Timestamp{Logical: 2},
+		}),
+		makeOp(&enginepb.MVCCUpdateIntentOp{
+			TxnID:     txn1.ID,
+			Timestamp: hlc.Timestamp{Logical: 2},
+		}),
+		makeOp(&enginepb.MVCCAbortIntentOp{
+			TxnID: txn1.ID,
+		}),
+		makeOp(&enginepb.MVCCCommitIntentOp{
+			TxnID:     txn1.ID,
+			Key:       testKey2,
+			Timestamp: hlc.Timestamp{Logical: 2},
+		}),
+	}
+	if ops := ol.LogicalOps(); !reflect.DeepEqual(exp, ops) {
+		t.Errorf("expected logical ops %+v, found %+v", exp, ops)
+	}
+}
@@ -3679,6 +3679,87 @@ func TestMVCCResolveWithPushedTimestamp(t *testing.T) {
 	}
 }
 
+// TestMVCCResolveWithIgnoredSeqNums verifies that resolving an intent with
+// ignored sequence numbers rewinds it to its latest write at a sequence
+// number that is not ignored, or removes it if there is no such write.
+func TestMVCCResolveWithIgnoredSeqNums(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	engine := createTestEngine()
+	defer engine.Close()
+
+	var ms enginepb.MVCCStats
+	txn := *txn1
+	for i, v := range []roachpb.Value{value1, value2, value3} {
+		txn.Sequence = int32(i + 1)
+		if err := MVCCPut(ctx, engine, &ms, testKey1, txn.Timestamp, v, &txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := MVCCPut(ctx, engine, &ms, testKey2, txn.Timestamp, value4, &txn); err != nil {
+		t.Fatal(err)
+	}
+
+	// Roll back the writes at sequence numbers 2 and 3.
+	ignored := []enginepb.IgnoredSeqNumRange{{Start: 2, End: 3}}
+	for _, key := range []roachpb.Key{testKey1, testKey2} {
+		if err := MVCCResolveWriteIntent(ctx, engine, &ms, roachpb.Intent{
+			Span:           roachpb.Span{Key: key},
+			Status:         roachpb.PENDING,
+			Txn:            txn.TxnMeta,
+			IgnoredSeqNums: ignored,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertEq(t, engine, "after rollback", &ms, &ms)
+
+	// The intent on key1 was rewound to the first write.
+	value, _, err := MVCCGet(ctx, engine, testKey1, txn.Timestamp, MVCCGetOptions{Txn: &txn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value1.RawBytes, value.RawBytes) {
+		t.Fatalf("expected value %s, got %s", value1.RawBytes, value.RawBytes)
+	}
+	// Its intent history no longer contains the rolled back writes, so reading
+	// at an ignored sequence number returns the surviving value as well.
+	txn.Sequence = 2
+	value, _, err = MVCCGet(ctx, engine, testKey1, txn.Timestamp, MVCCGetOptions{Txn: &txn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value1.RawBytes, value.RawBytes) {
+		t.Fatalf("expected value %s, got %s", value1.RawBytes, value.RawBytes)
+	}
+
+	// The intent on key2 was removed altogether.
+	value, _, err = MVCCGet(ctx, engine, testKey2, txn.Timestamp, MVCCGetOptions{})
+	if value != nil || err != nil {
+		t.Fatalf("expected value nil, err nil; got %+v, %v", value, err)
+	}
+
+	// Committing makes the surviving value permanent.
+	txnCommit := *txn1Commit
+	txnCommit.Sequence = 4
+	if err := MVCCResolveWriteIntent(ctx, engine, &ms, roachpb.Intent{
+		Span:   roachpb.Span{Key: testKey1},
+		Status: txnCommit.Status,
+		Txn:    txnCommit.TxnMeta,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assertEq(t, engine, "after commit", &ms, &ms)
+	value, _, err = MVCCGet(ctx, engine, testKey1, txn.Timestamp, MVCCGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value1.RawBytes, value.RawBytes) {
+		t.Fatalf("expected value %s, got %s", value1.RawBytes, value.RawBytes)
+	}
+}
+
 func TestMVCCResolveTxnNoOps(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -105,8 +105,8 @@ type cleanupIntentsFunc func(context.Context, []roachpb.Intent) error
 
 // A cleanupTxnIntentsFunc asynchronously cleans up intents from a
 // transaction record, pushing the transaction first if it is
-// PENDING. Once all intents are resolved successfully, removes the
-// transaction record.
+// PENDING or STAGING. Once all intents are resolved successfully,
+// removes the transaction record.
 type cleanupTxnIntentsAsyncFunc func(context.Context, *roachpb.Transaction, []roachpb.Intent) error
 
 // gcQueueScore holds details about the score returned by makeGCQueueScoreImpl for
@@ -166,11 +166,19 @@ func makeGCQueueScore(
 
 	desc, zone := repl.DescAndZone()
 
+	// Protected timestamps may prevent the GC threshold from advancing, in
+	// which case there is no point in queueing the replica. Otherwise, the
+	// score is computed as of the timestamp the GC threshold would advance to.
+	canGC, gcTimestamp, _, _ := repl.checkProtectedTimestampsForGC(ctx, now, *zone.GC)
+	if !canGC {
+		return gcQueueScore{}
+	}
+
 	// Use desc.RangeID for fuzzing the final score, so that different ranges
 	// have slightly different priorities and even symmetrical workloads don't
 	// trigger GC at the same time.
 	r := makeGCQueueScoreImpl(
-		ctx, int64(desc.RangeID), now, ms, zone.GC.TTLSeconds,
+		ctx, int64(desc.RangeID), gcTimestamp, ms, zone.GC.TTLSeconds,
 	)
 	if (gcThreshold != hlc.Timestamp{}) {
 		r.LikelyLastGC = time.Duration(now.WallTime - gcThreshold.Add(r.TTL.Nanoseconds(), 0).WallTime)
@@ -365,7 +373,7 @@ func processLocalKeyRange(
 	handleTxnIntents := func(key roachpb.Key, txn *roachpb.Transaction) error {
 		// If the transaction needs to be pushed or there are intents to
 		// resolve, invoke the cleanup function.
-		if txn.Status == roachpb.PENDING || len(txn.Intents) > 0 {
+		if !txn.Status.IsFinalized() || len(txn.Intents) > 0 {
 			return cleanupTxnIntentsAsyncFn(ctx, txn, roachpb.AsIntents(txn.Intents, txn))
 		}
 		gcKeys = append(gcKeys, roachpb.GCRequest_GCKey{Key: key}) // zero timestamp
@@ -386,6 +394,8 @@ func processLocalKeyRange(
 		switch txn.Status {
 		case roachpb.PENDING:
 			infoMu.TransactionSpanGCPending++
+		case roachpb.STAGING:
+			infoMu.TransactionSpanGCStaging++
 		case roachpb.ABORTED:
 			infoMu.TransactionSpanGCAborted++
 		case roachpb.COMMITTED:
@@ -570,7 +580,11 @@ func (gcq *gcQueue) processImpl(
 	// Lookup the descriptor and GC policy for the zone containing this key range.
 	desc, zone := repl.DescAndZone()
 
-	info, err := RunGC(ctx, desc, snap, now, *zone.GC, &replicaGCer{repl: repl},
+	// The new threshold may lag behind the one implied by now and the GC TTL
+	// if data in the range is protected by protected timestamp records.
+	_, _, _, newThreshold := repl.checkProtectedTimestampsForGC(ctx, now, *zone.GC)
+
+	info, err := RunGC(ctx, desc, snap, now, newThreshold, *zone.GC, &replicaGCer{repl: repl},
 		func(ctx context.Context, intents []roachpb.Intent) error {
 			intentCount, err := repl.store.intentResolver.CleanupIntents(ctx, intents, now, roachpb.PUSH_ABORT)
 			if err == nil {
@@ -619,7 +633,8 @@ type GCInfo struct {
 	TransactionSpanTotal int
 	// Summary of transactions which were found GCable (assuming that
 	// potentially necessary intent resolutions did not fail).
-	TransactionSpanGCAborted, TransactionSpanGCCommitted, TransactionSpanGCPending int
+	TransactionSpanGCAborted, TransactionSpanGCCommitted int
+	TransactionSpanGCStaging, TransactionSpanGCPending   int
 	// TxnSpanGCThreshold is the cutoff for transaction span GC. Transactions
 	// with a smaller LastActive() were considered for GC.
 	TxnSpanGCThreshold hlc.Timestamp
@@ -657,6 +672,7 @@ func (info *GCInfo) updateMetrics(metrics *StoreMetrics) {
 	metrics.GCTransactionSpanGCAborted.Inc(int64(info.TransactionSpanGCAborted))
 	metrics.GCTransactionSpanGCCommitted.Inc(int64(info.TransactionSpanGCCommitted))
 	metrics.GCTransactionSpanGCPending.Inc(int64(info.TransactionSpanGCPending))
+	metrics.GCTransactionSpanGCStaging.Inc(int64(info.TransactionSpanGCStaging))
 	metrics.GCAbortSpanScanned.Inc(int64(info.AbortSpanTotal))
 	metrics.GCAbortSpanConsidered.Inc(int64(info.AbortSpanConsidered))
 	metrics.GCAbortSpanGCNum.Inc(int64(info.AbortSpanGCNum))
@@ -686,12 +702,15 @@ type GCer interface {
 // to run garbage collection once on all implicated spans,
 // cleanupIntentsFn to resolve intents synchronously, and
 // cleanupTxnIntentsAsyncFn to asynchronously cleanup intents and
-// associated transaction record on success.
+// associated transaction record on success. User data is garbage collected
+// up to newThreshold, which is usually engine.CalculateThreshold(now, policy)
+// but may be earlier if the data is protected.
 func RunGC(
 	ctx context.Context,
 	desc *roachpb.RangeDescriptor,
 	snap engine.Reader,
 	now hlc.Timestamp,
+	newThreshold hlc.Timestamp,
 	policy config.GCPolicy,
 	gcer GCer,
 	cleanupIntentsFn cleanupIntentsFunc,
@@ -710,6 +729,7 @@ func RunGC(
 	txnExp := now.Add(-storagebase.TxnCleanupThreshold.Nanoseconds(), 0)
 
 	gc := engine.MakeGarbageCollector(now, policy)
+	gc.Threshold = newThreshold
 	infoMu.Threshold = gc.Threshold
 	infoMu.TxnSpanGCThreshold = txnExp
 
@@ -187,9 +187,11 @@ func (ir *IntentResolver) ProcessWriteIntentError(
 	}
 
 	// Possibly queue this processing if the write intent error is for a
-	// single intent affecting a unitary key.
+	// single intent affecting a unitary key. Requests that do not want to wait
+	// on conflicting intents are never queued.
 	var cleanup func(*roachpb.WriteIntentError, *enginepb.TxnMeta)
-	if len(wiErr.Intents) == 1 && len(wiErr.Intents[0].Span.EndKey) == 0 {
+	if len(wiErr.Intents) == 1 && len(wiErr.Intents[0].Span.EndKey) == 0 &&
+		h.WaitPolicy != roachpb.ERROR_ON_CONFLICT {
 		var done bool
 		// Note that the write intent error may be mutated here in the event
 		// that this pusher is queued to wait for a different transaction
@@ -300,6 +302,12 @@ func (ir *IntentResolver) MaybePushIntents(
 		}
 		intent.Txn = pushee.TxnMeta
 		intent.Status = pushee.Status
+		if intent.Status == roachpb.STAGING {
+			// The intents of a STAGING transaction are resolved like those
+			// of a PENDING transaction: they can only be moved forward to
+			// the transaction's timestamp.
+			intent.Status = roachpb.PENDING
+		}
 		resolveIntents = append(resolveIntents, intent)
 	}
 	return resolveIntents, nil
@@ -396,6 +404,66 @@ func (ir *IntentResolver) MaybePushTransactions(
 	return pushedTxns, nil
 }
 
+// RecoverIndeterminateCommit runs the transaction recovery procedure for the
+// transaction in the provided IndeterminateCommitError, which was found in
+// the STAGING status by a push. The transaction is implicitly committed if
+// all of its in-flight writes have succeeded. The procedure queries each
+// in-flight write and prevents the ones that are missing from ever
+// succeeding at the transaction's staging timestamp. It then uses the
+// outcome to move the transaction record to the COMMITTED or ABORTED status.
+// Returns the recovered transaction.
+func (ir *IntentResolver) RecoverIndeterminateCommit(
+	ctx context.Context, ice *roachpb.IndeterminateCommitError,
+) (*roachpb.Transaction, *roachpb.Error) {
+	txn := &ice.StagingTxn
+	log.VEventf(ctx, 2, "recovering txn %s from indeterminate commit", txn.ID.Short())
+
+	// Query all of the transaction's in-flight writes. Any write that is
+	// found to be missing is prevented from ever being written at or below
+	// the staging timestamp, so the transaction can't become implicitly
+	// committed after we decide that it isn't.
+	implicitlyCommitted := true
+	if len(txn.InFlightWrites) > 0 {
+		b := &client.Batch{}
+		for _, w := range txn.InFlightWrites {
+			meta := txn.TxnMeta
+			meta.Sequence = w.Sequence
+			b.AddRawRequest(&roachpb.QueryIntentRequest{
+				RequestHeader: roachpb.RequestHeader{
+					Key: w.Key,
+				},
+				Txn:       meta,
+				IfMissing: roachpb.QueryIntentRequest_PREVENT,
+			})
+		}
+		if err := ir.db.Run(ctx, b); err != nil {
+			return nil, b.MustPErr()
+		}
+		for _, resp := range b.RawResponse().Responses {
+			if !resp.GetInner().(*roachpb.QueryIntentResponse).FoundIntent {
+				implicitlyCommitted = false
+				break
+			}
+		}
+	}
+
+	// Finalize the transaction record based on the outcome of the queries.
+	b := &client.Batch{}
+	b.AddRawRequest(&roachpb.RecoverTxnRequest{
+		RequestHeader: roachpb.RequestHeader{
+			Key: txn.Key,
+		},
+		Txn:                 txn.TxnMeta,
+		ImplicitlyCommitted: implicitlyCommitted,
+	})
+	if err := ir.db.Run(ctx, b); err != nil {
+		return nil, b.MustPErr()
+	}
+	recoveredTxn := &b.RawResponse().Responses[0].GetInner().(*roachpb.RecoverTxnResponse).RecoveredTxn
+	log.VEventf(ctx, 2, "recovered txn %s with status %s", recoveredTxn.ID.Short(), recoveredTxn.Status)
+	return recoveredTxn, nil
+}
+
 // runAsyncTask semi-synchronously runs a generic task function. If
 // there is spare capacity in the limited async task semaphore, it's
 // run asynchronously; otherwise, it's run synchronously if
@@ -550,7 +618,8 @@ func (ir *IntentResolver) lockInFlightTxnCleanup(
 // CleanupTxnIntentsOnGCAsync cleans up extant intents owned by a
 // single transaction, asynchronously (but returning an error if the
 // IntentResolver's semaphore is maxed out). If the transaction is
-// PENDING, but expired, it is pushed first to abort it. onComplete is
+// PENDING or STAGING, but expired, it is pushed first to abort or
+// recover it. onComplete is
 // called if non-nil upon completion of async task with the intention that
 // it be used as a hook to update metrics. It will not be called if an error
 // is returned.
@@ -589,10 +658,11 @@ func (ir *IntentResolver) CleanupTxnIntentsOnGCAsync(
 			}
 			defer release()
 			// If the transaction is still pending, but expired, push it
-			// before resolving the intents.
-			if txn.Status == roachpb.PENDING {
+			// before resolving the intents. A push of an expired STAGING
+			// transaction first recovers its commit status.
+			if !txn.Status.IsFinalized() {
 				if !txnwait.IsExpired(now, txn) {
-					log.VErrEventf(ctx, 3, "cannot push a PENDING transaction which is not expired: %s", txn)
+					log.VErrEventf(ctx, 3, "cannot push a %s transaction which is not expired: %s", txn.Status, txn)
 					return
 				}
 				b := &client.Batch{}
@@ -607,14 +677,14 @@ func (ir *IntentResolver) CleanupTxnIntentsOnGCAsync(
 				})
 				pushed = true
 				if err := ir.db.Run(ctx, b); err != nil {
-					log.VErrEventf(ctx, 2, "failed to push PENDING, expired txn (%s): %s", txn, err)
+					log.VErrEventf(ctx, 2, "failed to push %s, expired txn (%s): %s", txn.Status, txn, err)
 					return
 				}
 				// Get the pushed txn and update the intents slice.
 				txn = &b.RawResponse().Responses[0].GetInner().(*roachpb.PushTxnResponse).PusheeTxn
-				for _, intent := range intents {
-					intent.Txn = txn.TxnMeta
-					intent.Status = txn.Status
+				for i := range intents {
+					intents[i].Txn = txn.TxnMeta
+					intents[i].Status = txn.Status
 				}
 			}
 			var onCleanupComplete func(error)
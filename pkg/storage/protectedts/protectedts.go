@@ -0,0 +1,116 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package protectedts exports the interfaces of the protected timestamp
+// subsystem, which allows clients to prevent the garbage collection of MVCC
+// data in a set of spans at and above a timestamp, independently of the GC
+// TTL configured in the zone configs. Long-running operations which read at a
+// fixed timestamp, like BACKUP or a paused CHANGEFEED, use it to make sure
+// that the data they are going to read remains available.
+//
+// Protected timestamps are persisted as records in the
+// system.protected_ts_records table, which is accessed through the Storage.
+// Each node keeps a periodically refreshed in-memory view of all records, the
+// Cache, which the GC queue consults before advancing the GC threshold of a
+// range: the threshold is never moved to or past the earliest timestamp
+// protected by a record overlapping the range.
+//
+// Note that a record only takes effect once the caches of the nodes holding
+// leases for its spans have observed it. Clients should therefore protect
+// timestamps well before the GC TTL would otherwise expire them, ideally when
+// they pick the timestamp.
+//
+//      +----------+   Protect   +---------+   Refresh   +-------+
+//      |   Jobs   |------------>| Storage |<------------| Cache |
+//      +----------+   Release   +---------+             +-------+
+//                                                           ^
+//                                                  Iterate  |
+//                                                      +----------+
+//                                                      | GC queue |
+//                                                      +----------+
+package protectedts
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/pkg/errors"
+)
+
+// ErrNotExists is returned from Release, UpdateTimestamp and GetRecord when a
+// record does not exist.
+var ErrNotExists = errors.New("protected timestamp record does not exist")
+
+// ErrExists is returned from Protect when a record with the same ID already
+// exists.
+var ErrExists = errors.New("protected timestamp record already exists")
+
+// Provider is the central coordinator of the protected timestamp subsystem.
+// It bundles the Storage and the Cache of a node.
+type Provider interface {
+	Storage
+	Cache
+
+	// Start starts the periodic refresh of the Cache.
+	Start(context.Context, *stop.Stopper) error
+}
+
+// Storage provides transactional access to the protected timestamp records.
+//
+// All methods run in the supplied transaction, which must not be nil, so that
+// clients can create and release records atomically with other changes, like
+// the creation or completion of a job.
+type Storage interface {
+	// Protect persists the record. It fails with ErrExists if a record with
+	// the same ID exists already.
+	Protect(context.Context, *client.Txn, *ptpb.Record) error
+
+	// GetRecord retrieves the record with the given ID. It fails with
+	// ErrNotExists if there is no such record.
+	GetRecord(context.Context, *client.Txn, uuid.UUID) (*ptpb.Record, error)
+
+	// UpdateTimestamp changes the timestamp protected by the record with the
+	// given ID. It fails with ErrNotExists if there is no such record.
+	UpdateTimestamp(context.Context, *client.Txn, uuid.UUID, hlc.Timestamp) error
+
+	// Release removes the record with the given ID. It fails with ErrNotExists
+	// if there is no such record.
+	Release(context.Context, *client.Txn, uuid.UUID) error
+
+	// GetRecords retrieves all of the records.
+	GetRecords(context.Context, *client.Txn) ([]ptpb.Record, error)
+}
+
+// Iterator is used to visit the records of the Cache. Iteration stops once it
+// returns false.
+type Iterator func(*ptpb.Record) (wantMore bool)
+
+// Cache is a node-local, periodically refreshed view of the protected
+// timestamp records.
+type Cache interface {
+	// Iterate visits the records which overlap the span [from, to). It returns
+	// the timestamp as of which the view of the Cache is accurate; records
+	// created after it may be missing. The zero timestamp is returned if the
+	// Cache has not been populated yet.
+	Iterate(_ context.Context, from, to roachpb.Key, it Iterator) (asOf hlc.Timestamp)
+
+	// Refresh forces the Cache to update its view to at least the given
+	// timestamp.
+	Refresh(_ context.Context, asOf hlc.Timestamp) error
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package ptcache implements protectedts.Cache by periodically reading all of
+// the protected timestamp records through a protectedts.Storage.
+package ptcache
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil/singleflight"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// Config configures a Cache.
+type Config struct {
+	DB       *client.DB
+	Storage  protectedts.Storage
+	Settings *cluster.Settings
+}
+
+// Cache implements protectedts.Cache.
+type Cache struct {
+	db       *client.DB
+	storage  protectedts.Storage
+	settings *cluster.Settings
+
+	// sf is used to deduplicate concurrent refreshes.
+	sf singleflight.Group
+
+	mu struct {
+		syncutil.RWMutex
+
+		// readAt is the timestamp as of which records is accurate.
+		readAt  hlc.Timestamp
+		records []ptpb.Record
+	}
+}
+
+var _ protectedts.Cache = (*Cache)(nil)
+
+// New creates a new Cache which has yet to be started.
+func New(cfg Config) *Cache {
+	return &Cache{
+		db:       cfg.DB,
+		storage:  cfg.Storage,
+		settings: cfg.Settings,
+	}
+}
+
+// Start starts the periodic refresh of the Cache.
+func (c *Cache) Start(ctx context.Context, stopper *stop.Stopper) error {
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		c.periodicallyRefresh(ctx, stopper)
+	})
+	return nil
+}
+
+func (c *Cache) periodicallyRefresh(ctx context.Context, stopper *stop.Stopper) {
+	timer := timeutil.NewTimer()
+	defer timer.Stop()
+	timer.Reset(0)
+	for {
+		select {
+		case <-timer.C:
+			timer.Read = true
+			if err := c.doRefresh(ctx); err != nil {
+				log.Warningf(ctx, "failed to refresh protected timestamps: %v", err)
+			}
+			timer.Reset(protectedts.PollInterval.Get(&c.settings.SV))
+		case <-stopper.ShouldQuiesce():
+			return
+		}
+	}
+}
+
+// Iterate implements protectedts.Cache.
+func (c *Cache) Iterate(
+	_ context.Context, from, to roachpb.Key, it protectedts.Iterator,
+) (asOf hlc.Timestamp) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	sp := roachpb.Span{Key: from, EndKey: to}
+	for i := range c.mu.records {
+		r := &c.mu.records[i]
+		for _, rSp := range r.Spans {
+			if !sp.Overlaps(rSp) {
+				continue
+			}
+			if !it(r) {
+				return c.mu.readAt
+			}
+			break
+		}
+	}
+	return c.mu.readAt
+}
+
+// Refresh implements protectedts.Cache.
+func (c *Cache) Refresh(ctx context.Context, asOf hlc.Timestamp) error {
+	for c.readAt().Less(asOf) {
+		if err := c.doRefresh(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache) readAt() hlc.Timestamp {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mu.readAt
+}
+
+// doRefresh reads all of the records and replaces the state of the Cache
+// with them. Concurrent calls share a single read.
+func (c *Cache) doRefresh(ctx context.Context) error {
+	_, _, err := c.sf.Do("refresh", func() (interface{}, error) {
+		// Before the cluster version is active the table may not exist yet,
+		// and no records can have been created.
+		if !c.settings.Version.IsActive(cluster.VersionProtectedTimestamps) {
+			c.setRecords(c.db.Clock().Now(), nil)
+			return nil, nil
+		}
+		var readAt hlc.Timestamp
+		var records []ptpb.Record
+		if err := c.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) (err error) {
+			readAt = txn.OrigTimestamp()
+			records, err = c.storage.GetRecords(ctx, txn)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		c.setRecords(readAt, records)
+		return nil, nil
+	})
+	return err
+}
+
+func (c *Cache) setRecords(readAt hlc.Timestamp, records []ptpb.Record) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if readAt.Less(c.mu.readAt) {
+		return
+	}
+	c.mu.readAt = readAt
+	c.mu.records = records
+}
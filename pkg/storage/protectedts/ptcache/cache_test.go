@@ -0,0 +1,93 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ptcache_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptcache"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptstorage"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	pts := ptstorage.New(s.InternalExecutor().(sqlutil.InternalExecutor), s.Gossip())
+	c := ptcache.New(ptcache.Config{
+		DB:       kvDB,
+		Storage:  pts,
+		Settings: s.ClusterSettings(),
+	})
+
+	// iterate returns the IDs of the records overlapping [from, to).
+	iterate := func(from, to string) (asOf hlc.Timestamp, ids []uuid.UUID) {
+		asOf = c.Iterate(ctx, roachpb.Key(from), roachpb.Key(to), func(r *ptpb.Record) bool {
+			ids = append(ids, r.ID)
+			return true
+		})
+		return asOf, ids
+	}
+
+	// The cache is empty until it is refreshed.
+	asOf, ids := iterate("a", "z")
+	require.Equal(t, hlc.Timestamp{}, asOf)
+	require.Empty(t, ids)
+
+	rec := ptpb.Record{
+		ID:        uuid.MakeV4(),
+		Timestamp: s.Clock().Now(),
+		Spans: []roachpb.Span{
+			{Key: roachpb.Key("b"), EndKey: roachpb.Key("c")},
+			{Key: roachpb.Key("d"), EndKey: roachpb.Key("e")},
+		},
+	}
+	require.NoError(t, kvDB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		return pts.Protect(ctx, txn, &rec)
+	}))
+
+	now := s.Clock().Now()
+	require.NoError(t, c.Refresh(ctx, now))
+	asOf, ids = iterate("a", "z")
+	require.False(t, asOf.Less(now))
+	require.Equal(t, []uuid.UUID{rec.ID}, ids)
+
+	// Each record is visited once, even if several of its spans overlap.
+	_, ids = iterate("b", "e")
+	require.Equal(t, []uuid.UUID{rec.ID}, ids)
+	_, ids = iterate("c", "d")
+	require.Empty(t, ids)
+
+	// Released records disappear after the next refresh.
+	require.NoError(t, kvDB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		return pts.Release(ctx, txn, rec.ID)
+	}))
+	require.NoError(t, c.Refresh(ctx, s.Clock().Now()))
+	_, ids = iterate("a", "z")
+	require.Empty(t, ids)
+}
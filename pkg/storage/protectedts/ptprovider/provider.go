@@ -0,0 +1,59 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package ptprovider encapsulates the concrete implementation of the
+// protectedts.Provider.
+package ptprovider
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptcache"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptstorage"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+)
+
+// Config configures the Provider.
+type Config struct {
+	Settings         *cluster.Settings
+	DB               *client.DB
+	InternalExecutor sqlutil.InternalExecutor
+	SystemConfig     ptstorage.SystemConfigProvider
+}
+
+type provider struct {
+	protectedts.Storage
+	*ptcache.Cache
+}
+
+// New creates a new protectedts.Provider.
+func New(cfg Config) protectedts.Provider {
+	storage := ptstorage.New(cfg.InternalExecutor, cfg.SystemConfig)
+	return &provider{
+		Storage: storage,
+		Cache: ptcache.New(ptcache.Config{
+			DB:       cfg.DB,
+			Storage:  storage,
+			Settings: cfg.Settings,
+		}),
+	}
+}
+
+func (p *provider) Start(ctx context.Context, stopper *stop.Stopper) error {
+	return p.Cache.Start(ctx, stopper)
+}
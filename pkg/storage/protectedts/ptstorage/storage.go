@@ -0,0 +1,246 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package ptstorage implements protectedts.Storage on top of the
+// system.protected_ts_records table.
+package ptstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	protectQuery = `
+INSERT INTO system.protected_ts_records (id, ts, meta_type, meta, spans)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING`
+
+	getRecordQuery = `
+SELECT id, ts, meta_type, meta, spans FROM system.protected_ts_records WHERE id = $1`
+
+	getRecordsQuery = `
+SELECT id, ts, meta_type, meta, spans FROM system.protected_ts_records`
+
+	updateTimestampQuery = `
+UPDATE system.protected_ts_records SET ts = $2 WHERE id = $1`
+
+	releaseQuery = `
+DELETE FROM system.protected_ts_records WHERE id = $1`
+)
+
+// SystemConfigProvider provides access to the latest system config, from
+// which the GC TTL of the protected spans is determined.
+type SystemConfigProvider interface {
+	GetSystemConfig() *config.SystemConfig
+}
+
+// storage implements protectedts.Storage.
+type storage struct {
+	ex  sqlutil.InternalExecutor
+	cfg SystemConfigProvider
+}
+
+var _ protectedts.Storage = (*storage)(nil)
+
+// New creates a new Storage which uses the supplied InternalExecutor to access
+// the records.
+func New(ex sqlutil.InternalExecutor, cfg SystemConfigProvider) protectedts.Storage {
+	return &storage{ex: ex, cfg: cfg}
+}
+
+func (p *storage) Protect(ctx context.Context, txn *client.Txn, r *ptpb.Record) error {
+	if err := validateRecord(r); err != nil {
+		return err
+	}
+	if err := p.verifyTimestampNotCollected(ctx, txn, r); err != nil {
+		return err
+	}
+	encodedSpans, err := protoutil.Marshal(&ptpb.Spans{Spans: r.Spans})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal spans")
+	}
+	meta := tree.DNull
+	if r.Meta != nil {
+		meta = tree.NewDBytes(tree.DBytes(r.Meta))
+	}
+	n, err := p.ex.Exec(ctx, "protectedts-protect", txn, protectQuery,
+		tree.NewDUuid(tree.DUuid{UUID: r.ID}),
+		tree.TimestampToDecimal(r.Timestamp),
+		r.MetaType,
+		meta,
+		tree.NewDBytes(tree.DBytes(encodedSpans)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to write record %v", r.ID)
+	}
+	if n == 0 {
+		return protectedts.ErrExists
+	}
+	return nil
+}
+
+// verifyTimestampNotCollected ensures that the data in the spans of the record
+// is still readable at the record's timestamp when the record takes effect.
+// The GC threshold of a range never exceeds the current time less the GC TTL
+// of its zone, so a record which commits no later than its timestamp plus the
+// smallest GC TTL of its spans protects data which has not been collected. The
+// commit of txn is bounded accordingly, and a record which could not commit in
+// time is rejected.
+func (p *storage) verifyTimestampNotCollected(
+	ctx context.Context, txn *client.Txn, r *ptpb.Record,
+) error {
+	ttl, err := p.minGCTTL(r.Spans)
+	if err != nil {
+		return err
+	}
+	deadline := r.Timestamp.Add(ttl.Nanoseconds(), 0)
+	if deadline.Less(txn.OrigTimestamp()) {
+		return errors.Errorf(
+			"cannot protect timestamp %s which is older than the GC TTL of %s", r.Timestamp, ttl)
+	}
+	txn.UpdateDeadlineMaybe(ctx, deadline)
+	return nil
+}
+
+// minGCTTL returns the smallest GC TTL of the zones of the spans, as
+// determined by the zone of the start key of each span.
+func (p *storage) minGCTTL(spans []roachpb.Span) (time.Duration, error) {
+	cfg := p.cfg.GetSystemConfig()
+	if cfg == nil {
+		return 0, errors.New("system config not yet available")
+	}
+	var ttl time.Duration
+	for i, sp := range spans {
+		key, err := keys.Addr(sp.Key)
+		if err != nil {
+			return 0, err
+		}
+		zone, err := cfg.GetZoneConfigForKey(key)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get zone config for span %s", sp)
+		}
+		if spanTTL := time.Duration(zone.GC.TTLSeconds) * time.Second; i == 0 || spanTTL < ttl {
+			ttl = spanTTL
+		}
+	}
+	return ttl, nil
+}
+
+func (p *storage) GetRecord(
+	ctx context.Context, txn *client.Txn, id uuid.UUID,
+) (*ptpb.Record, error) {
+	row, err := p.ex.QueryRow(ctx, "protectedts-get-record", txn, getRecordQuery,
+		tree.NewDUuid(tree.DUuid{UUID: id}))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read record %v", id)
+	}
+	if row == nil {
+		return nil, protectedts.ErrNotExists
+	}
+	var r ptpb.Record
+	if err := rowToRecord(row, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (p *storage) UpdateTimestamp(
+	ctx context.Context, txn *client.Txn, id uuid.UUID, ts hlc.Timestamp,
+) error {
+	if ts == (hlc.Timestamp{}) {
+		return errors.Errorf("cannot protect the zero timestamp")
+	}
+	n, err := p.ex.Exec(ctx, "protectedts-update-timestamp", txn, updateTimestampQuery,
+		tree.NewDUuid(tree.DUuid{UUID: id}), tree.TimestampToDecimal(ts))
+	if err != nil {
+		return errors.Wrapf(err, "failed to update record %v", id)
+	}
+	if n == 0 {
+		return protectedts.ErrNotExists
+	}
+	return nil
+}
+
+func (p *storage) Release(ctx context.Context, txn *client.Txn, id uuid.UUID) error {
+	n, err := p.ex.Exec(ctx, "protectedts-release", txn, releaseQuery,
+		tree.NewDUuid(tree.DUuid{UUID: id}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to release record %v", id)
+	}
+	if n == 0 {
+		return protectedts.ErrNotExists
+	}
+	return nil
+}
+
+func (p *storage) GetRecords(ctx context.Context, txn *client.Txn) ([]ptpb.Record, error) {
+	rows, _ /* cols */, err := p.ex.Query(ctx, "protectedts-get-records", txn, getRecordsQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read records")
+	}
+	records := make([]ptpb.Record, len(rows))
+	for i, row := range rows {
+		if err := rowToRecord(row, &records[i]); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// rowToRecord decodes a row of the system.protected_ts_records table, as
+// returned by getRecordQuery and getRecordsQuery, into r.
+func rowToRecord(row tree.Datums, r *ptpb.Record) error {
+	r.ID = row[0].(*tree.DUuid).UUID
+	ts, err := tree.DecimalToHLC(&row[1].(*tree.DDecimal).Decimal)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse timestamp of record %v", r.ID)
+	}
+	r.Timestamp = ts
+	r.MetaType = string(*row[2].(*tree.DString))
+	if meta, ok := row[3].(*tree.DBytes); ok {
+		r.Meta = []byte(*meta)
+	}
+	var spans ptpb.Spans
+	if err := protoutil.Unmarshal([]byte(*row[4].(*tree.DBytes)), &spans); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal spans of record %v", r.ID)
+	}
+	r.Spans = spans.Spans
+	return nil
+}
+
+func validateRecord(r *ptpb.Record) error {
+	if r.ID == uuid.Nil {
+		return errors.Errorf("invalid nil ID in record")
+	}
+	if r.Timestamp == (hlc.Timestamp{}) {
+		return errors.Errorf("invalid zero timestamp in record %v", r.ID)
+	}
+	if len(r.Spans) == 0 {
+		return errors.Errorf("invalid empty set of spans in record %v", r.ID)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ptstorage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptstorage"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	pts := ptstorage.New(s.InternalExecutor().(sqlutil.InternalExecutor), s.Gossip())
+	inTxn := func(fn func(context.Context, *client.Txn) error) error {
+		return kvDB.Txn(ctx, fn)
+	}
+
+	now := s.Clock().Now()
+	rec := ptpb.Record{
+		ID:        uuid.MakeV4(),
+		Timestamp: now,
+		MetaType:  "test",
+		Meta:      []byte("meta"),
+		Spans: []roachpb.Span{
+			{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")},
+			{Key: roachpb.Key("c"), EndKey: roachpb.Key("d")},
+		},
+	}
+	require.NoError(t, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.Protect(ctx, txn, &rec)
+	}))
+
+	// Protecting the same record twice fails.
+	require.Equal(t, protectedts.ErrExists, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.Protect(ctx, txn, &rec)
+	}))
+
+	getRecord := func(id uuid.UUID) (got *ptpb.Record, err error) {
+		err = inTxn(func(ctx context.Context, txn *client.Txn) (err error) {
+			got, err = pts.GetRecord(ctx, txn, id)
+			return err
+		})
+		return got, err
+	}
+	got, err := getRecord(rec.ID)
+	require.NoError(t, err)
+	require.Equal(t, rec, *got)
+
+	// Records without meta are supported.
+	noMeta := ptpb.Record{
+		ID:        uuid.MakeV4(),
+		Timestamp: now.Add(1, 0),
+		Spans:     []roachpb.Span{{Key: roachpb.Key("e"), EndKey: roachpb.Key("f")}},
+	}
+	require.NoError(t, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.Protect(ctx, txn, &noMeta)
+	}))
+	got, err = getRecord(noMeta.ID)
+	require.NoError(t, err)
+	require.Equal(t, noMeta, *got)
+
+	var records []ptpb.Record
+	require.NoError(t, inTxn(func(ctx context.Context, txn *client.Txn) (err error) {
+		records, err = pts.GetRecords(ctx, txn)
+		return err
+	}))
+	require.Len(t, records, 2)
+
+	// Update the timestamp of the first record.
+	rec.Timestamp = now.Add(2, 0)
+	require.NoError(t, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.UpdateTimestamp(ctx, txn, rec.ID, rec.Timestamp)
+	}))
+	got, err = getRecord(rec.ID)
+	require.NoError(t, err)
+	require.Equal(t, rec, *got)
+
+	// Release the first record; it cannot be released or updated again.
+	require.NoError(t, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.Release(ctx, txn, rec.ID)
+	}))
+	require.Equal(t, protectedts.ErrNotExists, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.Release(ctx, txn, rec.ID)
+	}))
+	require.Equal(t, protectedts.ErrNotExists, inTxn(func(ctx context.Context, txn *client.Txn) error {
+		return pts.UpdateTimestamp(ctx, txn, rec.ID, hlc.Timestamp{WallTime: 6})
+	}))
+	_, err = getRecord(rec.ID)
+	require.Equal(t, protectedts.ErrNotExists, err)
+}
+
+func TestStorageValidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	pts := ptstorage.New(s.InternalExecutor().(sqlutil.InternalExecutor), s.Gossip())
+	spans := []roachpb.Span{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}}
+	for _, tc := range []struct {
+		rec ptpb.Record
+		err string
+	}{
+		{ptpb.Record{Timestamp: hlc.Timestamp{WallTime: 1}, Spans: spans}, "invalid nil ID"},
+		{ptpb.Record{ID: uuid.MakeV4(), Spans: spans}, "invalid zero timestamp"},
+		{
+			ptpb.Record{ID: uuid.MakeV4(), Timestamp: hlc.Timestamp{WallTime: 1}},
+			"invalid empty set of spans",
+		},
+		{
+			ptpb.Record{ID: uuid.MakeV4(), Timestamp: hlc.Timestamp{WallTime: 1}, Spans: spans},
+			"older than the GC TTL",
+		},
+	} {
+		err := kvDB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			return pts.Protect(ctx, txn, &tc.rec)
+		})
+		if !testutils.IsError(err, tc.err) {
+			t.Errorf("expected error %q, got %v", tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package protectedts
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+)
+
+// PollInterval is the interval at which the Cache refreshes its view of the
+// protected timestamp records.
+var PollInterval = settings.RegisterNonNegativeDurationSetting(
+	"kv.protectedts.poll_interval",
+	"the interval at which the protected timestamp records are polled by each node",
+	2*time.Minute,
+)
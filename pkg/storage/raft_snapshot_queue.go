@@ -24,7 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/pkg/errors"
-	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 const (
@@ -70,7 +70,7 @@ func (rq *raftSnapshotQueue) shouldQueue(
 	if status := repl.RaftStatus(); status != nil {
 		// raft.Status.Progress is only populated on the Raft group leader.
 		for _, p := range status.Progress {
-			if p.State == raft.ProgressStateSnapshot {
+			if p.State == tracker.StateSnapshot {
 				if log.V(2) {
 					log.Infof(ctx, "raft snapshot needed, enqueuing")
 				}
@@ -88,7 +88,7 @@ func (rq *raftSnapshotQueue) process(
 	if status := repl.RaftStatus(); status != nil {
 		// raft.Status.Progress is only populated on the Raft group leader.
 		for id, p := range status.Progress {
-			if p.State == raft.ProgressStateSnapshot {
+			if p.State == tracker.StateSnapshot {
 				if log.V(1) {
 					log.Infof(ctx, "sending raft snapshot")
 				}
@@ -109,6 +109,18 @@ func (rq *raftSnapshotQueue) processRaftSnapshot(
 	if !ok {
 		return errors.Errorf("%s: replica %d not present in %v", repl, id, desc.Replicas)
 	}
+	if repDesc.GetType() == roachpb.ReplicaType_LEARNER {
+		// Learners are caught up with a snapshot sent by the replica which
+		// added them (see Replica.addReplicaViaLearner). Sending a second
+		// snapshot concurrently would only waste resources. A learner that
+		// is left behind is removed by the replicate queue. Note that demoting
+		// voters are not learners: they still vote in the outgoing half of a
+		// joint configuration, so they are caught up like any other voter.
+		if log.V(1) {
+			log.Infof(ctx, "skipping snapshot; replica %s is a learner", repDesc)
+		}
+		return nil
+	}
 	err := repl.sendSnapshot(ctx, repDesc, snapTypeRaft, SnapshotRequest_RECOVERY)
 
 	// NB: if the snapshot fails because of an overlapping replica on the
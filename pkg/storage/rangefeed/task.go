@@ -182,7 +182,7 @@ func (a *txnPushAttempt) pushOldTxns(ctx context.Context) error {
 	var toCleanup []roachpb.Transaction
 	for i, txn := range pushedTxns {
 		switch txn.Status {
-		case roachpb.PENDING:
+		case roachpb.PENDING, roachpb.STAGING:
 			// The intent is still pending but its timestamp was moved forward to
 			// the current time. Inform the Processor that it can forward the txn's
 			// timestamp in its unresolvedIntentQueue.
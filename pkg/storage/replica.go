@@ -843,7 +843,8 @@ func (r *Replica) RaftStatus() *raft.Status {
 
 func (r *Replica) raftStatusRLocked() *raft.Status {
 	if rg := r.mu.internalRaftGroup; rg != nil {
-		return rg.Status()
+		s := rg.Status()
+		return &s
 	}
 	return nil
 }
@@ -1203,14 +1204,7 @@ func (r *Replica) executeAdminBatch(
 		resp = &roachpb.AdminTransferLeaseResponse{}
 
 	case *roachpb.AdminChangeReplicasRequest:
-		var err error
-		for _, target := range tArgs.Targets {
-			err = r.ChangeReplicas(
-				ctx, tArgs.ChangeType, target, r.Desc(), storagepb.ReasonAdminRequest, "")
-			if err != nil {
-				break
-			}
-		}
+		err := r.ChangeReplicas(ctx, r.Desc(), storagepb.ReasonAdminRequest, "", tArgs.Changes())
 		pErr = roachpb.NewError(err)
 		resp = &roachpb.AdminChangeReplicasResponse{}
 
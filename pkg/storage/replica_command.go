@@ -27,6 +27,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
@@ -39,6 +41,16 @@ import (
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
+	"go.etcd.io/etcd/raft/tracker"
+)
+
+// useLearnerReplicas specifies whether replicas are added to ranges as Raft
+// learners, which are promoted to voters once caught up, instead of being
+// added as voters directly after a preemptive snapshot.
+var useLearnerReplicas = settings.RegisterBoolSetting(
+	"kv.learner_replicas.enabled",
+	"use learner replicas for replica addition",
+	true,
 )
 
 // AdminSplit divides the range into into two ranges using args.SplitKey.
@@ -115,17 +127,17 @@ func splitSnapshotWarningStr(rangeID roachpb.RangeID, status *raft.Status) strin
 				// https://github.com/etcd-io/etcd/pull/10279
 				continue
 			}
-			if pr.State == raft.ProgressStateReplicate {
+			if pr.State == tracker.StateReplicate {
 				// This follower is in good working order.
 				continue
 			}
 			s += fmt.Sprintf("; r%d/%d is ", rangeID, replicaID)
 			switch pr.State {
-			case raft.ProgressStateSnapshot:
+			case tracker.StateSnapshot:
 				// If the Raft snapshot queue is backed up, replicas can spend
 				// minutes or worse until they are caught up.
 				s += "waiting for a Raft snapshot"
-			case raft.ProgressStateProbe:
+			case tracker.StateProbe:
 				// Assuming the split has already been delayed for a little bit,
 				// seeing a follower that is probing hints at some problem with
 				// Raft or Raft message delivery. (Of course it's possible that
@@ -616,9 +628,10 @@ func IsSnapshotError(err error) bool {
 	})
 }
 
-// ChangeReplicas adds or removes a replica of a range. The change is performed
-// in a distributed transaction and takes effect when that transaction is committed.
-// When removing a replica, only the NodeID and StoreID fields of the Replica are used.
+// ChangeReplicas adds and removes replicas of a range. Each change is
+// performed in a distributed transaction and takes effect when that
+// transaction is committed. Multiple changes are carried out atomically (see
+// changeReplicasAtomic) if the cluster version permits it.
 //
 // The supplied RangeDescriptor is used as a form of optimistic lock. See the
 // comment of "adminSplitWithDescriptor" for more information on this pattern.
@@ -685,23 +698,102 @@ func IsSnapshotError(err error) bool {
 // to date via Raft log replay. In this scenario, the reservation will be left
 // dangling until it expires. See #7849.
 //
-// TODO(peter): Describe preemptive snapshots. Preemptive snapshots are needed
-// for the replicate queue to function properly. Currently the replicate queue
-// will fire off as many replica additions as possible until it starts getting
-// reservations denied at which point it will ignore the replica until the next
-// scanner cycle.
+// A replica is added in three steps. It is first added to the range as a Raft
+// learner, which receives the Raft log but does not vote and so can't affect
+// the availability of the range. The learner is then caught up using a Raft
+// snapshot, sent by the replica performing the change. Finally, it is promoted
+// to a voter using a second ChangeReplicas transaction. If any of the later
+// steps fail, the learner is removed again. Learners left behind by a crash are
+// removed by the replicate queue.
+//
+// When several replicas are changed at once, for example to swap a replica
+// for another one, the new replicas are first added as learners. A single
+// transaction then moves the range into a Raft joint configuration, in which
+// decisions require a quorum of both the old and the new set of voters. A
+// final transaction leaves the joint configuration, after which the removed
+// voters are learners and are removed. A joint configuration left behind by a
+// crash is finished by the replicate queue.
+//
+// Non-voting replicas (ADD_NON_VOTER and REMOVE_NON_VOTER) don't affect the
+// quorum, so they are always added and removed one at a time, before any
+// changes to the voters. A non-voter is added like a learner which is never
+// promoted.
+//
+// Before learners were supported (and when kv.learner_replicas.enabled is
+// off), a replica was added as a voter directly after being sent a preemptive
+// snapshot. A preemptive snapshot is addressed to a replica which is not yet
+// part of the range and so has no replica ID. This makes it invisible to the
+// rest of the system: it can be garbage collected before the replica is
+// added, it is not tracked by the Raft log queue, and the new voter counts
+// towards the quorum before it has caught up.
 func (r *Replica) ChangeReplicas(
 	ctx context.Context,
-	changeType roachpb.ReplicaChangeType,
-	target roachpb.ReplicationTarget,
 	desc *roachpb.RangeDescriptor,
 	reason storagepb.RangeLogEventReason,
 	details string,
+	chgs roachpb.ReplicationChanges,
 ) error {
-	return r.changeReplicas(ctx, changeType, target, desc, SnapshotRequest_REBALANCE, reason, details)
+	return r.changeReplicas(ctx, desc, SnapshotRequest_REBALANCE, reason, details, chgs)
 }
 
 func (r *Replica) changeReplicas(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason storagepb.RangeLogEventReason,
+	details string,
+	chgs roachpb.ReplicationChanges,
+) error {
+	if len(chgs) == 0 {
+		return errors.Errorf("%s: no replication changes requested", r)
+	}
+	// A previous atomic replication change may have left the range in a joint
+	// configuration. Finish it before starting a new one.
+	if desc.InAtomicReplicationChange() {
+		var err error
+		if desc, err = r.maybeLeaveAtomicChangeReplicas(ctx, desc, reason, details); err != nil {
+			return err
+		}
+	}
+
+	var voterChgs roachpb.ReplicationChanges
+	for _, chg := range chgs {
+		switch chg.ChangeType {
+		case roachpb.ADD_NON_VOTER, roachpb.REMOVE_NON_VOTER:
+			var err error
+			desc, err = r.changeReplica(ctx, chg.ChangeType, chg.Target, desc, priority, reason, details)
+			if err != nil {
+				return err
+			}
+		default:
+			voterChgs = append(voterChgs, chg)
+		}
+	}
+	chgs = voterChgs
+	if len(chgs) == 0 {
+		return nil
+	}
+
+	st := r.store.ClusterSettings()
+	if len(chgs) > 1 && useLearnerReplicas.Get(&st.SV) &&
+		st.Version.IsActive(cluster.VersionAtomicChangeReplicas) {
+		return r.changeReplicasAtomic(ctx, desc, priority, reason, details, chgs)
+	}
+
+	// Carry out the changes one at a time.
+	for _, chg := range chgs {
+		var err error
+		desc, err = r.changeReplica(ctx, chg.ChangeType, chg.Target, desc, priority, reason, details)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeReplica adds or removes a single replica, returning the updated range
+// descriptor.
+func (r *Replica) changeReplica(
 	ctx context.Context,
 	changeType roachpb.ReplicaChangeType,
 	target roachpb.ReplicationTarget,
@@ -709,7 +801,7 @@ func (r *Replica) changeReplicas(
 	priority SnapshotRequest_Priority,
 	reason storagepb.RangeLogEventReason,
 	details string,
-) error {
+) (*roachpb.RangeDescriptor, error) {
 	repDesc := roachpb.ReplicaDescriptor{
 		NodeID:  target.NodeID,
 		StoreID: target.StoreID,
@@ -727,19 +819,29 @@ func (r *Replica) changeReplicas(
 		}
 	}
 
-	rangeID := desc.RangeID
 	updatedDesc := *desc
 	updatedDesc.Replicas = append([]roachpb.ReplicaDescriptor(nil), desc.Replicas...)
 
 	switch changeType {
-	case roachpb.ADD_REPLICA:
+	case roachpb.ADD_REPLICA, roachpb.ADD_NON_VOTER:
 		// If the replica exists on the remote node, no matter in which store,
 		// abort the replica add.
 		if nodeUsed {
 			if repDescIdx != -1 {
-				return errors.Errorf("%s: unable to add replica %v which is already present", r, repDesc)
+				return nil, errors.Errorf("%s: unable to add replica %v which is already present", r, repDesc)
 			}
-			return errors.Errorf("%s: unable to add replica %v; node already has a replica", r, repDesc)
+			return nil, errors.Errorf("%s: unable to add replica %v; node already has a replica", r, repDesc)
+		}
+
+		st := r.store.ClusterSettings()
+		if changeType == roachpb.ADD_NON_VOTER {
+			if !st.Version.IsActive(cluster.VersionNonVotingReplicas) {
+				return nil, errors.Errorf("%s: cluster version does not support non-voting replicas", r)
+			}
+			return r.addNonVoterReplica(ctx, repDesc, desc, priority, reason, details)
+		}
+		if useLearnerReplicas.Get(&st.SV) && st.Version.IsActive(cluster.VersionLearnerReplicas) {
+			return r.addReplicaViaLearner(ctx, repDesc, desc, priority, reason, details)
 		}
 
 		// Send a pre-emptive snapshot. Note that the replica to which this
@@ -765,23 +867,346 @@ func (r *Replica) changeReplicas(
 		// progress which might be required for this ChangeReplicas operation to
 		// complete. See #10409.
 		if err := r.sendSnapshot(ctx, repDesc, snapTypePreemptive, priority); err != nil {
-			return err
+			return nil, err
 		}
 
 		repDesc.ReplicaID = updatedDesc.NextReplicaID
 		updatedDesc.NextReplicaID++
 		updatedDesc.Replicas = append(updatedDesc.Replicas, repDesc)
 
-	case roachpb.REMOVE_REPLICA:
+	case roachpb.REMOVE_REPLICA, roachpb.REMOVE_NON_VOTER:
 		// If that exact node-store combination does not have the replica,
 		// abort the removal.
 		if repDescIdx == -1 {
-			return errors.Errorf("%s: unable to remove replica %v which is not present", r, repDesc)
+			return nil, errors.Errorf("%s: unable to remove replica %v which is not present", r, repDesc)
+		}
+		if changeType == roachpb.REMOVE_NON_VOTER &&
+			updatedDesc.Replicas[repDescIdx].GetType() != roachpb.ReplicaType_NON_VOTER {
+			return nil, errors.Errorf("%s: unable to remove replica %v which is not a non-voter",
+				r, updatedDesc.Replicas[repDescIdx])
 		}
+		repDesc = updatedDesc.Replicas[repDescIdx]
 		updatedDesc.Replicas[repDescIdx] = updatedDesc.Replicas[len(updatedDesc.Replicas)-1]
 		updatedDesc.Replicas = updatedDesc.Replicas[:len(updatedDesc.Replicas)-1]
 	}
 
+	var added, removed []roachpb.ReplicaDescriptor
+	if changeType == roachpb.ADD_REPLICA {
+		added = append(added, repDesc)
+	} else {
+		removed = append(removed, repDesc)
+	}
+	if err := r.execChangeReplicasTxn(ctx, desc, &updatedDesc, added, removed, reason, details); err != nil {
+		return nil, err
+	}
+	return &updatedDesc, nil
+}
+
+// addReplicaViaLearner adds the specified replica to the range as a learner,
+// catches it up using a Raft snapshot and then promotes it to a voter. If the
+// replica can't be caught up or promoted, the learner is removed again.
+func (r *Replica) addReplicaViaLearner(
+	ctx context.Context,
+	repDesc roachpb.ReplicaDescriptor,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason storagepb.RangeLogEventReason,
+	details string,
+) (*roachpb.RangeDescriptor, error) {
+	learnerDesc, repDesc, err := r.addLearnerReplica(
+		ctx, desc, repDesc, roachpb.ReplicaType_LEARNER, reason, details)
+	if err != nil {
+		return nil, err
+	}
+
+	// Catch up the learner with a Raft snapshot. The learner is already part
+	// of the range, so unlike a preemptive snapshot, this snapshot is addressed
+	// to its replica ID. The Raft snapshot queue leaves learners alone to avoid
+	// sending a second snapshot concurrently.
+	if err := r.sendSnapshot(ctx, repDesc, snapTypeRaft, priority); err != nil {
+		r.rollbackLearnerReplica(ctx, repDesc, learnerDesc, reason, details)
+		return nil, err
+	}
+
+	// Promote the learner to a voter.
+	voterDesc := repDesc
+	voterDesc.Type = nil
+	promotedDesc := withReplicas(learnerDesc, func(rDesc roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool) {
+		if rDesc.ReplicaID == voterDesc.ReplicaID {
+			return voterDesc, true
+		}
+		return rDesc, true
+	})
+	if err := r.execChangeReplicasTxn(
+		ctx, learnerDesc, promotedDesc, []roachpb.ReplicaDescriptor{voterDesc}, nil, reason, details,
+	); err != nil {
+		r.rollbackLearnerReplica(ctx, repDesc, learnerDesc, reason, details)
+		return nil, err
+	}
+	return promotedDesc, nil
+}
+
+// addNonVoterReplica adds the specified replica to the range as a non-voter
+// and catches it up using a Raft snapshot. Unlike a learner, a non-voter is
+// never promoted. If it can't be caught up, it is removed again.
+func (r *Replica) addNonVoterReplica(
+	ctx context.Context,
+	repDesc roachpb.ReplicaDescriptor,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason storagepb.RangeLogEventReason,
+	details string,
+) (*roachpb.RangeDescriptor, error) {
+	nonVoterDesc, repDesc, err := r.addLearnerReplica(
+		ctx, desc, repDesc, roachpb.ReplicaType_NON_VOTER, reason, details)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.sendSnapshot(ctx, repDesc, snapTypeRaft, priority); err != nil {
+		r.rollbackLearnerReplica(ctx, repDesc, nonVoterDesc, reason, details)
+		return nil, err
+	}
+	return nonVoterDesc, nil
+}
+
+// addLearnerReplica adds the specified replica to the range as a Raft learner
+// of the given type, i.e. a LEARNER or a NON_VOTER. It returns the updated
+// range descriptor and the descriptor of the new replica.
+func (r *Replica) addLearnerReplica(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	repDesc roachpb.ReplicaDescriptor,
+	typ roachpb.ReplicaType,
+	reason storagepb.RangeLogEventReason,
+	details string,
+) (*roachpb.RangeDescriptor, roachpb.ReplicaDescriptor, error) {
+	learnerDesc := *desc
+	learnerDesc.Replicas = append([]roachpb.ReplicaDescriptor(nil), desc.Replicas...)
+	repDesc.ReplicaID = learnerDesc.NextReplicaID
+	repDesc.Type = typ.Enum()
+	learnerDesc.NextReplicaID++
+	learnerDesc.Replicas = append(learnerDesc.Replicas, repDesc)
+	if err := r.execChangeReplicasTxn(
+		ctx, desc, &learnerDesc, []roachpb.ReplicaDescriptor{repDesc}, nil, reason, details,
+	); err != nil {
+		return nil, roachpb.ReplicaDescriptor{}, err
+	}
+	return &learnerDesc, repDesc, nil
+}
+
+// rollbackLearnerReplica attempts to remove a learner that could not be
+// promoted to a voter. Failures are only logged: the replicate queue removes
+// any learners left behind. It returns the range descriptor resulting from the
+// removal, or desc if the removal failed.
+func (r *Replica) rollbackLearnerReplica(
+	ctx context.Context,
+	repDesc roachpb.ReplicaDescriptor,
+	desc *roachpb.RangeDescriptor,
+	reason storagepb.RangeLogEventReason,
+	details string,
+) *roachpb.RangeDescriptor {
+	updatedDesc := withReplicas(desc, func(rDesc roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool) {
+		return rDesc, rDesc.ReplicaID != repDesc.ReplicaID
+	})
+	if err := r.execChangeReplicasTxn(
+		ctx, desc, updatedDesc, nil, []roachpb.ReplicaDescriptor{repDesc}, reason, details,
+	); err != nil {
+		log.Infof(ctx, "failed to rollback learner %s, abandoning it for the replicate queue: %v",
+			repDesc, err)
+		return desc
+	}
+	return updatedDesc
+}
+
+// changeReplicasAtomic carries out several replication changes at once, in a
+// way that never leaves the range with a configuration less fault tolerant
+// than both the initial and the final one. This matters most when swapping a
+// replica for another, which done in two steps would temporarily run the
+// range with an even number of replicas or with two replicas in the same
+// locality.
+//
+// First, all replicas to be added are added as learners and caught up using
+// Raft snapshots. Learners among the replicas to be removed are removed right
+// away as they don't affect the quorum. Then, a single transaction makes the
+// range enter a joint configuration in which the new replicas are incoming
+// voters and the removed voters are demoting. Finally, the joint configuration
+// is left, turning incoming voters into voters and demoting voters into
+// learners, and the demoted learners are removed.
+func (r *Replica) changeReplicasAtomic(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason storagepb.RangeLogEventReason,
+	details string,
+	chgs roachpb.ReplicationChanges,
+) error {
+	for _, target := range chgs.Additions() {
+		for _, rDesc := range desc.Replicas {
+			if rDesc.NodeID != target.NodeID {
+				continue
+			}
+			if rDesc.StoreID == target.StoreID {
+				return errors.Errorf("%s: unable to add replica %v which is already present", r, target)
+			}
+			return errors.Errorf("%s: unable to add replica %v; node already has a replica", r, target)
+		}
+	}
+	var removedLearners, demoted []roachpb.ReplicaDescriptor
+	for _, target := range chgs.Removals() {
+		rDesc, ok := desc.GetReplicaDescriptor(target.StoreID)
+		if !ok || rDesc.NodeID != target.NodeID {
+			return errors.Errorf("%s: unable to remove replica %v which is not present", r, target)
+		}
+		if typ := rDesc.GetType(); typ == roachpb.ReplicaType_LEARNER || typ == roachpb.ReplicaType_NON_VOTER {
+			removedLearners = append(removedLearners, rDesc)
+		} else {
+			demoted = append(demoted, rDesc)
+		}
+	}
+
+	for _, repDesc := range removedLearners {
+		repDesc := repDesc
+		updatedDesc := withReplicas(desc, func(rDesc roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool) {
+			return rDesc, rDesc.ReplicaID != repDesc.ReplicaID
+		})
+		if err := r.execChangeReplicasTxn(
+			ctx, desc, updatedDesc, nil, []roachpb.ReplicaDescriptor{repDesc}, reason, details,
+		); err != nil {
+			return err
+		}
+		desc = updatedDesc
+	}
+
+	var learners []roachpb.ReplicaDescriptor
+	rollback := func() {
+		for _, repDesc := range learners {
+			desc = r.rollbackLearnerReplica(ctx, repDesc, desc, reason, details)
+		}
+	}
+	for _, target := range chgs.Additions() {
+		learnerDesc, repDesc, err := r.addLearnerReplica(ctx, desc, roachpb.ReplicaDescriptor{
+			NodeID:  target.NodeID,
+			StoreID: target.StoreID,
+		}, roachpb.ReplicaType_LEARNER, reason, details)
+		if err != nil {
+			rollback()
+			return err
+		}
+		desc = learnerDesc
+		learners = append(learners, repDesc)
+	}
+	for _, repDesc := range learners {
+		if err := r.sendSnapshot(ctx, repDesc, snapTypeRaft, priority); err != nil {
+			rollback()
+			return err
+		}
+	}
+	if len(learners) == 0 && len(demoted) == 0 {
+		return nil
+	}
+
+	// Enter the joint configuration.
+	var incoming, demoting []roachpb.ReplicaDescriptor
+	jointDesc := withReplicas(desc, func(rDesc roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool) {
+		for _, l := range learners {
+			if l.ReplicaID == rDesc.ReplicaID {
+				rDesc.Type = roachpb.ReplicaType_VOTER_INCOMING.Enum()
+				incoming = append(incoming, rDesc)
+				return rDesc, true
+			}
+		}
+		for _, d := range demoted {
+			if d.ReplicaID == rDesc.ReplicaID {
+				rDesc.Type = roachpb.ReplicaType_VOTER_DEMOTING.Enum()
+				demoting = append(demoting, rDesc)
+				return rDesc, true
+			}
+		}
+		return rDesc, true
+	})
+	if err := r.execChangeReplicasTxn(
+		ctx, desc, jointDesc, incoming, demoting, reason, details,
+	); err != nil {
+		rollback()
+		return err
+	}
+
+	_, err := r.maybeLeaveAtomicChangeReplicas(ctx, jointDesc, reason, details)
+	return err
+}
+
+// maybeLeaveAtomicChangeReplicas leaves the joint configuration desc is in,
+// if any, and removes the learners demoted by it. It returns the resulting
+// range descriptor.
+func (r *Replica) maybeLeaveAtomicChangeReplicas(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	reason storagepb.RangeLogEventReason,
+	details string,
+) (*roachpb.RangeDescriptor, error) {
+	if !desc.InAtomicReplicationChange() {
+		return desc, nil
+	}
+	var demoted []roachpb.ReplicaDescriptor
+	updatedDesc := withReplicas(desc, func(rDesc roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool) {
+		switch rDesc.GetType() {
+		case roachpb.ReplicaType_VOTER_INCOMING:
+			rDesc.Type = nil
+		case roachpb.ReplicaType_VOTER_DEMOTING:
+			rDesc.Type = roachpb.ReplicaType_LEARNER.Enum()
+			demoted = append(demoted, rDesc)
+		}
+		return rDesc, true
+	})
+	if err := r.execChangeReplicasTxn(ctx, desc, updatedDesc, nil, nil, reason, details); err != nil {
+		return nil, err
+	}
+	desc = updatedDesc
+
+	for _, repDesc := range demoted {
+		repDesc := repDesc
+		updatedDesc := withReplicas(desc, func(rDesc roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool) {
+			return rDesc, rDesc.ReplicaID != repDesc.ReplicaID
+		})
+		if err := r.execChangeReplicasTxn(
+			ctx, desc, updatedDesc, nil, []roachpb.ReplicaDescriptor{repDesc}, reason, details,
+		); err != nil {
+			return nil, err
+		}
+		desc = updatedDesc
+	}
+	return desc, nil
+}
+
+// withReplicas returns a copy of desc in which each replica is replaced by the
+// result of fn. Replicas for which fn returns false are dropped.
+func withReplicas(
+	desc *roachpb.RangeDescriptor,
+	fn func(roachpb.ReplicaDescriptor) (roachpb.ReplicaDescriptor, bool),
+) *roachpb.RangeDescriptor {
+	updatedDesc := *desc
+	updatedDesc.Replicas = nil
+	for _, rDesc := range desc.Replicas {
+		if rDesc, ok := fn(rDesc); ok {
+			updatedDesc.Replicas = append(updatedDesc.Replicas, rDesc)
+		}
+	}
+	return &updatedDesc
+}
+
+// execChangeReplicasTxn runs the transaction which replaces the range
+// descriptor desc by updatedDesc, adding (or promoting) the replicas in added
+// and removing (or demoting) the replicas in removed. A transaction which
+// neither adds nor removes replicas leaves a joint configuration.
+func (r *Replica) execChangeReplicasTxn(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	updatedDesc *roachpb.RangeDescriptor,
+	added, removed []roachpb.ReplicaDescriptor,
+	reason storagepb.RangeLogEventReason,
+	details string,
+) error {
+	rangeID := desc.RangeID
 	descKey := keys.RangeDescriptorKey(desc.StartKey)
 
 	if err := r.store.DB().Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
@@ -796,15 +1221,15 @@ func (r *Replica) changeReplicas(
 		if err := txn.GetProto(ctx, descKey, oldDesc); err != nil {
 			return err
 		}
-		log.Infof(ctx, "change replicas (%v %s): read existing descriptor %s",
-			changeType, repDesc, oldDesc)
+		log.Infof(ctx, "change replicas (add %v remove %v): read existing descriptor %s",
+			added, removed, oldDesc)
 
 		{
 			b := txn.NewBatch()
 
 			// Important: the range descriptor must be the first thing touched in the transaction
 			// so the transaction record is co-located with the range being modified.
-			if err := updateRangeDescriptor(b, descKey, desc, &updatedDesc); err != nil {
+			if err := updateRangeDescriptor(b, descKey, desc, updatedDesc); err != nil {
 				return err
 			}
 
@@ -814,11 +1239,20 @@ func (r *Replica) changeReplicas(
 			}
 		}
 
-		// Log replica change into range event log.
-		if err := r.store.logChange(
-			ctx, txn, changeType, repDesc, updatedDesc, reason, details,
-		); err != nil {
-			return err
+		// Log replica changes into range event log.
+		for _, repDesc := range added {
+			if err := r.store.logChange(
+				ctx, txn, roachpb.ADD_REPLICA, repDesc, *updatedDesc, reason, details,
+			); err != nil {
+				return err
+			}
+		}
+		for _, repDesc := range removed {
+			if err := r.store.logChange(
+				ctx, txn, roachpb.REMOVE_REPLICA, repDesc, *updatedDesc, reason, details,
+			); err != nil {
+				return err
+			}
 		}
 
 		// End the transaction manually instead of letting RunTransaction
@@ -826,22 +1260,33 @@ func (r *Replica) changeReplicas(
 		b := txn.NewBatch()
 
 		// Update range descriptor addressing record(s).
-		if err := updateRangeAddressing(b, &updatedDesc); err != nil {
+		if err := updateRangeAddressing(b, updatedDesc); err != nil {
 			return err
 		}
 
+		// TODO(benesch): this trigger should just specify the updated
+		// descriptor, like the split and merge triggers, so that the receiver
+		// doesn't need to reconstruct the range descriptor update.
+		crt := &roachpb.ChangeReplicasTrigger{
+			UpdatedReplicas: updatedDesc.Replicas,
+			NextReplicaID:   updatedDesc.NextReplicaID,
+		}
+		if len(added)+len(removed) == 1 && !updatedDesc.InAtomicReplicationChange() {
+			// Use the fields understood by nodes which predate atomic
+			// replication changes.
+			if len(added) == 1 {
+				crt.ChangeType, crt.Replica = roachpb.ADD_REPLICA, added[0]
+			} else {
+				crt.ChangeType, crt.Replica = roachpb.REMOVE_REPLICA, removed[0]
+			}
+		} else {
+			crt.InternalAddedReplicas = added
+			crt.InternalRemovedReplicas = removed
+		}
 		b.AddRawRequest(&roachpb.EndTransactionRequest{
 			Commit: true,
 			InternalCommitTrigger: &roachpb.InternalCommitTrigger{
-				// TODO(benesch): this trigger should just specify the updated
-				// descriptor, like the split and merge triggers, so that the receiver
-				// doesn't need to reconstruct the range descriptor update.
-				ChangeReplicasTrigger: &roachpb.ChangeReplicasTrigger{
-					ChangeType:      changeType,
-					Replica:         repDesc,
-					UpdatedReplicas: updatedDesc.Replicas,
-					NextReplicaID:   updatedDesc.NextReplicaID,
-				},
+				ChangeReplicasTrigger: crt,
 			},
 		})
 		if err := txn.Run(ctx, b); err != nil {
@@ -1095,9 +1540,9 @@ func (s *Store) AdminRelocateRange(
 		return false
 	}
 
-	transferLease := func() {
+	transferLease := func(storeID roachpb.StoreID) {
 		if err := s.DB().AdminTransferLease(
-			ctx, startKey, targets[0].StoreID,
+			ctx, startKey, storeID,
 		); err != nil {
 			log.Warningf(ctx, "while transferring lease: %s", err)
 		}
@@ -1117,7 +1562,13 @@ func (s *Store) AdminRelocateRange(
 
 	rangeInfo := RangeInfo{Desc: &rangeDesc}
 
-	// Step 2: Repeatedly add a replica then remove a replica until we reach the
+	// If all nodes support it, a replica is added and another one removed in a
+	// single atomic replication change, so that the range never runs with an
+	// even number of replicas in between.
+	st := s.ClusterSettings()
+	useAtomic := useLearnerReplicas.Get(&st.SV) && st.Version.IsActive(cluster.VersionAtomicChangeReplicas)
+
+	// Step 2: Repeatedly add a replica and remove a replica until we reach the
 	// desired state.
 	every := log.Every(time.Minute)
 	re := retry.StartWithCtx(ctx, retry.Options{MaxBackoff: 5 * time.Second})
@@ -1126,7 +1577,12 @@ func (s *Store) AdminRelocateRange(
 			return err
 		}
 
-		if len(addTargets) > 0 && len(addTargets) >= len(removeTargets) {
+		var chgs roachpb.ReplicationChanges
+		// The replicas of the range once the changes picked so far are made.
+		replicas := rangeInfo.Desc.Replicas
+		numAdds, numRemoves := len(addTargets), len(removeTargets)
+
+		if numAdds > 0 && numAdds >= numRemoves {
 			// Each iteration, pick the most desirable replica to add. However,
 			// prefer the first target if it doesn't yet have a replica so that we
 			// can always transfer the lease to it before removing a replica below.
@@ -1168,65 +1624,79 @@ func (s *Store) AdminRelocateRange(
 				NodeID:  targetStore.Node.NodeID,
 				StoreID: targetStore.StoreID,
 			}
-			if err := s.DB().AdminChangeReplicas(
-				ctx, startKey, roachpb.ADD_REPLICA, []roachpb.ReplicationTarget{target},
-			); err != nil {
-				returnErr := errors.Wrapf(err, "while adding target %v", target)
-				if !canRetry(err) {
-					return returnErr
-				}
-				if every.ShouldLog() {
-					log.Warning(ctx, returnErr)
-				}
-				re.Next()
-				continue
-			}
-
-			// Upon success, remove the target from our to-do list and add it to our
-			// local copy of the range descriptor such that future allocator
-			// decisions take it into account.
-			addTargets = removeTargetFromSlice(addTargets, target)
-			rangeInfo.Desc.Replicas = append(rangeInfo.Desc.Replicas, roachpb.ReplicaDescriptor{
+			chgs = append(chgs, roachpb.MakeReplicationChanges(roachpb.ADD_REPLICA, target)...)
+			numAdds--
+			// Take the new replica into account when picking a replica to remove
+			// below.
+			replicas = append(replicas[:len(replicas):len(replicas)], roachpb.ReplicaDescriptor{
 				NodeID:  target.NodeID,
 				StoreID: target.StoreID,
 			})
 		}
 
-		if len(removeTargets) > 0 && len(removeTargets) > len(addTargets) {
-			targetStore, _, err := s.allocator.RemoveTarget(ctx, zone, removeTargets, rangeInfo)
+		// Note that attempting to remove the leaseholder won't work, so the
+		// lease is transferred to a replica that's staying in the range first.
+		// This is the first specified target if it has a replica, which is the
+		// case unless it is being added in this iteration.
+		var leaseTarget roachpb.StoreID
+		for _, t := range targets {
+			if storeHasReplica(t.StoreID, rangeInfo.Desc.Replicas) {
+				leaseTarget = t.StoreID
+				break
+			}
+		}
+		if numRemoves > 0 && numRemoves > numAdds &&
+			(len(chgs) == 0 || (useAtomic && leaseTarget != 0)) {
+			removeInfo := rangeInfo
+			removeDesc := *rangeInfo.Desc
+			removeDesc.Replicas = replicas
+			removeInfo.Desc = &removeDesc
+			targetStore, _, err := s.allocator.RemoveTarget(ctx, zone, removeTargets, removeInfo)
 			if err != nil {
 				return errors.Wrapf(err, "unable to select removal target from %v; current replicas %v",
-					removeTargets, rangeInfo.Desc.Replicas)
+					removeTargets, replicas)
 			}
 			target := roachpb.ReplicationTarget{
 				NodeID:  targetStore.NodeID,
 				StoreID: targetStore.StoreID,
 			}
-			// Note that attempting to remove the leaseholder won't work, so transfer
-			// the lease first in such scenarios. The first specified target should be
-			// the leaseholder now, so we can always transfer the lease there.
-			transferLease()
-			if err := s.DB().AdminChangeReplicas(
-				ctx, startKey, roachpb.REMOVE_REPLICA, []roachpb.ReplicationTarget{target},
-			); err != nil {
-				log.Warningf(ctx, "while removing target %v: %s", target, err)
-				if !canRetry(err) {
-					return err
-				}
-				re.Next()
-				continue
+			if leaseTarget == 0 {
+				leaseTarget = targets[0].StoreID
 			}
+			transferLease(leaseTarget)
+			chgs = append(chgs, roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, target)...)
+		}
+
+		if err := s.DB().AdminChangeReplicas(ctx, startKey, chgs); err != nil {
+			returnErr := errors.Wrapf(err, "while carrying out changes %v", chgs)
+			if !canRetry(err) {
+				return returnErr
+			}
+			if every.ShouldLog() {
+				log.Warning(ctx, returnErr)
+			}
+			re.Next()
+			continue
+		}
 
-			// Upon success, remove the target from our to-do list and from our local
-			// copy of the range descriptor such that future allocator decisions take
-			// its absence into account.
+		// Upon success, remove the targets from our to-do lists and update our
+		// local copy of the range descriptor such that future allocator
+		// decisions take them into account.
+		for _, target := range chgs.Additions() {
+			addTargets = removeTargetFromSlice(addTargets, target)
+			rangeInfo.Desc.Replicas = append(rangeInfo.Desc.Replicas, roachpb.ReplicaDescriptor{
+				NodeID:  target.NodeID,
+				StoreID: target.StoreID,
+			})
+		}
+		for _, target := range chgs.Removals() {
 			removeTargets = removeTargetFromSlice(removeTargets, target)
 			rangeInfo.Desc.Replicas = removeTargetFromSlice(rangeInfo.Desc.Replicas, target)
 		}
 	}
 
 	// Step 3: Transfer the lease to the first listed target, as the API specifies.
-	transferLease()
+	transferLease(targets[0].StoreID)
 
 	return ctx.Err()
 }
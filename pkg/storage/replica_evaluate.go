@@ -283,6 +283,12 @@ func evaluateBatch(
 					// will succeed on a retry, so better to short circuit and return the
 					// write too old error.
 					returnWriteTooOldErr = true
+				case *roachpb.ScanRequest, *roachpb.ReverseScanRequest:
+					// Locking scans are also an exception. The values they returned
+					// were read below the newer committed value, so the txn will not
+					// be able to refresh its reads and commit; return the error now
+					// to allow the txn coord sender to retry the scan.
+					returnWriteTooOldErr = true
 				}
 				if ba.Txn != nil {
 					ba.Txn.Timestamp.Forward(tErr.ActualTimestamp)
@@ -125,7 +125,8 @@ func (rgcq *replicaGCQueue) shouldQueue(
 		return false, 0
 	}
 
-	if _, currentMember := repl.Desc().GetReplicaDescriptor(repl.store.StoreID()); !currentMember {
+	replDesc, currentMember := repl.Desc().GetReplicaDescriptor(repl.store.StoreID())
+	if !currentMember {
 		return true, replicaGCPriorityRemoved
 	}
 
@@ -137,10 +138,16 @@ func (rgcq *replicaGCQueue) shouldQueue(
 		lastActivity.Forward(*lease.ProposedTS)
 	}
 
-	var isCandidate bool
+	// Learners and the voters of a joint configuration only exist while a
+	// replication change is in progress, and learners (including demoted
+	// voters) are removed at the end of it. Such a replica which missed its
+	// removal never campaigns, so it is treated like a candidate. Non-voters
+	// never campaign either, but they are a permanent part of the range.
+	typ := replDesc.GetType()
+	isSuspect := typ != roachpb.ReplicaType_VOTER && typ != roachpb.ReplicaType_NON_VOTER
 	if raftStatus := repl.RaftStatus(); raftStatus != nil {
-		isCandidate = (raftStatus.SoftState.RaftState == raft.StateCandidate ||
-			raftStatus.SoftState.RaftState == raft.StatePreCandidate)
+		isSuspect = isSuspect || raftStatus.SoftState.RaftState == raft.StateCandidate ||
+			raftStatus.SoftState.RaftState == raft.StatePreCandidate
 	} else {
 		// If a replica doesn't have an active raft group, we should check whether
 		// we're decommissioning. If so, we should process the replica because it
@@ -153,18 +160,18 @@ func (rgcq *replicaGCQueue) shouldQueue(
 			}
 		}
 	}
-	return replicaGCShouldQueueImpl(now, lastCheck, lastActivity, isCandidate)
+	return replicaGCShouldQueueImpl(now, lastCheck, lastActivity, isSuspect)
 }
 
 func replicaGCShouldQueueImpl(
-	now, lastCheck, lastActivity hlc.Timestamp, isCandidate bool,
+	now, lastCheck, lastActivity hlc.Timestamp, isSuspect bool,
 ) (bool, float64) {
 	timeout := ReplicaGCQueueInactivityThreshold
 	priority := replicaGCPriorityDefault
 
-	if isCandidate {
+	if isSuspect {
 		// If the range is a candidate (which happens if its former replica set
-		// ignores it), let it expire much earlier.
+		// ignores it) or a non-voter, let it expire much earlier.
 		timeout = ReplicaGCQueueCandidateTimeout
 		priority = replicaGCPriorityCandidate
 	} else if now.Less(lastCheck.Add(ReplicaGCQueueInactivityThreshold.Nanoseconds(), 0)) {
@@ -120,7 +120,7 @@ func calcReplicaMetrics(
 	m.Ticking = ticking
 
 	m.RangeCounter, m.Unavailable, m.Underreplicated =
-		calcRangeCounter(storeID, desc, livenessMap, *zone.NumReplicas, clusterNodes)
+		calcRangeCounter(storeID, desc, livenessMap, zone.GetNumVoters(), clusterNodes)
 
 	// The raft leader computes the number of raft entries that replicas are
 	// behind.
@@ -159,7 +159,9 @@ func calcRangeCounter(
 	numReplicas int32,
 	clusterNodes int,
 ) (rangeCounter, unavailable, underreplicated bool) {
-	for _, rd := range desc.Replicas {
+	// Learners don't vote and don't count towards the replication factor.
+	voters := desc.Voters()
+	for _, rd := range voters {
 		if livenessMap[rd.NodeID].IsLive {
 			rangeCounter = rd.StoreID == storeID
 			break
@@ -168,8 +170,8 @@ func calcRangeCounter(
 	// We also compute an estimated per-range count of under-replicated and
 	// unavailable ranges for each range based on the liveness table.
 	if rangeCounter {
-		liveReplicas := calcLiveReplicas(desc, livenessMap)
-		if liveReplicas < computeQuorum(len(desc.Replicas)) {
+		liveReplicas := calcLiveReplicas(voters, livenessMap)
+		if liveReplicas < computeQuorum(len(voters)) {
 			unavailable = true
 		}
 		if GetNeededReplicas(numReplicas, clusterNodes) > liveReplicas {
@@ -181,9 +183,9 @@ func calcRangeCounter(
 
 // calcLiveReplicas returns a count of the live replicas; a live replica is
 // determined by checking its node in the provided liveness map.
-func calcLiveReplicas(desc *roachpb.RangeDescriptor, livenessMap IsLiveMap) int {
+func calcLiveReplicas(repls []roachpb.ReplicaDescriptor, livenessMap IsLiveMap) int {
 	var live int
-	for _, rd := range desc.Replicas {
+	for _, rd := range repls {
 		if livenessMap[rd.NodeID].IsLive {
 			live++
 		}
@@ -678,8 +678,8 @@ func (r *Replica) handleReplicatedEvalResult(
 	}
 
 	if change := rResult.ChangeReplicas; change != nil {
-		if change.ChangeType == roachpb.REMOVE_REPLICA &&
-			r.store.StoreID() == change.Replica.StoreID {
+		updatedDesc := roachpb.RangeDescriptor{Replicas: change.UpdatedReplicas}
+		if _, ok := updatedDesc.GetReplicaDescriptor(r.store.StoreID()); !ok {
 			// This wants to run as late as possible, maximizing the chances
 			// that the other nodes have finished this command as well (since
 			// processing the removal from the queue looks up the Range at the
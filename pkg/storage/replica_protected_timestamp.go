@@ -0,0 +1,73 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// checkProtectedTimestampsForGC determines the timestamp at which the GC
+// queue may run on this replica given the protected timestamp records which
+// overlap it, and the GC threshold which results from it.
+//
+// The returned gcTimestamp is no later than now. If a record protects data in
+// this range, it is moved back far enough for the new threshold to stay below
+// the earliest protected timestamp. It is also no later than the timestamp as
+// of which the protected timestamp cache is accurate, which reduces the
+// exposure to records that the cache has yet to observe. canGC is false if
+// the new threshold does not advance past the current one, in which case
+// running the GC queue would not make any progress on user data.
+func (r *Replica) checkProtectedTimestampsForGC(
+	ctx context.Context, now hlc.Timestamp, policy config.GCPolicy,
+) (canGC bool, gcTimestamp, oldThreshold, newThreshold hlc.Timestamp) {
+	r.mu.RLock()
+	oldThreshold = *r.mu.state.GCThreshold
+	r.mu.RUnlock()
+
+	gcTimestamp = now
+	if cache := r.store.cfg.ProtectedTimestampCache; cache != nil {
+		desc := r.Desc()
+		var earliest hlc.Timestamp
+		asOf := cache.Iterate(ctx, desc.StartKey.AsRawKey(), desc.EndKey.AsRawKey(),
+			func(rec *ptpb.Record) (wantMore bool) {
+				if earliest == (hlc.Timestamp{}) || rec.Timestamp.Less(earliest) {
+					earliest = rec.Timestamp
+				}
+				return true
+			})
+		if asOf == (hlc.Timestamp{}) {
+			// The cache has not been populated yet, so we cannot know whether
+			// any data in this range is protected.
+			log.VEventf(ctx, 2, "not gc'ing replica %v: protected timestamps not yet known", r)
+			return false, hlc.Timestamp{}, oldThreshold, oldThreshold
+		}
+		gcTimestamp.Backward(asOf)
+		if earliest != (hlc.Timestamp{}) {
+			// The data visible at earliest must remain readable, so the new
+			// threshold has to stay strictly below it.
+			ttlNanos := int64(policy.TTLSeconds) * 1E9
+			gcTimestamp.Backward(earliest.Prev().Add(ttlNanos, 0))
+			log.VEventf(ctx, 2, "replica %v is protected as of %v", r, earliest)
+		}
+	}
+	newThreshold = engine.CalculateThreshold(gcTimestamp, policy)
+	return oldThreshold.Less(newThreshold), gcTimestamp, oldThreshold, newThreshold
+}
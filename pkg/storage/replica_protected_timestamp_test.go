@@ -0,0 +1,129 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProtectedTimestampCache is a protectedts.Cache whose state is set
+// directly by tests.
+type fakeProtectedTimestampCache struct {
+	syncutil.Mutex
+	asOf    hlc.Timestamp
+	records []ptpb.Record
+}
+
+var _ protectedts.Cache = (*fakeProtectedTimestampCache)(nil)
+
+func (c *fakeProtectedTimestampCache) set(asOf hlc.Timestamp, records ...ptpb.Record) {
+	c.Lock()
+	defer c.Unlock()
+	c.asOf, c.records = asOf, records
+}
+
+func (c *fakeProtectedTimestampCache) Iterate(
+	_ context.Context, from, to roachpb.Key, it protectedts.Iterator,
+) hlc.Timestamp {
+	c.Lock()
+	defer c.Unlock()
+	sp := roachpb.Span{Key: from, EndKey: to}
+	for i := range c.records {
+		for _, rSp := range c.records[i].Spans {
+			if sp.Overlaps(rSp) {
+				if !it(&c.records[i]) {
+					return c.asOf
+				}
+				break
+			}
+		}
+	}
+	return c.asOf
+}
+
+func (c *fakeProtectedTimestampCache) Refresh(context.Context, hlc.Timestamp) error {
+	return nil
+}
+
+// TestCheckProtectedTimestampsForGC verifies that protected timestamp records
+// hold back the GC threshold of the ranges they overlap.
+func TestCheckProtectedTimestampsForGC(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	var cache fakeProtectedTimestampCache
+	tc := testContext{manualClock: hlc.NewManualClock(123)}
+	cfg := TestStoreConfig(hlc.NewClock(tc.manualClock.UnixNano, time.Nanosecond))
+	cfg.ProtectedTimestampCache = &cache
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	tc.StartWithStoreConfig(t, stopper, cfg)
+
+	policy := config.GCPolicy{TTLSeconds: 60 * 60}
+	tc.manualClock.Increment((48 * time.Hour).Nanoseconds())
+	now := tc.Clock().Now()
+	unprotectedThreshold := engine.CalculateThreshold(now, policy)
+	makeRecord := func(ts hlc.Timestamp) ptpb.Record {
+		return ptpb.Record{
+			ID:        uuid.MakeV4(),
+			Timestamp: ts,
+			Spans:     []roachpb.Span{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}},
+		}
+	}
+
+	// The cache has not been populated yet, so nothing can be GC'ed.
+	canGC, _, _, _ := tc.repl.checkProtectedTimestampsForGC(ctx, now, policy)
+	require.False(t, canGC)
+
+	// Without any records, GC proceeds as usual.
+	cache.set(now)
+	canGC, gcTimestamp, _, newThreshold := tc.repl.checkProtectedTimestampsForGC(ctx, now, policy)
+	require.True(t, canGC)
+	require.Equal(t, now, gcTimestamp)
+	require.Equal(t, unprotectedThreshold, newThreshold)
+
+	// GC does not run ahead of the view of the cache.
+	asOf := now.Add(-time.Minute.Nanoseconds(), 0)
+	cache.set(asOf)
+	canGC, gcTimestamp, _, _ = tc.repl.checkProtectedTimestampsForGC(ctx, now, policy)
+	require.True(t, canGC)
+	require.Equal(t, asOf, gcTimestamp)
+
+	// The earliest overlapping record holds back the threshold.
+	protected := now.Add(-(30 * time.Hour).Nanoseconds(), 0)
+	cache.set(now, makeRecord(protected.Add(time.Hour.Nanoseconds(), 0)), makeRecord(protected))
+	canGC, _, _, newThreshold = tc.repl.checkProtectedTimestampsForGC(ctx, now, policy)
+	require.True(t, canGC)
+	require.True(t, newThreshold.Less(protected))
+	require.True(t, newThreshold.Less(unprotectedThreshold))
+
+	// A record protecting data below the current threshold prevents GC.
+	cache.set(now, makeRecord(hlc.Timestamp{WallTime: 1}))
+	canGC, _, _, _ = tc.repl.checkProtectedTimestampsForGC(ctx, now, policy)
+	require.False(t, canGC)
+}
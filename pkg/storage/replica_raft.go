@@ -45,6 +45,7 @@ import (
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 // insertProposalLocked assigns a MaxLeaseIndex to a proposal and adds
@@ -377,9 +378,13 @@ func defaultSubmitProposalLocked(r *Replica, p *ProposalData) error {
 		// leases can stay in such a state for a very long time when using epoch-
 		// based range leases). This shouldn't happen often, but has been seen
 		// before (#12591).
-		if crt.ChangeType == roachpb.REMOVE_REPLICA && crt.Replica.ReplicaID == r.mu.replicaID {
-			log.Errorf(p.ctx, "received invalid ChangeReplicasTrigger %s to remove self (leaseholder)", crt)
-			return errors.Errorf("%s: received invalid ChangeReplicasTrigger %s to remove self (leaseholder)", r, crt)
+		// The same holds for demoting ourselves to a learner as part of an
+		// atomic replication change, as learners cannot hold the lease.
+		for _, rDesc := range crt.Removed() {
+			if rDesc.ReplicaID == r.mu.replicaID {
+				log.Errorf(p.ctx, "received invalid ChangeReplicasTrigger %s to remove self (leaseholder)", crt)
+				return errors.Errorf("%s: received invalid ChangeReplicasTrigger %s to remove self (leaseholder)", r, crt)
+			}
 		}
 
 		confChangeCtx := ConfChangeContext{
@@ -391,17 +396,17 @@ func defaultSubmitProposalLocked(r *Replica, p *ProposalData) error {
 		if err != nil {
 			return err
 		}
+		cc, err := confChangeFromTrigger(&crt.ChangeReplicasTrigger, encodedCtx)
+		if err != nil {
+			return err
+		}
 
 		return r.withRaftGroupLocked(true, func(raftGroup *raft.RawNode) (bool, error) {
 			// We're proposing a command here so there is no need to wake the
 			// leader if we were quiesced.
 			r.unquiesceLocked()
 			return false, /* unquiesceAndWakeLeader */
-				raftGroup.ProposeConfChange(raftpb.ConfChange{
-					Type:    changeTypeInternalToRaft[crt.ChangeType],
-					NodeID:  uint64(crt.Replica.ReplicaID),
-					Context: encodedCtx,
-				})
+				raftGroup.ProposeConfChange(cc)
 		})
 	}
 
@@ -802,14 +807,26 @@ func (r *Replica) handleRaftReadyRaftMuLocked(
 			}
 			r.mu.Unlock()
 
-		case raftpb.EntryConfChange:
-			var cc raftpb.ConfChange
-			if err := protoutil.Unmarshal(e.Data, &cc); err != nil {
-				const expl = "while unmarshaling ConfChange"
-				return stats, expl, errors.Wrap(err, expl)
+		case raftpb.EntryConfChange, raftpb.EntryConfChangeV2:
+			var cc raftpb.ConfChangeI
+			var encodedCtx []byte
+			if e.Type == raftpb.EntryConfChange {
+				var ccV1 raftpb.ConfChange
+				if err := protoutil.Unmarshal(e.Data, &ccV1); err != nil {
+					const expl = "while unmarshaling ConfChange"
+					return stats, expl, errors.Wrap(err, expl)
+				}
+				cc, encodedCtx = ccV1, ccV1.Context
+			} else {
+				var ccV2 raftpb.ConfChangeV2
+				if err := protoutil.Unmarshal(e.Data, &ccV2); err != nil {
+					const expl = "while unmarshaling ConfChangeV2"
+					return stats, expl, errors.Wrap(err, expl)
+				}
+				cc, encodedCtx = ccV2, ccV2.Context
 			}
 			var ccCtx ConfChangeContext
-			if err := protoutil.Unmarshal(cc.Context, &ccCtx); err != nil {
+			if err := protoutil.Unmarshal(encodedCtx, &ccCtx); err != nil {
 				const expl = "while unmarshaling ConfChangeContext"
 				return stats, expl, errors.Wrap(err, expl)
 
@@ -824,6 +841,8 @@ func (r *Replica) handleRaftReadyRaftMuLocked(
 				ctx, commandID, e.Term, e.Index, command,
 			); !changedRepl {
 				// If we did not apply the config change, tell raft that the config change was aborted.
+				// An empty ConfChange (as opposed to an empty ConfChangeV2, which
+				// leaves a joint configuration) is a no-op.
 				cc = raftpb.ConfChange{}
 			}
 			stats.processed++
@@ -1201,8 +1220,8 @@ func (r *Replica) sendRaftMessage(ctx context.Context, msg raftpb.Message) {
 		// below for more context:
 		_ = maybeDropMsgApp
 		// NB: this code is allocation free.
-		r.mu.internalRaftGroup.WithProgress(func(id uint64, _ raft.ProgressType, pr raft.Progress) {
-			if id == msg.To && pr.State == raft.ProgressStateProbe {
+		r.mu.internalRaftGroup.WithProgress(func(id uint64, _ raft.ProgressType, pr tracker.Progress) {
+			if id == msg.To && pr.State == tracker.StateProbe {
 				// It is moderately expensive to attach a full key to the message, but note that
 				// a probing follower will only be appended to once per heartbeat interval (i.e.
 				// on the order of seconds). See:
@@ -1557,7 +1576,7 @@ func (r *Replica) withRaftGroupLocked(
 			r.mu.state.RaftAppliedIndex,
 			r.store.cfg,
 			&raftLogger{ctx: ctx},
-		), nil)
+		))
 		if err != nil {
 			return err
 		}
@@ -1633,7 +1652,7 @@ func (r *Replica) maybeCampaignOnWakeLocked(ctx context.Context) {
 
 	leaseStatus := r.leaseStatus(*r.mu.state.Lease, r.store.Clock().Now(), r.mu.minLeaseProposedTS)
 	raftStatus := r.mu.internalRaftGroup.Status()
-	if shouldCampaignOnWake(leaseStatus, *r.mu.state.Lease, r.store.StoreID(), *raftStatus) {
+	if shouldCampaignOnWake(leaseStatus, *r.mu.state.Lease, r.store.StoreID(), raftStatus) {
 		log.VEventf(ctx, 3, "campaigning")
 		if err := r.mu.internalRaftGroup.Campaign(); err != nil {
 			log.VEventf(ctx, 1, "failed to campaign: %s", err)
@@ -1662,10 +1681,10 @@ func (m lastUpdateTimesMap) update(replicaID roachpb.ReplicaID, now time.Time) {
 // a suitable pattern of quiesce and unquiesce operations (and this in turn
 // can interfere with Raft log truncations).
 func (m lastUpdateTimesMap) updateOnUnquiesce(
-	descs []roachpb.ReplicaDescriptor, prs map[uint64]raft.Progress, now time.Time,
+	descs []roachpb.ReplicaDescriptor, prs map[uint64]tracker.Progress, now time.Time,
 ) {
 	for _, desc := range descs {
-		if prs[uint64(desc.ReplicaID)].State == raft.ProgressStateReplicate {
+		if prs[uint64(desc.ReplicaID)].State == tracker.StateReplicate {
 			m.update(desc.ReplicaID, now)
 		}
 	}
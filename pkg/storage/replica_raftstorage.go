@@ -69,12 +69,36 @@ func (r *replicaRaftStorage) InitialState() (raftpb.HardState, raftpb.ConfState,
 	if raft.IsEmptyHardState(hs) || err != nil {
 		return raftpb.HardState{}, raftpb.ConfState{}, err
 	}
+	cs := confStateFromDesc(r.mu.state.Desc)
+	return hs, cs, nil
+}
+
+// confStateFromDesc synthesizes the Raft configuration of a range from its
+// descriptor. Learners are part of the configuration, but do not vote.
+func confStateFromDesc(desc *roachpb.RangeDescriptor) raftpb.ConfState {
 	var cs raftpb.ConfState
-	for _, rep := range r.mu.state.Desc.Replicas {
-		cs.Nodes = append(cs.Nodes, uint64(rep.ReplicaID))
+	joint := desc.InAtomicReplicationChange()
+	for _, rep := range desc.Replicas {
+		id := uint64(rep.ReplicaID)
+		switch rep.GetType() {
+		case roachpb.ReplicaType_VOTER:
+			cs.Voters = append(cs.Voters, id)
+			if joint {
+				cs.VotersOutgoing = append(cs.VotersOutgoing, id)
+			}
+		case roachpb.ReplicaType_VOTER_INCOMING:
+			cs.Voters = append(cs.Voters, id)
+		case roachpb.ReplicaType_VOTER_DEMOTING:
+			// A demoting voter is a voter in the outgoing configuration and
+			// becomes a learner when the joint configuration is left.
+			cs.VotersOutgoing = append(cs.VotersOutgoing, id)
+			cs.LearnersNext = append(cs.LearnersNext, id)
+		case roachpb.ReplicaType_LEARNER, roachpb.ReplicaType_NON_VOTER:
+			// Non-voters are permanent Raft learners.
+			cs.Learners = append(cs.Learners, id)
+		}
 	}
-
-	return hs, cs, nil
+	return cs
 }
 
 // Entries implements the raft.Storage interface. Note that maxBytes is advisory
@@ -539,10 +563,7 @@ func snapshot(
 	}
 
 	// Synthesize our raftpb.ConfState from desc.
-	var cs raftpb.ConfState
-	for _, rep := range desc.Replicas {
-		cs.Nodes = append(cs.Nodes, uint64(rep.ReplicaID))
-	}
+	cs := confStateFromDesc(&desc)
 
 	term, err := term(ctx, rsl, snap, rangeID, eCache, appliedIndex)
 	if err != nil {
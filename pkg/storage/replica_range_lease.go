@@ -550,6 +550,13 @@ func (r *Replica) requestLeaseLocked(
 		return r.mu.pendingLeaseRequest.newResolvedHandle(roachpb.NewError(
 			newNotLeaseHolderError(nil, r.store.StoreID(), r.mu.state.Desc)))
 	}
+	if repDesc.GetType() == roachpb.ReplicaType_NON_VOTER {
+		// Non-voters can never hold the lease (see cmd_lease.go). Redirect
+		// instead of proposing a request that is bound to be rejected, which
+		// also gives the caller a chance to serve a follower read.
+		return r.mu.pendingLeaseRequest.newResolvedHandle(roachpb.NewError(
+			newNotLeaseHolderError(nil, r.store.StoreID(), r.mu.state.Desc)))
+	}
 	return r.mu.pendingLeaseRequest.InitOrJoinRequest(
 		ctx, repDesc, status, r.mu.state.Desc.StartKey.AsRawKey(), false /* transfer */)
 }
@@ -203,6 +203,33 @@ func TestReplicaRangefeed(t *testing.T) {
 	}...)
 	checkForExpEvents(expEvents)
 
+	// Lock a key with a locking scan without modifying it, then insert another
+	// key non-transactionally. The lock must not produce an event.
+	mtc.manualClock.Increment(1)
+	if err := mtc.dbs[1].Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		b := txn.NewBatch()
+		b.AddRawRequest(&roachpb.ScanRequest{
+			RequestHeader: roachpb.RequestHeader{Key: roachpb.Key("m"), EndKey: roachpb.Key("n")},
+			KeyLocking:    roachpb.EXCLUSIVE_KEY_LOCKING,
+		})
+		return txn.Run(ctx, b)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	mtc.manualClock.Increment(1)
+	ts4 := mtc.clock.Now()
+	pArgs = putArgs(roachpb.Key("d"), []byte("val4"))
+	_, pErr = client.SendWrappedWith(ctx, db, roachpb.Header{Timestamp: ts4}, pArgs)
+	if pErr != nil {
+		t.Fatal(pErr)
+	}
+
+	val4 := roachpb.MakeValueFromBytesAndTimestamp([]byte("val4"), ts4)
+	expEvents = append(expEvents, &roachpb.RangeFeedEvent{Val: &roachpb.RangeFeedValue{
+		Key: roachpb.Key("d"), Value: val4,
+	}})
+	checkForExpEvents(expEvents)
+
 	// Cancel each of the rangefeed streams.
 	for _, stream := range streams {
 		stream.Cancel()
@@ -67,6 +67,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 // allSpans is a SpanSet that covers *everything* for use in tests that don't
@@ -137,17 +138,19 @@ func leaseExpiry(repl *Replica) int64 {
 
 // Create a Raft status that shows everyone fully up to date.
 func upToDateRaftStatus(repls []roachpb.ReplicaDescriptor) *raft.Status {
-	prs := make(map[uint64]raft.Progress)
+	prs := make(map[uint64]tracker.Progress)
 	for _, repl := range repls {
-		prs[uint64(repl.ReplicaID)] = raft.Progress{
-			State: raft.ProgressStateReplicate,
+		prs[uint64(repl.ReplicaID)] = tracker.Progress{
+			State: tracker.StateReplicate,
 			Match: 100,
 		}
 	}
 	return &raft.Status{
-		HardState: raftpb.HardState{Commit: 100},
-		SoftState: raft.SoftState{Lead: 1, RaftState: raft.StateLeader},
-		Progress:  prs,
+		BasicStatus: raft.BasicStatus{
+			HardState: raftpb.HardState{Commit: 100},
+			SoftState: raft.SoftState{Lead: 1, RaftState: raft.StateLeader},
+		},
+		Progress: prs,
 	}
 }
 
@@ -5995,14 +5998,15 @@ func TestChangeReplicasDuplicateError(t *testing.T) {
 
 	if err := tc.repl.ChangeReplicas(
 		context.Background(),
-		roachpb.ADD_REPLICA,
-		roachpb.ReplicationTarget{
-			NodeID:  tc.store.Ident.NodeID,
-			StoreID: 9999,
-		},
 		tc.repl.Desc(),
 		storagepb.ReasonRebalance,
 		"",
+		roachpb.MakeReplicationChanges(
+			roachpb.ADD_REPLICA,
+			roachpb.ReplicationTarget{
+				NodeID:  tc.store.Ident.NodeID,
+				StoreID: 9999,
+			}),
 	); err == nil || !strings.Contains(err.Error(), "node already has a replica") {
 		t.Fatalf("must not be able to add second replica to same node (err=%s)", err)
 	}
@@ -8079,14 +8083,14 @@ func TestReplicaEvaluationNotTxnMutation(t *testing.T) {
 func TestReplicaMetrics(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
-	progress := func(vals ...uint64) map[uint64]raft.Progress {
-		m := make(map[uint64]raft.Progress)
+	progress := func(vals ...uint64) map[uint64]tracker.Progress {
+		m := make(map[uint64]tracker.Progress)
 		for i, v := range vals {
-			m[uint64(i+1)] = raft.Progress{Match: v}
+			m[uint64(i+1)] = tracker.Progress{Match: v}
 		}
 		return m
 	}
-	status := func(lead uint64, progress map[uint64]raft.Progress) *raft.Status {
+	status := func(lead uint64, progress map[uint64]tracker.Progress) *raft.Status {
 		status := &raft.Status{
 			Progress: progress,
 		}
@@ -9008,20 +9012,22 @@ func TestShouldReplicaQuiesce(t *testing.T) {
 					},
 				},
 				status: &raft.Status{
-					ID: 1,
-					HardState: raftpb.HardState{
-						Commit: logIndex,
+					BasicStatus: raft.BasicStatus{
+						ID: 1,
+						HardState: raftpb.HardState{
+							Commit: logIndex,
+						},
+						SoftState: raft.SoftState{
+							RaftState: raft.StateLeader,
+						},
+						Applied:        logIndex,
+						LeadTransferee: 0,
 					},
-					SoftState: raft.SoftState{
-						RaftState: raft.StateLeader,
-					},
-					Applied: logIndex,
-					Progress: map[uint64]raft.Progress{
+					Progress: map[uint64]tracker.Progress{
 						1: {Match: logIndex},
 						2: {Match: logIndex},
 						3: {Match: logIndex},
 					},
-					LeadTransferee: 0,
 				},
 				lastIndex:      logIndex,
 				raftReady:      false,
@@ -9085,7 +9091,7 @@ func TestShouldReplicaQuiesce(t *testing.T) {
 	})
 	for _, i := range []uint64{1, 2, 3} {
 		test(false, func(q *testQuiescer) *testQuiescer {
-			q.status.Progress[i] = raft.Progress{Match: invalidIndex}
+			q.status.Progress[i] = tracker.Progress{Match: invalidIndex}
 			return q
 		})
 	}
@@ -9119,7 +9125,7 @@ func TestShouldReplicaQuiesce(t *testing.T) {
 	for _, i := range []uint64{1, 2, 3} {
 		test(true, func(q *testQuiescer) *testQuiescer {
 			q.livenessMap[roachpb.NodeID(i)] = IsLiveMapEntry{IsLive: false}
-			q.status.Progress[i] = raft.Progress{Match: invalidIndex}
+			q.status.Progress[i] = tracker.Progress{Match: invalidIndex}
 			return q
 		})
 	}
@@ -9828,27 +9834,35 @@ func TestReplicaShouldCampaignOnWake(t *testing.T) {
 	}
 
 	followerWithoutLeader := raft.Status{
-		SoftState: raft.SoftState{
-			RaftState: raft.StateFollower,
-			Lead:      0,
+		BasicStatus: raft.BasicStatus{
+			SoftState: raft.SoftState{
+				RaftState: raft.StateFollower,
+				Lead:      0,
+			},
 		},
 	}
 	followerWithLeader := raft.Status{
-		SoftState: raft.SoftState{
-			RaftState: raft.StateFollower,
-			Lead:      1,
+		BasicStatus: raft.BasicStatus{
+			SoftState: raft.SoftState{
+				RaftState: raft.StateFollower,
+				Lead:      1,
+			},
 		},
 	}
 	candidate := raft.Status{
-		SoftState: raft.SoftState{
-			RaftState: raft.StateCandidate,
-			Lead:      0,
+		BasicStatus: raft.BasicStatus{
+			SoftState: raft.SoftState{
+				RaftState: raft.StateCandidate,
+				Lead:      0,
+			},
 		},
 	}
 	leader := raft.Status{
-		SoftState: raft.SoftState{
-			RaftState: raft.StateLeader,
-			Lead:      1,
+		BasicStatus: raft.BasicStatus{
+			SoftState: raft.SoftState{
+				RaftState: raft.StateLeader,
+				Lead:      1,
+			},
 		},
 	}
 
@@ -10716,7 +10730,7 @@ func TestSplitSnapshotWarningStr(t *testing.T) {
 	assert.Equal(t, "", splitSnapshotWarningStr(12, status))
 
 	pr := status.Progress[2]
-	pr.State = raft.ProgressStateProbe
+	pr.State = tracker.StateProbe
 	status.Progress[2] = pr
 
 	assert.Equal(
@@ -10725,7 +10739,7 @@ func TestSplitSnapshotWarningStr(t *testing.T) {
 		splitSnapshotWarningStr(12, status),
 	)
 
-	pr.State = raft.ProgressStateSnapshot
+	pr.State = tracker.StateSnapshot
 
 	assert.Equal(
 		t,
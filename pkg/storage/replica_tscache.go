@@ -92,7 +92,7 @@ func (r *Replica) updateTimestampCache(
 
 				var readCache bool
 				switch pushee.Status {
-				case roachpb.PENDING:
+				case roachpb.PENDING, roachpb.STAGING:
 					readCache = true
 				case roachpb.ABORTED:
 					readCache = false
@@ -103,6 +103,18 @@ func (r *Replica) updateTimestampCache(
 				}
 				key := keys.TransactionKey(start, pushee.ID)
 				tc.Add(key, nil, pushee.Timestamp, t.PusherTxn.ID, readCache)
+			case *roachpb.RecoverTxnRequest:
+				// A successful RecoverTxn request may or may not have finalized
+				// the transaction that it was trying to recover. If so, then we
+				// add the transaction key to the write timestamp cache as a
+				// tombstone to ensure that replays and concurrent requests
+				// aren't able to recreate the transaction record. This parallels
+				// what we do in the EndTransaction request case.
+				recovered := br.Responses[i].GetInner().(*roachpb.RecoverTxnResponse).RecoveredTxn
+				if recovered.Status.IsFinalized() {
+					key := keys.TransactionKey(start, recovered.ID)
+					tc.Add(key, nil, ts, uuid.UUID{}, false /* readCache */)
+				}
 			case *roachpb.ConditionalPutRequest:
 				if pErr != nil {
 					// ConditionalPut still updates on ConditionFailedErrors.
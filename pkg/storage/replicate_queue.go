@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -218,7 +219,7 @@ func (rq *replicateQueue) shouldQueue(
 	}
 
 	if !rq.store.TestingKnobs().DisableReplicaRebalancing {
-		target, _ := rq.allocator.RebalanceTarget(ctx, zone, repl.RaftStatus(), rangeInfo, storeFilterThrottled)
+		target, _, _ := rq.allocator.RebalanceTarget(ctx, zone, repl.RaftStatus(), rangeInfo, storeFilterThrottled)
 		if target != nil {
 			log.VEventf(ctx, 2, "rebalance target found, enqueuing")
 			return true, 0
@@ -230,7 +231,7 @@ func (rq *replicateQueue) shouldQueue(
 	if lease, _ := repl.GetLease(); repl.IsLeaseValid(lease, now) {
 		if rq.canTransferLease() &&
 			rq.allocator.ShouldTransferLease(
-				ctx, zone, desc.Replicas, lease.Replica.StoreID, desc.RangeID, repl.leaseholderStats) {
+				ctx, zone, desc.Voters(), lease.Replica.StoreID, desc.RangeID, repl.leaseholderStats) {
 			log.VEventf(ctx, 2, "lease transfer needed, enqueuing")
 			return true, 0
 		}
@@ -289,10 +290,11 @@ func (rq *replicateQueue) processOneChange(
 	desc, zone := repl.DescAndZone()
 
 	// Avoid taking action if the range has too many dead replicas to make
-	// quorum.
-	liveReplicas, deadReplicas := rq.allocator.storePool.liveAndDeadReplicas(desc.RangeID, desc.Replicas)
+	// quorum. Learners don't vote and so don't count towards the quorum.
+	voterReplicas := desc.Voters()
+	liveReplicas, deadReplicas := rq.allocator.storePool.liveAndDeadReplicas(desc.RangeID, voterReplicas)
 	{
-		quorum := computeQuorum(len(desc.Replicas))
+		quorum := computeQuorum(len(voterReplicas))
 		if lr := len(liveReplicas); lr < quorum {
 			return false, newQuorumError(
 				"range requires a replication change, but lacks a quorum of live replicas (%d/%d)", lr, quorum)
@@ -320,8 +322,8 @@ func (rq *replicateQueue) processOneChange(
 		}
 
 		clusterNodes := rq.allocator.storePool.ClusterNodeCount()
-		need := GetNeededReplicas(*zone.NumReplicas, clusterNodes)
-		willHave := len(desc.Replicas) + 1
+		need := GetNeededReplicas(zone.GetNumVoters(), clusterNodes)
+		willHave := len(voterReplicas) + 1
 
 		// Only up-replicate if there are suitable allocation targets such
 		// that, either the replication goal is met, or it is possible to get to the
@@ -335,7 +337,7 @@ func (rq *replicateQueue) processOneChange(
 		if willHave < need && willHave%2 == 0 {
 			// This means we are going to up-replicate to an even replica state.
 			// Check if it is possible to go to an odd replica state beyond it.
-			oldPlusNewReplicas := append([]roachpb.ReplicaDescriptor(nil), desc.Replicas...)
+			oldPlusNewReplicas := append([]roachpb.ReplicaDescriptor(nil), voterReplicas...)
 			oldPlusNewReplicas = append(oldPlusNewReplicas, roachpb.ReplicaDescriptor{
 				NodeID:  newStore.Node.NodeID,
 				StoreID: newStore.StoreID,
@@ -374,7 +376,7 @@ func (rq *replicateQueue) processOneChange(
 		if timeutil.Since(lastAddedTime) > newReplicaGracePeriod {
 			lastReplAdded = 0
 		}
-		candidates := filterUnremovableReplicas(repl.RaftStatus(), desc.Replicas, lastReplAdded)
+		candidates := filterUnremovableReplicas(repl.RaftStatus(), voterReplicas, lastReplAdded)
 		log.VEventf(ctx, 3, "filtered unremovable replicas from %v to get %v as candidates for removal: %s",
 			desc.Replicas, candidates, rangeRaftProgress(repl.RaftStatus(), desc.Replicas))
 		if len(candidates) == 0 {
@@ -472,6 +474,112 @@ func (rq *replicateQueue) processOneChange(
 				return false, err
 			}
 		}
+	case AllocatorFinalizeAtomicReplicationChange:
+		// An atomic replication change was abandoned midway, leaving the range
+		// in a joint configuration. Leave it, which also removes the voters
+		// demoted by the change.
+		log.VEventf(ctx, 1, "finalizing atomic replication change")
+		if dryRun {
+			return false, nil
+		}
+		if _, err := repl.maybeLeaveAtomicChangeReplicas(
+			ctx, desc, storagepb.ReasonAbandonedJointConfig, "",
+		); err != nil {
+			return false, err
+		}
+	case AllocatorRemoveLearner:
+		// A learner is only expected to exist while a replica is being added.
+		// Finding one here means that the addition was abandoned midway, so
+		// the learner is removed before it can get in the way of other
+		// replication changes.
+		learners := desc.Learners()
+		if len(learners) == 0 {
+			log.VEventf(ctx, 1, "range of replica %s was identified as having learner replicas, "+
+				"but no learner replicas were found", repl)
+			break
+		}
+		learner := learners[0]
+		rq.metrics.RemoveReplicaCount.Inc(1)
+		log.VEventf(ctx, 1, "removing learner replica %+v from store", learner)
+		target := roachpb.ReplicationTarget{
+			NodeID:  learner.NodeID,
+			StoreID: learner.StoreID,
+		}
+		if err := rq.removeReplica(
+			ctx, repl, target, desc, storagepb.ReasonAbandonedLearner, "", dryRun,
+		); err != nil {
+			return false, err
+		}
+	case AllocatorAddNonVoter:
+		log.VEventf(ctx, 1, "adding a new non-voting replica")
+		newStore, details, err := rq.allocator.AllocateNonVoterTarget(ctx, zone, rangeInfo)
+		if err != nil {
+			return false, err
+		}
+		newReplica := roachpb.ReplicationTarget{
+			NodeID:  newStore.Node.NodeID,
+			StoreID: newStore.StoreID,
+		}
+		rq.metrics.AddReplicaCount.Inc(1)
+		log.VEventf(ctx, 1, "adding non-voting replica %+v due to under-replication: %s",
+			newReplica, rangeRaftProgress(repl.RaftStatus(), desc.Replicas))
+		if err := rq.changeReplicas(
+			ctx,
+			repl,
+			roachpb.MakeReplicationChanges(roachpb.ADD_NON_VOTER, newReplica),
+			desc,
+			SnapshotRequest_RECOVERY,
+			storagepb.ReasonRangeUnderReplicated,
+			details,
+			dryRun,
+		); err != nil {
+			return false, err
+		}
+	case AllocatorRemoveNonVoter:
+		// Dead and decommissioning non-voters are removed first. Otherwise the
+		// range has more non-voters than it needs, and the allocator picks one.
+		log.VEventf(ctx, 1, "removing a non-voting replica")
+		nonVoterReplicas := desc.NonVoters()
+		_, deadNonVoters := rq.allocator.storePool.liveAndDeadReplicas(desc.RangeID, nonVoterReplicas)
+		decommissioningNonVoters := rq.allocator.storePool.decommissioningReplicas(desc.RangeID, nonVoterReplicas)
+		var removeReplica roachpb.ReplicaDescriptor
+		var reason storagepb.RangeLogEventReason
+		var details string
+		switch {
+		case len(deadNonVoters) > 0:
+			removeReplica, reason = deadNonVoters[0], storagepb.ReasonStoreDead
+		case len(decommissioningNonVoters) > 0:
+			removeReplica, reason = decommissioningNonVoters[0], storagepb.ReasonStoreDecommissioning
+		case len(nonVoterReplicas) > 0:
+			var err error
+			removeReplica, details, err = rq.allocator.RemoveNonVoterTarget(ctx, zone, nonVoterReplicas, rangeInfo)
+			if err != nil {
+				return false, err
+			}
+			reason = storagepb.ReasonRangeOverReplicated
+		default:
+			log.VEventf(ctx, 1, "range of replica %s was identified as having non-voting replicas to remove, "+
+				"but no non-voting replicas were found", repl)
+			return false, nil
+		}
+		rq.metrics.RemoveReplicaCount.Inc(1)
+		log.VEventf(ctx, 1, "removing non-voting replica %+v (%s)", removeReplica, reason)
+		target := roachpb.ReplicationTarget{
+			NodeID:  removeReplica.NodeID,
+			StoreID: removeReplica.StoreID,
+		}
+		if err := rq.changeReplicas(
+			ctx,
+			repl,
+			roachpb.MakeReplicationChanges(roachpb.REMOVE_NON_VOTER, target),
+			desc,
+			SnapshotRequest_REBALANCE,
+			reason,
+			details,
+			dryRun,
+		); err != nil {
+			return false, err
+		}
 	case AllocatorRemoveDead:
 		log.VEventf(ctx, 1, "removing a dead replica")
 		if len(deadReplicas) == 0 {
@@ -496,7 +604,7 @@ func (rq *replicateQueue) processOneChange(
 		log.VEventf(ctx, 1, "allocator noop - considering a rebalance or lease transfer")
 
 		if !rq.store.TestingKnobs().DisableReplicaRebalancing {
-			rebalanceStore, details := rq.allocator.RebalanceTarget(
+			rebalanceStore, removeReplica, details := rq.allocator.RebalanceTarget(
 				ctx, zone, repl.RaftStatus(), rangeInfo, storeFilterThrottled)
 			if rebalanceStore == nil {
 				log.VEventf(ctx, 1, "no suitable rebalance target")
@@ -505,13 +613,27 @@ func (rq *replicateQueue) processOneChange(
 					NodeID:  rebalanceStore.Node.NodeID,
 					StoreID: rebalanceStore.StoreID,
 				}
+				chgs := roachpb.MakeReplicationChanges(roachpb.ADD_REPLICA, rebalanceReplica)
+				// Swap the replica to be removed for the new one in a single atomic
+				// replication change, unless that replica is our own: we hold the
+				// lease and can't remove ourselves. In that case, only the new
+				// replica is added and the over-replicated range is taken care of
+				// by a later AllocatorRemove, which transfers the lease away first.
+				st := repl.store.ClusterSettings()
+				if removeReplica.StoreID != 0 && removeReplica.StoreID != repl.store.StoreID() &&
+					useLearnerReplicas.Get(&st.SV) && st.Version.IsActive(cluster.VersionAtomicChangeReplicas) {
+					chgs = append(chgs, roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, roachpb.ReplicationTarget{
+						NodeID:  removeReplica.NodeID,
+						StoreID: removeReplica.StoreID,
+					})...)
+				}
 				rq.metrics.RebalanceReplicaCount.Inc(1)
-				log.VEventf(ctx, 1, "rebalancing to %+v: %s",
-					rebalanceReplica, rangeRaftProgress(repl.RaftStatus(), desc.Replicas))
-				if err := rq.addReplica(
+				log.VEventf(ctx, 1, "rebalancing %+v: %s",
+					chgs, rangeRaftProgress(repl.RaftStatus(), desc.Replicas))
+				if err := rq.changeReplicas(
 					ctx,
 					repl,
-					rebalanceReplica,
+					chgs,
 					desc,
 					SnapshotRequest_REBALANCE,
 					storagepb.ReasonRebalance,
@@ -568,7 +690,8 @@ func (rq *replicateQueue) findTargetAndTransferLease(
 	zone *config.ZoneConfig,
 	opts transferLeaseOptions,
 ) (bool, error) {
-	candidates := filterBehindReplicas(repl.RaftStatus(), desc.Replicas)
+	// Learners can't hold the lease, so they are never candidates.
+	candidates := filterBehindReplicas(repl.RaftStatus(), desc.Voters())
 	target := rq.allocator.TransferLeaseTarget(
 		ctx,
 		zone,
@@ -621,15 +744,10 @@ func (rq *replicateQueue) addReplica(
 	details string,
 	dryRun bool,
 ) error {
-	if dryRun {
-		return nil
-	}
-	if err := repl.changeReplicas(ctx, roachpb.ADD_REPLICA, target, desc, priority, reason, details); err != nil {
-		return err
-	}
-	rangeInfo := rangeInfoForRepl(repl, desc)
-	rq.allocator.storePool.updateLocalStoreAfterRebalance(target.StoreID, rangeInfo, roachpb.ADD_REPLICA)
-	return nil
+	return rq.changeReplicas(
+		ctx, repl, roachpb.MakeReplicationChanges(roachpb.ADD_REPLICA, target),
+		desc, priority, reason, details, dryRun,
+	)
 }
 
 func (rq *replicateQueue) removeReplica(
@@ -640,15 +758,33 @@ func (rq *replicateQueue) removeReplica(
 	reason storagepb.RangeLogEventReason,
 	details string,
 	dryRun bool,
+) error {
+	return rq.changeReplicas(
+		ctx, repl, roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, target),
+		desc, SnapshotRequest_REBALANCE, reason, details, dryRun,
+	)
+}
+
+func (rq *replicateQueue) changeReplicas(
+	ctx context.Context,
+	repl *Replica,
+	chgs roachpb.ReplicationChanges,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason storagepb.RangeLogEventReason,
+	details string,
+	dryRun bool,
 ) error {
 	if dryRun {
 		return nil
 	}
-	if err := repl.ChangeReplicas(ctx, roachpb.REMOVE_REPLICA, target, desc, reason, details); err != nil {
+	if err := repl.changeReplicas(ctx, desc, priority, reason, details, chgs); err != nil {
 		return err
 	}
 	rangeInfo := rangeInfoForRepl(repl, desc)
-	rq.allocator.storePool.updateLocalStoreAfterRebalance(target.StoreID, rangeInfo, roachpb.REMOVE_REPLICA)
+	for _, chg := range chgs {
+		rq.allocator.storePool.updateLocalStoreAfterRebalance(chg.Target.StoreID, rangeInfo, chg.ChangeType)
+	}
 	return nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 type splitDelayHelperI interface {
@@ -115,7 +116,7 @@ func maybeDelaySplitToAvoidSnapshot(ctx context.Context, sdh splitDelayHelperI)
 				continue
 			}
 
-			if pr.State != raft.ProgressStateReplicate {
+			if pr.State != tracker.StateReplicate {
 				if !pr.RecentActive {
 					if ticks == 0 {
 						// Having set done = false, we make sure we're not exiting early.
@@ -26,4 +26,6 @@ const (
 	ReasonStoreDecommissioning RangeLogEventReason = "store decommissioning"
 	ReasonRebalance            RangeLogEventReason = "rebalance"
 	ReasonAdminRequest         RangeLogEventReason = "admin request"
+	ReasonAbandonedLearner     RangeLogEventReason = "abandoned learner replica"
+	ReasonAbandonedJointConfig RangeLogEventReason = "abandoned joint configuration"
 )
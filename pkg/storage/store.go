@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/idalloc"
 	"github.com/cockroachdb/cockroach/pkg/storage/intentresolver"
+	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/storage/raftentry"
 	"github.com/cockroachdb/cockroach/pkg/storage/rditer"
 	"github.com/cockroachdb/cockroach/pkg/storage/stateloader"
@@ -96,9 +97,76 @@ const (
 	systemDataGossipInterval = 1 * time.Minute
 )
 
-var changeTypeInternalToRaft = map[roachpb.ReplicaChangeType]raftpb.ConfChangeType{
-	roachpb.ADD_REPLICA:    raftpb.ConfChangeAddNode,
-	roachpb.REMOVE_REPLICA: raftpb.ConfChangeRemoveNode,
+// confChangeFromTrigger returns the Raft configuration change which carries
+// out the provided ChangeReplicasTrigger, with the given encoded
+// ConfChangeContext attached.
+//
+// Triggers which change a single replica outside of a joint configuration
+// translate into a simple ConfChange. A replica is added as a learner if its
+// type says so, and adding a replica which is already a learner as a voter
+// promotes it. Triggers which make a range descriptor enter a joint
+// configuration translate into a ConfChangeV2 which explicitly enters it, and
+// triggers which neither add nor remove replicas leave it.
+func confChangeFromTrigger(
+	crt *roachpb.ChangeReplicasTrigger, encodedCtx []byte,
+) (raftpb.ConfChangeI, error) {
+	added, removed := crt.Added(), crt.Removed()
+	updated := roachpb.RangeDescriptor{Replicas: crt.UpdatedReplicas}
+	if !updated.InAtomicReplicationChange() {
+		if len(added)+len(removed) == 0 {
+			// Leave the joint configuration.
+			return raftpb.ConfChangeV2{Context: encodedCtx}, nil
+		}
+		if len(added)+len(removed) > 1 {
+			return nil, errors.Errorf(
+				"more than one change requires a joint configuration: %s", crt)
+		}
+		cc := raftpb.ConfChange{Context: encodedCtx}
+		if len(added) == 1 {
+			cc.NodeID = uint64(added[0].ReplicaID)
+			cc.Type = raftpb.ConfChangeAddNode
+			if typ := added[0].GetType(); typ == roachpb.ReplicaType_LEARNER ||
+				typ == roachpb.ReplicaType_NON_VOTER {
+				cc.Type = raftpb.ConfChangeAddLearnerNode
+			}
+		} else {
+			cc.NodeID = uint64(removed[0].ReplicaID)
+			cc.Type = raftpb.ConfChangeRemoveNode
+		}
+		return cc, nil
+	}
+
+	cc := raftpb.ConfChangeV2{
+		Transition: raftpb.ConfChangeTransitionJointExplicit,
+		Context:    encodedCtx,
+	}
+	for _, rDesc := range added {
+		if rep, ok := updated.GetReplicaDescriptorByID(rDesc.ReplicaID); ok {
+			rDesc = rep
+		}
+		typ := raftpb.ConfChangeAddNode
+		if t := rDesc.GetType(); t == roachpb.ReplicaType_LEARNER || t == roachpb.ReplicaType_NON_VOTER {
+			typ = raftpb.ConfChangeAddLearnerNode
+		}
+		cc.Changes = append(cc.Changes, raftpb.ConfChangeSingle{
+			Type:   typ,
+			NodeID: uint64(rDesc.ReplicaID),
+		})
+	}
+	for _, rDesc := range removed {
+		// A voter which remains in the descriptor as a demoting voter becomes
+		// a learner in the incoming configuration.
+		typ := raftpb.ConfChangeRemoveNode
+		if rep, ok := updated.GetReplicaDescriptorByID(rDesc.ReplicaID); ok &&
+			rep.GetType() == roachpb.ReplicaType_VOTER_DEMOTING {
+			typ = raftpb.ConfChangeAddLearnerNode
+		}
+		cc.Changes = append(cc.Changes, raftpb.ConfChangeSingle{
+			Type:   typ,
+			NodeID: uint64(rDesc.ReplicaID),
+		})
+	}
+	return cc, nil
 }
 
 var storeSchedulerConcurrency = envutil.EnvOrDefaultInt(
@@ -599,6 +667,11 @@ type StoreConfig struct {
 
 	ClosedTimestamp *container.Container
 
+	// ProtectedTimestampCache is consulted by the GC queue to avoid garbage
+	// collecting data which is protected by a protected timestamp record. If
+	// nil, no data is protected.
+	ProtectedTimestampCache protectedts.Cache
+
 	// SQLExecutor is used by the store to execute SQL statements.
 	SQLExecutor sqlutil.InternalExecutor
 
@@ -3078,9 +3151,9 @@ func (s *Store) Send(
 	defer func() {
 		if cleanupAfterWriteIntentError != nil {
 			// This request wrote an intent only if there was no error, the request
-			// is transactional, the transaction is still pending, and the request
-			// wasn't read-only.
-			if pErr == nil && ba.Txn != nil && br.Txn.Status == roachpb.PENDING && !ba.IsReadOnly() {
+			// is transactional, the transaction is not yet finalized, and the
+			// request wasn't read-only.
+			if pErr == nil && ba.Txn != nil && !br.Txn.Status.IsFinalized() && !ba.IsReadOnly() {
 				cleanupAfterWriteIntentError(nil, &br.Txn.TxnMeta)
 			} else {
 				cleanupAfterWriteIntentError(nil, nil)
@@ -3164,12 +3237,32 @@ func (s *Store) Send(
 			repl.txnWaitQueue.Enqueue(&t.PusheeTxn)
 			pErr = nil
 
+		case *roachpb.IndeterminateCommitError:
+			// On an indeterminate commit error, attempt to recover and finalize
+			// the stuck transaction. Retry immediately if successful.
+			if _, recErr := s.intentResolver.RecoverIndeterminateCommit(ctx, t); recErr != nil {
+				// Do not propagate ambiguous results; assume success and retry
+				// the original op.
+				if _, ok := recErr.GetDetail().(*roachpb.AmbiguousResultError); !ok {
+					// Preserve the error index.
+					recErr.Index = pErr.Index
+					return nil, recErr
+				}
+			}
+			// We've recovered the transaction that blocked the push; retry
+			// the command.
+			pErr = nil
+
 		case *roachpb.WriteIntentError:
 			// Process and resolve write intent error. We do this here because
 			// this is the code path with the requesting client waiting.
 			if pErr.Index != nil {
 				var pushType roachpb.PushTxnType
-				if ba.IsWrite() {
+				if ba.WaitPolicy == roachpb.ERROR_ON_CONFLICT {
+					// The request does not want to wait on conflicting intents. Only
+					// attempt to clean up abandoned or finalized transactions.
+					pushType = roachpb.PUSH_TOUCH
+				} else if ba.IsWrite() {
 					pushType = roachpb.PUSH_ABORT
 				} else {
 					pushType = roachpb.PUSH_TIMESTAMP
@@ -3201,8 +3294,15 @@ func (s *Store) Send(
 				if cleanupAfterWriteIntentError != nil {
 					cleanupAfterWriteIntentError(t, nil)
 				}
+				wiPErr := pErr
 				if cleanupAfterWriteIntentError, pErr =
 					s.intentResolver.ProcessWriteIntentError(ctx, pErr, args, h, pushType); pErr != nil {
+					if _, ok := pErr.GetDetail().(*roachpb.TransactionPushError); ok &&
+						ba.WaitPolicy == roachpb.ERROR_ON_CONFLICT {
+						// The conflicting transaction is still active. Return the
+						// original error to the client instead of waiting for it.
+						return nil, wiPErr
+					}
 					// Do not propagate ambiguous results; assume success and retry original op.
 					if _, ok := pErr.GetDetail().(*roachpb.AmbiguousResultError); !ok {
 						// Preserve the error index.
@@ -3645,7 +3745,7 @@ func (s *Store) processRaftSnapshotRequest(
 					appliedIndex,
 					r.store.cfg,
 					&raftLogger{ctx: ctx},
-				), nil)
+				))
 			if err != nil {
 				return roachpb.NewError(err)
 			}
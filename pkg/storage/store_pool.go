@@ -347,11 +347,11 @@ func (sp *StorePool) updateLocalStoreAfterRebalance(
 		return
 	}
 	switch changeType {
-	case roachpb.ADD_REPLICA:
+	case roachpb.ADD_REPLICA, roachpb.ADD_NON_VOTER:
 		detail.desc.Capacity.RangeCount++
 		detail.desc.Capacity.LogicalBytes += rangeInfo.LogicalBytes
 		detail.desc.Capacity.WritesPerSecond += rangeInfo.WritesPerSecond
-	case roachpb.REMOVE_REPLICA:
+	case roachpb.REMOVE_REPLICA, roachpb.REMOVE_NON_VOTER:
 		detail.desc.Capacity.RangeCount--
 		if detail.desc.Capacity.LogicalBytes <= rangeInfo.LogicalBytes {
 			detail.desc.Capacity.LogicalBytes = 0
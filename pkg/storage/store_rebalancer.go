@@ -311,6 +311,15 @@ func (sr *StoreRebalancer) rebalanceStore(
 		}
 
 		descBeforeRebalance := replWithStats.repl.Desc()
+		if descBeforeRebalance.InAtomicReplicationChange() {
+			// Leave the range alone until the replicate queue finishes the
+			// replication change it is in the middle of.
+			log.VEventf(ctx, 1, "not rebalancing r%d: in the middle of an atomic replication change",
+				replWithStats.repl.RangeID)
+			continue
+		}
+		// AdminRelocateRange swaps each replica to be removed for a new one in
+		// a single atomic replication change, if the cluster version permits it.
 		log.VEventf(ctx, 1, "rebalancing r%d (%.2f qps) from %v to %v to better balance load",
 			replWithStats.repl.RangeID, replWithStats.qps, descBeforeRebalance.Replicas, targets)
 		replCtx, cancel := context.WithTimeout(replWithStats.repl.AnnotateCtx(ctx), sr.rq.processTimeout)
@@ -397,9 +406,9 @@ func (sr *StoreRebalancer) chooseLeaseToTransfer(
 		log.VEventf(ctx, 3, "considering lease transfer for r%d with %.2f qps",
 			desc.RangeID, replWithStats.qps)
 
-		// Check all the other replicas in order of increasing qps.
-		replicas := make([]roachpb.ReplicaDescriptor, len(desc.Replicas))
-		copy(replicas, desc.Replicas)
+		// Check all the other replicas in order of increasing qps. Learners
+		// can't hold the lease and are not considered.
+		replicas := desc.Voters()
 		sort.Slice(replicas, func(i, j int) bool {
 			var iQPS, jQPS float64
 			if desc := storeMap[replicas[i].StoreID]; desc != nil {
@@ -503,7 +512,16 @@ func (sr *StoreRebalancer) chooseReplicaToRebalance(
 		log.VEventf(ctx, 3, "considering replica rebalance for r%d with %.2f qps",
 			desc.RangeID, replWithStats.qps)
 
+		// AdminRelocateRange only knows about voters and would remove the
+		// non-voting replicas of a range, so such ranges are left to the
+		// replicate queue.
+		if len(desc.NonVoters()) > 0 {
+			log.VEventf(ctx, 3, "skipping r%d: it has non-voting replicas", desc.RangeID)
+			continue
+		}
+
 		clusterNodes := sr.rq.allocator.storePool.ClusterNodeCount()
+		zone = voterZone(zone)
 		desiredReplicas := GetNeededReplicas(*zone.NumReplicas, clusterNodes)
 		targets := make([]roachpb.ReplicationTarget, 0, desiredReplicas)
 		targetReplicas := make([]roachpb.ReplicaDescriptor, 0, desiredReplicas)
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/gogo/protobuf/proto"
 	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/tracker"
 )
 
 var (
@@ -140,14 +141,14 @@ func TestChooseLeaseToTransfer(t *testing.T) {
 	// raft status with one that always returns all replicas as up to date.
 	sr.getRaftStatusFn = func(r *Replica) *raft.Status {
 		status := &raft.Status{
-			Progress: make(map[uint64]raft.Progress),
+			Progress: make(map[uint64]tracker.Progress),
 		}
 		status.Lead = uint64(r.ReplicaID())
 		status.Commit = 1
 		for _, replica := range r.Desc().Replicas {
-			status.Progress[uint64(replica.ReplicaID)] = raft.Progress{
+			status.Progress[uint64(replica.ReplicaID)] = tracker.Progress{
 				Match: 1,
-				State: raft.ProgressStateReplicate,
+				State: tracker.StateReplicate,
 			}
 		}
 		return status
@@ -223,14 +224,14 @@ func TestChooseReplicaToRebalance(t *testing.T) {
 	// raft status with one that always returns all replicas as up to date.
 	sr.getRaftStatusFn = func(r *Replica) *raft.Status {
 		status := &raft.Status{
-			Progress: make(map[uint64]raft.Progress),
+			Progress: make(map[uint64]tracker.Progress),
 		}
 		status.Lead = uint64(r.ReplicaID())
 		status.Commit = 1
 		for _, replica := range r.Desc().Replicas {
-			status.Progress[uint64(replica.ReplicaID)] = raft.Progress{
+			status.Progress[uint64(replica.ReplicaID)] = tracker.Progress{
 				Match: 1,
-				State: raft.ProgressStateReplicate,
+				State: tracker.StateReplicate,
 			}
 		}
 		return status
@@ -338,7 +339,7 @@ func TestNoLeaseTransferToBehindReplicas(t *testing.T) {
 	// are caught up). We thus shouldn't transfer a lease to s5.
 	sr.getRaftStatusFn = func(r *Replica) *raft.Status {
 		status := &raft.Status{
-			Progress: make(map[uint64]raft.Progress),
+			Progress: make(map[uint64]tracker.Progress),
 		}
 		status.Lead = uint64(r.ReplicaID())
 		status.Commit = 1
@@ -347,9 +348,9 @@ func TestNoLeaseTransferToBehindReplicas(t *testing.T) {
 			if replica.StoreID == roachpb.StoreID(5) {
 				match = 0
 			}
-			status.Progress[uint64(replica.ReplicaID)] = raft.Progress{
+			status.Progress[uint64(replica.ReplicaID)] = tracker.Progress{
 				Match: match,
-				State: raft.ProgressStateReplicate,
+				State: tracker.StateReplicate,
 			}
 		}
 		return status
@@ -3254,6 +3254,82 @@ func TestSnapshotRateLimit(t *testing.T) {
 	}
 }
 
+// TestConfChangeFromTrigger verifies that the Raft configuration changes
+// derived from ChangeReplicasTriggers enter and leave joint configurations as
+// the range descriptors do.
+func TestConfChangeFromTrigger(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	replica := func(id roachpb.ReplicaID, typ roachpb.ReplicaType) roachpb.ReplicaDescriptor {
+		return roachpb.ReplicaDescriptor{
+			NodeID:    roachpb.NodeID(id),
+			StoreID:   roachpb.StoreID(id),
+			ReplicaID: id,
+			Type:      typ.Enum(),
+		}
+	}
+	encodedCtx := []byte("encodedCtx")
+
+	// A legacy trigger adding a learner.
+	learner := replica(4, roachpb.ReplicaType_LEARNER)
+	cc, err := confChangeFromTrigger(&roachpb.ChangeReplicasTrigger{
+		ChangeType:      roachpb.ADD_REPLICA,
+		Replica:         learner,
+		UpdatedReplicas: []roachpb.ReplicaDescriptor{replica(1, roachpb.ReplicaType_VOTER), learner},
+	}, encodedCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (raftpb.ConfChange{
+		Type: raftpb.ConfChangeAddLearnerNode, NodeID: 4, Context: encodedCtx,
+	}); !reflect.DeepEqual(cc, exp) {
+		t.Errorf("expected %+v, got %+v", exp, cc)
+	}
+
+	// Swapping replica 2 for replica 4 enters a joint configuration.
+	incoming := replica(4, roachpb.ReplicaType_VOTER_INCOMING)
+	demoting := replica(2, roachpb.ReplicaType_VOTER_DEMOTING)
+	cc, err = confChangeFromTrigger(&roachpb.ChangeReplicasTrigger{
+		UpdatedReplicas:         []roachpb.ReplicaDescriptor{replica(1, roachpb.ReplicaType_VOTER), demoting, incoming},
+		InternalAddedReplicas:   []roachpb.ReplicaDescriptor{incoming},
+		InternalRemovedReplicas: []roachpb.ReplicaDescriptor{demoting},
+	}, encodedCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (raftpb.ConfChangeV2{
+		Transition: raftpb.ConfChangeTransitionJointExplicit,
+		Changes: []raftpb.ConfChangeSingle{
+			{Type: raftpb.ConfChangeAddNode, NodeID: 4},
+			{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 2},
+		},
+		Context: encodedCtx,
+	}); !reflect.DeepEqual(cc, exp) {
+		t.Errorf("expected %+v, got %+v", exp, cc)
+	}
+
+	// Leaving the joint configuration.
+	cc, err = confChangeFromTrigger(&roachpb.ChangeReplicasTrigger{
+		UpdatedReplicas: []roachpb.ReplicaDescriptor{
+			replica(1, roachpb.ReplicaType_VOTER), replica(2, roachpb.ReplicaType_LEARNER), replica(4, roachpb.ReplicaType_VOTER),
+		},
+	}, encodedCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (raftpb.ConfChangeV2{Context: encodedCtx}); !reflect.DeepEqual(cc, exp) {
+		t.Errorf("expected %+v, got %+v", exp, cc)
+	}
+
+	// Several changes can't be made outside of a joint configuration.
+	if _, err := confChangeFromTrigger(&roachpb.ChangeReplicasTrigger{
+		UpdatedReplicas:       []roachpb.ReplicaDescriptor{replica(1, roachpb.ReplicaType_VOTER)},
+		InternalAddedReplicas: []roachpb.ReplicaDescriptor{replica(2, roachpb.ReplicaType_LEARNER), replica(3, roachpb.ReplicaType_LEARNER)},
+	}, encodedCtx); !testutils.IsError(err, "more than one change requires a joint configuration") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func BenchmarkStoreGetReplica(b *testing.B) {
 	stopper := stop.NewStopper()
 	defer stopper.Stop(context.TODO())
@@ -353,7 +353,7 @@ func (tc *TestCluster) changeReplicas(
 ) (roachpb.RangeDescriptor, error) {
 	ctx := context.TODO()
 	if err := tc.Servers[0].DB().AdminChangeReplicas(
-		ctx, startKey.AsRawKey(), changeType, targets,
+		ctx, startKey.AsRawKey(), roachpb.MakeReplicationChanges(changeType, targets...),
 	); err != nil {
 		return roachpb.RangeDescriptor{}, errors.Wrap(err, "AdminChangeReplicas error")
 	}
@@ -64,6 +64,7 @@ const typeOptions = [
   { value: JobType.SCHEMA_CHANGE.toString(), label: "Schema Changes" },
   { value: JobType.CHANGEFEED.toString(), label: "Changefeed"},
   { value: JobType.CREATE_STATS.toString(), label: "Statistics Creation"},
+  { value: JobType.REFRESH_MATERIALIZED_VIEW.toString(), label: "Materialized View Refreshes"},
 ];
 
 const typeSetting = new LocalSetting<AdminUIState, number>(
@@ -1208,6 +1208,7 @@ const (
 	Tuple        Type = 16
 	BitArray     Type = 17
 	BitArrayDesc Type = 18 // BitArray encoded descendingly
+	TimeTZ       Type = 19
 )
 
 // typMap maps an encoded type byte to a decoded Type. It's got 256 slots, one
@@ -1823,6 +1824,22 @@ func EncodeUntaggedTimeValue(appendTo []byte, t time.Time) []byte {
 	return EncodeNonsortingStdlibVarint(appendTo, int64(t.Nanosecond()))
 }
 
+// EncodeTimeTZValue encodes a time of day in microseconds and a zone offset in
+// seconds east of UTC with its value tag, appends it to the supplied buffer,
+// and returns the final buffer.
+func EncodeTimeTZValue(appendTo []byte, colID uint32, micros int64, offsetSecs int32) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, TimeTZ)
+	return EncodeUntaggedTimeTZValue(appendTo, micros, offsetSecs)
+}
+
+// EncodeUntaggedTimeTZValue encodes a time of day in microseconds and a zone
+// offset in seconds east of UTC, appends it to the supplied buffer, and
+// returns the final buffer.
+func EncodeUntaggedTimeTZValue(appendTo []byte, micros int64, offsetSecs int32) []byte {
+	appendTo = EncodeNonsortingStdlibVarint(appendTo, micros)
+	return EncodeNonsortingStdlibVarint(appendTo, int64(offsetSecs))
+}
+
 // EncodeDecimalValue encodes an apd.Decimal value with its value tag, appends
 // it to the supplied buffer, and returns the final buffer.
 func EncodeDecimalValue(appendTo []byte, colID uint32, d *apd.Decimal) []byte {
@@ -2056,6 +2073,31 @@ func DecodeUntaggedTimeValue(b []byte) (remaining []byte, t time.Time, err error
 	return b, timeutil.Unix(sec, nsec), nil
 }
 
+// DecodeTimeTZValue decodes a value encoded by EncodeTimeTZValue.
+func DecodeTimeTZValue(b []byte) (remaining []byte, micros int64, offsetSecs int32, err error) {
+	b, err = decodeValueTypeAssert(b, TimeTZ)
+	if err != nil {
+		return b, 0, 0, err
+	}
+	return DecodeUntaggedTimeTZValue(b)
+}
+
+// DecodeUntaggedTimeTZValue decodes a value encoded by EncodeUntaggedTimeTZValue.
+func DecodeUntaggedTimeTZValue(
+	b []byte,
+) (remaining []byte, micros int64, offsetSecs int32, err error) {
+	var offset int64
+	b, _, micros, err = DecodeNonsortingStdlibVarint(b)
+	if err != nil {
+		return b, 0, 0, err
+	}
+	b, _, offset, err = DecodeNonsortingStdlibVarint(b)
+	if err != nil {
+		return b, 0, 0, err
+	}
+	return b, micros, int32(offset), nil
+}
+
 // DecodeDecimalValue decodes a value encoded by EncodeDecimalValue.
 func DecodeDecimalValue(b []byte) (remaining []byte, d apd.Decimal, err error) {
 	b, err = decodeValueTypeAssert(b, Decimal)
@@ -2261,7 +2303,7 @@ func PeekValueLengthWithOffsetsAndType(b []byte, dataOffset int, typ Type) (leng
 	case Decimal:
 		_, n, i, err := DecodeNonsortingStdlibUvarint(b)
 		return dataOffset + n + int(i), err
-	case Time:
+	case Time, TimeTZ:
 		n, err := getMultiNonsortingVarintLen(b, 2)
 		return dataOffset + n, err
 	case Duration:
@@ -2352,6 +2394,14 @@ func PrettyPrintValueEncoded(b []byte) ([]byte, string, error) {
 			return b, "", err
 		}
 		return b, t.UTC().Format(time.RFC3339Nano), nil
+	case TimeTZ:
+		var micros int64
+		var offsetSecs int32
+		b, micros, offsetSecs, err = DecodeTimeTZValue(b)
+		if err != nil {
+			return b, "", err
+		}
+		return b, fmt.Sprintf("%dus%+ds", micros, offsetSecs), nil
 	case Duration:
 		var d duration.Duration
 		b, d, err = DecodeDurationValue(b)
@@ -102,6 +102,17 @@ func (t TimeOfDay) Add(d duration.Duration) TimeOfDay {
 	return FromInt(int64(t) + d.Nanos()/nanosPerMicro)
 }
 
+// Round rounds t to the nearest multiple of precision. A value which would
+// round up to midnight of the next day is truncated instead.
+func (t TimeOfDay) Round(precision time.Duration) TimeOfDay {
+	d := time.Duration(t) * time.Microsecond
+	r := d.Round(precision)
+	if r >= microsecondsPerDay*time.Microsecond {
+		r = d.Truncate(precision)
+	}
+	return TimeOfDay(r / time.Microsecond)
+}
+
 // Difference returns the interval between t1 and t2, which may be negative.
 func Difference(t1 TimeOfDay, t2 TimeOfDay) duration.Duration {
 	return duration.MakeDuration(int64(t1-t2)*nanosPerMicro, 0, 0)
@@ -113,3 +113,26 @@ func TestDifference(t *testing.T) {
 		})
 	}
 }
+
+func TestRound(t *testing.T) {
+	testData := []struct {
+		t         TimeOfDay
+		precision time.Duration
+		exp       TimeOfDay
+	}{
+		{New(12, 0, 0, 123456), time.Microsecond, New(12, 0, 0, 123456)},
+		{New(12, 0, 0, 123456), time.Millisecond, New(12, 0, 0, 123000)},
+		{New(12, 0, 0, 123500), time.Millisecond, New(12, 0, 0, 124000)},
+		{New(12, 0, 0, 500000), time.Second, New(12, 0, 1, 0)},
+		{New(12, 0, 59, 999999), time.Second, New(12, 1, 0, 0)},
+		{Max, time.Second, New(23, 59, 59, 0)},
+	}
+	for _, td := range testData {
+		t.Run(fmt.Sprintf("%s,%s", td.t, td.precision), func(t *testing.T) {
+			actual := td.t.Round(td.precision)
+			if actual != td.exp {
+				t.Errorf("expected %s, got %s", td.exp, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DefaultConfig is the text search configuration used by the text search
+// functions when none is specified.
+//
+// The only configuration currently supported is simple, which splits
+// documents into words of letters and digits and lowercases them. Unlike the
+// language-specific configurations of Postgres, it does not stem words or
+// remove stop words.
+const DefaultConfig = "simple"
+
+// checkConfig returns an error if config is not a supported text search
+// configuration.
+func checkConfig(config string) error {
+	switch config {
+	case "simple", "pg_catalog.simple":
+		return nil
+	}
+	return pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+		"text search configuration %q does not exist", config)
+}
+
+// words splits text into the words recognized by the simple configuration.
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalizeWords returns the lexemes for the words of text in the simple
+// configuration. Words too long to be lexemes are skipped.
+func normalizeWords(text string) []string {
+	ws := words(text)
+	lexemes := ws[:0]
+	for _, w := range ws {
+		if len(w) <= maxLexemeLength {
+			lexemes = append(lexemes, strings.ToLower(w))
+		}
+	}
+	return lexemes
+}
+
+// ToTSVector converts the document text to a TSVector using the given text
+// search configuration. Each lexeme records the positions of the words it was
+// produced from.
+func ToTSVector(config string, text string) (TSVector, error) {
+	if err := checkConfig(config); err != nil {
+		return nil, err
+	}
+	var lexemes []Lexeme
+	pos := 0
+	for _, w := range words(text) {
+		if pos < MaxPosition {
+			pos++
+		}
+		if len(w) > maxLexemeLength {
+			continue
+		}
+		lexemes = append(lexemes, Lexeme{
+			Word:      strings.ToLower(w),
+			Positions: []Position{{Pos: uint16(pos)}},
+		})
+	}
+	return MakeTSVector(lexemes), nil
+}
+
+// ToTSQuery parses the query text like ParseTSQuery, and normalizes its
+// operands using the given text search configuration. An operand which
+// normalizes to several lexemes matches documents containing all of them.
+func ToTSQuery(config string, text string) (TSQuery, error) {
+	if err := checkConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	return parseTSQuery(text, normalizeWords)
+}
+
+// PlainToTSQuery returns the query matching documents which contain all the
+// lexemes of text, normalized using the given text search configuration.
+// Operators and punctuation in text are ignored.
+func PlainToTSQuery(config string, text string) (TSQuery, error) {
+	if err := checkConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	var root *QueryNode
+	for _, lexeme := range normalizeWords(text) {
+		root = makeBinaryNode(QueryAnd, root, &QueryNode{Op: QueryLexeme, Lexeme: lexeme})
+	}
+	return TSQuery{Root: root}, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+)
+
+// The storage encoding of a TSVector is the number of lexemes followed by
+// each lexeme: the length of its word, the word, the number of positions and
+// the positions. A position is encoded along with its weight as pos<<2|weight.
+// All numbers are encoded as uvarints.
+//
+// The storage encoding of a TSQuery is the prefix-order encoding of its nodes.
+// Each node starts with its QueryOp. Lexeme nodes continue with the length of
+// the lexeme, the lexeme, a byte which is 1 for prefix matches, and the
+// WeightSet. The empty query is encoded as an empty byte slice.
+
+// EncodeTSVector appends the storage encoding of v to appendTo.
+func EncodeTSVector(appendTo []byte, v TSVector) []byte {
+	appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(v)))
+	for _, l := range v {
+		appendTo = encodeString(appendTo, l.Word)
+		appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(l.Positions)))
+		for _, p := range l.Positions {
+			appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(p.Pos)<<2|uint64(p.Weight))
+		}
+	}
+	return appendTo
+}
+
+// DecodeTSVector decodes a TSVector from its storage encoding.
+func DecodeTSVector(b []byte) (TSVector, error) {
+	b, n, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return nil, err
+	}
+	v := make(TSVector, n)
+	for i := range v {
+		if b, v[i].Word, err = decodeString(b); err != nil {
+			return nil, err
+		}
+		var numPositions uint64
+		if b, numPositions, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		if numPositions > 0 {
+			v[i].Positions = make([]Position, numPositions)
+		}
+		for j := range v[i].Positions {
+			var p uint64
+			if b, p, err = encoding.DecodeUvarintAscending(b); err != nil {
+				return nil, err
+			}
+			v[i].Positions[j] = Position{Pos: uint16(p >> 2), Weight: Weight(p & 3)}
+		}
+	}
+	if len(b) != 0 {
+		return nil, errTrailingBytes
+	}
+	return v, nil
+}
+
+// EncodeTSQuery appends the storage encoding of q to appendTo.
+func EncodeTSQuery(appendTo []byte, q TSQuery) []byte {
+	return q.Root.encode(appendTo)
+}
+
+func (n *QueryNode) encode(appendTo []byte) []byte {
+	if n == nil {
+		return appendTo
+	}
+	appendTo = append(appendTo, byte(n.Op))
+	switch n.Op {
+	case QueryLexeme:
+		appendTo = encodeString(appendTo, n.Lexeme)
+		prefix := byte(0)
+		if n.Prefix {
+			prefix = 1
+		}
+		return append(appendTo, prefix, byte(n.Weights))
+	case QueryNot:
+		return n.Left.encode(appendTo)
+	}
+	appendTo = n.Left.encode(appendTo)
+	return n.Right.encode(appendTo)
+}
+
+// DecodeTSQuery decodes a TSQuery from its storage encoding.
+func DecodeTSQuery(b []byte) (TSQuery, error) {
+	if len(b) == 0 {
+		return TSQuery{}, nil
+	}
+	b, root, err := decodeQueryNode(b)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	if len(b) != 0 {
+		return TSQuery{}, errTrailingBytes
+	}
+	return TSQuery{Root: root}, nil
+}
+
+func decodeQueryNode(b []byte) ([]byte, *QueryNode, error) {
+	if len(b) == 0 {
+		return nil, nil, errMissingBytes
+	}
+	n := &QueryNode{Op: QueryOp(b[0])}
+	b = b[1:]
+	var err error
+	switch n.Op {
+	case QueryLexeme:
+		if b, n.Lexeme, err = decodeString(b); err != nil {
+			return nil, nil, err
+		}
+		if len(b) < 2 {
+			return nil, nil, errMissingBytes
+		}
+		n.Prefix = b[0] == 1
+		n.Weights = WeightSet(b[1])
+		return b[2:], n, nil
+	case QueryNot:
+		b, n.Left, err = decodeQueryNode(b)
+		return b, n, err
+	case QueryAnd, QueryOr:
+		if b, n.Left, err = decodeQueryNode(b); err != nil {
+			return nil, nil, err
+		}
+		b, n.Right, err = decodeQueryNode(b)
+		return b, n, err
+	}
+	return nil, nil, pgerror.NewAssertionErrorf("unknown tsquery operator %d", n.Op)
+}
+
+var (
+	errMissingBytes  = pgerror.NewAssertionErrorf("insufficient bytes to decode text search value")
+	errTrailingBytes = pgerror.NewAssertionErrorf("trailing bytes after text search value")
+)
+
+func encodeString(appendTo []byte, s string) []byte {
+	appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(s)))
+	return append(appendTo, s...)
+}
+
+func decodeString(b []byte) ([]byte, string, error) {
+	b, n, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return nil, "", err
+	}
+	if uint64(len(b)) < n {
+		return nil, "", errMissingBytes
+	}
+	return b[n:], string(b[:n]), nil
+}
+
+// EncodeInvertedIndexKeys returns the inverted index keys of v: one key per
+// lexeme, consisting of inKey followed by the key encoding of the lexeme.
+func EncodeInvertedIndexKeys(inKey []byte, v TSVector) [][]byte {
+	outKeys := make([][]byte, len(v))
+	for i := range v {
+		key := make([]byte, len(inKey), len(inKey)+len(v[i].Word)+2)
+		copy(key, inKey)
+		outKeys[i] = encoding.EncodeStringAscending(key, v[i].Word)
+	}
+	return outKeys
+}
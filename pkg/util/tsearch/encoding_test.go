@@ -0,0 +1,105 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+)
+
+func TestEncodeDecodeTSVector(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`a`,
+		`'cat':3 'fat':1A,2,4 'mat':16383D`,
+		`'it''s' 'Ünïcode':2B`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := ParseTSVector(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enc := EncodeTSVector(nil, v)
+			decoded, err := DecodeTSVector(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, decoded) && !(len(v) == 0 && len(decoded) == 0) {
+				t.Fatalf("expected %s, got %s", v, decoded)
+			}
+			if _, err := DecodeTSVector(append(enc, 0)); err == nil {
+				t.Fatal("expected error decoding trailing bytes")
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeTSQuery(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`a`,
+		`!'fat':*AB & ( 'rat' | 'cat':C )`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			q, err := ParseTSQuery(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enc := EncodeTSQuery(nil, q)
+			decoded, err := DecodeTSQuery(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(q, decoded) {
+				t.Fatalf("expected %s, got %s", q, decoded)
+			}
+			if len(enc) > 0 {
+				if _, err := DecodeTSQuery(enc[:len(enc)-1]); err == nil {
+					t.Fatal("expected error decoding truncated bytes")
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeInvertedIndexKeys(t *testing.T) {
+	v, err := ParseTSVector(`'cat':3 'fat':2 'rat'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := []byte("prefix")
+	keys := EncodeInvertedIndexKeys(prefix, v)
+	if len(keys) != len(v) {
+		t.Fatalf("expected %d keys, got %d", len(v), len(keys))
+	}
+	for i, key := range keys {
+		if !bytes.HasPrefix(key, prefix) {
+			t.Fatalf("key %d does not start with the prefix: %q", i, key)
+		}
+		_, word, err := encoding.DecodeUnsafeStringAscending(key[len(prefix):], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if word != v[i].Word {
+			t.Fatalf("expected %s, got %s", v[i].Word, word)
+		}
+		if i > 0 && bytes.Compare(keys[i-1], key) >= 0 {
+			t.Fatalf("keys are not sorted: %q >= %q", keys[i-1], key)
+		}
+	}
+}
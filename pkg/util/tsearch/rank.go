@@ -0,0 +1,222 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import "math"
+
+// DefaultRankWeights are the weights of the D, C, B and A positions used by
+// Rank when none are specified.
+var DefaultRankWeights = [4]float64{0.1, 0.2, 0.4, 1.0}
+
+// Normalization options of Rank, which can be combined with a bitwise OR.
+const (
+	// RankNormLogLength divides the rank by 1 + the logarithm of the length
+	// of the document.
+	RankNormLogLength = 1 << iota
+	// RankNormLength divides the rank by the length of the document.
+	RankNormLength
+	// RankNormExtDist is only meaningful for the cover density ranking of
+	// Postgres, and is ignored.
+	RankNormExtDist
+	// RankNormUniq divides the rank by the number of unique words in the
+	// document.
+	RankNormUniq
+	// RankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// unique words in the document.
+	RankNormLogUniq
+	// RankNormRDivRPlus1 divides the rank by itself + 1.
+	RankNormRDivRPlus1
+)
+
+// maxEntryPos is the distance assumed by Rank between lexemes without
+// positions.
+const maxEntryPos = 1 << 14
+
+// noPositions stands in for the positions of a lexeme without positions.
+var noPositions = []Position{{}}
+
+// Rank computes how relevant the document v is to the query q, based on how
+// often the lexemes of the query occur in the document, how close together
+// they are, and the weights of their positions. weights are the weights of
+// the D, C, B and A positions, and method is a combination of the RankNorm
+// options.
+//
+// This is the ts_rank algorithm of Postgres: queries whose top-level operator
+// is & are ranked by the proximity of the lexemes of the query, and other
+// queries by their frequency.
+func Rank(weights [4]float64, v TSVector, q TSQuery, method int) float64 {
+	operands := q.uniqueOperands()
+	if len(operands) == 0 {
+		return 0
+	}
+	var res float64
+	if q.Root.Op == QueryAnd {
+		res = rankAnd(weights, v, operands)
+	} else {
+		res = rankOr(weights, v, operands)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+	if method&RankNormLogLength != 0 && len(v) > 0 {
+		res /= math.Log(float64(v.length()+1)) / math.Log(2.0)
+	}
+	if method&RankNormLength != 0 {
+		if l := v.length(); l > 0 {
+			res /= float64(l)
+		}
+	}
+	if method&RankNormUniq != 0 && len(v) > 0 {
+		res /= float64(len(v))
+	}
+	if method&RankNormLogUniq != 0 && len(v) > 0 {
+		res /= math.Log(float64(len(v)+1)) / math.Log(2.0)
+	}
+	if method&RankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return res
+}
+
+// length returns the number of words of the document v, counting lexemes
+// without positions once.
+func (v TSVector) length() int {
+	l := 0
+	for i := range v {
+		if n := len(v[i].Positions); n > 0 {
+			l += n
+		} else {
+			l++
+		}
+	}
+	return l
+}
+
+// uniqueOperands returns the distinct operands of q, including negated ones.
+func (q TSQuery) uniqueOperands() []*QueryNode {
+	type key struct {
+		lexeme string
+		prefix bool
+	}
+	seen := make(map[key]struct{})
+	var operands []*QueryNode
+	var walk func(n *QueryNode)
+	walk = func(n *QueryNode) {
+		if n == nil {
+			return
+		}
+		if n.Op == QueryLexeme {
+			k := key{lexeme: n.Lexeme, prefix: n.Prefix}
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				operands = append(operands, n)
+			}
+			return
+		}
+		walk(n.Left)
+		walk(n.Right)
+	}
+	walk(q.Root)
+	return operands
+}
+
+// positionsOrDefault returns the positions of l, or a single position of
+// weight D if it has none.
+func positionsOrDefault(l *Lexeme) []Position {
+	if len(l.Positions) == 0 {
+		return noPositions
+	}
+	return l.Positions
+}
+
+// rankOr ranks v by the frequency of the operands in it. The contribution of
+// each occurrence of a lexeme decreases with the square of its rank among the
+// occurrences, so that it converges.
+func rankOr(weights [4]float64, v TSVector, operands []*QueryNode) float64 {
+	var res float64
+	for _, operand := range operands {
+		operand.forEachMatchingLexeme(v, func(l *Lexeme) bool {
+			var sum float64
+			maxWeight := -1.0
+			maxIdx := 0
+			for j, p := range positionsOrDefault(l) {
+				w := weights[p.Weight]
+				sum += w / float64((j+1)*(j+1))
+				if w > maxWeight {
+					maxWeight = w
+					maxIdx = j
+				}
+			}
+			// The limit of sum(1/i^2) is pi^2/6.
+			res += (maxWeight + sum - maxWeight/float64((maxIdx+1)*(maxIdx+1))) / 1.64493406685
+			return true
+		})
+	}
+	return res / float64(len(operands))
+}
+
+// rankAnd ranks v by the distances between the occurrences of different
+// operands in it.
+func rankAnd(weights [4]float64, v TSVector, operands []*QueryNode) float64 {
+	if len(operands) < 2 {
+		return rankOr(weights, v, operands)
+	}
+	res := -1.0
+	// positions and hasPositions record the last lexeme matched by each
+	// operand.
+	positions := make([][]Position, len(operands))
+	hasPositions := make([]bool, len(operands))
+	for i, operand := range operands {
+		operand.forEachMatchingLexeme(v, func(l *Lexeme) bool {
+			positions[i] = positionsOrDefault(l)
+			hasPositions[i] = len(l.Positions) > 0
+			for k := 0; k < i; k++ {
+				if positions[k] == nil {
+					continue
+				}
+				for _, p := range positions[i] {
+					for _, o := range positions[k] {
+						dist := int(p.Pos) - int(o.Pos)
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 {
+							if hasPositions[i] && hasPositions[k] {
+								continue
+							}
+							dist = maxEntryPos
+						}
+						w := math.Sqrt(weights[p.Weight] * weights[o.Weight] * wordDistance(dist))
+						if res < 0 {
+							res = w
+						} else {
+							res = 1.0 - (1.0-res)*(1.0-w)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+	return res
+}
+
+// wordDistance returns the weight of a pair of lexemes at the given distance.
+func wordDistance(dist int) float64 {
+	if dist > 100 {
+		return 1e-30
+	}
+	return 1.0 / (1.005 + 0.05*math.Exp(float64(dist)/1.5-2))
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	testCases := []struct {
+		vector   string
+		query    string
+		method   int
+		expected float64
+	}{
+		{`'a':1 'b':2 'c':3`, ``, 0, 0},
+		{`'a':1 'b':2 'c':3`, `a`, 0, 0.0607927},
+		{`'a':1A 'b':2 'c':3`, `a`, 0, 0.6079271},
+		{`'a':1 'b':2 'c':3`, `a | b`, 0, 0.0607927},
+		{`'a':1 'b':2 'c':3`, `a & b`, 0, 0.0991032},
+		{`'a':1 'b':2 'c':3`, `a & c`, 0, 0.0985009},
+		{`'a':1 'b':2 'c':3`, `a & b`, RankNormRDivRPlus1, 0.0901673},
+		{`'a':1 'b':2 'c':3`, `a & b`, RankNormUniq, 0.0330344},
+		{`'a':1 'b':2`, `x & y`, 0, 1e-20},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.vector+"/"+tc.query, func(t *testing.T) {
+			v, err := ParseTSVector(tc.vector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q, err := ParseTSQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res := Rank(DefaultRankWeights, v, q, tc.method); math.Abs(res-tc.expected) > 1e-6 {
+				t.Fatalf("expected %g, got %g", tc.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"bytes"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// QueryOp is the kind of a QueryNode.
+type QueryOp uint8
+
+const (
+	// QueryLexeme is an operand, which matches a lexeme.
+	QueryLexeme QueryOp = iota
+	// QueryNot is the ! operator.
+	QueryNot
+	// QueryAnd is the & operator.
+	QueryAnd
+	// QueryOr is the | operator.
+	QueryOr
+)
+
+// priority returns the binding strength of the operator, as used when
+// formatting queries.
+func (op QueryOp) priority() int {
+	switch op {
+	case QueryOr:
+		return 1
+	case QueryAnd:
+		return 2
+	case QueryNot:
+		return 3
+	}
+	return 4
+}
+
+// WeightSet is a set of weights, with bit 1<<w set for each weight w in the
+// set. The empty set places no restriction on weights.
+type WeightSet uint8
+
+// Contains returns whether w is in the set.
+func (s WeightSet) Contains(w Weight) bool {
+	return s&(1<<w) != 0
+}
+
+// QueryNode is a node of the expression tree of a TSQuery.
+type QueryNode struct {
+	Op QueryOp
+	// Lexeme is the lexeme matched by a QueryLexeme node. If Prefix is set,
+	// the node matches all lexemes which start with Lexeme. If Weights is not
+	// empty, the lexeme only matches if it occurs with one of the weights.
+	Lexeme  string
+	Prefix  bool
+	Weights WeightSet
+	// Left is the operand of a QueryNot node, and the left operand of a
+	// QueryAnd or QueryOr node. Right is the right operand of the latter.
+	Left, Right *QueryNode
+}
+
+// TSQuery is a text search query: a boolean expression of lexemes to be
+// matched against a TSVector. The zero value is the empty query, which
+// matches nothing.
+//
+// TSQueries are immutable once constructed.
+type TSQuery struct {
+	Root *QueryNode
+}
+
+// Compare returns -1, 0 or 1 depending on whether q sorts before, equal to or
+// after other. The order is the order of the text formats of the queries.
+func (q TSQuery) Compare(other TSQuery) int {
+	return strings.Compare(q.String(), other.String())
+}
+
+// Size returns the approximate size of q in memory, in bytes.
+func (q TSQuery) Size() uintptr {
+	return unsafe.Sizeof(q) + q.Root.size()
+}
+
+func (n *QueryNode) size() uintptr {
+	if n == nil {
+		return 0
+	}
+	return unsafe.Sizeof(*n) + uintptr(len(n.Lexeme)) + n.Left.size() + n.Right.size()
+}
+
+// String implements the fmt.Stringer interface. The result uses the text
+// format of Postgres, e.g. 'fat' & ( 'rat' | 'cat':*A ).
+func (q TSQuery) String() string {
+	var buf bytes.Buffer
+	q.Root.format(&buf, 0 /* parentPriority */)
+	return buf.String()
+}
+
+func (n *QueryNode) format(buf *bytes.Buffer, parentPriority int) {
+	if n == nil {
+		return
+	}
+	if n.Op == QueryLexeme {
+		writeLexeme(buf, n.Lexeme)
+		if n.Prefix || n.Weights != 0 {
+			buf.WriteByte(':')
+			if n.Prefix {
+				buf.WriteByte('*')
+			}
+			for w := WeightA; ; w-- {
+				if n.Weights.Contains(w) {
+					buf.WriteString(w.String())
+				}
+				if w == WeightD {
+					break
+				}
+			}
+		}
+		return
+	}
+	priority := n.Op.priority()
+	parens := priority < parentPriority
+	if parens {
+		buf.WriteString("( ")
+	}
+	switch n.Op {
+	case QueryNot:
+		buf.WriteByte('!')
+		n.Left.format(buf, priority)
+	case QueryAnd, QueryOr:
+		n.Left.format(buf, priority)
+		if n.Op == QueryAnd {
+			buf.WriteString(" & ")
+		} else {
+			buf.WriteString(" | ")
+		}
+		n.Right.format(buf, priority)
+	}
+	if parens {
+		buf.WriteString(" )")
+	}
+}
+
+// ParseTSQuery parses the text format of a tsquery. Operands are lexemes,
+// which can be quoted with single quotes and followed by a colon and flags: *
+// for a prefix match, and weight letters to restrict the match to positions
+// with these weights. Operands are combined with the operators ! (not), &
+// (and) and | (or), in decreasing order of precedence, and parentheses. The
+// lexemes are not normalized.
+func ParseTSQuery(s string) (TSQuery, error) {
+	return parseTSQuery(s, func(word string) []string { return []string{word} })
+}
+
+// parseTSQuery parses the text format of a tsquery, and replaces the word of
+// each operand by the lexemes returned by normalize for it. An operand which
+// normalizes to several lexemes is replaced by their conjunction. An operand
+// which normalizes to none is removed from the query.
+func parseTSQuery(s string, normalize func(word string) []string) (TSQuery, error) {
+	p := queryParser{lexer: lexer{input: s}, normalize: normalize}
+	p.skipSpace()
+	if p.done() {
+		return TSQuery{}, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return TSQuery{}, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return TSQuery{}, p.syntaxError()
+	}
+	return TSQuery{Root: root}, nil
+}
+
+// queryParser is a recursive descent parser for the text format of tsqueries.
+// Operands which normalize to no lexemes are represented by nil nodes while
+// parsing; operators with nil operands are simplified accordingly.
+type queryParser struct {
+	lexer
+	normalize func(word string) []string
+}
+
+func (p *queryParser) syntaxError() error {
+	return pgerror.NewErrorf(pgerror.CodeSyntaxError, "syntax error in tsquery: %q", p.input)
+}
+
+// consume skips spaces and consumes the character c if it comes next.
+func (p *queryParser) consume(c byte) bool {
+	p.skipSpace()
+	if !p.done() && p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) parseOr() (*QueryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume('|') {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = makeBinaryNode(QueryOr, left, right)
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (*QueryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.consume('<') {
+			return nil, pgerror.Unimplemented("tsquery.phrase", "the <-> operator of tsquery is not supported")
+		}
+		if !p.consume('&') {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = makeBinaryNode(QueryAnd, left, right)
+	}
+}
+
+func (p *queryParser) parseNot() (*QueryNode, error) {
+	if p.consume('!') {
+		operand, err := p.parseNot()
+		if err != nil || operand == nil {
+			return nil, err
+		}
+		return &QueryNode{Op: QueryNot, Left: operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *queryParser) parseOperand() (*QueryNode, error) {
+	if p.consume('(') {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(')') {
+			return nil, p.syntaxError()
+		}
+		return n, nil
+	}
+	p.skipSpace()
+	if p.done() {
+		return nil, p.syntaxError()
+	}
+	word, ok := p.word(true /* inQuery */)
+	if !ok {
+		return nil, p.syntaxError()
+	}
+	var prefix bool
+	var weights WeightSet
+	if !p.done() && p.peek() == ':' {
+		p.pos++
+		for ; !p.done(); p.pos++ {
+			if p.peek() == '*' {
+				prefix = true
+			} else if w, ok := parseWeight(p.peek()); ok {
+				weights |= 1 << w
+			} else {
+				break
+			}
+		}
+	}
+	var n *QueryNode
+	for _, lexeme := range p.normalize(word) {
+		operand := &QueryNode{Op: QueryLexeme, Lexeme: lexeme, Prefix: prefix, Weights: weights}
+		n = makeBinaryNode(QueryAnd, n, operand)
+	}
+	return n, nil
+}
+
+// makeBinaryNode returns a node applying op to left and right. If either of
+// them is nil, the other one is returned.
+func makeBinaryNode(op QueryOp, left, right *QueryNode) *QueryNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	return &QueryNode{Op: op, Left: left, Right: right}
+}
+
+// Match returns whether the document v matches the query q.
+func Match(v TSVector, q TSQuery) bool {
+	if q.Root == nil {
+		return false
+	}
+	return q.Root.match(v)
+}
+
+func (n *QueryNode) match(v TSVector) bool {
+	switch n.Op {
+	case QueryNot:
+		return !n.Left.match(v)
+	case QueryAnd:
+		return n.Left.match(v) && n.Right.match(v)
+	case QueryOr:
+		return n.Left.match(v) || n.Right.match(v)
+	}
+	matched := false
+	n.forEachMatchingLexeme(v, func(l *Lexeme) bool {
+		matched = n.matchesWeights(l)
+		return !matched
+	})
+	return matched
+}
+
+// forEachMatchingLexeme calls fn with the lexemes of v matched by the operand
+// n, ignoring weight restrictions, until fn returns false.
+func (n *QueryNode) forEachMatchingLexeme(v TSVector, fn func(l *Lexeme) bool) {
+	i, found := v.Find(n.Lexeme)
+	if !n.Prefix {
+		if found {
+			fn(&v[i])
+		}
+		return
+	}
+	for ; i < len(v) && strings.HasPrefix(v[i].Word, n.Lexeme); i++ {
+		if !fn(&v[i]) {
+			return
+		}
+	}
+}
+
+// matchesWeights returns whether the lexeme l occurs with one of the weights
+// the operand n is restricted to. Like in Postgres, a lexeme without positions
+// only matches operands without weight restrictions.
+func (n *QueryNode) matchesWeights(l *Lexeme) bool {
+	if n.Weights == 0 {
+		return true
+	}
+	for _, p := range l.Positions {
+		if n.Weights.Contains(p.Weight) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredLexemes returns lexemes which a TSVector must all contain in order
+// to match q. Prefix operands and operands below a ! or | operator are
+// ignored, so the result may be empty even for queries which cannot match
+// every document. The second return value is true if a document matches q if
+// and only if it contains the returned lexemes.
+func (q TSQuery) RequiredLexemes() ([]string, bool) {
+	var lexemes []string
+	exact := q.Root.requiredLexemes(&lexemes)
+	return lexemes, exact && q.Root != nil
+}
+
+func (n *QueryNode) requiredLexemes(lexemes *[]string) bool {
+	if n == nil {
+		return true
+	}
+	switch n.Op {
+	case QueryLexeme:
+		if n.Prefix || n.Weights != 0 {
+			return false
+		}
+		*lexemes = append(*lexemes, n.Lexeme)
+		return true
+	case QueryAnd:
+		left := n.Left.requiredLexemes(lexemes)
+		right := n.Right.requiredLexemes(lexemes)
+		return left && right
+	}
+	return false
+}
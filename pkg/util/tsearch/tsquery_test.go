@@ -0,0 +1,183 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+)
+
+func TestParseTSQuery(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{``, ``},
+		{`a`, `'a'`},
+		{`a & b | c`, `'a' & 'b' | 'c'`},
+		{`a | b & c`, `'a' | 'b' & 'c'`},
+		{`a & (b | c)`, `'a' & ( 'b' | 'c' )`},
+		{`a&b&c`, `'a' & 'b' & 'c'`},
+		{`!a & !(b|c)`, `!'a' & !( 'b' | 'c' )`},
+		{`!!a`, `!!'a'`},
+		{`a:*AB`, `'a':*AB`},
+		{`a:ba`, `'a':AB`},
+		{`'It''s' | 'a b'`, `'It''s' | 'a b'`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := q.String(); s != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, s)
+			}
+			// The text format must round-trip.
+			q2, err := ParseTSQuery(q.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(q, q2) {
+				t.Fatalf("%s did not round-trip: %s vs %s", tc.input, q, q2)
+			}
+		})
+	}
+}
+
+func TestParseTSQueryError(t *testing.T) {
+	for _, input := range []string{
+		`a &`,
+		`(a`,
+		`a)`,
+		`a b`,
+		`&`,
+		`!`,
+		`a | | b`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseTSQuery(input); !testutils.IsError(err, "syntax error in tsquery") {
+				t.Fatalf("expected syntax error, got %v", err)
+			}
+		})
+	}
+
+	if _, err := ParseTSQuery(`a <-> b`); !testutils.IsError(err, "the <-> operator of tsquery is not supported") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestToTSQuery(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{``, ``},
+		{`Fat:AB & Rats`, `'fat':AB & 'rats'`},
+		{`e-mail | foo`, `'e' & 'mail' | 'foo'`},
+		{`'--' & a`, `'a'`},
+		{`!'--'`, ``},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ToTSQuery(DefaultConfig, tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := q.String(); s != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, s)
+			}
+		})
+	}
+
+	q, err := PlainToTSQuery(DefaultConfig, `The Fat & rats!`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, expected := q.String(), `'the' & 'fat' & 'rats'`; s != expected {
+		t.Fatalf("expected %s, got %s", expected, s)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	v, err := ParseTSVector(`'cat':3 'fat':2A 'mat':7 'sat':4 'rat'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		query    string
+		expected bool
+	}{
+		{``, false},
+		{`cat`, true},
+		{`dog`, false},
+		{`cat & fat`, true},
+		{`cat & dog`, false},
+		{`cat | dog`, true},
+		{`!dog`, true},
+		{`!cat`, false},
+		{`ca:*`, true},
+		{`d:*`, false},
+		{`fat:A`, true},
+		{`fat:B`, false},
+		{`cat:AB`, false},
+		{`cat:D`, true},
+		{`f:*A`, true},
+		{`rat`, true},
+		{`rat:D`, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res := Match(v, q); res != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestRequiredLexemes(t *testing.T) {
+	testCases := []struct {
+		query    string
+		lexemes  []string
+		expected bool
+	}{
+		{``, nil, false},
+		{`a`, []string{"a"}, true},
+		{`a & b`, []string{"a", "b"}, true},
+		{`a & b:*`, []string{"a"}, false},
+		{`a & b:A`, []string{"a"}, false},
+		{`a & !b`, []string{"a"}, false},
+		{`a | b`, nil, false},
+		{`c & (a | b)`, []string{"c"}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lexemes, exact := q.RequiredLexemes()
+			if !reflect.DeepEqual(lexemes, tc.lexemes) || exact != tc.expected {
+				t.Fatalf("expected %v, %t, got %v, %t", tc.lexemes, tc.expected, lexemes, exact)
+			}
+		})
+	}
+}
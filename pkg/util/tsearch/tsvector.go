@@ -0,0 +1,387 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package tsearch implements the tsvector and tsquery types used for full
+// text search, the text search configurations which produce them from
+// documents, and the ranking of search results.
+package tsearch
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Weight is the weight of a lexeme position in a TSVector. The zero value is
+// the default weight, D.
+type Weight uint8
+
+// The weights of lexeme positions, from least to most important. The values
+// match the ones used by Postgres.
+const (
+	WeightD Weight = iota
+	WeightC
+	WeightB
+	WeightA
+)
+
+// weightLetters are the letters used to format weights, indexed by Weight.
+const weightLetters = "DCBA"
+
+// String implements the fmt.Stringer interface.
+func (w Weight) String() string {
+	return weightLetters[w : w+1]
+}
+
+// parseWeight returns the weight denoted by the letter c, which may be in
+// either case.
+func parseWeight(c byte) (Weight, bool) {
+	switch c {
+	case 'A', 'a':
+		return WeightA, true
+	case 'B', 'b':
+		return WeightB, true
+	case 'C', 'c':
+		return WeightC, true
+	case 'D', 'd':
+		return WeightD, true
+	}
+	return 0, false
+}
+
+const (
+	// MaxPosition is the largest position which can be stored in a TSVector.
+	// Larger positions are clamped to it, like in Postgres.
+	MaxPosition = 1<<14 - 1
+	// maxPositionsPerLexeme is the maximum number of positions stored for a
+	// single lexeme. Further positions are dropped.
+	maxPositionsPerLexeme = 256
+	// maxLexemeLength is the maximum length of a lexeme, in bytes.
+	maxLexemeLength = 2047
+)
+
+// Position is the position of a lexeme in a document, along with its weight.
+type Position struct {
+	Pos    uint16
+	Weight Weight
+}
+
+// Lexeme is a normalized word of a document, along with the positions it
+// occurs at. A lexeme without positions is valid; it only records that the
+// word occurs in the document.
+type Lexeme struct {
+	Word      string
+	Positions []Position
+}
+
+// TSVector is a document prepared for full text search: a list of distinct
+// lexemes sorted in byte order, each with its sorted positions.
+//
+// TSVectors are immutable once constructed.
+type TSVector []Lexeme
+
+// MakeTSVector returns the TSVector containing the given lexemes. Lexemes
+// which occur multiple times are merged, and positions are sorted and
+// deduplicated, keeping the highest weight of each position.
+func MakeTSVector(lexemes []Lexeme) TSVector {
+	sort.SliceStable(lexemes, func(i, j int) bool {
+		return lexemes[i].Word < lexemes[j].Word
+	})
+	v := make(TSVector, 0, len(lexemes))
+	for _, l := range lexemes {
+		if n := len(v); n > 0 && v[n-1].Word == l.Word {
+			v[n-1].Positions = append(v[n-1].Positions, l.Positions...)
+			continue
+		}
+		v = append(v, Lexeme{Word: l.Word, Positions: append([]Position(nil), l.Positions...)})
+	}
+	for i := range v {
+		v[i].Positions = normalizePositions(v[i].Positions)
+	}
+	return v
+}
+
+// normalizePositions sorts and deduplicates positions in place.
+func normalizePositions(positions []Position) []Position {
+	if len(positions) == 0 {
+		return nil
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return positions[i].Pos < positions[j].Pos
+	})
+	res := positions[:1]
+	for _, p := range positions[1:] {
+		last := &res[len(res)-1]
+		if p.Pos != last.Pos {
+			res = append(res, p)
+		} else if p.Weight > last.Weight {
+			last.Weight = p.Weight
+		}
+	}
+	if len(res) > maxPositionsPerLexeme {
+		res = res[:maxPositionsPerLexeme]
+	}
+	return res
+}
+
+// Find returns the index of the lexeme word in v, and whether it was found.
+// If it was not found, the index is the one it would be inserted at.
+func (v TSVector) Find(word string) (int, bool) {
+	i := sort.Search(len(v), func(i int) bool { return v[i].Word >= word })
+	return i, i < len(v) && v[i].Word == word
+}
+
+// Compare returns -1, 0 or 1 depending on whether v sorts before, equal to or
+// after other. Lexemes are compared in order, first by word and then by their
+// positions.
+func (v TSVector) Compare(other TSVector) int {
+	for i := 0; i < len(v) && i < len(other); i++ {
+		if c := strings.Compare(v[i].Word, other[i].Word); c != 0 {
+			return c
+		}
+		if c := comparePositions(v[i].Positions, other[i].Positions); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(v), len(other))
+}
+
+func comparePositions(a, b []Position) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareInts(int(a[i].Pos), int(b[i].Pos)); c != 0 {
+			return c
+		}
+		if c := compareInts(int(a[i].Weight), int(b[i].Weight)); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Size returns the approximate size of v in memory, in bytes.
+func (v TSVector) Size() uintptr {
+	sz := uintptr(len(v)) * unsafe.Sizeof(Lexeme{})
+	for _, l := range v {
+		sz += uintptr(len(l.Word)) + uintptr(len(l.Positions))*unsafe.Sizeof(Position{})
+	}
+	return sz
+}
+
+// String implements the fmt.Stringer interface. The result uses the text
+// format of Postgres, e.g. 'cat':3 'fat':2A,4.
+func (v TSVector) String() string {
+	var buf bytes.Buffer
+	for i, l := range v {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		writeLexeme(&buf, l.Word)
+		for j, p := range l.Positions {
+			if j == 0 {
+				buf.WriteByte(':')
+			} else {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Itoa(int(p.Pos)))
+			if p.Weight != WeightD {
+				buf.WriteString(p.Weight.String())
+			}
+		}
+	}
+	return buf.String()
+}
+
+// writeLexeme writes word to buf as a quoted lexeme.
+func writeLexeme(buf *bytes.Buffer, word string) {
+	buf.WriteByte('\'')
+	for i := 0; i < len(word); i++ {
+		switch c := word[i]; c {
+		case '\'':
+			buf.WriteString("''")
+		case '\\':
+			buf.WriteString(`\\`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('\'')
+}
+
+// ParseTSVector parses the text format of a tsvector. Lexemes are separated by
+// whitespace and can be quoted with single quotes. Each lexeme can be followed
+// by a colon and a comma-separated list of positions, each optionally followed
+// by a weight letter. The lexemes are not normalized.
+func ParseTSVector(s string) (TSVector, error) {
+	l := lexer{input: s}
+	var lexemes []Lexeme
+	for {
+		l.skipSpace()
+		if l.done() {
+			break
+		}
+		word, ok := l.word(false /* inQuery */)
+		if !ok {
+			return nil, makeTSVectorSyntaxError(s)
+		}
+		lexeme := Lexeme{Word: word}
+		if !l.done() && l.peek() == ':' {
+			l.pos++
+			for {
+				p, ok := l.position()
+				if !ok {
+					return nil, makeTSVectorSyntaxError(s)
+				}
+				lexeme.Positions = append(lexeme.Positions, p)
+				if l.done() || l.peek() != ',' {
+					break
+				}
+				l.pos++
+			}
+		}
+		if !l.done() && !l.atSpace() {
+			return nil, makeTSVectorSyntaxError(s)
+		}
+		lexemes = append(lexemes, lexeme)
+	}
+	return MakeTSVector(lexemes), nil
+}
+
+func makeTSVectorSyntaxError(s string) error {
+	return pgerror.NewErrorf(pgerror.CodeSyntaxError, "syntax error in tsvector: %q", s)
+}
+
+// lexer splits the text format of tsvectors and tsqueries into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) done() bool {
+	return l.pos >= len(l.input)
+}
+
+func (l *lexer) peek() byte {
+	return l.input[l.pos]
+}
+
+func (l *lexer) atSpace() bool {
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+	return unicode.IsSpace(r)
+}
+
+func (l *lexer) skipSpace() {
+	for !l.done() && l.atSpace() {
+		_, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		l.pos += size
+	}
+}
+
+// isQueryOperator returns whether c is a character with a special meaning in
+// the text format of tsqueries.
+func isQueryOperator(c byte) bool {
+	switch c {
+	case '&', '|', '!', '(', ')', '<':
+		return true
+	}
+	return false
+}
+
+// word consumes a lexeme, which is either quoted with single quotes or ends
+// at the next space or colon. Inside a query, an unquoted lexeme also ends at
+// the next operator. A backslash escapes the next character. It returns false
+// if the lexeme is empty or malformed.
+func (l *lexer) word(inQuery bool) (string, bool) {
+	var buf bytes.Buffer
+	if l.peek() == '\'' {
+		l.pos++
+		for {
+			if l.done() {
+				return "", false
+			}
+			c := l.peek()
+			l.pos++
+			switch {
+			case c == '\\':
+				if l.done() {
+					return "", false
+				}
+				buf.WriteByte(l.peek())
+				l.pos++
+			case c == '\'' && !l.done() && l.peek() == '\'':
+				buf.WriteByte('\'')
+				l.pos++
+			case c == '\'':
+				return buf.String(), buf.Len() > 0 && buf.Len() <= maxLexemeLength
+			default:
+				buf.WriteByte(c)
+			}
+		}
+	}
+	for !l.done() && !l.atSpace() {
+		c := l.peek()
+		if c == ':' || c == '\'' || (inQuery && isQueryOperator(c)) {
+			break
+		}
+		l.pos++
+		if c == '\\' {
+			if l.done() {
+				return "", false
+			}
+			c = l.peek()
+			l.pos++
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String(), buf.Len() > 0 && buf.Len() <= maxLexemeLength
+}
+
+// position consumes a lexeme position, which is a positive number optionally
+// followed by a weight letter.
+func (l *lexer) position() (Position, bool) {
+	start := l.pos
+	for !l.done() && l.peek() >= '0' && l.peek() <= '9' {
+		l.pos++
+	}
+	n, err := strconv.Atoi(l.input[start:l.pos])
+	if err != nil || n == 0 {
+		return Position{}, false
+	}
+	if n > MaxPosition {
+		n = MaxPosition
+	}
+	p := Position{Pos: uint16(n)}
+	if !l.done() {
+		if w, ok := parseWeight(l.peek()); ok {
+			p.Weight = w
+			l.pos++
+		}
+	}
+	return p, true
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tsearch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+)
+
+func TestParseTSVector(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{``, ``},
+		{`a`, `'a'`},
+		{`  b a  `, `'a' 'b'`},
+		{`a a b`, `'a' 'b'`},
+		{`fat:2 cat:3 fat:1A,4`, `'cat':3 'fat':1A,2,4`},
+		{`a:3,1,3B,2c`, `'a':1,2C,3B`},
+		{`a:99999`, `'a':16383`},
+		{`'quoted word':1`, `'quoted word':1`},
+		{`'it''s' 'a\'b' back\\slash`, `'a''b' 'back\\slash' 'it''s'`},
+		{`Ünïcode`, `'Ünïcode'`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseTSVector(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := v.String(); s != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, s)
+			}
+			// The text format must round-trip.
+			v2, err := ParseTSVector(v.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, v2) {
+				t.Fatalf("%s did not round-trip: %v vs %v", tc.input, v, v2)
+			}
+		})
+	}
+}
+
+func TestParseTSVectorError(t *testing.T) {
+	for _, input := range []string{
+		`''`,
+		`'unterminated`,
+		`a:`,
+		`a:0`,
+		`a:1,`,
+		`a:1x`,
+		`a'b`,
+		`trailing\`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseTSVector(input); !testutils.IsError(err, "syntax error in tsvector") {
+				t.Fatalf("expected syntax error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTSVectorCompare(t *testing.T) {
+	ordered := []string{
+		``,
+		`a`,
+		`a:1`,
+		`a:1 b`,
+		`a:1,2`,
+		`a:1A`,
+		`a:2`,
+		`b`,
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, err := ParseTSVector(ordered[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseTSVector(ordered[j])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c, expected := a.Compare(b), compareInts(i, j); c != expected {
+				t.Errorf("expected %q vs %q to be %d, got %d", ordered[i], ordered[j], expected, c)
+			}
+		}
+	}
+}
+
+func TestToTSVector(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{``, ``},
+		{`The fat cat sat on the mat.`, `'cat':3 'fat':2 'mat':7 'on':5 'sat':4 'the':1,6`},
+		{`e-mail: foo@bar.com, 42x`, `'42x':6 'bar':4 'com':5 'e':1 'foo':3 'mail':2`},
+		{`ÉCOLE école`, `'école':1,2`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ToTSVector(DefaultConfig, tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := v.String(); s != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, s)
+			}
+		})
+	}
+
+	if _, err := ToTSVector("english", "foo"); !testutils.IsError(err,
+		`text search configuration "english" does not exist`) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
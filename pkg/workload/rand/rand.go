@@ -316,7 +316,7 @@ func datumToGoSQL(d tree.Datum) (interface{}, error) {
 		return string(*d), nil
 	case *tree.DBytes:
 		return string(*d), nil
-	case *tree.DDate, *tree.DTime:
+	case *tree.DDate, *tree.DTime, *tree.DTimeTZ:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	case *tree.DTimestamp:
 		return d.Time, nil